@@ -0,0 +1,84 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// bloccverify verifies the bundles exported with peer blocc export-bundle
+// without contacting the network.
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/internal/pkg/blocc/bundle"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+var (
+	app        = kingpin.New("bloccverify", "Verify a BLOCC evidence bundle offline")
+	bundlePath = app.Arg("bundle", "The bundle exported with peer blocc export-bundle.").Required().ExistingFile()
+	tipHash    = app.Flag("tipHash", "The hex encoded hash of the tip block header of the bundle, obtained independently from a peer of the channel.").String()
+)
+
+func main() {
+	kingpin.Version("0.0.1")
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	valid, err := verify(*bundlePath, *tipHash, os.Stdout)
+	if err != nil {
+		fmt.Printf("Bundle Verify Error: %s\n", err)
+		os.Exit(1)
+	}
+	if !valid {
+		os.Exit(2)
+	}
+}
+
+func verify(path, tipHashHex string, w io.Writer) (bool, error) {
+	trusted, err := hex.DecodeString(tipHashHex)
+	if err != nil {
+		return false, errors.Wrap(err, "invalid tip hash")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer f.Close()
+
+	b, err := bundle.Read(f)
+	if err != nil {
+		return false, err
+	}
+
+	manifest := b.Manifest
+	report := bundle.Verify(b, trusted)
+	fmt.Fprintf(w, "Bundle of %d readings of sensor %s on channel %s, exported at %s by %s of %s\n",
+		len(manifest.Readings), manifest.SensorID, manifest.ChannelID, manifest.CreatedAt, report.Signer, report.MSPID)
+	if manifest.Tip != nil {
+		fmt.Fprintf(w, "Tip block %d with hash %x\n", manifest.Tip.Number, protoutil.BlockHeaderHash(manifest.Tip))
+	}
+	for _, check := range report.Checks {
+		if check.Err != nil {
+			fmt.Fprintf(w, "  [FAIL] %s: %s\n", check.Description, check.Err)
+			continue
+		}
+		fmt.Fprintf(w, "  [OK]   %s\n", check.Description)
+	}
+
+	if !report.Valid() {
+		fmt.Fprintln(w, "Verdict: INVALID")
+		return false, nil
+	}
+	if len(trusted) == 0 {
+		fmt.Fprintln(w, "Verdict: VALID, provided that the tip hash matches the channel")
+		return true, nil
+	}
+	fmt.Fprintln(w, "Verdict: VALID")
+	return true, nil
+}
@@ -1,35 +1,314 @@
 package event
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+// Type - Kind of information carried by an Event
+type Type int
+
+const (
+	// ApprovalRequest - A sensory transaction should be approved by this peer
+	ApprovalRequest Type = iota
+	// HeightLag - The peer's ledger lags behind the orderer beyond the configured threshold
+	HeightLag
+	// ForkStatusChanged - A channel was detected as forked, or no longer as forked
+	ForkStatusChanged
+	// ApprovalCommitted - An approval of a sensory transaction was committed as valid
+	ApprovalCommitted
+	// SensorSilent - A sensor has not submitted readings within the configured threshold
+	SensorSilent
+	// RejectionCommitted - A rejection of a sensory transaction was committed as valid
+	RejectionCommitted
+	// ApprovalInvalidated - An approval transaction was committed as invalid
+	ApprovalInvalidated
+	// ServiceReady - The BLOCC service ran its preflight checks and starts approving readings
+	ServiceReady
+	// ForkAcknowledged - An operator acknowledged the fork of a channel
+	ForkAcknowledged
+	// MissingApproval - A registered approver did not approve a reading within the configured deadline
+	MissingApproval
+	// SensorUnreliable - The rejection rate or the anomaly frequency of a sensor exceeds the configured threshold
+	SensorUnreliable
+	// ApprovalDigestReceived - A peer gossiped the digest of the approvals it submitted recently
+	ApprovalDigestReceived
+	// ApprovalLost - An approval a peer reported submitted in its approval digest is missing from the chain
+	ApprovalLost
+	// ApprovalsSuspended - The approvals of a channel are suspended, the approval identity having lost its write access to the channel
+	ApprovalsSuspended
+	// ApprovalsResumed - The approvals of a channel are resumed, the write access of the approval identity having been restored
+	ApprovalsResumed
 )
 
+var typeNames = map[Type]string{
+	ApprovalRequest:        "ApprovalRequest",
+	HeightLag:              "HeightLag",
+	ForkStatusChanged:      "ForkStatusChanged",
+	ApprovalCommitted:      "ApprovalCommitted",
+	SensorSilent:           "SensorSilent",
+	RejectionCommitted:     "RejectionCommitted",
+	ApprovalInvalidated:    "ApprovalInvalidated",
+	ServiceReady:           "ServiceReady",
+	ForkAcknowledged:       "ForkAcknowledged",
+	MissingApproval:        "MissingApproval",
+	SensorUnreliable:       "SensorUnreliable",
+	ApprovalDigestReceived: "ApprovalDigestReceived",
+	ApprovalLost:           "ApprovalLost",
+	ApprovalsSuspended:     "ApprovalsSuspended",
+	ApprovalsResumed:       "ApprovalsResumed",
+}
+
+func (t Type) String() string {
+	if name, ok := typeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
 // Event - BSCC Information to send a transaction successfully to the orderer
 type Event struct {
+	Type        Type
 	ChannelID   string
 	SensoryTxID string
+
+	// PeerHeight and OrdererHeight are only set for HeightLag events
+	PeerHeight    uint64
+	OrdererHeight uint64
+
+	// Forked is only set for ForkStatusChanged events
+	Forked bool
+
+	// MSPID is only set for ApprovalCommitted, RejectionCommitted,
+	// ApprovalInvalidated, ForkAcknowledged, MissingApproval,
+	// SensorUnreliable, ApprovalDigestReceived and ApprovalLost events
+	MSPID string
+
+	// Note is only set for ForkAcknowledged events, holding the note of the
+	// operator
+	Note string
+
+	// ValidationCode is only set for ApprovalInvalidated events, holding the
+	// name of the validation code of the transaction
+	ValidationCode string
+
+	// SensorID is only set for SensorSilent and SensorUnreliable events, and
+	// LastSeen for SensorSilent events
+	SensorID string
+	LastSeen time.Time
+
+	// RejectionRate and AnomalyFrequency are only set for SensorUnreliable
+	// events, holding the rolling statistics of the sensor
+	RejectionRate    float64
+	AnomalyFrequency float64
+
+	// PreflightFailures is only set for ServiceReady events, holding the
+	// preflight checks that failed
+	PreflightFailures []string
+
+	// Digest is only set for ApprovalDigestReceived events, holding the
+	// approval digest gossiped by a peer of the MSPID organization
+	Digest []byte
+
+	// Reason is only set for ApprovalsSuspended events, telling why the
+	// approval identity may not write to the channel
+	Reason string
+
+	// TraceID is set for ApprovalRequest events, and for the ApprovalCommitted,
+	// RejectionCommitted, ApprovalInvalidated and MissingApproval events of
+	// the approvals they led to, so that the logs of the peers and orderers handling a reading
+	// can be joined
+	TraceID string
+}
+
+// NewTraceID - Generate a random trace ID for an ApprovalRequest event
+func NewTraceID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate trace ID: %s", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// Guarantee - Delivery semantics declared by a subscriber
+type Guarantee int
+
+const (
+	// AtMostOnce - Events are handed over once and lost if the subscriber fails to process them
+	AtMostOnce Guarantee = iota
+	// AtLeastOnce - Events are redelivered until the subscriber acknowledges them
+	AtLeastOnce
+)
+
+// subscriberBufferSize - Number of events buffered for a subscriber before it
+// is considered slow
+const subscriberBufferSize = 64
+
+// subscriberOverflowSize - Number of events queued for a subscriber whose
+// buffer is full, further events being dropped until it catches up
+const subscriberOverflowSize = 1024
+
+// subscriber - A named subscriber of the bus
+type subscriber struct {
+	name string
+	ch   chan Event
+	// overflow queues the events published while the buffer is full, which
+	// forward hands over in order once the subscriber catches up
+	overflow []Event
+	// notify wakes forward up when events are queued
+	notify chan struct{}
+	// done stops forward when the subscriber leaves, and exited is closed
+	// once forward has returned and closed ch
+	done   chan struct{}
+	exited chan struct{}
+	mu     sync.Mutex
+	// fullSince is when the buffer of the subscriber was found full, zero
+	// while the subscriber keeps up
+	fullSince time.Time
+	reported  bool
+	dropped   int
+}
+
+// publish hands the event over, or queues it if the buffer is full, returning
+// false if the buffer is full.
+func (sub *subscriber) publish(e Event) bool {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	// events are handed over directly only if none is queued before them
+	if len(sub.overflow) == 0 {
+		select {
+		case sub.ch <- e:
+			return true
+		default:
+		}
+	}
+
+	if len(sub.overflow) < subscriberOverflowSize {
+		sub.overflow = append(sub.overflow, e)
+	} else {
+		sub.dropped++
+	}
+	select {
+	case sub.notify <- struct{}{}:
+	default:
+	}
+	return false
 }
 
+func (sub *subscriber) droppedEvents() int {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	return sub.dropped
+}
+
+// forward hands the queued events over once the subscriber catches up, and
+// closes the channel of the subscriber once it leaves.
+func (sub *subscriber) forward() {
+	defer close(sub.exited)
+	defer close(sub.ch)
+
+	for {
+		sub.mu.Lock()
+		queued := len(sub.overflow) > 0
+		var next Event
+		if queued {
+			next = sub.overflow[0]
+		}
+		sub.mu.Unlock()
+
+		if !queued {
+			select {
+			case <-sub.notify:
+				continue
+			case <-sub.done:
+				return
+			}
+		}
+
+		select {
+		case sub.ch <- next:
+		case <-sub.done:
+			return
+		}
+
+		// the event stays queued until handed over, so that publish does
+		// not hand later events over before it
+		sub.mu.Lock()
+		sub.overflow[0] = Event{}
+		sub.overflow = sub.overflow[1:]
+		sub.mu.Unlock()
+	}
+}
+
+// SlowConsumer - A subscriber whose buffer stayed full beyond the slow
+// consumer threshold
+type SlowConsumer struct {
+	Name string
+	// FullFor is how long the buffer of the subscriber has been full
+	FullFor time.Duration
+	// Dropped is the number of events dropped so far, published while both
+	// the buffer and the overflow queue of the subscriber were full
+	Dropped int
+	// Disconnected tells whether the subscriber was disconnected, in which
+	// case its channel is closed once its buffered events are received
+	Disconnected bool
+}
+
+// Bus - Event bus of a peer. Every peer owns its bus, so that peers running in
+// the same process do not receive the events of each other.
 type Bus struct {
-	subscribers []chan Event
+	subscribers []*subscriber
+	reliable    []*Subscription
 	mu          sync.Mutex
+	// clock times the redeliveries of the reliable subscriptions and how long
+	// subscribers stay full
+	clock clock.Clock
+
+	slowThreshold  time.Duration
+	disconnectSlow bool
+	reportSlow     func(SlowConsumer)
 }
 
 func NewEventBus() *Bus {
+	return NewEventBusWithClock(clock.NewClock())
+}
+
+// NewEventBusWithClock returns a bus timing redeliveries with clk, so that
+// tests can drive redeliveries deterministically.
+func NewEventBusWithClock(clk clock.Clock) *Bus {
 	return &Bus{
-		subscribers: []chan Event{},
+		subscribers: []*subscriber{},
 		mu:          sync.Mutex{},
+		clock:       clk,
 	}
 }
 
-// Subscribe - Subscribe to the event bus to receive events
-func (bus *Bus) Subscribe() <-chan Event {
+// Subscribe - Subscribe to the event bus to receive events, name identifying
+// the subscriber when it is reported slow. Events published while the buffer
+// of the subscriber is full are queued, up to subscriberOverflowSize events,
+// and dropped beyond. The channel is closed once the subscriber unsubscribes
+// or is disconnected.
+func (bus *Bus) Subscribe(name string) <-chan Event {
 	bus.mu.Lock()
 	defer bus.mu.Unlock()
 
-	ch := make(chan Event)
-	bus.subscribers = append(bus.subscribers, ch)
-	return ch
+	sub := &subscriber{
+		name:   name,
+		ch:     make(chan Event, subscriberBufferSize),
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+	bus.subscribers = append(bus.subscribers, sub)
+	go sub.forward()
+	return sub.ch
 }
 
 // Unsubscribe - Unsubscribe from the event bus
@@ -37,26 +316,109 @@ func (bus *Bus) Unsubscribe(ch <-chan Event) {
 	bus.mu.Lock()
 	defer bus.mu.Unlock()
 
-	for i, subscriber := range bus.subscribers {
-		if subscriber == ch {
-			// Delete without preserving order
-			bus.subscribers[i] = bus.subscribers[len(bus.subscribers)-1]
-			bus.subscribers = bus.subscribers[:len(bus.subscribers)-1]
+	for i, sub := range bus.subscribers {
+		if sub.ch == ch {
+			bus.remove(i)
 			break
 		}
 	}
 }
 
+// remove removes the i-th subscriber, dropping its queued events and closing
+// its channel. The mutex must be held.
+func (bus *Bus) remove(i int) {
+	sub := bus.subscribers[i]
+	close(sub.done)
+	<-sub.exited
+	// Delete without preserving order
+	bus.subscribers[i] = bus.subscribers[len(bus.subscribers)-1]
+	bus.subscribers = bus.subscribers[:len(bus.subscribers)-1]
+}
+
+// DetectSlowConsumers - Report the subscribers whose buffer stays full for
+// longer than threshold, disconnecting them if disconnect is set so that
+// they do not pile up the events published. A zero threshold disables the
+// detection. Slow subscribers are detected when events are published, and
+// report is called with the bus locked.
+func (bus *Bus) DetectSlowConsumers(threshold time.Duration, disconnect bool, report func(SlowConsumer)) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	bus.slowThreshold = threshold
+	bus.disconnectSlow = disconnect
+	bus.reportSlow = report
+}
+
+// SubscribeWith - Subscribe to the event bus with the given delivery guarantee.
+// At-least-once subscribers must acknowledge every delivery, which is
+// redelivered if it is not acknowledged within redeliveryTimeout.
+func (bus *Bus) SubscribeWith(guarantee Guarantee, redeliveryTimeout time.Duration) *Subscription {
+	if guarantee == AtMostOnce {
+		sub := newSubscription(bus, 0)
+		sub.events = bus.Subscribe("at-most-once-subscription")
+		go sub.forward()
+		return sub
+	}
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	sub := newSubscription(bus, redeliveryTimeout)
+	bus.reliable = append(bus.reliable, sub)
+	go sub.dispatch()
+	return sub
+}
+
 // Publish - Publish an event to all subscribers
 func (bus *Bus) Publish(event Event) {
 	bus.mu.Lock()
 	defer bus.mu.Unlock()
 
-	for _, ch := range bus.subscribers {
-		go func(ch chan Event) {
-			ch <- event
-		}(ch)
+	now := bus.clock.Now()
+	for i := 0; i < len(bus.subscribers); {
+		sub := bus.subscribers[i]
+		if sub.publish(event) {
+			sub.fullSince, sub.reported = time.Time{}, false
+			i++
+			continue
+		}
+
+		if sub.fullSince.IsZero() {
+			sub.fullSince = now
+		}
+		if fullFor := now.Sub(sub.fullSince); bus.slowThreshold > 0 && fullFor >= bus.slowThreshold {
+			if bus.disconnectSlow {
+				bus.remove(i)
+				bus.report(SlowConsumer{Name: sub.name, FullFor: fullFor, Dropped: sub.droppedEvents(), Disconnected: true})
+				continue
+			}
+			if !sub.reported {
+				sub.reported = true
+				bus.report(SlowConsumer{Name: sub.name, FullFor: fullFor, Dropped: sub.droppedEvents()})
+			}
+		}
+		i++
+	}
+
+	for _, sub := range bus.reliable {
+		sub.append(event)
 	}
 }
 
-var GlobalEventBus = NewEventBus()
+func (bus *Bus) report(slow SlowConsumer) {
+	if bus.reportSlow != nil {
+		bus.reportSlow(slow)
+	}
+}
+
+func (bus *Bus) unsubscribeReliable(sub *Subscription) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	for i, subscriber := range bus.reliable {
+		if subscriber == sub {
+			bus.reliable = append(bus.reliable[:i], bus.reliable[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package event
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlowConsumers(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bus := NewEventBusWithClock(clock)
+	var reports []SlowConsumer
+	bus.DetectSlowConsumers(time.Minute, false, func(slow SlowConsumer) { reports = append(reports, slow) })
+
+	slow := bus.Subscribe("slow")
+	fast := bus.Subscribe("fast")
+	defer bus.Unsubscribe(slow)
+	defer bus.Unsubscribe(fast)
+
+	publish := func(n int) {
+		for i := 0; i < n; i++ {
+			bus.Publish(Event{Type: ApprovalRequest})
+			<-fast
+		}
+	}
+
+	// the buffer of the slow subscriber fills up without blocking publishers
+	publish(subscriberBufferSize + 1)
+	require.Empty(t, reports)
+
+	clock.Increment(time.Minute)
+	publish(2)
+	require.Equal(t, []SlowConsumer{{Name: "slow", FullFor: time.Minute}}, reports)
+
+	// once caught up the subscriber receives the events piled up, and is
+	// reported again only if it falls behind again
+	for i := 0; i < subscriberBufferSize+3; i++ {
+		<-slow
+	}
+	publish(1)
+	<-slow
+	require.Len(t, reports, 1)
+}
+
+func TestDisconnectSlowConsumers(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bus := NewEventBusWithClock(clock)
+	var reports []SlowConsumer
+	bus.DetectSlowConsumers(time.Minute, true, func(slow SlowConsumer) { reports = append(reports, slow) })
+
+	slow := bus.Subscribe("slow")
+	for i := 0; i <= subscriberBufferSize; i++ {
+		bus.Publish(Event{Type: ApprovalRequest})
+	}
+	clock.Increment(2 * time.Minute)
+	bus.Publish(Event{Type: ApprovalRequest})
+	require.Equal(t, []SlowConsumer{{Name: "slow", FullFor: 2 * time.Minute, Disconnected: true}}, reports)
+	require.Empty(t, bus.subscribers)
+
+	// only the buffered events are received after the disconnection, the
+	// channel being closed then
+	for i := 0; i < subscriberBufferSize; i++ {
+		_, ok := <-slow
+		require.True(t, ok)
+	}
+	_, ok := <-slow
+	require.False(t, ok)
+	bus.Unsubscribe(slow)
+}
+
+func TestOverflowingSubscriber(t *testing.T) {
+	bus := NewEventBus()
+	var reports []SlowConsumer
+	bus.DetectSlowConsumers(time.Nanosecond, false, func(slow SlowConsumer) { reports = append(reports, slow) })
+
+	slow := bus.Subscribe("slow")
+	published := subscriberBufferSize + subscriberOverflowSize + 3
+	for i := 0; i < published; i++ {
+		bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: strconv.Itoa(i)})
+	}
+	bus.Publish(Event{Type: ApprovalRequest})
+	require.Len(t, reports, 1)
+	require.Equal(t, "slow", reports[0].Name)
+	require.Equal(t, 4, bus.subscribers[0].droppedEvents())
+
+	// the queued events are received in publication order, those
+	// overflowing the queue being dropped
+	for i := 0; i < subscriberBufferSize+subscriberOverflowSize; i++ {
+		e := <-slow
+		require.Equal(t, strconv.Itoa(i), e.SensoryTxID)
+	}
+	select {
+	case e := <-slow:
+		t.Fatalf("unexpected event %s", e.SensoryTxID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// the channel is closed on unsubscription
+	bus.Unsubscribe(slow)
+	_, ok := <-slow
+	require.False(t, ok)
+	require.Empty(t, bus.subscribers)
+}
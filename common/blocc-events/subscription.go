@@ -0,0 +1,261 @@
+package event
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+)
+
+// Delivery - An event handed to a subscriber, to be acknowledged once processed
+type Delivery struct {
+	Event
+	// Seq orders the deliveries of a subscription
+	Seq uint64
+	// Attempt counts the deliveries of the event, starting at 1
+	Attempt int
+
+	sub *Subscription
+}
+
+// Ack - Acknowledge the delivery so that the event is not redelivered
+func (d Delivery) Ack() {
+	if d.sub != nil {
+		d.sub.ack(d.Seq)
+	}
+}
+
+type logEntry struct {
+	delivery    Delivery
+	deliveredAt time.Time
+}
+
+// Subscription - Subscription to the event bus with a declared delivery guarantee.
+// At-least-once subscriptions keep published events in an in-memory log until they are
+// acknowledged and redeliver those left unacknowledged beyond the redelivery
+// timeout, in publication order. The log is not persisted: its events are
+// lost when the process exits, unless they are saved with PendingEvents and
+// restored with Restore. It is unbounded unless SetMaxPending bounds it.
+type Subscription struct {
+	bus *Bus
+
+	// events is set for at-most-once subscriptions
+	events <-chan Event
+
+	deliveries        chan Delivery
+	redeliveryTimeout time.Duration
+	log               []*logEntry
+	maxPending        int
+	dropped           func(Event)
+	seq               uint64
+	notify            chan struct{}
+	done              chan struct{}
+	closeOnce         sync.Once
+	mu                sync.Mutex
+}
+
+func newSubscription(bus *Bus, redeliveryTimeout time.Duration) *Subscription {
+	return &Subscription{
+		bus:               bus,
+		deliveries:        make(chan Delivery),
+		redeliveryTimeout: redeliveryTimeout,
+		notify:            make(chan struct{}, 1),
+		done:              make(chan struct{}),
+	}
+}
+
+// Deliveries - Channel of the events delivered to the subscriber
+func (sub *Subscription) Deliveries() <-chan Delivery {
+	return sub.deliveries
+}
+
+// forward hands the events of an at-most-once subscription over as deliveries
+// that need no acknowledgment.
+func (sub *Subscription) forward() {
+	for {
+		select {
+		case e, ok := <-sub.events:
+			if !ok {
+				return
+			}
+			select {
+			case sub.deliveries <- Delivery{Event: e, Attempt: 1}:
+			case <-sub.done:
+				return
+			}
+		case <-sub.done:
+			return
+		}
+	}
+}
+
+// Pending - Number of events published to the subscription and not yet acknowledged
+func (sub *Subscription) Pending() int {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	return len(sub.log)
+}
+
+// PendingEvents - Events published to the subscription and not yet acknowledged, in publication order
+func (sub *Subscription) PendingEvents() []Event {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	events := make([]Event, 0, len(sub.log))
+	for _, entry := range sub.log {
+		events = append(events, entry.delivery.Event)
+	}
+	return events
+}
+
+// SetMaxPending - Bound the log of an at-least-once subscription to max
+// unacknowledged events, zero leaving it unbounded. Once the log is full, the
+// oldest event is dropped from it for every event published, and handed to
+// dropped if it is not nil. dropped is called with the log locked.
+func (sub *Subscription) SetMaxPending(max int, dropped func(Event)) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	sub.maxPending = max
+	sub.dropped = dropped
+	if max > 0 {
+		sub.trim(max)
+	}
+}
+
+// Restore - Queue events for delivery to this subscription only, e.g. the
+// pending events of a previous subscription saved before a restart
+func (sub *Subscription) Restore(events ...Event) {
+	for _, e := range events {
+		sub.append(e)
+	}
+}
+
+// Close - Unsubscribe from the event bus, dropping the unacknowledged events
+func (sub *Subscription) Close() {
+	if sub.events != nil {
+		sub.bus.Unsubscribe(sub.events)
+	} else {
+		sub.bus.unsubscribeReliable(sub)
+	}
+	sub.closeOnce.Do(func() { close(sub.done) })
+}
+
+func (sub *Subscription) append(e Event) {
+	sub.mu.Lock()
+	sub.seq++
+	if sub.maxPending > 0 {
+		sub.trim(sub.maxPending - 1)
+	}
+	sub.log = append(sub.log, &logEntry{delivery: Delivery{Event: e, Seq: sub.seq, sub: sub}})
+	sub.mu.Unlock()
+
+	sub.wake()
+}
+
+// trim drops the oldest events of the log until it holds at most max events.
+// The mutex must be held.
+func (sub *Subscription) trim(max int) {
+	for len(sub.log) > max {
+		entry := sub.log[0]
+		sub.log[0] = nil
+		sub.log = sub.log[1:]
+		if sub.dropped != nil {
+			sub.dropped(entry.delivery.Event)
+		}
+	}
+}
+
+func (sub *Subscription) ack(seq uint64) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	for i, entry := range sub.log {
+		if entry.delivery.Seq == seq {
+			sub.log = append(sub.log[:i], sub.log[i+1:]...)
+			return
+		}
+	}
+}
+
+func (sub *Subscription) wake() {
+	select {
+	case sub.notify <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the first event of the log that is due for delivery, or the
+// time to wait until an event becomes due for redelivery.
+func (sub *Subscription) next(now time.Time) (*Delivery, time.Duration) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	wait := time.Duration(-1)
+	for _, entry := range sub.log {
+		remaining := entry.deliveredAt.Add(sub.redeliveryTimeout).Sub(now)
+		if entry.delivery.Attempt == 0 || remaining <= 0 {
+			delivery := entry.delivery
+			delivery.Attempt++
+			return &delivery, 0
+		}
+		if wait < 0 || remaining < wait {
+			wait = remaining
+		}
+	}
+
+	return nil, wait
+}
+
+// delivered starts the redelivery timeout of the event once it has been
+// handed to the subscriber.
+func (sub *Subscription) delivered(delivery *Delivery, now time.Time) {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	for _, entry := range sub.log {
+		if entry.delivery.Seq == delivery.Seq {
+			entry.delivery.Attempt = delivery.Attempt
+			entry.deliveredAt = now
+			return
+		}
+	}
+}
+
+func (sub *Subscription) dispatch() {
+	for {
+		delivery, wait := sub.next(sub.bus.clock.Now())
+		if delivery != nil {
+			select {
+			case sub.deliveries <- *delivery:
+				sub.delivered(delivery, sub.bus.clock.Now())
+			case <-sub.done:
+				return
+			}
+			continue
+		}
+
+		var timeout <-chan time.Time
+		var timer clock.Timer
+		if wait >= 0 {
+			timer = sub.bus.clock.NewTimer(wait)
+			timeout = timer.C()
+		}
+
+		select {
+		case <-sub.notify:
+		case <-timeout:
+		case <-sub.done:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+
+		select {
+		case <-sub.done:
+			return
+		default:
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, sub *Subscription) Delivery {
+	select {
+	case d := <-sub.Deliveries():
+		return d
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event delivered")
+		return Delivery{}
+	}
+}
+
+func TestAtLeastOnceSubscription(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.SubscribeWith(AtLeastOnce, 100*time.Millisecond)
+	defer sub.Close()
+
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx1"})
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx2"})
+	require.Equal(t, 2, sub.Pending())
+
+	d := receive(t, sub)
+	require.Equal(t, "tx1", d.SensoryTxID)
+	require.Equal(t, 1, d.Attempt)
+	d.Ack()
+
+	d = receive(t, sub)
+	require.Equal(t, "tx2", d.SensoryTxID)
+	require.Equal(t, 1, d.Attempt)
+	require.Equal(t, 1, sub.Pending())
+
+	// redelivered once the timeout expires without acknowledgment
+	d = receive(t, sub)
+	require.Equal(t, "tx2", d.SensoryTxID)
+	require.Equal(t, 2, d.Attempt)
+	d.Ack()
+	require.Equal(t, 0, sub.Pending())
+
+	select {
+	case d := <-sub.Deliveries():
+		t.Fatalf("unexpected delivery of %s", d.SensoryTxID)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestRedeliveryTimeout(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bus := NewEventBusWithClock(clock)
+	sub := bus.SubscribeWith(AtLeastOnce, time.Minute)
+	defer sub.Close()
+
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx1"})
+	d := receive(t, sub)
+	require.Equal(t, 1, d.Attempt)
+
+	clock.WaitForWatcherAndIncrement(59 * time.Second)
+	select {
+	case d := <-sub.Deliveries():
+		t.Fatalf("unexpected redelivery of %s before the timeout", d.SensoryTxID)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	clock.WaitForWatcherAndIncrement(time.Second)
+	d = receive(t, sub)
+	require.Equal(t, "tx1", d.SensoryTxID)
+	require.Equal(t, 2, d.Attempt)
+	d.Ack()
+	require.Equal(t, 0, sub.Pending())
+}
+
+func TestAtMostOnceSubscription(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.SubscribeWith(AtMostOnce, time.Millisecond)
+	defer sub.Close()
+
+	bus.Publish(Event{Type: HeightLag, ChannelID: "mychannel"})
+
+	d := receive(t, sub)
+	require.Equal(t, HeightLag, d.Type)
+	require.Equal(t, 1, d.Attempt)
+	d.Ack()
+	require.Equal(t, 0, sub.Pending())
+
+	select {
+	case d := <-sub.Deliveries():
+		t.Fatalf("unexpected redelivery of %s", d.Type)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestCloseSubscription(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.SubscribeWith(AtLeastOnce, time.Minute)
+	sub.Close()
+
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx1"})
+	require.Equal(t, 0, sub.Pending())
+	require.Empty(t, bus.reliable)
+}
+
+func TestRestorePendingEvents(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.SubscribeWith(AtLeastOnce, time.Minute)
+
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx1"})
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx2"})
+	receive(t, sub).Ack()
+	sub.Close()
+
+	pending := sub.PendingEvents()
+	require.Equal(t, []Event{{Type: ApprovalRequest, SensoryTxID: "tx2"}}, pending)
+
+	restored := bus.SubscribeWith(AtLeastOnce, time.Minute)
+	defer restored.Close()
+	restored.Restore(pending...)
+
+	d := receive(t, restored)
+	require.Equal(t, "tx2", d.SensoryTxID)
+	require.Equal(t, 1, d.Attempt)
+}
+
+func TestMaxPendingEvents(t *testing.T) {
+	bus := NewEventBus()
+	sub := bus.SubscribeWith(AtLeastOnce, time.Minute)
+	defer sub.Close()
+
+	var dropped []string
+	sub.SetMaxPending(2, func(e Event) { dropped = append(dropped, e.SensoryTxID) })
+
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx1"})
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx2"})
+	bus.Publish(Event{Type: ApprovalRequest, SensoryTxID: "tx3"})
+	require.Equal(t, []string{"tx1"}, dropped)
+	require.Equal(t, []Event{
+		{Type: ApprovalRequest, SensoryTxID: "tx2"},
+		{Type: ApprovalRequest, SensoryTxID: "tx3"},
+	}, sub.PendingEvents())
+
+	// lowering the bound drops the oldest events
+	sub.SetMaxPending(1, func(e Event) { dropped = append(dropped, e.SensoryTxID) })
+	require.Equal(t, []string{"tx1", "tx2"}, dropped)
+	require.Equal(t, 1, sub.Pending())
+
+	// zero leaves the log unbounded
+	sub.SetMaxPending(0, nil)
+	for i := 0; i < 10; i++ {
+		bus.Publish(Event{Type: ApprovalRequest})
+	}
+	require.Equal(t, 11, sub.Pending())
+}
@@ -0,0 +1,122 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package merkle implements the binary Merkle trees used by BLOCC to let
+// light clients verify the inclusion of a record without replaying the
+// ledger. Leaves and interior nodes are hashed with SHA-256 under distinct
+// prefixes, following RFC 6962, so that a leaf cannot be passed off as a node.
+package merkle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// ProofStep is a sibling hash on the path from a leaf to the root.
+type ProofStep struct {
+	// Hash is the hash of the sibling subtree
+	Hash []byte `json:"hash"`
+	// Left is set if the sibling is the left child of their parent
+	Left bool `json:"left"`
+}
+
+// KeyValueLeaf returns the leaf data of a state entry. The key is length
+// prefixed so that distinct entries cannot encode to the same leaf.
+func KeyValueLeaf(key string, value []byte) []byte {
+	leaf := make([]byte, 0, binary.MaxVarintLen64+len(key)+len(value))
+	leaf = binary.AppendUvarint(leaf, uint64(len(key)))
+	leaf = append(leaf, key...)
+	return append(leaf, value...)
+}
+
+// LeafHash returns the hash of a leaf holding data.
+func LeafHash(data []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// EmptyRoot is the root of a tree without leaves.
+func EmptyRoot() []byte {
+	root := sha256.Sum256(nil)
+	return root[:]
+}
+
+// Root returns the root of the tree whose leaves hold the given data, in
+// order. A node without a sibling is promoted to the next level unchanged.
+func Root(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return EmptyRoot()
+	}
+
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = LeafHash(leaf)
+	}
+
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+	return level[0]
+}
+
+// Proof returns the path from the leaf at index to the root of the tree.
+func Proof(leaves [][]byte, index int) []ProofStep {
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = LeafHash(leaf)
+	}
+
+	var proof []ProofStep
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, ProofStep{Hash: level[sibling], Left: sibling < index})
+		}
+		level = nextLevel(level)
+		index /= 2
+	}
+	return proof
+}
+
+// Verify returns whether the proof links the leaf holding data to root.
+func Verify(data []byte, proof []ProofStep, root []byte) bool {
+	hash := LeafHash(data)
+	for _, step := range proof {
+		if step.Left {
+			hash = nodeHash(step.Hash, hash)
+		} else {
+			hash = nodeHash(hash, step.Hash)
+		}
+	}
+	return bytes.Equal(hash, root)
+}
+
+func nextLevel(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 == len(level) {
+			next = append(next, level[i])
+			continue
+		}
+		next = append(next, nodeHash(level[i], level[i+1]))
+	}
+	return next
+}
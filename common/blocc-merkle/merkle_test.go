@@ -0,0 +1,48 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoot(t *testing.T) {
+	require.Equal(t, EmptyRoot(), Root(nil))
+	require.Equal(t, LeafHash([]byte("a")), Root([][]byte{[]byte("a")}))
+
+	ab := nodeHash(LeafHash([]byte("a")), LeafHash([]byte("b")))
+	require.Equal(t, ab, Root([][]byte{[]byte("a"), []byte("b")}))
+
+	// the odd leaf is promoted
+	require.Equal(t, nodeHash(ab, LeafHash([]byte("c"))), Root([][]byte{[]byte("a"), []byte("b"), []byte("c")}))
+}
+
+func TestProof(t *testing.T) {
+	for size := 1; size <= 9; size++ {
+		var leaves [][]byte
+		for i := 0; i < size; i++ {
+			leaves = append(leaves, []byte(fmt.Sprintf("leaf-%d", i)))
+		}
+		root := Root(leaves)
+
+		for i := range leaves {
+			proof := Proof(leaves, i)
+			require.True(t, Verify(leaves[i], proof, root), "leaf %d of %d", i, size)
+			require.False(t, Verify([]byte("forged"), proof, root), "leaf %d of %d", i, size)
+		}
+	}
+}
+
+func TestLeafIsNotNode(t *testing.T) {
+	a, b := LeafHash([]byte("a")), LeafHash([]byte("b"))
+	root := Root([][]byte{[]byte("a"), []byte("b")})
+
+	// the concatenation of the children does not verify as a leaf
+	require.False(t, Verify(append(a, b...), nil, root))
+}
@@ -20,6 +20,8 @@ import (
 const (
 	CHANNELREADERS = policies.ChannelApplicationReaders
 	CHANNELWRITERS = policies.ChannelApplicationWriters
+	// BLOCCAPPROVALS is the channel policy of the peers approving readings
+	BLOCCAPPROVALS = "/Channel/Application/BloccApprovals"
 )
 
 type defaultACLProvider interface {
@@ -101,6 +103,48 @@ func newDefaultACLProvider(policyChecker policy.PolicyChecker) defaultACLProvide
 
 	//--------------- BSCC resources -----------
 	d.pResourcePolicyMap[resources.Bscc_ApproveForThisPeer] = CHANNELREADERS
+	d.pResourcePolicyMap[resources.Bscc_RegisterSensor] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_RegisterSensors] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_IssueSensorToken] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_RevokeSensorToken] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_DecommissionSensor] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_ReloadConfig] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_DrainApprovals] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_ClearForkStatus] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_AcknowledgeFork] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_MigrateState] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_ArchiveMetricReadings] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_SetTransformation] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_SetFeatureFlag] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_GetFeatureFlags] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_GetRecentEvents] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_GetDiskUsage] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_GetApprovalAccess] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_FlushPendingApprovals] = policy.Admins
+	d.pResourcePolicyMap[resources.Bscc_GetMetadata] = policy.Members
+	d.pResourcePolicyMap[resources.Bscc_SetValidationPolicy] = policy.Admins
+
+	d.cResourcePolicyMap[resources.Bscc_ApproveSensoryReading] = BLOCCAPPROVALS
+	d.cResourcePolicyMap[resources.Bscc_QueryApprovals] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_QueryRejections] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetSensor] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_AuthenticateSensor] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Bscc_AttestSensorFirmware] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Bscc_GetDeliveryReceipt] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Bscc_ListSensors] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetReadingProof] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetReading] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetReadingProvenance] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetApprovalWatermark] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetRevalidations] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetSensorStats] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetSensorReliability] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetTransformation] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_GetValidationPolicy] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_QueryMetricReadings] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_RegisterApprover] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Bscc_GetApprovers] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Bscc_EvaluateReading] = CHANNELREADERS
 
 	//---------------- non-scc resources ------------
 	//Peer resources
@@ -55,7 +55,48 @@ const (
 	Cscc_GetChannels          = "cscc/GetChannels"
 
 	// Bscc resources
-	Bscc_ApproveForThisPeer = "bscc/ApproveForThisPeer"
+	Bscc_ApproveForThisPeer    = "bscc/ApproveForThisPeer"
+	Bscc_ApproveSensoryReading = "bscc/ApproveSensoryReading"
+	Bscc_QueryApprovals        = "bscc/QueryApprovals"
+	Bscc_QueryRejections       = "bscc/QueryRejections"
+	Bscc_RegisterSensor        = "bscc/RegisterSensor"
+	Bscc_GetSensor             = "bscc/GetSensor"
+	Bscc_IssueSensorToken      = "bscc/IssueSensorToken"
+	Bscc_RevokeSensorToken     = "bscc/RevokeSensorToken"
+	Bscc_AuthenticateSensor    = "bscc/AuthenticateSensor"
+	Bscc_GetDeliveryReceipt    = "bscc/GetDeliveryReceipt"
+	Bscc_ReloadConfig          = "bscc/ReloadConfig"
+	Bscc_ListSensors           = "bscc/ListSensors"
+	Bscc_GetReadingProof       = "bscc/GetReadingProof"
+	Bscc_GetReading            = "bscc/GetReading"
+	Bscc_DrainApprovals        = "bscc/DrainApprovals"
+	Bscc_GetSensorStats        = "bscc/GetSensorStats"
+	Bscc_SetTransformation     = "bscc/SetTransformation"
+	Bscc_GetTransformation     = "bscc/GetTransformation"
+	Bscc_SetFeatureFlag        = "bscc/SetFeatureFlag"
+	Bscc_GetFeatureFlags       = "bscc/GetFeatureFlags"
+	Bscc_GetRecentEvents       = "bscc/GetRecentEvents"
+	Bscc_GetDiskUsage          = "bscc/GetDiskUsage"
+	Bscc_SetValidationPolicy   = "bscc/SetValidationPolicy"
+	Bscc_GetValidationPolicy   = "bscc/GetValidationPolicy"
+	Bscc_QueryMetricReadings   = "bscc/QueryMetricReadings"
+	Bscc_ClearForkStatus       = "bscc/ClearForkStatus"
+	Bscc_AcknowledgeFork       = "bscc/AcknowledgeFork"
+	Bscc_MigrateState          = "bscc/MigrateState"
+	Bscc_DecommissionSensor    = "bscc/DecommissionSensor"
+	Bscc_RegisterApprover      = "bscc/RegisterApprover"
+	Bscc_GetApprovers          = "bscc/GetApprovers"
+	Bscc_EvaluateReading       = "bscc/EvaluateReading"
+	Bscc_ArchiveMetricReadings = "bscc/ArchiveMetricReadings"
+	Bscc_RegisterSensors       = "bscc/RegisterSensors"
+	Bscc_GetSensorReliability  = "bscc/GetSensorReliability"
+	Bscc_GetApprovalAccess     = "bscc/GetApprovalAccess"
+	Bscc_FlushPendingApprovals = "bscc/FlushPendingApprovals"
+	Bscc_GetMetadata           = "bscc/GetMetadata"
+	Bscc_GetApprovalWatermark  = "bscc/GetApprovalWatermark"
+	Bscc_GetRevalidations      = "bscc/GetRevalidations"
+	Bscc_AttestSensorFirmware  = "bscc/AttestSensorFirmware"
+	Bscc_GetReadingProvenance  = "bscc/GetReadingProvenance"
 
 	// Peer resources
 	Peer_Propose              = "peer/Propose"
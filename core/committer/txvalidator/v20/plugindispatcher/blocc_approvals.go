@@ -0,0 +1,244 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugindispatcher
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// ApprovalPolicyPath is the channel policy that the endorsements of the BSCC
+// transactions must satisfy, independently of the endorsement policy of the
+// sensor chaincodes. Every channel running BSCC must define it.
+const ApprovalPolicyPath = "/Channel/Application/BloccApprovals"
+
+// AnonymousApprovalPolicyPath is the channel policy that the endorsements of
+// anonymous BSCC transactions, created and endorsed by an idemix identity,
+// must satisfy. It is optional: without it anonymous transactions are
+// rejected. It should be a signature policy over ORGANIZATION_UNIT
+// principals, each binding an idemix MSP to the organizational unit its
+// credentials disclose, which is the MSP ID of the organization they approve
+// for.
+const AnonymousApprovalPolicyPath = "/Channel/Application/BloccAnonymousApprovals"
+
+// AdminFunctions are the BSCC functions restricted to the administrators of
+// the organization of the endorsing peer. They are the only ones which may
+// write BSCC keys not owned by the organization of their creator.
+var AdminFunctions = map[string]bool{
+	"RegisterSensor":        true,
+	"RegisterSensors":       true,
+	"IssueSensorToken":      true,
+	"RevokeSensorToken":     true,
+	"DecommissionSensor":    true,
+	"SetTransformation":     true,
+	"SetFeatureFlag":        true,
+	"SetValidationPolicy":   true,
+	"MigrateState":          true,
+	"ArchiveMetricReadings": true,
+}
+
+// validateApproval checks the endorsements and the write set of a BSCC
+// transaction against the approval policy of the channel.
+func (v *dispatcherImpl) validateApproval(payload *common.Payload) error {
+	return ValidateApproval(v.pluginValidator.Manager(v.chainID), payload)
+}
+
+// ValidateApproval checks a BSCC transaction against the approval policy of
+// the channel of manager. An approval or rejection is recorded for the
+// organization of its creator, so only the endorsements of that organization
+// are evaluated, and unless it invokes one of the AdminFunctions, the
+// transaction may only write the BSCC keys owned by that organization: the
+// composite keys whose last attribute is its MSP ID. BSCC transactions may
+// not write to the namespaces of other chaincodes.
+//
+// The endorsements of an anonymous transaction, whose creator is an idemix
+// identity, are evaluated against AnonymousApprovalPolicyPath instead, and
+// the transaction is recorded for the organizational unit of its creator.
+// Anonymous transactions may not invoke the AdminFunctions.
+func ValidateApproval(manager policies.Manager, payload *common.Payload) error {
+	if manager == nil {
+		return errors.New("no policy manager for the channel of the BSCC transaction")
+	}
+	policy, ok := manager.GetPolicy(ApprovalPolicyPath)
+	if !ok {
+		return errors.Errorf("channel does not define the approval policy %s", ApprovalPolicyPath)
+	}
+
+	shdr, err := protoutil.UnmarshalSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return err
+	}
+	creator, err := protoutil.UnmarshalSerializedIdentity(shdr.Creator)
+	if err != nil {
+		return errors.WithMessage(err, "invalid creator of approval transaction")
+	}
+
+	tx, err := protoutil.UnmarshalTransaction(payload.Data)
+	if err != nil {
+		return err
+	}
+	if len(tx.Actions) != 1 {
+		return errors.Errorf("approval transaction has %d actions, expected 1", len(tx.Actions))
+	}
+	cap, err := protoutil.UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return err
+	}
+
+	var signatureSet []*protoutil.SignedData
+	for _, endorsement := range cap.GetAction().GetEndorsements() {
+		endorser, err := protoutil.UnmarshalSerializedIdentity(endorsement.Endorser)
+		if err != nil {
+			return errors.WithMessage(err, "invalid endorser of approval transaction")
+		}
+		if endorser.Mspid != creator.Mspid {
+			continue
+		}
+		prespBytes := cap.Action.ProposalResponsePayload
+		data := make([]byte, len(prespBytes)+len(endorsement.Endorser))
+		copy(data, prespBytes)
+		copy(data[len(prespBytes):], endorsement.Endorser)
+		signatureSet = append(signatureSet, &protoutil.SignedData{
+			Data:      data,
+			Identity:  endorsement.Endorser,
+			Signature: endorsement.Signature,
+		})
+	}
+
+	if ou, ok := protoutil.IdemixOrganizationalUnit(creator.IdBytes); ok {
+		return validateAnonymousApproval(manager, cap, signatureSet, creator.Mspid, ou)
+	}
+	if err := policy.EvaluateSignedData(signatureSet); err != nil {
+		return errors.WithMessagef(err, "endorsements of %s do not satisfy approval policy %s", creator.Mspid, ApprovalPolicyPath)
+	}
+
+	return validateApprovalWrites(cap, creator.Mspid)
+}
+
+// validateAnonymousApproval checks the anonymous BSCC transaction of cap,
+// created by an identity of the idemix MSP mspID disclosing the
+// organizational unit ou, against the anonymous approval policy of the
+// channel of manager. Only the endorsements disclosing ou are evaluated, and
+// its writes are those of the organization ou.
+func validateAnonymousApproval(manager policies.Manager, cap *peer.ChaincodeActionPayload, signatureSet []*protoutil.SignedData, mspID, ou string) error {
+	policy, ok := manager.GetPolicy(AnonymousApprovalPolicyPath)
+	if !ok {
+		return errors.Errorf("channel does not define the anonymous approval policy %s", AnonymousApprovalPolicyPath)
+	}
+	fname, err := invokedFunction(cap)
+	if err != nil {
+		return err
+	}
+	if AdminFunctions[fname] {
+		return errors.Errorf("anonymous approval transaction of %s may not invoke %s", mspID, fname)
+	}
+	var ouSignatureSet []*protoutil.SignedData
+	for _, signedData := range signatureSet {
+		endorser, err := protoutil.UnmarshalSerializedIdentity(signedData.Identity)
+		if err != nil {
+			return errors.WithMessage(err, "invalid endorser of approval transaction")
+		}
+		if endorserOU, ok := protoutil.IdemixOrganizationalUnit(endorser.IdBytes); ok && endorserOU == ou {
+			ouSignatureSet = append(ouSignatureSet, signedData)
+		}
+	}
+	if err := policy.EvaluateSignedData(ouSignatureSet); err != nil {
+		return errors.WithMessagef(err, "endorsements of %s for %s do not satisfy anonymous approval policy %s", mspID, ou, AnonymousApprovalPolicyPath)
+	}
+
+	return validateApprovalWrites(cap, ou)
+}
+
+// validateApprovalWrites checks that the BSCC transaction of cap, created by
+// the organization mspID, only writes the keys it may write.
+func validateApprovalWrites(cap *peer.ChaincodeActionPayload, mspID string) error {
+	fname, err := invokedFunction(cap)
+	if err != nil {
+		return err
+	}
+
+	prp, err := protoutil.UnmarshalProposalResponsePayload(cap.Action.ProposalResponsePayload)
+	if err != nil {
+		return err
+	}
+	action, err := protoutil.UnmarshalChaincodeAction(prp.Extension)
+	if err != nil {
+		return err
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(action.Results); err != nil {
+		return errors.WithMessage(err, "invalid read write set of approval transaction")
+	}
+
+	for _, ns := range txRWSet.NsRwSets {
+		// BSCC has no chaincode definition, and so no collections
+		for _, c := range ns.CollHashedRwSets {
+			if c.HashedRwSet != nil && (len(c.HashedRwSet.HashedWrites) > 0 || len(c.HashedRwSet.MetadataWrites) > 0) {
+				return errors.Errorf("approval transaction writes to collection %s of namespace %s", c.CollectionName, ns.NameSpace)
+			}
+		}
+		if ns.KvRwSet == nil {
+			continue
+		}
+
+		var keys []string
+		for _, write := range ns.KvRwSet.Writes {
+			keys = append(keys, write.Key)
+		}
+		for _, write := range ns.KvRwSet.MetadataWrites {
+			keys = append(keys, write.Key)
+		}
+		if len(keys) > 0 && ns.NameSpace != "bscc" {
+			return errors.Errorf("approval transaction writes to namespace %s", ns.NameSpace)
+		}
+		if AdminFunctions[fname] {
+			continue
+		}
+		for _, key := range keys {
+			if !ownedKey(key, mspID) {
+				return errors.Errorf("%s transaction of %s writes key %q not owned by its organization", fname, mspID, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// invokedFunction returns the name of the BSCC function invoked by the
+// proposal of cap.
+func invokedFunction(cap *peer.ChaincodeActionPayload) (string, error) {
+	cpp, err := protoutil.UnmarshalChaincodeProposalPayload(cap.ChaincodeProposalPayload)
+	if err != nil {
+		return "", err
+	}
+	cis, err := protoutil.UnmarshalChaincodeInvocationSpec(cpp.Input)
+	if err != nil {
+		return "", err
+	}
+	args := cis.GetChaincodeSpec().GetInput().GetArgs()
+	if len(args) == 0 {
+		return "", errors.New("approval transaction invokes no function")
+	}
+	return string(args[0]), nil
+}
+
+// ownedKey returns whether key is a composite key owned by the organization
+// mspID, that is whose last attribute is mspID.
+func ownedKey(key, mspID string) bool {
+	// composite keys are the object type and attributes, each followed by a
+	// null character, prefixed by a null character
+	if len(key) < 2 || key[0] != 0 || key[len(key)-1] != 0 {
+		return false
+	}
+	components := strings.Split(key[1:len(key)-1], "\x00")
+	return len(components) > 1 && components[len(components)-1] == mspID
+}
@@ -0,0 +1,255 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package plugindispatcher
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/committer/txvalidator/v20/plugindispatcher/mocks"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type approvalPolicy struct {
+	signatureSet []*protoutil.SignedData
+	err          error
+}
+
+func (p *approvalPolicy) EvaluateSignedData(signatureSet []*protoutil.SignedData) error {
+	p.signatureSet = signatureSet
+	return p.err
+}
+
+func (p *approvalPolicy) EvaluateIdentities(identities []msp.Identity) error {
+	return p.err
+}
+
+func serializedIdentity(mspID string) []byte {
+	return protoutil.MarshalOrPanic(&mspproto.SerializedIdentity{Mspid: mspID, IdBytes: []byte(mspID)})
+}
+
+// bsccPayload returns the payload of a BSCC transaction of Org1MSP invoking
+// fname, if not empty, endorsed by Org2MSP and Org1MSP, and writing the keys
+// of writes by namespace.
+func bsccPayload(t *testing.T, fname string, writes map[string][]string) (*common.Payload, []byte) {
+	rwsb := rwsetutil.NewRWSetBuilder()
+	for ns, keys := range writes {
+		for _, key := range keys {
+			rwsb.AddToWriteSet(ns, key, []byte("value"))
+		}
+	}
+	simRes, err := rwsb.GetTxSimulationResults()
+	require.NoError(t, err)
+	results, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	prp := protoutil.MarshalOrPanic(&peer.ProposalResponsePayload{
+		Extension: protoutil.MarshalOrPanic(&peer.ChaincodeAction{Results: results}),
+	})
+	input := &peer.ChaincodeInput{}
+	if fname != "" {
+		input.Args = [][]byte{[]byte(fname)}
+	}
+	cis := &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: &peer.ChaincodeID{Name: "bscc"}, Input: input},
+	}
+	return &common.Payload{
+		Header: &common.Header{
+			SignatureHeader: protoutil.MarshalOrPanic(&common.SignatureHeader{Creator: serializedIdentity("Org1MSP")}),
+		},
+		Data: protoutil.MarshalOrPanic(&peer.Transaction{
+			Actions: []*peer.TransactionAction{{
+				Payload: protoutil.MarshalOrPanic(&peer.ChaincodeActionPayload{
+					ChaincodeProposalPayload: protoutil.MarshalOrPanic(&peer.ChaincodeProposalPayload{
+						Input: protoutil.MarshalOrPanic(cis),
+					}),
+					Action: &peer.ChaincodeEndorsedAction{
+						ProposalResponsePayload: prp,
+						Endorsements: []*peer.Endorsement{
+							{Endorser: serializedIdentity("Org2MSP"), Signature: []byte("sig2")},
+							{Endorser: serializedIdentity("Org1MSP"), Signature: []byte("sig1")},
+						},
+					},
+				}),
+			}},
+		}),
+	}, prp
+}
+
+func TestValidateApproval(t *testing.T) {
+	approvalKey, err := shim.CreateCompositeKey("approval", []string{"tx1", "Org1MSP"})
+	require.NoError(t, err)
+	payload, prp := bsccPayload(t, "ApproveSensoryReading", map[string][]string{"bscc": {approvalKey}})
+
+	policyManager := &mocks.PolicyManager{}
+	cpmg := &mocks.ChannelPolicyManagerGetter{}
+	cpmg.On("Manager", "mychannel").Return(policyManager)
+	v := New("mychannel", nil, nil, nil, NewPluginValidator(nil, nil, nil, nil, cpmg, nil))
+
+	// the approval policy is required
+	policyManager.On("GetPolicy", ApprovalPolicyPath).Return(nil, false).Once()
+	require.EqualError(t, v.validateApproval(payload), "channel does not define the approval policy /Channel/Application/BloccApprovals")
+
+	// only the endorsements of the approving organization are evaluated
+	policy := &approvalPolicy{}
+	policyManager.On("GetPolicy", ApprovalPolicyPath).Return(policy, true)
+	require.NoError(t, v.validateApproval(payload))
+	require.Equal(t, []*protoutil.SignedData{{
+		Data:      append(append([]byte{}, prp...), serializedIdentity("Org1MSP")...),
+		Identity:  serializedIdentity("Org1MSP"),
+		Signature: []byte("sig1"),
+	}}, policy.signatureSet)
+
+	policy.err = errors.New("signature set did not satisfy policy")
+	require.EqualError(t, v.validateApproval(payload), "endorsements of Org1MSP do not satisfy approval policy /Channel/Application/BloccApprovals: signature set did not satisfy policy")
+	policy.err = nil
+
+	payload.Data = protoutil.MarshalOrPanic(&peer.Transaction{})
+	require.EqualError(t, v.validateApproval(payload), "approval transaction has 0 actions, expected 1")
+}
+
+// idemixIdentity returns a serialized identity of the idemix MSP mspID
+// disclosing the organizational unit ou.
+func idemixIdentity(mspID, ou string) []byte {
+	return protoutil.MarshalOrPanic(&mspproto.SerializedIdentity{
+		Mspid: mspID,
+		IdBytes: protoutil.MarshalOrPanic(&mspproto.SerializedIdemixIdentity{
+			Ou:    protoutil.MarshalOrPanic(&mspproto.OrganizationUnit{MspIdentifier: mspID, OrganizationalUnitIdentifier: ou}),
+			Proof: []byte("proof"),
+		}),
+	})
+}
+
+func TestValidateAnonymousApproval(t *testing.T) {
+	approvalKey, err := shim.CreateCompositeKey("approval", []string{"tx1", "Org1MSP"})
+	require.NoError(t, err)
+	anonymousPayload := func(fname string) (*common.Payload, []byte) {
+		payload, prp := bsccPayload(t, fname, map[string][]string{"bscc": {approvalKey}})
+		payload.Header.SignatureHeader = protoutil.MarshalOrPanic(&common.SignatureHeader{Creator: idemixIdentity("IdemixMSP", "Org1MSP")})
+		tx, err := protoutil.UnmarshalTransaction(payload.Data)
+		require.NoError(t, err)
+		cap, err := protoutil.UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+		require.NoError(t, err)
+		cap.Action.Endorsements = []*peer.Endorsement{
+			{Endorser: idemixIdentity("IdemixMSP", "Org2MSP"), Signature: []byte("sig2")},
+			{Endorser: idemixIdentity("IdemixMSP", "Org1MSP"), Signature: []byte("sig1")},
+			{Endorser: serializedIdentity("Org1MSP"), Signature: []byte("peer-sig1")},
+		}
+		tx.Actions[0].Payload = protoutil.MarshalOrPanic(cap)
+		payload.Data = protoutil.MarshalOrPanic(tx)
+		return payload, prp
+	}
+	payload, prp := anonymousPayload("ApproveSensoryReading")
+
+	policyManager := &mocks.PolicyManager{}
+	approvalPolicy, anonymousPolicy := &approvalPolicy{}, &approvalPolicy{}
+	policyManager.On("GetPolicy", ApprovalPolicyPath).Return(approvalPolicy, true)
+
+	// the anonymous approval policy is required
+	policyManager.On("GetPolicy", AnonymousApprovalPolicyPath).Return(nil, false).Once()
+	require.EqualError(t, ValidateApproval(policyManager, payload), "channel does not define the anonymous approval policy /Channel/Application/BloccAnonymousApprovals")
+
+	// only the idemix endorsements disclosing the organization of the
+	// creator are evaluated, and the keys of that organization are written
+	policyManager.On("GetPolicy", AnonymousApprovalPolicyPath).Return(anonymousPolicy, true)
+	require.NoError(t, ValidateApproval(policyManager, payload))
+	require.Nil(t, approvalPolicy.signatureSet)
+	require.Equal(t, []*protoutil.SignedData{{
+		Data:      append(append([]byte{}, prp...), idemixIdentity("IdemixMSP", "Org1MSP")...),
+		Identity:  idemixIdentity("IdemixMSP", "Org1MSP"),
+		Signature: []byte("sig1"),
+	}}, anonymousPolicy.signatureSet)
+
+	anonymousPolicy.err = errors.New("signature set did not satisfy policy")
+	require.EqualError(t, ValidateApproval(policyManager, payload), "endorsements of IdemixMSP for Org1MSP do not satisfy anonymous approval policy /Channel/Application/BloccAnonymousApprovals: signature set did not satisfy policy")
+	anonymousPolicy.err = nil
+
+	// an anonymous creator may not write the keys of the idemix MSP
+	payload.Header.SignatureHeader = protoutil.MarshalOrPanic(&common.SignatureHeader{Creator: idemixIdentity("IdemixMSP", "Org2MSP")})
+	require.EqualError(t, ValidateApproval(policyManager, payload), "ApproveSensoryReading transaction of Org2MSP writes key \"\\x00approval\\x00tx1\\x00Org1MSP\\x00\" not owned by its organization")
+
+	// nor invoke the administration functions
+	payload, _ = anonymousPayload("SetFeatureFlag")
+	require.EqualError(t, ValidateApproval(policyManager, payload), "anonymous approval transaction of IdemixMSP may not invoke SetFeatureFlag")
+}
+
+func TestValidateApprovalWrites(t *testing.T) {
+	key := func(objectType string, attributes ...string) string {
+		key, err := shim.CreateCompositeKey(objectType, attributes)
+		require.NoError(t, err)
+		return key
+	}
+	policyManager := &mocks.PolicyManager{}
+	policyManager.On("GetPolicy", ApprovalPolicyPath).Return(&approvalPolicy{}, true)
+
+	tests := []struct {
+		name   string
+		fname  string
+		writes map[string][]string
+		err    string
+	}{
+		{
+			name:  "keys owned by the creator",
+			fname: "ApproveSensoryReading",
+			writes: map[string][]string{"bscc": {
+				key("approval", "tx1", "Org1MSP"),
+				key("sensorReliability", "sensor1", "Org1MSP"),
+			}},
+		},
+		{
+			name:   "approval of another organization",
+			fname:  "ApproveSensoryReading",
+			writes: map[string][]string{"bscc": {key("approval", "tx1", "Org2MSP")}},
+			err:    `ApproveSensoryReading transaction of Org1MSP writes key "\x00approval\x00tx1\x00Org2MSP\x00" not owned by its organization`,
+		},
+		{
+			name:   "key not owned by any organization",
+			fname:  "ApproveSensoryReading",
+			writes: map[string][]string{"bscc": {key("sensor", "sensor1")}},
+			err:    `ApproveSensoryReading transaction of Org1MSP writes key "\x00sensor\x00sensor1\x00" not owned by its organization`,
+		},
+		{
+			name:   "simple key",
+			fname:  "AuthenticateSensor",
+			writes: map[string][]string{"bscc": {"Org1MSP"}},
+			err:    `AuthenticateSensor transaction of Org1MSP writes key "Org1MSP" not owned by its organization`,
+		},
+		{
+			name:   "admin function",
+			fname:  "RegisterSensor",
+			writes: map[string][]string{"bscc": {key("sensor", "sensor1")}},
+		},
+		{
+			name:   "write to another namespace",
+			fname:  "RegisterSensor",
+			writes: map[string][]string{"bscc": {key("sensor", "sensor1")}, "sensor_chaincode": {"key"}},
+			err:    "approval transaction writes to namespace sensor_chaincode",
+		},
+		{
+			name:  "no function",
+			fname: "",
+			err:   "approval transaction invokes no function",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, _ := bsccPayload(t, tt.fname, tt.writes)
+			err := ValidateApproval(policyManager, payload)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
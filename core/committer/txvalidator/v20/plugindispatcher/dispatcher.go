@@ -194,11 +194,16 @@ func (v *dispatcherImpl) Dispatch(seq int, payload *common.Payload, envBytes []b
 	// this is mostly an "aesthetic" issue and brings with it many underlying
 	// problems (i.e. change configtxlator to handle new type of txs) or create
 	// an ad-hoc validation system whose only purpose is to validate bscc txs,
-	// we decided to go with this workaround. The workaround is to simply
-	// skip the validation of bscc txs. This is safe because bscc txs are
-	// signed by the peer and therefore, the peer is the only one that can
-	// create them.
-	if ccID != "bscc" {
+	// we decided to go with this workaround. The workaround is to skip the
+	// chaincode validation of bscc txs, which any channel member may create.
+	// They are validated against the approval policy of the channel instead,
+	// which also restricts the keys they may write.
+	if ccID == "bscc" {
+		if err := v.validateApproval(payload); err != nil {
+			logger.Errorf("Approval transaction %s failed validation: %+v", chdr.TxId, err)
+			return peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE, err
+		}
+	} else {
 		for ns := range wrNamespace {
 			// Get latest chaincode validation plugin name and policy
 			validationPlugin, args, err := v.GetInfoForValidate(chdr, ns)
@@ -0,0 +1,130 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/scc/bscc"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// consistencyWaitTimeout bounds the wait for the approval of a consistency
+// token to be committed.
+var consistencyWaitTimeout = 30 * time.Second
+
+//go:generate counterfeiter -o fake/commit_notifier.go --fake-name CommitNotifier . CommitNotifier
+
+// CommitNotifier notifies the commits of transactions.
+type CommitNotifier interface {
+	// NotifyStatus notifies the status of the transaction once it and the
+	// state updates of its block are committed, until done is closed.
+	NotifyStatus(done <-chan struct{}, channelName string, transactionID string) (<-chan *commit.Status, error)
+}
+
+// consistencyToken returns the consistency token decorating the proposal,
+// if it is a BSCC query. The tokens of other proposals are ignored.
+func consistencyToken(up *UnpackedProposal) string {
+	token := up.Input.GetDecorations()[protoutil.ConsistencyTokenDecoration]
+	if len(token) == 0 || up.ChaincodeName != "bscc" || len(up.Input.Args) == 0 {
+		return ""
+	}
+	if !bscc.IsQuery(string(up.Input.Args[0])) {
+		return ""
+	}
+	return string(token)
+}
+
+// awaitConsistency waits for the record written by the approval transaction
+// of the consistency token to be committed to the state of the channel. It
+// must be called before the simulator of the proposal is acquired, as the
+// simulator holds the commits of the channel back.
+func (e *Endorser) awaitConsistency(channelID, encodedToken string) error {
+	token, err := protoutil.DecodeConsistencyToken(encodedToken)
+	if err != nil {
+		return err
+	}
+	if e.CommitNotifier == nil {
+		return errors.New("consistency tokens are not supported by this peer")
+	}
+
+	// register for the commit first so that no commit is missed after the
+	// state is read
+	done := make(chan struct{})
+	defer close(done)
+	statusReceive, err := e.CommitNotifier.NotifyStatus(done, channelID, token.TxID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to check the consistency token")
+	}
+
+	recorded, err := e.approvalRecorded(channelID, token)
+	if err != nil || recorded {
+		return err
+	}
+	// the transaction may be in a block whose state is not committed yet,
+	// but it may not have been invalidated
+	if processedTx, err := e.Support.GetTransactionByID(channelID, token.TxID); err == nil {
+		if err := validApproval(token, pb.TxValidationCode(processedTx.ValidationCode)); err != nil {
+			return err
+		}
+	}
+
+	timeout := time.NewTimer(consistencyWaitTimeout)
+	defer timeout.Stop()
+	select {
+	case status, ok := <-statusReceive:
+		if !ok {
+			return errors.New("unexpected close of commit notification channel")
+		}
+		if err := validApproval(token, status.Code); err != nil {
+			return err
+		}
+	case <-timeout.C:
+		return errors.Errorf("timed out waiting for approval transaction %s of the consistency token to be committed", token.TxID)
+	}
+
+	recorded, err = e.approvalRecorded(channelID, token)
+	if err != nil {
+		return err
+	}
+	if !recorded {
+		return errors.Errorf("approval transaction %s of the consistency token recorded no decision of %s on reading %s", token.TxID, token.MSPID, token.SensoryTxID)
+	}
+	return nil
+}
+
+// validApproval returns an error if the approval transaction of token was
+// committed with the validation code code, other than VALID.
+func validApproval(token *protoutil.ConsistencyToken, code pb.TxValidationCode) error {
+	if code != pb.TxValidationCode_VALID {
+		return errors.Errorf("approval transaction %s of the consistency token was invalidated with code %s", token.TxID, code)
+	}
+	return nil
+}
+
+// approvalRecorded returns whether the approval or rejection record of the
+// token is in the state of the channel. It may have been overwritten by a
+// later transaction than the one of the token.
+func (e *Endorser) approvalRecorded(channelID string, token *protoutil.ConsistencyToken) (bool, error) {
+	key, err := bscc.ConsistencyRecordKey(token)
+	if err != nil {
+		return false, err
+	}
+
+	qe, err := e.Support.GetQueryExecutor(channelID)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to check the consistency token")
+	}
+	defer qe.Done()
+	recordBytes, err := qe.GetState("bscc", key)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to check the consistency token")
+	}
+	return recordBytes != nil, nil
+}
@@ -0,0 +1,50 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"encoding/json"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/ingestion"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// ReadingAdmitter admits the proposals of the sensor chaincodes within the
+// ingestion limits of the peer.
+type ReadingAdmitter interface {
+	// Admit admits the proposal of a reading of the severity on the
+	// channel, returning the function to call once it is endorsed, or the
+	// Shed if the peer is overloaded.
+	Admit(channelID, severity string) (release func(), shed *ingestion.Shed)
+}
+
+// admitReading admits the proposal of a sensor chaincode to the endorsement,
+// returning the function to call once it is endorsed, or the response to
+// return if the reading is shed as the peer is overloaded. The response has
+// status 429 and the ingestion.Shed as payload, telling the gateway when to
+// retry rather than letting it time out.
+func (e *Endorser) admitReading(up *UnpackedProposal) (release func(), shedResponse *pb.ProposalResponse) {
+	if e.ReadingAdmitter == nil || up.ChannelID() == "" || !sensorcc.Default.MatchName(up.ChaincodeName) {
+		return func() {}, nil
+	}
+
+	release, shed := e.ReadingAdmitter.Admit(up.ChannelID(), protoutil.ExtractSeverityFromInput(up.Input))
+	if shed == nil {
+		return release, nil
+	}
+
+	endorserLogger.Debugw("Shedding reading", "channel", up.ChannelID(), "txID", up.TxID(), "class", shed.Class)
+	payload, _ := json.Marshal(shed)
+	return nil, &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  ingestion.StatusTooManyRequests,
+			Message: shed.Error(),
+			Payload: payload,
+		},
+	}
+}
@@ -52,6 +52,10 @@ type Support interface {
 	// specified ledger
 	GetHistoryQueryExecutor(ledgername string) (ledger.HistoryQueryExecutor, error)
 
+	// GetQueryExecutor gives handle to a query executor for the specified
+	// ledger
+	GetQueryExecutor(ledgername string) (ledger.QueryExecutor, error)
+
 	// GetTransactionByID retrieves a transaction by id
 	GetTransactionByID(chid, txID string) (*pb.ProcessedTransaction, error)
 
@@ -101,6 +105,12 @@ type Endorser struct {
 	Support                Support
 	PvtRWSetAssembler      PvtRWSetAssembler
 	Metrics                *Metrics
+	// CommitNotifier notifies the commits awaited by the BSCC queries
+	// decorated with a consistency token
+	CommitNotifier CommitNotifier
+	// ReadingAdmitter sheds the readings while the peer is overloaded, the
+	// readings being all admitted if nil
+	ReadingAdmitter ReadingAdmitter
 }
 
 // call specified chaincode (system or user)
@@ -338,6 +348,13 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
 
+	// BLOCC: the readings are shed while the peer is overloaded
+	release, shedResponse := e.admitReading(up)
+	if shedResponse != nil {
+		return shedResponse, nil
+	}
+	defer release()
+
 	defer func() {
 		meterLabels := []string{
 			"channel", up.ChannelHeader.ChannelId,
@@ -377,6 +394,14 @@ func (e *Endorser) ProcessProposalSuccessfullyOrError(up *UnpackedProposal) (*pb
 	logger := decorateLogger(endorserLogger, txParams)
 
 	if acquireTxSimulator(up.ChannelHeader.ChannelId, up.ChaincodeName) {
+		// BLOCC: a BSCC query decorated with a consistency token reads the
+		// writes of the approval of the token
+		if token := consistencyToken(up); token != "" {
+			if err := e.awaitConsistency(up.ChannelID(), token); err != nil {
+				return nil, err
+			}
+		}
+
 		txSim, err := e.Support.GetTxSimulator(up.ChannelID(), up.TxID())
 		if err != nil {
 			return nil, err
@@ -597,8 +622,9 @@ func acquireTxSimulator(chainID string, chaincodeName string) bool {
 	// ¯\_(ツ)_/¯ locking.
 	// Don't get a simulator for the query and config system chaincode.
 	// These don't need the simulator and its read lock results in deadlocks.
+	// bscc needs one as approval records are written to its namespace.
 	switch chaincodeName {
-	case "qscc", "cscc", "bscc":
+	case "qscc", "cscc":
 		return false
 	default:
 		return true
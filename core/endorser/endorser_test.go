@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
@@ -23,6 +24,9 @@ import (
 	"github.com/hyperledger/fabric/core/endorser/fake"
 	"github.com/hyperledger/fabric/core/ledger"
 	ledgermock "github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/ingestion"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
 	"github.com/hyperledger/fabric/protoutil"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -346,6 +350,165 @@ var _ = Describe("Endorser", func() {
 		})
 	})
 
+	Context("when the proposal is decorated with a consistency token", func() {
+		var (
+			fakeQueryExecutor  *fake.QueryExecutor
+			fakeCommitNotifier *fake.CommitNotifier
+			statusChannel      chan *commit.Status
+		)
+
+		BeforeEach(func() {
+			token := &protoutil.ConsistencyToken{TxID: "approval-txid", SensoryTxID: "reading-txid", MSPID: "Org1MSP"}
+			chaincodeName = "bscc"
+			chaincodeInput = &pb.ChaincodeInput{
+				Args:        [][]byte{[]byte("QueryApprovals"), []byte("reading-txid")},
+				Decorations: map[string][]byte{protoutil.ConsistencyTokenDecoration: []byte(token.Encode())},
+			}
+
+			fakeQueryExecutor = &fake.QueryExecutor{}
+			fakeQueryExecutor.GetStateReturns([]byte(`{"approvalTxID":"approval-txid"}`), nil)
+			fakeSupport.GetQueryExecutorReturns(fakeQueryExecutor, nil)
+
+			statusChannel = make(chan *commit.Status, 1)
+			fakeCommitNotifier = &fake.CommitNotifier{}
+			fakeCommitNotifier.NotifyStatusReturns(statusChannel, nil)
+			e.CommitNotifier = fakeCommitNotifier
+		})
+
+		It("checks the approval of the token before simulating the proposal", func() {
+			_, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			_, ledgerName, txid := fakeCommitNotifier.NotifyStatusArgsForCall(0)
+			Expect(ledgerName).To(Equal("channel-id"))
+			Expect(txid).To(Equal("approval-txid"))
+			Expect(fakeSupport.GetQueryExecutorArgsForCall(0)).To(Equal("channel-id"))
+			namespace, key := fakeQueryExecutor.GetStateArgsForCall(0)
+			Expect(namespace).To(Equal("bscc"))
+			Expect(key).To(Equal("\x00approval\x00reading-txid\x00Org1MSP\x00"))
+			Expect(fakeQueryExecutor.DoneCallCount()).To(Equal(1))
+			Expect(fakeSupport.GetTxSimulatorCallCount()).To(Equal(1))
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(1))
+		})
+
+		Context("when the approval is not committed yet", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetStateReturnsOnCall(0, nil, nil)
+				statusChannel <- &commit.Status{TransactionID: "approval-txid", Code: pb.TxValidationCode_VALID}
+			})
+
+			It("waits for the commit of the approval transaction", func() {
+				_, err := e.ProcessProposal(context.Background(), signedProposal)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeQueryExecutor.GetStateCallCount()).To(Equal(2))
+				Expect(fakeSupport.ExecuteCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the approval transaction is invalidated", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetStateReturns(nil, nil)
+				statusChannel <- &commit.Status{TransactionID: "approval-txid", Code: pb.TxValidationCode_MVCC_READ_CONFLICT}
+			})
+
+			It("returns a response with the error", func() {
+				proposalResponse, err := e.ProcessProposal(context.Background(), signedProposal)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proposalResponse.Response).To(Equal(&pb.Response{
+					Status:  500,
+					Message: "approval transaction approval-txid of the consistency token was invalidated with code MVCC_READ_CONFLICT",
+				}))
+				Expect(fakeSupport.ExecuteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the approval transaction was invalidated before", func() {
+			BeforeEach(func() {
+				fakeQueryExecutor.GetStateReturns(nil, nil)
+				fakeSupport.GetTransactionByIDStub = func(_, txID string) (*pb.ProcessedTransaction, error) {
+					if txID == "approval-txid" {
+						return &pb.ProcessedTransaction{ValidationCode: int32(pb.TxValidationCode_MVCC_READ_CONFLICT)}, nil
+					}
+					return nil, fmt.Errorf("txid-error")
+				}
+			})
+
+			It("returns a response with the error without waiting", func() {
+				proposalResponse, err := e.ProcessProposal(context.Background(), signedProposal)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proposalResponse.Response.Message).To(Equal("approval transaction approval-txid of the consistency token was invalidated with code MVCC_READ_CONFLICT"))
+				Expect(fakeSupport.ExecuteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the proposal is not a BSCC query", func() {
+			BeforeEach(func() {
+				chaincodeInput.Args = [][]byte{[]byte("ApproveSensoryReading"), []byte("reading-txid")}
+			})
+
+			It("ignores the token", func() {
+				_, err := e.ProcessProposal(context.Background(), signedProposal)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeCommitNotifier.NotifyStatusCallCount()).To(Equal(0))
+				Expect(fakeSupport.GetQueryExecutorCallCount()).To(Equal(0))
+				Expect(fakeSupport.ExecuteCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the token is invalid", func() {
+			BeforeEach(func() {
+				chaincodeInput.Decorations = map[string][]byte{protoutil.ConsistencyTokenDecoration: []byte("garbage")}
+			})
+
+			It("returns a response with the error", func() {
+				proposalResponse, err := e.ProcessProposal(context.Background(), signedProposal)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proposalResponse.Response.Status).To(Equal(int32(500)))
+				Expect(proposalResponse.Response.Message).To(ContainSubstring("invalid consistency token"))
+			})
+		})
+	})
+
+	Context("when the peer is overloaded with readings", func() {
+		var shedder *ingestion.Shedder
+
+		BeforeEach(func() {
+			sensorcc.Default.Set([]sensorcc.Chaincode{{Name: "chaincode-name"}})
+			shedder = ingestion.NewShedder(nil)
+			shedder.SetLimits(ingestion.Limits{MaxInFlight: 1, RetryAfter: time.Second})
+			e.ReadingAdmitter = shedder
+		})
+
+		AfterEach(func() {
+			sensorcc.Default.Set([]sensorcc.Chaincode{{Name: sensorcc.DefaultName}})
+		})
+
+		It("sheds the reading with a retry-after response", func() {
+			release, shed := shedder.Admit("channel-id", "alarm")
+			Expect(shed).To(BeNil())
+			defer release()
+
+			proposalResponse, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proposalResponse.Response).To(Equal(&pb.Response{
+				Status:  429,
+				Message: "bulk reading on channel channel-id shed as the peer is overloaded with 1 proposals in flight, retry after 1s",
+				Payload: []byte(`{"channelID":"channel-id","class":"bulk","inFlight":1,"limit":1,"retryAfterMillis":1000}`),
+			}))
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(0))
+		})
+
+		It("endorses the reading within the limits", func() {
+			_, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(1))
+
+			// the proposal is no longer in flight once endorsed
+			release, shed := shedder.Admit("channel-id", "")
+			Expect(shed).To(BeNil())
+			release()
+		})
+	})
+
 	It("gets a history query executor", func() {
 		_, err := e.ProcessProposal(context.Background(), signedProposal)
 		Expect(err).NotTo(HaveOccurred())
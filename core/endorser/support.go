@@ -76,6 +76,15 @@ func (s *SupportImpl) GetHistoryQueryExecutor(ledgername string) (ledger.History
 	return lgr.NewHistoryQueryExecutor()
 }
 
+// GetQueryExecutor gives handle to a query executor for the specified ledger
+func (s *SupportImpl) GetQueryExecutor(ledgername string) (ledger.QueryExecutor, error) {
+	lgr := s.Peer.GetLedger(ledgername)
+	if lgr == nil {
+		return nil, errors.Errorf("Channel does not exist: %s", ledgername)
+	}
+	return lgr.NewQueryExecutor()
+}
+
 // GetTransactionByID retrieves a transaction by id
 func (s *SupportImpl) GetTransactionByID(chid, txID string) (*pb.ProcessedTransaction, error) {
 	lgr := s.Peer.GetLedger(chid)
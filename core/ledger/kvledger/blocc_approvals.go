@@ -0,0 +1,225 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	bloccevent "github.com/hyperledger/fabric/common/blocc-events"
+	merkle "github.com/hyperledger/fabric/common/blocc-merkle"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// approvalKeyPrefix is the prefix of the composite keys of the approval
+// records written by BSCC. Approval transactions of rejected readings write
+// rejection records, keyed with rejectionKeyPrefix, instead.
+const (
+	approvalKeyPrefix  = "\x00approval\x00"
+	rejectionKeyPrefix = "\x00rejection\x00"
+)
+
+// publishCommittedApprovals publishes an ApprovalCommitted or a
+// RejectionCommitted event for every valid transaction of the block that
+// records an approval or a rejection, and an ApprovalInvalidated event for
+// every such transaction invalidated on commit to bus.
+func publishCommittedApprovals(bus *bloccevent.Bus, block *common.Block) {
+	var flags txflags.ValidationFlags
+	if len(block.GetMetadata().GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	for i, data := range block.GetData().GetData() {
+		validationCode := peer.TxValidationCode_VALID
+		if len(flags) > i {
+			validationCode = flags.Flag(i)
+		}
+
+		env, err := protoutil.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+
+		chdr, err := protoutil.ChannelHeader(env)
+		if err != nil || common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+
+		isBscc, err := protoutil.IsBscc(data)
+		if err != nil || !isBscc {
+			continue
+		}
+
+		eventType := bloccevent.ApprovalCommitted
+		switch {
+		case writesApproval(env):
+		case len(bsccWrites(env, rejectionKeyPrefix)) > 0:
+			eventType = bloccevent.RejectionCommitted
+		default:
+			continue
+		}
+		if validationCode != peer.TxValidationCode_VALID {
+			// invalid approval transactions are published whatever the
+			// decision they would have recorded, for resubmission
+			eventType = bloccevent.ApprovalInvalidated
+		}
+
+		mspID, sensoryTxID, err := protoutil.ExtractApprovalInfo(data)
+		if err != nil {
+			logger.Warningf("BLOCC: Failed to extract approval info of transaction %s: %s", chdr.TxId, err)
+			continue
+		}
+
+		traceID := protoutil.ExtractTraceID(data)
+		logger.Debugf("BLOCC: Committed %s of reading %s by %s in transaction %s, trace %s", eventType, sensoryTxID, mspID, chdr.TxId, traceID)
+
+		e := bloccevent.Event{
+			Type:        eventType,
+			ChannelID:   chdr.ChannelId,
+			SensoryTxID: sensoryTxID,
+			MSPID:       mspID,
+			TraceID:     traceID,
+		}
+		if eventType == bloccevent.ApprovalInvalidated {
+			e.ValidationCode = validationCode.String()
+		}
+		bus.Publish(e)
+	}
+}
+
+// writesApproval returns whether the transaction writes an approval record.
+func writesApproval(env *common.Envelope) bool {
+	return len(approvalWrites(env)) > 0
+}
+
+// approvalWrites returns the approval records written by the transaction.
+func approvalWrites(env *common.Envelope) []*kvrwset.KVWrite {
+	return bsccWrites(env, approvalKeyPrefix)
+}
+
+// bsccWrites returns the BSCC records written by the transaction whose keys
+// start with prefix.
+func bsccWrites(env *common.Envelope, prefix string) []*kvrwset.KVWrite {
+	action, err := protoutil.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		return nil
+	}
+
+	txRWSet := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(action.Results, txRWSet); err != nil {
+		return nil
+	}
+
+	var writes []*kvrwset.KVWrite
+	for _, nsRWSet := range txRWSet.NsRwset {
+		if nsRWSet.Namespace != bsccNamespace {
+			continue
+		}
+
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return nil
+		}
+
+		for _, write := range kvRWSet.Writes {
+			if strings.HasPrefix(write.Key, prefix) && !write.IsDelete {
+				writes = append(writes, write)
+			}
+		}
+	}
+
+	return writes
+}
+
+// blockApprovalLeaves returns the Merkle leaves of the approval records
+// written by the valid transactions of the block, ordered by key. A record
+// written more than once in the block contributes its last value only.
+func blockApprovalLeaves(block *common.Block) [][]byte {
+	var flags txflags.ValidationFlags
+	if len(block.GetMetadata().GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	records := map[string][]byte{}
+	for i, data := range block.GetData().GetData() {
+		if len(flags) > i && !flags.IsValid(i) {
+			continue
+		}
+
+		env, err := protoutil.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+
+		chdr, err := protoutil.ChannelHeader(env)
+		if err != nil || common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+
+		for _, write := range approvalWrites(env) {
+			records[write.Key] = write.Value
+		}
+	}
+
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	leaves := make([][]byte, len(keys))
+	for i, key := range keys {
+		leaves[i] = merkle.KeyValueLeaf(key, records[key])
+	}
+	return leaves
+}
+
+// addApprovalAnchor computes the Merkle root of the approval records written
+// by the block and chains it to the anchor of the previous block, so that
+// every anchor commits to all the approvals committed so far. Like the commit
+// hash, the anchor is added to the block metadata and is identical on every
+// peer of the channel. It is left out of blocks whose metadata entry of the
+// anchor is taken by another entry.
+func (l *kvLedger) addApprovalAnchor(block *common.Block) {
+	root := merkle.Root(blockApprovalLeaves(block))
+	l.approvalAnchor = protoutil.ChainApprovalAnchor(l.approvalAnchor, root)
+	if err := protoutil.SetApprovalAnchor(block, &protoutil.ApprovalAnchor{Root: root, Anchor: l.approvalAnchor}); err != nil {
+		logger.Warningf("BLOCC: Leaving out the approval anchor of block %d: %s", block.Header.Number, err)
+	}
+}
+
+// lastPersistedApprovalAnchor returns the approval anchor of the last block,
+// or nil if the chain is empty or the ledger was created from a snapshot
+// since, in which case the chain of anchors restarts.
+func (l *kvLedger) lastPersistedApprovalAnchor() ([]byte, error) {
+	bcInfo, err := l.GetBlockchainInfo()
+	if err != nil {
+		return nil, err
+	}
+	if bcInfo.Height == 0 {
+		return nil, nil
+	}
+	if l.bootSnapshotMetadata != nil && l.bootSnapshotMetadata.LastBlockNumber == bcInfo.Height-1 {
+		return nil, nil
+	}
+
+	block, err := l.GetBlockByNumber(bcInfo.Height - 1)
+	if err != nil {
+		return nil, err
+	}
+
+	anchor, err := protoutil.GetApprovalAnchor(block)
+	if err != nil || anchor == nil {
+		return nil, err
+	}
+	return anchor.Anchor, nil
+}
@@ -0,0 +1,108 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	merkle "github.com/hyperledger/fabric/common/blocc-merkle"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func bsccWriteEnvelope(t *testing.T, namespace string, keys ...string) *common.Envelope {
+	kvRWSet := &kvrwset.KVRWSet{}
+	for _, key := range keys {
+		kvRWSet.Writes = append(kvRWSet.Writes, &kvrwset.KVWrite{Key: key, Value: []byte(`{"key":"` + key + `"}`)})
+	}
+	kvRWSetBytes, err := proto.Marshal(kvRWSet)
+	require.NoError(t, err)
+
+	resultsBytes, err := proto.Marshal(&rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{{Namespace: namespace, Rwset: kvRWSetBytes}},
+	})
+	require.NoError(t, err)
+
+	actionBytes, err := proto.Marshal(&peer.ChaincodeAction{Results: resultsBytes})
+	require.NoError(t, err)
+
+	prpBytes, err := proto.Marshal(&peer.ProposalResponsePayload{Extension: actionBytes})
+	require.NoError(t, err)
+
+	capBytes, err := proto.Marshal(&peer.ChaincodeActionPayload{
+		Action: &peer.ChaincodeEndorsedAction{ProposalResponsePayload: prpBytes},
+	})
+	require.NoError(t, err)
+
+	txBytes, err := proto.Marshal(&peer.Transaction{Actions: []*peer.TransactionAction{{Payload: capBytes}}})
+	require.NoError(t, err)
+
+	chdrBytes, err := proto.Marshal(&common.ChannelHeader{Type: int32(common.HeaderType_ENDORSER_TRANSACTION), ChannelId: "mychannel"})
+	require.NoError(t, err)
+
+	payloadBytes, err := proto.Marshal(&common.Payload{Header: &common.Header{ChannelHeader: chdrBytes}, Data: txBytes})
+	require.NoError(t, err)
+
+	return &common.Envelope{Payload: payloadBytes}
+}
+
+func TestWritesApproval(t *testing.T) {
+	require.True(t, writesApproval(bsccWriteEnvelope(t, bsccNamespace, "\x00approval\x00tx1\x00Org1MSP\x00")))
+	require.False(t, writesApproval(bsccWriteEnvelope(t, bsccNamespace, "\x00rejection\x00tx1\x00Org1MSP\x00")))
+	require.False(t, writesApproval(bsccWriteEnvelope(t, "mycc", "\x00approval\x00tx1\x00Org1MSP\x00")))
+	require.False(t, writesApproval(&common.Envelope{}))
+
+	require.Len(t, bsccWrites(bsccWriteEnvelope(t, bsccNamespace, "\x00rejection\x00tx1\x00Org1MSP\x00"), rejectionKeyPrefix), 1)
+	require.Empty(t, bsccWrites(bsccWriteEnvelope(t, bsccNamespace, "\x00approval\x00tx1\x00Org1MSP\x00"), rejectionKeyPrefix))
+}
+
+func TestBlockApprovalLeaves(t *testing.T) {
+	block := protoutil.NewBlock(1, nil)
+	for _, env := range []*common.Envelope{
+		bsccWriteEnvelope(t, bsccNamespace, "\x00approval\x00tx2\x00Org1MSP\x00"),
+		bsccWriteEnvelope(t, bsccNamespace, "\x00approval\x00tx1\x00Org1MSP\x00"),
+		bsccWriteEnvelope(t, bsccNamespace, "\x00approval\x00tx3\x00Org1MSP\x00"),
+		bsccWriteEnvelope(t, bsccNamespace, "\x00rejection\x00tx4\x00Org1MSP\x00"),
+	} {
+		block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(env))
+	}
+	flags := txflags.NewWithValues(4, peer.TxValidationCode_VALID)
+	flags.SetFlag(2, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+
+	// ordered by key, skipping invalid transactions and rejections
+	require.Equal(t, [][]byte{
+		merkle.KeyValueLeaf("\x00approval\x00tx1\x00Org1MSP\x00", []byte(`{"key":"`+"\x00approval\x00tx1\x00Org1MSP\x00"+`"}`)),
+		merkle.KeyValueLeaf("\x00approval\x00tx2\x00Org1MSP\x00", []byte(`{"key":"`+"\x00approval\x00tx2\x00Org1MSP\x00"+`"}`)),
+	}, blockApprovalLeaves(block))
+}
+
+func TestAddApprovalAnchor(t *testing.T) {
+	l := &kvLedger{}
+	block := protoutil.NewBlock(1, nil)
+	block.Data.Data = [][]byte{protoutil.MarshalOrPanic(bsccWriteEnvelope(t, bsccNamespace, "\x00approval\x00tx1\x00Org1MSP\x00"))}
+
+	l.addApprovalAnchor(block)
+	anchor, err := protoutil.GetApprovalAnchor(block)
+	require.NoError(t, err)
+	require.Equal(t, merkle.Root(blockApprovalLeaves(block)), anchor.Root)
+	require.Equal(t, protoutil.ChainApprovalAnchor(nil, anchor.Root), anchor.Anchor)
+	require.Equal(t, anchor.Anchor, l.approvalAnchor)
+
+	// blocks without approvals still extend the chain
+	next := protoutil.NewBlock(2, nil)
+	l.addApprovalAnchor(next)
+	nextAnchor, err := protoutil.GetApprovalAnchor(next)
+	require.NoError(t, err)
+	require.Equal(t, merkle.EmptyRoot(), nextAnchor.Root)
+	require.Equal(t, protoutil.ChainApprovalAnchor(anchor.Anchor, merkle.EmptyRoot()), nextAnchor.Anchor)
+}
@@ -0,0 +1,38 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+)
+
+// bsccNamespace is the state namespace of the BLOCC system chaincode
+const bsccNamespace = "bscc"
+
+// bsccIndexes are the CouchDB indexes on the JSON documents written by BSCC.
+// System chaincodes are not deployed through the chaincode lifecycle, so the
+// indexes are created when the ledger is opened rather than from a package.
+var bsccIndexes = map[string][]byte{
+	"indexApprovalMSP.json":     []byte(`{"index":{"fields":["docType","mspID"]},"ddoc":"indexApprovalMSPDoc","name":"indexApprovalMSP","type":"json"}`),
+	"indexApprovalReading.json": []byte(`{"index":{"fields":["docType","sensoryTxID"]},"ddoc":"indexApprovalReadingDoc","name":"indexApprovalReading","type":"json"}`),
+	"indexApprovalTime.json":    []byte(`{"index":{"fields":["docType","timestamp"]},"ddoc":"indexApprovalTimeDoc","name":"indexApprovalTime","type":"json"}`),
+	"indexSensorMSP.json":       []byte(`{"index":{"fields":["docType","mspID"]},"ddoc":"indexSensorMSPDoc","name":"indexSensorMSP","type":"json"}`),
+}
+
+// createBSCCIndexes creates the BSCC indexes if the state database supports
+// them. Index creation errors are logged only, as queries still succeed
+// without the indexes.
+func createBSCCIndexes(ledgerID string, stateDB *privacyenabledstate.DB) {
+	indexCapable, ok := stateDB.VersionedDB.(statedb.IndexCapable)
+	if !ok {
+		return
+	}
+
+	if err := indexCapable.ProcessIndexesForChaincodeDeploy(bsccNamespace, bsccIndexes); err != nil {
+		logger.Errorf("Failed to create bscc indexes on channel [%s]: %s", ledgerID, err)
+	}
+}
@@ -0,0 +1,166 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/deadletter"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// exportUnresolvedReadings exports the valid sensory readings up to
+// lastBlockNum that are approved by fewer organizations than the threshold
+// of the dead-letter store. Fabric does not prune blocks, but a peer joining
+// from a snapshot has none of the blocks before it, so the readings whose
+// approval is pending are exported before the snapshot is generated. The
+// export is kept out of the snapshot, whose files must be identical on every
+// peer. This function should be invoked when commits are paused, as is
+// generateSnapshot.
+func (l *kvLedger) exportUnresolvedReadings(lastBlockNum uint64) error {
+	threshold := deadletter.Default.Threshold()
+	if threshold <= 0 {
+		return nil
+	}
+
+	bcInfo, err := l.GetBlockchainInfo()
+	if err != nil {
+		return err
+	}
+	var firstBlockNum uint64
+	if snapshotInfo := bcInfo.GetBootstrappingSnapshotInfo(); snapshotInfo != nil {
+		firstBlockNum = snapshotInfo.LastBlockInSnapshot + 1
+	}
+
+	qe, err := l.txmgr.NewQueryExecutor("")
+	if err != nil {
+		return err
+	}
+	defer qe.Done()
+
+	blocksItr, err := l.blockStore.RetrieveBlocks(firstBlockNum)
+	if err != nil {
+		return err
+	}
+	defer blocksItr.Close()
+
+	export := &deadletter.Export{
+		ChannelID:    l.ledgerID,
+		LastBlockNum: lastBlockNum,
+		Threshold:    threshold,
+	}
+	for blockNum := firstBlockNum; blockNum <= lastBlockNum; blockNum++ {
+		result, err := blocksItr.Next()
+		if err != nil {
+			return err
+		}
+		for _, reading := range sensoryReadings(result.(*common.Block)) {
+			reading.Approvals, err = readingDecisions(qe, approvalKeyPrefix, reading.TxID)
+			if err != nil {
+				return err
+			}
+			if len(reading.Approvals) >= threshold {
+				continue
+			}
+			reading.Rejections, err = readingDecisions(qe, rejectionKeyPrefix, reading.TxID)
+			if err != nil {
+				return err
+			}
+			export.Readings = append(export.Readings, reading)
+		}
+	}
+	if len(export.Readings) == 0 {
+		return nil
+	}
+
+	path, err := deadletter.Default.Write(export)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to export unresolved readings of channel [%s]", l.ledgerID)
+	}
+	logger.Warningf("BLOCC: Exported %d readings approved by fewer than %d organizations on channel [%s] up to block %d to %s",
+		len(export.Readings), threshold, l.ledgerID, lastBlockNum, path)
+
+	return nil
+}
+
+// sensoryReadings returns the valid transactions of the block that invoke a
+// sensor chaincode.
+func sensoryReadings(block *common.Block) []deadletter.Reading {
+	var flags txflags.ValidationFlags
+	if len(block.GetMetadata().GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var readings []deadletter.Reading
+	for i, data := range block.GetData().GetData() {
+		if len(flags) > i && flags.Flag(i) != peer.TxValidationCode_VALID {
+			continue
+		}
+
+		env, err := protoutil.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		chdr, err := protoutil.ChannelHeader(env)
+		if err != nil || common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		cis, err := protoutil.ExtractChaincodeInvocationSpec(data)
+		if err != nil {
+			continue
+		}
+		action, err := protoutil.GetActionFromEnvelopeMsg(env)
+		if err != nil {
+			continue
+		}
+		if _, ok := sensorcc.Default.Match(cis.GetChaincodeSpec().GetChaincodeId().GetName(), action.GetChaincodeId().GetVersion()); !ok {
+			continue
+		}
+
+		readings = append(readings, deadletter.Reading{
+			TxID:     chdr.TxId,
+			BlockNum: block.GetHeader().GetNumber(),
+			TxNum:    uint64(i),
+		})
+	}
+
+	return readings
+}
+
+// readingDecisions returns the MSP IDs of the BSCC records of the reading
+// whose keys start with prefix, i.e. of the organizations that approved or
+// rejected it.
+func readingDecisions(qe ledger.SimpleQueryExecutor, prefix, sensoryTxID string) ([]string, error) {
+	startKey := prefix + sensoryTxID + "\x00"
+	itr, err := qe.GetStateRangeScanIterator(bsccNamespace, startKey, startKey+string(utf8.MaxRune))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to query the decisions on reading %s", sensoryTxID)
+	}
+	defer itr.Close()
+
+	var mspIDs []string
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to query the decisions on reading %s", sensoryTxID)
+		}
+		if result == nil {
+			break
+		}
+		mspID := strings.TrimSuffix(strings.TrimPrefix(result.(*queryresult.KV).Key, startKey), "\x00")
+		mspIDs = append(mspIDs, mspID)
+	}
+
+	return mspIDs, nil
+}
@@ -0,0 +1,112 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/deadletter"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUnresolvedReadings(t *testing.T) {
+	conf, cleanup := testConfig(t)
+	defer cleanup()
+	provider := testutilNewProvider(conf, t, &mock.DeployedChaincodeInfoProvider{})
+	defer provider.Close()
+
+	_, genesisBlk := testutil.NewBlockGenerator(t, "testLedgerid", false)
+	lgr, err := provider.CreateFromGenesisBlock(genesisBlk)
+	require.NoError(t, err)
+	defer lgr.Close()
+	kvlgr := lgr.(*kvLedger)
+
+	simulate := func(txID string, kvs map[string]string) []byte {
+		simulator, err := kvlgr.NewTxSimulator(txID)
+		require.NoError(t, err)
+		for k, v := range kvs {
+			require.NoError(t, simulator.SetState(bsccNamespace, k, []byte(v)))
+		}
+		simulator.Done()
+		simRes, err := simulator.GetTxSimulationResults()
+		require.NoError(t, err)
+		pubSimBytes, err := simRes.GetPubSimulationBytes()
+		require.NoError(t, err)
+		return pubSimBytes
+	}
+
+	// block 1 holds two readings and a transaction of another chaincode, and
+	// block 2 an approval of the first reading and a rejection of the second,
+	// written to the BSCC state without the arguments of an approval
+	// transaction
+	previousHash := protoutil.BlockHeaderHash(genesisBlk.Header)
+	block1 := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+		BlockNum:     1,
+		PreviousHash: previousHash,
+		Txs: []*testutil.TxDetails{
+			{TxID: "reading1", ChaincodeName: "sensor_chaincode", ChaincodeVersion: "1.0", Type: common.HeaderType_ENDORSER_TRANSACTION},
+			{TxID: "other", ChaincodeName: "mycc", ChaincodeVersion: "1.0", Type: common.HeaderType_ENDORSER_TRANSACTION},
+			{TxID: "reading2", ChaincodeName: "sensor_chaincode", ChaincodeVersion: "1.0", Type: common.HeaderType_ENDORSER_TRANSACTION},
+		},
+	}, false)
+	require.NoError(t, kvlgr.CommitLegacy(&ledger.BlockAndPvtData{Block: block1}, &ledger.CommitOptions{}))
+
+	block2 := testutil.ConstructBlockFromBlockDetails(t, &testutil.BlockDetails{
+		BlockNum:     2,
+		PreviousHash: protoutil.BlockHeaderHash(block1.Header),
+		Txs: []*testutil.TxDetails{{
+			TxID:          "approvals",
+			ChaincodeName: "mycc",
+			SimulationResults: simulate("approvals", map[string]string{
+				"\x00approval\x00reading1\x00Org1MSP\x00":  "{}",
+				"\x00rejection\x00reading2\x00Org2MSP\x00": "{}",
+			}),
+			Type: common.HeaderType_ENDORSER_TRANSACTION,
+		}},
+	}, false)
+	require.NoError(t, kvlgr.CommitLegacy(&ledger.BlockAndPvtData{Block: block2}, &ledger.CommitOptions{}))
+
+	// exports are disabled by default
+	require.NoError(t, kvlgr.generateSnapshot())
+
+	store := deadletter.Default
+	defer store.Set("", 0)
+	store.Set(t.TempDir(), 1)
+	require.NoError(t, kvlgr.exportUnresolvedReadings(2))
+	export, err := store.Read("testLedgerid", 2)
+	require.NoError(t, err)
+	require.Equal(t, &deadletter.Export{
+		ChannelID:    "testLedgerid",
+		LastBlockNum: 2,
+		Threshold:    1,
+		Readings: []deadletter.Reading{
+			{TxID: "reading2", BlockNum: 1, TxNum: 2, Rejections: []string{"Org2MSP"}},
+		},
+	}, export)
+
+	store.Set(t.TempDir(), 2)
+	require.NoError(t, os.RemoveAll(SnapshotDirForLedgerBlockNum(conf.SnapshotsConfig.RootDir, "testLedgerid", 2)))
+	require.NoError(t, kvlgr.generateSnapshot())
+	export, err = store.Read("testLedgerid", 2)
+	require.NoError(t, err)
+	require.Equal(t, []deadletter.Reading{
+		{TxID: "reading1", BlockNum: 1, TxNum: 0, Approvals: []string{"Org1MSP"}},
+		{TxID: "reading2", BlockNum: 1, TxNum: 2, Rejections: []string{"Org2MSP"}},
+	}, export.Readings)
+
+	// nothing is exported if every reading is resolved
+	store.Set(t.TempDir(), 1)
+	require.NoError(t, kvlgr.exportUnresolvedReadings(0))
+	export, err = store.Read("testLedgerid", 0)
+	require.NoError(t, err)
+	require.Nil(t, export)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
+	bloccevent "github.com/hyperledger/fabric/common/blocc-events"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -34,6 +35,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatastorage"
 	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -63,6 +65,7 @@ type kvLedger struct {
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
 	commitHash             []byte
+	approvalAnchor         []byte
 	hashProvider           ledger.HashProvider
 	config                 *ledger.Config
 
@@ -77,6 +80,8 @@ type kvLedger struct {
 	// blockCommitter is used to gossip the very last committed block as
 	// per BLOCC requirements
 	blockCommitter service.GossipBlockCommitter
+	// eventBus is the BLOCC event bus the committed approvals are published to
+	eventBus *bloccevent.Bus
 }
 
 type lgrInitializer struct {
@@ -97,6 +102,7 @@ type lgrInitializer struct {
 	hashProvider             ledger.HashProvider
 	config                   *ledger.Config
 	blockCommitter           service.GossipBlockCommitter
+	eventBus                 *bloccevent.Bus
 }
 
 // This is a request for checking if a transaction is sensory endorsement transaction.
@@ -142,6 +148,7 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		config:               initializer.config,
 		blockAPIsRWLock:      &sync.RWMutex{},
 		blockCommitter:       initializer.blockCommitter,
+		eventBus:             initializer.eventBus,
 	}
 
 	btlPolicy := pvtdatapolicy.ConstructBTLPolicy(&collectionInfoRetriever{ledgerID, l, initializer.ccInfoProvider})
@@ -181,6 +188,11 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		return nil, err
 	}
 
+	l.approvalAnchor, err = l.lastPersistedApprovalAnchor()
+	if err != nil {
+		return nil, err
+	}
+
 	isAhead, err := l.isPvtDataStoreAheadOfBlockStore()
 	if err != nil {
 		return nil, err
@@ -200,6 +212,7 @@ func newKVLedger(initializer *lgrInitializer) (*kvLedger, error) {
 		if err != nil {
 			return nil, err
 		}
+		createBSCCIndexes(ledgerID, initializer.stateDB)
 	}
 
 	// Recover both state DB and history DB if they are out of sync with block storage
@@ -644,6 +657,9 @@ func (l *kvLedger) CommitLegacy(pvtdataAndBlock *ledger.BlockAndPvtData, commitO
 	l.snapshotMgr.events <- &event{commitDone, blockNumber}
 
 	l.gossipIfSensoryTx(pvtdataAndBlock)
+	if l.eventBus != nil {
+		publishCommittedApprovals(l.eventBus, pvtdataAndBlock.Block)
+	}
 
 	return nil
 }
@@ -772,8 +788,18 @@ func (l *kvLedger) checkIfSensoryChaincode(txIndex int, txID string, channelID s
 		return
 	}
 
+	// the version of the chaincode is that of the endorsing definition, so
+	// that readings of the old and new chaincodes can be told apart during
+	// an upgrade
+	var ccVersion string
+	if prp, err := protoutil.UnmarshalProposalResponsePayload(chaincodeActionPayload.GetAction().GetProposalResponsePayload()); err == nil {
+		if action, err := protoutil.UnmarshalChaincodeAction(prp.Extension); err == nil {
+			ccVersion = action.GetChaincodeId().GetVersion()
+		}
+	}
+
 	ccName := cis.ChaincodeSpec.ChaincodeId.Name
-	if ccName == "sensor_chaincode" {
+	if sensorcc.Default.Handle(channelID, txID, ccName, ccVersion) {
 		results <- createSensoryCheckResult(txIndex, txID, channelID, true, nil)
 	} else {
 		results <- createSensoryCheckResult(txIndex, "", "", false, nil)
@@ -835,6 +861,7 @@ func (l *kvLedger) commit(pvtdataAndBlock *ledger.BlockAndPvtData, commitOpts *l
 	if block.Header.Number == 1 || len(l.commitHash) != 0 {
 		l.addBlockCommitHash(pvtdataAndBlock.Block, updateBatchBytes)
 	}
+	l.addApprovalAnchor(pvtdataAndBlock.Block)
 
 	logger.Debugf("[%s] Committing pvtdata and block [%d] to storage", l.ledgerID, blockNo)
 	l.blockAPIsRWLock.Lock()
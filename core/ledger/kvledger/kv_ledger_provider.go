@@ -381,6 +381,7 @@ func (p *Provider) open(ledgerID string, bootSnapshotMetadata *SnapshotMetadata,
 		bootSnapshotMetadata:     bootSnapshotMetadata,
 		initializingFromSnapshot: initializingFromSnapshot,
 		blockCommitter:           p.initializer.BlockCommitter,
+		eventBus:                 p.initializer.EventBus,
 	}
 
 	l, err := newKVLedger(initializer)
@@ -98,6 +98,9 @@ func (l *kvLedger) generateSnapshot() error {
 		return err
 	}
 	lastBlockNum := bcInfo.Height - 1
+	if err := l.exportUnresolvedReadings(lastBlockNum); err != nil {
+		return err
+	}
 	snapshotTempDir, err := ioutil.TempDir(
 		SnapshotsTempDirPath(snapshotsRootDir),
 		fmt.Sprintf("%s-%d-", l.ledgerID, lastBlockNum),
@@ -17,6 +17,7 @@ import (
 	protopeer "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -275,6 +276,15 @@ func (r *retrievedBlockAndPvtdata) sameMetadata(expectedBlock *common.Block) {
 			// production code in this test too, so skipping this match
 			continue
 		}
+		if i == int(protoutil.BlockMetadataIndexApprovalAnchor) {
+			// the approval anchor is added by the ledger at commit, as the
+			// commit hash, and is covered by the tests of kvledger; only
+			// check that the retrieved block carries a well formed anchor
+			anchor, err := protoutil.GetApprovalAnchor(r.Block)
+			r.assert.NoError(err)
+			r.assert.NotNil(anchor)
+			continue
+		}
 		if len(expectedMetadata[i])+len(retrievedMetadata[i]) != 0 {
 			r.assert.Equal(expectedMetadata[i], retrievedMetadata[i])
 		}
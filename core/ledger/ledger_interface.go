@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
+	bloccevent "github.com/hyperledger/fabric/common/blocc-events"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/metrics"
 )
@@ -39,6 +40,9 @@ type Initializer struct {
 	CustomTxProcessors              map[common.HeaderType]CustomTxProcessor
 	HashProvider                    HashProvider
 	BlockCommitter                  GossipBlockCommitter
+	// EventBus is the BLOCC event bus the committed approvals are published
+	// to, none being published if it is nil
+	EventBus *bloccevent.Bus
 }
 
 // GossipBlockCommitter is an interface that allows the ledger to notify the gossip layer
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
+	bloccevent "github.com/hyperledger/fabric/common/blocc-events"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -61,6 +62,7 @@ type Initializer struct {
 	HashProvider                    ledger.HashProvider
 	EbMetadataProvider              MetadataProvider
 	BlockCommitter                  service.GossipBlockCommitter
+	EventBus                        *bloccevent.Bus
 }
 
 // NewLedgerMgr creates a new LedgerMgr
@@ -82,6 +84,7 @@ func NewLedgerMgr(initializer *Initializer) *LedgerMgr {
 			CustomTxProcessors:              initializer.CustomTxProcessors,
 			HashProvider:                    initializer.HashProvider,
 			BlockCommitter:                  initializer.BlockCommitter,
+			EventBus:                        initializer.EventBus,
 		},
 	)
 	if err != nil {
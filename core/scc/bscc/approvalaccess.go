@@ -0,0 +1,184 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+)
+
+// ApprovalAccess is the access of this peer to a channel for submitting
+// approvals, as of the last configuration of the channel.
+type ApprovalAccess struct {
+	// Suspended is set while the approval identity may not write to the
+	// channel, the approvals of the channel being skipped
+	Suspended bool `json:"suspended"`
+	// Reason tells why the approvals are suspended
+	Reason string `json:"reason,omitempty"`
+	// ConfigSequence is the sequence of the configuration of the channel
+	// the access was checked against
+	ConfigSequence uint64 `json:"configSequence"`
+	// Since is when the access last changed
+	Since time.Time `json:"since"`
+}
+
+// approvalAccess keeps the approval access of the channels.
+type approvalAccess struct {
+	mutex    sync.Mutex
+	channels map[string]ApprovalAccess
+	clock    clock.Clock
+}
+
+func newApprovalAccess(clk clock.Clock) *approvalAccess {
+	return &approvalAccess{
+		channels: map[string]ApprovalAccess{},
+		clock:    clk,
+	}
+}
+
+// update records the access of the channel as of the configuration sequence,
+// suspended if reason is set, and returns whether the approvals were
+// suspended or resumed by it. A channel seen for the first time is only
+// reported if suspended.
+func (a *approvalAccess) update(channelID string, sequence uint64, reason string) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	access, known := a.channels[channelID]
+	suspended := reason != ""
+	changed := access.Suspended != suspended || access.Reason != reason
+	if changed || !known {
+		access.Since = a.clock.Now()
+	}
+	access.Suspended = suspended
+	access.Reason = reason
+	access.ConfigSequence = sequence
+	a.channels[channelID] = access
+	return changed && (known || suspended)
+}
+
+// suspended returns why the approvals of the channel are suspended, if they
+// are.
+func (a *approvalAccess) suspended(channelID string) (string, bool) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	access := a.channels[channelID]
+	return access.Reason, access.Suspended
+}
+
+func (a *approvalAccess) all() map[string]ApprovalAccess {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	channels := map[string]ApprovalAccess{}
+	for channelID, access := range a.channels {
+		channels[channelID] = access
+	}
+	return channels
+}
+
+// ConfigUpdate checks the approval access of this peer to the channel of the
+// configuration bundle, whenever the configuration of a channel is loaded or
+// updated. The approvals of a channel are suspended while the organization of
+// the approval identity is not a member of the channel or the identity does
+// not satisfy its /Channel/Writers policy, so that they do not fail one by
+// one, and resumed once the access is restored.
+func (s *BloccService) ConfigUpdate(bundle *channelconfig.Bundle) {
+	channelID := bundle.ConfigtxValidator().ChannelID()
+	signer, err := blocc.ApprovalSigner(s.config.CryptoProvider)
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to check the approval access to channel %s: %s", channelID, err)
+		return
+	}
+	serializedIdentity, err := signer.Serialize()
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to check the approval access to channel %s: %s", channelID, err)
+		return
+	}
+
+	reason := ""
+	if err := checkApprovalAccess(bundle, serializedIdentity); err != nil {
+		reason = err.Error()
+	}
+	s.updateApprovalAccess(channelID, bundle.ConfigtxValidator().Sequence(), reason)
+}
+
+func (s *BloccService) updateApprovalAccess(channelID string, sequence uint64, reason string) {
+	if !s.approvalAccess.update(channelID, sequence, reason) {
+		return
+	}
+
+	e := event.Event{Type: event.ApprovalsResumed, ChannelID: channelID}
+	if reason != "" {
+		bloccProtoLogger.Warningf("Suspending the approvals of channel %s: %s", channelID, reason)
+		s.metrics.ApprovalsSuspended.With(ChannelLabel, channelID).Set(1)
+		e = event.Event{Type: event.ApprovalsSuspended, ChannelID: channelID, Reason: reason}
+	} else {
+		bloccProtoLogger.Infof("Resuming the approvals of channel %s", channelID)
+		s.metrics.ApprovalsSuspended.With(ChannelLabel, channelID).Set(0)
+	}
+	s.eventBus.Publish(e)
+}
+
+// checkApprovalAccess returns why the approval identity may not write to the
+// channel of the configuration bundle, if it may not.
+func checkApprovalAccess(bundle channelconfig.Resources, serializedIdentity []byte) error {
+	sid := &mspproto.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sid); err != nil {
+		return errors.Wrap(err, "failed to unmarshal the approval identity")
+	}
+
+	application, ok := bundle.ApplicationConfig()
+	if !ok {
+		return errors.New("channel has no application configuration")
+	}
+	member := false
+	for _, org := range application.Organizations() {
+		if org.MSPID() == sid.Mspid {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return errors.Errorf("organization %s is not a member of the channel", sid.Mspid)
+	}
+
+	identity, err := bundle.MSPManager().DeserializeIdentity(serializedIdentity)
+	if err != nil {
+		return errors.WithMessagef(err, "approval identity of %s is not valid on the channel", sid.Mspid)
+	}
+	if writers, ok := bundle.PolicyManager().GetPolicy(policies.ChannelWriters); ok {
+		if err := writers.EvaluateIdentities([]msp.Identity{identity}); err != nil {
+			return errors.WithMessagef(err, "approval identity of %s does not satisfy the %s policy", sid.Mspid, policies.ChannelWriters)
+		}
+	}
+	return nil
+}
+
+// GetApprovalAccess returns the JSON encoded ApprovalAccess of every channel
+// whose configuration was checked, keyed by channel.
+func (bscc *BSCC) GetApprovalAccess() pb.Response {
+	accessBytes, err := json.Marshal(bscc.approvalAccess.all())
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal approval access: %s", err))
+	}
+	return shim.Success(accessBytes)
+}
@@ -0,0 +1,101 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMSPManager struct {
+	msp.MSPManager
+	err error
+}
+
+func (m *fakeMSPManager) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	return nil, m.err
+}
+
+func TestCheckApprovalAccess(t *testing.T) {
+	identity := protoutil.MarshalOrPanic(&mspproto.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("peer0")})
+	writers := &fakePolicy{}
+	mspManager := &fakeMSPManager{}
+	application := &fakeApplication{orgs: map[string]channelconfig.ApplicationOrg{
+		"Org1": &fakeApplicationOrg{mspID: "Org1MSP"},
+		"Org2": &fakeApplicationOrg{mspID: "Org2MSP"},
+	}}
+	resources := &fakeChannelResources{
+		application: application,
+		policies:    &fakePolicyManager{policies: map[string]policies.Policy{policies.ChannelWriters: writers}},
+		mspManager:  mspManager,
+	}
+	require.NoError(t, checkApprovalAccess(resources, identity))
+
+	writers.err = errors.New("signature set did not satisfy policy")
+	require.EqualError(t, checkApprovalAccess(resources, identity), "approval identity of Org1MSP does not satisfy the /Channel/Writers policy: signature set did not satisfy policy")
+
+	mspManager.err = errors.New("MSP Org1MSP is unknown")
+	require.EqualError(t, checkApprovalAccess(resources, identity), "approval identity of Org1MSP is not valid on the channel: MSP Org1MSP is unknown")
+
+	delete(application.orgs, "Org1")
+	require.EqualError(t, checkApprovalAccess(resources, identity), "organization Org1MSP is not a member of the channel")
+}
+
+func TestUpdateApprovalAccess(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bscc := newTestBSCCWithClock(&mock.PeerInfoProvider{}, clock)
+	events := bscc.eventBus.Subscribe("test")
+	defer bscc.eventBus.Unsubscribe(events)
+	expectEvent := func(expected event.Event) {
+		select {
+		case e := <-events:
+			require.Equal(t, expected, e)
+		case <-time.After(time.Second):
+			t.Fatalf("expected a %s event", expected.Type)
+		}
+	}
+
+	// the channels with access are not reported when first checked
+	bscc.updateApprovalAccess("mychannel", 1, "")
+	_, suspended := bscc.approvalAccess.suspended("mychannel")
+	require.False(t, suspended)
+
+	clock.Increment(time.Minute)
+	bscc.updateApprovalAccess("mychannel", 2, "organization Org1MSP is not a member of the channel")
+	reason, suspended := bscc.approvalAccess.suspended("mychannel")
+	require.True(t, suspended)
+	require.Equal(t, "organization Org1MSP is not a member of the channel", reason)
+	expectEvent(event.Event{Type: event.ApprovalsSuspended, ChannelID: "mychannel", Reason: reason})
+
+	// approvals of suspended channels are skipped
+	require.NoError(t, bscc.processEvent(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx1"}))
+
+	resp := bscc.GetApprovalAccess()
+	require.Equal(t, int32(200), resp.Status, resp.Message)
+	access := map[string]ApprovalAccess{}
+	require.NoError(t, json.Unmarshal(resp.Payload, &access))
+	require.True(t, access["mychannel"].Suspended)
+	require.Equal(t, reason, access["mychannel"].Reason)
+	require.Equal(t, uint64(2), access["mychannel"].ConfigSequence)
+	require.True(t, clock.Now().Equal(access["mychannel"].Since))
+
+	bscc.updateApprovalAccess("mychannel", 3, "")
+	_, suspended = bscc.approvalAccess.suspended("mychannel")
+	require.False(t, suspended)
+	expectEvent(event.Event{Type: event.ApprovalsResumed, ChannelID: "mychannel"})
+}
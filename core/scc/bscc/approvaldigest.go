@@ -0,0 +1,263 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	// approvalDigestWindow bounds the age of the approvals listed in the
+	// approval digests.
+	approvalDigestWindow = time.Hour
+	// approvalDigestIdleInterval is the interval between two checks for the
+	// approval digests to be enabled.
+	approvalDigestIdleInterval = 30 * time.Second
+)
+
+// ApprovalGossiper gossips the approval digests of this peer to the other
+// peers of a channel.
+type ApprovalGossiper interface {
+	GossipApprovalDigest(channelID string, digest []byte)
+}
+
+// approvalDigest is the digest of the approvals a peer submitted recently on
+// a channel, gossiped as JSON. The organization of the peer is not part of
+// the digest, the receivers taking it from the gossip identity of the
+// sender.
+type approvalDigest struct {
+	// Height is the height of the ledger of the peer when the digest was
+	// built, every listed approval having been submitted before
+	Height uint64 `json:"height"`
+	// SensoryTxIDs are the readings the peer approved or rejected
+	SensoryTxIDs []string `json:"sensoryTxIDs"`
+}
+
+// submittedDecisions records when this peer submitted the approval or
+// rejection of each reading, until it is too old to be listed in the approval
+// digests.
+type submittedDecisions struct {
+	mutex     sync.Mutex
+	submitted map[readingKey]time.Time
+	clock     clock.Clock
+}
+
+func newSubmittedDecisions(clk clock.Clock) *submittedDecisions {
+	return &submittedDecisions{
+		submitted: map[readingKey]time.Time{},
+		clock:     clk,
+	}
+}
+
+// record records the submission of the approval or rejection of the reading.
+func (d *submittedDecisions) record(channelID, sensoryTxID string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.submitted[readingKey{channelID: channelID, sensoryTxID: sensoryTxID}] = d.clock.Now()
+}
+
+// digest returns the readings of each channel decided within
+// approvalDigestWindow but for the last grace, sorted, and forgets the older
+// ones.
+func (d *submittedDecisions) digest(grace time.Duration) map[string][]string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	now := d.clock.Now()
+	readings := map[string][]string{}
+	for key, submittedAt := range d.submitted {
+		age := now.Sub(submittedAt)
+		if age > approvalDigestWindow {
+			delete(d.submitted, key)
+			continue
+		}
+		if age < grace {
+			continue
+		}
+		readings[key.channelID] = append(readings[key.channelID], key.sensoryTxID)
+	}
+	for _, sensoryTxIDs := range readings {
+		sort.Strings(sensoryTxIDs)
+	}
+	return readings
+}
+
+// gossipApprovalDigests periodically gossips the digest of the approvals this
+// peer submitted on each channel. The options are read on every round so
+// that reloaded settings take effect.
+func (s *BloccService) gossipApprovalDigests(stop <-chan struct{}) {
+	for {
+		options := s.currentOptions()
+		if options.ApprovalDigestInterval <= 0 || s.config.Gossip == nil {
+			if !s.sleep(stop, approvalDigestIdleInterval) {
+				return
+			}
+			continue
+		}
+		if !s.sleep(stop, options.ApprovalDigestInterval) {
+			return
+		}
+
+		for channelID, sensoryTxIDs := range s.decisions.digest(options.ApprovalDigestGracePeriod) {
+			s.gossipApprovalDigest(channelID, sensoryTxIDs)
+		}
+	}
+}
+
+func (s *BloccService) gossipApprovalDigest(channelID string, sensoryTxIDs []string) {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return
+	}
+	info, err := ledger.GetBlockchainInfo()
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to get blockchain info for channel %s: %s", channelID, err)
+		return
+	}
+
+	digest, err := json.Marshal(&approvalDigest{Height: info.Height, SensoryTxIDs: sensoryTxIDs})
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to marshal approval digest: %s", err)
+		return
+	}
+	bloccProtoLogger.Debugf("Gossiping the digest of %d approvals on channel %s", len(sensoryTxIDs), channelID)
+	s.config.Gossip.GossipApprovalDigest(channelID, digest)
+}
+
+type lostApprovalKey struct {
+	readingKey
+	mspID string
+}
+
+// lostApprovals tracks the approvals reported as lost, so that an approval
+// listed in several digests is reported once.
+type lostApprovals struct {
+	mutex    sync.Mutex
+	reported map[lostApprovalKey]time.Time
+	clock    clock.Clock
+}
+
+func newLostApprovals(clk clock.Clock) *lostApprovals {
+	return &lostApprovals{
+		reported: map[lostApprovalKey]time.Time{},
+		clock:    clk,
+	}
+}
+
+// report returns whether the approval of the reading by the organization is
+// to be reported as lost, that is whether it was not reported already.
+func (l *lostApprovals) report(channelID, sensoryTxID, mspID string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.clock.Now()
+	for key, reportedAt := range l.reported {
+		if now.Sub(reportedAt) > 2*approvalDigestWindow {
+			delete(l.reported, key)
+		}
+	}
+
+	key := lostApprovalKey{readingKey: readingKey{channelID: channelID, sensoryTxID: sensoryTxID}, mspID: mspID}
+	if _, ok := l.reported[key]; ok {
+		return false
+	}
+	l.reported[key] = now
+	return true
+}
+
+// missingDecisions returns the readings of the digest the organization
+// neither approved nor rejected according to the ledger of this peer. No
+// reading is missing if the ledger is behind the one of the sender of the
+// digest.
+func (s *BloccService) missingDecisions(channelID, mspID string, digest *approvalDigest) ([]string, error) {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return nil, bloccerrors.WithCategory(errors.Errorf("channel %s not found", channelID), bloccerrors.ErrChannelNotFound)
+	}
+	info, err := ledger.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get blockchain info for channel %s", channelID)
+	}
+	if info.Height < digest.Height {
+		return nil, nil
+	}
+
+	qe, err := ledger.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create query executor")
+	}
+	defer qe.Done()
+
+	var missing []string
+	for _, sensoryTxID := range digest.SensoryTxIDs {
+		decided := false
+		for _, objectType := range []string{approvalObjectType, rejectionObjectType} {
+			key, err := shim.CreateCompositeKey(objectType, []string{sensoryTxID, mspID})
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed to create %s key", objectType)
+			}
+			value, err := qe.GetState("bscc", key)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed to get %s of reading %s", objectType, sensoryTxID)
+			}
+			if value != nil {
+				decided = true
+				break
+			}
+		}
+		if !decided {
+			missing = append(missing, sensoryTxID)
+		}
+	}
+	return missing, nil
+}
+
+// reconcileApprovals compares the approval digests gossiped by the other
+// peers with the ledger of this peer, and publishes an ApprovalLost event for
+// every listed approval missing from the chain, e.g. lost between the peer
+// and the orderer.
+func (s *BloccService) reconcileApprovals(events <-chan event.Event) {
+	for e := range events {
+		if e.Type != event.ApprovalDigestReceived {
+			continue
+		}
+
+		digest := &approvalDigest{}
+		if err := json.Unmarshal(e.Digest, digest); err != nil {
+			bloccProtoLogger.Warningf("Failed to unmarshal approval digest of %s on channel %s: %s", e.MSPID, e.ChannelID, err)
+			continue
+		}
+		missing, err := s.missingDecisions(e.ChannelID, e.MSPID, digest)
+		if err != nil {
+			bloccProtoLogger.Warningf("Failed to reconcile the approval digest of %s on channel %s: %s", e.MSPID, e.ChannelID, err)
+			continue
+		}
+
+		for _, sensoryTxID := range missing {
+			if !s.lostApprovals.report(e.ChannelID, sensoryTxID, e.MSPID) {
+				continue
+			}
+			bloccProtoLogger.Warningf("Approval of reading %s by %s on channel %s is missing from the chain", sensoryTxID, e.MSPID, e.ChannelID)
+			s.metrics.LostApprovals.With(ChannelLabel, e.ChannelID, OrgLabel, e.MSPID).Add(1)
+			s.eventBus.Publish(event.Event{
+				Type:        event.ApprovalLost,
+				ChannelID:   e.ChannelID,
+				SensoryTxID: sensoryTxID,
+				MSPID:       e.MSPID,
+			})
+		}
+	}
+}
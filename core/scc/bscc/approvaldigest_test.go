@@ -0,0 +1,58 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmittedDecisionsDigest(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	decisions := newSubmittedDecisions(clock)
+
+	decisions.record("mychannel", "tx2")
+	decisions.record("mychannel", "tx1")
+	decisions.record("otherchannel", "tx3")
+	clock.Increment(time.Minute)
+	decisions.record("mychannel", "tx4")
+
+	// the decisions submitted within the grace period are left out
+	require.Equal(t, map[string][]string{
+		"mychannel":    {"tx1", "tx2"},
+		"otherchannel": {"tx3"},
+	}, decisions.digest(time.Minute))
+	require.Equal(t, map[string][]string{
+		"mychannel":    {"tx1", "tx2", "tx4"},
+		"otherchannel": {"tx3"},
+	}, decisions.digest(0))
+
+	// a decision submitted again is listed from its last submission
+	decisions.record("otherchannel", "tx3")
+	clock.Increment(approvalDigestWindow)
+	require.Equal(t, map[string][]string{
+		"mychannel":    {"tx4"},
+		"otherchannel": {"tx3"},
+	}, decisions.digest(0))
+	require.Len(t, decisions.submitted, 2)
+}
+
+func TestLostApprovals(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	lost := newLostApprovals(clock)
+
+	require.True(t, lost.report("mychannel", "tx1", "Org1MSP"))
+	require.False(t, lost.report("mychannel", "tx1", "Org1MSP"))
+	require.True(t, lost.report("mychannel", "tx1", "Org2MSP"))
+	require.True(t, lost.report("otherchannel", "tx1", "Org1MSP"))
+
+	clock.Increment(2*approvalDigestWindow + time.Second)
+	require.True(t, lost.report("mychannel", "tx1", "Org1MSP"))
+	require.Len(t, lost.reported, 1)
+}
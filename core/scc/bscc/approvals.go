@@ -0,0 +1,373 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// approvalObjectType is the composite key object type of approval records,
+// keyed by the sensory transaction ID and the approving MSP ID.
+const approvalObjectType = "approval"
+
+// metadataTransientKey is the transient field holding the JSON encoded
+// metadata of an approval, which is kept out of the public args so that the
+// metadata of private approvals never lands in a block.
+const metadataTransientKey = "metadata"
+
+// ApprovalRecord is the state entry written by BSCC for every approval of a
+// sensory reading.
+type ApprovalRecord struct {
+	// DocType is the approval object type, used to select approvals in rich queries
+	DocType      string `json:"docType"`
+	SensoryTxID  string `json:"sensoryTxID"`
+	ApprovalTxID string `json:"approvalTxID"`
+	MSPID        string `json:"mspID"`
+	// Timestamp is the time at which the approving peer received the reading
+	Timestamp int64 `json:"timestamp"`
+	// SensorTimestamp is the reading timestamp reported by the sensor
+	SensorTimestamp int64 `json:"sensorTimestamp"`
+	// ClockSkew is Timestamp minus SensorTimestamp, in seconds
+	ClockSkew int64 `json:"clockSkew"`
+	// ClockSkewExceeded flags readings whose clock skew exceeds the maximum
+	// configured on the approving peer
+	ClockSkewExceeded bool              `json:"clockSkewExceeded,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	// MetadataHash is the hex encoded SHA-256 hash of the JSON encoded
+	// metadata of a private record, which is stored in place of the metadata.
+	// Like the hashes of private data, it is not salted: metadata taking few
+	// values should include a random nonce entry to not be guessed.
+	MetadataHash string `json:"metadataHash,omitempty"`
+	// Private records hold the hash of their metadata rather than the
+	// metadata, and are returned redacted to the organizations other than the
+	// approving one. The approving organization and the approval transaction
+	// are still disclosed by the record key and by the approval transaction
+	// in the blocks of the channel.
+	Private bool `json:"private,omitempty"`
+	// Redacted is set on the private records returned to other organizations
+	// than the approving one, stripped of the approving organization, the
+	// approval transaction and the metadata
+	Redacted bool `json:"redacted,omitempty"`
+	// Quality is the quality score of the reading at approval time
+	Quality *QualityScore `json:"quality,omitempty"`
+	// FirmwareVersion is the firmware version attested for the sensor at the
+	// time of the reading, empty if none was attested
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+}
+
+// redactedFor returns the record as disclosed to the organization mspID.
+func (r *ApprovalRecord) redactedFor(mspID string) *ApprovalRecord {
+	if !r.Private || r.MSPID == mspID {
+		return r
+	}
+	redacted := *r
+	redacted.ApprovalTxID = ""
+	redacted.MSPID = ""
+	redacted.Metadata = nil
+	redacted.MetadataHash = ""
+	redacted.Redacted = true
+	return &redacted
+}
+
+// ApproveSensoryReading records the approval of the creator's organization for
+// the sensory transaction in args[0]. The metadata transient field, or args[1]
+// for older peers, holds the JSON encoded metadata configured on the approving
+// peer, if any. A rejection record is
+// written instead if the reading fails validation. The message of the
+// response is the consistency token of the record written, on which queries
+// can wait to read it.
+func (bscc *BSCC) ApproveSensoryReading(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	approveArgs := &lb.ApproveSensoryTxArgs{}
+	if err := proto.Unmarshal(args[0], approveArgs); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to unmarshal approval arguments: %s", err))
+	}
+	if approveArgs.TxId == "" {
+		return shim.Error("TxID not specified")
+	}
+
+	transient, err := stub.GetTransient()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transient data: %s", err))
+	}
+	metadataBytes := transient[metadataTransientKey]
+	if len(metadataBytes) == 0 && len(args) > 1 {
+		metadataBytes = args[1]
+	}
+	var metadata map[string]string
+	if len(metadataBytes) > 0 {
+		if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+			return shim.Error(fmt.Sprintf("Failed to unmarshal approval metadata: %s", err))
+		}
+	}
+
+	mspID, err := approvingMSPID(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	timestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+	}
+
+	envelope, err := bscc.validateReading(stub, approveArgs.TxId)
+	var attestation *TimestampAttestation
+	var last *lastReading
+	if err == nil {
+		attestation, err = attestTimestamp(envelope, timestamp.GetSeconds(), bscc.currentOptions().MaxClockSkew)
+	}
+	if err == nil {
+		last, err = checkValidationPolicy(stub, approveArgs.TxId, mspID, envelope)
+	}
+	if err == nil {
+		err = bscc.verifyPayloadReference(envelope)
+	}
+	var quality *QualityScore
+	if err == nil {
+		quality, err = scoreReading(stub, mspID, envelope, attestation, bscc.currentOptions().MaxClockSkew)
+	}
+	if err != nil {
+		rejection, ok := err.(*Rejection)
+		if !ok {
+			return shim.Error(fmt.Sprintf("Failed to validate reading %s: %s", approveArgs.TxId, err))
+		}
+
+		bloccProtoLogger.Warningf("Rejecting reading %s: %s", approveArgs.TxId, rejection)
+		err = storeRejection(stub, &RejectionRecord{
+			SensoryTxID:   approveArgs.TxId,
+			RejectionTxID: stub.GetTxID(),
+			MSPID:         mspID,
+			Timestamp:     timestamp.GetSeconds(),
+			Reason:        rejection.Reason,
+			Message:       rejection.Message,
+		})
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if envelope != nil {
+			// the readings of unidentifiable sensors count against no sensor
+			if id, _, err := readingSensor(stub, envelope); err == nil {
+				decision := sensorDecision{}
+				if attestation != nil {
+					decision.timestamp = attestation.SensorTimestamp
+				}
+				if err := countDecision(stub, id, mspID, decision, bscc.currentOptions().SensorSilenceThreshold); err != nil {
+					return shim.Error(err.Error())
+				}
+			}
+		}
+
+		return approvalResponse(stub, approveArgs.TxId, mspID, true)
+	}
+
+	private, err := stubFeatureEnabled(stub, privateApprovalsFlag)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	if attestation.SkewExceeded {
+		bloccProtoLogger.Warningf("Reading %s is skewed by %ds from the peer clock", approveArgs.TxId, attestation.ClockSkew)
+	}
+
+	id, _, err := readingSensor(stub, envelope)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	firmware, err := activeFirmware(stub, id, attestation.SensorTimestamp)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	record := &ApprovalRecord{
+		DocType:           approvalObjectType,
+		SensoryTxID:       approveArgs.TxId,
+		ApprovalTxID:      stub.GetTxID(),
+		MSPID:             mspID,
+		Timestamp:         attestation.ReceiptTimestamp,
+		SensorTimestamp:   attestation.SensorTimestamp,
+		ClockSkew:         attestation.ClockSkew,
+		ClockSkewExceeded: attestation.SkewExceeded,
+		Metadata:          metadata,
+		Private:           private,
+		Quality:           quality,
+	}
+	if firmware != nil {
+		record.FirmwareVersion = firmware.Version
+	}
+	if private && len(metadata) > 0 {
+		// json.Marshal sorts the keys, so the hash does not depend on the
+		// encoding of the metadata by the approving peer
+		metadataBytes, err := json.Marshal(metadata)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to marshal approval metadata: %s", err))
+		}
+		hash := sha256.Sum256(metadataBytes)
+		record.Metadata = nil
+		record.MetadataHash = hex.EncodeToString(hash[:])
+	}
+
+	key, err := stub.CreateCompositeKey(approvalObjectType, []string{record.SensoryTxID, record.MSPID})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to create approval key: %s", err))
+	}
+
+	recordBytes, err := marshalState(record)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal approval record: %s", err))
+	}
+
+	if err := stub.PutState(key, recordBytes); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to store approval record: %s", err))
+	}
+	if last != nil {
+		if err := storeLastReading(stub, mspID, last); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+	if err := indexMetrics(stub, record.SensoryTxID, mspID, envelope); err != nil {
+		return shim.Error(err.Error())
+	}
+	decision := sensorDecision{approved: true, anomaly: quality.Anomaly, timestamp: attestation.SensorTimestamp}
+	if err := countDecision(stub, id, mspID, decision, bscc.currentOptions().SensorSilenceThreshold); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return approvalResponse(stub, record.SensoryTxID, mspID, false)
+}
+
+// approvalResponse returns the response of a successful approval of the
+// reading, whose message is the consistency token of the record written.
+func approvalResponse(stub shim.ChaincodeStubInterface, sensoryTxID, mspID string, rejection bool) pb.Response {
+	token := &protoutil.ConsistencyToken{
+		TxID:        stub.GetTxID(),
+		SensoryTxID: sensoryTxID,
+		MSPID:       mspID,
+		Rejection:   rejection,
+	}
+	return pb.Response{
+		Status:  shim.OK,
+		Message: token.Encode(),
+		Payload: []byte(sensoryTxID),
+	}
+}
+
+// QueryApprovals returns a page of the approval records of the sensory
+// transaction in args[0], or of all sensory transactions if it is empty.
+// args[1] and args[2] are the optional page size and bookmark, the bookmarks
+// being offsets so that they disclose no approving organization. The
+// remaining args are key=value filters which must all match the metadata of a
+// record: private records, whose metadata is not stored, match none.
+func (bscc *BSCC) QueryApprovals(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	var attributes []string
+	if len(args[0]) > 0 {
+		attributes = []string{string(args[0])}
+	}
+
+	req, err := parsePageRequest(args[1:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	filters := map[string]string{}
+	if len(args) > 3 {
+		for _, arg := range args[3:] {
+			kv := strings.SplitN(string(arg), "=", 2)
+			if len(kv) != 2 {
+				return shim.Error(fmt.Sprintf("Invalid metadata filter '%s', expected key=value", string(arg)))
+			}
+			filters[kv[0]] = kv[1]
+		}
+	}
+
+	// private records of other organizations are redacted, and the records
+	// of an unknown caller are all redacted
+	callerMSPID, _ := creatorMSPID(stub)
+
+	// Initialise to an empty array
+	records := make([]*ApprovalRecord, 0)
+	bookmark, err := iterateOffsetPage(req, partialKeyQuery(stub, approvalObjectType, attributes), func(key string, value []byte) error {
+		record := &ApprovalRecord{}
+		if err := json.Unmarshal(value, record); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal approval record %s", key)
+		}
+		record = record.redactedFor(callerMSPID)
+
+		if matchesMetadata(record, filters) {
+			records = append(records, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query approval records: %s", err))
+	}
+
+	jsonResponse, err := json.Marshal(&Page{Records: records, Bookmark: bookmark})
+	if err != nil {
+		errMsg := fmt.Sprintf("BLOCC: Failed to marshal the result to JSON, error %s", err)
+		bloccProtoLogger.Error(errMsg)
+		return shim.Error(errMsg)
+	}
+
+	return shim.Success(jsonResponse)
+}
+
+func matchesMetadata(record *ApprovalRecord, filters map[string]string) bool {
+	for k, v := range filters {
+		if record.Metadata[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func creatorMSPID(stub shim.ChaincodeStubInterface) (string, error) {
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to get creator")
+	}
+
+	serializedIdentity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, serializedIdentity); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal creator identity")
+	}
+
+	return serializedIdentity.Mspid, nil
+}
+
+// approvingMSPID returns the organization an approval of the creator of the
+// transaction is recorded for: the organizational unit disclosed by an
+// anonymous idemix creator, which is the MSP ID of the organization it
+// approves for, or the MSP ID of any other creator. The validator only
+// accepts an idemix creator whose MSP and organizational unit satisfy the
+// anonymous approval policy of the channel.
+func approvingMSPID(stub shim.ChaincodeStubInterface) (string, error) {
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to get creator")
+	}
+
+	serializedIdentity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, serializedIdentity); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal creator identity")
+	}
+	if ou, ok := protoutil.IdemixOrganizationalUnit(serializedIdentity.IdBytes); ok {
+		return ou, nil
+	}
+
+	return serializedIdentity.Mspid, nil
+}
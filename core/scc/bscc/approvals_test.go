@@ -0,0 +1,164 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// paginatedStub serves the first page of the paginated partial composite
+// key queries, which MockStub does not implement.
+type paginatedStub struct {
+	*shimtest.MockStub
+}
+
+func (s *paginatedStub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if bookmark != "" {
+		return nil, nil, errors.New("bookmarks are not supported")
+	}
+	iter, err := s.GetStateByPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer iter.Close()
+	page := &limitedIterator{}
+	for iter.HasNext() && int32(len(page.kvs)) < pageSize {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, nil, err
+		}
+		page.kvs = append(page.kvs, kv)
+	}
+	return page, &pb.QueryResponseMetadata{FetchedRecordsCount: int32(len(page.kvs))}, nil
+}
+
+type limitedIterator struct {
+	kvs []*queryresult.KV
+}
+
+func (i *limitedIterator) HasNext() bool { return len(i.kvs) > 0 }
+func (i *limitedIterator) Close() error  { return nil }
+
+func (i *limitedIterator) Next() (*queryresult.KV, error) {
+	kv := i.kvs[0]
+	i.kvs = i.kvs[1:]
+	return kv, nil
+}
+
+func TestQueryPrivateApprovals(t *testing.T) {
+	stub := &paginatedStub{MockStub: shimtest.NewMockStub("bscc", nil)}
+	stub.MockTransactionStart("approvals")
+	for _, record := range []*ApprovalRecord{
+		{SensoryTxID: "tx1", ApprovalTxID: "a1", MSPID: "Org1MSP", MetadataHash: "8d7a", Private: true},
+		{SensoryTxID: "tx1", ApprovalTxID: "a2", MSPID: "Org2MSP"},
+		// records written before their metadata was hashed
+		{SensoryTxID: "tx1", ApprovalTxID: "a3", MSPID: "Org3MSP", Metadata: map[string]string{"site": "c"}, Private: true},
+	} {
+		key, err := stub.CreateCompositeKey(approvalObjectType, []string{record.SensoryTxID, record.MSPID})
+		require.NoError(t, err)
+		recordBytes, err := marshalState(record)
+		require.NoError(t, err)
+		require.NoError(t, stub.PutState(key, recordBytes))
+	}
+	stub.MockTransactionEnd("approvals")
+
+	query := func(mspID string, args ...string) ([]*ApprovalRecord, string) {
+		stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: mspID})
+		queryArgs := [][]byte{[]byte("tx1")}
+		for _, arg := range args {
+			queryArgs = append(queryArgs, []byte(arg))
+		}
+		resp := (&BSCC{}).QueryApprovals(stub, queryArgs)
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+		page := struct {
+			Records  []*ApprovalRecord `json:"records"`
+			Bookmark string            `json:"bookmark"`
+		}{}
+		require.NoError(t, json.Unmarshal(resp.Payload, &page))
+		return page.Records, page.Bookmark
+	}
+
+	// the approving organization sees its private records, with the hash of
+	// their metadata
+	records, _ := query("Org1MSP")
+	require.Len(t, records, 3)
+	require.Equal(t, "Org1MSP", records[0].MSPID)
+	require.Equal(t, "8d7a", records[0].MetadataHash)
+	require.Nil(t, records[0].Metadata)
+	require.False(t, records[0].Redacted)
+
+	// other organizations see them redacted
+	records, _ = query("Org2MSP")
+	require.Len(t, records, 3)
+	require.Equal(t, &ApprovalRecord{SensoryTxID: "tx1", Private: true, Redacted: true}, records[0])
+	require.Equal(t, "Org2MSP", records[1].MSPID)
+	require.Equal(t, "a2", records[1].ApprovalTxID)
+	require.Equal(t, &ApprovalRecord{SensoryTxID: "tx1", Private: true, Redacted: true}, records[2])
+
+	// the bookmarks do not disclose the keys of the records
+	records, bookmark := query("Org2MSP", "1")
+	require.Len(t, records, 1)
+	require.Equal(t, "1", bookmark)
+	records, bookmark = query("Org2MSP", "1", bookmark)
+	require.Len(t, records, 1)
+	require.Equal(t, "a2", records[0].ApprovalTxID)
+	require.Equal(t, "2", bookmark)
+	records, bookmark = query("Org2MSP", "2", bookmark)
+	require.Len(t, records, 1)
+	require.True(t, records[0].Redacted)
+	require.Empty(t, bookmark)
+
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org2MSP"})
+	resp := (&BSCC{}).QueryApprovals(stub, [][]byte{[]byte("tx1"), []byte("1"), []byte("\x00approval\x00tx1\x00Org1MSP\x00")})
+	require.Equal(t, int32(500), resp.Status)
+	require.Contains(t, resp.Message, "invalid bookmark")
+}
+
+func TestApprovalResponse(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.MockTransactionStart("approval1")
+	resp := approvalResponse(stub, "tx1", "Org1MSP", false)
+	stub.MockTransactionEnd("approval1")
+
+	require.Equal(t, int32(200), resp.Status)
+	require.Equal(t, []byte("tx1"), resp.Payload)
+	token, err := protoutil.DecodeConsistencyToken(resp.Message)
+	require.NoError(t, err)
+	require.Equal(t, &protoutil.ConsistencyToken{TxID: "approval1", SensoryTxID: "tx1", MSPID: "Org1MSP"}, token)
+}
+
+func TestApprovingMSPID(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("-----BEGIN CERTIFICATE-----")})
+	mspID, err := approvingMSPID(stub)
+	require.NoError(t, err)
+	require.Equal(t, "Org1MSP", mspID)
+
+	// anonymous approvals are recorded for the organization of the credential
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{
+		Mspid: "IdemixMSP",
+		IdBytes: protoutil.MarshalOrPanic(&msp.SerializedIdemixIdentity{
+			Ou:    protoutil.MarshalOrPanic(&msp.OrganizationUnit{MspIdentifier: "IdemixMSP", OrganizationalUnitIdentifier: "Org1MSP"}),
+			Proof: []byte("proof"),
+		}),
+	})
+	mspID, err = approvingMSPID(stub)
+	require.NoError(t, err)
+	require.Equal(t, "Org1MSP", mspID)
+	mspID, err = creatorMSPID(stub)
+	require.NoError(t, err)
+	require.Equal(t, "IdemixMSP", mspID)
+}
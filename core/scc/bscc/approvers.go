@@ -0,0 +1,304 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/pkg/errors"
+)
+
+// approverObjectType is the composite key object type of the approver
+// registrations, keyed by the address of the approving peer and the MSP ID of
+// its organization owning the key.
+const approverObjectType = "approver"
+
+// Approver is the registration of a peer approving the readings of a
+// channel, submitted by the peer itself once it joined the channel. The
+// registered approvers are the approver set expected to approve every
+// reading of the channel.
+type Approver struct {
+	// DocType is the approver object type, used to select approvers in rich
+	// queries
+	DocType     string `json:"docType"`
+	PeerAddress string `json:"peerAddress"`
+	MSPID       string `json:"mspID"`
+	// IdentityHash is the hex encoded SHA-256 hash of the serialized
+	// identity the peer approves readings with
+	IdentityHash string `json:"identityHash"`
+	// Capabilities are the approval capabilities declared by the peer, e.g.
+	// approveOnEndorse, in sorted order
+	Capabilities []string `json:"capabilities"`
+	// TxID and Timestamp are those of the registering transaction
+	TxID      string `json:"txID"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// RegisterApprover registers the creator as the approver of the channel
+// running on the peer at args[0], declaring the capabilities in args[1:].
+// The registration of a peer is only replaced when its identity or
+// capabilities change, by the same organization. The JSON encoded Approver
+// is returned.
+func (bscc *BSCC) RegisterApprover(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return shim.Error("Peer address not specified")
+	}
+	peerAddress := string(args[0])
+
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get creator: %s", err))
+	}
+	mspID, err := approvingMSPID(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	capabilities := []string{}
+	for _, arg := range args[1:] {
+		if len(arg) == 0 {
+			return shim.Error("Empty approver capability")
+		}
+		capabilities = append(capabilities, string(arg))
+	}
+	sort.Strings(capabilities)
+
+	identityHash := sha256.Sum256(creator)
+	approver := &Approver{
+		DocType:      approverObjectType,
+		PeerAddress:  peerAddress,
+		MSPID:        mspID,
+		IdentityHash: hex.EncodeToString(identityHash[:]),
+		Capabilities: capabilities,
+	}
+
+	previous, err := loadApprover(stub, peerAddress)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	switch {
+	case previous == nil:
+	case previous.MSPID != mspID:
+		return shim.Error(fmt.Sprintf("Peer %s is registered as an approver of %s, not %s", peerAddress, previous.MSPID, mspID))
+	case previous.IdentityHash == approver.IdentityHash && reflect.DeepEqual(previous.Capabilities, capabilities):
+		approver = previous
+	}
+
+	if approver != previous {
+		timestamp, err := stub.GetTxTimestamp()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+		}
+		approver.TxID = stub.GetTxID()
+		approver.Timestamp = timestamp.GetSeconds()
+
+		if err := storeApprover(stub, approver); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
+	approverBytes, err := json.Marshal(approver)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal approver: %s", err))
+	}
+	return shim.Success(approverBytes)
+}
+
+// GetApprovers returns the JSON encoded approvers registered on the channel,
+// the expected approver set of its readings, ordered by peer address.
+func (bscc *BSCC) GetApprovers(stub shim.ChaincodeStubInterface) pb.Response {
+	iterator, err := stub.GetStateByPartialCompositeKey(approverObjectType, nil)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query approvers: %s", err))
+	}
+	defer iterator.Close()
+
+	approvers := []*Approver{}
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to query approvers: %s", err))
+		}
+		approver := &Approver{}
+		if err := json.Unmarshal(kv.Value, approver); err != nil {
+			return shim.Error(fmt.Sprintf("Failed to unmarshal approver %s: %s", kv.Key, err))
+		}
+		approvers = append(approvers, approver)
+	}
+
+	approversBytes, err := json.Marshal(approvers)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal approvers: %s", err))
+	}
+	return shim.Success(approversBytes)
+}
+
+// loadApprover returns the registration of the peer, nil if it has none.
+func loadApprover(stub shim.ChaincodeStubInterface, peerAddress string) (*Approver, error) {
+	iterator, err := stub.GetStateByPartialCompositeKey(approverObjectType, []string{peerAddress})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get approver %s", peerAddress)
+	}
+	defer iterator.Close()
+
+	if !iterator.HasNext() {
+		return nil, nil
+	}
+	kv, err := iterator.Next()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get approver %s", peerAddress)
+	}
+	return decodeApprover(peerAddress, kv.Value)
+}
+
+func storeApprover(stub shim.ChaincodeStubInterface, approver *Approver) error {
+	key, err := stub.CreateCompositeKey(approverObjectType, []string{approver.PeerAddress, approver.MSPID})
+	if err != nil {
+		return errors.WithMessage(err, "failed to create approver key")
+	}
+
+	approverBytes, err := marshalState(approver)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approver")
+	}
+
+	if err := stub.PutState(key, approverBytes); err != nil {
+		return errors.WithMessagef(err, "failed to store approver %s", approver.PeerAddress)
+	}
+
+	return nil
+}
+
+// decodeApprover decodes the registration of the peer, nil if it has none.
+func decodeApprover(peerAddress string, approverBytes []byte) (*Approver, error) {
+	if approverBytes == nil {
+		return nil, nil
+	}
+
+	approver := &Approver{}
+	if err := json.Unmarshal(approverBytes, approver); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal approver %s", peerAddress)
+	}
+	return approver, nil
+}
+
+// approverRegistered returns whether this peer is registered as an approver
+// of the channel with its current capabilities, as committed to the ledger
+// of this peer.
+func (s *BloccService) approverRegistered(channelID string, capabilities []string) (bool, error) {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return false, bloccerrors.WithCategory(errors.Errorf("channel %s not found", channelID), bloccerrors.ErrChannelNotFound)
+	}
+
+	startKey, err := shim.CreateCompositeKey(approverObjectType, []string{s.config.PeerAddress})
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to create approver key")
+	}
+
+	qe, err := ledger.NewQueryExecutor()
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to create query executor")
+	}
+	defer qe.Done()
+
+	iterator, err := qe.GetStateRangeScanIterator("bscc", startKey, startKey+string(utf8.MaxRune))
+	if err != nil {
+		return false, errors.WithMessagef(err, "failed to get approver %s", s.config.PeerAddress)
+	}
+	defer iterator.Close()
+	result, err := iterator.Next()
+	if err != nil {
+		return false, errors.WithMessagef(err, "failed to get approver %s", s.config.PeerAddress)
+	}
+	if result == nil {
+		return false, nil
+	}
+	approver, err := decodeApprover(s.config.PeerAddress, result.(*queryresult.KV).Value)
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(approver.Capabilities, capabilities), nil
+}
+
+// registerAsApprover registers this peer as an approver of the channels it
+// approves readings on and is not yet registered on. The channels are
+// enumerated on every round so that joined channels are picked up, and a
+// registration which failed or did not commit is submitted again on the next
+// round.
+func (s *BloccService) registerAsApprover(stop <-chan struct{}) {
+	for {
+		options := s.currentOptions()
+		if options.ApproverRegistrationEnabled {
+			capabilities := options.ApproverCapabilities()
+			for _, channelID := range s.joinedChannels() {
+				if !options.ApprovesChannel(channelID) {
+					continue
+				}
+				if _, suspended := s.approvalAccess.suspended(channelID); suspended {
+					continue
+				}
+				registered, err := s.approverRegistered(channelID, capabilities)
+				if err != nil {
+					bloccProtoLogger.Errorf("Failed to check the approver registration on channel %s: %s", channelID, err)
+					continue
+				}
+				if registered {
+					continue
+				}
+				if err := s.submitApproverRegistration(channelID); err != nil {
+					bloccProtoLogger.Errorf("Failed to register as an approver of channel %s: %s", channelID, err)
+					continue
+				}
+				bloccProtoLogger.Infof("Registered as an approver of channel %s with capabilities %v", channelID, capabilities)
+			}
+		}
+
+		if !s.sleep(stop, options.ApproverRegistrationInterval) {
+			return
+		}
+	}
+}
+
+// submitApproverRegistration submits the registration of this peer as an
+// approver of the channel, signed with the approval identity.
+func (s *BloccService) submitApproverRegistration(channelID string) error {
+	address, rootCertFile, err := s.gatherOrdererInfo(channelID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to gather orderer info")
+	}
+	rootCertFilePath, err := s.createTempFile(rootCertFile)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create temp file")
+	}
+	defer s.removeTempFile(rootCertFilePath)
+
+	registration, err := blocc.NewRegisterApprover(&blocc.RegisterApproverInput{
+		OrdererAddress:   address,
+		RootCertFilePath: rootCertFilePath,
+		ChannelID:        channelID,
+		PeerAddress:      s.config.PeerAddress,
+		TLSRootCertFile:  s.config.TLSCertFile,
+		Pinner:           s.pinner,
+	}, s.currentOptions(), s.config.CryptoProvider)
+	if err != nil {
+		return err
+	}
+
+	return registration.Register()
+}
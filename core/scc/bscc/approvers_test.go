@@ -0,0 +1,81 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterApprover(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("peer0")})
+	bscc := &BSCC{}
+
+	register := func(txID string, args ...string) (*Approver, string) {
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		stub.MockTransactionStart(txID)
+		defer stub.MockTransactionEnd(txID)
+		resp := bscc.RegisterApprover(stub, byteArgs)
+		if resp.Status != 200 {
+			return nil, resp.Message
+		}
+		approver := &Approver{}
+		require.NoError(t, json.Unmarshal(resp.Payload, approver))
+		return approver, ""
+	}
+	get := func() []*Approver {
+		resp := bscc.GetApprovers(stub)
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+		var approvers []*Approver
+		require.NoError(t, json.Unmarshal(resp.Payload, &approvers))
+		return approvers
+	}
+
+	require.Empty(t, get())
+	_, msg := register("tx1")
+	require.Equal(t, "Peer address not specified", msg)
+	_, msg = register("tx1", "peer0.org1:7051", "approve", "")
+	require.Equal(t, "Empty approver capability", msg)
+
+	approver, msg := register("tx1", "peer0.org1:7051", "approveOnEndorse", "approve")
+	require.Empty(t, msg)
+	require.Equal(t, "approver", approver.DocType)
+	require.Equal(t, "peer0.org1:7051", approver.PeerAddress)
+	require.Equal(t, "Org1MSP", approver.MSPID)
+	require.Len(t, approver.IdentityHash, 64)
+	require.Equal(t, []string{"approve", "approveOnEndorse"}, approver.Capabilities)
+	require.Equal(t, "tx1", approver.TxID)
+
+	// the registration is kept as long as the identity and capabilities of
+	// the peer are unchanged
+	again, msg := register("tx2", "peer0.org1:7051", "approve", "approveOnEndorse")
+	require.Empty(t, msg)
+	require.Equal(t, approver, again)
+	updated, msg := register("tx3", "peer0.org1:7051", "approve")
+	require.Empty(t, msg)
+	require.Equal(t, "tx3", updated.TxID)
+	require.Equal(t, []string{"approve"}, updated.Capabilities)
+
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org2MSP", IdBytes: []byte("peer0")})
+	_, msg = register("tx4", "peer0.org1:7051", "approve")
+	require.Equal(t, "Peer peer0.org1:7051 is registered as an approver of Org1MSP, not Org2MSP", msg)
+	_, msg = register("tx5", "peer0.org2:7051", "approve")
+	require.Empty(t, msg)
+
+	approvers := get()
+	require.Len(t, approvers, 2)
+	require.Equal(t, updated, approvers[0])
+	require.Equal(t, "Org2MSP", approvers[1].MSPID)
+}
@@ -0,0 +1,190 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// argKind is the JSON type of a named argument.
+type argKind int
+
+const (
+	stringArg argKind = iota
+	intArg
+	boolArg
+	// stringsArg is a JSON array of strings, passed as the remaining args
+	stringsArg
+	// filtersArg is a JSON object of strings, passed as the remaining args
+	// in key=value form
+	filtersArg
+)
+
+// argField is a named argument of a BSCC function.
+type argField struct {
+	name     string
+	kind     argKind
+	required bool
+}
+
+// namedArgs are the named arguments of the BSCC functions, in positional
+// order. Functions whose first positional arg is itself a JSON document, such
+// as RegisterSensor, are left out so that their callers are never mistaken
+// for named args.
+var namedArgs = map[string][]argField{
+	checkForkStatus:       {{"channelID", stringArg, false}, {"detailed", boolArg, false}},
+	queryApprovals:        {{"txID", stringArg, false}, {"pageSize", intArg, false}, {"bookmark", stringArg, false}, {"metadata", filtersArg, false}},
+	queryRejections:       {{"txID", stringArg, false}, {"pageSize", intArg, false}, {"bookmark", stringArg, false}},
+	getSensor:             {{"sensorID", stringArg, true}},
+	listSensors:           {{"pageSize", intArg, false}, {"bookmark", stringArg, false}},
+	issueSensorToken:      {{"sensorID", stringArg, true}},
+	revokeSensorToken:     {{"sensorID", stringArg, true}},
+	decommissionSensor:    {{"sensorID", stringArg, true}, {"finalTxID", stringArg, true}, {"reason", stringArg, true}},
+	attestSensorFirmware:  {{"sensorID", stringArg, true}, {"version", stringArg, true}, {"hash", stringArg, true}, {"signature", stringArg, true}},
+	registerApprover:      {{"peerAddress", stringArg, true}, {"capabilities", stringsArg, false}},
+	authenticateSensor:    {{"sensorID", stringArg, true}, {"sequence", intArg, false}},
+	getDeliveryReceipt:    {{"txID", stringArg, true}},
+	getReadingProvenance:  {{"txID", stringArg, true}},
+	getDiskUsage:          {{"cleanup", boolArg, false}},
+	getValidationPolicy:   {{"sensorType", stringArg, true}},
+	queryMetricReadings:   {{"metric", stringArg, true}, {"sensorID", stringArg, false}, {"pageSize", intArg, false}, {"bookmark", stringArg, false}},
+	getSensorStats:        {{"sensorIDs", stringsArg, false}},
+	getSensorReliability:  {{"sensorID", stringArg, true}, {"mspID", stringArg, false}},
+	getTransformation:     {{"sensorType", stringArg, true}, {"version", intArg, false}},
+	setFeatureFlag:        {{"name", stringArg, true}, {"enabled", boolArg, true}},
+	getRecentEvents:       {{"limit", intArg, false}},
+	archiveMetricReadings: {{"limit", intArg, false}},
+	getReadingProof:       {{"channelID", stringArg, true}, {"txID", stringArg, true}, {"trustedBlock", intArg, false}},
+	getReading:            {{"channelID", stringArg, true}, {"txID", stringArg, true}},
+	getApprovalWatermark:  {{"channelID", stringArg, true}},
+	getRevalidations:      {{"channelID", stringArg, true}},
+	clearForkStatus:       {{"channelID", stringArg, true}},
+	acknowledgeFork:       {{"channelID", stringArg, true}, {"note", stringArg, false}, {"clear", boolArg, false}},
+}
+
+// checkArgSizes rejects the args of the function fname if one of them is
+// larger than maxSize bytes, before they are decoded. A maxSize of zero
+// leaves the args unbounded.
+func checkArgSizes(fname string, args [][]byte, maxSize int64) error {
+	if maxSize <= 0 {
+		return nil
+	}
+	for i, arg := range args {
+		if int64(len(arg)) > maxSize {
+			return errors.Errorf("Argument %d of %s is %d bytes, exceeding the limit of %d bytes", i+1, fname, len(arg), maxSize)
+		}
+	}
+	return nil
+}
+
+// decodeArgs returns the positional args of the function fname. A single
+// JSON object arg is decoded as the named args of the function, if it has
+// any, and the other args are returned as they are. Absent named args are
+// passed as empty args, which the functions treat as unset.
+func decodeArgs(fname string, args [][]byte) ([][]byte, error) {
+	fields, ok := namedArgs[fname]
+	if !ok || len(args) != 1 || !bytes.HasPrefix(bytes.TrimSpace(args[0]), []byte("{")) {
+		return args, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(args[0]))
+	decoder.UseNumber()
+	named := map[string]json.RawMessage{}
+	if err := decoder.Decode(&named); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode named arguments of %s", fname)
+	}
+
+	declared := map[string]bool{}
+	for _, field := range fields {
+		declared[field.name] = true
+	}
+	for name := range named {
+		if !declared[name] {
+			return nil, errors.Errorf("unknown argument '%s' of %s", name, fname)
+		}
+	}
+
+	var positional [][]byte
+	for _, field := range fields {
+		value, ok := named[field.name]
+		if !ok || string(value) == "null" {
+			if field.required {
+				return nil, errors.Errorf("missing argument '%s' of %s", field.name, fname)
+			}
+			if field.kind != stringsArg && field.kind != filtersArg {
+				positional = append(positional, []byte{})
+			}
+			continue
+		}
+
+		decoded, err := decodeArg(field, value)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid argument '%s' of %s", field.name, fname)
+		}
+		positional = append(positional, decoded...)
+	}
+
+	return positional, nil
+}
+
+func decodeArg(field argField, value json.RawMessage) ([][]byte, error) {
+	switch field.kind {
+	case stringArg:
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return nil, errors.New("expected a string")
+		}
+		return [][]byte{[]byte(s)}, nil
+	case intArg:
+		var n json.Number
+		if err := json.Unmarshal(value, &n); err != nil {
+			return nil, errors.New("expected an integer")
+		}
+		i, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil {
+			return nil, errors.New("expected an integer")
+		}
+		return [][]byte{[]byte(strconv.FormatInt(i, 10))}, nil
+	case boolArg:
+		var b bool
+		if err := json.Unmarshal(value, &b); err != nil {
+			return nil, errors.New("expected a boolean")
+		}
+		return [][]byte{[]byte(strconv.FormatBool(b))}, nil
+	case stringsArg:
+		var ss []string
+		if err := json.Unmarshal(value, &ss); err != nil {
+			return nil, errors.New("expected an array of strings")
+		}
+		var decoded [][]byte
+		for _, s := range ss {
+			decoded = append(decoded, []byte(s))
+		}
+		return decoded, nil
+	case filtersArg:
+		var filters map[string]string
+		if err := json.Unmarshal(value, &filters); err != nil {
+			return nil, errors.New("expected an object of strings")
+		}
+		keys := make([]string, 0, len(filters))
+		for k := range filters {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var decoded [][]byte
+		for _, k := range keys {
+			decoded = append(decoded, []byte(k+"="+filters[k]))
+		}
+		return decoded, nil
+	}
+
+	return nil, errors.Errorf("unknown kind %d", field.kind)
+}
@@ -0,0 +1,85 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/stretchr/testify/require"
+)
+
+func stringArgs(args [][]byte) []string {
+	var s []string
+	for _, arg := range args {
+		s = append(s, string(arg))
+	}
+	return s
+}
+
+func TestDecodeArgs(t *testing.T) {
+	decode := func(fname string, args ...string) ([]string, error) {
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		decoded, err := decodeArgs(fname, byteArgs)
+		return stringArgs(decoded), err
+	}
+
+	// positional args are returned as they are
+	args, err := decode(queryApprovals, "tx1", "10", "", "site=lab")
+	require.NoError(t, err)
+	require.Equal(t, []string{"tx1", "10", "", "site=lab"}, args)
+
+	args, err = decode(queryApprovals, `{"pageSize": 10, "metadata": {"site": "lab", "floor": "2"}}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"", "10", "", "floor=2", "site=lab"}, args)
+
+	args, err = decode(authenticateSensor, `{"sensorID": "sensor1"}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"sensor1", ""}, args)
+
+	args, err = decode(setFeatureFlag, `{"name": "autoApproval", "enabled": false}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"autoApproval", "false"}, args)
+
+	args, err = decode(getSensorStats, `{"sensorIDs": ["sensor1", "sensor2"]}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"sensor1", "sensor2"}, args)
+
+	// JSON documents of functions without named args are left alone
+	args, err = decode(registerSensor, `{"id": "sensor1"}`)
+	require.NoError(t, err)
+	require.Equal(t, []string{`{"id": "sensor1"}`}, args)
+
+	_, err = decode(getSensor, `{"id": "sensor1"}`)
+	require.EqualError(t, err, "unknown argument 'id' of GetSensor")
+	_, err = decode(getSensor, `{}`)
+	require.EqualError(t, err, "missing argument 'sensorID' of GetSensor")
+	_, err = decode(listSensors, `{"pageSize": 2.5}`)
+	require.EqualError(t, err, "invalid argument 'pageSize' of ListSensors: expected an integer")
+	_, err = decode(getDiskUsage, `{"cleanup": "yes"}`)
+	require.EqualError(t, err, "invalid argument 'cleanup' of GetDiskUsage: expected a boolean")
+	_, err = decode(getSensor, `{"sensorID": `)
+	require.Error(t, err)
+}
+
+func TestCheckArgSizes(t *testing.T) {
+	args := [][]byte{[]byte("sensor1"), []byte(`{"id":"sensor1","type":"thermometer"}`)}
+	require.NoError(t, checkArgSizes(registerSensor, args, 0))
+	require.NoError(t, checkArgSizes(registerSensor, args, 64))
+	require.EqualError(t, checkArgSizes(registerSensor, args, 8), "Argument 2 of RegisterSensor is 37 bytes, exceeding the limit of 8 bytes")
+
+	// oversized args are rejected before they are decoded
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+	bscc.options = config.Options{FunctionMaxArgSizes: map[string]int64{registerSensor: 8}}
+	stub := shimtest.NewMockStub("bscc", bscc)
+	resp := stub.MockInvoke("tx1", [][]byte{[]byte(registerSensor), args[1]})
+	require.Equal(t, "Argument 1 of RegisterSensor is 37 bytes, exceeding the limit of 8 bytes", resp.Message)
+}
@@ -0,0 +1,50 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/pkg/errors"
+)
+
+// retryableStatuses are the broadcast statuses returned by orderers that are
+// temporarily unable to order transactions, e.g. while electing a leader.
+// Approvals rejected with any other status would be rejected again.
+var retryableStatuses = map[cb.Status]bool{
+	cb.Status_SERVICE_UNAVAILABLE:   true,
+	cb.Status_INTERNAL_SERVER_ERROR: true,
+}
+
+// broadcastStatus returns the status with which the orderer rejected the
+// approval broadcast, if err carries one.
+func broadcastStatus(err error) (cb.Status, bool) {
+	var statusErr *common.BroadcastStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Status, true
+	}
+	return cb.Status_UNKNOWN, false
+}
+
+// retryable returns whether a failed approval may succeed if attempted
+// again. Failures that did not come from an orderer status, e.g. connection
+// failures, are retryable, unless they are policy violations which would
+// fail again.
+func retryable(err error) bool {
+	if errors.Is(err, bloccerrors.ErrPolicyViolation) {
+		return false
+	}
+	status, ok := broadcastStatus(err)
+	return !ok || retryableStatuses[status]
+}
+
+// countPinMismatch counts the connections refused as the orderer at address
+// presented no certificate matching a pin.
+func (s *BloccService) countPinMismatch(address string) {
+	bloccProtoLogger.Errorf("Orderer %s presented no TLS certificate matching the pinned keys, refusing the connection", address)
+	s.metrics.OrdererPinMismatches.With("orderer", address).Add(1)
+}
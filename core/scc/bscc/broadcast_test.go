@@ -0,0 +1,91 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastStatus(t *testing.T) {
+	err := errors.WithMessage(&common.BroadcastStatusError{Status: cb.Status_BAD_REQUEST, Info: "invalid signature"}, "failed to send transaction")
+	require.EqualError(t, err, "failed to send transaction: got unexpected status: BAD_REQUEST -- invalid signature")
+
+	status, ok := broadcastStatus(err)
+	require.True(t, ok)
+	require.Equal(t, cb.Status_BAD_REQUEST, status)
+
+	_, ok = broadcastStatus(errors.New("could not send to orderer node: EOF"))
+	require.False(t, ok)
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{&common.BroadcastStatusError{Status: cb.Status_SERVICE_UNAVAILABLE}, true},
+		{&common.BroadcastStatusError{Status: cb.Status_INTERNAL_SERVER_ERROR}, true},
+		{&common.BroadcastStatusError{Status: cb.Status_BAD_REQUEST}, false},
+		{&common.BroadcastStatusError{Status: cb.Status_FORBIDDEN}, false},
+		{&common.BroadcastStatusError{Status: cb.Status_NOT_FOUND}, false},
+		{&common.BroadcastStatusError{Status: cb.Status_REQUEST_ENTITY_TOO_LARGE}, false},
+		{errors.New("could not send to orderer node: EOF"), true},
+		{bloccerrors.WithCategory(errors.New("approval would be invalidated on commit"), bloccerrors.ErrPolicyViolation), false},
+	}
+
+	for _, tt := range tests {
+		err := errors.WithMessage(tt.err, "failed to send transaction")
+		require.Equal(t, tt.retryable, retryable(err), err.Error())
+	}
+}
+
+func TestCountPinMismatch(t *testing.T) {
+	counter := &metricsfakes.Counter{}
+	counter.WithReturns(counter)
+	s := &BloccService{metrics: &Metrics{OrdererPinMismatches: counter}}
+
+	s.countPinMismatch("orderer0.example.com:7050")
+	require.Equal(t, 1, counter.AddCallCount())
+	require.Equal(t, []string{"orderer", "orderer0.example.com:7050"}, counter.WithArgsForCall(0))
+}
+
+func TestPinMismatchesPerService(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	pin := base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+
+	var counters []*metricsfakes.Counter
+	var services []*BloccService
+	for i := 0; i < 2; i++ {
+		counter := &metricsfakes.Counter{}
+		counter.WithReturns(counter)
+		s := NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus())
+		s.metrics.OrdererPinMismatches = counter
+		counters = append(counters, counter)
+		services = append(services, s)
+	}
+
+	// the mismatches are counted by the service whose orderer connection
+	// refused the certificate only
+	verify, err := services[0].pinner.Verifier("orderer0.example.com:7050", []string{pin})
+	require.NoError(t, err)
+	require.Error(t, verify([][]byte{ca.CertBytes()}, nil))
+	require.Equal(t, 1, counters[0].AddCallCount())
+	require.Equal(t, 0, counters[1].AddCallCount())
+}
@@ -8,23 +8,25 @@ package bscc
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
+	"strconv"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
-	"github.com/hyperledger/fabric/bccsp"
-	event "github.com/hyperledger/fabric/common/blocc-events"
 	"github.com/hyperledger/fabric/common/flogging"
-	"github.com/hyperledger/fabric/core/peer"
-	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/committer/txvalidator/v20/plugindispatcher"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
 	"github.com/hyperledger/fabric/protoutil"
-	"github.com/pkg/errors"
 )
 
-func New(peerInstance *peer.Peer) *BSCC {
+// New returns the BLOCC system chaincode, answering queries and invocations
+// over the given BLOCC service.
+func New(service *BloccService, aclProvider aclmgmt.ACLProvider) *BSCC {
 	return &BSCC{
-		peerInstance: peerInstance,
+		BloccService: service,
+		aclProvider:  aclProvider,
 	}
 }
 
@@ -36,15 +38,11 @@ func (bscc *BSCC) Chaincode() shim.Chaincode {
 	return bscc
 }
 
+// BSCC is a thin query and invoke façade over the BLOCC service, which runs
+// independently of the chaincode lifecycle.
 type BSCC struct {
-	peerInstance *peer.Peer
-	config       Config
-}
-
-type Config struct {
-	PeerAddress    string
-	TLSCertFile    string
-	CryptoProvider bccsp.BCCSP
+	*BloccService
+	aclProvider aclmgmt.ACLProvider
 }
 
 var bloccProtoLogger = flogging.MustGetLogger("bscc")
@@ -53,6 +51,49 @@ const (
 	approveSensoryReading string = "ApproveSensoryReading"
 	simulateForkAttempt   string = "SimulateForkAttempt"
 	checkForkStatus       string = "CheckForkStatus"
+	queryApprovals        string = "QueryApprovals"
+	registerSensor        string = "RegisterSensor"
+	getSensor             string = "GetSensor"
+	queryRejections       string = "QueryRejections"
+	reloadConfig          string = "ReloadConfig"
+	listSensors           string = "ListSensors"
+	queryApprovalsBySel   string = "QueryApprovalsBySelector"
+	querySensorsBySel     string = "QuerySensorsBySelector"
+	querySensorsInArea    string = "QuerySensorsInArea"
+	getReadingProof       string = "GetReadingProof"
+	getReading            string = "GetReading"
+	issueSensorToken      string = "IssueSensorToken"
+	revokeSensorToken     string = "RevokeSensorToken"
+	authenticateSensor    string = "AuthenticateSensor"
+	getDeliveryReceipt    string = "GetDeliveryReceipt"
+	getDiskUsage          string = "GetDiskUsage"
+	setValidationPolicy   string = "SetValidationPolicy"
+	getValidationPolicy   string = "GetValidationPolicy"
+	queryMetricReadings   string = "QueryMetricReadings"
+	getSensorStats        string = "GetSensorStats"
+	setTransformation     string = "SetTransformation"
+	getTransformation     string = "GetTransformation"
+	setFeatureFlag        string = "SetFeatureFlag"
+	getFeatureFlags       string = "GetFeatureFlags"
+	getRecentEvents       string = "GetRecentEvents"
+	drainApprovals        string = "DrainApprovals"
+	clearForkStatus       string = "ClearForkStatus"
+	acknowledgeFork       string = "AcknowledgeFork"
+	migrateState          string = "MigrateState"
+	decommissionSensor    string = "DecommissionSensor"
+	registerApprover      string = "RegisterApprover"
+	getApprovers          string = "GetApprovers"
+	evaluateReading       string = "EvaluateReading"
+	archiveMetricReadings string = "ArchiveMetricReadings"
+	registerSensors       string = "RegisterSensors"
+	getSensorReliability  string = "GetSensorReliability"
+	getApprovalAccess     string = "GetApprovalAccess"
+	flushPendingApprovals string = "FlushPendingApprovals"
+	getMetadata           string = "GetMetadata"
+	getApprovalWatermark  string = "GetApprovalWatermark"
+	attestSensorFirmware  string = "AttestSensorFirmware"
+	getRevalidations      string = "GetRevalidations"
+	getReadingProvenance  string = "GetReadingProvenance"
 )
 
 // ------------------- Error handling ------------------- //
@@ -65,46 +106,59 @@ func (f InvalidFunctionError) Error() string {
 
 // -------------------- Stub Interface ------------------- //
 
+// Init does nothing, the BLOCC service being started by the peer.
 func (bscc *BSCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
-	bloccProtoLogger.Info("Init BSCC")
-	go func() {
-		for _event := range event.GlobalEventBus.Subscribe() {
-			bscc.processEvent(_event)
-		}
-	}()
+	return shim.Success(nil)
+}
 
-	peerAddress, ok := os.LookupEnv("CORE_PEER_ADDRESS")
-	if !ok {
-		bloccProtoLogger.Error("CORE_PEER_ADDRESS is not set")
-		return shim.Error("CORE_PEER_ADDRESS is not set")
+// Invoke [BLOCC System CC] This function is not allowed for external calls, only internal calls are allowed.
+// The version of the BSCC API told by the client is checked first, so that
+// clients speaking a version this peer does not serve get an explicit error.
+func (bscc *BSCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	transient, err := stub.GetTransient()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get the transient map of the proposal: %s", err))
 	}
-
-	tlsCertFile, ok := os.LookupEnv("CORE_PEER_TLS_ROOTCERT_FILE")
-	if !ok {
-		bloccProtoLogger.Error("CORE_PEER_TLS_ROOTCERT_FILE is not set")
-		return shim.Error("CORE_PEER_TLS_ROOTCERT_FILE is not set")
+	version, err := apiversion.FromTransient(transient)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if !apiversion.Supported(version) {
+		return shim.Error(messages.Sprintf(messages.UnsupportedAPIVersion, version, apiversion.Min, apiversion.Current))
 	}
 
-	bscc.config = Config{
-		PeerAddress:    peerAddress,
-		TLSCertFile:    tlsCertFile,
-		CryptoProvider: bscc.peerInstance.CryptoProvider,
+	response := bscc.invoke(stub)
+	if apiversion.Deprecated(version) && response.Status < shim.ERRORTHRESHOLD {
+		fname, _ := stub.GetFunctionAndParameters()
+		bloccProtoLogger.Warningf("Function %s invoked with the deprecated BSCC API version %d", fname, version)
+		warning := messages.Sprintf(messages.DeprecatedAPIVersion, version, apiversion.Current)
+		if err := stub.SetEvent(apiversion.DeprecationEvent, []byte(warning)); err != nil {
+			bloccProtoLogger.Errorf("Failed to set the deprecation warning of function %s: %s", fname, err)
+		}
 	}
-	return shim.Success(nil)
+	return response
 }
 
-// Invoke [BLOCC System CC] This function is not allowed for external calls, only internal calls are allowed.
-func (bscc *BSCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+func (bscc *BSCC) invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 	var err error
 
 	if len(args) < 2 {
-		return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+		return shim.Error(messages.Sprintf(messages.IncorrectArguments, len(args)))
 	}
 
 	fname := string(args[0])
 	bloccProtoLogger.Infof("Invoke function: %s", fname)
 
+	if err := checkArgSizes(fname, args[1:], bscc.currentOptions().MaxArgSize(fname)); err != nil {
+		return shim.Error(err.Error())
+	}
+	fargs, err := decodeArgs(fname, args[1:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	args = append(args[:1:1], fargs...)
+
 	// Handle ACL:
 	sp, err := stub.GetSignedProposal()
 	if err != nil {
@@ -120,129 +174,327 @@ func (bscc *BSCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return shim.Error(fmt.Sprintf("Rejecting invoke of CSCC from another chaincode, original invocation for '%s'", name))
 	}
 
+	// the BSCC state written by earlier releases is migrated by the first
+	// administrator transaction writing to it
+	if writingFunctions[fname] && plugindispatcher.AdminFunctions[fname] {
+		if _, err := applyStateMigrations(stub); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
 	switch fname {
 	case approveSensoryReading:
-		txID := args[1]
-		bloccProtoLogger.Infof("ApproveSensoryReading for: %s", txID)
-		return shim.Success(txID)
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_ApproveSensoryReading, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		bloccProtoLogger.Infof("ApproveSensoryReading in transaction: %s", stub.GetTxID())
+		return bscc.ApproveSensoryReading(stub, args[1:])
 	case simulateForkAttempt:
 		bloccProtoLogger.Warningf("Adding a fork block!")
 		return shim.Success(nil)
 	case checkForkStatus:
 		bloccProtoLogger.Infof("Checking fork status")
-		return bscc.CheckForkStatus(string(args[1]))
-	}
-
-	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
-}
-
-// ----------------- BSCC Implementation ----------------- //
-
-func (bscc *BSCC) processEvent(event event.Event) {
-	var err error
-	bloccProtoLogger.Info("BLOCC - Received approval event:", event)
-	address, rootCertFile, err := bscc.gatherOrdererInfo(event.ChannelID)
-	if err != nil {
-		bloccProtoLogger.Errorf("Failed to gather orderer info: %s", err)
-		return
-	}
-
-	rootCertFilePath, err := bscc.createTempFile(rootCertFile)
-	if err != nil {
-		bloccProtoLogger.Errorf("Failed to create temp file: %s", err)
-		return
+		var detailed bool
+		if len(args) > 2 && len(args[2]) > 0 {
+			if detailed, err = strconv.ParseBool(string(args[2])); err != nil {
+				return shim.Error(fmt.Sprintf("Invalid detailed flag '%s'", args[2]))
+			}
+		}
+		return bscc.CheckForkStatus(string(args[1]), detailed)
+	case queryApprovals:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_QueryApprovals, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.QueryApprovals(stub, args[1:])
+	case queryRejections:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_QueryRejections, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.QueryRejections(stub, args[1:])
+	case registerSensor:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_RegisterSensor, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.RegisterSensor(stub, args[1:])
+	case registerSensors:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_RegisterSensors, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.RegisterSensors(stub, args[1:])
+	case issueSensorToken:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_IssueSensorToken, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.IssueSensorToken(stub, args[1:])
+	case revokeSensorToken:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_RevokeSensorToken, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.RevokeSensorToken(stub, args[1:])
+	case decommissionSensor:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_DecommissionSensor, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.DecommissionSensor(stub, args[1:])
+	case attestSensorFirmware:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_AttestSensorFirmware, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.AttestSensorFirmware(stub, args[1:])
+	case registerApprover:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_RegisterApprover, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.RegisterApprover(stub, args[1:])
+	case getApprovers:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetApprovers, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetApprovers(stub)
+	case evaluateReading:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_EvaluateReading, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.EvaluateReading(stub, args[1:])
+	case archiveMetricReadings:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_ArchiveMetricReadings, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.ArchiveMetricReadings(stub, args[1:])
+	case authenticateSensor:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_AuthenticateSensor, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.AuthenticateSensor(stub, args[1:])
+	case getDeliveryReceipt:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetDeliveryReceipt, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetDeliveryReceipt(stub, args[1:])
+	case getReadingProvenance:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetReadingProvenance, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetReadingProvenance(stub, args[1:])
+	case getSensor:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetSensor, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetSensor(stub, args[1:])
+	case listSensors:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_ListSensors, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.ListSensors(stub, args[1:])
+	case getSensorStats:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetSensorStats, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetSensorStats(stub, args[1:])
+	case getSensorReliability:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetSensorReliability, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetSensorReliability(stub, args[1:])
+	case setTransformation:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_SetTransformation, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.SetTransformation(stub, args[1:])
+	case getTransformation:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetTransformation, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetTransformation(stub, args[1:])
+	case setFeatureFlag:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_SetFeatureFlag, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.SetFeatureFlag(stub, args[1:])
+	case getFeatureFlags:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetFeatureFlags, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetFeatureFlags(stub)
+	case queryApprovalsBySel:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_QueryApprovals, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.QueryApprovalsBySelector(stub, args[1:])
+	case querySensorsBySel:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_ListSensors, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.QuerySensorsBySelector(stub, args[1:])
+	case querySensorsInArea:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_ListSensors, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.QuerySensorsInArea(stub, args[1:])
+	case getReadingProof:
+		if len(args) < 3 {
+			return shim.Error(messages.Sprintf(messages.IncorrectArguments, len(args)))
+		}
+		channelID := string(args[1])
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetReadingProof, channelID, sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		var trustedBlock string
+		if len(args) > 3 {
+			trustedBlock = string(args[3])
+		}
+		return bscc.GetReadingProof(channelID, string(args[2]), trustedBlock)
+	case getReading:
+		if len(args) < 3 {
+			return shim.Error(messages.Sprintf(messages.IncorrectArguments, len(args)))
+		}
+		channelID := string(args[1])
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetReading, channelID, sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetReading(channelID, string(args[2]))
+	case getApprovalWatermark:
+		channelID := string(args[1])
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetApprovalWatermark, channelID, sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetApprovalWatermark(stub, channelID)
+	case getRevalidations:
+		channelID := string(args[1])
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetRevalidations, channelID, sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetRevalidations(channelID)
+	case drainApprovals:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_DrainApprovals, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.DrainApprovals()
+	case clearForkStatus:
+		channelID := string(args[1])
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_ClearForkStatus, channelID, sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.ClearForkStatus(channelID)
+	case acknowledgeFork:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_AcknowledgeFork, string(args[1]), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.AcknowledgeFork(stub, args[1:])
+	case migrateState:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_MigrateState, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.MigrateState(stub)
+	case getRecentEvents:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetRecentEvents, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetRecentEvents(args[1:])
+	case setValidationPolicy:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_SetValidationPolicy, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.SetValidationPolicy(stub, args[1:])
+	case getValidationPolicy:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetValidationPolicy, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetValidationPolicy(stub, args[1:])
+	case queryMetricReadings:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_QueryMetricReadings, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.QueryMetricReadings(stub, args[1:])
+	case getApprovalAccess:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetApprovalAccess, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetApprovalAccess()
+	case flushPendingApprovals:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_FlushPendingApprovals, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.FlushPendingApprovals()
+	case getMetadata:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetMetadata, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetMetadata()
+	case getDiskUsage:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_GetDiskUsage, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.GetDiskUsage(stub, args[1:])
+	case reloadConfig:
+		if err = bscc.aclProvider.CheckACL(resources.Bscc_ReloadConfig, stub.GetChannelID(), sp); err != nil {
+			return shim.Error(messages.Sprintf(messages.AccessDenied, fname, err))
+		}
+		return bscc.reloadConfig()
 	}
-	defer bscc.removeTempFile(rootCertFilePath)
 
-	err = bscc.approveSensoryReading(address, rootCertFilePath, event)
-	if err != nil {
-		bloccProtoLogger.Errorf("Failed to approve sensory reading: %s", err)
-	}
+	return shim.Error(messages.Sprintf(messages.FunctionNotFound, fname))
 }
 
-func (bscc *BSCC) gatherOrdererInfo(channelID string) (address string, rootCertFile []byte, err error) {
-	_, ordererOrg, err := bscc.peerInstance.GetOrdererInfo(channelID)
-	if err != nil {
-		return "", nil, err
-	}
+// CheckForkStatus returns the ForkStatusResponse of the channel, or the
+// ForkStatusesResponse of every channel this peer has joined if channelID is
+// empty. If detailed is set, the ForkState of the channels is recorded in the
+// responses, telling acknowledged forks apart from new ones. The bare fork
+// status, or ForkState if detailed, is returned instead in the legacy format.
+func (bscc *BSCC) CheckForkStatus(channelID string, detailed bool) pb.Response {
+	options := bscc.currentOptions()
+	ttl := options.ForkStatusCacheTTL
 
-	if len(ordererOrg) == 0 {
-		return "", nil, errors.New("No orderer organization found")
+	var result interface{}
+	if channelID == "" {
+		statuses := map[string]ChannelForkStatus{}
+		for channelID, forked := range bscc.forkStatuses.getAll(bscc.joinedChannels(), ttl) {
+			status, err := bscc.channelForkStatus(channelID, forked, detailed)
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			statuses[channelID] = status
+		}
+		result = &ForkStatusesResponse{Version: ForkStatusVersion, Channels: statuses}
+		if options.ForkStatusLegacyFormat {
+			result = legacyForkStatuses(statuses, detailed)
+		}
 	} else {
-		for _, orderer := range ordererOrg {
-			// TODO: This is a hack, we should not assume that the orderer has only one address and one root cert.
-			// To be checked against multiple orderers.
-			return orderer.Addresses[0], orderer.RootCerts[0], nil
+		status, err := bscc.channelForkStatus(channelID, bscc.forkStatuses.get(channelID, ttl), detailed)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		result = &ForkStatusResponse{Version: ForkStatusVersion, ChannelForkStatus: status}
+		if options.ForkStatusLegacyFormat {
+			result = status.legacy(detailed)
 		}
 	}
 
-	return "", nil, errors.New("Error occurred gathering orderer info")
-}
-
-func (bscc *BSCC) createTempFile(rootCertFile []byte) (string, error) {
-	tempFile, err := ioutil.TempFile("", "rootCertFile")
-	if err != nil {
-		return "", err
-	}
-
-	_, err = tempFile.Write(rootCertFile)
-	if err != nil {
-		return "", err
-	}
-
-	err = tempFile.Close()
+	jsonResponse, err := json.Marshal(result)
 	if err != nil {
-		return "", err
-	}
-
-	return tempFile.Name(), nil
-}
-
-func (bscc *BSCC) removeTempFile(filePath string) {
-	if err := os.Remove(filePath); err != nil {
-		bloccProtoLogger.Errorf("Failed to remove temp file: %s", err)
+		errMsg := fmt.Sprintf("BLOCC: Failed to marshal the result to JSON, error %s", err)
+		bloccProtoLogger.Error(errMsg)
+		return shim.Error(errMsg)
 	}
-}
 
-func (bscc *BSCC) approveSensoryReading(address, rootCertFilePath string, event event.Event) error {
-	approveForThisPeerCmd := blocc.ApproveForThisPeerCmd(nil, bscc.config.CryptoProvider)
-	approveForThisPeerCmd.SetArgs([]string{
-		"--ordererAddress=" + address,
-		"--rootCertFilePath=" + rootCertFilePath,
-		"--channelID=" + event.ChannelID,
-		"--txID=" + event.SensoryTxID,
-		"--peerAddress=" + bscc.config.PeerAddress,
-		"--tlsRootCertFile=" + bscc.config.TLSCertFile,
-	})
-	err := approveForThisPeerCmd.Execute()
-	approveForThisPeerCmd.ResetFlags()
-
-	return err
+	return shim.Success(jsonResponse)
 }
 
-func (bscc *BSCC) CheckForkStatus(channelID string) pb.Response {
+// ClearForkStatus removes the fork information of the channel, e.g. after a
+// SimulateForkAttempt experiment, returning whether there was any.
+func (bscc *BSCC) ClearForkStatus(channelID string) pb.Response {
 	if channelID == "" {
-		return shim.Error("ChannelID not specified")
+		return shim.Error(messages.Sprintf(messages.ChannelIDRequired))
 	}
 
-	var err error
-	var isForked bool
-
-	// Define the filename based on the channel ID
-	filename := fmt.Sprintf("/var/hyperledger/production/ledgersData/chains/chains/%s/fork_info.txt", channelID)
-
-	// Check if the file exists
-	if _, err = os.Stat(filename); os.IsNotExist(err) {
-		isForked = false
-	} else {
-		isForked = true
+	cleared, err := bscc.clearForkInfo(channelID)
+	if err != nil {
+		return shim.Error(err.Error())
 	}
 
-	jsonResponse, err := json.Marshal(isForked)
+	jsonResponse, err := json.Marshal(cleared)
 	if err != nil {
-		errMsg := fmt.Sprintf("BLOCC: Failed to marshal the result to JSON, error %s", err)
-		bloccProtoLogger.Error(errMsg)
-		return shim.Error(errMsg)
+		return shim.Error(fmt.Sprintf("BLOCC: Failed to marshal the result to JSON, error %s", err))
 	}
 
 	return shim.Success(jsonResponse)
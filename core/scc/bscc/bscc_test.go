@@ -0,0 +1,209 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate counterfeiter -o mock/peer_info_provider.go --fake-name PeerInfoProvider . peerInfoProvider
+type peerInfoProvider interface {
+	PeerInfoProvider
+}
+
+func newTestBSCC(peerInfo *mock.PeerInfoProvider) *BSCC {
+	return New(NewBloccService(peerInfo, &disabled.Provider{}, event.NewEventBus()), nil)
+}
+
+// newTestBSCCWithClock returns a BSCC whose components tell the time of clk.
+func newTestBSCCWithClock(peerInfo *mock.PeerInfoProvider, clk clock.Clock) *BSCC {
+	return New(newBloccService(peerInfo, &disabled.Provider{}, event.NewEventBus(), clk), nil)
+}
+
+// sleepRecorder is a fake clock recording the durations slept, sleeping
+// advancing the clock instead of blocking.
+type sleepRecorder struct {
+	*fakeclock.FakeClock
+	mutex  sync.Mutex
+	sleeps []time.Duration
+}
+
+func newSleepRecorder() *sleepRecorder {
+	return &sleepRecorder{FakeClock: fakeclock.NewFakeClock(time.Unix(1700000000, 0))}
+}
+
+func (c *sleepRecorder) Sleep(d time.Duration) {
+	c.mutex.Lock()
+	c.sleeps = append(c.sleeps, d)
+	c.mutex.Unlock()
+	c.Increment(d)
+}
+
+func (c *sleepRecorder) slept() []time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]time.Duration(nil), c.sleeps...)
+}
+
+func TestGatherOrdererInfo(t *testing.T) {
+	peerInfo := &mock.PeerInfoProvider{}
+	peerInfo.GetOrdererInfoReturns(nil, map[string]orderers.OrdererOrg{
+		"OrdererOrg": {
+			Addresses: []string{"orderer.example.com:7050"},
+			RootCerts: [][]byte{[]byte("root-cert")},
+		},
+	}, nil)
+	bscc := newTestBSCC(peerInfo)
+
+	address, rootCert, err := bscc.gatherOrdererInfo("mychannel")
+	require.NoError(t, err)
+	require.Equal(t, "orderer.example.com:7050", address)
+	require.Equal(t, []byte("root-cert"), rootCert)
+	require.Equal(t, "mychannel", peerInfo.GetOrdererInfoArgsForCall(0))
+
+	peerInfo.GetOrdererInfoReturns(nil, nil, nil)
+	_, _, err = bscc.gatherOrdererInfo("mychannel")
+	require.EqualError(t, err, "No orderer organization found")
+
+	peerInfo.GetOrdererInfoReturns(nil, nil, errors.New("channel mychannel not found"))
+	_, _, err = bscc.gatherOrdererInfo("mychannel")
+	require.EqualError(t, err, "channel mychannel not found")
+}
+
+func TestGatherBFTOrdererInfo(t *testing.T) {
+	peerInfo := &mock.PeerInfoProvider{}
+	peerInfo.GetOrdererInfoReturns(nil, map[string]orderers.OrdererOrg{
+		"OrdererOrg2": {
+			Addresses: []string{"orderer2.example.com:7050", "orderer3.example.com:7050"},
+			RootCerts: [][]byte{[]byte("root-cert2")},
+		},
+		"OrdererOrg1": {
+			Addresses: []string{"orderer0.example.com:7050", "orderer1.example.com:7050"},
+			RootCerts: [][]byte{[]byte("root-cert1")},
+		},
+	}, nil)
+	bscc := newTestBSCC(peerInfo)
+
+	// channels not ordered by a BFT ordering service
+	endpoints, _, err := bscc.gatherBFTOrdererInfo("mychannel")
+	require.NoError(t, err)
+	require.Nil(t, endpoints)
+	resources := &fakeChannelResources{orderer: &fakeOrdererConfig{consensusType: "etcdraft"}}
+	peerInfo.GetChannelResourcesReturns(resources)
+	endpoints, _, err = bscc.gatherBFTOrdererInfo("mychannel")
+	require.NoError(t, err)
+	require.Nil(t, endpoints)
+
+	resources.orderer = &fakeOrdererConfig{consensusType: "BFT"}
+	endpoints, rootCerts, err := bscc.gatherBFTOrdererInfo("mychannel")
+	require.NoError(t, err)
+	require.Equal(t, []string{"orderer0.example.com:7050", "orderer1.example.com:7050", "orderer2.example.com:7050", "orderer3.example.com:7050"}, endpoints)
+	require.Equal(t, "root-cert1\nroot-cert2\n", string(rootCerts))
+
+	// the configured endpoints of a channel prevail
+	resources.orderer = &fakeOrdererConfig{consensusType: "etcdraft"}
+	bscc.options.ApprovalBFTOrderers = map[string][]string{"mychannel": {"bft0:7050", "bft1:7050"}}
+	endpoints, rootCerts, err = bscc.gatherBFTOrdererInfo("mychannel")
+	require.NoError(t, err)
+	require.Equal(t, []string{"bft0:7050", "bft1:7050"}, endpoints)
+	require.Equal(t, "root-cert1\nroot-cert2\n", string(rootCerts))
+}
+
+func TestCheckForkStatusOfJoinedChannels(t *testing.T) {
+	peerInfo := &mock.PeerInfoProvider{}
+	peerInfo.GetChannelsInfoReturns([]*pb.ChannelInfo{{ChannelId: "mychannel"}, {ChannelId: "forkedchannel"}})
+	bscc := newTestBSCC(peerInfo)
+	bscc.forkStatuses.stat = func(channelID string) bool { return channelID == "forkedchannel" }
+
+	resp := bscc.CheckForkStatus("", false)
+	require.Equal(t, int32(200), resp.Status)
+	require.Equal(t, `{"version":1,"channels":{"forkedchannel":{"forked":true,"record":null},"mychannel":{"forked":false,"record":null}}}`, string(resp.Payload))
+
+	resp = bscc.CheckForkStatus("forkedchannel", false)
+	require.Equal(t, int32(200), resp.Status)
+	require.Equal(t, `{"version":1,"forked":true,"record":null}`, string(resp.Payload))
+
+	bscc.options.ForkStatusLegacyFormat = true
+	resp = bscc.CheckForkStatus("", false)
+	require.Equal(t, int32(200), resp.Status)
+	require.JSONEq(t, `{"mychannel":false,"forkedchannel":true}`, string(resp.Payload))
+
+	resp = bscc.CheckForkStatus("forkedchannel", false)
+	require.Equal(t, int32(200), resp.Status)
+	require.Equal(t, "true", string(resp.Payload))
+}
+
+func TestGetReadingProofUnknownChannel(t *testing.T) {
+	peerInfo := &mock.PeerInfoProvider{}
+	bscc := newTestBSCC(peerInfo)
+
+	resp := bscc.GetReadingProof("mychannel", "tx1", "")
+	require.Equal(t, "channel mychannel not found", resp.Message)
+	require.Equal(t, "mychannel", peerInfo.GetLedgerArgsForCall(0))
+}
+
+// channelACLProvider permits its callers on a single channel only.
+type channelACLProvider struct {
+	channelID string
+}
+
+func (p *channelACLProvider) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	if channelID != p.channelID {
+		return errors.Errorf("%s is not permitted on channel %s", resName, channelID)
+	}
+	return nil
+}
+
+func (p *channelACLProvider) CheckACLNoChannel(resName string, idinfo interface{}) error {
+	return errors.Errorf("%s is not permitted without a channel", resName)
+}
+
+// invokeOnChannel invokes the BSCC through a proposal sent on the channel.
+func invokeOnChannel(bscc *BSCC, channelID string, args ...string) pb.Response {
+	stub := shimtest.NewMockStub("bscc", bscc)
+	stub.ChannelID = channelID
+	var byteArgs [][]byte
+	for _, arg := range args {
+		byteArgs = append(byteArgs, []byte(arg))
+	}
+	sp, _ := protoutil.MockSignedEndorserProposalOrPanic(channelID, &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "bscc"}}, nil, nil)
+	return stub.MockInvokeWithSignedProposal("tx1", byteArgs, sp)
+}
+
+func TestClearForkStatusChecksTargetChannel(t *testing.T) {
+	forked := map[string]bool{"forkedchannel": true}
+	bscc := New(NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus()), &channelACLProvider{channelID: "mychannel"})
+	bscc.forkStatuses.stat = func(channelID string) bool { return forked[channelID] }
+	bscc.forkStatuses.remove = func(channelID string) error {
+		delete(forked, channelID)
+		return nil
+	}
+
+	// a caller permitted on another channel cannot clear the fork
+	resp := invokeOnChannel(bscc, "mychannel", clearForkStatus, "forkedchannel")
+	require.Equal(t, "access denied for [ClearForkStatus]: bscc/ClearForkStatus is not permitted on channel forkedchannel", resp.Message)
+	require.True(t, forked["forkedchannel"])
+
+	bscc.aclProvider = &channelACLProvider{channelID: "forkedchannel"}
+	resp = invokeOnChannel(bscc, "mychannel", clearForkStatus, "forkedchannel")
+	require.Equal(t, int32(200), resp.Status, resp.Message)
+	require.Equal(t, "true", string(resp.Payload))
+	require.False(t, forked["forkedchannel"])
+}
@@ -0,0 +1,58 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// queryFunctions are the BSCC functions reading the state without writing
+// to it. Their proposals may carry a consistency token to read the writes of
+// an approval.
+var queryFunctions = map[string]bool{
+	queryApprovals:       true,
+	queryRejections:      true,
+	queryApprovalsBySel:  true,
+	getSensor:            true,
+	listSensors:          true,
+	querySensorsBySel:    true,
+	querySensorsInArea:   true,
+	getReadingProof:      true,
+	getReading:           true,
+	getDeliveryReceipt:   true,
+	getValidationPolicy:  true,
+	queryMetricReadings:  true,
+	getSensorStats:       true,
+	getTransformation:    true,
+	getFeatureFlags:      true,
+	getApprovers:         true,
+	getSensorReliability: true,
+	getApprovalAccess:    true,
+	getApprovalWatermark: true,
+	getRevalidations:     true,
+	getReadingProvenance: true,
+}
+
+// IsQuery returns whether the BSCC function fname only reads the state.
+func IsQuery(fname string) bool {
+	return queryFunctions[fname]
+}
+
+// ConsistencyRecordKey returns the BSCC key of the approval or rejection
+// record written by the approval transaction of token.
+func ConsistencyRecordKey(token *protoutil.ConsistencyToken) (string, error) {
+	objectType := approvalObjectType
+	if token.Rejection {
+		objectType = rejectionObjectType
+	}
+	key, err := shim.CreateCompositeKey(objectType, []string{token.SensoryTxID, token.MSPID})
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to create %s key of the consistency token", objectType)
+	}
+	return key, nil
+}
@@ -0,0 +1,22 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/deadletter"
+)
+
+// applyDeadLetterStore sets the directory and approval threshold of the
+// exports of the unresolved readings on ledger snapshots.
+func applyDeadLetterStore(options config.Options) {
+	threshold := options.DeadLetterThreshold
+	if threshold < 0 {
+		bloccProtoLogger.Warningf("Invalid dead-letter approval threshold %d, disabling the exports of unresolved readings", threshold)
+		threshold = 0
+	}
+	deadletter.Default.Set(options.DeadLetterDir, threshold)
+}
@@ -0,0 +1,157 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Decommission records the decommissioning of a sensor, e.g. when its
+// hardware is replaced. The readings of a decommissioned sensor committed
+// after its final reading are rejected.
+type Decommission struct {
+	Reason string `json:"reason"`
+	// FinalTxID is the final reading of the sensor, attested to be a valid
+	// reading of the sensor when it was decommissioned
+	FinalTxID string `json:"finalTxID"`
+	// FinalBlock and FinalTxIndex locate the final reading on the channel
+	FinalBlock   uint64 `json:"finalBlock"`
+	FinalTxIndex int    `json:"finalTxIndex"`
+	// TxID and Timestamp are those of the decommissioning transaction
+	TxID      string `json:"txID"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// txLocator is the subset of the ledger locating committed transactions.
+type txLocator interface {
+	GetBlockByTxID(txID string) (*cb.Block, error)
+}
+
+// DecommissionSensor marks the registered sensor in args[0] inactive, on
+// behalf of its organization. args[1] is the final reading of the sensor,
+// which must be a valid reading of the sensor committed on the channel, and
+// args[2] the reason of the decommissioning. The JSON encoded Decommission
+// is returned.
+func (bscc *BSCC) DecommissionSensor(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	sensor, err := ownedSensor(stub, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if sensor.Decommission != nil {
+		return shim.Error(fmt.Sprintf("Sensor %s is already decommissioned", sensor.ID))
+	}
+	if len(args) < 2 || len(args[1]) == 0 {
+		return shim.Error("Final reading not specified")
+	}
+	if len(args) < 3 || len(args[2]) == 0 {
+		return shim.Error("Decommission reason not specified")
+	}
+
+	ledger := bscc.peerInfo.GetLedger(stub.GetChannelID())
+	if ledger == nil {
+		return shim.Error(fmt.Sprintf("channel %s not found", stub.GetChannelID()))
+	}
+	decommission, err := attestFinalReading(ledger, sensor.ID, string(args[1]))
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	timestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+	}
+	decommission.Reason = string(args[2])
+	decommission.TxID = stub.GetTxID()
+	decommission.Timestamp = timestamp.GetSeconds()
+
+	sensor.Decommission = decommission
+	if err := storeSensor(stub, sensor); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	decommissionBytes, err := json.Marshal(decommission)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal decommission: %s", err))
+	}
+
+	return shim.Success(decommissionBytes)
+}
+
+// attestFinalReading checks that the transaction txID is a valid reading of
+// the sensor and returns the decommission locating it.
+func attestFinalReading(source txLocator, sensor string, txID string) (*Decommission, error) {
+	proof, err := locateTx(source, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := protoutil.UnmarshalEnvelope(proof.Data.Data[proof.TxIndex])
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to unmarshal final reading %s", txID)
+	}
+	creator, err := protoutil.ExtractCreatorFromEnvelope(envelope)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to extract creator of final reading %s", txID)
+	}
+	id, err := sensorID(creator)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to identify sensor of final reading %s", txID)
+	}
+	if id != sensor {
+		return nil, errors.Errorf("final reading %s is a reading of sensor %s, not %s", txID, id, sensor)
+	}
+
+	return &Decommission{
+		FinalTxID:    txID,
+		FinalBlock:   proof.Headers[0].Number,
+		FinalTxIndex: proof.TxIndex,
+	}, nil
+}
+
+// checkDecommission rejects the reading of the decommissioned sensor unless it
+// was committed before or as its final reading.
+func checkDecommission(source txLocator, sensor *Sensor, sensoryTxID string) error {
+	decommission := sensor.Decommission
+	if sensoryTxID == decommission.FinalTxID {
+		return nil
+	}
+
+	proof, err := locateTx(source, sensoryTxID)
+	if err != nil {
+		return err
+	}
+	if block := proof.Headers[0].Number; block > decommission.FinalBlock || (block == decommission.FinalBlock && proof.TxIndex > decommission.FinalTxIndex) {
+		return reject(ReasonSensorDecommissioned, "sensor %s was decommissioned after reading %s: %s", sensor.ID, decommission.FinalTxID, decommission.Reason)
+	}
+
+	return nil
+}
+
+// locateTx returns the proof locating the transaction txID in its block,
+// which must have committed it as valid.
+func locateTx(source txLocator, txID string) (*protoutil.ReadingProof, error) {
+	block, err := source.GetBlockByTxID(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get block of transaction %s", txID)
+	}
+
+	proof, err := protoutil.NewReadingProof(block, txID, []*cb.BlockHeader{block.Header})
+	if err != nil {
+		return nil, err
+	}
+	if err := protoutil.VerifyReadingProof(proof, block.Header); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
@@ -0,0 +1,147 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTxLocator map[string]*cb.Block
+
+func (l fakeTxLocator) GetBlockByTxID(txID string) (*cb.Block, error) {
+	if block, ok := l[txID]; ok {
+		return block, nil
+	}
+	return nil, errors.Errorf("transaction %s not found", txID)
+}
+
+// sensorIdentity returns the serialized identity of the sensor, the common
+// name of its certificate being the sensor ID.
+func sensorIdentity(t *testing.T, id string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: id},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: cert})
+}
+
+// readingBlock returns the block committing the readings, keyed by
+// transaction ID, in the given order.
+func readingBlock(number uint64, txIDs []string, creators map[string][]byte) *cb.Block {
+	block := protoutil.NewBlock(number, nil)
+	for _, txID := range txIDs {
+		payload := &cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader:   protoutil.MarshalOrPanic(&cb.ChannelHeader{TxId: txID}),
+				SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{Creator: creators[txID]}),
+			},
+		}
+		block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(&cb.Envelope{Payload: protoutil.MarshalOrPanic(payload)}))
+		block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = append(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER], byte(pb.TxValidationCode_VALID))
+	}
+	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+	return block
+}
+
+func TestDecommissionReadings(t *testing.T) {
+	sensor1, sensor2 := sensorIdentity(t, "sensor1"), sensorIdentity(t, "sensor2")
+	creators := map[string][]byte{"r1": sensor1, "r2": sensor1, "r3": sensor1, "o1": sensor2, "r4": sensor1}
+	block5 := readingBlock(5, []string{"r1", "r2", "r3"}, creators)
+	block6 := readingBlock(6, []string{"o1", "r4"}, creators)
+	block6.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER][1] = byte(pb.TxValidationCode_MVCC_READ_CONFLICT)
+	locator := fakeTxLocator{"r1": block5, "r2": block5, "r3": block5, "o1": block6, "r4": block6}
+
+	decommission, err := attestFinalReading(locator, "sensor1", "r2")
+	require.NoError(t, err)
+	require.Equal(t, &Decommission{FinalTxID: "r2", FinalBlock: 5, FinalTxIndex: 1}, decommission)
+
+	_, err = attestFinalReading(locator, "sensor1", "o1")
+	require.EqualError(t, err, "final reading o1 is a reading of sensor sensor2, not sensor1")
+	_, err = attestFinalReading(locator, "sensor1", "r4")
+	require.EqualError(t, err, "transaction r4 was invalidated with code MVCC_READ_CONFLICT")
+	_, err = attestFinalReading(locator, "sensor1", "r5")
+	require.EqualError(t, err, "failed to get block of transaction r5: transaction r5 not found")
+
+	// the readings committed after the final reading are rejected
+	decommission.Reason = "replaced"
+	sensor := &Sensor{ID: "sensor1", Decommission: decommission}
+	require.NoError(t, checkDecommission(locator, sensor, "r1"))
+	require.NoError(t, checkDecommission(locator, sensor, "r2"))
+	err = checkDecommission(locator, sensor, "r3")
+	require.Equal(t, reject(ReasonSensorDecommissioned, "sensor sensor1 was decommissioned after reading r2: replaced"), err)
+}
+
+func TestDecommissionSensor(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+
+	decommission := func(args ...string) string {
+		stub.MockTransactionStart("tx1")
+		defer stub.MockTransactionEnd("tx1")
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		return bscc.DecommissionSensor(stub, byteArgs).Message
+	}
+
+	require.Equal(t, "Sensor sensor1 is not registered", decommission("sensor1", "r2", "replaced"))
+
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP"}))
+	stub.MockTransactionEnd("setup")
+
+	require.Equal(t, "Final reading not specified", decommission("sensor1"))
+	require.Equal(t, "Decommission reason not specified", decommission("sensor1", "r2"))
+	require.Equal(t, "channel  not found", decommission("sensor1", "r2", "replaced"))
+
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org2MSP"})
+	require.Equal(t, "Sensor sensor1 is registered by Org1MSP, not Org2MSP", decommission("sensor1", "r2", "replaced"))
+
+	// decommissioned sensors can be neither decommissioned again, registered
+	// again nor authenticated
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP", TokenHash: tokenHash([]byte("t0k3n")), Decommission: &Decommission{FinalTxID: "r2"}}))
+	stub.MockTransactionEnd("setup")
+	require.Equal(t, "Sensor sensor1 is already decommissioned", decommission("sensor1", "r2", "replaced"))
+
+	stub.MockTransactionStart("tx2")
+	resp := bscc.RegisterSensor(stub, [][]byte{[]byte(`{"id":"sensor1"}`)})
+	stub.MockTransactionEnd("tx2")
+	require.Equal(t, "Sensor sensor1 is decommissioned", resp.Message)
+
+	stub.MockTransactionStart("tx3")
+	stub.TransientMap = map[string][]byte{tokenTransientKey: []byte("t0k3n")}
+	resp = bscc.AuthenticateSensor(stub, [][]byte{[]byte("sensor1")})
+	stub.MockTransactionEnd("tx3")
+	require.Equal(t, "Sensor sensor1 is decommissioned", resp.Message)
+}
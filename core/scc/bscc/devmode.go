@@ -0,0 +1,77 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/internal/pkg/blocc/devorderer"
+	"github.com/pkg/errors"
+)
+
+// startDevOrderer starts the development orderer if dev mode is enabled,
+// keeping its chains in a temporary directory removed when it stops. The
+// approvals of every channel are then broadcast to it rather than to the
+// orderers of the channel.
+func (s *BloccService) startDevOrderer() error {
+	options := s.currentOptions()
+	if !options.DevModeEnabled {
+		return nil
+	}
+	if s.config.Signer == nil {
+		return errors.New("dev mode requires the signer of the peer")
+	}
+
+	dir, err := ioutil.TempDir("", "blocc-devorderer")
+	if err != nil {
+		return errors.Wrap(err, "failed to create the directory of the development orderer")
+	}
+	orderer, err := devorderer.New(filepath.Join(dir, "chains"), s.config.Signer, func(channelID string) devorderer.Ledger {
+		if ledger := s.peerInfo.GetLedger(channelID); ledger != nil {
+			return ledger
+		}
+		return nil
+	})
+	if err != nil {
+		os.RemoveAll(dir)
+		return err
+	}
+	server, err := devorderer.Listen(options.DevOrdererAddress, orderer)
+	if err != nil {
+		orderer.Close()
+		os.RemoveAll(dir)
+		return err
+	}
+
+	caFile := filepath.Join(dir, "tls-ca.pem")
+	if err := ioutil.WriteFile(caFile, server.RootCert(), 0o644); err != nil {
+		server.Stop()
+		os.RemoveAll(dir)
+		return errors.Wrap(err, "failed to write the TLS CA of the development orderer")
+	}
+
+	bloccProtoLogger.Warningf("Dev mode: approvals are ordered by the development orderer on %s rather than by the orderers of the channels, "+
+		"deliver its blocks to this peer with peer.deliveryclient.addressOverrides trusting %s", server.Address(), caFile)
+	s.devOrderer = server
+	s.devOrdererDir = dir
+	return nil
+}
+
+// stopDevOrderer stops the development orderer, if started, and removes its
+// directory.
+func (s *BloccService) stopDevOrderer() {
+	if s.devOrderer == nil {
+		return
+	}
+	s.devOrderer.Stop()
+	if err := os.RemoveAll(s.devOrdererDir); err != nil {
+		bloccProtoLogger.Warningf("Failed to remove the directory of the development orderer: %s", err)
+	}
+	s.devOrderer = nil
+	s.devOrdererDir = ""
+}
@@ -0,0 +1,42 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevOrderer(t *testing.T) {
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+
+	// the development orderer is not started unless dev mode is enabled
+	require.NoError(t, bscc.startDevOrderer())
+	require.Nil(t, bscc.devOrderer)
+
+	bscc.options = config.Options{DevModeEnabled: true, DevOrdererAddress: "127.0.0.1:0"}
+	require.EqualError(t, bscc.startDevOrderer(), "dev mode requires the signer of the peer")
+
+	bscc.config.Signer = &preflightSigner{}
+	require.NoError(t, bscc.startDevOrderer())
+	dir := bscc.devOrdererDir
+
+	caBytes, err := ioutil.ReadFile(filepath.Join(dir, "tls-ca.pem"))
+	require.NoError(t, err)
+	address, rootCert, err := bscc.gatherOrdererInfo("mychannel")
+	require.NoError(t, err)
+	require.Equal(t, bscc.devOrderer.Address(), address)
+	require.Equal(t, caBytes, rootCert)
+
+	bscc.stopDevOrderer()
+	require.Nil(t, bscc.devOrderer)
+	require.NoDirExists(t, dir)
+}
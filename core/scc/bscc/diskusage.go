@@ -0,0 +1,160 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	// rootCertTempFilePrefix prefixes the temporary files holding the root
+	// certificate of the orderer an approval is submitted to.
+	rootCertTempFilePrefix = "rootCertFile"
+	// orphanedTempFileAge is the age after which a root certificate temporary
+	// file is considered left over, approvals completing within seconds.
+	orphanedTempFileAge = time.Hour
+)
+
+// stateObjectTypes are the object types of the BSCC state of a channel,
+// including its secondary indexes.
+var stateObjectTypes = []string{
+	approvalObjectType,
+	rejectionObjectType,
+	sensorObjectType,
+	sensorGeohashObjectType,
+	sensorSequenceObjectType,
+	transformationObjectType,
+	validationPolicyObjectType,
+	lastReadingObjectType,
+	sensorReliabilityObjectType,
+	metricReadingObjectType,
+	featureFlagObjectType,
+	migrationObjectType,
+	approverObjectType,
+	firmwareAttestationObjectType,
+}
+
+// ArtifactUsage is the number of entries and bytes consumed by an artifact.
+type ArtifactUsage struct {
+	Entries int   `json:"entries"`
+	Bytes   int64 `json:"bytes"`
+}
+
+// DiskUsage reports the disk consumed by the BLOCC artifacts of a peer. State
+// is the BSCC state of the channel by object type, and the approval queue and
+// temporary files are shared by every channel of the peer.
+type DiskUsage struct {
+	ChannelID     string                   `json:"channelID"`
+	State         map[string]ArtifactUsage `json:"state"`
+	ApprovalQueue ArtifactUsage            `json:"approvalQueue"`
+	TempFiles     ArtifactUsage            `json:"tempFiles"`
+	// RemovedTempFiles is the number of orphaned temporary files removed
+	RemovedTempFiles int `json:"removedTempFiles,omitempty"`
+}
+
+// GetDiskUsage returns the JSON encoded disk usage of the BLOCC artifacts of
+// the channel. The orphaned temporary files are removed if args[0] is true.
+func (bscc *BSCC) GetDiskUsage(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	cleanup := false
+	if len(args) > 0 && len(args[0]) > 0 {
+		var err error
+		if cleanup, err = strconv.ParseBool(string(args[0])); err != nil {
+			return shim.Error(fmt.Sprintf("Invalid cleanup flag '%s'", args[0]))
+		}
+	}
+
+	usage := &DiskUsage{
+		ChannelID: stub.GetChannelID(),
+		State:     map[string]ArtifactUsage{},
+	}
+	for _, objectType := range stateObjectTypes {
+		stateUsage, err := objectTypeUsage(stub, objectType)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		usage.State[objectType] = stateUsage
+	}
+
+	if info, err := os.Stat(bscc.currentOptions().ApprovalQueueFile); err == nil {
+		usage.ApprovalQueue = ArtifactUsage{Entries: 1, Bytes: info.Size()}
+	}
+
+	tempFiles, removed, err := tempFileUsage(os.TempDir(), cleanup, bscc.clock.Now())
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	usage.TempFiles = tempFiles
+	usage.RemovedTempFiles = removed
+	if removed > 0 {
+		bloccProtoLogger.Infof("Removed %d orphaned BLOCC temporary files", removed)
+	}
+
+	usageBytes, err := json.Marshal(usage)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal disk usage: %s", err))
+	}
+
+	return shim.Success(usageBytes)
+}
+
+func objectTypeUsage(stub shim.ChaincodeStubInterface, objectType string) (ArtifactUsage, error) {
+	usage := ArtifactUsage{}
+
+	iterator, err := stub.GetStateByPartialCompositeKey(objectType, nil)
+	if err != nil {
+		return usage, errors.WithMessagef(err, "failed to query %s state", objectType)
+	}
+	defer iterator.Close()
+
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return usage, errors.WithMessagef(err, "failed to query %s state", objectType)
+		}
+		usage.Entries++
+		usage.Bytes += int64(len(kv.Key) + len(kv.Value))
+	}
+
+	return usage, nil
+}
+
+// tempFileUsage returns the usage of the root certificate temporary files of
+// dir, after removing the orphaned ones if cleanup is set.
+func tempFileUsage(dir string, cleanup bool, now time.Time) (ArtifactUsage, int, error) {
+	usage := ArtifactUsage{}
+	removed := 0
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return usage, 0, errors.Wrapf(err, "failed to read temporary directory %s", dir)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), rootCertTempFilePrefix) {
+			continue
+		}
+		if cleanup && now.Sub(entry.ModTime()) > orphanedTempFileAge {
+			if err := os.Remove(filepath.Join(dir, entry.Name())); err == nil {
+				removed++
+				continue
+			}
+		}
+		usage.Entries++
+		usage.Bytes += entry.Size()
+	}
+
+	return usage, removed, nil
+}
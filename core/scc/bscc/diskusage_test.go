@@ -0,0 +1,63 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectTypeUsage(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.MockTransactionStart("tx1")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP"}))
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor2", MSPID: "Org1MSP"}))
+	stub.MockTransactionEnd("tx1")
+
+	usage, err := objectTypeUsage(stub, sensorObjectType)
+	require.NoError(t, err)
+	require.Equal(t, 2, usage.Entries)
+	require.NotZero(t, usage.Bytes)
+
+	usage, err = objectTypeUsage(stub, approvalObjectType)
+	require.NoError(t, err)
+	require.Equal(t, ArtifactUsage{}, usage)
+}
+
+func TestTempFileUsage(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	write := func(name string, age time.Duration) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte("cert"), 0o600))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+	}
+	write(rootCertTempFilePrefix+"1", time.Minute)
+	write(rootCertTempFilePrefix+"2", 2*time.Hour)
+	write("unrelated", 2*time.Hour)
+
+	usage, removed, err := tempFileUsage(dir, false, now)
+	require.NoError(t, err)
+	require.Equal(t, ArtifactUsage{Entries: 2, Bytes: 8}, usage)
+	require.Equal(t, 0, removed)
+
+	// only the orphaned root certificate files are removed
+	usage, removed, err = tempFileUsage(dir, true, now)
+	require.NoError(t, err)
+	require.Equal(t, ArtifactUsage{Entries: 1, Bytes: 4}, usage)
+	require.Equal(t, 1, removed)
+	require.NoFileExists(t, filepath.Join(dir, rootCertTempFilePrefix+"2"))
+	require.FileExists(t, filepath.Join(dir, "unrelated"))
+
+	_, _, err = tempFileUsage(filepath.Join(dir, "missing"), false, now)
+	require.Error(t, err)
+}
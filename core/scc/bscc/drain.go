@@ -0,0 +1,177 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/pkg/errors"
+)
+
+// DrainStatus reports the progress of draining approvals before maintenance.
+type DrainStatus struct {
+	// Draining is set once a drain was requested, after which no approval
+	// request is started
+	Draining bool `json:"draining"`
+	// Drained is set once the approvals being processed completed and the
+	// pending approval requests were saved
+	Drained bool `json:"drained"`
+	// Saved is the number of pending approval requests saved for the next start
+	Saved int `json:"saved"`
+	// Error reports a failure to save the pending approval requests
+	Error string `json:"error,omitempty"`
+}
+
+// approvalDrain tracks the drain of the approvals of this peer.
+type approvalDrain struct {
+	mutex     sync.Mutex
+	status    DrainStatus
+	requested chan struct{}
+}
+
+func newApprovalDrain() *approvalDrain {
+	return &approvalDrain{requested: make(chan struct{})}
+}
+
+// request starts the drain, if not already started, and returns its status.
+func (d *approvalDrain) request() DrainStatus {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if !d.status.Draining {
+		d.status.Draining = true
+		close(d.requested)
+	}
+	return d.status
+}
+
+func (d *approvalDrain) complete(saved int, err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.status.Drained = true
+	d.status.Saved = saved
+	if err != nil {
+		d.status.Error = err.Error()
+	}
+}
+
+// DrainApprovals stops this peer from starting approvals, so that it can be
+// restarted without losing approvals mid-flight. The approvals being
+// processed are completed and the pending approval requests are saved to be restored on
+// the next start. The JSON encoded DrainStatus is returned, Drained being
+// set once the drain completed.
+func (bscc *BSCC) DrainApprovals() pb.Response {
+	status := bscc.currentDrain().request()
+
+	statusBytes, err := json.Marshal(status)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal drain status: %s", err))
+	}
+
+	return shim.Success(statusBytes)
+}
+
+// completeDrain saves the approval requests left unacknowledged by the
+// subscription, once no approval is in flight.
+func (s *BloccService) completeDrain(subscription *event.Subscription) {
+	saved, err := s.savePendingApprovals(subscription)
+	if err == nil {
+		bloccProtoLogger.Info("Approvals drained")
+	}
+
+	s.drain.complete(saved, err)
+}
+
+// savePendingApprovals closes the subscription and saves the approval
+// requests it left unacknowledged, returning their number.
+func (s *BloccService) savePendingApprovals(subscription *event.Subscription) (int, error) {
+	subscription.Close()
+
+	var pending []event.Event
+	for _, e := range subscription.PendingEvents() {
+		if e.Type == event.ApprovalRequest {
+			pending = append(pending, e)
+		}
+	}
+
+	path := s.currentOptions().ApprovalQueueFile
+	if err := saveApprovalQueue(path, pending); err != nil {
+		bloccProtoLogger.Errorf("Failed to save %d pending approval requests: %s", len(pending), err)
+		return len(pending), err
+	}
+	bloccProtoLogger.Infof("Saved %d pending approval requests to %s", len(pending), path)
+
+	return len(pending), nil
+}
+
+// restoreApprovalQueue queues the approval requests saved by a drain before
+// the peer was restarted.
+func (s *BloccService) restoreApprovalQueue(subscription *event.Subscription) {
+	path := s.currentOptions().ApprovalQueueFile
+	pending, err := loadApprovalQueue(path)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to restore pending approval requests: %s", err)
+		return
+	}
+	if pending == nil {
+		return
+	}
+
+	subscription.Restore(pending...)
+	bloccProtoLogger.Infof("Restored %d pending approval requests from %s", len(pending), path)
+
+	if err := os.Remove(path); err != nil {
+		bloccProtoLogger.Errorf("Failed to remove %s: %s", path, err)
+	}
+}
+
+func saveApprovalQueue(path string, pending []event.Event) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create directory of %s", path)
+	}
+
+	pendingBytes, err := json.Marshal(pending)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal approval requests")
+	}
+
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, pendingBytes, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmpPath)
+	}
+
+	return errors.Wrapf(os.Rename(tmpPath, path), "failed to rename %s", tmpPath)
+}
+
+// loadApprovalQueue returns the saved approval requests, or nil if none were saved.
+func loadApprovalQueue(path string) ([]event.Event, error) {
+	pendingBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	var pending []event.Event
+	if err := json.Unmarshal(pendingBytes, &pending); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", path)
+	}
+	if pending == nil {
+		pending = []event.Event{}
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,74 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainApprovals(t *testing.T) {
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+	bscc.options = config.Options{ApprovalQueueFile: filepath.Join(t.TempDir(), "blocc", "approval_queue.json")}
+
+	bus := event.NewEventBus()
+	subscription := bus.SubscribeWith(event.AtLeastOnce, time.Minute)
+	bus.Publish(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx1"})
+	bus.Publish(event.Event{Type: event.HeightLag, ChannelID: "mychannel"})
+	bus.Publish(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx2"})
+
+	resp := bscc.DrainApprovals()
+	require.Equal(t, int32(200), resp.Status)
+	status := &DrainStatus{}
+	require.NoError(t, json.Unmarshal(resp.Payload, status))
+	require.Equal(t, &DrainStatus{Draining: true}, status)
+
+	done := make(chan struct{})
+	go func() {
+		bscc.serveApprovals(newApprovalQueues(), subscription, nil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("approvals not drained")
+	}
+
+	resp = bscc.DrainApprovals()
+	require.NoError(t, json.Unmarshal(resp.Payload, status))
+	require.Equal(t, &DrainStatus{Draining: true, Drained: true, Saved: 2}, status)
+
+	// the saved requests are restored on the next start
+	restored := bus.SubscribeWith(event.AtLeastOnce, time.Minute)
+	defer restored.Close()
+	bscc.restoreApprovalQueue(restored)
+	require.Equal(t, []event.Event{
+		{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx1"},
+		{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx2"},
+	}, restored.PendingEvents())
+	require.NoFileExists(t, bscc.options.ApprovalQueueFile)
+}
+
+func TestLoadApprovalQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "approval_queue.json")
+
+	pending, err := loadApprovalQueue(path)
+	require.NoError(t, err)
+	require.Nil(t, pending)
+
+	require.NoError(t, saveApprovalQueue(path, nil))
+	pending, err = loadApprovalQueue(path)
+	require.NoError(t, err)
+	require.NotNil(t, pending)
+	require.Empty(t, pending)
+}
@@ -0,0 +1,57 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/committer/txvalidator/v20/plugindispatcher"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// dryRunApproval checks the signed approval transaction env against the
+// current configuration of the channel, as the orderer and the committing
+// peers will, so that an approval bound to be rejected fails with the reason
+// instead of being broadcast.
+func (s *BloccService) dryRunApproval(channelID string, env *cb.Envelope) error {
+	resources := s.peerInfo.GetChannelResources(channelID)
+	if resources == nil {
+		return bloccerrors.WithCategory(errors.Errorf("peer has not joined channel %s", channelID), bloccerrors.ErrChannelNotFound)
+	}
+
+	// the approval transactions of channels without the V2_0 application
+	// capability are validated as invocations of a system chaincode, and
+	// invalidated
+	ac, ok := resources.ApplicationConfig()
+	if !ok {
+		return errors.Errorf("channel %s has no application configuration", channelID)
+	}
+	if !ac.Capabilities().V2_0Validation() {
+		return errors.Errorf("channel %s does not enable the V2_0 application capability required to commit approvals, enable it in the channel configuration", channelID)
+	}
+
+	signedData, err := protoutil.EnvelopeAsSignedData(env)
+	if err != nil {
+		return errors.WithMessage(err, "failed to extract the signature of the approval transaction")
+	}
+	if writers, ok := resources.PolicyManager().GetPolicy(policies.ChannelWriters); ok {
+		if err := writers.EvaluateSignedData(signedData); err != nil {
+			return bloccerrors.WithCategory(errors.WithMessagef(err, "approval identity does not satisfy the %s policy of channel %s, the orderer would refuse the transaction", policies.ChannelWriters, channelID), bloccerrors.ErrPolicyViolation)
+		}
+	}
+
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal the approval transaction")
+	}
+	if err := plugindispatcher.ValidateApproval(resources.PolicyManager(), payload); err != nil {
+		return bloccerrors.WithCategory(errors.WithMessagef(err, "approval would be invalidated on commit, check the %s policy of channel %s", plugindispatcher.ApprovalPolicyPath, channelID), bloccerrors.ErrPolicyViolation)
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/core/committer/txvalidator/v20/plugindispatcher"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChannelResources struct {
+	channelconfig.Resources
+	application channelconfig.Application
+	orderer     channelconfig.Orderer
+	policies    policies.Manager
+	mspManager  msp.MSPManager
+}
+
+func (r *fakeChannelResources) ApplicationConfig() (channelconfig.Application, bool) {
+	return r.application, r.application != nil
+}
+
+func (r *fakeChannelResources) OrdererConfig() (channelconfig.Orderer, bool) {
+	return r.orderer, r.orderer != nil
+}
+
+func (r *fakeChannelResources) PolicyManager() policies.Manager {
+	return r.policies
+}
+
+func (r *fakeChannelResources) MSPManager() msp.MSPManager {
+	return r.mspManager
+}
+
+type fakeApplication struct {
+	channelconfig.Application
+	channelconfig.ApplicationCapabilities
+	v20  bool
+	orgs map[string]channelconfig.ApplicationOrg
+}
+
+func (a *fakeApplication) Organizations() map[string]channelconfig.ApplicationOrg {
+	return a.orgs
+}
+
+type fakeApplicationOrg struct {
+	channelconfig.ApplicationOrg
+	mspID string
+}
+
+func (o *fakeApplicationOrg) MSPID() string {
+	return o.mspID
+}
+
+func (a *fakeApplication) Capabilities() channelconfig.ApplicationCapabilities {
+	return a
+}
+
+func (a *fakeApplication) V2_0Validation() bool {
+	return a.v20
+}
+
+type fakeOrdererConfig struct {
+	channelconfig.Orderer
+	consensusType string
+}
+
+func (o *fakeOrdererConfig) ConsensusType() string {
+	return o.consensusType
+}
+
+type fakePolicyManager struct {
+	policies map[string]policies.Policy
+}
+
+func (m *fakePolicyManager) Manager(path []string) (policies.Manager, bool) {
+	return nil, false
+}
+
+func (m *fakePolicyManager) GetPolicy(id string) (policies.Policy, bool) {
+	policy, ok := m.policies[id]
+	return policy, ok
+}
+
+type fakePolicy struct {
+	err error
+}
+
+func (p *fakePolicy) EvaluateSignedData(signatureSet []*protoutil.SignedData) error {
+	return p.err
+}
+
+func (p *fakePolicy) EvaluateIdentities(identities []msp.Identity) error {
+	return p.err
+}
+
+func TestDryRunApproval(t *testing.T) {
+	identity := protoutil.MarshalOrPanic(&mspproto.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("peer0")})
+	env := &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{Creator: identity}),
+			},
+			Data: protoutil.MarshalOrPanic(&pb.Transaction{
+				Actions: []*pb.TransactionAction{{
+					Payload: protoutil.MarshalOrPanic(&pb.ChaincodeActionPayload{
+						ChaincodeProposalPayload: protoutil.MarshalOrPanic(&pb.ChaincodeProposalPayload{
+							Input: protoutil.MarshalOrPanic(&pb.ChaincodeInvocationSpec{
+								ChaincodeSpec: &pb.ChaincodeSpec{Input: &pb.ChaincodeInput{Args: [][]byte{[]byte(approveSensoryReading)}}},
+							}),
+						}),
+						Action: &pb.ChaincodeEndorsedAction{
+							ProposalResponsePayload: protoutil.MarshalOrPanic(&pb.ProposalResponsePayload{
+								Extension: protoutil.MarshalOrPanic(&pb.ChaincodeAction{}),
+							}),
+							Endorsements: []*pb.Endorsement{{Endorser: identity, Signature: []byte("sig")}},
+						},
+					}),
+				}},
+			}),
+		}),
+		Signature: []byte("sig"),
+	}
+
+	peerInfo := &mock.PeerInfoProvider{}
+	service := newTestBSCC(peerInfo)
+	err := service.dryRunApproval("mychannel", env)
+	require.EqualError(t, err, "peer has not joined channel mychannel")
+	require.True(t, errors.Is(err, bloccerrors.ErrChannelNotFound))
+
+	resources := &fakeChannelResources{}
+	peerInfo.GetChannelResourcesReturns(resources)
+	require.EqualError(t, service.dryRunApproval("mychannel", env), "channel mychannel has no application configuration")
+
+	application := &fakeApplication{}
+	resources.application = application
+	require.EqualError(t, service.dryRunApproval("mychannel", env), "channel mychannel does not enable the V2_0 application capability required to commit approvals, enable it in the channel configuration")
+
+	// channels without the approval policy invalidate the approval
+	application.v20 = true
+	policyManager := &fakePolicyManager{policies: map[string]policies.Policy{}}
+	resources.policies = policyManager
+	require.EqualError(t, service.dryRunApproval("mychannel", env), "approval would be invalidated on commit, check the /Channel/Application/BloccApprovals policy of channel mychannel: channel does not define the approval policy /Channel/Application/BloccApprovals")
+	policyManager.policies[plugindispatcher.ApprovalPolicyPath] = &fakePolicy{}
+	require.NoError(t, service.dryRunApproval("mychannel", env))
+
+	policyManager.policies[policies.ChannelWriters] = &fakePolicy{err: errors.New("signature set did not satisfy policy")}
+	err = service.dryRunApproval("mychannel", env)
+	require.EqualError(t, err, "approval identity does not satisfy the /Channel/Writers policy of channel mychannel, the orderer would refuse the transaction: signature set did not satisfy policy")
+	require.True(t, errors.Is(err, bloccerrors.ErrPolicyViolation))
+
+	policyManager.policies[policies.ChannelWriters] = &fakePolicy{}
+	policyManager.policies[plugindispatcher.ApprovalPolicyPath] = &fakePolicy{err: errors.New("signature set did not satisfy policy")}
+	require.EqualError(t, service.dryRunApproval("mychannel", env), "approval would be invalidated on commit, check the /Channel/Application/BloccApprovals policy of channel mychannel: endorsements of Org1MSP do not satisfy approval policy /Channel/Application/BloccApprovals: signature set did not satisfy policy")
+
+	policyManager.policies[plugindispatcher.ApprovalPolicyPath] = &fakePolicy{}
+	require.NoError(t, service.dryRunApproval("mychannel", env))
+}
@@ -0,0 +1,41 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// marshalState returns the canonical JSON encoding of a state entry written
+// by BSCC. Object keys are sorted at every level, numbers keep their textual
+// representation and HTML characters are not escaped, so that endorsements
+// of peers running builds with differently ordered struct fields still match.
+func marshalState(v interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, errors.Wrap(err, "failed to decode state entry")
+	}
+
+	// maps are encoded with sorted keys
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(generic); err != nil {
+		return nil, errors.Wrap(err, "failed to encode state entry")
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
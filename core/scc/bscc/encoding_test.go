@@ -0,0 +1,37 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalState(t *testing.T) {
+	record := &ApprovalRecord{
+		DocType:         approvalObjectType,
+		SensoryTxID:     "tx1",
+		ApprovalTxID:    "tx2",
+		MSPID:           "Org1MSP",
+		Timestamp:       1700000000,
+		SensorTimestamp: 1699999990,
+		ClockSkew:       10,
+		Metadata:        map[string]string{"siteID": "a<b", "experimentID": "exp-42"},
+	}
+
+	encoded, err := marshalState(record)
+	require.NoError(t, err)
+	require.Equal(t, `{"approvalTxID":"tx2","clockSkew":10,"docType":"approval","metadata":{"experimentID":"exp-42","siteID":"a<b"},"mspID":"Org1MSP","sensorTimestamp":1699999990,"sensoryTxID":"tx1","timestamp":1700000000}`, string(encoded))
+
+	type reordered struct {
+		B float64 `json:"b"`
+		A float64 `json:"a"`
+	}
+	encoded, err = marshalState(&reordered{B: 1e21, A: 0.1})
+	require.NoError(t, err)
+	require.Equal(t, `{"a":0.1,"b":1e+21}`, string(encoded))
+}
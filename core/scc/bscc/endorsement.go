@@ -0,0 +1,60 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"bytes"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// endorsedReading returns whether the committed reading of the approval
+// request carries an endorsement of this peer. In approve-on-endorse mode
+// such an endorsement stands for the approval of the reading by this peer.
+func (s *BloccService) endorsedReading(request event.Event) bool {
+	if len(s.config.PeerIdentity) == 0 {
+		return false
+	}
+
+	envelope := s.readingEnvelope(request.ChannelID, request.SensoryTxID)
+	if envelope == nil {
+		return false
+	}
+
+	endorsers, err := protoutil.ExtractEndorsersFromEnvelope(envelope)
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to extract endorsers of reading %s: %s", request.SensoryTxID, err)
+		return false
+	}
+	for _, endorser := range endorsers {
+		if bytes.Equal(endorser, s.config.PeerIdentity) {
+			return true
+		}
+	}
+	return false
+}
+
+// publishEndorsementApproval publishes the ApprovalCommitted event of an
+// approval given on endorsement, the reading holding the approval being
+// committed already.
+func (s *BloccService) publishEndorsementApproval(request event.Event) {
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(s.config.PeerIdentity, identity); err != nil {
+		bloccProtoLogger.Warningf("Failed to unmarshal peer identity: %s", err)
+		return
+	}
+
+	s.eventBus.Publish(event.Event{
+		Type:        event.ApprovalCommitted,
+		ChannelID:   request.ChannelID,
+		SensoryTxID: request.SensoryTxID,
+		MSPID:       identity.Mspid,
+		TraceID:     request.TraceID,
+	})
+}
@@ -0,0 +1,41 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/msp"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndorsementApproval(t *testing.T) {
+	peerInfo := &mock.PeerInfoProvider{}
+	service := newTestBSCC(peerInfo)
+	request := event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx1", TraceID: "trace1"}
+
+	// without a peer identity no reading is recognized as endorsed
+	require.False(t, service.endorsedReading(request))
+	require.Equal(t, 0, peerInfo.GetLedgerCallCount())
+
+	// nor without the ledger of the channel
+	service.config.PeerIdentity = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("peer0")})
+	require.False(t, service.endorsedReading(request))
+
+	events := service.eventBus.Subscribe("test")
+	defer service.eventBus.Unsubscribe(events)
+	service.publishEndorsementApproval(request)
+	select {
+	case e := <-events:
+		require.Equal(t, event.Event{Type: event.ApprovalCommitted, ChannelID: "mychannel", SensoryTxID: "tx1", MSPID: "Org1MSP", TraceID: "trace1"}, e)
+	case <-time.After(5 * time.Second):
+		t.Fatal("approval not published")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// Evaluation is the decision this peer would record for a candidate reading,
+// as returned by EvaluateReading.
+type Evaluation struct {
+	// Approved is set if the reading would be approved, Reason and Message
+	// describing why it would be rejected otherwise
+	Approved bool            `json:"approved"`
+	Reason   RejectionReason `json:"reason,omitempty"`
+	Message  string          `json:"message,omitempty"`
+	SensorID string          `json:"sensorID,omitempty"`
+	// Registered is set if the sensor is in the registry, the readings of
+	// unregistered sensors being validated against no policy
+	Registered bool               `json:"registered"`
+	Metrics    map[string]float64 `json:"metrics,omitempty"`
+	Severity   string             `json:"severity,omitempty"`
+	// Priority is set if readings of the severity are approved ahead of
+	// bulk telemetry by this peer
+	Priority bool `json:"priority"`
+	// Timestamp compares the timestamp of the reading with the time of the
+	// evaluation
+	Timestamp *TimestampAttestation `json:"timestamp,omitempty"`
+	// PayloadRef references the off-chain payload of the reading, if any
+	PayloadRef *protoutil.PayloadReference `json:"payloadRef,omitempty"`
+	// Quality is the quality score the approval would record
+	Quality *QualityScore `json:"quality,omitempty"`
+}
+
+// EvaluateReading runs the validation of an approval on the candidate reading
+// in args[0], the protobuf encoded envelope of a sensory transaction not yet
+// submitted, on behalf of the creator's organization. Nothing is written: the
+// JSON encoded Evaluation tells the decision that would be recorded, so that
+// sensor gateways can check their payloads before submitting them.
+func (bscc *BSCC) EvaluateReading(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return shim.Error("Reading not specified")
+	}
+	envelope, err := protoutil.UnmarshalEnvelope(args[0])
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to unmarshal reading envelope: %s", err))
+	}
+
+	mspID, err := creatorMSPID(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	timestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+	}
+
+	evaluation := &Evaluation{}
+	err = bscc.evaluateReading(stub, mspID, timestamp.GetSeconds(), envelope, evaluation)
+	if rejection, ok := err.(*Rejection); ok {
+		evaluation.Reason = rejection.Reason
+		evaluation.Message = rejection.Message
+	} else if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to evaluate reading: %s", err))
+	} else {
+		evaluation.Approved = true
+	}
+
+	evaluationBytes, err := json.Marshal(evaluation)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal evaluation: %s", err))
+	}
+	return shim.Success(evaluationBytes)
+}
+
+// evaluateReading fills in the evaluation of the candidate reading as
+// ApproveSensoryReading validates a committed one, receiptTimestamp standing
+// for the time of receipt. A *Rejection is returned if the reading would be
+// rejected.
+func (bscc *BSCC) evaluateReading(stub shim.ChaincodeStubInterface, mspID string, receiptTimestamp int64, envelope *cb.Envelope, evaluation *Evaluation) error {
+	id, sensor, err := readingSensor(stub, envelope)
+	if err != nil {
+		return err
+	}
+	evaluation.SensorID = id
+	evaluation.Registered = sensor != nil
+
+	if sensor != nil && sensor.Decommission != nil {
+		// a candidate reading is committed after the final reading
+		return reject(ReasonSensorDecommissioned, "sensor %s was decommissioned after reading %s: %s",
+			sensor.ID, sensor.Decommission.FinalTxID, sensor.Decommission.Reason)
+	}
+	if sensor != nil && sensor.PairedWith != "" {
+		if err := bscc.validateCoSignature(stub.GetChannelID(), sensor, envelope); err != nil {
+			return err
+		}
+	}
+
+	options := bscc.currentOptions()
+	evaluation.Timestamp, err = attestTimestamp(envelope, receiptTimestamp, options.MaxClockSkew)
+	if err != nil {
+		return err
+	}
+	evaluation.Metrics, _, err = protoutil.ExtractMetricsReadingFromEnvelope(envelope)
+	if err != nil {
+		return reject(ReasonMalformedReading, "failed to extract reading: %s", err)
+	}
+	evaluation.Severity, err = protoutil.ExtractSeverityFromEnvelope(envelope)
+	if err != nil {
+		return reject(ReasonMalformedReading, "failed to extract reading severity: %s", err)
+	}
+	evaluation.Priority = options.IsPriority(evaluation.Severity)
+
+	evaluation.PayloadRef, err = protoutil.ExtractPayloadReferenceFromEnvelope(envelope)
+	if err != nil {
+		return reject(ReasonMalformedReading, "failed to extract payload reference: %s", err)
+	}
+
+	if _, err = checkSensorValidationPolicy(stub, "", mspID, id, envelope); err != nil {
+		return err
+	}
+	if err := bscc.verifyPayloadReference(envelope); err != nil {
+		return err
+	}
+	evaluation.Quality, err = scoreReading(stub, mspID, envelope, evaluation.Timestamp, options.MaxClockSkew)
+	return err
+}
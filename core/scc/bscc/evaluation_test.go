@@ -0,0 +1,109 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+// candidateReading returns the envelope of a reading created by the sensor
+// with the given args.
+func candidateReading(t *testing.T, creator []byte, args ...string) []byte {
+	envelope := readingEnvelope(t, args...)
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	require.NoError(t, err)
+	payload.Header.SignatureHeader = protoutil.MarshalOrPanic(&cb.SignatureHeader{Creator: creator})
+	envelope.Payload = protoutil.MarshalOrPanic(payload)
+	return protoutil.MarshalOrPanic(envelope)
+}
+
+func TestEvaluateReading(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+
+	evaluate := func(reading []byte) (*Evaluation, string) {
+		stub.MockTransactionStart("query")
+		defer stub.MockTransactionEnd("query")
+		resp := bscc.EvaluateReading(stub, [][]byte{reading})
+		if resp.Status != 200 {
+			return nil, resp.Message
+		}
+		evaluation := &Evaluation{}
+		require.NoError(t, json.Unmarshal(resp.Payload, evaluation))
+		return evaluation, ""
+	}
+
+	sensor1, sensor2 := sensorIdentity(t, "sensor1"), sensorIdentity(t, "sensor2")
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	_, msg := evaluate(nil)
+	require.Equal(t, "Reading not specified", msg)
+	_, msg = evaluate([]byte("not an envelope"))
+	require.Contains(t, msg, "Failed to unmarshal reading envelope")
+
+	// readings of unregistered sensors are validated against no policy
+	evaluation, msg := evaluate(candidateReading(t, sensor1, "Set", "21.5", "0.4", now, "", "", "alarm"))
+	require.Empty(t, msg)
+	require.True(t, evaluation.Approved)
+	require.Equal(t, "sensor1", evaluation.SensorID)
+	require.False(t, evaluation.Registered)
+	require.Equal(t, map[string]float64{"temperature": 21.5, "relativeHumidity": 0.4}, evaluation.Metrics)
+	require.Equal(t, "alarm", evaluation.Severity)
+	require.NotNil(t, evaluation.Timestamp)
+
+	evaluation, msg = evaluate(candidateReading(t, sensor1, "Set", "21.5"))
+	require.Empty(t, msg)
+	require.False(t, evaluation.Approved)
+	require.Equal(t, ReasonMalformedReading, evaluation.Reason)
+	require.Equal(t, "failed to extract reading timestamp: expected at least 4 reading args", evaluation.Message)
+
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP", Type: "dht22"}))
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor2", MSPID: "Org1MSP", Decommission: &Decommission{FinalTxID: "r2", Reason: "replaced"}}))
+	maxTemperature := 30.0
+	policyBytes, err := marshalState(&ValidationPolicy{SensorType: "dht22", Ranges: map[string]MetricRange{"temperature": {Max: &maxTemperature}}})
+	require.NoError(t, err)
+	key, err := stub.CreateCompositeKey(validationPolicyObjectType, []string{"dht22"})
+	require.NoError(t, err)
+	require.NoError(t, stub.PutState(key, policyBytes))
+	stub.MockTransactionEnd("setup")
+
+	evaluation, msg = evaluate(candidateReading(t, sensor1, "Set", "21.5", "0.4", now))
+	require.Empty(t, msg)
+	require.True(t, evaluation.Approved)
+	require.True(t, evaluation.Registered)
+	require.Equal(t, 0.875, evaluation.Quality.Score)
+
+	evaluation, msg = evaluate(candidateReading(t, sensor1, "Set", "35", "0.4", now))
+	require.Empty(t, msg)
+	require.False(t, evaluation.Approved)
+	require.Equal(t, ReasonMetricOutOfRange, evaluation.Reason)
+	require.Equal(t, "temperature of sensor sensor1 is 35, above the maximum of 30", evaluation.Message)
+
+	evaluation, msg = evaluate(candidateReading(t, sensor2, "Set", "21.5", "0.4", now))
+	require.Empty(t, msg)
+	require.False(t, evaluation.Approved)
+	require.Equal(t, ReasonSensorDecommissioned, evaluation.Reason)
+	require.Equal(t, "sensor sensor2 was decommissioned after reading r2: replaced", evaluation.Message)
+
+	// nothing is written
+	stub.MockTransactionStart("check")
+	last, err := loadLastReading(stub, "sensor1", "Org1MSP")
+	stub.MockTransactionEnd("check")
+	require.NoError(t, err)
+	require.Nil(t, last)
+}
@@ -0,0 +1,62 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"runtime"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/deadletter"
+)
+
+// recordFailure records the approval given up on in the dead-letter store,
+// with a snapshot of the peer to help finding out why it failed.
+func (s *BloccService) recordFailure(queues *approvalQueues, d event.Delivery, err error) {
+	failure := &deadletter.Failure{
+		ChannelID:   d.ChannelID,
+		SensoryTxID: d.SensoryTxID,
+		TraceID:     d.TraceID,
+		Attempts:    d.Attempt,
+		Error:       err.Error(),
+		FailedAt:    s.clock.Now().UTC(),
+		Diagnostics: s.diagnostics(queues, d.ChannelID),
+	}
+
+	path, err := deadletter.Default.WriteFailure(failure)
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to record the failed approval of reading %s: %s", d.SensoryTxID, err)
+		return
+	}
+	if path != "" {
+		bloccProtoLogger.Infof("Failed approval of reading %s, trace %s, recorded in %s", d.SensoryTxID, d.TraceID, path)
+	}
+}
+
+// diagnostics returns a snapshot of the approvals of the peer and of the
+// channel.
+func (s *BloccService) diagnostics(queues *approvalQueues, channelID string) *deadletter.Diagnostics {
+	diagnostics := &deadletter.Diagnostics{
+		Goroutines:  runtime.NumGoroutine(),
+		QueueDepths: map[string]int{},
+	}
+
+	queues.mu.Lock()
+	for id, cq := range queues.channels {
+		diagnostics.QueueDepths[id] = cq.length()
+		if lastError, ok := cq.lastError.Load().(string); ok && id == channelID {
+			diagnostics.LastOrdererError = lastError
+		}
+	}
+	queues.mu.Unlock()
+
+	if ledger := s.peerInfo.GetLedger(channelID); ledger != nil {
+		if info, err := ledger.GetBlockchainInfo(); err == nil {
+			diagnostics.ChannelHeight = info.Height
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,59 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/deadletter"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordFailure(t *testing.T) {
+	deadletter.Default.Set(t.TempDir(), 1)
+	defer deadletter.Default.Set("", 0)
+
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bscc := newTestBSCCWithClock(&mock.PeerInfoProvider{}, clock)
+
+	// queues without workers, so that the queued request stays queued
+	queues := newApprovalQueues()
+	cq := &channelQueues{channelID: "mychannel", bulk: make(chan event.Delivery, 2)}
+	cq.bulk <- event.Delivery{Event: event.Event{ChannelID: "mychannel", SensoryTxID: "tx2"}}
+	cq.lastError.Store("SERVICE_UNAVAILABLE")
+	queues.channels["mychannel"] = cq
+	queues.channels["otherchannel"] = &channelQueues{channelID: "otherchannel"}
+
+	d := event.Delivery{Event: event.Event{ChannelID: "mychannel", SensoryTxID: "tx1", TraceID: "trace1"}, Attempt: 5}
+	bscc.recordFailure(queues, d, errors.New("orderer unavailable"))
+
+	failureBytes, err := ioutil.ReadFile(deadletter.Default.FailurePath("mychannel", "trace1"))
+	require.NoError(t, err)
+	failure := &deadletter.Failure{}
+	require.NoError(t, json.Unmarshal(failureBytes, failure))
+
+	require.NotZero(t, failure.Diagnostics.Goroutines)
+	failure.Diagnostics.Goroutines = 0
+	require.Equal(t, &deadletter.Failure{
+		ChannelID:   "mychannel",
+		SensoryTxID: "tx1",
+		TraceID:     "trace1",
+		Attempts:    5,
+		Error:       "orderer unavailable",
+		FailedAt:    time.Unix(1700000000, 0).UTC(),
+		Diagnostics: &deadletter.Diagnostics{
+			QueueDepths:      map[string]int{"mychannel": 1, "otherchannel": 0},
+			LastOrdererError: "SERVICE_UNAVAILABLE",
+		},
+	}, failure)
+}
@@ -0,0 +1,194 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/pkg/errors"
+)
+
+// featureFlagObjectType is the composite key object type of the feature
+// flags of a channel, keyed by flag name.
+const featureFlagObjectType = "featureFlag"
+
+// autoApprovalFlag enables the automatic approval of the readings of the
+// channel by the peers.
+const autoApprovalFlag = "autoApproval"
+
+// privateApprovalsFlag makes the approval records of the channel private to
+// the approving organization: only the hash of their metadata is stored, and
+// queries by other organizations return them redacted.
+const privateApprovalsFlag = "privateApprovals"
+
+// featureFlagDefaults holds the value of the known feature flags that were
+// never set on a channel. Flags missing here default to disabled.
+var featureFlagDefaults = map[string]bool{
+	autoApprovalFlag:     true,
+	privateApprovalsFlag: false,
+}
+
+var featureFlagName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+
+// FeatureFlag toggles a behavior of BLOCC on a channel, so that behaviors can
+// be flipped without redeploying peers. MSPID, Timestamp and TxID record the
+// last change of the flag, and are empty for flags never set.
+type FeatureFlag struct {
+	Name      string `json:"name"`
+	Enabled   bool   `json:"enabled"`
+	MSPID     string `json:"mspID,omitempty"`
+	Timestamp int64  `json:"timestamp,omitempty"`
+	TxID      string `json:"txID,omitempty"`
+}
+
+// SetFeatureFlag enables or disables the feature flag named in args[0] on the
+// channel, args[1] holding the boolean value of the flag.
+func (bscc *BSCC) SetFeatureFlag(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 2 {
+		return shim.Error("Feature flag name and value not specified")
+	}
+	name := string(args[0])
+	if !featureFlagName.MatchString(name) {
+		return shim.Error(fmt.Sprintf("Invalid feature flag name '%s'", name))
+	}
+	enabled, err := strconv.ParseBool(string(args[1]))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Invalid value '%s' of feature flag %s", args[1], name))
+	}
+
+	mspID, err := creatorMSPID(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	timestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+	}
+
+	flag := &FeatureFlag{
+		Name:      name,
+		Enabled:   enabled,
+		MSPID:     mspID,
+		Timestamp: timestamp.GetSeconds(),
+		TxID:      stub.GetTxID(),
+	}
+	key, err := shim.CreateCompositeKey(featureFlagObjectType, []string{name})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to create feature flag key: %s", err))
+	}
+	flagBytes, err := marshalState(flag)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal feature flag: %s", err))
+	}
+	if err := stub.PutState(key, flagBytes); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to store feature flag %s: %s", name, err))
+	}
+
+	return shim.Success(nil)
+}
+
+// GetFeatureFlags returns the JSON encoded feature flags of the channel,
+// ordered by name, including the known flags never set.
+func (bscc *BSCC) GetFeatureFlags(stub shim.ChaincodeStubInterface) pb.Response {
+	flags := map[string]*FeatureFlag{}
+	for name, enabled := range featureFlagDefaults {
+		flags[name] = &FeatureFlag{Name: name, Enabled: enabled}
+	}
+
+	iterator, err := stub.GetStateByPartialCompositeKey(featureFlagObjectType, nil)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query feature flags: %s", err))
+	}
+	defer iterator.Close()
+
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to query feature flags: %s", err))
+		}
+		flag := &FeatureFlag{}
+		if err := json.Unmarshal(kv.Value, flag); err != nil {
+			return shim.Error(fmt.Sprintf("Failed to unmarshal feature flag %s: %s", kv.Key, err))
+		}
+		flags[flag.Name] = flag
+	}
+
+	result := make([]*FeatureFlag, 0, len(flags))
+	for _, flag := range flags {
+		result = append(result, flag)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal feature flags: %s", err))
+	}
+
+	return shim.Success(resultBytes)
+}
+
+// featureEnabled returns whether the feature flag is enabled on the channel,
+// as committed to the ledger of this peer.
+func (s *BloccService) featureEnabled(channelID, name string) (bool, error) {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return false, bloccerrors.WithCategory(errors.Errorf("channel %s not found", channelID), bloccerrors.ErrChannelNotFound)
+	}
+
+	key, err := shim.CreateCompositeKey(featureFlagObjectType, []string{name})
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to create feature flag key")
+	}
+
+	qe, err := ledger.NewQueryExecutor()
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to create query executor")
+	}
+	defer qe.Done()
+
+	flagBytes, err := qe.GetState("bscc", key)
+	if err != nil {
+		return false, errors.WithMessagef(err, "failed to get feature flag %s", name)
+	}
+
+	return decodeFeatureFlag(name, flagBytes)
+}
+
+// stubFeatureEnabled returns whether the feature flag is enabled on the
+// channel of the invocation.
+func stubFeatureEnabled(stub shim.ChaincodeStubInterface, name string) (bool, error) {
+	key, err := stub.CreateCompositeKey(featureFlagObjectType, []string{name})
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to create feature flag key")
+	}
+
+	flagBytes, err := stub.GetState(key)
+	if err != nil {
+		return false, errors.WithMessagef(err, "failed to get feature flag %s", name)
+	}
+
+	return decodeFeatureFlag(name, flagBytes)
+}
+
+func decodeFeatureFlag(name string, flagBytes []byte) (bool, error) {
+	if flagBytes == nil {
+		return featureFlagDefaults[name], nil
+	}
+
+	flag := &FeatureFlag{}
+	if err := json.Unmarshal(flagBytes, flag); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal feature flag %s", name)
+	}
+
+	return flag.Enabled, nil
+}
@@ -0,0 +1,64 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatureFlags(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := &BSCC{}
+
+	set := func(txID string, args ...string) string {
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		stub.MockTransactionStart(txID)
+		defer stub.MockTransactionEnd(txID)
+		return bscc.SetFeatureFlag(stub, byteArgs).Message
+	}
+	get := func() []*FeatureFlag {
+		resp := bscc.GetFeatureFlags(stub)
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+		var flags []*FeatureFlag
+		require.NoError(t, json.Unmarshal(resp.Payload, &flags))
+		return flags
+	}
+
+	require.Equal(t, []*FeatureFlag{{Name: "autoApproval", Enabled: true}, {Name: "privateApprovals", Enabled: false}}, get())
+
+	require.Empty(t, set("tx1", "autoApproval", "false"))
+	require.Empty(t, set("tx2", "batching", "true"))
+	flags := get()
+	require.Len(t, flags, 3)
+	require.Equal(t, "autoApproval", flags[0].Name)
+	require.False(t, flags[0].Enabled)
+	require.Equal(t, "Org1MSP", flags[0].MSPID)
+	require.Equal(t, "tx1", flags[0].TxID)
+	require.Equal(t, "batching", flags[1].Name)
+	require.True(t, flags[1].Enabled)
+	require.Equal(t, "privateApprovals", flags[2].Name)
+
+	enabled, err := stubFeatureEnabled(stub, "autoApproval")
+	require.NoError(t, err)
+	require.False(t, enabled)
+	enabled, err = stubFeatureEnabled(stub, "privateApprovals")
+	require.NoError(t, err)
+	require.False(t, enabled)
+
+	require.Equal(t, "Invalid value 'maybe' of feature flag batching", set("tx3", "batching", "maybe"))
+	require.Equal(t, "Invalid feature flag name '1flag'", set("tx4", "1flag", "true"))
+	require.Equal(t, "Feature flag name and value not specified", set("tx5", "batching"))
+}
@@ -0,0 +1,150 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// firmwareAttestationObjectType is the composite key object type of the
+// firmware attestations, keyed by sensor ID, zero-padded timestamp and
+// transaction ID so that the attestations of a sensor are ordered in time,
+// and by the MSP ID of the organization of the sensor owning the key.
+const firmwareAttestationObjectType = "firmwareAttestation"
+
+// FirmwareAttestation records the firmware a sensor runs, as attested by its
+// gateway. The attestation is in effect from its timestamp until the next
+// attestation of the sensor.
+type FirmwareAttestation struct {
+	SensorID string `json:"sensorID"`
+	Version  string `json:"version"`
+	// Hash is the hex encoded SHA-256 hash of the firmware image
+	Hash string `json:"hash"`
+	// Signature is the attestation signature produced by the sensor, kept
+	// as evidence of the attestation
+	Signature []byte `json:"signature"`
+	MSPID     string `json:"mspID"`
+	// TxID and Timestamp are those of the attestation transaction
+	TxID      string `json:"txID"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// AttestSensorFirmware records the firmware attestation of the registered
+// sensor in args[0], submitted by its gateway on behalf of its organization.
+// args[1] is the firmware version, args[2] the hex encoded SHA-256 hash of
+// the firmware image and args[3] the base64 encoded attestation signature.
+// The JSON encoded FirmwareAttestation is returned.
+func (bscc *BSCC) AttestSensorFirmware(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	sensor, err := ownedSensor(stub, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if sensor.Decommission != nil {
+		return shim.Error(fmt.Sprintf("Sensor %s is decommissioned", sensor.ID))
+	}
+	if len(args) < 2 || len(args[1]) == 0 {
+		return shim.Error("Firmware version not specified")
+	}
+	if len(args) < 3 || len(args[2]) == 0 {
+		return shim.Error("Firmware hash not specified")
+	}
+	if hash, err := hex.DecodeString(string(args[2])); err != nil || len(hash) != 32 {
+		return shim.Error(fmt.Sprintf("Invalid firmware hash '%s', expected a hex encoded SHA-256 hash", args[2]))
+	}
+	if len(args) < 4 || len(args[3]) == 0 {
+		return shim.Error("Attestation signature not specified")
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(args[3]))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Invalid attestation signature, expected base64: %s", err))
+	}
+
+	timestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+	}
+	attestation := &FirmwareAttestation{
+		SensorID:  sensor.ID,
+		Version:   string(args[1]),
+		Hash:      string(args[2]),
+		Signature: signature,
+		MSPID:     sensor.MSPID,
+		TxID:      stub.GetTxID(),
+		Timestamp: timestamp.GetSeconds(),
+	}
+
+	key, err := stub.CreateCompositeKey(firmwareAttestationObjectType, []string{sensor.ID, fmt.Sprintf("%020d", attestation.Timestamp), attestation.TxID, attestation.MSPID})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to create firmware attestation key: %s", err))
+	}
+	attestationBytes, err := marshalState(attestation)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal firmware attestation: %s", err))
+	}
+	if err := stub.PutState(key, attestationBytes); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to store firmware attestation of sensor %s: %s", sensor.ID, err))
+	}
+
+	attestationBytes, err = json.Marshal(attestation)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal firmware attestation: %s", err))
+	}
+
+	return shim.Success(attestationBytes)
+}
+
+// activeFirmware returns the last firmware attestation of the sensor at or
+// before timestamp, nil if there is none.
+func activeFirmware(stub shim.ChaincodeStubInterface, sensorID string, timestamp int64) (*FirmwareAttestation, error) {
+	iterator, err := stub.GetStateByPartialCompositeKey(firmwareAttestationObjectType, []string{sensorID})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to query firmware attestations of sensor %s", sensorID)
+	}
+	defer iterator.Close()
+
+	var active *FirmwareAttestation
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to query firmware attestations of sensor %s", sensorID)
+		}
+		attestation := &FirmwareAttestation{}
+		if err := json.Unmarshal(kv.Value, attestation); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal firmware attestation of sensor %s", sensorID)
+		}
+		if attestation.Timestamp > timestamp {
+			break
+		}
+		active = attestation
+	}
+
+	return active, nil
+}
+
+// checkFirmware rejects the reading of the sensor at timestamp unless the
+// firmware active then is one of the allowed versions.
+func checkFirmware(stub shim.ChaincodeStubInterface, sensorID string, timestamp int64, allowed []string) error {
+	firmware, err := activeFirmware(stub, sensorID, timestamp)
+	if err != nil {
+		return err
+	}
+	if firmware == nil {
+		return reject(ReasonFirmwareNotAllowed, "sensor %s has no firmware attested at %d", sensorID, timestamp)
+	}
+	for _, version := range allowed {
+		if firmware.Version == version {
+			return nil
+		}
+	}
+	return reject(ReasonFirmwareNotAllowed, "firmware %s of sensor %s is not allowed", firmware.Version, sensorID)
+}
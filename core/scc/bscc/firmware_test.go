@@ -0,0 +1,112 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestSensorFirmware(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+	hash := strings.Repeat("ab", 32)
+
+	attest := func(txID string, seconds int64, args ...string) *FirmwareAttestation {
+		stub.MockTransactionStart(txID)
+		defer stub.MockTransactionEnd(txID)
+		stub.TxTimestamp = &timestamp.Timestamp{Seconds: seconds}
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		resp := bscc.AttestSensorFirmware(stub, byteArgs)
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+		attestation := &FirmwareAttestation{}
+		require.NoError(t, json.Unmarshal(resp.Payload, attestation))
+		return attestation
+	}
+	attestError := func(args ...string) string {
+		stub.MockTransactionStart("tx0")
+		defer stub.MockTransactionEnd("tx0")
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		return bscc.AttestSensorFirmware(stub, byteArgs).Message
+	}
+
+	require.Equal(t, "Sensor sensor1 is not registered", attestError("sensor1", "1.0.0", hash, "c2ln"))
+
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP", Type: "dht22"}))
+	stub.MockTransactionEnd("setup")
+
+	require.Equal(t, "Firmware version not specified", attestError("sensor1"))
+	require.Equal(t, "Firmware hash not specified", attestError("sensor1", "1.0.0"))
+	require.Equal(t, "Invalid firmware hash 'abcd', expected a hex encoded SHA-256 hash", attestError("sensor1", "1.0.0", "abcd"))
+	require.Equal(t, "Attestation signature not specified", attestError("sensor1", "1.0.0", hash))
+	require.Contains(t, attestError("sensor1", "1.0.0", hash, "!"), "Invalid attestation signature, expected base64")
+
+	require.Equal(t, &FirmwareAttestation{
+		SensorID:  "sensor1",
+		Version:   "1.0.0",
+		Hash:      hash,
+		Signature: []byte("sig"),
+		MSPID:     "Org1MSP",
+		TxID:      "tx1",
+		Timestamp: 1628887200,
+	}, attest("tx1", 1628887200, "sensor1", "1.0.0", hash, "c2ln"))
+	attest("tx2", 1628890800, "sensor1", "1.1.0", hash, "c2ln")
+
+	// the firmware active at the time of a reading is the last one attested
+	// before it
+	for _, tc := range []struct {
+		timestamp int64
+		version   string
+	}{
+		{1628887199, ""},
+		{1628887200, "1.0.0"},
+		{1628890799, "1.0.0"},
+		{1628890800, "1.1.0"},
+	} {
+		firmware, err := activeFirmware(stub, "sensor1", tc.timestamp)
+		require.NoError(t, err)
+		if tc.version == "" {
+			require.Nil(t, firmware)
+			continue
+		}
+		require.Equal(t, tc.version, firmware.Version)
+	}
+
+	// the readings of disallowed or unattested firmware are rejected
+	stub.MockTransactionStart("setup")
+	policyBytes, err := marshalState(&ValidationPolicy{SensorType: "dht22", AllowedFirmwareVersions: []string{"1.1.0"}})
+	require.NoError(t, err)
+	key, err := stub.CreateCompositeKey(validationPolicyObjectType, []string{"dht22"})
+	require.NoError(t, err)
+	require.NoError(t, stub.PutState(key, policyBytes))
+	stub.MockTransactionEnd("setup")
+
+	check := func(sensorTimestamp string) error {
+		stub.MockTransactionStart("tx3")
+		defer stub.MockTransactionEnd("tx3")
+		_, err := checkSensorValidationPolicy(stub, "tx3", "Org1MSP", "sensor1", readingEnvelope(t, "Set", "21.5", "0.4", sensorTimestamp))
+		return err
+	}
+	require.NoError(t, check("1628890900"))
+	require.Equal(t, reject(ReasonFirmwareNotAllowed, "firmware 1.0.0 of sensor sensor1 is not allowed"), check("1628887300"))
+	require.Equal(t, reject(ReasonFirmwareNotAllowed, "sensor sensor1 has no firmware attested at 1628887100"), check("1628887100"))
+}
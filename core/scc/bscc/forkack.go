@@ -0,0 +1,220 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/pkg/errors"
+)
+
+// forkAckPath is the file recording the acknowledgement of the fork of the
+// channel, next to its fork information.
+func forkAckPath(channelID string) string {
+	return filepath.Join(filepath.Dir(forkInfoPath(channelID)), "fork_ack.json")
+}
+
+// ForkAcknowledgement records that an operator handled the fork of a channel.
+type ForkAcknowledgement struct {
+	ChannelID string `json:"channelID"`
+	// MSPID and Operator identify the operator, Operator being the subject
+	// of its certificate
+	MSPID          string    `json:"mspID"`
+	Operator       string    `json:"operator"`
+	Note           string    `json:"note,omitempty"`
+	AcknowledgedAt time.Time `json:"acknowledgedAt"`
+	// Cleared is set if the fork information was cleared on acknowledgement
+	Cleared bool `json:"cleared,omitempty"`
+}
+
+// ForkState is the detailed fork status of a channel. A fork is acknowledged
+// if it was detected before the last acknowledgement of the channel, a fork
+// detected again afterwards being reported as new.
+type ForkState struct {
+	Forked          bool                 `json:"forked"`
+	Acknowledged    bool                 `json:"acknowledged"`
+	Acknowledgement *ForkAcknowledgement `json:"acknowledgement,omitempty"`
+}
+
+// forkAckStore keeps the fork acknowledgements of the channels.
+type forkAckStore struct {
+	path func(channelID string) string
+	// detectedAt returns when the fork of the channel was last detected
+	detectedAt func(channelID string) (time.Time, error)
+	clock      clock.Clock
+}
+
+func newForkAckStore(clk clock.Clock) *forkAckStore {
+	return &forkAckStore{
+		path: forkAckPath,
+		detectedAt: func(channelID string) (time.Time, error) {
+			info, err := os.Stat(forkInfoPath(channelID))
+			if err != nil {
+				return time.Time{}, err
+			}
+			return info.ModTime(), nil
+		},
+		clock: clk,
+	}
+}
+
+// read returns the last acknowledgement of the channel, or nil if there is
+// none.
+func (s *forkAckStore) read(channelID string) (*ForkAcknowledgement, error) {
+	ackBytes, err := ioutil.ReadFile(s.path(channelID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read fork acknowledgement of channel %s", channelID)
+	}
+
+	ack := &ForkAcknowledgement{}
+	if err := json.Unmarshal(ackBytes, ack); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal fork acknowledgement of channel %s", channelID)
+	}
+	return ack, nil
+}
+
+// write replaces the acknowledgement of its channel.
+func (s *forkAckStore) write(ack *ForkAcknowledgement) error {
+	ackBytes, err := json.Marshal(ack)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal fork acknowledgement")
+	}
+
+	path := s.path(ack.ChannelID)
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, ackBytes, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "failed to rename %s", tmpPath)
+	}
+	return nil
+}
+
+// state returns the detailed fork status of the channel.
+func (s *forkAckStore) state(channelID string, forked bool) (*ForkState, error) {
+	ack, err := s.read(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &ForkState{Forked: forked, Acknowledgement: ack}
+	if forked && ack != nil {
+		detectedAt, err := s.detectedAt(channelID)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "failed to read fork information of channel %s", channelID)
+		}
+		state.Acknowledged = !detectedAt.After(ack.AcknowledgedAt)
+	}
+	return state, nil
+}
+
+// AcknowledgeFork marks the fork of the channel as handled by the creator of
+// the proposal, with the note of the operator, and clears the fork
+// information if requested, e.g. once the channel recovered.
+func (bscc *BSCC) AcknowledgeFork(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 1 || len(args[0]) == 0 {
+		return shim.Error(messages.Sprintf(messages.ChannelIDRequired))
+	}
+	channelID := string(args[0])
+	var note string
+	if len(args) > 1 {
+		note = string(args[1])
+	}
+	var clearFork bool
+	if len(args) > 2 && len(args[2]) > 0 {
+		var err error
+		if clearFork, err = strconv.ParseBool(string(args[2])); err != nil {
+			return shim.Error(fmt.Sprintf("Invalid clear flag '%s'", args[2]))
+		}
+	}
+
+	if !bscc.forkStatuses.get(channelID, 0) {
+		return shim.Error(fmt.Sprintf("channel %s is not forked", channelID))
+	}
+
+	mspID, operator, err := operatorIdentity(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	ack := &ForkAcknowledgement{
+		ChannelID:      channelID,
+		MSPID:          mspID,
+		Operator:       operator,
+		Note:           note,
+		AcknowledgedAt: bscc.forkAcks.clock.Now(),
+	}
+	if err := bscc.forkAcks.write(ack); err != nil {
+		return shim.Error(err.Error())
+	}
+	bloccProtoLogger.Warningf("Fork of channel %s acknowledged by %s of %s: %s", channelID, operator, mspID, note)
+
+	if clearFork {
+		if _, err := bscc.clearForkInfo(channelID); err != nil {
+			return shim.Error(err.Error())
+		}
+		ack.Cleared = true
+		if err := bscc.forkAcks.write(ack); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
+	bscc.eventBus.Publish(event.Event{
+		Type:      event.ForkAcknowledged,
+		ChannelID: channelID,
+		MSPID:     mspID,
+		Note:      note,
+	})
+
+	ackBytes, err := json.Marshal(ack)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal fork acknowledgement: %s", err))
+	}
+	return shim.Success(ackBytes)
+}
+
+// operatorIdentity returns the MSP ID of the creator of the proposal and the
+// subject of its certificate.
+func operatorIdentity(stub shim.ChaincodeStubInterface) (string, string, error) {
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return "", "", errors.WithMessage(err, "failed to get creator")
+	}
+
+	serializedIdentity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, serializedIdentity); err != nil {
+		return "", "", errors.Wrap(err, "failed to unmarshal creator identity")
+	}
+
+	block, _ := pem.Decode(serializedIdentity.IdBytes)
+	if block == nil {
+		return serializedIdentity.Mspid, "", nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to parse creator certificate")
+	}
+
+	return serializedIdentity.Mspid, cert.Subject.String(), nil
+}
@@ -0,0 +1,129 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcknowledgeFork(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: ca.CertBytes()})
+
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	detectedAt := clock.Now().Add(-time.Minute)
+	forked := map[string]bool{"forkedchannel": true}
+	dir := t.TempDir()
+	bscc := newTestBSCCWithClock(&mock.PeerInfoProvider{}, clock)
+	events := bscc.eventBus.Subscribe("test")
+	defer bscc.eventBus.Unsubscribe(events)
+	bscc.forkStatuses.stat = func(channelID string) bool { return forked[channelID] }
+	bscc.forkStatuses.remove = func(channelID string) error {
+		delete(forked, channelID)
+		return nil
+	}
+	bscc.forkAcks.path = func(channelID string) string { return filepath.Join(dir, channelID+".json") }
+	bscc.forkAcks.detectedAt = func(string) (time.Time, error) { return detectedAt, nil }
+
+	state := func(channelID string) *ForkState {
+		resp := bscc.CheckForkStatus(channelID, true)
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+		status := &ForkStatusResponse{}
+		require.NoError(t, json.Unmarshal(resp.Payload, status))
+		require.Equal(t, ForkStatusVersion, status.Version)
+		require.Equal(t, status.Forked, status.Record.Forked)
+		return status.Record
+	}
+	require.Equal(t, &ForkState{Forked: true}, state("forkedchannel"))
+
+	resp := bscc.AcknowledgeFork(stub, [][]byte{[]byte("mychannel"), []byte("note")})
+	require.Equal(t, "channel mychannel is not forked", resp.Message)
+
+	resp = bscc.AcknowledgeFork(stub, [][]byte{[]byte("forkedchannel"), []byte("rolled back orderer")})
+	require.Equal(t, int32(200), resp.Status, resp.Message)
+	ack := &ForkAcknowledgement{}
+	require.NoError(t, json.Unmarshal(resp.Payload, ack))
+	require.Equal(t, "Org1MSP", ack.MSPID)
+	require.Contains(t, ack.Operator, "SERIALNUMBER=")
+	require.Equal(t, "rolled back orderer", ack.Note)
+	require.False(t, ack.Cleared)
+
+	select {
+	case e := <-events:
+		require.Equal(t, event.Event{Type: event.ForkAcknowledged, ChannelID: "forkedchannel", MSPID: "Org1MSP", Note: "rolled back orderer"}, e)
+	case <-time.After(time.Second):
+		t.Fatal("expected a fork acknowledgement event")
+	}
+
+	s := state("forkedchannel")
+	require.True(t, s.Forked)
+	require.True(t, s.Acknowledged)
+	require.True(t, ack.AcknowledgedAt.Equal(s.Acknowledgement.AcknowledgedAt))
+
+	// a fork detected after the acknowledgement is a new one
+	detectedAt = clock.Now().Add(time.Minute)
+	require.False(t, state("forkedchannel").Acknowledged)
+
+	// the fork information is cleared on request, the acknowledgement being
+	// kept
+	resp = bscc.AcknowledgeFork(stub, [][]byte{[]byte("forkedchannel"), nil, []byte("true")})
+	require.Equal(t, int32(200), resp.Status, resp.Message)
+	s = state("forkedchannel")
+	require.False(t, s.Forked)
+	require.False(t, s.Acknowledged)
+	require.True(t, s.Acknowledgement.Cleared)
+
+	resp = bscc.AcknowledgeFork(stub, [][]byte{[]byte("forkedchannel"), nil, []byte("maybe")})
+	require.Equal(t, "Invalid clear flag 'maybe'", resp.Message)
+	_, err = os.Stat(filepath.Join(dir, "mychannel.json"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestAcknowledgeForkChecksTargetChannel(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	dir := t.TempDir()
+	bscc := New(NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus()), &channelACLProvider{channelID: "mychannel"})
+	bscc.forkStatuses.stat = func(channelID string) bool { return channelID == "forkedchannel" }
+	bscc.forkAcks.path = func(channelID string) string { return filepath.Join(dir, channelID+".json") }
+
+	invoke := func() pb.Response {
+		stub := shimtest.NewMockStub("bscc", bscc)
+		stub.ChannelID = "mychannel"
+		stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: ca.CertBytes()})
+		sp, _ := protoutil.MockSignedEndorserProposalOrPanic("mychannel", &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "bscc"}}, nil, nil)
+		return stub.MockInvokeWithSignedProposal("tx1", [][]byte{[]byte(acknowledgeFork), []byte("forkedchannel"), []byte("note")}, sp)
+	}
+
+	// a caller permitted on another channel cannot acknowledge the fork
+	resp := invoke()
+	require.Equal(t, "access denied for [AcknowledgeFork]: bscc/AcknowledgeFork is not permitted on channel forkedchannel", resp.Message)
+	_, err = os.Stat(filepath.Join(dir, "forkedchannel.json"))
+	require.True(t, os.IsNotExist(err))
+
+	bscc.aclProvider = &channelACLProvider{channelID: "forkedchannel"}
+	resp = invoke()
+	require.Equal(t, int32(200), resp.Status, resp.Message)
+	_, err = os.Stat(filepath.Join(dir, "forkedchannel.json"))
+	require.NoError(t, err)
+}
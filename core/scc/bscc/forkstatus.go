@@ -0,0 +1,268 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/pkg/errors"
+)
+
+// forkInfoPath is the file written by the deliver service when a fork of the
+// channel is detected.
+func forkInfoPath(channelID string) string {
+	return fmt.Sprintf("/var/hyperledger/production/ledgersData/chains/chains/%s/fork_info.txt", channelID)
+}
+
+// ForkStatusVersion is the version of the schema of the CheckForkStatus
+// responses. Fields may be added to the responses without a new version, a
+// new version breaking the clients of the previous one.
+const ForkStatusVersion = 1
+
+// ChannelForkStatus is the fork status of a channel.
+type ChannelForkStatus struct {
+	Forked bool `json:"forked"`
+	// Record is the detailed fork state of the channel, only set if
+	// requested
+	Record *ForkState `json:"record"`
+}
+
+// legacy returns the fork status of the channel in the format of the
+// unversioned responses, the bare status or the ForkState if detailed.
+func (s ChannelForkStatus) legacy(detailed bool) interface{} {
+	if detailed {
+		return s.Record
+	}
+	return s.Forked
+}
+
+// ForkStatusResponse is the CheckForkStatus response for a channel.
+type ForkStatusResponse struct {
+	Version int `json:"version"`
+	ChannelForkStatus
+}
+
+// ForkStatusesResponse is the CheckForkStatus response for every channel the
+// peer has joined.
+type ForkStatusesResponse struct {
+	Version  int                          `json:"version"`
+	Channels map[string]ChannelForkStatus `json:"channels"`
+}
+
+func legacyForkStatuses(statuses map[string]ChannelForkStatus, detailed bool) map[string]interface{} {
+	legacy := map[string]interface{}{}
+	for channelID, status := range statuses {
+		legacy[channelID] = status.legacy(detailed)
+	}
+	return legacy
+}
+
+// channelForkStatus returns the fork status of the channel, with its fork
+// state if detailed.
+func (s *BloccService) channelForkStatus(channelID string, forked, detailed bool) (ChannelForkStatus, error) {
+	status := ChannelForkStatus{Forked: forked}
+	if detailed {
+		state, err := s.forkAcks.state(channelID, forked)
+		if err != nil {
+			return ChannelForkStatus{}, err
+		}
+		status.Record = state
+	}
+	return status, nil
+}
+
+type forkStatus struct {
+	forked    bool
+	checkedAt time.Time
+	// forkedAt is when the channel was first seen forked
+	forkedAt time.Time
+}
+
+// forkStatusCache keeps the fork status of each channel for a TTL so that
+// frequent polling does not hit the filesystem, and publishes an event when
+// the status of a channel changes.
+type forkStatusCache struct {
+	mutex    sync.Mutex
+	statuses map[string]forkStatus
+	stat     func(channelID string) bool
+	remove   func(channelID string) error
+	bus      *event.Bus
+	clock    clock.Clock
+}
+
+func newForkStatusCache(bus *event.Bus, clk clock.Clock) *forkStatusCache {
+	return &forkStatusCache{
+		statuses: map[string]forkStatus{},
+		stat: func(channelID string) bool {
+			_, err := os.Stat(forkInfoPath(channelID))
+			return !os.IsNotExist(err)
+		},
+		remove: func(channelID string) error {
+			return os.Remove(forkInfoPath(channelID))
+		},
+		bus:   bus,
+		clock: clk,
+	}
+}
+
+// get returns the fork status of the channel, refreshing it if the cached
+// status is older than ttl.
+func (c *forkStatusCache) get(channelID string, ttl time.Duration) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cached, ok := c.statuses[channelID]
+	if ok && c.clock.Now().Sub(cached.checkedAt) < ttl {
+		return cached.forked
+	}
+	return c.refresh(channelID)
+}
+
+// refresh checks the fork status of the channel, publishing an event if it
+// changed. The mutex must be held.
+func (c *forkStatusCache) refresh(channelID string) bool {
+	now := c.clock.Now()
+	cached, ok := c.statuses[channelID]
+	forked := c.stat(channelID)
+	status := forkStatus{forked: forked, checkedAt: now}
+	if forked {
+		status.forkedAt = now
+		if ok && cached.forked {
+			status.forkedAt = cached.forkedAt
+		}
+	}
+	c.statuses[channelID] = status
+
+	if ok && cached.forked != forked {
+		bloccProtoLogger.Warningf("Fork status of channel %s changed to forked=%t", channelID, forked)
+		c.bus.Publish(event.Event{
+			Type:      event.ForkStatusChanged,
+			ChannelID: channelID,
+			Forked:    forked,
+		})
+	}
+
+	return forked
+}
+
+// clear removes the fork information of the channel and refreshes its status,
+// returning whether the fork information was found.
+func (c *forkStatusCache) clear(channelID string) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err := c.remove(channelID)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to remove fork information of channel %s", channelID)
+	}
+
+	c.refresh(channelID)
+	return true, nil
+}
+
+// forkedLongerThan returns the channels whose cached status has been forked
+// for longer than d.
+func (c *forkStatusCache) forkedLongerThan(d time.Duration) []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var channelIDs []string
+	now := c.clock.Now()
+	for channelID, status := range c.statuses {
+		if status.forked && now.Sub(status.forkedAt) > d {
+			channelIDs = append(channelIDs, channelID)
+		}
+	}
+	sort.Strings(channelIDs)
+	return channelIDs
+}
+
+// getAll returns the fork status of every listed channel.
+func (c *forkStatusCache) getAll(channelIDs []string, ttl time.Duration) map[string]bool {
+	statuses := map[string]bool{}
+	for _, channelID := range channelIDs {
+		statuses[channelID] = c.get(channelID, ttl)
+	}
+	return statuses
+}
+
+// retain forgets the status of the channels that are not listed, e.g. those
+// the peer no longer belongs to.
+func (c *forkStatusCache) retain(channelIDs []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	joined := map[string]bool{}
+	for _, channelID := range channelIDs {
+		joined[channelID] = true
+	}
+	for channelID := range c.statuses {
+		if !joined[channelID] {
+			delete(c.statuses, channelID)
+		}
+	}
+}
+
+// monitorForks periodically checks the fork status of every channel this
+// peer has joined, so that forks are published on the event bus without
+// anyone polling CheckForkStatus. The channels are enumerated on every round
+// so that joined and removed channels are picked up.
+func (s *BloccService) monitorForks(stop <-chan struct{}) {
+	for {
+		options := s.currentOptions()
+		if !s.sleep(stop, options.ForkMonitorInterval) {
+			return
+		}
+		if !options.ForkMonitorEnabled {
+			continue
+		}
+
+		channelIDs := s.joinedChannels()
+		s.forkStatuses.retain(channelIDs)
+		s.forkStatuses.getAll(channelIDs, options.ForkStatusCacheTTL)
+
+		if options.ForkCleanupAfter > 0 {
+			for _, channelID := range s.forkStatuses.forkedLongerThan(options.ForkCleanupAfter) {
+				bloccProtoLogger.Warningf("Channel %s forked for longer than %s, clearing its fork information", channelID, options.ForkCleanupAfter)
+				s.clearForkInfo(channelID)
+			}
+		}
+	}
+}
+
+// clearForkInfo removes the fork information of the channel, e.g. the one
+// left by a simulated fork, so that the channel is no longer reported as
+// forked. The delivery of blocks stopped on the fork only resumes once the
+// peer restarts.
+func (s *BloccService) clearForkInfo(channelID string) (bool, error) {
+	cleared, err := s.forkStatuses.clear(channelID)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to clear fork information: %s", err)
+		return false, err
+	}
+	if cleared {
+		bloccProtoLogger.Infof("Fork information of channel %s cleared", channelID)
+	}
+	return cleared, nil
+}
+
+// joinedChannels returns the IDs of the channels this peer has joined.
+func (s *BloccService) joinedChannels() []string {
+	var channelIDs []string
+	for _, channel := range s.peerInfo.GetChannelsInfo() {
+		channelIDs = append(channelIDs, channel.ChannelId)
+	}
+	return channelIDs
+}
@@ -0,0 +1,104 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkStatusCache(t *testing.T) {
+	bus := event.NewEventBus()
+	events := bus.Subscribe("test")
+	defer bus.Unsubscribe(events)
+
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	forked := false
+	var stats int
+	cache := newForkStatusCache(bus, clock)
+	cache.stat = func(string) bool {
+		stats++
+		return forked
+	}
+
+	require.False(t, cache.get("mychannel", 5*time.Second))
+	require.Equal(t, 1, stats)
+
+	// served from memory within the TTL
+	forked = true
+	clock.Increment(4 * time.Second)
+	require.False(t, cache.get("mychannel", 5*time.Second))
+	require.Equal(t, 1, stats)
+
+	// refreshed once the TTL expired, publishing the change
+	clock.Increment(time.Second)
+	require.True(t, cache.get("mychannel", 5*time.Second))
+	require.Equal(t, 2, stats)
+
+	select {
+	case e := <-events:
+		require.Equal(t, event.Event{Type: event.ForkStatusChanged, ChannelID: "mychannel", Forked: true}, e)
+	case <-time.After(time.Second):
+		t.Fatal("expected a fork status change event")
+	}
+}
+
+func TestForkStatusCacheChannels(t *testing.T) {
+	forked := map[string]bool{"forkedchannel": true}
+	cache := newForkStatusCache(event.NewEventBus(), fakeclock.NewFakeClock(time.Unix(1700000000, 0)))
+	cache.stat = func(channelID string) bool { return forked[channelID] }
+
+	statuses := cache.getAll([]string{"mychannel", "forkedchannel"}, time.Minute)
+	require.Equal(t, map[string]bool{"mychannel": false, "forkedchannel": true}, statuses)
+	require.Len(t, cache.statuses, 2)
+
+	// channels the peer no longer belongs to are forgotten
+	cache.retain([]string{"mychannel", "newchannel"})
+	require.Len(t, cache.statuses, 1)
+	require.Contains(t, cache.statuses, "mychannel")
+}
+
+func TestForkStatusCacheClear(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	forked := map[string]bool{"forkedchannel": true}
+	cache := newForkStatusCache(event.NewEventBus(), clock)
+	cache.stat = func(channelID string) bool { return forked[channelID] }
+	cache.remove = func(channelID string) error {
+		if !forked[channelID] {
+			return os.ErrNotExist
+		}
+		delete(forked, channelID)
+		return nil
+	}
+
+	cache.getAll([]string{"mychannel", "forkedchannel"}, time.Minute)
+	clock.Increment(5 * time.Minute)
+	cache.getAll([]string{"mychannel", "forkedchannel"}, 0)
+	require.Empty(t, cache.forkedLongerThan(10*time.Minute))
+
+	// the channel is forked since it was first seen forked
+	clock.Increment(5 * time.Minute)
+	require.Equal(t, []string{"forkedchannel"}, cache.forkedLongerThan(9*time.Minute))
+
+	cleared, err := cache.clear("forkedchannel")
+	require.NoError(t, err)
+	require.True(t, cleared)
+	require.False(t, cache.get("forkedchannel", time.Minute))
+	require.Empty(t, cache.forkedLongerThan(0))
+
+	cleared, err = cache.clear("mychannel")
+	require.NoError(t, err)
+	require.False(t, cleared)
+
+	cache.remove = func(string) error { return os.ErrPermission }
+	_, err = cache.clear("mychannel")
+	require.EqualError(t, err, "failed to remove fork information of channel mychannel: permission denied")
+}
@@ -0,0 +1,104 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+)
+
+// WatchForkStatusPath is the path of the WatchForkStatus endpoint of the
+// operations server of the peer.
+const WatchForkStatusPath = "/blocc/forkstatus/watch"
+
+// ForkStatusChange is a message of the WatchForkStatus stream.
+type ForkStatusChange struct {
+	// Version is the ForkStatusVersion of the message
+	Version   int    `json:"version"`
+	ChannelID string `json:"channelID"`
+	Forked    bool   `json:"forked"`
+	// Snapshot is set for the statuses of the joined channels sent when the
+	// stream opens, before the changes
+	Snapshot bool      `json:"snapshot,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// WatchForkStatus returns the handler of the WatchForkStatus endpoint,
+// streaming the fork status of every channel the peer has joined and then
+// its changes as they are detected, as newline delimited JSON
+// ForkStatusChange messages, so that monitoring systems need not poll
+// CheckForkStatus channel by channel. The stream ends when the client goes
+// away or the service stops.
+func (s *BloccService) WatchForkStatus() http.Handler {
+	return http.HandlerFunc(s.watchForkStatus)
+}
+
+func (s *BloccService) watchForkStatus(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.runLock.Lock()
+	stop := s.stop
+	s.runLock.Unlock()
+	if stop == nil {
+		http.Error(w, "BLOCC service is not running", http.StatusServiceUnavailable)
+		return
+	}
+
+	// the watcher subscribes before the snapshot is taken so that no change
+	// is missed in between, a change being possibly sent twice
+	events := s.eventBus.Subscribe("fork-status-watch " + req.RemoteAddr)
+	defer s.eventBus.Unsubscribe(events)
+
+	// the stream outlives the write timeout of the operations server
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		bloccProtoLogger.Debugf("Failed to clear the write deadline of the fork status watch of %s: %s", req.RemoteAddr, err)
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	send := func(change ForkStatusChange) bool {
+		change.Version = ForkStatusVersion
+		if err := encoder.Encode(&change); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	ttl := s.currentOptions().ForkStatusCacheTTL
+	for _, channelID := range s.joinedChannels() {
+		forked := s.forkStatuses.get(channelID, ttl)
+		if !send(ForkStatusChange{ChannelID: channelID, Forked: forked, Snapshot: true, Time: s.clock.Now().UTC()}) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e.Type != event.ForkStatusChanged {
+				continue
+			}
+			if !send(ForkStatusChange{ChannelID: e.ChannelID, Forked: e.Forked, Time: s.clock.Now().UTC()}) {
+				return
+			}
+		case <-req.Context().Done():
+			return
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchForkStatus(t *testing.T) {
+	peerInfo := &mock.PeerInfoProvider{}
+	peerInfo.GetChannelsInfoReturns([]*pb.ChannelInfo{{ChannelId: "mychannel"}, {ChannelId: "forkedchannel"}})
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bscc := newTestBSCCWithClock(peerInfo, clock)
+	var mutex sync.Mutex
+	forked := map[string]bool{"forkedchannel": true}
+	bscc.forkStatuses.stat = func(channelID string) bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return forked[channelID]
+	}
+
+	server := httptest.NewServer(bscc.WatchForkStatus())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	resp.Body.Close()
+
+	bscc.stop = make(chan struct{})
+	resp, err = http.Post(server.URL, "application/json", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	resp.Body.Close()
+
+	resp, err = http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	lines := bufio.NewScanner(resp.Body)
+	next := func() ForkStatusChange {
+		require.True(t, lines.Scan(), "stream ended: %v", lines.Err())
+		var change ForkStatusChange
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &change))
+		return change
+	}
+
+	// the statuses of the joined channels come first
+	now := clock.Now().UTC()
+	require.Equal(t, ForkStatusChange{Version: ForkStatusVersion, ChannelID: "mychannel", Snapshot: true, Time: now}, next())
+	require.Equal(t, ForkStatusChange{Version: ForkStatusVersion, ChannelID: "forkedchannel", Forked: true, Snapshot: true, Time: now}, next())
+
+	// then the changes of every channel as they are detected
+	mutex.Lock()
+	forked["mychannel"] = true
+	delete(forked, "forkedchannel")
+	mutex.Unlock()
+	bscc.forkStatuses.get("mychannel", 0)
+	require.Equal(t, ForkStatusChange{Version: ForkStatusVersion, ChannelID: "mychannel", Forked: true, Time: now}, next())
+	bscc.forkStatuses.get("forkedchannel", 0)
+	require.Equal(t, ForkStatusChange{Version: ForkStatusVersion, ChannelID: "forkedchannel", Time: now}, next())
+
+	// the stream ends when the service stops
+	close(bscc.stop)
+	require.False(t, lines.Scan())
+}
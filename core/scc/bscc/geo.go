@@ -0,0 +1,253 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sensorGeohashObjectType is the composite key object type of the geohash
+	// index of the sensor registry. The attributes of an index key are the
+	// characters of the geohash of the sensor's location followed by the
+	// sensor ID, so that a partial key lists the sensors of a geohash cell.
+	sensorGeohashObjectType = "sensor~geohash"
+	// geohashPrecision is the length of the indexed geohashes, whose cells
+	// are about 5 metres wide.
+	geohashPrecision = 9
+	// maxAreaCells bounds the number of geohash cells scanned by an area
+	// query, coarser cells being scanned for larger areas.
+	maxAreaCells = 64
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Location is the position of a sensor.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Floor     int     `json:"floor"`
+}
+
+func (l *Location) validate() error {
+	if l.Latitude < -90 || l.Latitude > 90 {
+		return errors.Errorf("latitude %v is out of range", l.Latitude)
+	}
+	if l.Longitude < -180 || l.Longitude > 180 {
+		return errors.Errorf("longitude %v is out of range", l.Longitude)
+	}
+	return nil
+}
+
+// BoundingBox is an area delimited by two parallels and two meridians,
+// restricted to a floor if Floor is set. Boxes crossing the antimeridian
+// are not supported.
+type BoundingBox struct {
+	MinLatitude  float64 `json:"minLatitude"`
+	MinLongitude float64 `json:"minLongitude"`
+	MaxLatitude  float64 `json:"maxLatitude"`
+	MaxLongitude float64 `json:"maxLongitude"`
+	Floor        *int    `json:"floor,omitempty"`
+}
+
+func (b *BoundingBox) validate() error {
+	for _, l := range []*Location{
+		{Latitude: b.MinLatitude, Longitude: b.MinLongitude},
+		{Latitude: b.MaxLatitude, Longitude: b.MaxLongitude},
+	} {
+		if err := l.validate(); err != nil {
+			return err
+		}
+	}
+	if b.MinLatitude > b.MaxLatitude || b.MinLongitude > b.MaxLongitude {
+		return errors.New("minimum coordinates exceed maximum coordinates")
+	}
+	return nil
+}
+
+func (b *BoundingBox) contains(l *Location) bool {
+	return l.Latitude >= b.MinLatitude && l.Latitude <= b.MaxLatitude &&
+		l.Longitude >= b.MinLongitude && l.Longitude <= b.MaxLongitude &&
+		(b.Floor == nil || *b.Floor == l.Floor)
+}
+
+// geohashCells returns the number of latitude and longitude cells of the
+// geohashes of the given precision.
+func geohashCells(precision int) (latCells, lonCells uint64) {
+	bits := uint(5 * precision)
+	return 1 << (bits / 2), 1 << ((bits + 1) / 2)
+}
+
+// cellIndex returns the index of the cell holding the coordinate among the
+// cells evenly dividing [min, max].
+func cellIndex(coordinate, min, max float64, cells uint64) uint64 {
+	index := uint64(math.Floor((coordinate - min) / (max - min) * float64(cells)))
+	if index >= cells {
+		index = cells - 1
+	}
+	return index
+}
+
+// geohashOfCell returns the geohash of the cell at the given latitude and
+// longitude indices, whose bits interleave, starting with longitude.
+func geohashOfCell(latIndex, lonIndex uint64, precision int) string {
+	latCells, lonCells := geohashCells(precision)
+	latBit, lonBit := latCells>>1, lonCells>>1
+
+	hash := make([]byte, precision)
+	for i := range hash {
+		var char byte
+		for b := 0; b < 5; b++ {
+			char <<= 1
+			if (5*i+b)%2 == 0 {
+				if lonIndex&lonBit != 0 {
+					char |= 1
+				}
+				lonBit >>= 1
+			} else {
+				if latIndex&latBit != 0 {
+					char |= 1
+				}
+				latBit >>= 1
+			}
+		}
+		hash[i] = geohashAlphabet[char]
+	}
+
+	return string(hash)
+}
+
+// geohash returns the geohash of the location with the given precision.
+func geohash(l *Location, precision int) string {
+	latCells, lonCells := geohashCells(precision)
+	return geohashOfCell(
+		cellIndex(l.Latitude, -90, 90, latCells),
+		cellIndex(l.Longitude, -180, 180, lonCells),
+		precision,
+	)
+}
+
+// coveringGeohashes returns the geohashes of the cells covering the box, at
+// the finest precision needing no more than maxAreaCells cells.
+func coveringGeohashes(b *BoundingBox) []string {
+	for precision := geohashPrecision; ; precision-- {
+		latCells, lonCells := geohashCells(precision)
+		minLat, maxLat := cellIndex(b.MinLatitude, -90, 90, latCells), cellIndex(b.MaxLatitude, -90, 90, latCells)
+		minLon, maxLon := cellIndex(b.MinLongitude, -180, 180, lonCells), cellIndex(b.MaxLongitude, -180, 180, lonCells)
+		// a single character geohash has at most 32 cells
+		if (maxLat-minLat+1)*(maxLon-minLon+1) > maxAreaCells && precision > 1 {
+			continue
+		}
+
+		var hashes []string
+		for lat := minLat; lat <= maxLat; lat++ {
+			for lon := minLon; lon <= maxLon; lon++ {
+				hashes = append(hashes, geohashOfCell(lat, lon, precision))
+			}
+		}
+		return hashes
+	}
+}
+
+func geohashIndexAttributes(hash string) []string {
+	attributes := make([]string, len(hash))
+	for i, char := range hash {
+		attributes[i] = string(char)
+	}
+	return attributes
+}
+
+func geohashIndexKey(stub shim.ChaincodeStubInterface, sensor *Sensor) (string, error) {
+	attributes := append(geohashIndexAttributes(geohash(sensor.Location, geohashPrecision)), sensor.ID)
+	key, err := stub.CreateCompositeKey(sensorGeohashObjectType, attributes)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to create sensor geohash key")
+	}
+	return key, nil
+}
+
+// indexSensorLocation moves the sensor from the geohash index entry of its
+// previous registry entry, if any, to the entry of its current location.
+func indexSensorLocation(stub shim.ChaincodeStubInterface, previous, sensor *Sensor) error {
+	if previous != nil && previous.Location != nil {
+		key, err := geohashIndexKey(stub, previous)
+		if err != nil {
+			return err
+		}
+		if err := stub.DelState(key); err != nil {
+			return errors.WithMessagef(err, "failed to remove sensor %s from geohash index", sensor.ID)
+		}
+	}
+
+	if sensor.Location == nil {
+		return nil
+	}
+
+	key, err := geohashIndexKey(stub, sensor)
+	if err != nil {
+		return err
+	}
+	// a nil value would delete the key
+	if err := stub.PutState(key, []byte{0x00}); err != nil {
+		return errors.WithMessagef(err, "failed to add sensor %s to geohash index", sensor.ID)
+	}
+
+	return nil
+}
+
+// QuerySensorsInArea returns the registry entries of the sensors located in
+// the JSON encoded bounding box in args[0], ordered by sensor ID.
+func (bscc *BSCC) QuerySensorsInArea(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	box := &BoundingBox{}
+	if err := json.Unmarshal(args[0], box); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to unmarshal bounding box: %s", err))
+	}
+	if err := box.validate(); err != nil {
+		return shim.Error(fmt.Sprintf("Invalid bounding box: %s", err))
+	}
+
+	// Initialise to an empty array
+	sensors := make([]*Sensor, 0)
+	for _, hash := range coveringGeohashes(box) {
+		iter, err := stub.GetStateByPartialCompositeKey(sensorGeohashObjectType, geohashIndexAttributes(hash))
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to query sensors in area: %s", err))
+		}
+		err = drain(iter, func(key string, _ []byte) error {
+			_, attributes, err := stub.SplitCompositeKey(key)
+			if err != nil {
+				return errors.WithMessagef(err, "failed to split sensor geohash key %s", key)
+			}
+
+			sensor, err := loadSensor(stub, attributes[len(attributes)-1])
+			if err != nil {
+				return err
+			}
+			if sensor != nil && sensor.Location != nil && box.contains(sensor.Location) {
+				sensors = append(sensors, sensor)
+			}
+			return nil
+		})
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Failed to query sensors in area: %s", err))
+		}
+	}
+	sort.Slice(sensors, func(i, j int) bool { return sensors[i].ID < sensors[j].ID })
+
+	jsonResponse, err := json.Marshal(&Page{Records: sensors})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal sensors: %s", err))
+	}
+
+	return shim.Success(jsonResponse)
+}
@@ -0,0 +1,87 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeohash(t *testing.T) {
+	require.Equal(t, "u4pruydqq", geohash(&Location{Latitude: 57.64911, Longitude: 10.40744}, geohashPrecision))
+	require.Equal(t, "gcpuu", geohash(&Location{Latitude: 51.4988, Longitude: -0.1749}, 5))
+	require.Equal(t, "zzzzz", geohash(&Location{Latitude: 90, Longitude: 180}, 5))
+	require.Equal(t, "00000", geohash(&Location{Latitude: -90, Longitude: -180}, 5))
+}
+
+func TestCoveringGeohashes(t *testing.T) {
+	// a box within a single cell
+	location := &Location{Latitude: 57.64911, Longitude: 10.40744}
+	require.Equal(t, []string{"u4pruydqq"}, coveringGeohashes(&BoundingBox{
+		MinLatitude:  location.Latitude,
+		MinLongitude: location.Longitude,
+		MaxLatitude:  location.Latitude,
+		MaxLongitude: location.Longitude,
+	}))
+
+	// coarser cells cover larger boxes
+	hashes := coveringGeohashes(&BoundingBox{MinLatitude: 51.49, MinLongitude: -0.18, MaxLatitude: 51.50, MaxLongitude: -0.17})
+	require.True(t, len(hashes) <= maxAreaCells)
+	require.Contains(t, hashes, geohash(&Location{Latitude: 51.4988, Longitude: -0.1749}, len(hashes[0])))
+
+	require.Len(t, coveringGeohashes(&BoundingBox{MinLatitude: -90, MinLongitude: -180, MaxLatitude: 90, MaxLongitude: 180}), 32)
+}
+
+func TestQuerySensorsInArea(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.MockTransactionStart("tx1")
+	floor := 2
+	sensors := []*Sensor{
+		{ID: "sensor1", Location: &Location{Latitude: 51.4988, Longitude: -0.1749, Floor: 2}},
+		{ID: "sensor2", Location: &Location{Latitude: 51.4990, Longitude: -0.1752, Floor: 1}},
+		{ID: "sensor3", Location: &Location{Latitude: 51.5007, Longitude: -0.1246, Floor: 2}},
+		{ID: "sensor4"},
+	}
+	for _, sensor := range sensors {
+		require.NoError(t, indexSensorLocation(stub, nil, sensor))
+		require.NoError(t, storeSensor(stub, sensor))
+	}
+
+	// moving a sensor updates its index entry
+	moved := &Sensor{ID: "sensor3", Location: &Location{Latitude: 51.4985, Longitude: -0.1745, Floor: 2}}
+	require.NoError(t, indexSensorLocation(stub, sensors[2], moved))
+	require.NoError(t, storeSensor(stub, moved))
+	stub.MockTransactionEnd("tx1")
+
+	query := func(box *BoundingBox) []string {
+		boxBytes, err := json.Marshal(box)
+		require.NoError(t, err)
+		resp := (&BSCC{}).QuerySensorsInArea(stub, [][]byte{boxBytes})
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+
+		var page struct {
+			Records []*Sensor `json:"records"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Payload, &page))
+		ids := []string{}
+		for _, sensor := range page.Records {
+			ids = append(ids, sensor.ID)
+		}
+		return ids
+	}
+
+	campus := &BoundingBox{MinLatitude: 51.498, MinLongitude: -0.176, MaxLatitude: 51.500, MaxLongitude: -0.174}
+	require.Equal(t, []string{"sensor1", "sensor2", "sensor3"}, query(campus))
+	campus.Floor = &floor
+	require.Equal(t, []string{"sensor1", "sensor3"}, query(campus))
+	require.Equal(t, []string{}, query(&BoundingBox{MinLatitude: 51.5, MinLongitude: -0.13, MaxLatitude: 51.51, MaxLongitude: -0.12}))
+
+	resp := (&BSCC{}).QuerySensorsInArea(stub, [][]byte{[]byte(`{"minLatitude":1,"maxLatitude":0}`)})
+	require.Equal(t, "Invalid bounding box: minimum coordinates exceed maximum coordinates", resp.Message)
+}
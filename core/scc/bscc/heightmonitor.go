@@ -0,0 +1,80 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+)
+
+// monitorHeight periodically compares the height of every channel this peer
+// has joined with the height reported by the channel's orderer. Stale peers
+// produce stale approvals, so lagging channels are reported on the event bus.
+// The options are read on every round so that reloaded settings take effect.
+func (s *BloccService) monitorHeight(stop <-chan struct{}) {
+	for {
+		options := s.currentOptions()
+		if !s.sleep(stop, options.HeightMonitorInterval) {
+			return
+		}
+		if !options.HeightMonitorEnabled {
+			continue
+		}
+
+		for _, channel := range s.peerInfo.GetChannelsInfo() {
+			s.checkHeight(channel.ChannelId, options.HeightLagThreshold)
+		}
+	}
+}
+
+func (s *BloccService) checkHeight(channelID string, threshold uint64) {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return
+	}
+
+	info, err := ledger.GetBlockchainInfo()
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to get blockchain info for channel %s: %s", channelID, err)
+		return
+	}
+
+	address, rootCertFile, err := s.gatherOrdererInfo(channelID)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to gather orderer info: %s", err)
+		return
+	}
+
+	rootCertFilePath, err := s.createTempFile(rootCertFile)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to create temp file: %s", err)
+		return
+	}
+	defer s.removeTempFile(rootCertFilePath)
+
+	ordererHeight, err := blocc.OrdererHeight(address, rootCertFilePath, channelID, s.pinner)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to get orderer height for channel %s: %s", channelID, err)
+		return
+	}
+
+	var lag uint64
+	if ordererHeight > info.Height {
+		lag = ordererHeight - info.Height
+	}
+	s.metrics.HeightLag.With("channel", channelID).Set(float64(lag))
+
+	if lag > threshold {
+		bloccProtoLogger.Warningf("Peer lags %d blocks behind the orderer on channel %s (peer height %d, orderer height %d)",
+			lag, channelID, info.Height, ordererHeight)
+		s.eventBus.Publish(event.Event{
+			Type:          event.HeightLag,
+			ChannelID:     channelID,
+			PeerHeight:    info.Height,
+			OrdererHeight: ordererHeight,
+		})
+	}
+}
@@ -0,0 +1,34 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/ingestion"
+)
+
+// applyIngestionLimits sets the limits above which the endorser sheds the
+// readings, the priority severities of the approvals being shed last.
+func (s *BloccService) applyIngestionLimits(options config.Options) {
+	s.shedder.SetLimits(ingestion.Limits{
+		MaxInFlight:        options.IngestionMaxInFlight,
+		BulkMaxInFlight:    options.IngestionBulkMaxInFlight,
+		RetryAfter:         options.IngestionRetryAfter,
+		PrioritySeverities: options.PrioritySeverities,
+	})
+}
+
+// Shedder returns the shedder the endorser admits the readings with, within
+// the ingestion limits of the service.
+func (s *BloccService) Shedder() *ingestion.Shedder {
+	return s.shedder
+}
+
+// countShedReading is the shed hook of the ingestion, counting the readings
+// shed as the peer was overloaded.
+func (s *BloccService) countShedReading(channelID string, class ingestion.Class) {
+	s.metrics.ReadingsShed.With("channel", channelID, "class", string(class)).Add(1)
+}
@@ -0,0 +1,46 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/ingestion"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyIngestionLimits(t *testing.T) {
+	counter := &metricsfakes.Counter{}
+	counter.WithReturns(counter)
+	s := &BloccService{metrics: &Metrics{ReadingsShed: counter}}
+	s.shedder = ingestion.NewShedder(s.countShedReading)
+
+	s.applyIngestionLimits(config.Options{IngestionMaxInFlight: 2, IngestionBulkMaxInFlight: 1, IngestionRetryAfter: time.Second, PrioritySeverities: []string{"alarm"}})
+	release, shed := s.Shedder().Admit("mychannel", "")
+	require.Nil(t, shed)
+	defer release()
+	_, shed = s.Shedder().Admit("mychannel", "info")
+	require.NotNil(t, shed)
+	require.Equal(t, int64(1000), shed.RetryAfterMillis)
+	release2, shed := s.Shedder().Admit("mychannel", "alarm")
+	require.Nil(t, shed)
+	defer release2()
+
+	require.Equal(t, 1, counter.AddCallCount())
+	require.Equal(t, []string{"channel", "mychannel", "class", "bulk"}, counter.WithArgsForCall(0))
+
+	// the limits and the load of a service do not apply to another one
+	other := NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus())
+	release3, shed := other.Shedder().Admit("mychannel", "info")
+	require.Nil(t, shed)
+	release3()
+}
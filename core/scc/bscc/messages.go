@@ -0,0 +1,20 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+)
+
+// applyMessageCatalog selects the language of the messages of BSCC, the
+// messages being left in the language selected last if the catalog of the
+// locale cannot be loaded.
+func applyMessageCatalog(options config.Options) {
+	if err := messages.Default.Load(options.Locale, options.MessageCatalogDir); err != nil {
+		bloccProtoLogger.Warningf("Failed to load the messages of locale %s, keeping locale %s: %s", options.Locale, messages.Default.Locale(), err)
+	}
+}
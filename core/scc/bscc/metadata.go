@@ -0,0 +1,174 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metadata"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
+)
+
+// MetadataVersion is the version of the schema of the GetMetadata response.
+const MetadataVersion = 1
+
+// Metadata describes the BSCC of this peer, so that tooling can discover
+// the capabilities of the peers of a network running different releases.
+type Metadata struct {
+	// Version is the version of the schema of the response
+	Version int `json:"version"`
+	// PeerVersion is the release of the peer
+	PeerVersion string `json:"peerVersion"`
+	// StateVersion is the schema version of the BSCC state written by the
+	// peer
+	StateVersion int `json:"stateVersion"`
+	// APIVersion and MinAPIVersion are the newest and the oldest versions
+	// of the BSCC API served by the peer
+	APIVersion    int `json:"apiVersion"`
+	MinAPIVersion int `json:"minAPIVersion"`
+	// Functions are the functions supported by BSCC, by name
+	Functions []FunctionMetadata `json:"functions"`
+}
+
+// FunctionMetadata describes a BSCC function.
+type FunctionMetadata struct {
+	Name string `json:"name"`
+	// Args are the named arguments of the function, in positional order,
+	// if it takes named arguments
+	Args []ArgMetadata `json:"args,omitempty"`
+	// ACLResource is the ACL resource checked before the function is
+	// invoked, if any
+	ACLResource string `json:"aclResource,omitempty"`
+	// Writes is set for the functions writing to the BSCC state
+	Writes bool `json:"writes"`
+}
+
+// ArgMetadata describes a named argument of a BSCC function.
+type ArgMetadata struct {
+	Name string `json:"name"`
+	// Type is the JSON type of the argument: string, integer, boolean,
+	// array of strings, or object of strings
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+var argKindNames = map[argKind]string{
+	stringArg:  "string",
+	intArg:     "integer",
+	boolArg:    "boolean",
+	stringsArg: "array",
+	filtersArg: "object",
+}
+
+// functionACLResources are the ACL resources checked by Invoke before the
+// functions are invoked. The functions missing from it are invoked without
+// an ACL check.
+var functionACLResources = map[string]string{
+	approveSensoryReading: resources.Bscc_ApproveSensoryReading,
+	queryApprovals:        resources.Bscc_QueryApprovals,
+	queryRejections:       resources.Bscc_QueryRejections,
+	queryApprovalsBySel:   resources.Bscc_QueryApprovals,
+	registerSensor:        resources.Bscc_RegisterSensor,
+	registerSensors:       resources.Bscc_RegisterSensors,
+	issueSensorToken:      resources.Bscc_IssueSensorToken,
+	revokeSensorToken:     resources.Bscc_RevokeSensorToken,
+	decommissionSensor:    resources.Bscc_DecommissionSensor,
+	registerApprover:      resources.Bscc_RegisterApprover,
+	getApprovers:          resources.Bscc_GetApprovers,
+	evaluateReading:       resources.Bscc_EvaluateReading,
+	archiveMetricReadings: resources.Bscc_ArchiveMetricReadings,
+	authenticateSensor:    resources.Bscc_AuthenticateSensor,
+	getDeliveryReceipt:    resources.Bscc_GetDeliveryReceipt,
+	getSensor:             resources.Bscc_GetSensor,
+	listSensors:           resources.Bscc_ListSensors,
+	getSensorStats:        resources.Bscc_GetSensorStats,
+	getSensorReliability:  resources.Bscc_GetSensorReliability,
+	setTransformation:     resources.Bscc_SetTransformation,
+	getTransformation:     resources.Bscc_GetTransformation,
+	setFeatureFlag:        resources.Bscc_SetFeatureFlag,
+	getFeatureFlags:       resources.Bscc_GetFeatureFlags,
+	querySensorsBySel:     resources.Bscc_ListSensors,
+	querySensorsInArea:    resources.Bscc_ListSensors,
+	getReadingProof:       resources.Bscc_GetReadingProof,
+	getReading:            resources.Bscc_GetReading,
+	drainApprovals:        resources.Bscc_DrainApprovals,
+	clearForkStatus:       resources.Bscc_ClearForkStatus,
+	acknowledgeFork:       resources.Bscc_AcknowledgeFork,
+	migrateState:          resources.Bscc_MigrateState,
+	getRecentEvents:       resources.Bscc_GetRecentEvents,
+	setValidationPolicy:   resources.Bscc_SetValidationPolicy,
+	getValidationPolicy:   resources.Bscc_GetValidationPolicy,
+	queryMetricReadings:   resources.Bscc_QueryMetricReadings,
+	getApprovalAccess:     resources.Bscc_GetApprovalAccess,
+	flushPendingApprovals: resources.Bscc_FlushPendingApprovals,
+	getDiskUsage:          resources.Bscc_GetDiskUsage,
+	reloadConfig:          resources.Bscc_ReloadConfig,
+	getMetadata:           resources.Bscc_GetMetadata,
+	getApprovalWatermark:  resources.Bscc_GetApprovalWatermark,
+	attestSensorFirmware:  resources.Bscc_AttestSensorFirmware,
+	getRevalidations:      resources.Bscc_GetRevalidations,
+	getReadingProvenance:  resources.Bscc_GetReadingProvenance,
+}
+
+// bsccFunctions are the functions supported by BSCC.
+var bsccFunctions = []string{
+	approveSensoryReading, simulateForkAttempt, checkForkStatus, queryApprovals,
+	registerSensor, getSensor, queryRejections, reloadConfig, listSensors,
+	queryApprovalsBySel, querySensorsBySel, querySensorsInArea, getReadingProof,
+	getReading, issueSensorToken, revokeSensorToken, authenticateSensor,
+	getDeliveryReceipt, getDiskUsage, setValidationPolicy, getValidationPolicy,
+	queryMetricReadings, getSensorStats, setTransformation, getTransformation,
+	setFeatureFlag, getFeatureFlags, getRecentEvents, drainApprovals,
+	clearForkStatus, acknowledgeFork, migrateState, decommissionSensor,
+	registerApprover, getApprovers, evaluateReading, archiveMetricReadings,
+	registerSensors, getSensorReliability, getApprovalAccess,
+	flushPendingApprovals, getMetadata, getApprovalWatermark, attestSensorFirmware,
+	getRevalidations, getReadingProvenance,
+}
+
+// functionsMetadata returns the metadata of the BSCC functions, sorted by
+// name.
+func functionsMetadata() []FunctionMetadata {
+	functions := make([]FunctionMetadata, 0, len(bsccFunctions))
+	for _, name := range bsccFunctions {
+		function := FunctionMetadata{
+			Name:        name,
+			ACLResource: functionACLResources[name],
+			Writes:      writingFunctions[name],
+		}
+		for _, field := range namedArgs[name] {
+			function.Args = append(function.Args, ArgMetadata{
+				Name:     field.name,
+				Type:     argKindNames[field.kind],
+				Required: field.required,
+			})
+		}
+		functions = append(functions, function)
+	}
+	sort.Slice(functions, func(i, j int) bool { return functions[i].Name < functions[j].Name })
+	return functions
+}
+
+// GetMetadata returns the JSON encoded Metadata of BSCC.
+func (bscc *BSCC) GetMetadata() pb.Response {
+	metadataBytes, err := json.Marshal(&Metadata{
+		Version:       MetadataVersion,
+		PeerVersion:   metadata.Version,
+		StateVersion:  stateVersion(),
+		APIVersion:    apiversion.Current,
+		MinAPIVersion: apiversion.Min,
+		Functions:     functionsMetadata(),
+	})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal metadata: %s", err))
+	}
+	return shim.Success(metadataBytes)
+}
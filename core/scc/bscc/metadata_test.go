@@ -0,0 +1,149 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric/common/metadata"
+	"github.com/hyperledger/fabric/core/committer/txvalidator/v20/plugindispatcher"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
+	"github.com/stretchr/testify/require"
+)
+
+// invokeACLs parses the invoke switch of bscc.go and returns the functions
+// it dispatches, with the ACL resource they are checked against, if any.
+func invokeACLs(t *testing.T) map[string]string {
+	file, err := parser.ParseFile(token.NewFileSet(), "bscc.go", nil, 0)
+	require.NoError(t, err)
+
+	names := map[string]string{}
+	acls := map[string]string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, ident := range n.Names {
+				if i >= len(n.Values) {
+					break
+				}
+				if lit, ok := n.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					names[ident.Name], _ = strconv.Unquote(lit.Value)
+				}
+			}
+		case *ast.FuncDecl:
+			if n.Name.Name != "invoke" {
+				return false
+			}
+		case *ast.CaseClause:
+			resource := ""
+			ast.Inspect(n, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "CheckACL" {
+					arg := call.Args[0].(*ast.SelectorExpr).Sel.Name
+					resource = "bscc/" + strings.TrimPrefix(arg, "Bscc_")
+				}
+				return true
+			})
+			for _, expr := range n.List {
+				if ident, ok := expr.(*ast.Ident); ok {
+					acls[names[ident.Name]] = resource
+				}
+			}
+			return false
+		}
+		return true
+	})
+	return acls
+}
+
+func TestFunctionsMetadata(t *testing.T) {
+	acls := invokeACLs(t)
+	require.Len(t, bsccFunctions, len(acls))
+	for _, function := range functionsMetadata() {
+		resource, ok := acls[function.Name]
+		require.True(t, ok, "function %s is not dispatched by Invoke", function.Name)
+		require.Equal(t, resource, function.ACLResource, "ACL resource of %s", function.Name)
+	}
+	for name := range namedArgs {
+		require.Contains(t, acls, name)
+	}
+	for name := range writingFunctions {
+		require.Contains(t, acls, name)
+	}
+	for name := range plugindispatcher.AdminFunctions {
+		require.Contains(t, acls, name)
+	}
+	for name := range queryFunctions {
+		require.Contains(t, acls, name)
+		require.False(t, writingFunctions[name], "query function %s writes to the state", name)
+	}
+}
+
+func TestGetMetadata(t *testing.T) {
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+
+	resp := bscc.GetMetadata()
+	require.Equal(t, int32(200), resp.Status, resp.Message)
+	md := &Metadata{}
+	require.NoError(t, json.Unmarshal(resp.Payload, md))
+	require.Equal(t, MetadataVersion, md.Version)
+	require.Equal(t, metadata.Version, md.PeerVersion)
+	require.Equal(t, stateVersion(), md.StateVersion)
+	require.Equal(t, apiversion.Current, md.APIVersion)
+	require.Equal(t, apiversion.Min, md.MinAPIVersion)
+
+	functions := map[string]FunctionMetadata{}
+	for _, function := range md.Functions {
+		functions[function.Name] = function
+	}
+	require.Equal(t, FunctionMetadata{
+		Name: "CheckForkStatus",
+		Args: []ArgMetadata{{Name: "channelID", Type: "string"}, {Name: "detailed", Type: "boolean"}},
+	}, functions["CheckForkStatus"])
+	require.Equal(t, FunctionMetadata{
+		Name:        "DecommissionSensor",
+		ACLResource: "bscc/DecommissionSensor",
+		Writes:      true,
+		Args: []ArgMetadata{
+			{Name: "sensorID", Type: "string", Required: true},
+			{Name: "finalTxID", Type: "string", Required: true},
+			{Name: "reason", Type: "string", Required: true},
+		},
+	}, functions["DecommissionSensor"])
+	require.Equal(t, FunctionMetadata{Name: "GetMetadata", ACLResource: "bscc/GetMetadata"}, functions["GetMetadata"])
+}
+
+func TestInvokeAPIVersion(t *testing.T) {
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+	stub := shimtest.NewMockStub("bscc", bscc)
+
+	// the clients speaking a version this peer does not serve are rejected
+	// before the function is invoked
+	stub.TransientMap = map[string][]byte{apiversion.TransientKey: []byte("2")}
+	resp := stub.MockInvoke("tx1", [][]byte{[]byte(getMetadata), nil})
+	require.Equal(t, int32(500), resp.Status)
+	require.Equal(t, "BSCC API version 2 is not supported by this peer, which serves versions 1 to 1; upgrade the client or the peer", resp.Message)
+
+	stub.TransientMap = map[string][]byte{apiversion.TransientKey: []byte("latest")}
+	resp = stub.MockInvoke("tx2", [][]byte{[]byte(getMetadata), nil})
+	require.Equal(t, "invalid BSCC API version 'latest'", resp.Message)
+
+	// unversioned clients get past the version check
+	stub.TransientMap = nil
+	resp = stub.MockInvoke("tx3", [][]byte{[]byte(getMetadata), nil})
+	require.Contains(t, resp.Message, "Failed to identify the called chaincode")
+}
@@ -0,0 +1,176 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// metricReadingObjectType is the composite key object type of the metric
+// index of the approved readings, keyed by metric name, sensor ID, sensory
+// transaction ID and the MSP ID of the approving organization owning the
+// key. A reading approved by several organizations is indexed by each.
+const metricReadingObjectType = "metricReading"
+
+// MetricReading is the value of one metric of an approved reading.
+type MetricReading struct {
+	Metric      string  `json:"metric"`
+	SensorID    string  `json:"sensorID"`
+	SensoryTxID string  `json:"sensoryTxID"`
+	Value       float64 `json:"value"`
+	Timestamp   int64   `json:"timestamp"`
+	// TransformationVersion is the version of the transformation of the
+	// sensor type the metric was computed with, zero if the metric is that
+	// of the raw reading
+	TransformationVersion uint64 `json:"transformationVersion,omitempty"`
+}
+
+// indexMetrics indexes every metric of the approved reading in envelope, so
+// that the readings of a metric can be queried without decoding the sensory
+// transactions. The metrics indexed are those output by the latest
+// transformation of the type of the sensor, if any. Readings without
+// metrics, such as those of sensor chaincodes writing their own payloads, are
+// not indexed.
+func indexMetrics(stub shim.ChaincodeStubInterface, sensoryTxID, mspID string, envelope *cb.Envelope) error {
+	creator, err := protoutil.ExtractCreatorFromEnvelope(envelope)
+	if err != nil {
+		return nil
+	}
+	id, err := sensorID(creator)
+	if err != nil {
+		return nil
+	}
+
+	return indexSensorMetrics(stub, sensoryTxID, id, mspID, envelope)
+}
+
+func indexSensorMetrics(stub shim.ChaincodeStubInterface, sensoryTxID, id, mspID string, envelope *cb.Envelope) error {
+	metrics, timestamp, err := protoutil.ExtractMetricsReadingFromEnvelope(envelope)
+	if err != nil {
+		return nil
+	}
+	metrics, version, err := transformReading(stub, id, metrics)
+	if err != nil {
+		return err
+	}
+
+	for _, metric := range metricNames(metrics) {
+		key, err := stub.CreateCompositeKey(metricReadingObjectType, []string{metric, id, sensoryTxID, mspID})
+		if err != nil {
+			return errors.WithMessage(err, "failed to create metric reading key")
+		}
+		readingBytes, err := marshalState(&MetricReading{
+			Metric:      metric,
+			SensorID:    id,
+			SensoryTxID: sensoryTxID,
+			Value:       metrics[metric],
+			Timestamp:   timestamp,
+
+			TransformationVersion: version,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal metric reading")
+		}
+		if err := stub.PutState(key, readingBytes); err != nil {
+			return errors.WithMessagef(err, "failed to index %s of reading %s", metric, sensoryTxID)
+		}
+	}
+
+	return nil
+}
+
+// QueryMetricReadings returns a page of the approved readings of the metric
+// in args[0], of the sensor in args[1] or of all sensors if it is empty.
+// args[2] and args[3] are the optional page size and bookmark.
+func (bscc *BSCC) QueryMetricReadings(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 1 || len(args[0]) == 0 {
+		return shim.Error("Metric not specified")
+	}
+	attributes := []string{string(args[0])}
+	if len(args) > 1 && len(args[1]) > 0 {
+		attributes = append(attributes, string(args[1]))
+	}
+
+	var pageArgs [][]byte
+	if len(args) > 2 {
+		pageArgs = args[2:]
+	}
+	req, err := parsePageRequest(pageArgs)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	// the index entries of a reading approved by several organizations are
+	// adjacent, and only the first one is returned
+	previous, err := continuedReading(stub, req.bookmark)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query metric readings: %s", err))
+	}
+
+	// Initialise to an empty array
+	readings := make([]*MetricReading, 0)
+	bookmark, err := iteratePage(stub, metricReadingObjectType, attributes, req, func(key string, value []byte) error {
+		reading := &MetricReading{}
+		if err := json.Unmarshal(value, reading); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal metric reading %s", key)
+		}
+		if reading.SensoryTxID == previous {
+			return nil
+		}
+		previous = reading.SensoryTxID
+		readings = append(readings, reading)
+		return nil
+	})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query metric readings: %s", err))
+	}
+
+	readingsBytes, err := json.Marshal(&Page{Records: readings, Bookmark: bookmark})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal metric readings: %s", err))
+	}
+
+	return shim.Success(readingsBytes)
+}
+
+// continuedReading returns the sensory transaction ID of the reading whose
+// index entries the page of bookmark continues, if the previous page
+// returned the reading already.
+func continuedReading(stub shim.ChaincodeStubInterface, bookmark string) (string, error) {
+	// the bookmarks of the partial composite key queries are the key the
+	// page starts at
+	if len(bookmark) < 2 || bookmark[0] != 0 || bookmark[len(bookmark)-1] != 0 {
+		return "", nil
+	}
+	_, attributes, err := stub.SplitCompositeKey(bookmark)
+	if err != nil || len(attributes) < 4 {
+		return "", nil
+	}
+
+	iterator, err := stub.GetStateByPartialCompositeKey(metricReadingObjectType, attributes[:3])
+	if err != nil {
+		return "", err
+	}
+	defer iterator.Close()
+	if !iterator.HasNext() {
+		return "", nil
+	}
+	first, err := iterator.Next()
+	if err != nil {
+		return "", err
+	}
+	if first.Key == bookmark {
+		return "", nil
+	}
+	return attributes[2], nil
+}
@@ -0,0 +1,133 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricIndex(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	bscc := &BSCC{}
+
+	stub.MockTransactionStart("tx1")
+	require.NoError(t, indexSensorMetrics(stub, "reading1", "sensor1", "Org1MSP", readingEnvelope(t, "Set", "21.5", "0.4", "1628887200")))
+	// the readings approved by several organizations are returned once
+	require.NoError(t, indexSensorMetrics(stub, "reading1", "sensor1", "Org2MSP", readingEnvelope(t, "Set", "21.5", "0.4", "1628887200")))
+	require.NoError(t, indexSensorMetrics(stub, "reading2", "sensor2", "Org1MSP", readingEnvelope(t, "Set", `{"temperature":19,"co2":415}`, "", "1628887260")))
+	// readings without metrics are not indexed
+	require.NoError(t, indexSensorMetrics(stub, "reading3", "sensor2", "Org1MSP", readingEnvelope(t, "SetRaw", "blob")))
+	stub.MockTransactionEnd("tx1")
+
+	query := func(args ...string) []*MetricReading {
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		resp := bscc.QueryMetricReadings(stub, byteArgs)
+		require.Empty(t, resp.Message)
+		var readings []*MetricReading
+		require.NoError(t, json.Unmarshal(resp.Payload, &Page{Records: &readings}))
+		return readings
+	}
+
+	require.Equal(t, []*MetricReading{
+		{Metric: "temperature", SensorID: "sensor1", SensoryTxID: "reading1", Value: 21.5, Timestamp: 1628887200},
+		{Metric: "temperature", SensorID: "sensor2", SensoryTxID: "reading2", Value: 19, Timestamp: 1628887260},
+	}, query("temperature"))
+	require.Equal(t, []*MetricReading{
+		{Metric: "temperature", SensorID: "sensor2", SensoryTxID: "reading2", Value: 19, Timestamp: 1628887260},
+	}, query("temperature", "sensor2"))
+	require.Len(t, query("co2"), 1)
+	require.Empty(t, query("pressure"))
+
+	require.Equal(t, "Metric not specified", bscc.QueryMetricReadings(stub, nil).Message)
+}
+
+func TestContinuedReading(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.MockTransactionStart("tx1")
+	require.NoError(t, indexSensorMetrics(stub, "reading1", "sensor1", "Org1MSP", readingEnvelope(t, "Set", "21.5", "0.4", "1628887200")))
+	require.NoError(t, indexSensorMetrics(stub, "reading1", "sensor1", "Org2MSP", readingEnvelope(t, "Set", "21.5", "0.4", "1628887200")))
+	stub.MockTransactionEnd("tx1")
+
+	key := func(mspID string) string {
+		key, err := stub.CreateCompositeKey(metricReadingObjectType, []string{"temperature", "sensor1", "reading1", mspID})
+		require.NoError(t, err)
+		return key
+	}
+
+	// a page starting at the entry of the second approving organization
+	// continues a reading returned by the previous page
+	previous, err := continuedReading(stub, key("Org2MSP"))
+	require.NoError(t, err)
+	require.Equal(t, "reading1", previous)
+
+	for _, bookmark := range []string{"", key("Org1MSP"), "not a key", "\x00"} {
+		previous, err := continuedReading(stub, bookmark)
+		require.NoError(t, err)
+		require.Empty(t, previous)
+	}
+}
+
+func TestTransformedMetricIndex(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := &BSCC{}
+
+	stub.MockTransactionStart("tx1")
+	require.NoError(t, storeSensor(stub, &Sensor{ID: "sensor1", MSPID: "Org1MSP", Type: "dht22"}))
+	require.NoError(t, storeSensor(stub, &Sensor{ID: "sensor2", MSPID: "Org1MSP", Type: "sht31"}))
+	resp := bscc.SetTransformation(stub, [][]byte{[]byte(`{"sensorType":"dht22","fields":{"temperatureF":"temperature * 9 / 5 + 32","humidity":"relativeHumidity * 100"}}`)})
+	require.Equal(t, int32(200), resp.Status, resp.Message)
+	stub.MockTransactionEnd("tx1")
+	stub.MockTransactionStart("tx2")
+	resp = bscc.SetTransformation(stub, [][]byte{[]byte(`{"sensorType":"dht22","fields":{"temperatureF":"temperature * 9 / 5 + 32","humidity":"relativeHumidity * 100","dewPoint":"temperature - (100 - relativeHumidity * 100) / 5"}}`)})
+	require.Equal(t, int32(200), resp.Status, resp.Message)
+	stub.MockTransactionEnd("tx2")
+
+	// the raw readings are indexed as output by the latest transformation of
+	// the type of their sensor
+	stub.MockTransactionStart("tx3")
+	require.NoError(t, indexSensorMetrics(stub, "reading1", "sensor1", "Org1MSP", readingEnvelope(t, "Set", "20", "0.5", "1628887200")))
+	// sensors without transformation, unregistered sensors and readings the
+	// transformation cannot be evaluated against are indexed untransformed
+	require.NoError(t, indexSensorMetrics(stub, "reading2", "sensor2", "Org1MSP", readingEnvelope(t, "Set", "19", "0.4", "1628887260")))
+	require.NoError(t, indexSensorMetrics(stub, "reading3", "sensor3", "Org1MSP", readingEnvelope(t, "Set", "18", "0.3", "1628887320")))
+	require.NoError(t, indexSensorMetrics(stub, "reading4", "sensor1", "Org1MSP", readingEnvelope(t, "Set", `{"co2":415}`, "", "1628887380")))
+	stub.MockTransactionEnd("tx3")
+
+	query := func(metric string) []*MetricReading {
+		resp := bscc.QueryMetricReadings(stub, [][]byte{[]byte(metric)})
+		require.Empty(t, resp.Message)
+		var readings []*MetricReading
+		require.NoError(t, json.Unmarshal(resp.Payload, &Page{Records: &readings}))
+		return readings
+	}
+
+	require.Equal(t, []*MetricReading{
+		{Metric: "temperatureF", SensorID: "sensor1", SensoryTxID: "reading1", Value: 68, Timestamp: 1628887200, TransformationVersion: 2},
+	}, query("temperatureF"))
+	require.Equal(t, []*MetricReading{
+		{Metric: "humidity", SensorID: "sensor1", SensoryTxID: "reading1", Value: 50, Timestamp: 1628887200, TransformationVersion: 2},
+	}, query("humidity"))
+	require.Equal(t, []*MetricReading{
+		{Metric: "dewPoint", SensorID: "sensor1", SensoryTxID: "reading1", Value: 10, Timestamp: 1628887200, TransformationVersion: 2},
+	}, query("dewPoint"))
+	require.Equal(t, []*MetricReading{
+		{Metric: "temperature", SensorID: "sensor2", SensoryTxID: "reading2", Value: 19, Timestamp: 1628887260},
+		{Metric: "temperature", SensorID: "sensor3", SensoryTxID: "reading3", Value: 18, Timestamp: 1628887320},
+	}, query("temperature"))
+	require.Equal(t, []*MetricReading{
+		{Metric: "co2", SensorID: "sensor1", SensoryTxID: "reading4", Value: 415, Timestamp: 1628887380},
+	}, query("co2"))
+}
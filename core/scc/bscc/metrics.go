@@ -0,0 +1,335 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+// The labels shared by the BLOCC metrics, so that the panels of a dashboard
+// filter and join on the same names.
+const (
+	ChannelLabel = "channel"
+	SensorLabel  = "sensor"
+	OrgLabel     = "org"
+)
+
+var (
+	heightLagOpts = metrics.GaugeOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "height_lag",
+		Help:         "The number of blocks the peer's ledger lags behind the orderer.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	approvalBroadcastFailuresOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "approval_broadcast_failures",
+		Help:         "The number of approval transactions rejected by the orderer, by broadcast status.",
+		LabelNames:   []string{"channel", "status"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{status}",
+	}
+	approvalInvalidationsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "approval_invalidations",
+		Help:         "The number of approval transactions of this peer invalidated on commit, by validation code.",
+		LabelNames:   []string{"channel", "validation_code"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{validation_code}",
+	}
+	approvalQueueLengthOpts = metrics.GaugeOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "approval_queue_length",
+		Help:         "The number of approval requests of a channel queued awaiting a worker.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	approvalQueueOverflowsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "approval_queue_overflows",
+		Help:         "The number of approval requests left to be redelivered as the queue of their channel was full.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	approvalWorkersBusyOpts = metrics.GaugeOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "approval_workers_busy",
+		Help:         "The number of approval workers of a channel processing an approval request.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	approvalsInFlightOpts = metrics.GaugeOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "approvals_in_flight",
+		Help:         "The number of approvals of a channel submitted by this peer and not yet committed.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	busEventsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "bus_events",
+		Help:         "The number of events carried by the BLOCC event bus, by type.",
+		LabelNames:   []string{"channel", "type"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{type}",
+	}
+	migratedReadingsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "migrated_readings",
+		Help:         "The number of readings of a sensor chaincode being migrated from during an upgrade.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+	lostApprovalsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "lost_approvals",
+		Help:         "The number of approvals missing from the chain although listed in the approval digest of their peer, by organization.",
+		LabelNames:   []string{"channel", "org"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{org}",
+	}
+	approvalsSuspendedOpts = metrics.GaugeOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "approvals_suspended",
+		Help:         "Whether the approvals of a channel are suspended, the approval identity having lost its write access to the channel.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	pendingApprovalsOpts = metrics.GaugeOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "pending_approvals",
+		Help:         "The number of approvals of a channel stored while its orderer was unavailable, waiting to be flushed.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	pendingRecoveryDurationOpts = metrics.GaugeOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "pending_recovery_duration",
+		Help:         "The time in seconds taken at startup to recover and validate the approvals stored while the orderer was unavailable.",
+		StatsdFormat: "%{#fqname}",
+	}
+	pendingRecoveredOpts = metrics.GaugeOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "pending_recovered",
+		Help:         "The number of stored approvals found at startup, by whether they were recovered or quarantined as corrupt.",
+		LabelNames:   []string{"status"},
+		StatsdFormat: "%{#fqname}.%{status}",
+	}
+	ordererPinMismatchesOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "orderer_pin_mismatches",
+		Help:         "The number of TLS connections refused as the orderer presented no certificate matching a pin, by orderer address.",
+		LabelNames:   []string{"orderer"},
+		StatsdFormat: "%{#fqname}.%{orderer}",
+	}
+	readingsShedOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "readings_shed",
+		Help:         "The number of readings shed as the peer was overloaded, by channel and priority class.",
+		LabelNames:   []string{"channel", "class"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{class}",
+	}
+	missingApprovalsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "missing_approvals",
+		Help:         "The number of readings a registered approver did not approve within the deadline, by organization.",
+		LabelNames:   []string{"channel", "org"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{org}",
+	}
+	sensorReadingsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "sensor_readings",
+		Help:         "The number of readings received from a sensor.",
+		LabelNames:   []string{"channel", "sensor"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{sensor}",
+	}
+	sensorApprovalsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "sensor_approvals",
+		Help:         "The number of readings of a sensor whose first committed decision is an approval.",
+		LabelNames:   []string{"channel", "sensor"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{sensor}",
+	}
+	sensorRejectionsOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "sensor_rejections",
+		Help:         "The number of readings of a sensor whose first committed decision is a rejection.",
+		LabelNames:   []string{"channel", "sensor"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{sensor}",
+	}
+	sensorApprovalLatencyOpts = metrics.HistogramOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "sensor_approval_latency",
+		Help:         "The time in seconds between the receipt of a reading and the commit of its first approval or rejection.",
+		LabelNames:   []string{"channel", "sensor"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{sensor}",
+	}
+	slowEventConsumersOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "slow_event_consumers",
+		Help:         "The number of times a subscriber of the event bus was found slow, by whether it was disconnected.",
+		LabelNames:   []string{"subscriber", "disconnected"},
+		StatsdFormat: "%{#fqname}.%{subscriber}.%{disconnected}",
+	}
+	webhookDeliveriesOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "webhook_deliveries",
+		Help:         "The number of BLOCC events posted to webhook endpoints, by delivery status.",
+		LabelNames:   []string{"endpoint", "status"},
+		StatsdFormat: "%{#fqname}.%{endpoint}.%{status}",
+	}
+	webhookRetriesOpts = metrics.CounterOpts{
+		Namespace:    "blocc",
+		Subsystem:    "bscc",
+		Name:         "webhook_retries",
+		Help:         "The number of retried deliveries of BLOCC events to webhook endpoints.",
+		LabelNames:   []string{"endpoint"},
+		StatsdFormat: "%{#fqname}.%{endpoint}",
+	}
+)
+
+type Metrics struct {
+	HeightLag                 metrics.Gauge
+	ApprovalBroadcastFailures metrics.Counter
+	ApprovalInvalidations     metrics.Counter
+	ApprovalQueueLength       metrics.Gauge
+	ApprovalQueueOverflows    metrics.Counter
+	ApprovalWorkersBusy       metrics.Gauge
+	ApprovalsInFlight         metrics.Gauge
+	BusEvents                 metrics.Counter
+	MigratedReadings          metrics.Counter
+	MissingApprovals          metrics.Counter
+	LostApprovals             metrics.Counter
+	ApprovalsSuspended        metrics.Gauge
+	PendingApprovals          metrics.Gauge
+	PendingRecoveryDuration   metrics.Gauge
+	PendingRecovered          metrics.Gauge
+	OrdererPinMismatches      metrics.Counter
+	ReadingsShed              metrics.Counter
+	SensorReadings            metrics.Counter
+	SensorApprovals           metrics.Counter
+	SensorRejections          metrics.Counter
+	SensorApprovalLatency     metrics.Histogram
+	SlowEventConsumers        metrics.Counter
+	WebhookDeliveries         metrics.Counter
+	WebhookRetries            metrics.Counter
+}
+
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		HeightLag:                 p.NewGauge(heightLagOpts),
+		ApprovalBroadcastFailures: p.NewCounter(approvalBroadcastFailuresOpts),
+		ApprovalInvalidations:     p.NewCounter(approvalInvalidationsOpts),
+		ApprovalQueueLength:       p.NewGauge(approvalQueueLengthOpts),
+		ApprovalQueueOverflows:    p.NewCounter(approvalQueueOverflowsOpts),
+		ApprovalWorkersBusy:       p.NewGauge(approvalWorkersBusyOpts),
+		ApprovalsInFlight:         p.NewGauge(approvalsInFlightOpts),
+		BusEvents:                 p.NewCounter(busEventsOpts),
+		MigratedReadings:          p.NewCounter(migratedReadingsOpts),
+		MissingApprovals:          p.NewCounter(missingApprovalsOpts),
+		LostApprovals:             p.NewCounter(lostApprovalsOpts),
+		ApprovalsSuspended:        p.NewGauge(approvalsSuspendedOpts),
+		PendingApprovals:          p.NewGauge(pendingApprovalsOpts),
+		PendingRecoveryDuration:   p.NewGauge(pendingRecoveryDurationOpts),
+		PendingRecovered:          p.NewGauge(pendingRecoveredOpts),
+		OrdererPinMismatches:      p.NewCounter(ordererPinMismatchesOpts),
+		ReadingsShed:              p.NewCounter(readingsShedOpts),
+		SensorReadings:            p.NewCounter(sensorReadingsOpts),
+		SensorApprovals:           p.NewCounter(sensorApprovalsOpts),
+		SensorRejections:          p.NewCounter(sensorRejectionsOpts),
+		SensorApprovalLatency:     p.NewHistogram(sensorApprovalLatencyOpts),
+		SlowEventConsumers:        p.NewCounter(slowEventConsumersOpts),
+		WebhookDeliveries:         p.NewCounter(webhookDeliveriesOpts),
+		WebhookRetries:            p.NewCounter(webhookRetriesOpts),
+	}
+}
+
+// MetricDescriptor describes a BLOCC metric as exported to Prometheus.
+type MetricDescriptor struct {
+	// Name is the fully qualified name of the metric, e.g.
+	// blocc_bscc_height_lag.
+	Name string `json:"name"`
+	// Type is counter, gauge or histogram.
+	Type   string   `json:"type"`
+	Help   string   `json:"help"`
+	Labels []string `json:"labels"`
+}
+
+// Registry returns the descriptors of the BLOCC metrics, in the order of the
+// fields of Metrics, so that dashboards can be built without reading the
+// source for metric names.
+func Registry() []MetricDescriptor {
+	return []MetricDescriptor{
+		gaugeDescriptor(heightLagOpts),
+		counterDescriptor(approvalBroadcastFailuresOpts),
+		counterDescriptor(approvalInvalidationsOpts),
+		gaugeDescriptor(approvalQueueLengthOpts),
+		counterDescriptor(approvalQueueOverflowsOpts),
+		gaugeDescriptor(approvalWorkersBusyOpts),
+		gaugeDescriptor(approvalsInFlightOpts),
+		counterDescriptor(busEventsOpts),
+		counterDescriptor(migratedReadingsOpts),
+		counterDescriptor(missingApprovalsOpts),
+		counterDescriptor(lostApprovalsOpts),
+		gaugeDescriptor(approvalsSuspendedOpts),
+		gaugeDescriptor(pendingApprovalsOpts),
+		gaugeDescriptor(pendingRecoveryDurationOpts),
+		gaugeDescriptor(pendingRecoveredOpts),
+		counterDescriptor(ordererPinMismatchesOpts),
+		counterDescriptor(readingsShedOpts),
+		counterDescriptor(sensorReadingsOpts),
+		counterDescriptor(sensorApprovalsOpts),
+		counterDescriptor(sensorRejectionsOpts),
+		histogramDescriptor(sensorApprovalLatencyOpts),
+		counterDescriptor(slowEventConsumersOpts),
+		counterDescriptor(webhookDeliveriesOpts),
+		counterDescriptor(webhookRetriesOpts),
+	}
+}
+
+func counterDescriptor(o metrics.CounterOpts) MetricDescriptor {
+	return MetricDescriptor{Name: fqname(o.Namespace, o.Subsystem, o.Name), Type: "counter", Help: o.Help, Labels: o.LabelNames}
+}
+
+func gaugeDescriptor(o metrics.GaugeOpts) MetricDescriptor {
+	return MetricDescriptor{Name: fqname(o.Namespace, o.Subsystem, o.Name), Type: "gauge", Help: o.Help, Labels: o.LabelNames}
+}
+
+func histogramDescriptor(o metrics.HistogramOpts) MetricDescriptor {
+	return MetricDescriptor{Name: fqname(o.Namespace, o.Subsystem, o.Name), Type: "histogram", Help: o.Help, Labels: o.LabelNames}
+}
+
+func fqname(parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "_")
+}
@@ -0,0 +1,37 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := Registry()
+	require.Len(t, registry, reflect.TypeOf(Metrics{}).NumField())
+
+	names := map[string]bool{}
+	for _, d := range registry {
+		require.True(t, strings.HasPrefix(d.Name, "blocc_"), d.Name)
+		require.False(t, names[d.Name], "%s is registered twice", d.Name)
+		names[d.Name] = true
+		require.NotEmpty(t, d.Help, d.Name)
+		for _, label := range d.Labels {
+			require.NotContains(t, []string{"msp", "mspid", "channel_id", "sensor_id"}, label, d.Name)
+		}
+	}
+
+	require.Equal(t, MetricDescriptor{
+		Name:   "blocc_bscc_missing_approvals",
+		Type:   "counter",
+		Help:   missingApprovalsOpts.Help,
+		Labels: []string{ChannelLabel, OrgLabel},
+	}, registry[9])
+}
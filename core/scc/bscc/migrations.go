@@ -0,0 +1,251 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+const migrationObjectType = "migration"
+
+// MigrationRecord is the schema version of the BSCC state of a channel, with
+// the migrations applied to reach it.
+type MigrationRecord struct {
+	Version int                `json:"version"`
+	Applied []AppliedMigration `json:"applied"`
+}
+
+// AppliedMigration is a migration applied to the BSCC state of a channel.
+type AppliedMigration struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	TxID        string `json:"txID"`
+}
+
+// stateMigration changes the schema of the BSCC state written by earlier
+// releases. Migrations must be idempotent, as the transaction applying them
+// may be invalidated and the migration applied again.
+type stateMigration struct {
+	version     int
+	description string
+	migrate     func(stub shim.ChaincodeStubInterface) error
+}
+
+// stateMigrations are the migrations of the BSCC state, in version order.
+// New migrations are appended with the next version.
+var stateMigrations = []stateMigration{
+	{1, "Set the document type of the approval and sensor records written before rich queries", setDocTypes},
+}
+
+// writingFunctions are the BSCC functions writing to the state. Those
+// restricted to administrators apply the pending migrations in their
+// transaction first, the others only writing the keys of their organization.
+var writingFunctions = map[string]bool{
+	approveSensoryReading: true,
+	registerSensor:        true,
+	registerSensors:       true,
+	issueSensorToken:      true,
+	revokeSensorToken:     true,
+	decommissionSensor:    true,
+	registerApprover:      true,
+	authenticateSensor:    true,
+	setTransformation:     true,
+	setFeatureFlag:        true,
+	setValidationPolicy:   true,
+	archiveMetricReadings: true,
+	attestSensorFirmware:  true,
+}
+
+// stateVersion is the schema version of the BSCC state written by this
+// release.
+func stateVersion() int {
+	return stateMigrations[len(stateMigrations)-1].version
+}
+
+// loadMigrationRecord returns the migration record of the channel, or nil if
+// no migration was applied to its state.
+func loadMigrationRecord(stub shim.ChaincodeStubInterface) (*MigrationRecord, error) {
+	key, err := stub.CreateCompositeKey(migrationObjectType, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create migration key")
+	}
+
+	recordBytes, err := stub.GetState(key)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get migration record")
+	}
+	return decodeMigrationRecord(recordBytes)
+}
+
+func decodeMigrationRecord(recordBytes []byte) (*MigrationRecord, error) {
+	if recordBytes == nil {
+		return nil, nil
+	}
+
+	record := &MigrationRecord{}
+	if err := json.Unmarshal(recordBytes, record); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal migration record")
+	}
+	return record, nil
+}
+
+// applyStateMigrations applies the migrations of the BSCC state the channel is
+// missing within the transaction of stub, and returns its migration record.
+func applyStateMigrations(stub shim.ChaincodeStubInterface) (*MigrationRecord, error) {
+	record, err := loadMigrationRecord(stub)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		record = &MigrationRecord{}
+	}
+	if record.Version >= stateVersion() {
+		return record, nil
+	}
+
+	for _, migration := range stateMigrations {
+		if migration.version <= record.Version {
+			continue
+		}
+		if err := migration.migrate(stub); err != nil {
+			return nil, errors.WithMessagef(err, "failed to apply migration %d of the BSCC state", migration.version)
+		}
+		record.Version = migration.version
+		record.Applied = append(record.Applied, AppliedMigration{
+			Version:     migration.version,
+			Description: migration.description,
+			TxID:        stub.GetTxID(),
+		})
+		bloccProtoLogger.Infof("Applied migration %d of the BSCC state of channel %s in transaction %s: %s",
+			migration.version, stub.GetChannelID(), stub.GetTxID(), migration.description)
+	}
+
+	key, err := stub.CreateCompositeKey(migrationObjectType, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create migration key")
+	}
+	recordBytes, err := marshalState(record)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to marshal migration record")
+	}
+	if err := stub.PutState(key, recordBytes); err != nil {
+		return nil, errors.WithMessage(err, "failed to store migration record")
+	}
+
+	return record, nil
+}
+
+// MigrateState applies the pending migrations of the BSCC state of the
+// channel, which are otherwise applied by the next transaction writing to it,
+// and returns the migration record of the channel.
+func (bscc *BSCC) MigrateState(stub shim.ChaincodeStubInterface) pb.Response {
+	record, err := applyStateMigrations(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal migration record: %s", err))
+	}
+	return shim.Success(recordBytes)
+}
+
+// checkStateMigrations logs the channels whose BSCC state misses migrations,
+// as committed to the ledger of this peer.
+func (s *BloccService) checkStateMigrations() {
+	key, err := shim.CreateCompositeKey(migrationObjectType, nil)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to create migration key: %s", err)
+		return
+	}
+
+	for _, channelID := range s.joinedChannels() {
+		ledger := s.peerInfo.GetLedger(channelID)
+		if ledger == nil {
+			continue
+		}
+		qe, err := ledger.NewQueryExecutor()
+		if err != nil {
+			bloccProtoLogger.Errorf("Failed to check the BSCC state version of channel %s: %s", channelID, err)
+			continue
+		}
+		recordBytes, err := qe.GetState("bscc", key)
+		qe.Done()
+		if err != nil {
+			bloccProtoLogger.Errorf("Failed to check the BSCC state version of channel %s: %s", channelID, err)
+			continue
+		}
+		record, err := decodeMigrationRecord(recordBytes)
+		if err != nil {
+			bloccProtoLogger.Errorf("Failed to check the BSCC state version of channel %s: %s", channelID, err)
+			continue
+		}
+
+		var version int
+		if record != nil {
+			version = record.Version
+		}
+		if version < stateVersion() {
+			bloccProtoLogger.Warningf("BSCC state of channel %s is at version %d, migrations up to version %d will be applied by the next BSCC transaction or by MigrateState",
+				channelID, version, stateVersion())
+		}
+	}
+}
+
+// setDocTypes sets the document type of the approval and sensor records
+// written before the document type was introduced, which rich queries miss.
+func setDocTypes(stub shim.ChaincodeStubInterface) error {
+	for _, objectType := range []string{approvalObjectType, sensorObjectType} {
+		if err := setDocType(stub, objectType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setDocType(stub shim.ChaincodeStubInterface, objectType string) error {
+	iterator, err := stub.GetStateByPartialCompositeKey(objectType, nil)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to query %s state", objectType)
+	}
+	defer iterator.Close()
+
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return errors.WithMessagef(err, "failed to query %s state", objectType)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(kv.Value))
+		decoder.UseNumber()
+		entry := map[string]interface{}{}
+		if err := decoder.Decode(&entry); err != nil {
+			bloccProtoLogger.Warningf("Skipping undecodable %s entry %q: %s", objectType, kv.Key, err)
+			continue
+		}
+		if docType, _ := entry["docType"].(string); docType != "" {
+			continue
+		}
+
+		entry["docType"] = objectType
+		entryBytes, err := marshalState(entry)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to marshal %s entry %q", objectType, kv.Key)
+		}
+		if err := stub.PutState(kv.Key, entryBytes); err != nil {
+			return errors.WithMessagef(err, "failed to store %s entry %q", objectType, kv.Key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateMigrations(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	bscc := &BSCC{}
+
+	put := func(objectType, id, value string) string {
+		key, err := stub.CreateCompositeKey(objectType, []string{id})
+		require.NoError(t, err)
+		stub.MockTransactionStart("setup")
+		require.NoError(t, stub.PutState(key, []byte(value)))
+		stub.MockTransactionEnd("setup")
+		return key
+	}
+	migrate := func(txID string) *MigrationRecord {
+		stub.MockTransactionStart(txID)
+		defer stub.MockTransactionEnd(txID)
+		resp := bscc.MigrateState(stub)
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+		record := &MigrationRecord{}
+		require.NoError(t, json.Unmarshal(resp.Payload, record))
+		return record
+	}
+
+	oldApproval := put(approvalObjectType, "tx1", `{"txID":"tx1","reading":12345678901234567890}`)
+	newApproval := put(approvalObjectType, "tx2", `{"docType":"approval","txID":"tx2"}`)
+	oldSensor := put(sensorObjectType, "s1", `{"id":"s1"}`)
+	put(sensorObjectType, "s2", `not json`)
+
+	record := migrate("tx3")
+	require.Equal(t, stateVersion(), record.Version)
+	require.Len(t, record.Applied, len(stateMigrations))
+	require.Equal(t, AppliedMigration{Version: 1, Description: stateMigrations[0].description, TxID: "tx3"}, record.Applied[0])
+
+	require.JSONEq(t, `{"docType":"approval","txID":"tx1","reading":12345678901234567890}`, string(stub.State[oldApproval]))
+	require.JSONEq(t, `{"docType":"approval","txID":"tx2"}`, string(stub.State[newApproval]))
+	require.JSONEq(t, `{"docType":"sensor","id":"s1"}`, string(stub.State[oldSensor]))
+
+	// migrations are applied once
+	require.Equal(t, record, migrate("tx4"))
+	stub.MockTransactionStart("tx5")
+	loaded, err := loadMigrationRecord(stub)
+	stub.MockTransactionEnd("tx5")
+	require.NoError(t, err)
+	require.Equal(t, record, loaded)
+}
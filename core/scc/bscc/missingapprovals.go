@@ -0,0 +1,257 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// missingApprovalIdleInterval is the interval between two checks for the
+// missing approval deadline to be enabled.
+const missingApprovalIdleInterval = 30 * time.Second
+
+type readingKey struct {
+	channelID   string
+	sensoryTxID string
+}
+
+// expectedApprovals are the organizations expected to approve a reading and
+// that did not yet.
+type expectedApprovals struct {
+	traceID  string
+	received time.Time
+	pending  map[string]bool
+}
+
+// approverTracker tracks which of the registered approvers of a channel
+// approved or rejected each reading, so that the organizations lagging
+// behind are reported. A reading is reported once and forgotten, whether or
+// not its approvals are committed later.
+type approverTracker struct {
+	mutex    sync.Mutex
+	readings map[readingKey]*expectedApprovals
+	clock    clock.Clock
+}
+
+func newApproverTracker(clk clock.Clock) *approverTracker {
+	return &approverTracker{
+		readings: map[readingKey]*expectedApprovals{},
+		clock:    clk,
+	}
+}
+
+// expect starts tracking the approvals of the reading by the organizations,
+// unless it is tracked already.
+func (t *approverTracker) expect(channelID, sensoryTxID, traceID string, mspIDs []string) {
+	if len(mspIDs) == 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := readingKey{channelID: channelID, sensoryTxID: sensoryTxID}
+	if _, ok := t.readings[key]; ok {
+		return
+	}
+	pending := map[string]bool{}
+	for _, mspID := range mspIDs {
+		pending[mspID] = true
+	}
+	t.readings[key] = &expectedApprovals{traceID: traceID, received: t.clock.Now(), pending: pending}
+}
+
+// decided records the approval or rejection of the reading by the
+// organization.
+func (t *approverTracker) decided(channelID, sensoryTxID, mspID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := readingKey{channelID: channelID, sensoryTxID: sensoryTxID}
+	expected, ok := t.readings[key]
+	if !ok {
+		return
+	}
+	delete(expected.pending, mspID)
+	if len(expected.pending) == 0 {
+		delete(t.readings, key)
+	}
+}
+
+// overdue returns a MissingApproval event for every organization that did
+// not approve or reject a reading within deadline of its receipt.
+func (t *approverTracker) overdue(deadline time.Duration) []event.Event {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var events []event.Event
+	now := t.clock.Now()
+	for key, expected := range t.readings {
+		if now.Sub(expected.received) <= deadline {
+			continue
+		}
+		delete(t.readings, key)
+		for mspID := range expected.pending {
+			events = append(events, event.Event{
+				Type:        event.MissingApproval,
+				ChannelID:   key.channelID,
+				SensoryTxID: key.sensoryTxID,
+				MSPID:       mspID,
+				TraceID:     expected.traceID,
+			})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].ChannelID != events[j].ChannelID {
+			return events[i].ChannelID < events[j].ChannelID
+		}
+		if events[i].SensoryTxID != events[j].SensoryTxID {
+			return events[i].SensoryTxID < events[j].SensoryTxID
+		}
+		return events[i].MSPID < events[j].MSPID
+	})
+	return events
+}
+
+// expectedApprovers returns the organizations of the approvers registered on
+// the channel, as committed to the ledger of this peer, mapped to whether
+// their endorsements stand for approvals.
+func (s *BloccService) expectedApprovers(channelID string) (map[string]bool, error) {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return nil, bloccerrors.WithCategory(errors.Errorf("channel %s not found", channelID), bloccerrors.ErrChannelNotFound)
+	}
+
+	startKey, err := shim.CreateCompositeKey(approverObjectType, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create approver key")
+	}
+
+	qe, err := ledger.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create query executor")
+	}
+	defer qe.Done()
+
+	iterator, err := qe.GetStateRangeScanIterator("bscc", startKey, startKey+string(utf8.MaxRune))
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to query approvers")
+	}
+	defer iterator.Close()
+
+	approvers := map[string]bool{}
+	for {
+		result, err := iterator.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to query approvers")
+		}
+		if result == nil {
+			break
+		}
+		kv := result.(*queryresult.KV)
+		approver, err := decodeApprover(kv.Key, kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		onEndorse := approvers[approver.MSPID]
+		for _, capability := range approver.Capabilities {
+			onEndorse = onEndorse || capability == config.CapabilityApproveOnEndorse
+		}
+		approvers[approver.MSPID] = onEndorse
+	}
+
+	return approvers, nil
+}
+
+// expectApprovals starts tracking the approvals of the reading by the
+// registered approvers of its channel. The organizations approving on
+// endorsement which endorsed the reading approved it already.
+func (s *BloccService) expectApprovals(request event.Event, envelope *cb.Envelope) {
+	approvers, err := s.expectedApprovers(request.ChannelID)
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to get the approvers of channel %s: %s", request.ChannelID, err)
+		return
+	}
+
+	endorsed := map[string]bool{}
+	if envelope != nil {
+		endorsers, err := protoutil.ExtractEndorsersFromEnvelope(envelope)
+		if err != nil {
+			bloccProtoLogger.Warningf("Failed to extract endorsers of reading %s: %s", request.SensoryTxID, err)
+		}
+		for _, endorser := range endorsers {
+			identity := &msp.SerializedIdentity{}
+			if err := proto.Unmarshal(endorser, identity); err == nil {
+				endorsed[identity.Mspid] = true
+			}
+		}
+	}
+
+	var mspIDs []string
+	for mspID, onEndorse := range approvers {
+		if onEndorse && endorsed[mspID] {
+			continue
+		}
+		mspIDs = append(mspIDs, mspID)
+	}
+	s.approverTracker.expect(request.ChannelID, request.SensoryTxID, request.TraceID, mspIDs)
+}
+
+// trackApprovers records the approvals and rejections committed on the
+// channels of this peer against the expected approvers of their readings.
+func (s *BloccService) trackApprovers(events <-chan event.Event) {
+	for e := range events {
+		switch e.Type {
+		case event.ApprovalRequest:
+			if s.currentOptions().MissingApprovalDeadline > 0 {
+				s.expectApprovals(e, s.readingEnvelope(e.ChannelID, e.SensoryTxID))
+			}
+		case event.ApprovalCommitted, event.RejectionCommitted:
+			s.approverTracker.decided(e.ChannelID, e.SensoryTxID, e.MSPID)
+		}
+	}
+}
+
+// monitorMissingApprovals periodically publishes a MissingApproval event for
+// every registered approver that did not approve a reading within the
+// configured deadline. The options are read on every round so that reloaded
+// settings take effect.
+func (s *BloccService) monitorMissingApprovals(stop <-chan struct{}) {
+	for {
+		deadline := s.currentOptions().MissingApprovalDeadline
+		if deadline <= 0 {
+			if !s.sleep(stop, missingApprovalIdleInterval) {
+				return
+			}
+			continue
+		}
+		if !s.sleep(stop, deadline/4) {
+			return
+		}
+
+		for _, e := range s.approverTracker.overdue(deadline) {
+			bloccProtoLogger.Warningf("%s did not approve reading %s on channel %s within %s", e.MSPID, e.SensoryTxID, e.ChannelID, deadline)
+			s.metrics.MissingApprovals.With(ChannelLabel, e.ChannelID, OrgLabel, e.MSPID).Add(1)
+			s.eventBus.Publish(e)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApproverTracker(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	tracker := newApproverTracker(clock)
+
+	tracker.expect("mychannel", "tx1", "trace1", []string{"Org1MSP", "Org2MSP", "Org3MSP"})
+	tracker.expect("mychannel", "tx2", "trace2", []string{"Org1MSP"})
+	tracker.expect("mychannel", "tx3", "trace3", nil)
+	clock.Increment(time.Minute)
+	tracker.expect("mychannel", "tx4", "trace4", []string{"Org2MSP"})
+
+	// readings are tracked once, and forgotten once every approver decided
+	tracker.expect("mychannel", "tx1", "trace1", []string{"Org4MSP"})
+	tracker.decided("mychannel", "tx1", "Org1MSP")
+	tracker.decided("mychannel", "tx1", "Org3MSP")
+	tracker.decided("mychannel", "tx2", "Org1MSP")
+	tracker.decided("otherchannel", "tx4", "Org2MSP")
+	require.Empty(t, tracker.overdue(time.Minute))
+
+	clock.Increment(time.Second)
+	require.Equal(t, []event.Event{
+		{Type: event.MissingApproval, ChannelID: "mychannel", SensoryTxID: "tx1", MSPID: "Org2MSP", TraceID: "trace1"},
+	}, tracker.overdue(time.Minute))
+	require.Empty(t, tracker.overdue(time.Minute))
+
+	clock.Increment(time.Minute)
+	require.Equal(t, []event.Event{
+		{Type: event.MissingApproval, ChannelID: "mychannel", SensoryTxID: "tx4", MSPID: "Org2MSP", TraceID: "trace4"},
+	}, tracker.overdue(time.Minute))
+	require.Empty(t, tracker.readings)
+}
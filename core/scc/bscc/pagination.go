@@ -0,0 +1,174 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// Page is the JSON response of the BSCC list functions. Bookmark is passed
+// back to fetch the next page and is empty once all records were returned.
+type Page struct {
+	Records  interface{} `json:"records"`
+	Bookmark string      `json:"bookmark"`
+}
+
+// pageRequest holds the pagination args of a list function. A zero page size
+// returns all records at once.
+type pageRequest struct {
+	pageSize int32
+	bookmark string
+}
+
+// parsePageRequest reads the page size and bookmark from args[0] and args[1],
+// both of which are optional.
+func parsePageRequest(args [][]byte) (pageRequest, error) {
+	var req pageRequest
+	if len(args) > 0 && len(args[0]) > 0 {
+		pageSize, err := strconv.ParseInt(string(args[0]), 10, 32)
+		if err != nil || pageSize < 0 {
+			return pageRequest{}, errors.Errorf("invalid page size '%s'", string(args[0]))
+		}
+		req.pageSize = int32(pageSize)
+	}
+	if len(args) > 1 {
+		req.bookmark = string(args[1])
+	}
+	return req, nil
+}
+
+// iteratePage calls visit for every state entry of the requested page of the
+// partial composite key and returns the bookmark of the next page.
+func iteratePage(stub shim.ChaincodeStubInterface, objectType string, attributes []string, req pageRequest, visit func(key string, value []byte) error) (string, error) {
+	if req.pageSize == 0 {
+		iter, err := stub.GetStateByPartialCompositeKey(objectType, attributes)
+		if err != nil {
+			return "", err
+		}
+		return "", drain(iter, visit)
+	}
+
+	iter, metadata, err := stub.GetStateByPartialCompositeKeyWithPagination(objectType, attributes, req.pageSize, req.bookmark)
+	if err != nil {
+		return "", err
+	}
+	return nextBookmark(req, metadata), drain(iter, visit)
+}
+
+// iterateQueryPage calls visit for every state entry of the requested page of
+// the rich query result and returns the bookmark of the next page.
+func iterateQueryPage(stub shim.ChaincodeStubInterface, query string, req pageRequest, visit func(key string, value []byte) error) (string, error) {
+	if req.pageSize == 0 {
+		iter, err := stub.GetQueryResult(query)
+		if err != nil {
+			return "", err
+		}
+		return "", drain(iter, visit)
+	}
+
+	iter, metadata, err := stub.GetQueryResultWithPagination(query, req.pageSize, req.bookmark)
+	if err != nil {
+		return "", err
+	}
+	return nextBookmark(req, metadata), drain(iter, visit)
+}
+
+// iterateOffsetPage calls visit for every state entry of the requested page
+// of the entries returned by query and returns the bookmark of the next page.
+// The bookmarks are the number of entries of the previous pages rather than
+// state keys, so that they do not disclose the keys of redacted records, and
+// every page reads the entries of the previous ones again. Entries added or
+// removed between two pages may shift the next one.
+func iterateOffsetPage(req pageRequest, query func(pageSize int32) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error), visit func(key string, value []byte) error) (string, error) {
+	if req.pageSize == 0 {
+		iter, _, err := query(0)
+		if err != nil {
+			return "", err
+		}
+		return "", drain(iter, visit)
+	}
+
+	var offset int64
+	if req.bookmark != "" {
+		var err error
+		offset, err = strconv.ParseInt(req.bookmark, 10, 32)
+		if err != nil || offset < 0 || offset+int64(req.pageSize) > math.MaxInt32 {
+			return "", errors.Errorf("invalid bookmark '%s'", req.bookmark)
+		}
+	}
+
+	end := int32(offset) + req.pageSize
+	iter, _, err := query(end)
+	if err != nil {
+		return "", err
+	}
+	var fetched int32
+	err = drain(iter, func(key string, value []byte) error {
+		fetched++
+		if int64(fetched) <= offset {
+			return nil
+		}
+		return visit(key, value)
+	})
+	if err != nil || fetched < end {
+		// a short page is the last one
+		return "", err
+	}
+	return strconv.FormatInt(int64(end), 10), nil
+}
+
+// partialKeyQuery returns the query of iterateOffsetPage reading the state
+// entries of the partial composite key.
+func partialKeyQuery(stub shim.ChaincodeStubInterface, objectType string, attributes []string) func(pageSize int32) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return func(pageSize int32) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+		if pageSize == 0 {
+			iter, err := stub.GetStateByPartialCompositeKey(objectType, attributes)
+			return iter, nil, err
+		}
+		return stub.GetStateByPartialCompositeKeyWithPagination(objectType, attributes, pageSize, "")
+	}
+}
+
+// richQuery returns the query of iterateOffsetPage reading the state entries
+// of the rich query.
+func richQuery(stub shim.ChaincodeStubInterface, query string) func(pageSize int32) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return func(pageSize int32) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+		if pageSize == 0 {
+			iter, err := stub.GetQueryResult(query)
+			return iter, nil, err
+		}
+		return stub.GetQueryResultWithPagination(query, pageSize, "")
+	}
+}
+
+func nextBookmark(req pageRequest, metadata *pb.QueryResponseMetadata) string {
+	// a short page is the last one
+	if metadata.GetFetchedRecordsCount() < req.pageSize {
+		return ""
+	}
+	return metadata.GetBookmark()
+}
+
+func drain(iter shim.StateQueryIteratorInterface, visit func(key string, value []byte) error) error {
+	defer iter.Close()
+
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return err
+		}
+		if err := visit(kv.Key, kv.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"time"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+)
+
+// eventPayload is the JSON document describing a BLOCC event to external
+// systems, posted to webhook endpoints and mirrored onto streaming platforms.
+type eventPayload struct {
+	Type           string `json:"type"`
+	ChannelID      string `json:"channelID"`
+	Timestamp      int64  `json:"timestamp"`
+	SensoryTxID    string `json:"sensoryTxID,omitempty"`
+	MSPID          string `json:"mspID,omitempty"`
+	SensorID       string `json:"sensorID,omitempty"`
+	LastSeen       int64  `json:"lastSeen,omitempty"`
+	PeerHeight     uint64 `json:"peerHeight,omitempty"`
+	OrdererHeight  uint64 `json:"ordererHeight,omitempty"`
+	Forked         *bool  `json:"forked,omitempty"`
+	TraceID        string `json:"traceID,omitempty"`
+	ValidationCode string `json:"validationCode,omitempty"`
+	Note           string `json:"note,omitempty"`
+	// RejectionRate and AnomalyFrequency are only set for SensorUnreliable
+	// events
+	RejectionRate    float64 `json:"rejectionRate,omitempty"`
+	AnomalyFrequency float64 `json:"anomalyFrequency,omitempty"`
+	// PreflightFailures is only set for ServiceReady events
+	PreflightFailures []string `json:"preflightFailures,omitempty"`
+}
+
+func newEventPayload(e event.Event, now time.Time) *eventPayload {
+	payload := &eventPayload{
+		Type:           e.Type.String(),
+		ChannelID:      e.ChannelID,
+		Timestamp:      now.Unix(),
+		SensoryTxID:    e.SensoryTxID,
+		MSPID:          e.MSPID,
+		SensorID:       e.SensorID,
+		PeerHeight:     e.PeerHeight,
+		OrdererHeight:  e.OrdererHeight,
+		TraceID:        e.TraceID,
+		ValidationCode: e.ValidationCode,
+		Note:           e.Note,
+	}
+	payload.RejectionRate = e.RejectionRate
+	payload.AnomalyFrequency = e.AnomalyFrequency
+	payload.PreflightFailures = e.PreflightFailures
+	if !e.LastSeen.IsZero() {
+		payload.LastSeen = e.LastSeen.Unix()
+	}
+	if e.Type == event.ForkStatusChanged {
+		forked := e.Forked
+		payload.Forked = &forked
+	}
+	return payload
+}
@@ -0,0 +1,91 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// verifyPayloadReference checks, if enabled, that the off-chain payload
+// referenced by the reading in envelope matches the hash the reading carries.
+// Readings without reference are accepted as is. A *Rejection is returned if
+// the payload does not match, and an error if it could not be fetched, so
+// that the approval is attempted again.
+func (s *BloccService) verifyPayloadReference(envelope *cb.Envelope) error {
+	options := s.currentOptions()
+	if !options.VerifyPayloadReferences {
+		return nil
+	}
+
+	ref, err := protoutil.ExtractPayloadReferenceFromEnvelope(envelope)
+	if err != nil {
+		return reject(ReasonMalformedReading, "failed to extract payload reference: %s", err)
+	}
+	if ref == nil {
+		return nil
+	}
+
+	u, err := url.Parse(ref.URI)
+	if err != nil {
+		return reject(ReasonMalformedReading, "invalid payload URI %s: %s", ref.URI, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("cannot fetch payload %s, unsupported scheme %s", ref.URI, u.Scheme)
+	}
+	if options.MaxPayloadSize > 0 && ref.Size > options.MaxPayloadSize {
+		return errors.Errorf("payload %s of %d bytes exceeds the limit of %d bytes", ref.URI, ref.Size, options.MaxPayloadSize)
+	}
+
+	ctx := context.Background()
+	if options.PayloadFetchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.PayloadFetchTimeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref.URI, nil)
+	if err != nil {
+		return reject(ReasonMalformedReading, "invalid payload URI %s: %s", ref.URI, err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch payload %s", ref.URI)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to fetch payload %s: %s", ref.URI, resp.Status)
+	}
+
+	body := io.Reader(resp.Body)
+	if options.MaxPayloadSize > 0 {
+		body = io.LimitReader(resp.Body, options.MaxPayloadSize+1)
+	}
+	hash := sha256.New()
+	size, err := io.Copy(hash, body)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read payload %s", ref.URI)
+	}
+	if options.MaxPayloadSize > 0 && size > options.MaxPayloadSize {
+		return errors.Errorf("payload %s exceeds the limit of %d bytes", ref.URI, options.MaxPayloadSize)
+	}
+
+	if ref.Size > 0 && size != ref.Size {
+		return reject(ReasonPayloadMismatch, "payload %s is %d bytes, the reading references %d bytes", ref.URI, size, ref.Size)
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(sum, ref.SHA256) {
+		return reject(ReasonPayloadMismatch, "payload %s hashes to %s, the reading references %s", ref.URI, sum, ref.SHA256)
+	}
+	return nil
+}
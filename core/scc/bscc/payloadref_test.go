@@ -0,0 +1,67 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPayloadReference(t *testing.T) {
+	waveform := []byte("vibration waveform")
+	sum := sha256.Sum256(waveform)
+	hash := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/w1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(waveform)
+	}))
+	defer server.Close()
+
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+	verify := func(uri, hash string, size int) error {
+		return bscc.verifyPayloadReference(readingEnvelope(t, "Set",
+			fmt.Sprintf(`{"payloadRef":{"uri":"%s","sha256":"%s","size":%d}}`, uri, hash, size), "", "1628887200"))
+	}
+
+	// payloads are not fetched unless enabled
+	require.NoError(t, verify(server.URL+"/missing", hash, 0))
+
+	bscc.options = config.Options{VerifyPayloadReferences: true, MaxPayloadSize: 1024}
+	require.NoError(t, verify(server.URL+"/w1", hash, len(waveform)))
+	require.NoError(t, bscc.verifyPayloadReference(readingEnvelope(t, "Set", "21.5", "0.4", "1628887200")))
+
+	err := verify(server.URL+"/w1", hex.EncodeToString(make([]byte, 32)), 0)
+	require.Equal(t, ReasonPayloadMismatch, err.(*Rejection).Reason)
+	require.Contains(t, err.Error(), "hashes to "+hash)
+	err = verify(server.URL+"/w1", hash, 512)
+	require.Equal(t, &Rejection{Reason: ReasonPayloadMismatch, Message: fmt.Sprintf("payload %s/w1 is 18 bytes, the reading references 512 bytes", server.URL)}, err)
+	err = verify(server.URL+"/w1", "abc", 0)
+	require.Equal(t, ReasonMalformedReading, err.(*Rejection).Reason)
+
+	// payloads that cannot be fetched are not rejected, to be verified again
+	err = verify(server.URL+"/missing", hash, 0)
+	require.EqualError(t, err, fmt.Sprintf("failed to fetch payload %s/missing: 404 Not Found", server.URL))
+	err = verify("s3://bucket/w1", hash, 0)
+	require.EqualError(t, err, "cannot fetch payload s3://bucket/w1, unsupported scheme s3")
+	err = verify(server.URL+"/w1", hash, 2048)
+	require.EqualError(t, err, fmt.Sprintf("payload %s/w1 of 2048 bytes exceeds the limit of 1024 bytes", server.URL))
+
+	bscc.options.MaxPayloadSize = 8
+	err = verify(server.URL+"/w1", hash, 0)
+	require.EqualError(t, err, fmt.Sprintf("payload %s/w1 exceeds the limit of 8 bytes", server.URL))
+}
@@ -0,0 +1,65 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
+	"github.com/hyperledger/fabric/msp"
+)
+
+// PeerInfoProvider gives BSCC access to the channels, ledgers and orderers
+// known to the peer.
+type PeerInfoProvider interface {
+	// GetCryptoProvider returns the crypto provider of the peer
+	GetCryptoProvider() bccsp.BCCSP
+	// GetOrdererInfo returns the orderer addresses of the channel
+	GetOrdererInfo(channelID string) ([]string, map[string]orderers.OrdererOrg, error)
+	// GetChannelsInfo returns the channels the peer has joined
+	GetChannelsInfo() []*pb.ChannelInfo
+	// GetLedger returns the ledger of the channel, or nil if the peer has
+	// not joined it
+	GetLedger(channelID string) ledger.PeerLedger
+	// GetMSPManager returns the MSP manager of the channel, or nil if the
+	// peer has not joined it
+	GetMSPManager(channelID string) msp.MSPManager
+	// GetChannelResources returns the configuration of the channel, or nil
+	// if the peer has not joined it
+	GetChannelResources(channelID string) channelconfig.Resources
+}
+
+// NewPeerInfoProvider returns the PeerInfoProvider of the peer instance.
+func NewPeerInfoProvider(peerInstance *peer.Peer) PeerInfoProvider {
+	return &peerInfoAdapter{Peer: peerInstance}
+}
+
+type peerInfoAdapter struct {
+	*peer.Peer
+}
+
+func (p *peerInfoAdapter) GetCryptoProvider() bccsp.BCCSP {
+	return p.CryptoProvider
+}
+
+func (p *peerInfoAdapter) GetMSPManager(channelID string) msp.MSPManager {
+	channel := p.Channel(channelID)
+	if channel == nil {
+		return nil
+	}
+	return channel.MSPManager()
+}
+
+func (p *peerInfoAdapter) GetChannelResources(channelID string) channelconfig.Resources {
+	channel := p.Channel(channelID)
+	if channel == nil {
+		return nil
+	}
+	return channel.Resources()
+}
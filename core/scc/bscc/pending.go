@@ -0,0 +1,564 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/fileutil"
+	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// PendingStatus reports a flush of the approvals stored while the orderer
+// was unavailable.
+type PendingStatus struct {
+	// Flushed is the number of stored approvals broadcast by the flush
+	Flushed int `json:"flushed"`
+	// Dropped is the number of stored approvals the orderer rejected and
+	// that were dropped, as they would be rejected again
+	Dropped int `json:"dropped"`
+	// Pending is the number of approvals still stored after the flush
+	Pending int `json:"pending"`
+	// Error reports why approvals are still stored, if any
+	Error string `json:"error,omitempty"`
+}
+
+// The fsync policies of the stored approvals.
+const (
+	// FsyncWrite syncs every stored approval to disk before the approval is
+	// reported stored, so that no stored approval is lost in a crash.
+	FsyncWrite = "write"
+	// FsyncPeriodic syncs the approvals stored since the last sync every
+	// fsync interval, the approvals of the interval being lost in a crash.
+	FsyncPeriodic = "periodic"
+	// FsyncNone leaves the stored approvals to be written back by the
+	// operating system.
+	FsyncNone = "none"
+)
+
+// pendingQuarantineDir is the subdirectory of the pending approvals
+// directory the stored approvals found corrupt at startup are moved to.
+const pendingQuarantineDir = "corrupt"
+
+// pendingRecovery reports the recovery of the approvals stored before the
+// peer started.
+type pendingRecovery struct {
+	// Recovered is the number of valid stored approvals
+	Recovered int
+	// Quarantined is the number of stored approvals found corrupt, e.g.
+	// truncated by a crash, and moved to the quarantine directory
+	Quarantined int
+}
+
+// pendingApproval is a signed approval transaction stored in a file of the
+// pending approvals directory, named after its sequence, channel and
+// transaction ID so that the files list in the order they were stored.
+type pendingApproval struct {
+	sequence  uint64
+	channelID string
+	txID      string
+}
+
+func (a pendingApproval) fileName() string {
+	return fmt.Sprintf("%020d_%s_%s", a.sequence, a.channelID, a.txID)
+}
+
+// parsePendingApproval parses the name of a pending approval file. The
+// channel names cannot contain underscores.
+func parsePendingApproval(name string) (pendingApproval, bool) {
+	parts := strings.SplitN(name, "_", 3)
+	if len(parts) != 3 {
+		return pendingApproval{}, false
+	}
+	sequence, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return pendingApproval{}, false
+	}
+	return pendingApproval{sequence: sequence, channelID: parts[1], txID: parts[2]}, true
+}
+
+// pendingApprovals stores the signed approval transactions that could not be
+// broadcast as the orderer was unavailable, until they are flushed.
+type pendingApprovals struct {
+	dir   string
+	fsync string
+	// mutex guards the files and counts, flushLock serializes the flushes
+	mutex     sync.Mutex
+	flushLock sync.Mutex
+	loaded    bool
+	recovery  pendingRecovery
+	sequence  uint64
+	counts    map[string]int
+	// unsynced are the files stored since the last periodic sync
+	unsynced []string
+}
+
+// newPendingApprovals returns the store of the pending approvals of dir,
+// synced to disk according to the fsync policy.
+func newPendingApprovals(dir, fsync string) (*pendingApprovals, error) {
+	switch fsync {
+	case FsyncWrite, FsyncPeriodic, FsyncNone:
+	default:
+		return nil, errors.Errorf("unknown fsync policy '%s'", fsync)
+	}
+	return &pendingApprovals{dir: dir, fsync: fsync, counts: map[string]int{}}, nil
+}
+
+// load reads the approvals stored in the directory, once, so that the
+// sequence continues from the last stored approval. The files left over by
+// a crash are validated: the partially written files are removed and the
+// corrupt approvals are quarantined.
+func (p *pendingApprovals) load() error {
+	if p.loaded {
+		return nil
+	}
+	if err := p.removeTempFiles(); err != nil {
+		return err
+	}
+	approvals, err := p.list()
+	if err != nil {
+		return err
+	}
+	for _, a := range approvals {
+		// the quarantined approvals keep their sequence, so that their files
+		// are never overwritten
+		if a.sequence > p.sequence {
+			p.sequence = a.sequence
+		}
+		if err := p.validate(a); err != nil {
+			bloccProtoLogger.Errorf("Quarantining corrupt stored approval %s of channel %s: %s", a.txID, a.channelID, err)
+			if err := p.quarantine(a); err != nil {
+				return err
+			}
+			p.recovery.Quarantined++
+			continue
+		}
+		p.recovery.Recovered++
+		p.counts[a.channelID]++
+	}
+	p.loaded = true
+	return nil
+}
+
+// restore loads the approvals stored before the peer started and reports
+// their recovery.
+func (p *pendingApprovals) restore() (pendingRecovery, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.load(); err != nil {
+		return pendingRecovery{}, err
+	}
+	return p.recovery, nil
+}
+
+// removeTempFiles removes the files of the approvals whose store was
+// interrupted before they were renamed into place.
+func (p *pendingApprovals) removeTempFiles() error {
+	files, err := ioutil.ReadDir(p.dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", p.dir)
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasPrefix(f.Name(), ".") || !strings.HasSuffix(f.Name(), ".tmp") {
+			continue
+		}
+		path := filepath.Join(p.dir, f.Name())
+		if err := os.Remove(path); err != nil {
+			return errors.Wrapf(err, "failed to remove %s", path)
+		}
+	}
+	return nil
+}
+
+// validate checks that the stored approval is the approval transaction its
+// file is named after.
+func (p *pendingApprovals) validate(a pendingApproval) error {
+	env, err := p.read(a)
+	if err != nil {
+		return err
+	}
+	chdr, err := protoutil.ChannelHeader(env)
+	if err != nil {
+		return errors.WithMessage(err, "failed to read the approval transaction")
+	}
+	if chdr.ChannelId != a.channelID || chdr.TxId != a.txID {
+		return errors.Errorf("approval transaction %s of channel %s stored as %s", chdr.TxId, chdr.ChannelId, a.fileName())
+	}
+	return nil
+}
+
+// quarantine moves the corrupt stored approval out of the pending approvals.
+func (p *pendingApprovals) quarantine(a pendingApproval) error {
+	dir := filepath.Join(p.dir, pendingQuarantineDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create %s", dir)
+	}
+	path := filepath.Join(p.dir, a.fileName())
+	if err := os.Rename(path, filepath.Join(dir, a.fileName())); err != nil {
+		return errors.Wrapf(err, "failed to quarantine %s", path)
+	}
+	return nil
+}
+
+// sync syncs the approvals stored since the last sync to disk, with the
+// directory entries of the approvals stored and removed since.
+func (p *pendingApprovals) sync() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, path := range p.unsynced {
+		if err := syncFile(path); err != nil && !os.IsNotExist(errors.Cause(err)) {
+			return err
+		}
+	}
+	p.unsynced = nil
+	if err := fileutil.SyncDir(p.dir); err != nil && !os.IsNotExist(errors.Cause(err)) {
+		return err
+	}
+	return nil
+}
+
+func syncFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer f.Close()
+	if err := f.Sync(); err != nil {
+		return errors.Wrapf(err, "failed to sync %s", path)
+	}
+	return nil
+}
+
+// list returns the stored approvals in the order they were stored.
+func (p *pendingApprovals) list() ([]pendingApproval, error) {
+	files, err := ioutil.ReadDir(p.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", p.dir)
+	}
+
+	var approvals []pendingApproval
+	for _, f := range files {
+		if a, ok := parsePendingApproval(f.Name()); ok && !f.IsDir() {
+			approvals = append(approvals, a)
+		}
+	}
+	sort.Slice(approvals, func(i, j int) bool { return approvals[i].sequence < approvals[j].sequence })
+	return approvals, nil
+}
+
+// channelCounts returns the number of stored approvals of each channel.
+func (p *pendingApprovals) channelCounts() (map[string]int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+	counts := map[string]int{}
+	for channelID, count := range p.counts {
+		counts[channelID] = count
+	}
+	return counts, nil
+}
+
+// store stores the approval transaction env and returns the number of stored
+// approvals of its channel.
+func (p *pendingApprovals) store(channelID, txID string, env *cb.Envelope) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.load(); err != nil {
+		return 0, err
+	}
+	envBytes, err := proto.Marshal(env)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to marshal approval transaction")
+	}
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return 0, errors.Wrapf(err, "failed to create %s", p.dir)
+	}
+
+	a := pendingApproval{sequence: p.sequence + 1, channelID: channelID, txID: txID}
+	path := filepath.Join(p.dir, a.fileName())
+	tmpPath := filepath.Join(p.dir, "."+a.fileName()+".tmp")
+	if p.fsync == FsyncWrite {
+		if err := fileutil.CreateAndSyncFile(tmpPath, envBytes, 0o644); err != nil {
+			return 0, err
+		}
+	} else if err := ioutil.WriteFile(tmpPath, envBytes, 0o644); err != nil {
+		return 0, errors.Wrapf(err, "failed to write %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, errors.Wrapf(err, "failed to rename %s", tmpPath)
+	}
+	switch p.fsync {
+	case FsyncWrite:
+		if err := fileutil.SyncDir(p.dir); err != nil {
+			return 0, err
+		}
+	case FsyncPeriodic:
+		p.unsynced = append(p.unsynced, path)
+	}
+
+	p.sequence = a.sequence
+	p.counts[channelID]++
+	return p.counts[channelID], nil
+}
+
+func (p *pendingApprovals) read(a pendingApproval) (*cb.Envelope, error) {
+	path := filepath.Join(p.dir, a.fileName())
+	envBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	env := &cb.Envelope{}
+	if err := proto.Unmarshal(envBytes, env); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", path)
+	}
+	return env, nil
+}
+
+// remove removes the stored approval and returns the number of stored
+// approvals left of its channel.
+func (p *pendingApprovals) remove(a pendingApproval) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	path := filepath.Join(p.dir, a.fileName())
+	if err := os.Remove(path); err != nil {
+		return p.counts[a.channelID], errors.Wrapf(err, "failed to remove %s", path)
+	}
+	// a removal lost in a crash only broadcasts the approval again, to be
+	// invalidated as a duplicate transaction, so it is not synced on its own
+	p.counts[a.channelID]--
+	if p.counts[a.channelID] <= 0 {
+		delete(p.counts, a.channelID)
+		return 0, nil
+	}
+	return p.counts[a.channelID], nil
+}
+
+// flush broadcasts the stored approvals with send in the order they were
+// stored. The approvals of a channel are left stored from the first one that
+// failed and may succeed later, see retryable, so that their order is
+// preserved. The approvals the orderer rejected are dropped, as they would be
+// rejected again. removed is called with the number of approvals left of the
+// channel each time an approval is removed.
+func (p *pendingApprovals) flush(send func(channelID string, env *cb.Envelope) error, removed func(channelID string, left int)) PendingStatus {
+	p.flushLock.Lock()
+	defer p.flushLock.Unlock()
+
+	p.mutex.Lock()
+	err := p.load()
+	var approvals []pendingApproval
+	if err == nil {
+		approvals, err = p.list()
+	}
+	p.mutex.Unlock()
+	if err != nil {
+		return PendingStatus{Error: err.Error()}
+	}
+
+	status := PendingStatus{}
+	failed := map[string]bool{}
+	var failures []string
+	for _, a := range approvals {
+		if failed[a.channelID] {
+			status.Pending++
+			continue
+		}
+		env, err := p.read(a)
+		if err != nil {
+			bloccProtoLogger.Errorf("Dropping unreadable stored approval %s of channel %s: %s", a.txID, a.channelID, err)
+			status.Dropped++
+		} else if err := send(a.channelID, env); err != nil && retryable(err) {
+			bloccProtoLogger.Warningf("Failed to flush stored approval %s of channel %s: %s", a.txID, a.channelID, err)
+			failed[a.channelID] = true
+			failures = append(failures, fmt.Sprintf("channel %s: %s", a.channelID, err))
+			status.Pending++
+			continue
+		} else if err != nil {
+			bloccProtoLogger.Errorf("Dropping stored approval %s of channel %s rejected by the orderer: %s", a.txID, a.channelID, err)
+			status.Dropped++
+		} else {
+			status.Flushed++
+		}
+
+		left, err := p.remove(a)
+		if err != nil {
+			bloccProtoLogger.Errorf("Failed to remove stored approval %s of channel %s: %s", a.txID, a.channelID, err)
+			continue
+		}
+		removed(a.channelID, left)
+	}
+	status.Error = strings.Join(failures, "; ")
+	return status
+}
+
+// currentPending returns the store of the pending approvals, nil if
+// store-and-forward is disabled.
+func (s *BloccService) currentPending() *pendingApprovals {
+	s.runLock.Lock()
+	defer s.runLock.Unlock()
+	return s.pending
+}
+
+// restorePendingApprovals recovers the approvals stored before the peer was
+// restarted.
+func (s *BloccService) restorePendingApprovals() {
+	start := s.clock.Now()
+	recovery, err := s.pending.restore()
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to restore the stored approvals: %s", err)
+		return
+	}
+	s.metrics.PendingRecoveryDuration.Set(s.clock.Since(start).Seconds())
+	s.metrics.PendingRecovered.With("status", "recovered").Set(float64(recovery.Recovered))
+	s.metrics.PendingRecovered.With("status", "quarantined").Set(float64(recovery.Quarantined))
+	if recovery.Quarantined > 0 {
+		bloccProtoLogger.Warningf("Quarantined %d corrupt stored approvals in %s", recovery.Quarantined, filepath.Join(s.pending.dir, pendingQuarantineDir))
+	}
+
+	counts, err := s.pending.channelCounts()
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to restore the stored approvals: %s", err)
+		return
+	}
+	for channelID, count := range counts {
+		bloccProtoLogger.Infof("Channel %s in store-and-forward mode, %d approvals stored", channelID, count)
+		s.metrics.PendingApprovals.With(ChannelLabel, channelID).Set(float64(count))
+	}
+}
+
+// storeApproval stores the signed approval transaction env, which could not
+// be broadcast as the orderer is unavailable, to be flushed later. Its
+// channel enters the store-and-forward mode until its stored approvals are
+// flushed.
+func (s *BloccService) storeApproval(env *cb.Envelope) error {
+	pending := s.currentPending()
+	if pending == nil {
+		return errors.New("store-and-forward is disabled")
+	}
+	chdr, err := protoutil.ChannelHeader(env)
+	if err != nil {
+		return errors.WithMessage(err, "failed to read the approval transaction")
+	}
+
+	count, err := pending.store(chdr.ChannelId, chdr.TxId, env)
+	if err != nil {
+		return err
+	}
+	if count == 1 {
+		bloccProtoLogger.Warningf("Orderer of channel %s unavailable, entering store-and-forward mode", chdr.ChannelId)
+	}
+	s.metrics.PendingApprovals.With(ChannelLabel, chdr.ChannelId).Set(float64(count))
+	return nil
+}
+
+// forwarding returns whether the channel channelID is in store-and-forward
+// mode, its stored approvals waiting to be broadcast.
+func (s *BloccService) forwarding(channelID string) bool {
+	pending := s.currentPending()
+	if pending == nil {
+		return false
+	}
+	counts, err := pending.channelCounts()
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to read the stored approvals: %s", err)
+		return false
+	}
+	return counts[channelID] > 0
+}
+
+// flushPendingApprovals broadcasts the stored approvals, the channels whose
+// approvals were all flushed leaving the store-and-forward mode.
+func (s *BloccService) flushPendingApprovals() PendingStatus {
+	pending := s.currentPending()
+	if pending == nil {
+		return PendingStatus{Error: "store-and-forward is disabled"}
+	}
+
+	status := pending.flush(s.broadcastApproval, func(channelID string, left int) {
+		s.metrics.PendingApprovals.With(ChannelLabel, channelID).Set(float64(left))
+		if left == 0 {
+			bloccProtoLogger.Infof("Stored approvals of channel %s flushed, leaving store-and-forward mode", channelID)
+		}
+	})
+	if status.Flushed > 0 || status.Dropped > 0 {
+		bloccProtoLogger.Infof("Flushed %d stored approvals, %d dropped, %d still pending", status.Flushed, status.Dropped, status.Pending)
+	}
+	return status
+}
+
+// broadcastApproval broadcasts the signed approval transaction env to the
+// orderer of the channel.
+func (s *BloccService) broadcastApproval(channelID string, env *cb.Envelope) error {
+	return s.withOrderer(channelID, func(address, rootCertFilePath string, bftEndpoints []string) error {
+		return blocc.BroadcastEnvelope(s.currentStreams(), s.pinner, address, rootCertFilePath, bftEndpoints, env)
+	})
+}
+
+// forwardPendingApprovals flushes the stored approvals periodically, until
+// stop is closed.
+func (s *BloccService) forwardPendingApprovals(stop <-chan struct{}) {
+	for s.sleep(stop, s.currentOptions().StoreAndForwardFlushInterval) {
+		counts, err := s.pending.channelCounts()
+		if err != nil {
+			bloccProtoLogger.Errorf("Failed to read the stored approvals: %s", err)
+			continue
+		}
+		if len(counts) > 0 {
+			s.flushPendingApprovals()
+		}
+	}
+}
+
+// syncPendingApprovals syncs the stored approvals to disk every fsync
+// interval, and a last time when stop is closed.
+func (s *BloccService) syncPendingApprovals(stop <-chan struct{}) {
+	for {
+		stopped := !s.sleep(stop, s.currentOptions().StoreAndForwardFsyncInterval)
+		if err := s.pending.sync(); err != nil {
+			bloccProtoLogger.Errorf("Failed to sync the stored approvals: %s", err)
+		}
+		if stopped {
+			return
+		}
+	}
+}
+
+// FlushPendingApprovals broadcasts the approvals stored while the orderer was
+// unavailable, without waiting for the next periodic flush, and returns the
+// JSON encoded PendingStatus.
+func (bscc *BSCC) FlushPendingApprovals() pb.Response {
+	statusBytes, err := json.Marshal(bscc.flushPendingApprovals())
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal pending approvals status: %s", err))
+	}
+	return shim.Success(statusBytes)
+}
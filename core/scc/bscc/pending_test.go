@@ -0,0 +1,193 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"code.cloudfoundry.org/clock"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func pendingEnvelope(channelID, txID string) *cb.Envelope {
+	return &cb.Envelope{
+		Payload: protoutil.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{ChannelId: channelID, TxId: txID}),
+			},
+		}),
+	}
+}
+
+func txIDOf(t *testing.T, env *cb.Envelope) string {
+	chdr, err := protoutil.ChannelHeader(env)
+	require.NoError(t, err)
+	return chdr.TxId
+}
+
+func TestPendingApprovalsStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "pending")
+	pending, err := newPendingApprovals(dir, FsyncWrite)
+	require.NoError(t, err)
+
+	for _, a := range []struct{ channelID, txID string }{{"ch1", "tx1"}, {"ch2", "tx2"}, {"ch1", "tx3"}} {
+		_, err := pending.store(a.channelID, a.txID, pendingEnvelope(a.channelID, a.txID))
+		require.NoError(t, err)
+	}
+	counts, err := pending.channelCounts()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"ch1": 2, "ch2": 1}, counts)
+
+	// the sequence continues from the approvals stored before a restart
+	pending, err = newPendingApprovals(dir, FsyncWrite)
+	require.NoError(t, err)
+	count, err := pending.store("ch1", "tx4", pendingEnvelope("ch1", "tx4"))
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	approvals, err := pending.list()
+	require.NoError(t, err)
+	var txIDs []string
+	for _, a := range approvals {
+		txIDs = append(txIDs, a.txID)
+	}
+	require.Equal(t, []string{"tx1", "tx2", "tx3", "tx4"}, txIDs)
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+	require.Equal(t, "00000000000000000004_ch1_tx4", files[3].Name())
+}
+
+func TestPendingApprovalsFlush(t *testing.T) {
+	pending, err := newPendingApprovals(t.TempDir(), FsyncWrite)
+	require.NoError(t, err)
+	for _, a := range []struct{ channelID, txID string }{
+		{"ch1", "tx1"}, {"ch2", "tx2"}, {"ch1", "tx3"}, {"ch2", "tx4"}, {"ch3", "tx5"},
+	} {
+		_, err := pending.store(a.channelID, a.txID, pendingEnvelope(a.channelID, a.txID))
+		require.NoError(t, err)
+	}
+
+	// ch2 is still unavailable and its approvals are kept in order, the
+	// approval rejected on ch3 is dropped
+	var sent []string
+	left := map[string]int{}
+	send := func(channelID string, env *cb.Envelope) error {
+		switch channelID {
+		case "ch2":
+			return errors.New("connection refused")
+		case "ch3":
+			return &common.BroadcastStatusError{Status: cb.Status_BAD_REQUEST}
+		}
+		sent = append(sent, txIDOf(t, env))
+		return nil
+	}
+	removed := func(channelID string, n int) { left[channelID] = n }
+
+	status := pending.flush(send, removed)
+	require.Equal(t, PendingStatus{Flushed: 2, Dropped: 1, Pending: 2, Error: "channel ch2: connection refused"}, status)
+	require.Equal(t, []string{"tx1", "tx3"}, sent)
+	require.Equal(t, map[string]int{"ch1": 0, "ch3": 0}, left)
+
+	sent = nil
+	status = pending.flush(func(channelID string, env *cb.Envelope) error {
+		sent = append(sent, txIDOf(t, env))
+		return nil
+	}, removed)
+	require.Equal(t, PendingStatus{Flushed: 2}, status)
+	require.Equal(t, []string{"tx2", "tx4"}, sent)
+	counts, err := pending.channelCounts()
+	require.NoError(t, err)
+	require.Empty(t, counts)
+}
+
+func TestStoreApproval(t *testing.T) {
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+
+	err := bscc.storeApproval(pendingEnvelope("mychannel", "tx1"))
+	require.EqualError(t, err, "store-and-forward is disabled")
+	require.False(t, bscc.forwarding("mychannel"))
+	status := PendingStatus{}
+	require.NoError(t, json.Unmarshal(bscc.FlushPendingApprovals().Payload, &status))
+	require.Equal(t, PendingStatus{Error: "store-and-forward is disabled"}, status)
+
+	bscc.pending, err = newPendingApprovals(t.TempDir(), FsyncWrite)
+	require.NoError(t, err)
+	require.NoError(t, bscc.storeApproval(pendingEnvelope("mychannel", "tx1")))
+	counts, err := bscc.pending.channelCounts()
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"mychannel": 1}, counts)
+	require.True(t, bscc.forwarding("mychannel"))
+	require.False(t, bscc.forwarding("otherchannel"))
+	require.Error(t, bscc.storeApproval(&cb.Envelope{Payload: []byte("garbage")}))
+}
+
+func TestPendingApprovalsRecovery(t *testing.T) {
+	_, err := newPendingApprovals(t.TempDir(), "sometimes")
+	require.EqualError(t, err, "unknown fsync policy 'sometimes'")
+
+	dir := t.TempDir()
+	pending, err := newPendingApprovals(dir, FsyncPeriodic)
+	require.NoError(t, err)
+	for _, a := range []struct{ channelID, txID string }{{"ch1", "tx1"}, {"ch2", "tx2"}} {
+		_, err := pending.store(a.channelID, a.txID, pendingEnvelope(a.channelID, a.txID))
+		require.NoError(t, err)
+	}
+	require.NoError(t, pending.sync())
+	require.Empty(t, pending.unsynced)
+
+	// a crash leaves a partially written approval, a truncated one and one
+	// not matching its file name
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".00000000000000000005_ch1_tx5.tmp"), []byte("partial"), 0o644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "00000000000000000003_ch1_tx3"), []byte("\x0a\xff"), 0o644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "00000000000000000004_ch1_tx4"), protoutil.MarshalOrPanic(pendingEnvelope("ch1", "tx1")), 0o644))
+
+	durations, recovered, counts := &metricsfakes.Gauge{}, &metricsfakes.Gauge{}, &metricsfakes.Gauge{}
+	recovered.WithReturns(recovered)
+	counts.WithReturns(counts)
+	s := &BloccService{
+		clock:   clock.NewClock(),
+		metrics: &Metrics{PendingRecoveryDuration: durations, PendingRecovered: recovered, PendingApprovals: counts},
+	}
+	s.pending, err = newPendingApprovals(dir, FsyncWrite)
+	require.NoError(t, err)
+	s.restorePendingApprovals()
+
+	require.Equal(t, 1, durations.SetCallCount())
+	require.Equal(t, []string{"status", "recovered"}, recovered.WithArgsForCall(0))
+	require.Equal(t, float64(2), recovered.SetArgsForCall(0))
+	require.Equal(t, []string{"status", "quarantined"}, recovered.WithArgsForCall(1))
+	require.Equal(t, float64(2), recovered.SetArgsForCall(1))
+	require.Equal(t, 2, counts.SetCallCount())
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	require.Equal(t, []string{"00000000000000000001_ch1_tx1", "00000000000000000002_ch2_tx2", "corrupt"}, names)
+	files, err = ioutil.ReadDir(filepath.Join(dir, "corrupt"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	// the sequence continues from the last stored approval
+	_, err = s.pending.store("ch1", "tx6", pendingEnvelope("ch1", "tx6"))
+	require.NoError(t, err)
+	approvals, err := s.pending.list()
+	require.NoError(t, err)
+	require.Equal(t, pendingApproval{sequence: 5, channelID: "ch1", txID: "tx6"}, approvals[2])
+}
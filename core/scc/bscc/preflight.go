@@ -0,0 +1,168 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/proxy"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sockopt"
+	"github.com/pkg/errors"
+)
+
+// preflightDialTimeout bounds the connection to the orderer of a channel
+// during the preflight checks.
+const preflightDialTimeout = 5 * time.Second
+
+// preflight runs the checks of the configuration the approvals depend on
+// when the service starts, so that a misconfiguration is reported at boot
+// rather than on the first sensory reading.
+type preflight struct {
+	service *BloccService
+	dial    func(ctx context.Context, address string) (net.Conn, error)
+	signer  func() (blocc.Signer, error)
+	stat    func(path string) error
+}
+
+func newPreflight(s *BloccService) *preflight {
+	return &preflight{
+		service: s,
+		dial: func(ctx context.Context, address string) (net.Conn, error) {
+			options := s.currentOptions()
+			netDialer, err := sockopt.Options{DSCP: options.ApprovalGRPC.DSCP, TCPKeepAlive: options.ApprovalGRPC.TCPKeepAlive}.Dialer()
+			if err != nil {
+				return nil, err
+			}
+			proxyConfig := proxy.Config{URL: options.ApprovalProxyURL, Endpoints: options.ApprovalProxyEndpoints}
+			proxyURL, err := proxyConfig.Resolve(address)
+			if err != nil {
+				return nil, err
+			}
+			if proxyURL != nil {
+				return proxy.DialerVia(netDialer, proxyURL)(ctx, address)
+			}
+			return netDialer.DialContext(ctx, "tcp", address)
+		},
+		signer: func() (blocc.Signer, error) {
+			return blocc.ApprovalSigner(s.config.CryptoProvider)
+		},
+		stat: func(path string) error {
+			_, err := os.Stat(path)
+			return err
+		},
+	}
+}
+
+// run returns the failed checks: the orderer and the fork status of every
+// approval channel, and the approval identity.
+func (p *preflight) run() []string {
+	var (
+		mutex    sync.Mutex
+		failures []string
+		wg       sync.WaitGroup
+	)
+	fail := func(format string, args ...interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		failures = append(failures, fmt.Sprintf(format, args...))
+	}
+
+	for _, channelID := range p.channels() {
+		wg.Add(1)
+		go func(channelID string) {
+			defer wg.Done()
+			if err := p.checkOrderer(channelID); err != nil {
+				fail("orderer of channel %s: %s", channelID, err)
+			}
+			if err := p.checkForkStatus(channelID); err != nil {
+				fail("fork status of channel %s: %s", channelID, err)
+			}
+		}(channelID)
+	}
+	if err := p.checkSigner(); err != nil {
+		fail("approval identity: %s", err)
+	}
+	wg.Wait()
+
+	sort.Strings(failures)
+	return failures
+}
+
+// channels returns the joined channels this peer approves readings on.
+func (p *preflight) channels() []string {
+	var channelIDs []string
+	for _, info := range p.service.peerInfo.GetChannelsInfo() {
+		if p.service.currentOptions().ApprovesChannel(info.ChannelId) {
+			channelIDs = append(channelIDs, info.ChannelId)
+		}
+	}
+	return channelIDs
+}
+
+// checkOrderer connects to the orderer the approvals of the channel are
+// submitted to.
+func (p *preflight) checkOrderer(channelID string) error {
+	address, _, err := p.service.gatherOrdererInfo(channelID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), preflightDialTimeout)
+	defer cancel()
+	conn, err := p.dial(ctx, address)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to connect to %s", address)
+	}
+	return conn.Close()
+}
+
+// checkSigner signs a test message with the approval identity.
+func (p *preflight) checkSigner() error {
+	signer, err := p.signer()
+	if err != nil {
+		return err
+	}
+	if _, err := signer.Serialize(); err != nil {
+		return errors.WithMessage(err, "failed to serialize")
+	}
+	if _, err := signer.Sign([]byte("BLOCC preflight")); err != nil {
+		return errors.WithMessage(err, "failed to sign")
+	}
+	return nil
+}
+
+// checkForkStatus checks that the fork status of the channel can be read.
+func (p *preflight) checkForkStatus(channelID string) error {
+	if err := p.stat(forkInfoPath(channelID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// runPreflight runs the preflight checks, logging the failed ones, and
+// publishes a ServiceReady event.
+func (s *BloccService) runPreflight(p *preflight) {
+	failures := p.run()
+	for _, failure := range failures {
+		bloccProtoLogger.Errorf("BLOCC preflight check failed: %s", failure)
+	}
+	if len(failures) == 0 {
+		bloccProtoLogger.Info("BLOCC preflight checks passed")
+	}
+
+	s.eventBus.Publish(event.Event{
+		Type:              event.ServiceReady,
+		PreflightFailures: failures,
+	})
+}
@@ -0,0 +1,85 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/peer/orderers"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type preflightSigner struct {
+	err error
+}
+
+func (s *preflightSigner) Sign(msg []byte) ([]byte, error) { return msg, s.err }
+func (s *preflightSigner) Serialize() ([]byte, error)      { return []byte("signer"), nil }
+
+func TestPreflight(t *testing.T) {
+	peerInfo := &mock.PeerInfoProvider{}
+	peerInfo.GetChannelsInfoReturns([]*pb.ChannelInfo{{ChannelId: "ch1"}, {ChannelId: "ch2"}, {ChannelId: "ch3"}})
+	peerInfo.GetOrdererInfoStub = func(channelID string) ([]string, map[string]orderers.OrdererOrg, error) {
+		return nil, map[string]orderers.OrdererOrg{
+			"OrdererOrg": {Addresses: []string{channelID + ".orderer:7050"}, RootCerts: [][]byte{nil}},
+		}, nil
+	}
+	bscc := newTestBSCC(peerInfo)
+	bscc.options = config.Options{ApprovalChannels: []string{"ch1", "ch2"}}
+
+	server, client := net.Pipe()
+	defer server.Close()
+	signer := &preflightSigner{}
+	p := newPreflight(bscc.BloccService)
+	p.dial = func(ctx context.Context, address string) (net.Conn, error) {
+		if address == "ch2.orderer:7050" {
+			return nil, errors.New("connection refused")
+		}
+		return client, nil
+	}
+	p.signer = func() (blocc.Signer, error) { return signer, nil }
+	p.stat = func(path string) error {
+		if path == forkInfoPath("ch1") {
+			return os.ErrPermission
+		}
+		return os.ErrNotExist
+	}
+
+	require.Equal(t, []string{
+		"fork status of channel ch1: permission denied",
+		"orderer of channel ch2: failed to connect to ch2.orderer:7050: connection refused",
+	}, p.run())
+	require.Equal(t, 2, peerInfo.GetOrdererInfoCallCount())
+
+	signer.err = errors.New("token not present")
+	p.stat = func(string) error { return nil }
+	p.dial = func(context.Context, string) (net.Conn, error) {
+		_, client := net.Pipe()
+		return client, nil
+	}
+	require.Equal(t, []string{"approval identity: failed to sign: token not present"}, p.run())
+
+	events := bscc.eventBus.Subscribe("test")
+	defer bscc.eventBus.Unsubscribe(events)
+	bscc.runPreflight(p)
+	select {
+	case e := <-events:
+		require.Equal(t, event.ServiceReady, e.Type)
+		require.Equal(t, []string{"approval identity: failed to sign: token not present"}, e.PreflightFailures)
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServiceReady event not published")
+	}
+}
@@ -0,0 +1,339 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// inFlightPollInterval is the interval at which a worker held back by the
+// in-flight limit of its channel checks whether approvals were committed.
+const inFlightPollInterval = 500 * time.Millisecond
+
+// channelQueues hold the pending approval requests of a channel. Requests on
+// the priority queue are always served before bulk requests.
+type channelQueues struct {
+	channelID string
+	priority  chan event.Delivery
+	bulk      chan event.Delivery
+	// busy is the number of workers of the channel processing a request
+	busy int32
+	// lastError holds the error of the last failed approval of the channel
+	lastError atomic.Value
+}
+
+func (c *channelQueues) length() int {
+	return len(c.priority) + len(c.bulk)
+}
+
+// approvalQueues hold the pending approval requests by channel, the requests
+// of each channel being served by workers of their own so that a busy
+// channel does not starve the others.
+type approvalQueues struct {
+	channels map[string]*channelQueues
+	// queued holds the sequence numbers of the queued requests, so that a
+	// request redelivered while still queued is not queued twice
+	queued map[uint64]bool
+	// closed is closed once the workers are to stop
+	closed  chan struct{}
+	workers sync.WaitGroup
+	mu      sync.Mutex
+}
+
+func newApprovalQueues() *approvalQueues {
+	return &approvalQueues{
+		channels: map[string]*channelQueues{},
+		queued:   map[uint64]bool{},
+		closed:   make(chan struct{}),
+	}
+}
+
+// add marks the request as queued, returning false if it already is.
+func (q *approvalQueues) add(seq uint64) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.queued[seq] {
+		return false
+	}
+	q.queued[seq] = true
+	return true
+}
+
+func (q *approvalQueues) remove(seq uint64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.queued, seq)
+}
+
+// close stops the workers and waits for the requests they are processing, if
+// any, to complete.
+func (q *approvalQueues) close() {
+	q.mu.Lock()
+	select {
+	case <-q.closed:
+	default:
+		close(q.closed)
+	}
+	q.mu.Unlock()
+
+	q.workers.Wait()
+}
+
+// channelQueues returns the queues of the channel, or nil once the queues are
+// closed. The queues of a channel are created on its first request, sized
+// and served by workers according to the approval limits of the channel then.
+// They are not resized when the configuration is reloaded.
+func (s *BloccService) channelQueues(queues *approvalQueues, channelID string) *channelQueues {
+	queues.mu.Lock()
+	defer queues.mu.Unlock()
+
+	select {
+	case <-queues.closed:
+		return nil
+	default:
+	}
+	if cq, ok := queues.channels[channelID]; ok {
+		return cq
+	}
+
+	limits := s.currentOptions().ChannelApprovalLimits(channelID)
+	if limits.QueueLength < 1 {
+		limits.QueueLength = 1
+	}
+	if limits.Parallelism < 1 {
+		limits.Parallelism = 1
+	}
+	cq := &channelQueues{
+		channelID: channelID,
+		priority:  make(chan event.Delivery, limits.QueueLength),
+		bulk:      make(chan event.Delivery, limits.QueueLength),
+	}
+	queues.channels[channelID] = cq
+
+	for i := 0; i < limits.Parallelism; i++ {
+		queues.workers.Add(1)
+		go func() {
+			defer queues.workers.Done()
+			s.serveChannelApprovals(queues, cq)
+		}()
+	}
+	bloccProtoLogger.Infof("Serving the approvals of channel %s with %d workers, queueing up to %d requests", channelID, limits.Parallelism, limits.QueueLength)
+
+	return cq
+}
+
+// queueLimitsChanged returns whether the queue length or the parallelism of
+// the approvals of a channel differ between previous and options, which
+// applies to the channels already served from the next start only.
+func queueLimitsChanged(previous, options config.Options) bool {
+	// the empty channel ID stands for the channels without limits of their own
+	channelIDs := map[string]bool{"": true}
+	for channelID := range previous.ApprovalChannelLimits {
+		channelIDs[channelID] = true
+	}
+	for channelID := range options.ApprovalChannelLimits {
+		channelIDs[channelID] = true
+	}
+
+	for channelID := range channelIDs {
+		p, o := previous.ChannelApprovalLimits(channelID), options.ChannelApprovalLimits(channelID)
+		if p.QueueLength != o.QueueLength || p.Parallelism != o.Parallelism {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue routes the approval request to the queue of its channel matching
+// the severity of the reading, recording the activity of its sensor on the
+// way. Requests overflowing the queue are left to be redelivered.
+func (s *BloccService) enqueue(queues *approvalQueues, d event.Delivery) {
+	select {
+	case <-s.drain.requested:
+		// left unacknowledged to be saved by the drain
+		return
+	default:
+	}
+
+	cq := s.channelQueues(queues, d.ChannelID)
+	if cq == nil {
+		return
+	}
+	if !queues.add(d.Seq) {
+		bloccProtoLogger.Debugf("Approval request for reading %s is already queued", d.SensoryTxID)
+		return
+	}
+
+	envelope := s.readingEnvelope(d.ChannelID, d.SensoryTxID)
+	// resubmitted approvals were observed on their first submission
+	if d.Attempt == 1 && !s.approvals.tracked(d.TraceID) {
+		s.observeSensor(d.ChannelID, d.SensoryTxID, envelope)
+	}
+
+	queue := cq.bulk
+	if s.currentOptions().IsPriority(readingSeverity(d.SensoryTxID, envelope)) {
+		queue = cq.priority
+	}
+
+	select {
+	case queue <- d:
+		s.metrics.ApprovalQueueLength.With("channel", d.ChannelID).Set(float64(cq.length()))
+	default:
+		queues.remove(d.Seq)
+		s.metrics.ApprovalQueueOverflows.With("channel", d.ChannelID).Add(1)
+		bloccProtoLogger.Debugf("Approval queue of channel %s is full, the request for reading %s will be redelivered", d.ChannelID, d.SensoryTxID)
+	}
+}
+
+// serveApprovals lets the workers serve the queued approval requests until
+// approvals are drained or stop is closed, and saves the pending requests
+// once the requests being processed completed.
+func (s *BloccService) serveApprovals(queues *approvalQueues, subscription *event.Subscription, stop <-chan struct{}) {
+	select {
+	case <-s.drain.requested:
+		queues.close()
+		s.completeDrain(subscription)
+	case <-stop:
+		queues.close()
+		s.savePendingApprovals(subscription)
+	}
+}
+
+// serveChannelApprovals processes the queued approval requests of a channel,
+// preferring priority requests whenever both queues hold requests, until the
+// queues are closed.
+func (s *BloccService) serveChannelApprovals(queues *approvalQueues, cq *channelQueues) {
+	for {
+		select {
+		case <-queues.closed:
+			return
+		default:
+		}
+
+		select {
+		case d := <-cq.priority:
+			s.serveApproval(queues, cq, d)
+			continue
+		default:
+		}
+
+		select {
+		case <-queues.closed:
+			return
+		case d := <-cq.priority:
+			s.serveApproval(queues, cq, d)
+		case d := <-cq.bulk:
+			s.serveApproval(queues, cq, d)
+		}
+	}
+}
+
+// awaitInFlight holds the approval request back while the approvals of its
+// channel awaiting their commit reach the in-flight limit of the channel,
+// returning false if the queues are closed meanwhile. Resubmitted approvals
+// are counted already and are not held back.
+func (s *BloccService) awaitInFlight(queues *approvalQueues, d event.Delivery) bool {
+	for {
+		maxInFlight := s.currentOptions().ChannelApprovalLimits(d.ChannelID).MaxInFlight
+		if maxInFlight <= 0 || s.approvals.tracked(d.TraceID) || s.approvals.inFlight(d.ChannelID) < maxInFlight {
+			return true
+		}
+		if !s.sleep(queues.closed, inFlightPollInterval) {
+			return false
+		}
+	}
+}
+
+// reportInFlight updates the number of approvals of the channel awaiting
+// their commit.
+func (s *BloccService) reportInFlight(channelID string) {
+	s.metrics.ApprovalsInFlight.With("channel", channelID).Set(float64(s.approvals.inFlight(channelID)))
+}
+
+// serveApproval processes the approval request, acknowledging it unless it
+// failed with a retryable error and may still be redelivered. Submitted
+// approvals are tracked until committed, to be resubmitted if invalidated.
+func (s *BloccService) serveApproval(queues *approvalQueues, cq *channelQueues, d event.Delivery) {
+	defer queues.remove(d.Seq)
+	s.metrics.ApprovalQueueLength.With("channel", cq.channelID).Set(float64(cq.length()))
+
+	if !s.awaitInFlight(queues, d) {
+		// left unacknowledged to be saved by the drain or on stop
+		return
+	}
+
+	s.metrics.ApprovalWorkersBusy.With("channel", cq.channelID).Set(float64(atomic.AddInt32(&cq.busy, 1)))
+	defer func() {
+		s.metrics.ApprovalWorkersBusy.With("channel", cq.channelID).Set(float64(atomic.AddInt32(&cq.busy, -1)))
+	}()
+
+	if err := s.processEvent(d.Event); err != nil {
+		cq.lastError.Store(err.Error())
+		if status, ok := broadcastStatus(err); ok {
+			s.metrics.ApprovalBroadcastFailures.With("channel", d.ChannelID, "status", status.String()).Add(1)
+		}
+		if !retryable(err) {
+			bloccProtoLogger.Errorf("Giving up approval of reading %s, trace %s, rejected by the orderer: %s", d.SensoryTxID, d.TraceID, err)
+			s.recordFailure(queues, d, err)
+			d.Ack()
+			return
+		}
+
+		maxDeliveries := s.currentOptions().ApprovalMaxDeliveries
+		if d.Attempt < maxDeliveries {
+			bloccProtoLogger.Warningf("Approval of reading %s, trace %s, failed on attempt %d of %d, it will be retried", d.SensoryTxID, d.TraceID, d.Attempt, maxDeliveries)
+			return
+		}
+		bloccProtoLogger.Errorf("Giving up approval of reading %s, trace %s, after %d attempts", d.SensoryTxID, d.TraceID, d.Attempt)
+		s.recordFailure(queues, d, err)
+	} else {
+		s.approvals.track(d.Event)
+		s.reportInFlight(d.ChannelID)
+	}
+	d.Ack()
+}
+
+// readingEnvelope returns the envelope of the committed sensory transaction,
+// or nil if it cannot be retrieved.
+func (s *BloccService) readingEnvelope(channelID, sensoryTxID string) *cb.Envelope {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return nil
+	}
+
+	processedTx, err := ledger.GetTransactionByID(sensoryTxID)
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to get reading %s: %s", sensoryTxID, err)
+		return nil
+	}
+
+	return processedTx.GetTransactionEnvelope()
+}
+
+// readingSeverity returns the severity of the sensory transaction, or an
+// empty severity if it cannot be determined.
+func readingSeverity(sensoryTxID string, envelope *cb.Envelope) string {
+	if envelope == nil {
+		return ""
+	}
+
+	severity, err := protoutil.ExtractSeverityFromEnvelope(envelope)
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to extract severity of reading %s: %s", sensoryTxID, err)
+		return ""
+	}
+
+	return severity
+}
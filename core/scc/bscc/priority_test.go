@@ -0,0 +1,125 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnqueueOverflow(t *testing.T) {
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+	overflows := &metricsfakes.Counter{}
+	overflows.WithReturns(overflows)
+	bscc.metrics.ApprovalQueueOverflows = overflows
+
+	// the queues of the channel are not served
+	queues := newApprovalQueues()
+	cq := &channelQueues{
+		channelID: "mychannel",
+		priority:  make(chan event.Delivery, 1),
+		bulk:      make(chan event.Delivery, 1),
+	}
+	queues.channels["mychannel"] = cq
+
+	delivery := func(seq uint64, txID string) event.Delivery {
+		return event.Delivery{Event: event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: txID}, Seq: seq, Attempt: 1}
+	}
+	bscc.enqueue(queues, delivery(1, "tx1"))
+	bscc.enqueue(queues, delivery(1, "tx1"))
+	require.Equal(t, 1, cq.length())
+	require.Equal(t, 0, overflows.AddCallCount())
+
+	// the overflowing request is left to be redelivered
+	bscc.enqueue(queues, delivery(2, "tx2"))
+	require.Equal(t, 1, cq.length())
+	require.Equal(t, 1, overflows.AddCallCount())
+	require.Equal(t, []string{"channel", "mychannel"}, overflows.WithArgsForCall(0))
+	require.False(t, queues.queued[2])
+
+	// no request is queued once the queues are closed
+	queues.close()
+	require.Nil(t, bscc.channelQueues(queues, "otherchannel"))
+}
+
+func TestChannelQueuesLimits(t *testing.T) {
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+	bscc.options = config.Options{
+		ApprovalLimits:        config.ChannelLimits{QueueLength: 4, Parallelism: 1},
+		ApprovalChannelLimits: map[string]config.ChannelLimits{"bigchannel": {QueueLength: 16}},
+	}
+
+	queues := newApprovalQueues()
+	defer queues.close()
+	cq := bscc.channelQueues(queues, "bigchannel")
+	require.Equal(t, 16, cap(cq.priority))
+	require.Equal(t, 16, cap(cq.bulk))
+	require.Same(t, cq, bscc.channelQueues(queues, "bigchannel"))
+	require.Equal(t, 4, cap(bscc.channelQueues(queues, "mychannel").bulk))
+}
+
+func TestAwaitInFlight(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bscc := newTestBSCCWithClock(&mock.PeerInfoProvider{}, clock)
+	bscc.options = config.Options{ApprovalLimits: config.ChannelLimits{MaxInFlight: 2}}
+	for _, traceID := range []string{"trace1", "trace2"} {
+		bscc.approvals.track(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", TraceID: traceID})
+	}
+	queues := newApprovalQueues()
+	request := func(channelID, traceID string) event.Delivery {
+		return event.Delivery{Event: event.Event{Type: event.ApprovalRequest, ChannelID: channelID, TraceID: traceID}}
+	}
+
+	// other channels and resubmissions are not held back
+	require.True(t, bscc.awaitInFlight(queues, request("otherchannel", "trace3")))
+	require.True(t, bscc.awaitInFlight(queues, request("mychannel", "trace1")))
+
+	// the request is submitted once an approval committed
+	done := make(chan bool)
+	go func() { done <- bscc.awaitInFlight(queues, request("mychannel", "trace3")) }()
+	require.Eventually(t, func() bool { return clock.WatcherCount() == 1 }, time.Second, time.Millisecond)
+	bscc.approvals.forget("trace1")
+	clock.Increment(inFlightPollInterval)
+	require.True(t, <-done)
+
+	// or given up once the queues are closed
+	bscc.approvals.track(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", TraceID: "trace3"})
+	go func() { done <- bscc.awaitInFlight(queues, request("mychannel", "trace4")) }()
+	require.Eventually(t, func() bool { return clock.WatcherCount() == 1 }, time.Second, time.Millisecond)
+	queues.close()
+	require.False(t, <-done)
+}
+
+func TestQueueLimitsChanged(t *testing.T) {
+	previous := config.Options{
+		ApprovalLimits:        config.ChannelLimits{QueueLength: 1024, MaxInFlight: 256, Parallelism: 1},
+		ApprovalChannelLimits: map[string]config.ChannelLimits{"bigchannel": {Parallelism: 4}},
+	}
+	options := previous
+	require.False(t, queueLimitsChanged(previous, options))
+
+	// the in-flight limit applies to the next submissions
+	options.ApprovalLimits.MaxInFlight = 16
+	require.False(t, queueLimitsChanged(previous, options))
+
+	options.ApprovalLimits.QueueLength = 64
+	require.True(t, queueLimitsChanged(previous, options))
+
+	options = previous
+	options.ApprovalChannelLimits = map[string]config.ChannelLimits{"bigchannel": {Parallelism: 8}}
+	require.True(t, queueLimitsChanged(previous, options))
+
+	// limits overridden to their previous values
+	options.ApprovalChannelLimits = map[string]config.ChannelLimits{"bigchannel": {Parallelism: 4}, "otherchannel": {QueueLength: 1024}}
+	require.False(t, queueLimitsChanged(previous, options))
+}
@@ -0,0 +1,192 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sqlite"
+)
+
+// projectionIdleInterval is the interval between two checks of the options
+// while the projection is disabled.
+const projectionIdleInterval = time.Minute
+
+// projectedDecision is an approval or a rejection committed on a channel.
+type projectedDecision struct {
+	channelID   string
+	sensoryTxID string
+	mspID       string
+	traceID     string
+	committedAt time.Time
+}
+
+// sqlProjection holds the approvals and rejections committed on the channels
+// of the peer, projected with the statistics of the sensors into a SQLite
+// database.
+type sqlProjection struct {
+	mutex      sync.Mutex
+	approvals  []projectedDecision
+	rejections []projectedDecision
+	// version counts the decisions recorded, writtenVersion and writtenStats
+	// telling what the database holds
+	version        uint64
+	written        bool
+	writtenVersion uint64
+	writtenStats   []SensorStats
+}
+
+func newSQLProjection() *sqlProjection {
+	return &sqlProjection{}
+}
+
+// record records the decision committed at the time at if e is an
+// ApprovalCommitted or RejectionCommitted event, keeping about the last
+// maxRows decisions of each kind.
+func (p *sqlProjection) record(e event.Event, at time.Time, maxRows int) {
+	decision := projectedDecision{
+		channelID:   e.ChannelID,
+		sensoryTxID: e.SensoryTxID,
+		mspID:       e.MSPID,
+		traceID:     e.TraceID,
+		committedAt: at,
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	switch e.Type {
+	case event.ApprovalCommitted:
+		p.approvals = appendDecision(p.approvals, decision, maxRows)
+	case event.RejectionCommitted:
+		p.rejections = appendDecision(p.rejections, decision, maxRows)
+	default:
+		return
+	}
+	p.version++
+}
+
+// appendDecision appends the decision, dropping the oldest decisions once
+// twice maxRows are held so that they are not copied on every decision.
+func appendDecision(decisions []projectedDecision, decision projectedDecision, maxRows int) []projectedDecision {
+	decisions = append(decisions, decision)
+	if maxRows > 0 && len(decisions) >= 2*maxRows {
+		decisions = append([]projectedDecision(nil), decisions[len(decisions)-maxRows:]...)
+	}
+	return decisions
+}
+
+// tables returns the tables of the projection updated at now, with the
+// version of the decisions they hold. changed is false if the database
+// already holds the decisions and the statistics.
+func (p *sqlProjection) tables(stats []SensorStats, now time.Time, maxRows int) (tables []sqlite.Table, version uint64, changed bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.written && p.writtenVersion == p.version && reflect.DeepEqual(p.writtenStats, stats) {
+		return nil, p.version, false
+	}
+
+	statsRows := [][]interface{}{}
+	for _, s := range stats {
+		statsRows = append(statsRows, []interface{}{s.ChannelID, s.SensorID, s.Received, s.Approved, s.Rejected, s.AverageApprovalLatency})
+	}
+	return []sqlite.Table{
+		decisionTable("approvals", p.approvals, maxRows),
+		decisionTable("rejections", p.rejections, maxRows),
+		{
+			Name:    "sensor_stats",
+			Columns: []string{"channel_id TEXT", "sensor_id TEXT", "received INTEGER", "approved INTEGER", "rejected INTEGER", "average_approval_latency REAL"},
+			Rows:    statsRows,
+		},
+		{
+			Name:    "projection",
+			Columns: []string{"updated_at TEXT"},
+			Rows:    [][]interface{}{{now.UTC().Format(time.RFC3339Nano)}},
+		},
+	}, p.version, true
+}
+
+// decisionTable returns the table of the last maxRows decisions.
+func decisionTable(name string, decisions []projectedDecision, maxRows int) sqlite.Table {
+	if maxRows > 0 && len(decisions) > maxRows {
+		decisions = decisions[len(decisions)-maxRows:]
+	}
+	rows := make([][]interface{}, 0, len(decisions))
+	for _, d := range decisions {
+		rows = append(rows, []interface{}{d.channelID, d.sensoryTxID, d.mspID, d.traceID, d.committedAt.UTC().Format(time.RFC3339Nano)})
+	}
+	return sqlite.Table{
+		Name:    name,
+		Columns: []string{"channel_id TEXT", "sensory_tx_id TEXT", "msp_id TEXT", "trace_id TEXT", "committed_at TEXT"},
+		Rows:    rows,
+	}
+}
+
+// writtenTables records that the database holds the version of the
+// decisions and the statistics.
+func (p *sqlProjection) writtenTables(version uint64, stats []SensorStats) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.written = true
+	p.writtenVersion = version
+	p.writtenStats = stats
+}
+
+// projectDecisions records the approvals and rejections committed on the
+// channels of this peer while the projection is enabled.
+func (s *BloccService) projectDecisions(events <-chan event.Event) {
+	for e := range events {
+		if options := s.currentOptions(); options.ProjectionEnabled {
+			s.projection.record(e, s.clock.Now(), options.ProjectionMaxRows)
+		}
+	}
+}
+
+// projectPipeline updates the SQLite projection of the approval pipeline
+// every interval while it is enabled.
+func (s *BloccService) projectPipeline(stop <-chan struct{}) {
+	for {
+		interval := projectionIdleInterval
+		if options := s.currentOptions(); options.ProjectionEnabled && options.ProjectionInterval > 0 {
+			interval = options.ProjectionInterval
+		}
+		if !s.sleep(stop, interval) {
+			return
+		}
+
+		options := s.currentOptions()
+		if !options.ProjectionEnabled {
+			continue
+		}
+		if err := s.updateProjection(options.ProjectionPath, options.ProjectionMaxRows); err != nil {
+			bloccProtoLogger.Errorf("Failed to update the projection %s: %s", options.ProjectionPath, err)
+		}
+	}
+}
+
+// updateProjection replaces the database at path with the current
+// projection, unless it is up to date.
+func (s *BloccService) updateProjection(path string, maxRows int) error {
+	var stats []SensorStats
+	for _, channel := range s.peerInfo.GetChannelsInfo() {
+		stats = append(stats, s.sensorStats.stats(channel.ChannelId)...)
+	}
+
+	tables, version, changed := s.projection.tables(stats, s.clock.Now(), maxRows)
+	if !changed {
+		return nil
+	}
+	if err := sqlite.WriteFile(path, tables); err != nil {
+		return err
+	}
+	s.projection.writtenTables(version, stats)
+	return nil
+}
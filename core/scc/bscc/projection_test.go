@@ -0,0 +1,112 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sqlite"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLProjection(t *testing.T) {
+	p := newSQLProjection()
+	at := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+	for _, e := range []event.Event{
+		{Type: event.ApprovalCommitted, ChannelID: "sensorchannel", SensoryTxID: "tx1", MSPID: "Org1MSP", TraceID: "trace1"},
+		{Type: event.ApprovalCommitted, ChannelID: "sensorchannel", SensoryTxID: "tx2", MSPID: "Org2MSP"},
+		{Type: event.RejectionCommitted, ChannelID: "sensorchannel", SensoryTxID: "tx3", MSPID: "Org1MSP"},
+		{Type: event.ApprovalCommitted, ChannelID: "sensorchannel", SensoryTxID: "tx4", MSPID: "Org1MSP"},
+		{Type: event.ApprovalRequest, ChannelID: "sensorchannel", SensoryTxID: "tx5"},
+	} {
+		p.record(e, at, 2)
+	}
+
+	stats := []SensorStats{{ChannelID: "sensorchannel", SensorID: "sensor1", Received: 4, Approved: 3, Rejected: 1, AverageApprovalLatency: 1.5}}
+	tables, version, changed := p.tables(stats, at.Add(time.Second), 2)
+	require.True(t, changed)
+	require.Equal(t, uint64(4), version)
+	// only the last maxRows approvals are kept
+	require.Equal(t, [][]interface{}{
+		{"sensorchannel", "tx2", "Org2MSP", "", "2026-10-15T09:30:00Z"},
+		{"sensorchannel", "tx4", "Org1MSP", "", "2026-10-15T09:30:00Z"},
+	}, tables[0].Rows)
+	require.Equal(t, [][]interface{}{{"sensorchannel", "tx3", "Org1MSP", "", "2026-10-15T09:30:00Z"}}, tables[1].Rows)
+	require.Equal(t, [][]interface{}{{"sensorchannel", "sensor1", uint64(4), uint64(3), uint64(1), 1.5}}, tables[2].Rows)
+	require.Equal(t, [][]interface{}{{"2026-10-15T09:30:01Z"}}, tables[3].Rows)
+
+	// nothing is written again until a decision is recorded or the
+	// statistics change
+	p.writtenTables(version, stats)
+	_, _, changed = p.tables(stats, at.Add(time.Minute), 2)
+	require.False(t, changed)
+	stats = []SensorStats{stats[0]}
+	stats[0].Received++
+	_, _, changed = p.tables(stats, at.Add(time.Minute), 2)
+	require.True(t, changed)
+	p.writtenTables(version, stats)
+	p.record(event.Event{Type: event.RejectionCommitted, ChannelID: "sensorchannel", SensoryTxID: "tx6"}, at, 2)
+	_, _, changed = p.tables(stats, at.Add(time.Minute), 2)
+	require.True(t, changed)
+}
+
+func TestAppendDecision(t *testing.T) {
+	var decisions []projectedDecision
+	for i := 0; i < 7; i++ {
+		decisions = appendDecision(decisions, projectedDecision{sensoryTxID: string(rune('a' + i))}, 3)
+	}
+	// the oldest decisions are dropped once twice maxRows are held
+	require.Len(t, decisions, 4)
+	require.Equal(t, "d", decisions[0].sensoryTxID)
+	require.Len(t, decisionTable("approvals", decisions, 3).Rows, 3)
+	require.Len(t, decisionTable("approvals", decisions, 0).Rows, 4)
+}
+
+func TestUpdateProjection(t *testing.T) {
+	dir, err := ioutil.TempDir("", "projection")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "blocc", "projection.db")
+
+	peerInfo := &mock.PeerInfoProvider{}
+	peerInfo.GetChannelsInfoReturns([]*pb.ChannelInfo{{ChannelId: "sensorchannel"}})
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bscc := newTestBSCCWithClock(peerInfo, clock)
+	bscc.sensorStats.received("sensorchannel", "sensor1", "tx1")
+	clock.Increment(2 * time.Second)
+	bscc.sensorStats.decided("tx1", true)
+	bscc.projection.record(event.Event{Type: event.ApprovalCommitted, ChannelID: "sensorchannel", SensoryTxID: "tx1", MSPID: "Org1MSP"}, clock.Now(), 10)
+
+	require.NoError(t, bscc.updateProjection(path, 10))
+	db, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	expected, err := sqlite.Encode([]sqlite.Table{
+		decisionTable("approvals", []projectedDecision{{channelID: "sensorchannel", sensoryTxID: "tx1", mspID: "Org1MSP", committedAt: clock.Now()}}, 10),
+		decisionTable("rejections", nil, 10),
+		{
+			Name:    "sensor_stats",
+			Columns: []string{"channel_id TEXT", "sensor_id TEXT", "received INTEGER", "approved INTEGER", "rejected INTEGER", "average_approval_latency REAL"},
+			Rows:    [][]interface{}{{"sensorchannel", "sensor1", uint64(1), uint64(1), uint64(0), 2.0}},
+		},
+		{Name: "projection", Columns: []string{"updated_at TEXT"}, Rows: [][]interface{}{{"2023-11-14T22:13:22Z"}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, db)
+
+	// the database is left as is while the projection is unchanged
+	require.NoError(t, os.Remove(path))
+	clock.Increment(time.Minute)
+	require.NoError(t, bscc.updateProjection(path, 10))
+	require.NoFileExists(t, path)
+}
@@ -0,0 +1,98 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// blockSource is the subset of the ledger needed to build reading proofs.
+type blockSource interface {
+	GetBlockchainInfo() (*cb.BlockchainInfo, error)
+	GetBlockByNumber(blockNumber uint64) (*cb.Block, error)
+	GetBlockByTxID(txID string) (*cb.Block, error)
+}
+
+// GetReadingProof returns the JSON encoded proof that the reading
+// transaction txID is committed on the channel, chained up to the block
+// trustedBlock whose header the verifier trusts, or to the current tip of the
+// channel if trustedBlock is empty.
+func (bscc *BSCC) GetReadingProof(channelID, txID, trustedBlock string) pb.Response {
+	if channelID == "" {
+		return shim.Error("ChannelID not specified")
+	}
+	if txID == "" {
+		return shim.Error("TxID not specified")
+	}
+	var trusted *uint64
+	if trustedBlock != "" {
+		blockNum, err := strconv.ParseUint(trustedBlock, 10, 64)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Invalid trusted block number '%s'", trustedBlock))
+		}
+		trusted = &blockNum
+	}
+
+	ledger := bscc.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return shim.Error(fmt.Sprintf("channel %s not found", channelID))
+	}
+
+	proof, err := readingProof(ledger, txID, trusted)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	proofBytes, err := json.Marshal(proof)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal reading proof: %s", err))
+	}
+
+	return shim.Success(proofBytes)
+}
+
+// readingProof returns the proof of the transaction txID chained up to the
+// block trusted, or to the tip of the channel if trusted is nil.
+func readingProof(source blockSource, txID string, trusted *uint64) (*protoutil.ReadingProof, error) {
+	block, err := source.GetBlockByTxID(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get block of transaction %s", txID)
+	}
+
+	info, err := source.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get blockchain info")
+	}
+	last := info.Height - 1
+	if trusted != nil {
+		if *trusted > last {
+			return nil, errors.Errorf("trusted block %d is beyond the tip %d of the channel", *trusted, last)
+		}
+		if *trusted < block.Header.Number {
+			return nil, errors.Errorf("transaction %s is committed in block %d, after the trusted block %d", txID, block.Header.Number, *trusted)
+		}
+		last = *trusted
+	}
+
+	headers := []*cb.BlockHeader{block.Header}
+	for blockNum := block.Header.Number + 1; blockNum <= last; blockNum++ {
+		next, err := source.GetBlockByNumber(blockNum)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get block %d", blockNum)
+		}
+		headers = append(headers, next.Header)
+	}
+
+	return protoutil.NewReadingProof(block, txID, headers)
+}
@@ -0,0 +1,91 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBlockSource []*cb.Block
+
+func (f fakeBlockSource) GetBlockchainInfo() (*cb.BlockchainInfo, error) {
+	return &cb.BlockchainInfo{Height: uint64(len(f))}, nil
+}
+
+func (f fakeBlockSource) GetBlockByNumber(blockNumber uint64) (*cb.Block, error) {
+	return f[blockNumber], nil
+}
+
+func (f fakeBlockSource) GetBlockByTxID(txID string) (*cb.Block, error) {
+	for _, block := range f {
+		for _, envBytes := range block.Data.Data {
+			if id, _ := protoutil.GetOrComputeTxIDFromEnvelope(envBytes); id == txID {
+				return block, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("no such transaction ID [%s] in index", txID)
+}
+
+func newFakeBlockSource(txIDs ...string) fakeBlockSource {
+	var blocks fakeBlockSource
+	var previousHash []byte
+	for i, txID := range txIDs {
+		block := protoutil.NewBlock(uint64(i), previousHash)
+		payload := &cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{TxId: txID}),
+			},
+		}
+		block.Data.Data = [][]byte{protoutil.MarshalOrPanic(&cb.Envelope{Payload: protoutil.MarshalOrPanic(payload)})}
+		block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+		block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(pb.TxValidationCode_VALID)}
+
+		blocks = append(blocks, block)
+		previousHash = protoutil.BlockHeaderHash(block.Header)
+	}
+	return blocks
+}
+
+func TestReadingProof(t *testing.T) {
+	source := newFakeBlockSource("tx0", "tx1", "tx2", "tx3")
+
+	proof, err := readingProof(source, "tx1", nil)
+	require.NoError(t, err)
+	require.Len(t, proof.Headers, 3)
+	require.Equal(t, uint64(1), proof.Headers[0].Number)
+	require.NoError(t, protoutil.VerifyReadingProof(proof, source[3].Header))
+
+	proof, err = readingProof(source, "tx3", nil)
+	require.NoError(t, err)
+	require.Len(t, proof.Headers, 1)
+	require.NoError(t, protoutil.VerifyReadingProof(proof, source[3].Header))
+
+	// the proof is chained up to the block trusted by the verifier
+	trusted := uint64(2)
+	proof, err = readingProof(source, "tx1", &trusted)
+	require.NoError(t, err)
+	require.Len(t, proof.Headers, 2)
+	require.NoError(t, protoutil.VerifyReadingProof(proof, source[2].Header))
+	trusted = 1
+	proof, err = readingProof(source, "tx1", &trusted)
+	require.NoError(t, err)
+	require.Len(t, proof.Headers, 1)
+	_, err = readingProof(source, "tx2", &trusted)
+	require.EqualError(t, err, "transaction tx2 is committed in block 2, after the trusted block 1")
+	trusted = 4
+	_, err = readingProof(source, "tx1", &trusted)
+	require.EqualError(t, err, "trusted block 4 is beyond the tip 3 of the channel")
+
+	_, err = readingProof(source, "tx4", nil)
+	require.EqualError(t, err, "failed to get block of transaction tx4: no such transaction ID [tx4] in index")
+}
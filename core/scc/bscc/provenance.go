@@ -0,0 +1,255 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/prov"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// ProvenanceNamespace is the namespace of the blocc prefix of the qualified
+// names of the provenance documents.
+const ProvenanceNamespace = "urn:blocc:"
+
+// GetReadingProvenance returns the chain of custody of the reading
+// transaction in args[0] as a W3C PROV-JSON document, for provenance
+// tooling: the reading is generated by its sensor, submitted by the gateway
+// of the sensor on behalf of its organization, endorsed by the endorsing
+// organizations, approved by the approving ones and committed in a block of
+// the channel. The private approvals of other organizations than the
+// caller's are disclosed redacted, as by QueryApprovals.
+func (bscc *BSCC) GetReadingProvenance(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 1 || len(args[0]) == 0 {
+		return shim.Error("TxID not specified")
+	}
+
+	channelID := stub.GetChannelID()
+	ledger := bscc.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return shim.Error(fmt.Sprintf("channel %s not found", channelID))
+	}
+
+	document, err := readingProvenance(ledger, stub, string(args[0]))
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	documentBytes, err := json.Marshal(document)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal reading provenance: %s", err))
+	}
+
+	return shim.Success(documentBytes)
+}
+
+func readingProvenance(source receiptSource, stub shim.ChaincodeStubInterface, txID string) (*prov.Document, error) {
+	reading, err := decodeReading(source, txID)
+	if err != nil {
+		return nil, err
+	}
+	processedTx, err := source.GetTransactionByID(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get transaction %s", txID)
+	}
+	envelope := processedTx.GetTransactionEnvelope()
+	block, err := source.GetBlockByTxID(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get block of transaction %s", txID)
+	}
+
+	channelID := stub.GetChannelID()
+	d := prov.NewDocument()
+	d.Prefix("blocc", ProvenanceNamespace)
+
+	readingID := "blocc:reading/" + txID
+	attributes := prov.Attributes{
+		"prov:type":            prov.QualifiedName("blocc:Reading"),
+		"blocc:channelID":      channelID,
+		"blocc:txID":           txID,
+		"blocc:validationCode": reading.ValidationCode,
+	}
+	if reading.Severity != "" {
+		attributes["blocc:severity"] = reading.Severity
+	}
+	for name, value := range reading.Metrics {
+		attributes["blocc:"+name] = value
+	}
+	d.Entity(readingID, attributes)
+
+	// the sensor generates the reading
+	sensingID := "blocc:sensing/" + txID
+	sensing := prov.Attributes{}
+	if reading.Timestamp != 0 {
+		sensing["prov:endTime"] = prov.Time(time.Unix(reading.Timestamp, 0))
+	}
+	d.Activity(sensingID, sensing)
+	d.WasGeneratedBy(readingID, sensingID, nil)
+	if reading.SensorID != "" {
+		sensorAgent := "blocc:sensor/" + reading.SensorID
+		d.Agent(sensorAgent, prov.Attributes{"prov:type": prov.QualifiedName("blocc:Sensor"), "blocc:sensorID": reading.SensorID})
+		d.WasAssociatedWith(sensingID, sensorAgent, "blocc:sensor", nil)
+		d.WasAttributedTo(readingID, sensorAgent, nil)
+	}
+	if _, coSigner, _, err := protoutil.ExtractCoSignatureFromEnvelope(envelope); err == nil && coSigner != nil {
+		if id, err := sensorID(coSigner); err == nil {
+			coSignerAgent := "blocc:sensor/" + id
+			d.Agent(coSignerAgent, prov.Attributes{"prov:type": prov.QualifiedName("blocc:Sensor"), "blocc:sensorID": id})
+			d.WasAssociatedWith(sensingID, coSignerAgent, "blocc:coSigner", nil)
+		}
+	}
+
+	// the gateway of the sensor submits it with the credentials of the
+	// sensor, on behalf of its organization
+	submissionID := "blocc:submission/" + txID
+	submission := prov.Attributes{}
+	if channelHeader, err := protoutil.ChannelHeader(envelope); err == nil && channelHeader.GetTimestamp() != nil {
+		submission["prov:startTime"] = prov.Time(channelHeader.GetTimestamp().AsTime())
+	}
+	d.Activity(submissionID, submission)
+	d.Used(submissionID, readingID, nil)
+	d.WasInformedBy(submissionID, sensingID, nil)
+	if creator, err := protoutil.ExtractCreatorFromEnvelope(envelope); err == nil {
+		if mspID := identityMSPID(creator); mspID != "" && reading.SensorID != "" {
+			gatewayAgent := "blocc:gateway/" + reading.SensorID
+			d.Agent(gatewayAgent, prov.Attributes{"prov:type": prov.QualifiedName("blocc:Gateway"), "blocc:sensorID": reading.SensorID})
+			d.WasAssociatedWith(submissionID, gatewayAgent, "blocc:submitter", nil)
+			d.Agent(organizationAgent(mspID), organizationAttributes(mspID))
+			d.ActedOnBehalfOf(gatewayAgent, organizationAgent(mspID), nil)
+		}
+	}
+
+	// the endorsing organizations endorse it
+	endorsementID := "blocc:endorsement/" + txID
+	d.Activity(endorsementID, nil)
+	d.Used(endorsementID, readingID, nil)
+	d.WasInformedBy(endorsementID, submissionID, nil)
+	endorsers, err := protoutil.ExtractEndorsersFromEnvelope(envelope)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to extract endorsers of transaction %s", txID)
+	}
+	endorsingMSPIDs := map[string]bool{}
+	for _, endorser := range endorsers {
+		if mspID := identityMSPID(endorser); mspID != "" {
+			endorsingMSPIDs[mspID] = true
+		}
+	}
+	for _, mspID := range sortedKeys(endorsingMSPIDs) {
+		d.Agent(organizationAgent(mspID), organizationAttributes(mspID))
+		d.WasAssociatedWith(endorsementID, organizationAgent(mspID), "blocc:endorser", nil)
+	}
+
+	// the approving organizations approve it
+	records, err := approvalRecords(stub, txID)
+	if err != nil {
+		return nil, err
+	}
+	for i, record := range records {
+		approvalID := fmt.Sprintf("blocc:approval/%s/%d", txID, i)
+		if record.ApprovalTxID != "" {
+			approvalID = "blocc:approval/" + record.ApprovalTxID
+		}
+		approval := prov.Attributes{"prov:type": prov.QualifiedName("blocc:Approval")}
+		if record.Timestamp != 0 {
+			approval["prov:startTime"] = prov.Time(time.Unix(record.Timestamp, 0))
+		}
+		if record.ClockSkewExceeded {
+			approval["blocc:clockSkewExceeded"] = true
+		}
+		if record.Redacted {
+			approval["blocc:redacted"] = true
+		}
+		d.Activity(approvalID, approval)
+		d.Used(approvalID, readingID, nil)
+		if record.MSPID != "" {
+			d.Agent(organizationAgent(record.MSPID), organizationAttributes(record.MSPID))
+			d.WasAssociatedWith(approvalID, organizationAgent(record.MSPID), "blocc:approver", nil)
+		}
+	}
+
+	// the block of the channel committing it holds it
+	blockID := fmt.Sprintf("blocc:block/%s/%d", channelID, block.GetHeader().GetNumber())
+	d.Entity(blockID, prov.Attributes{
+		"prov:type":          prov.QualifiedName("prov:Collection"),
+		"blocc:channelID":    channelID,
+		"blocc:number":       block.GetHeader().GetNumber(),
+		"blocc:dataHash":     hex.EncodeToString(block.GetHeader().GetDataHash()),
+		"blocc:previousHash": hex.EncodeToString(block.GetHeader().GetPreviousHash()),
+	})
+	d.HadMember(blockID, readingID)
+
+	return d, nil
+}
+
+// approvalRecords returns the approval records of the reading, as disclosed
+// to the organization of the creator of the stub, sorted by approving
+// organization with the redacted records last.
+func approvalRecords(stub shim.ChaincodeStubInterface, txID string) ([]*ApprovalRecord, error) {
+	iterator, err := stub.GetStateByPartialCompositeKey(approvalObjectType, []string{txID})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to query approvals of reading %s", txID)
+	}
+	defer iterator.Close()
+
+	callerMSPID, _ := creatorMSPID(stub)
+	var records []*ApprovalRecord
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to query approvals of reading %s", txID)
+		}
+		record := &ApprovalRecord{}
+		if err := json.Unmarshal(kv.Value, record); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal approval record %s", kv.Key)
+		}
+		records = append(records, record.redactedFor(callerMSPID))
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Redacted != records[j].Redacted {
+			return !records[i].Redacted
+		}
+		return records[i].MSPID < records[j].MSPID
+	})
+
+	return records, nil
+}
+
+func organizationAgent(mspID string) string {
+	return "blocc:organization/" + mspID
+}
+
+func organizationAttributes(mspID string) prov.Attributes {
+	return prov.Attributes{"prov:type": prov.QualifiedName("prov:Organization"), "blocc:mspID": mspID}
+}
+
+// identityMSPID returns the MSP ID of the serialized identity, empty if it
+// cannot be unmarshaled.
+func identityMSPID(serializedIdentity []byte) string {
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, identity); err != nil {
+		return ""
+	}
+	return identity.Mspid
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
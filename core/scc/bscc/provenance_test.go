@@ -0,0 +1,148 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+// custodyTransaction returns the committed reading submitted by the sensor
+// and endorsed by the endorsers.
+func custodyTransaction(t *testing.T, args []string, creator []byte, endorsers ...[]byte) *pb.ProcessedTransaction {
+	processedTx := sensoryTransaction("sensor_chaincode", args, nil)
+	payload, err := protoutil.UnmarshalPayload(processedTx.TransactionEnvelope.Payload)
+	require.NoError(t, err)
+	tx, err := protoutil.UnmarshalTransaction(payload.Data)
+	require.NoError(t, err)
+	actionPayload, err := protoutil.UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+	require.NoError(t, err)
+
+	for _, endorser := range endorsers {
+		actionPayload.Action.Endorsements = append(actionPayload.Action.Endorsements, &pb.Endorsement{Endorser: endorser, Signature: []byte("sig")})
+	}
+	tx.Actions[0].Payload = protoutil.MarshalOrPanic(actionPayload)
+	timestamp, err := ptypes.TimestampProto(time.Unix(1628887201, 0))
+	require.NoError(t, err)
+	payload.Header = &cb.Header{
+		ChannelHeader:   protoutil.MarshalOrPanic(&cb.ChannelHeader{ChannelId: "mychannel", TxId: "tx1", Timestamp: timestamp}),
+		SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{Creator: creator}),
+	}
+	payload.Data = protoutil.MarshalOrPanic(tx)
+	processedTx.TransactionEnvelope.Payload = protoutil.MarshalOrPanic(payload)
+	return processedTx
+}
+
+func TestReadingProvenance(t *testing.T) {
+	sensor, pairedSensor := sensorIdentity(t, "sensor1"), sensorIdentity(t, "sensor2")
+	peer1 := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: []byte("peer0.org1")})
+	peer2 := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org2MSP", IdBytes: []byte("peer0.org2")})
+	block := protoutil.NewBlock(7, []byte("previous"))
+	block.Header.DataHash = []byte("data")
+	source := &fakeReceiptSource{
+		fakeTransactionSource: fakeTransactionSource{
+			"tx1": custodyTransaction(t, []string{"Set", "21.5", "0.4", "1628887200", string(pairedSensor), "cosig", "alarm"}, sensor, peer2, peer1),
+			"tx2": sensoryTransaction("mycc", []string{"Set"}, nil),
+		},
+		blocks: map[string]*cb.Block{"tx1": block},
+	}
+
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.ChannelID = "mychannel"
+	stub.MockTransactionStart("approvals")
+	for _, record := range []*ApprovalRecord{
+		{SensoryTxID: "tx1", ApprovalTxID: "approval2", MSPID: "Org2MSP", Timestamp: 1628887205},
+		{SensoryTxID: "tx1", ApprovalTxID: "approval3", MSPID: "Org3MSP", Timestamp: 1628887206, Private: true},
+		{SensoryTxID: "tx1", ApprovalTxID: "approval1", MSPID: "Org1MSP", Timestamp: 1628887204, ClockSkewExceeded: true},
+	} {
+		key, err := stub.CreateCompositeKey(approvalObjectType, []string{record.SensoryTxID, record.MSPID})
+		require.NoError(t, err)
+		recordBytes, err := marshalState(record)
+		require.NoError(t, err)
+		require.NoError(t, stub.PutState(key, recordBytes))
+	}
+	stub.MockTransactionEnd("approvals")
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+
+	document, err := readingProvenance(source, stub, "tx1")
+	require.NoError(t, err)
+	documentBytes, err := json.Marshal(document)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"prefix": {"blocc": "urn:blocc:"},
+		"entity": {
+			"blocc:reading/tx1": {
+				"prov:type": {"$": "blocc:Reading", "type": "prov:QUALIFIED_NAME"},
+				"blocc:channelID": "mychannel",
+				"blocc:txID": "tx1",
+				"blocc:validationCode": "VALID",
+				"blocc:severity": "alarm",
+				"blocc:temperature": 21.5,
+				"blocc:relativeHumidity": 0.4
+			},
+			"blocc:block/mychannel/7": {
+				"prov:type": {"$": "prov:Collection", "type": "prov:QUALIFIED_NAME"},
+				"blocc:channelID": "mychannel",
+				"blocc:number": 7,
+				"blocc:dataHash": "64617461",
+				"blocc:previousHash": "70726576696f7573"
+			}
+		},
+		"agent": {
+			"blocc:sensor/sensor1": {"prov:type": {"$": "blocc:Sensor", "type": "prov:QUALIFIED_NAME"}, "blocc:sensorID": "sensor1"},
+			"blocc:sensor/sensor2": {"prov:type": {"$": "blocc:Sensor", "type": "prov:QUALIFIED_NAME"}, "blocc:sensorID": "sensor2"},
+			"blocc:gateway/sensor1": {"prov:type": {"$": "blocc:Gateway", "type": "prov:QUALIFIED_NAME"}, "blocc:sensorID": "sensor1"},
+			"blocc:organization/Org1MSP": {"prov:type": {"$": "prov:Organization", "type": "prov:QUALIFIED_NAME"}, "blocc:mspID": "Org1MSP"},
+			"blocc:organization/Org2MSP": {"prov:type": {"$": "prov:Organization", "type": "prov:QUALIFIED_NAME"}, "blocc:mspID": "Org2MSP"}
+		},
+		"activity": {
+			"blocc:sensing/tx1": {"prov:endTime": "2021-08-13T20:40:00Z"},
+			"blocc:submission/tx1": {"prov:startTime": "2021-08-13T20:40:01Z"},
+			"blocc:endorsement/tx1": {},
+			"blocc:approval/approval1": {"prov:type": {"$": "blocc:Approval", "type": "prov:QUALIFIED_NAME"}, "prov:startTime": "2021-08-13T20:40:04Z", "blocc:clockSkewExceeded": true},
+			"blocc:approval/approval2": {"prov:type": {"$": "blocc:Approval", "type": "prov:QUALIFIED_NAME"}, "prov:startTime": "2021-08-13T20:40:05Z"},
+			"blocc:approval/tx1/2": {"prov:type": {"$": "blocc:Approval", "type": "prov:QUALIFIED_NAME"}, "prov:startTime": "2021-08-13T20:40:06Z", "blocc:redacted": true}
+		},
+		"wasGeneratedBy": {"_:wasGeneratedBy1": {"prov:entity": "blocc:reading/tx1", "prov:activity": "blocc:sensing/tx1"}},
+		"wasAssociatedWith": {
+			"_:wasAssociatedWith2": {"prov:activity": "blocc:sensing/tx1", "prov:agent": "blocc:sensor/sensor1", "prov:role": {"$": "blocc:sensor", "type": "prov:QUALIFIED_NAME"}},
+			"_:wasAssociatedWith4": {"prov:activity": "blocc:sensing/tx1", "prov:agent": "blocc:sensor/sensor2", "prov:role": {"$": "blocc:coSigner", "type": "prov:QUALIFIED_NAME"}},
+			"_:wasAssociatedWith7": {"prov:activity": "blocc:submission/tx1", "prov:agent": "blocc:gateway/sensor1", "prov:role": {"$": "blocc:submitter", "type": "prov:QUALIFIED_NAME"}},
+			"_:wasAssociatedWith11": {"prov:activity": "blocc:endorsement/tx1", "prov:agent": "blocc:organization/Org1MSP", "prov:role": {"$": "blocc:endorser", "type": "prov:QUALIFIED_NAME"}},
+			"_:wasAssociatedWith12": {"prov:activity": "blocc:endorsement/tx1", "prov:agent": "blocc:organization/Org2MSP", "prov:role": {"$": "blocc:endorser", "type": "prov:QUALIFIED_NAME"}},
+			"_:wasAssociatedWith14": {"prov:activity": "blocc:approval/approval1", "prov:agent": "blocc:organization/Org1MSP", "prov:role": {"$": "blocc:approver", "type": "prov:QUALIFIED_NAME"}},
+			"_:wasAssociatedWith16": {"prov:activity": "blocc:approval/approval2", "prov:agent": "blocc:organization/Org2MSP", "prov:role": {"$": "blocc:approver", "type": "prov:QUALIFIED_NAME"}}
+		},
+		"wasAttributedTo": {"_:wasAttributedTo3": {"prov:entity": "blocc:reading/tx1", "prov:agent": "blocc:sensor/sensor1"}},
+		"used": {
+			"_:used5": {"prov:activity": "blocc:submission/tx1", "prov:entity": "blocc:reading/tx1"},
+			"_:used9": {"prov:activity": "blocc:endorsement/tx1", "prov:entity": "blocc:reading/tx1"},
+			"_:used13": {"prov:activity": "blocc:approval/approval1", "prov:entity": "blocc:reading/tx1"},
+			"_:used15": {"prov:activity": "blocc:approval/approval2", "prov:entity": "blocc:reading/tx1"},
+			"_:used17": {"prov:activity": "blocc:approval/tx1/2", "prov:entity": "blocc:reading/tx1"}
+		},
+		"wasInformedBy": {
+			"_:wasInformedBy6": {"prov:informed": "blocc:submission/tx1", "prov:informant": "blocc:sensing/tx1"},
+			"_:wasInformedBy10": {"prov:informed": "blocc:endorsement/tx1", "prov:informant": "blocc:submission/tx1"}
+		},
+		"actedOnBehalfOf": {"_:actedOnBehalfOf8": {"prov:delegate": "blocc:gateway/sensor1", "prov:responsible": "blocc:organization/Org1MSP"}},
+		"hadMember": {"_:hadMember18": {"prov:collection": "blocc:block/mychannel/7", "prov:entity": "blocc:reading/tx1"}}
+	}`, string(documentBytes))
+
+	_, err = readingProvenance(source, stub, "tx2")
+	require.EqualError(t, err, "transaction tx2 invokes mycc, not a sensory reading")
+	_, err = readingProvenance(source, stub, "tx3")
+	require.EqualError(t, err, "failed to get transaction tx3: no such transaction ID [tx3] in index")
+}
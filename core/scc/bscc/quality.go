@@ -0,0 +1,143 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"math"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// QualityScore rates a reading when it is approved, so that consumers can
+// weight or filter readings by quality. Every component ranges from 0, the
+// worst, to 1, the best, but Anomaly which ranges from 0, a typical reading,
+// to 1, a reading at the limits of the validation policy.
+type QualityScore struct {
+	// Score is the mean of Freshness, Conformance, 1 - Anomaly and
+	// Reliability
+	Score float64 `json:"score"`
+	// Freshness decays linearly with the clock skew of the reading, down to
+	// 0 at the maximum clock skew of the approving peer. It is 1 if the peer
+	// has no maximum.
+	Freshness float64 `json:"freshness"`
+	// Conformance is the share of the metrics ruled by the validation policy
+	// of the sensor type that the reading carries, 1 if there is no policy.
+	Conformance float64 `json:"conformance"`
+	// Anomaly is the largest deviation of a metric from the middle of its
+	// range, relative to the half range, or of its rate of change relative
+	// to the maximum rate.
+	Anomaly float64 `json:"anomaly"`
+	// Reliability is the reliability of the sensor according to the earlier
+	// decisions of the organization, see SensorReliability.
+	Reliability float64 `json:"reliability"`
+}
+
+// scoreReading scores the quality of the reading in envelope as approved by
+// the organization mspID, before its decision is counted.
+func scoreReading(stub shim.ChaincodeStubInterface, mspID string, envelope *cb.Envelope, attestation *TimestampAttestation, maxSkew time.Duration) (*QualityScore, error) {
+	quality := &QualityScore{
+		Freshness:   freshness(attestation.ClockSkew, maxSkew),
+		Conformance: 1,
+	}
+
+	id, sensor, err := readingSensor(stub, envelope)
+	if err != nil {
+		return nil, err
+	}
+	var policy *ValidationPolicy
+	if sensor != nil && sensor.Type != "" {
+		if policy, err = loadValidationPolicy(stub, sensor.Type); err != nil {
+			return nil, err
+		}
+	}
+	if policy != nil {
+		metrics, timestamp, err := protoutil.ExtractMetricsReadingFromEnvelope(envelope)
+		if err != nil {
+			return nil, reject(ReasonMalformedReading, "failed to extract reading: %s", err)
+		}
+		previous, err := loadLastReading(stub, id, mspID)
+		if err != nil {
+			return nil, err
+		}
+		quality.Conformance = conformance(policy, metrics)
+		quality.Anomaly = anomaly(policy, metrics, timestamp, previous)
+	}
+
+	reliability, err := loadSensorReliability(stub, id, mspID)
+	if err != nil {
+		return nil, err
+	}
+	quality.Reliability = reliability.score()
+
+	quality.Score = (quality.Freshness + quality.Conformance + 1 - quality.Anomaly + quality.Reliability) / 4
+	quality.Freshness = roundScore(quality.Freshness)
+	quality.Conformance = roundScore(quality.Conformance)
+	quality.Anomaly = roundScore(quality.Anomaly)
+	quality.Reliability = roundScore(quality.Reliability)
+	quality.Score = roundScore(quality.Score)
+	return quality, nil
+}
+
+func freshness(skew int64, maxSkew time.Duration) float64 {
+	if maxSkew <= 0 {
+		return 1
+	}
+	absSkew := math.Abs(float64(skew))
+	return math.Max(0, 1-absSkew/maxSkew.Seconds())
+}
+
+func conformance(policy *ValidationPolicy, metrics map[string]float64) float64 {
+	ruled := map[string]bool{}
+	for metric := range policy.Ranges {
+		ruled[metric] = true
+	}
+	for metric := range policy.MaxRateOfChange {
+		ruled[metric] = true
+	}
+	if len(ruled) == 0 {
+		return 1
+	}
+
+	carried := 0
+	for metric := range ruled {
+		if _, ok := metrics[metric]; ok {
+			carried++
+		}
+	}
+	return float64(carried) / float64(len(ruled))
+}
+
+func anomaly(policy *ValidationPolicy, metrics map[string]float64, timestamp int64, previous *lastReading) float64 {
+	score := 0.0
+	for metric, value := range metrics {
+		r, ok := policy.Ranges[metric]
+		if ok && r.Min != nil && r.Max != nil && *r.Max > *r.Min {
+			halfRange := (*r.Max - *r.Min) / 2
+			score = math.Max(score, math.Abs(value-(*r.Min+halfRange))/halfRange)
+		}
+
+		max, ok := policy.MaxRateOfChange[metric]
+		if !ok || previous == nil || timestamp <= previous.Timestamp {
+			continue
+		}
+		previousValue, ok := previous.Metrics[metric]
+		if !ok {
+			continue
+		}
+		rate := math.Abs(value-previousValue) / float64(timestamp-previous.Timestamp)
+		score = math.Max(score, rate/max)
+	}
+	return math.Min(score, 1)
+}
+
+// roundScore rounds the score to three decimals, so that the records do not
+// carry floating point noise.
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
@@ -0,0 +1,84 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreReading(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP", Type: "dht22"}))
+	minTemperature, maxTemperature, maxCO2 := 0.0, 40.0, 5000.0
+	policyBytes, err := marshalState(&ValidationPolicy{
+		SensorType: "dht22",
+		Ranges: map[string]MetricRange{
+			"temperature": {Min: &minTemperature, Max: &maxTemperature},
+			"co2":         {Max: &maxCO2},
+		},
+		MaxRateOfChange: map[string]float64{"relativeHumidity": 0.01},
+	})
+	require.NoError(t, err)
+	key, err := stub.CreateCompositeKey(validationPolicyObjectType, []string{"dht22"})
+	require.NoError(t, err)
+	require.NoError(t, stub.PutState(key, policyBytes))
+	require.NoError(t, storeLastReading(stub, "Org1MSP", &lastReading{
+		SensorID:  "sensor1",
+		TxID:      "tx0",
+		Timestamp: 1628887190,
+		Metrics:   map[string]float64{"relativeHumidity": 0.38},
+	}))
+	stub.MockTransactionEnd("setup")
+
+	for _, approved := range []bool{true, true, false, true} {
+		stub.MockTransactionStart("decision")
+		require.NoError(t, countDecision(stub, "sensor1", "Org1MSP", sensorDecision{approved: approved}, 0))
+		stub.MockTransactionEnd("decision")
+	}
+
+	score := func(sensor string, skew int64, maxSkew time.Duration) *QualityScore {
+		envelope, err := protoutil.UnmarshalEnvelope(candidateReading(t, sensorIdentity(t, sensor), "Set", "30", "0.4", "1628887200"))
+		require.NoError(t, err)
+		stub.MockTransactionStart("approval")
+		defer stub.MockTransactionEnd("approval")
+		quality, err := scoreReading(stub, "Org1MSP", envelope, &TimestampAttestation{ClockSkew: skew}, maxSkew)
+		require.NoError(t, err)
+		return quality
+	}
+
+	// temperature is halfway between the middle and the maximum of its
+	// range, the reading carries no co2, and 3 of the 4 readings of the
+	// sensor were approved
+	require.Equal(t, &QualityScore{
+		Score:       0.658,
+		Freshness:   0.8,
+		Conformance: 0.667,
+		Anomaly:     0.5,
+		Reliability: 0.667,
+	}, score("sensor1", -60, 5*time.Minute))
+
+	// readings of unregistered sensors are only scored on freshness and
+	// reliability
+	require.Equal(t, &QualityScore{
+		Score:       0.875,
+		Freshness:   1,
+		Conformance: 1,
+		Reliability: 0.5,
+	}, score("sensor2", 600, 0))
+
+	stub.MockTransactionStart("check")
+	reliability, err := loadSensorReliability(stub, "sensor1", "Org1MSP")
+	stub.MockTransactionEnd("check")
+	require.NoError(t, err)
+	require.Equal(t, &SensorReliability{SensorID: "sensor1", MSPID: "Org1MSP", Approved: 3, Rejected: 1, RejectionRate: 0.25}, reliability)
+}
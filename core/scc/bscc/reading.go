@@ -0,0 +1,165 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// transactionSource is the subset of the ledger needed to decode readings.
+type transactionSource interface {
+	GetTransactionByID(txID string) (*pb.ProcessedTransaction, error)
+}
+
+// Reading is a sensory reading decoded from its committed transaction. The
+// metrics are decoded from the invocation args of the transaction, and
+// Writes holds the values written by the sensor chaincode, for readings
+// whose args are not a temperature and humidity or composite reading.
+// PayloadRef references the off-chain payload of the reading, if any, as
+// committed: the payload itself is not fetched.
+type Reading struct {
+	TxID             string                      `json:"txID"`
+	ValidationCode   string                      `json:"validationCode"`
+	SensorID         string                      `json:"sensorID,omitempty"`
+	Temperature      *float64                    `json:"temperature,omitempty"`
+	RelativeHumidity *float64                    `json:"relativeHumidity,omitempty"`
+	Metrics          map[string]float64          `json:"metrics,omitempty"`
+	Timestamp        int64                       `json:"timestamp,omitempty"`
+	Severity         string                      `json:"severity,omitempty"`
+	CoSigned         bool                        `json:"coSigned"`
+	Writes           map[string]string           `json:"writes,omitempty"`
+	PayloadRef       *protoutil.PayloadReference `json:"payloadRef,omitempty"`
+}
+
+// GetReading returns the JSON encoded reading of the sensory transaction
+// txID committed on the channel, decoded from the transaction, so that
+// clients need not parse blocks.
+func (bscc *BSCC) GetReading(channelID, txID string) pb.Response {
+	if channelID == "" {
+		return shim.Error("ChannelID not specified")
+	}
+	if txID == "" {
+		return shim.Error("TxID not specified")
+	}
+
+	ledger := bscc.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return shim.Error(fmt.Sprintf("channel %s not found", channelID))
+	}
+
+	reading, err := decodeReading(ledger, txID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	readingBytes, err := json.Marshal(reading)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal reading: %s", err))
+	}
+
+	return shim.Success(readingBytes)
+}
+
+func decodeReading(source transactionSource, txID string) (*Reading, error) {
+	processedTx, err := source.GetTransactionByID(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get transaction %s", txID)
+	}
+	envelope := processedTx.GetTransactionEnvelope()
+
+	cis, err := protoutil.ExtractChaincodeInvocationSpec(protoutil.MarshalOrPanic(envelope))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to extract invocation of transaction %s", txID)
+	}
+	action, err := protoutil.GetActionFromEnvelopeMsg(envelope)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to extract chaincode action of transaction %s", txID)
+	}
+	name := cis.GetChaincodeSpec().GetChaincodeId().GetName()
+	cc, ok := sensorcc.Default.Match(name, action.GetChaincodeId().GetVersion())
+	if !ok {
+		return nil, errors.Errorf("transaction %s invokes %s, not a sensory reading", txID, name)
+	}
+
+	reading := &Reading{
+		TxID:           txID,
+		ValidationCode: pb.TxValidationCode(processedTx.ValidationCode).String(),
+	}
+
+	if creator, err := protoutil.ExtractCreatorFromEnvelope(envelope); err == nil {
+		if id, err := sensorID(creator); err == nil {
+			reading.SensorID = id
+		}
+	}
+
+	if metrics, timestamp, err := protoutil.ExtractMetricsReadingFromEnvelope(envelope); err == nil {
+		if temperature, ok := metrics["temperature"]; ok {
+			reading.Temperature = &temperature
+		}
+		if humidity, ok := metrics["relativeHumidity"]; ok {
+			reading.RelativeHumidity = &humidity
+		}
+		reading.Metrics = metrics
+		reading.Timestamp = timestamp
+	}
+	if severity, err := protoutil.ExtractSeverityFromEnvelope(envelope); err == nil {
+		reading.Severity = severity
+	}
+	if ref, err := protoutil.ExtractPayloadReferenceFromEnvelope(envelope); err == nil {
+		reading.PayloadRef = ref
+	}
+	if _, coSigner, _, err := protoutil.ExtractCoSignatureFromEnvelope(envelope); err == nil {
+		reading.CoSigned = coSigner != nil
+	}
+
+	writes, err := sensorWrites(action, cc.Name)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to extract write set of transaction %s", txID)
+	}
+	if len(writes) > 0 {
+		reading.Writes = writes
+	}
+
+	return reading, nil
+}
+
+// sensorWrites returns the values written by the sensor chaincode in the
+// chaincode action, keyed by state key. Deleted keys are left out.
+func sensorWrites(action *pb.ChaincodeAction, chaincodeName string) (map[string]string, error) {
+	txRWSet := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(action.Results, txRWSet); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal read-write set")
+	}
+
+	writes := map[string]string{}
+	for _, nsRWSet := range txRWSet.NsRwset {
+		if nsRWSet.Namespace != chaincodeName {
+			continue
+		}
+
+		kvRWSet := &kvrwset.KVRWSet{}
+		if err := proto.Unmarshal(nsRWSet.Rwset, kvRWSet); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal sensor chaincode write set")
+		}
+		for _, write := range kvRWSet.Writes {
+			if !write.IsDelete {
+				writes[write.Key] = string(write.Value)
+			}
+		}
+	}
+
+	return writes, nil
+}
@@ -0,0 +1,119 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTransactionSource map[string]*pb.ProcessedTransaction
+
+func (f fakeTransactionSource) GetTransactionByID(txID string) (*pb.ProcessedTransaction, error) {
+	if tx, ok := f[txID]; ok {
+		return tx, nil
+	}
+	return nil, errors.Errorf("no such transaction ID [%s] in index", txID)
+}
+
+func sensoryTransaction(chaincodeName string, args []string, writes map[string]string) *pb.ProcessedTransaction {
+	input := &pb.ChaincodeInput{}
+	for _, arg := range args {
+		input.Args = append(input.Args, []byte(arg))
+	}
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: chaincodeName},
+			Input:       input,
+		},
+	}
+
+	kvRWSet := &kvrwset.KVRWSet{}
+	for key, value := range writes {
+		kvRWSet.Writes = append(kvRWSet.Writes, &kvrwset.KVWrite{Key: key, Value: []byte(value)})
+	}
+	results := protoutil.MarshalOrPanic(&rwset.TxReadWriteSet{
+		NsRwset: []*rwset.NsReadWriteSet{{Namespace: chaincodeName, Rwset: protoutil.MarshalOrPanic(kvRWSet)}},
+	})
+	prp := protoutil.MarshalOrPanic(&pb.ProposalResponsePayload{
+		Extension: protoutil.MarshalOrPanic(&pb.ChaincodeAction{Results: results}),
+	})
+
+	actionPayload := &pb.ChaincodeActionPayload{
+		ChaincodeProposalPayload: protoutil.MarshalOrPanic(&pb.ChaincodeProposalPayload{Input: protoutil.MarshalOrPanic(cis)}),
+		Action:                   &pb.ChaincodeEndorsedAction{ProposalResponsePayload: prp},
+	}
+	tx := &pb.Transaction{Actions: []*pb.TransactionAction{{Payload: protoutil.MarshalOrPanic(actionPayload)}}}
+	payload := &cb.Payload{
+		Header: &cb.Header{SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{})},
+		Data:   protoutil.MarshalOrPanic(tx),
+	}
+
+	return &pb.ProcessedTransaction{
+		TransactionEnvelope: &cb.Envelope{Payload: protoutil.MarshalOrPanic(payload)},
+		ValidationCode:      int32(pb.TxValidationCode_VALID),
+	}
+}
+
+func TestDecodeReading(t *testing.T) {
+	source := fakeTransactionSource{
+		"tx1": sensoryTransaction("sensor_chaincode", []string{"Set", "21.5", "0.4", "1628887200"}, map[string]string{"reading": "21.5"}),
+		"tx2": sensoryTransaction("sensor_chaincode", []string{"SetRaw", "blob"}, map[string]string{"raw": "blob"}),
+		"tx3": sensoryTransaction("mycc", []string{"Set", "21.5", "0.4", "1628887200"}, nil),
+		"tx5": sensoryTransaction("sensor_chaincode", []string{"Set", `{"temperature":21.5,"co2":415}`, "", "1628887200"}, nil),
+		"tx6": sensoryTransaction("sensor_chaincode", []string{"Set", `{"peakVelocity":2.5,"payloadRef":{"uri":"https://store/w1","sha256":"` + strings.Repeat("ab", 32) + `","mediaType":"audio/wav"}}`, "", "1628887200"}, nil),
+	}
+
+	reading, err := decodeReading(source, "tx1")
+	require.NoError(t, err)
+	readingBytes, err := json.Marshal(reading)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"txID": "tx1",
+		"validationCode": "VALID",
+		"temperature": 21.5,
+		"relativeHumidity": 0.4,
+		"metrics": {"temperature": 21.5, "relativeHumidity": 0.4},
+		"timestamp": 1628887200,
+		"coSigned": false,
+		"writes": {"reading": "21.5"}
+	}`, string(readingBytes))
+
+	// composite readings carry every metric
+	reading, err = decodeReading(source, "tx5")
+	require.NoError(t, err)
+	require.Equal(t, 21.5, *reading.Temperature)
+	require.Nil(t, reading.RelativeHumidity)
+	require.Equal(t, map[string]float64{"temperature": 21.5, "co2": 415}, reading.Metrics)
+	require.Nil(t, reading.PayloadRef)
+
+	// readings referencing an off-chain payload carry the reference
+	reading, err = decodeReading(source, "tx6")
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"peakVelocity": 2.5}, reading.Metrics)
+	require.Equal(t, &protoutil.PayloadReference{URI: "https://store/w1", SHA256: strings.Repeat("ab", 32), MediaType: "audio/wav"}, reading.PayloadRef)
+
+	// readings whose args cannot be decoded are returned with their writes
+	reading, err = decodeReading(source, "tx2")
+	require.NoError(t, err)
+	require.Nil(t, reading.Temperature)
+	require.Equal(t, map[string]string{"raw": "blob"}, reading.Writes)
+
+	_, err = decodeReading(source, "tx3")
+	require.EqualError(t, err, "transaction tx3 invokes mycc, not a sensory reading")
+
+	_, err = decodeReading(source, "tx4")
+	require.EqualError(t, err, "failed to get transaction tx4: no such transaction ID [tx4] in index")
+}
@@ -0,0 +1,106 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// receiptSource is the subset of the ledger needed to build delivery receipts.
+type receiptSource interface {
+	GetTransactionByID(txID string) (*pb.ProcessedTransaction, error)
+	GetBlockByTxID(txID string) (*cb.Block, error)
+}
+
+// DeliveryReceipt acknowledges the delivery of a reading to the ledger. A
+// store-and-forward gateway may prune a reading from its local buffer once
+// its receipt reports it valid.
+type DeliveryReceipt struct {
+	TxID           string `json:"txID"`
+	BlockNumber    uint64 `json:"blockNumber"`
+	ValidationCode string `json:"validationCode"`
+	Approvals      int    `json:"approvals"`
+	// ApprovingMSPIDs lists the approving organizations disclosed to the
+	// caller, which may be fewer than Approvals if approvals are private
+	ApprovingMSPIDs []string `json:"approvingMSPIDs"`
+}
+
+// GetDeliveryReceipt returns the JSON encoded delivery receipt of the reading
+// transaction in args[0]. An error is returned until the reading is
+// committed, so that gateways can poll for the receipt of their submissions.
+func (bscc *BSCC) GetDeliveryReceipt(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 1 || len(args[0]) == 0 {
+		return shim.Error("TxID not specified")
+	}
+
+	channelID := stub.GetChannelID()
+	ledger := bscc.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return shim.Error(fmt.Sprintf("channel %s not found", channelID))
+	}
+
+	receipt, err := deliveryReceipt(ledger, stub, string(args[0]))
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	receiptBytes, err := json.Marshal(receipt)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal delivery receipt: %s", err))
+	}
+
+	return shim.Success(receiptBytes)
+}
+
+func deliveryReceipt(source receiptSource, stub shim.ChaincodeStubInterface, txID string) (*DeliveryReceipt, error) {
+	processedTx, err := source.GetTransactionByID(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "reading %s is not committed", txID)
+	}
+	block, err := source.GetBlockByTxID(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get block of transaction %s", txID)
+	}
+
+	receipt := &DeliveryReceipt{
+		TxID:            txID,
+		BlockNumber:     block.GetHeader().GetNumber(),
+		ValidationCode:  pb.TxValidationCode(processedTx.ValidationCode).String(),
+		ApprovingMSPIDs: []string{},
+	}
+
+	iterator, err := stub.GetStateByPartialCompositeKey(approvalObjectType, []string{txID})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to query approvals of reading %s", txID)
+	}
+	defer iterator.Close()
+
+	callerMSPID, _ := creatorMSPID(stub)
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to query approvals of reading %s", txID)
+		}
+		record := &ApprovalRecord{}
+		if err := json.Unmarshal(kv.Value, record); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal approval record %s", kv.Key)
+		}
+		receipt.Approvals++
+		if record = record.redactedFor(callerMSPID); !record.Redacted {
+			receipt.ApprovingMSPIDs = append(receipt.ApprovingMSPIDs, record.MSPID)
+		}
+	}
+	sort.Strings(receipt.ApprovingMSPIDs)
+
+	return receipt, nil
+}
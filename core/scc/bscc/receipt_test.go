@@ -0,0 +1,61 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReceiptSource struct {
+	fakeTransactionSource
+	blocks map[string]*cb.Block
+}
+
+func (f *fakeReceiptSource) GetBlockByTxID(txID string) (*cb.Block, error) {
+	return f.blocks[txID], nil
+}
+
+func TestDeliveryReceipt(t *testing.T) {
+	source := &fakeReceiptSource{
+		fakeTransactionSource: fakeTransactionSource{
+			"tx1": sensoryTransaction("sensor_chaincode", []string{"Set", "21.5", "0.4", "1628887200"}, nil),
+		},
+		blocks: map[string]*cb.Block{"tx1": protoutil.NewBlock(7, nil)},
+	}
+
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.MockTransactionStart("approvals")
+	for _, record := range []*ApprovalRecord{
+		{SensoryTxID: "tx1", MSPID: "Org2MSP"},
+		{SensoryTxID: "tx1", MSPID: "Org1MSP"},
+		{SensoryTxID: "tx2", MSPID: "Org1MSP"},
+	} {
+		key, err := stub.CreateCompositeKey(approvalObjectType, []string{record.SensoryTxID, record.MSPID})
+		require.NoError(t, err)
+		recordBytes, err := marshalState(record)
+		require.NoError(t, err)
+		require.NoError(t, stub.PutState(key, recordBytes))
+	}
+	stub.MockTransactionEnd("approvals")
+
+	receipt, err := deliveryReceipt(source, stub, "tx1")
+	require.NoError(t, err)
+	require.Equal(t, &DeliveryReceipt{
+		TxID:            "tx1",
+		BlockNumber:     7,
+		ValidationCode:  "VALID",
+		Approvals:       2,
+		ApprovingMSPIDs: []string{"Org1MSP", "Org2MSP"},
+	}, receipt)
+
+	_, err = deliveryReceipt(source, stub, "tx2")
+	require.EqualError(t, err, "reading tx2 is not committed: no such transaction ID [tx2] in index")
+}
@@ -0,0 +1,129 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+)
+
+type recordedEvent struct {
+	event      event.Event
+	receivedAt time.Time
+}
+
+// eventRecorder is the built-in subscriber of the event bus. It counts the
+// events carried by the bus and keeps the most recent ones in a ring buffer,
+// giving visibility into the bus without attaching a debugger.
+type eventRecorder struct {
+	mutex   sync.Mutex
+	events  []recordedEvent
+	next    int
+	full    bool
+	metrics *Metrics
+	clock   clock.Clock
+}
+
+func newEventRecorder(metrics *Metrics, clk clock.Clock) *eventRecorder {
+	return &eventRecorder{
+		metrics: metrics,
+		clock:   clk,
+	}
+}
+
+// resize sets the number of events kept, keeping the most recent ones.
+func (r *eventRecorder) resize(size int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if size < 0 {
+		size = 0
+	}
+	recent := r.ordered()
+	if len(recent) > size {
+		recent = recent[len(recent)-size:]
+	}
+
+	r.events = make([]recordedEvent, size)
+	copy(r.events, recent)
+	r.full = size > 0 && len(recent) == size
+	r.next = len(recent)
+	if r.full {
+		r.next = 0
+	}
+}
+
+func (r *eventRecorder) serve(events <-chan event.Event) {
+	for e := range events {
+		r.record(e)
+	}
+}
+
+func (r *eventRecorder) record(e event.Event) {
+	r.metrics.BusEvents.With("channel", e.ChannelID, "type", e.Type.String()).Add(1)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.events) == 0 {
+		return
+	}
+	r.events[r.next] = recordedEvent{event: e, receivedAt: r.clock.Now()}
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// recent returns the recorded events, oldest first, as posted to webhooks.
+func (r *eventRecorder) recent() []*eventPayload {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	payloads := []*eventPayload{}
+	for _, e := range r.ordered() {
+		payloads = append(payloads, newEventPayload(e.event, e.receivedAt))
+	}
+	return payloads
+}
+
+func (r *eventRecorder) ordered() []recordedEvent {
+	if r.full {
+		return append(append([]recordedEvent{}, r.events[r.next:]...), r.events[:r.next]...)
+	}
+	return append([]recordedEvent{}, r.events[:r.next]...)
+}
+
+// GetRecentEvents returns the JSON encoded events most recently carried by
+// the event bus of this peer, oldest first, limited to the number in args[0]
+// if any.
+func (bscc *BSCC) GetRecentEvents(args [][]byte) pb.Response {
+	events := bscc.recorder.recent()
+	if len(args) > 0 && len(args[0]) > 0 {
+		limit, err := strconv.Atoi(string(args[0]))
+		if err != nil || limit <= 0 {
+			return shim.Error(fmt.Sprintf("Invalid limit '%s'", args[0]))
+		}
+		if len(events) > limit {
+			events = events[len(events)-limit:]
+		}
+	}
+
+	eventsBytes, err := json.Marshal(events)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal recent events: %s", err))
+	}
+
+	return shim.Success(eventsBytes)
+}
@@ -0,0 +1,73 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRecentEvents(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	bscc := newTestBSCCWithClock(&mock.PeerInfoProvider{}, clock)
+	bscc.recorder.resize(3)
+
+	recent := func(args ...string) []string {
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		resp := bscc.GetRecentEvents(byteArgs)
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+		var payloads []*eventPayload
+		require.NoError(t, json.Unmarshal(resp.Payload, &payloads))
+
+		txIDs := []string{}
+		for _, payload := range payloads {
+			require.Equal(t, clock.Now().Unix(), payload.Timestamp)
+			txIDs = append(txIDs, payload.SensoryTxID)
+		}
+		return txIDs
+	}
+
+	require.Empty(t, recent())
+	for _, txID := range []string{"tx1", "tx2"} {
+		bscc.recorder.record(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: txID})
+	}
+	require.Equal(t, []string{"tx1", "tx2"}, recent())
+
+	for _, txID := range []string{"tx3", "tx4"} {
+		bscc.recorder.record(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: txID})
+	}
+	require.Equal(t, []string{"tx2", "tx3", "tx4"}, recent())
+	require.Equal(t, []string{"tx4"}, recent("1"))
+
+	bscc.recorder.resize(2)
+	require.Equal(t, []string{"tx3", "tx4"}, recent())
+	bscc.recorder.resize(4)
+	bscc.recorder.record(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx5"})
+	require.Equal(t, []string{"tx3", "tx4", "tx5"}, recent())
+
+	resp := bscc.GetRecentEvents([][]byte{[]byte("none")})
+	require.Equal(t, "Invalid limit 'none'", resp.Message)
+}
+
+func TestEventRecorderMetrics(t *testing.T) {
+	counter := &metricsfakes.Counter{}
+	counter.WithReturns(counter)
+	recorder := newEventRecorder(&Metrics{BusEvents: counter}, fakeclock.NewFakeClock(time.Now()))
+
+	recorder.record(event.Event{Type: event.HeightLag, ChannelID: "mychannel"})
+	require.Equal(t, 1, counter.AddCallCount())
+	require.Equal(t, []string{"channel", "mychannel", "type", "HeightLag"}, counter.WithArgsForCall(0))
+}
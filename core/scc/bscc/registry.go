@@ -0,0 +1,283 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/pkg/errors"
+)
+
+// sensorObjectType is the composite key object type of sensor registry
+// entries, keyed by the sensor ID.
+const sensorObjectType = "sensor"
+
+// Sensor is a sensor registry entry. The ID of a sensor is the common name of
+// the certificate it signs its readings with.
+type Sensor struct {
+	// DocType is the sensor object type, used to select sensors in rich queries
+	DocType string `json:"docType"`
+	ID      string `json:"id"`
+	MSPID   string `json:"mspID"`
+	// Type is the type of the sensor, selecting the transformation applied
+	// to its readings, if any.
+	Type string `json:"type,omitempty"`
+	// PairedWith is the ID of the sensor which must co-sign every reading of
+	// this sensor, empty if readings are signed by this sensor only.
+	PairedWith string `json:"pairedWith,omitempty"`
+	// TokenHash is the hex encoded SHA-256 hash of the sensor's pre-shared
+	// token, empty if the sensor has none.
+	TokenHash string `json:"tokenHash,omitempty"`
+	// Location is the position of the sensor, nil if unknown. Located
+	// sensors are indexed by the geohash of their location.
+	Location *Location `json:"location,omitempty"`
+	// Decommission is set once the sensor is decommissioned, the sensor
+	// being inactive from then on.
+	Decommission *Decommission `json:"decommission,omitempty"`
+	// RetentionClass, hot, warm or cold, sets how long the readings of the
+	// sensor stay in the metric index before they are archived, empty
+	// standing for hot.
+	RetentionClass string `json:"retentionClass,omitempty"`
+}
+
+// tokenTransientKey is the transient field holding a sensor's pre-shared
+// token, which is kept out of the public args so it never lands in a block.
+const tokenTransientKey = "token"
+
+// RegisterSensor adds or replaces the JSON encoded sensor in args[0] in the
+// registry, on behalf of the creator's organization. Only the hash of the
+// pre-shared token passed in the transient field, if any, is stored.
+func (bscc *BSCC) RegisterSensor(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	sensor := &Sensor{}
+	if err := json.Unmarshal(args[0], sensor); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to unmarshal sensor: %s", err))
+	}
+
+	mspID, err := creatorMSPID(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	transient, err := stub.GetTransient()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transient data: %s", err))
+	}
+
+	if err := addSensor(stub, sensor, mspID, transient[tokenTransientKey], nil); err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(nil)
+}
+
+// RegisterSensors registers each sensor of the JSON encoded array in args[0]
+// as RegisterSensor does, all of them or none. The pre-shared token of a
+// sensor, if any, is passed in the transient field "token.<sensor ID>". A
+// sensor may be paired with a sensor registered earlier in the same array.
+func (bscc *BSCC) RegisterSensors(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	var sensors []*Sensor
+	if err := json.Unmarshal(args[0], &sensors); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to unmarshal sensors: %s", err))
+	}
+	if len(sensors) == 0 {
+		return shim.Error("No sensors specified")
+	}
+
+	mspID, err := creatorMSPID(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	transient, err := stub.GetTransient()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transient data: %s", err))
+	}
+
+	// the writes of the transaction are not visible to its own reads, so the
+	// sensors of the batch are tracked to pair them and to reject duplicates
+	batch := map[string]*Sensor{}
+	for i, sensor := range sensors {
+		if sensor == nil {
+			return shim.Error(fmt.Sprintf("Sensor %d is null", i))
+		}
+		if batch[sensor.ID] != nil {
+			return shim.Error(fmt.Sprintf("Sensor %d: Sensor %s is listed twice", i, sensor.ID))
+		}
+		if err := addSensor(stub, sensor, mspID, transient[tokenTransientKey+"."+sensor.ID], batch); err != nil {
+			return shim.Error(fmt.Sprintf("Sensor %d: %s", i, err))
+		}
+		batch[sensor.ID] = sensor
+	}
+
+	return shim.Success(nil)
+}
+
+// addSensor validates the sensor and stores it on behalf of mspID. The
+// sensor may be paired with a sensor of batch, the sensors registered by the
+// same transaction.
+func addSensor(stub shim.ChaincodeStubInterface, sensor *Sensor, mspID string, token []byte, batch map[string]*Sensor) error {
+	if sensor.ID == "" {
+		return errors.New("Sensor ID not specified")
+	}
+	if sensor.PairedWith == sensor.ID {
+		return errors.Errorf("Sensor %s cannot be paired with itself", sensor.ID)
+	}
+	if sensor.Location != nil {
+		if err := sensor.Location.validate(); err != nil {
+			return errors.Errorf("Invalid location of sensor %s: %s", sensor.ID, err)
+		}
+	}
+	if !config.ValidRetentionClass(sensor.RetentionClass) {
+		return errors.Errorf("Unknown retention class %s of sensor %s, expected %s, %s or %s",
+			sensor.RetentionClass, sensor.ID, config.RetentionHot, config.RetentionWarm, config.RetentionCold)
+	}
+
+	if sensor.PairedWith != "" && batch[sensor.PairedWith] == nil {
+		paired, err := loadSensor(stub, sensor.PairedWith)
+		if err != nil {
+			return err
+		}
+		if paired == nil {
+			return errors.Errorf("Paired sensor %s is not registered", sensor.PairedWith)
+		}
+	}
+
+	sensor.DocType = sensorObjectType
+	sensor.MSPID = mspID
+	sensor.TokenHash = ""
+	if len(token) > 0 {
+		sensor.TokenHash = tokenHash(token)
+	}
+
+	previous, err := loadSensor(stub, sensor.ID)
+	if err != nil {
+		return err
+	}
+	if previous != nil && previous.Decommission != nil {
+		return errors.Errorf("Sensor %s is decommissioned", sensor.ID)
+	}
+	sensor.Decommission = nil
+	if err := indexSensorLocation(stub, previous, sensor); err != nil {
+		return err
+	}
+
+	return storeSensor(stub, sensor)
+}
+
+// GetSensor returns the JSON encoded registry entry of the sensor in args[0].
+func (bscc *BSCC) GetSensor(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	sensor, err := loadSensor(stub, string(args[0]))
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if sensor == nil {
+		return shim.Error(fmt.Sprintf("Sensor %s is not registered", string(args[0])))
+	}
+
+	sensorBytes, err := json.Marshal(sensor)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal sensor: %s", err))
+	}
+
+	return shim.Success(sensorBytes)
+}
+
+// ListSensors returns a page of the sensor registry. args[0] and args[1] are
+// the optional page size and bookmark.
+func (bscc *BSCC) ListSensors(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	req, err := parsePageRequest(args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	// Initialise to an empty array
+	sensors := make([]*Sensor, 0)
+	bookmark, err := iteratePage(stub, sensorObjectType, nil, req, func(key string, value []byte) error {
+		sensor := &Sensor{}
+		if err := json.Unmarshal(value, sensor); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal sensor %s", key)
+		}
+		sensors = append(sensors, sensor)
+		return nil
+	})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to list sensors: %s", err))
+	}
+
+	jsonResponse, err := json.Marshal(&Page{Records: sensors, Bookmark: bookmark})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal sensors: %s", err))
+	}
+
+	return shim.Success(jsonResponse)
+}
+
+// loadSensor returns the registry entry of the given sensor, or nil if the
+// sensor is not registered.
+func loadSensor(stub shim.ChaincodeStubInterface, id string) (*Sensor, error) {
+	key, err := stub.CreateCompositeKey(sensorObjectType, []string{id})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create sensor key")
+	}
+
+	sensorBytes, err := stub.GetState(key)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get sensor %s", id)
+	}
+	if sensorBytes == nil {
+		return nil, nil
+	}
+
+	sensor := &Sensor{}
+	if err := json.Unmarshal(sensorBytes, sensor); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal sensor %s", id)
+	}
+
+	return sensor, nil
+}
+
+func storeSensor(stub shim.ChaincodeStubInterface, sensor *Sensor) error {
+	key, err := stub.CreateCompositeKey(sensorObjectType, []string{sensor.ID})
+	if err != nil {
+		return errors.WithMessage(err, "failed to create sensor key")
+	}
+
+	sensorBytes, err := marshalState(sensor)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal sensor")
+	}
+
+	if err := stub.PutState(key, sensorBytes); err != nil {
+		return errors.WithMessagef(err, "failed to store sensor %s", sensor.ID)
+	}
+
+	return nil
+}
+
+// sensorID returns the ID of the sensor with the given serialized identity
+func sensorID(serializedIdentity []byte) (string, error) {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sID); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal sensor identity")
+	}
+
+	block, _ := pem.Decode(sID.IdBytes)
+	if block == nil {
+		return "", errors.New("sensor identity is not a PEM encoded certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse sensor certificate")
+	}
+
+	return cert.Subject.CommonName, nil
+}
@@ -0,0 +1,46 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterSensors(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+
+	register := func(sensors string, transient map[string][]byte) string {
+		stub.MockTransactionStart("tx")
+		stub.TransientMap = transient
+		resp := bscc.RegisterSensors(stub, [][]byte{[]byte(sensors)})
+		stub.MockTransactionEnd("tx")
+		return resp.Message
+	}
+
+	require.Equal(t, "No sensors specified", register(`[]`, nil))
+	require.Equal(t, "Sensor 1: Sensor sensor1 is listed twice", register(`[{"id":"sensor1"},{"id":"sensor1"}]`, nil))
+	require.Equal(t, "Sensor 1: Paired sensor sensor3 is not registered", register(`[{"id":"sensor1"},{"id":"sensor2","pairedWith":"sensor3"}]`, nil))
+	require.Equal(t, "Sensor 0: Unknown retention class frozen of sensor sensor1, expected hot, warm or cold", register(`[{"id":"sensor1","retentionClass":"frozen"}]`, nil))
+
+	// sensors are paired with sensors registered earlier in the batch
+	require.Empty(t, register(`[{"id":"sensor1"},{"id":"sensor2","pairedWith":"sensor1","retentionClass":"cold"}]`,
+		map[string][]byte{"token.sensor2": []byte("t0k3n")}))
+
+	sensor1, err := loadSensor(stub, "sensor1")
+	require.NoError(t, err)
+	require.Equal(t, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP"}, sensor1)
+	sensor2, err := loadSensor(stub, "sensor2")
+	require.NoError(t, err)
+	require.Equal(t, &Sensor{DocType: sensorObjectType, ID: "sensor2", MSPID: "Org1MSP", PairedWith: "sensor1", TokenHash: tokenHash([]byte("t0k3n")), RetentionClass: "cold"}, sensor2)
+}
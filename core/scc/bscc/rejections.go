@@ -0,0 +1,133 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// rejectionObjectType is the composite key object type of rejection records,
+// keyed by the sensory transaction ID and the rejecting MSP ID.
+const rejectionObjectType = "rejection"
+
+// RejectionReason is the machine-readable reason for declining a reading
+type RejectionReason string
+
+const (
+	// ReasonMalformedReading is used when the reading transaction cannot be parsed
+	ReasonMalformedReading RejectionReason = "MALFORMED_READING"
+	// ReasonInvalidTransaction is used when the reading transaction was invalidated on commit
+	ReasonInvalidTransaction RejectionReason = "INVALID_TRANSACTION"
+	// ReasonInvalidSignature is used when a signature on the reading does not verify
+	ReasonInvalidSignature RejectionReason = "INVALID_SIGNATURE"
+	// ReasonMissingCoSignature is used when a paired sensor did not co-sign the reading
+	ReasonMissingCoSignature RejectionReason = "MISSING_CO_SIGNATURE"
+	// ReasonPolicyViolation is used when the reading contradicts the sensor registry
+	ReasonPolicyViolation RejectionReason = "POLICY_VIOLATION"
+	// ReasonRateOfChangeExceeded is used when a field of the reading changed
+	// faster than allowed by the validation policy of the sensor type
+	ReasonRateOfChangeExceeded RejectionReason = "RATE_OF_CHANGE_EXCEEDED"
+	// ReasonMetricOutOfRange is used when a metric of the reading is out of
+	// the range allowed by the validation policy of the sensor type
+	ReasonMetricOutOfRange RejectionReason = "METRIC_OUT_OF_RANGE"
+	// ReasonSensorDecommissioned is used when the reading was committed after
+	// the final reading of its decommissioned sensor
+	ReasonSensorDecommissioned RejectionReason = "SENSOR_DECOMMISSIONED"
+	// ReasonPayloadMismatch is used when the off-chain payload referenced by
+	// the reading does not match the hash or size the reading carries
+	ReasonPayloadMismatch RejectionReason = "PAYLOAD_MISMATCH"
+	// ReasonFirmwareNotAllowed is used when the firmware of the sensor at the
+	// time of the reading is not allowed by the validation policy of the
+	// sensor type
+	ReasonFirmwareNotAllowed RejectionReason = "FIRMWARE_NOT_ALLOWED"
+)
+
+// Rejection is returned by reading validation when this peer declines to
+// approve a reading, as opposed to failing to evaluate it.
+type Rejection struct {
+	Reason  RejectionReason
+	Message string
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("%s: %s", r.Reason, r.Message)
+}
+
+func reject(reason RejectionReason, format string, args ...interface{}) *Rejection {
+	return &Rejection{Reason: reason, Message: fmt.Sprintf(format, args...)}
+}
+
+// RejectionRecord is the state entry written by BSCC when this peer declines
+// to approve a sensory reading.
+type RejectionRecord struct {
+	SensoryTxID   string          `json:"sensoryTxID"`
+	RejectionTxID string          `json:"rejectionTxID"`
+	MSPID         string          `json:"mspID"`
+	Timestamp     int64           `json:"timestamp"`
+	Reason        RejectionReason `json:"reason"`
+	Message       string          `json:"message"`
+}
+
+func storeRejection(stub shim.ChaincodeStubInterface, record *RejectionRecord) error {
+	key, err := stub.CreateCompositeKey(rejectionObjectType, []string{record.SensoryTxID, record.MSPID})
+	if err != nil {
+		return errors.WithMessage(err, "failed to create rejection key")
+	}
+
+	recordBytes, err := marshalState(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal rejection record")
+	}
+
+	if err := stub.PutState(key, recordBytes); err != nil {
+		return errors.WithMessage(err, "failed to store rejection record")
+	}
+
+	return nil
+}
+
+// QueryRejections returns a page of the rejection records of the sensory
+// transaction in args[0], or of all sensory transactions if it is empty.
+// args[1] and args[2] are the optional page size and bookmark.
+func (bscc *BSCC) QueryRejections(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	var attributes []string
+	if len(args[0]) > 0 {
+		attributes = []string{string(args[0])}
+	}
+
+	req, err := parsePageRequest(args[1:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	// Initialise to an empty array
+	records := make([]*RejectionRecord, 0)
+	bookmark, err := iteratePage(stub, rejectionObjectType, attributes, req, func(key string, value []byte) error {
+		record := &RejectionRecord{}
+		if err := json.Unmarshal(value, record); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal rejection record %s", key)
+		}
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query rejection records: %s", err))
+	}
+
+	jsonResponse, err := json.Marshal(&Page{Records: records, Bookmark: bookmark})
+	if err != nil {
+		errMsg := fmt.Sprintf("BLOCC: Failed to marshal the result to JSON, error %s", err)
+		bloccProtoLogger.Error(errMsg)
+		return shim.Error(errMsg)
+	}
+
+	return shim.Success(jsonResponse)
+}
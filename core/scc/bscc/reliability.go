@@ -0,0 +1,309 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// sensorReliabilityObjectType is the composite key object type of the
+	// reliability statistics of a sensor, keyed by sensor ID and MSP ID.
+	sensorReliabilityObjectType = "sensorReliability"
+	// sensorReliabilityWindow is the number of decisions over which the
+	// rates of the reliability statistics roll.
+	sensorReliabilityWindow = 100
+	// anomalousScore is the anomaly score from which an approved reading
+	// counts as anomalous.
+	anomalousScore = 0.8
+)
+
+// SensorReliability are the rolling statistics of the decisions of an
+// organization on the readings of a sensor, updated by every approval or
+// rejection it commits. The rates weigh the decisions of the last
+// sensorReliabilityWindow readings or so, older decisions fading out.
+type SensorReliability struct {
+	SensorID string `json:"sensorID"`
+	MSPID    string `json:"mspID"`
+	Approved uint64 `json:"approved"`
+	Rejected uint64 `json:"rejected"`
+	// RejectionRate is the rolling share of the readings rejected
+	RejectionRate float64 `json:"rejectionRate"`
+	// AnomalyFrequency is the rolling share of the approved readings whose
+	// anomaly score reached anomalousScore
+	AnomalyFrequency float64 `json:"anomalyFrequency"`
+	// MissedHeartbeats is the number of sensor silence thresholds, as
+	// configured on the approving peer, elapsed between the readings
+	MissedHeartbeats uint64 `json:"missedHeartbeats"`
+	// LastReadingTimestamp is the latest reading timestamp reported by the
+	// sensor, in seconds
+	LastReadingTimestamp int64 `json:"lastReadingTimestamp,omitempty"`
+}
+
+// sensorDecision is an approval or a rejection of a reading to count in the
+// reliability of its sensor.
+type sensorDecision struct {
+	approved bool
+	// anomaly is the anomaly score of an approved reading
+	anomaly float64
+	// timestamp is the timestamp of the reading, zero if unknown
+	timestamp int64
+}
+
+// count adds the decision to the statistics, heartbeat being the expected
+// interval between two readings, zero if there is none.
+func (r *SensorReliability) count(d sensorDecision, heartbeat time.Duration) {
+	rejected := 1.0
+	if d.approved {
+		r.Approved++
+		rejected = 0
+
+		anomalous := 0.0
+		if d.anomaly >= anomalousScore {
+			anomalous = 1
+		}
+		r.AnomalyFrequency += (anomalous - r.AnomalyFrequency) / math.Min(float64(r.Approved), sensorReliabilityWindow)
+	} else {
+		r.Rejected++
+	}
+	r.RejectionRate += (rejected - r.RejectionRate) / math.Min(float64(r.Approved+r.Rejected), sensorReliabilityWindow)
+
+	if d.timestamp <= r.LastReadingTimestamp {
+		// readings decided out of order leave the heartbeats unchanged
+		return
+	}
+	if r.LastReadingTimestamp > 0 && heartbeat > 0 {
+		r.MissedHeartbeats += uint64(time.Duration(d.timestamp-r.LastReadingTimestamp) * time.Second / heartbeat)
+	}
+	r.LastReadingTimestamp = d.timestamp
+}
+
+// score returns the reliability of the sensor, from 0 to 1, as the share of
+// the recent readings approved, not anomalous and not missed, with Laplace
+// smoothing so that a sensor without decisions scores 0.5.
+func (r *SensorReliability) score() float64 {
+	decided := float64(r.Approved + r.Rejected)
+	share := (1 - r.RejectionRate) * (1 - r.AnomalyFrequency)
+	if decided > 0 {
+		share *= decided / (decided + float64(r.MissedHeartbeats))
+	}
+	n := math.Min(decided, sensorReliabilityWindow)
+	return (n*share + 1) / (n + 2)
+}
+
+func sensorReliabilityKey(sensorID, mspID string) (string, error) {
+	key, err := shim.CreateCompositeKey(sensorReliabilityObjectType, []string{sensorID, mspID})
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to create sensor reliability key")
+	}
+	return key, nil
+}
+
+// decodeSensorReliability decodes the stored statistics of the sensor, which
+// are empty if reliabilityBytes is nil.
+func decodeSensorReliability(sensorID, mspID string, reliabilityBytes []byte) (*SensorReliability, error) {
+	reliability := &SensorReliability{SensorID: sensorID, MSPID: mspID}
+	if reliabilityBytes == nil {
+		return reliability, nil
+	}
+	if err := json.Unmarshal(reliabilityBytes, reliability); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal reliability of sensor %s", sensorID)
+	}
+	return reliability, nil
+}
+
+// loadSensorReliability returns the statistics of the decisions of the
+// organization on the readings of the sensor, empty if it decided none.
+func loadSensorReliability(stub shim.ChaincodeStubInterface, sensorID, mspID string) (*SensorReliability, error) {
+	key, err := sensorReliabilityKey(sensorID, mspID)
+	if err != nil {
+		return nil, err
+	}
+
+	reliabilityBytes, err := stub.GetState(key)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get reliability of sensor %s", sensorID)
+	}
+	return decodeSensorReliability(sensorID, mspID, reliabilityBytes)
+}
+
+// countDecision adds the decision of the organization on a reading of the
+// sensor to the reliability statistics of the sensor.
+func countDecision(stub shim.ChaincodeStubInterface, sensorID, mspID string, d sensorDecision, heartbeat time.Duration) error {
+	reliability, err := loadSensorReliability(stub, sensorID, mspID)
+	if err != nil {
+		return err
+	}
+	reliability.count(d, heartbeat)
+
+	key, err := sensorReliabilityKey(sensorID, mspID)
+	if err != nil {
+		return err
+	}
+	reliabilityBytes, err := marshalState(reliability)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal sensor reliability")
+	}
+	if err := stub.PutState(key, reliabilityBytes); err != nil {
+		return errors.WithMessagef(err, "failed to store reliability of sensor %s", sensorID)
+	}
+
+	return nil
+}
+
+// GetSensorReliability returns the JSON encoded reliability statistics of the
+// sensor in args[0], as decided by the organization in args[1], or by the
+// creator's organization if args[1] is absent or empty.
+func (bscc *BSCC) GetSensorReliability(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 1 || len(args[0]) == 0 {
+		return shim.Error("Sensor ID not specified")
+	}
+	sensorID := string(args[0])
+
+	var mspID string
+	if len(args) > 1 && len(args[1]) > 0 {
+		mspID = string(args[1])
+	} else {
+		var err error
+		if mspID, err = creatorMSPID(stub); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
+	reliability, err := loadSensorReliability(stub, sensorID, mspID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	reliabilityBytes, err := json.Marshal(reliability)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal sensor reliability: %s", err))
+	}
+
+	return shim.Success(reliabilityBytes)
+}
+
+type reliabilityKey struct {
+	sensorKey
+	mspID string
+}
+
+// reliabilityAlerts tracks the sensors reported as unreliable. An unreliable
+// sensor is reported once, and again only after it recovered and became
+// unreliable anew.
+type reliabilityAlerts struct {
+	mutex    sync.Mutex
+	reported map[reliabilityKey]bool
+}
+
+func newReliabilityAlerts() *reliabilityAlerts {
+	return &reliabilityAlerts{reported: map[reliabilityKey]bool{}}
+}
+
+// check returns whether the sensor is to be reported as unreliable, given
+// whether it is unreliable now.
+func (a *reliabilityAlerts) check(key reliabilityKey, unreliable bool) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !unreliable {
+		delete(a.reported, key)
+		return false
+	}
+	if a.reported[key] {
+		return false
+	}
+	a.reported[key] = true
+	return true
+}
+
+// committedReliability returns the reliability statistics of the sensor
+// whose reading sensoryTxID was decided by the organization mspID, as
+// committed to the ledger of this peer.
+func (s *BloccService) committedReliability(channelID, sensoryTxID, mspID string) (*SensorReliability, error) {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return nil, bloccerrors.WithCategory(errors.Errorf("channel %s not found", channelID), bloccerrors.ErrChannelNotFound)
+	}
+
+	processedTx, err := ledger.GetTransactionByID(sensoryTxID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get sensory transaction %s", sensoryTxID)
+	}
+	creator, err := protoutil.ExtractCreatorFromEnvelope(processedTx.GetTransactionEnvelope())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to extract creator of reading %s", sensoryTxID)
+	}
+	sensorID, err := sensorID(creator)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := sensorReliabilityKey(sensorID, mspID)
+	if err != nil {
+		return nil, err
+	}
+	qe, err := ledger.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create query executor")
+	}
+	defer qe.Done()
+
+	reliabilityBytes, err := qe.GetState("bscc", key)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get reliability of sensor %s", sensorID)
+	}
+	return decodeSensorReliability(sensorID, mspID, reliabilityBytes)
+}
+
+// monitorSensorReliability publishes a SensorUnreliable event when a
+// committed decision takes the rejection rate or the anomaly frequency of a
+// sensor above the configured thresholds. The thresholds are read on every
+// decision so that reloaded settings take effect.
+func (s *BloccService) monitorSensorReliability(events <-chan event.Event) {
+	for e := range events {
+		if e.Type != event.ApprovalCommitted && e.Type != event.RejectionCommitted {
+			continue
+		}
+		options := s.currentOptions()
+		if options.SensorRejectionRateThreshold <= 0 && options.SensorAnomalyFrequencyThreshold <= 0 {
+			continue
+		}
+
+		reliability, err := s.committedReliability(e.ChannelID, e.SensoryTxID, e.MSPID)
+		if err != nil {
+			bloccProtoLogger.Debugf("Failed to read the reliability of the sensor of reading %s: %s", e.SensoryTxID, err)
+			continue
+		}
+		unreliable := (options.SensorRejectionRateThreshold > 0 && reliability.RejectionRate > options.SensorRejectionRateThreshold) ||
+			(options.SensorAnomalyFrequencyThreshold > 0 && reliability.AnomalyFrequency > options.SensorAnomalyFrequencyThreshold)
+		key := reliabilityKey{sensorKey: sensorKey{channelID: e.ChannelID, sensorID: reliability.SensorID}, mspID: e.MSPID}
+		if !s.reliabilityAlerts.check(key, unreliable) {
+			continue
+		}
+
+		bloccProtoLogger.Warningf("Sensor %s on channel %s is unreliable for %s, rejection rate %.2f, anomaly frequency %.2f",
+			reliability.SensorID, e.ChannelID, e.MSPID, reliability.RejectionRate, reliability.AnomalyFrequency)
+		s.eventBus.Publish(event.Event{
+			Type:             event.SensorUnreliable,
+			ChannelID:        e.ChannelID,
+			SensorID:         reliability.SensorID,
+			MSPID:            e.MSPID,
+			RejectionRate:    reliability.RejectionRate,
+			AnomalyFrequency: reliability.AnomalyFrequency,
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSensorReliabilityCount(t *testing.T) {
+	r := &SensorReliability{}
+	require.Equal(t, 0.5, r.score())
+
+	r.count(sensorDecision{approved: true, timestamp: 1000}, time.Minute)
+	r.count(sensorDecision{approved: false, timestamp: 1060}, time.Minute)
+	r.count(sensorDecision{approved: true, anomaly: 0.9, timestamp: 1300}, time.Minute)
+	// readings decided out of order leave the heartbeats unchanged
+	r.count(sensorDecision{approved: true, timestamp: 1200}, time.Minute)
+	require.Equal(t, uint64(3), r.Approved)
+	require.Equal(t, uint64(1), r.Rejected)
+	require.Equal(t, 0.25, r.RejectionRate)
+	require.InDelta(t, 1.0/3, r.AnomalyFrequency, 1e-9)
+	// 1 heartbeat missed between 1000 and 1060, and 4 between 1060 and 1300
+	require.Equal(t, uint64(5), r.MissedHeartbeats)
+	require.Equal(t, int64(1300), r.LastReadingTimestamp)
+	require.InDelta(t, (4*0.75*(2.0/3)*(4.0/9)+1)/6, r.score(), 1e-9)
+
+	// beyond the window, the rates roll rather than average every decision
+	r = &SensorReliability{}
+	for i := 0; i < sensorReliabilityWindow; i++ {
+		r.count(sensorDecision{approved: false}, 0)
+	}
+	for i := 0; i < sensorReliabilityWindow; i++ {
+		r.count(sensorDecision{approved: true}, 0)
+	}
+	require.Less(t, r.RejectionRate, 0.5)
+	require.Zero(t, r.MissedHeartbeats)
+}
+
+func TestGetSensorReliability(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+
+	stub.MockTransactionStart("approval")
+	require.NoError(t, countDecision(stub, "sensor1", "Org2MSP", sensorDecision{approved: false, timestamp: 1000}, 0))
+	stub.MockTransactionEnd("approval")
+
+	get := func(args ...string) (*SensorReliability, string) {
+		var bargs [][]byte
+		for _, arg := range args {
+			bargs = append(bargs, []byte(arg))
+		}
+		resp := bscc.GetSensorReliability(stub, bargs)
+		if resp.Status != 200 {
+			return nil, resp.Message
+		}
+		reliability := &SensorReliability{}
+		require.NoError(t, json.Unmarshal(resp.Payload, reliability))
+		return reliability, ""
+	}
+
+	_, msg := get()
+	require.Equal(t, "Sensor ID not specified", msg)
+
+	reliability, msg := get("sensor1", "Org2MSP")
+	require.Empty(t, msg)
+	require.Equal(t, &SensorReliability{SensorID: "sensor1", MSPID: "Org2MSP", Rejected: 1, RejectionRate: 1, LastReadingTimestamp: 1000}, reliability)
+
+	// the creator's organization decided nothing
+	reliability, msg = get("sensor1")
+	require.Empty(t, msg)
+	require.Equal(t, &SensorReliability{SensorID: "sensor1", MSPID: "Org1MSP"}, reliability)
+}
+
+func TestReliabilityAlerts(t *testing.T) {
+	alerts := newReliabilityAlerts()
+	key := reliabilityKey{sensorKey: sensorKey{channelID: "mychannel", sensorID: "sensor1"}, mspID: "Org1MSP"}
+
+	require.False(t, alerts.check(key, false))
+	require.True(t, alerts.check(key, true))
+	require.False(t, alerts.check(key, true))
+	// the sensor is reported again once it recovered
+	require.False(t, alerts.check(key, false))
+	require.True(t, alerts.check(key, true))
+}
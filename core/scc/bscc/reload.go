@@ -0,0 +1,62 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// currentOptions returns the BLOCC options currently in effect.
+func (s *BloccService) currentOptions() config.Options {
+	s.optionsLock.RLock()
+	defer s.optionsLock.RUnlock()
+	return s.options
+}
+
+// ReloadConfig re-reads the peer configuration file and applies the changes
+// to the blocc section, logging every changed option.
+func (s *BloccService) ReloadConfig() error {
+	if err := viper.ReadInConfig(); err != nil {
+		return errors.Wrap(err, "failed to read peer configuration")
+	}
+	options := config.GetOptions(viper.GetViper())
+
+	s.optionsLock.Lock()
+	changes := config.Diff(s.options, options)
+	limitsChanged := queueLimitsChanged(s.options, options)
+	s.options = options
+	s.optionsLock.Unlock()
+	applySensorChaincodes(options)
+	applyDeadLetterStore(options)
+	applyMessageCatalog(options)
+	s.applyIngestionLimits(options)
+	s.applySlowConsumerDetection(options)
+
+	if len(changes) == 0 {
+		bloccProtoLogger.Info("BLOCC configuration reloaded, no changes")
+		return nil
+	}
+	bloccProtoLogger.Infof("BLOCC configuration reloaded, changes: %s", strings.Join(changes, "; "))
+	if limitsChanged {
+		bloccProtoLogger.Warning("The approval queue length and parallelism of the channels already served are only changed from the next start")
+	}
+
+	return nil
+}
+
+func (bscc *BSCC) reloadConfig() pb.Response {
+	if err := bscc.ReloadConfig(); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to reload BLOCC configuration: %s", err))
+	}
+	return shim.Success(nil)
+}
@@ -0,0 +1,177 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+)
+
+const (
+	// maxApprovalResubmissions caps the resubmissions of an approval, so
+	// that a persistent conflict does not resubmit it forever.
+	maxApprovalResubmissions = 3
+	// submittedApprovalTTL bounds the time an approval is tracked awaiting
+	// its commit.
+	submittedApprovalTTL = time.Hour
+)
+
+// resubmittableCodes are the validation codes of approval transactions which
+// are resubmitted, the approval being rebuilt from fresh reads.
+var resubmittableCodes = map[string]bool{
+	pb.TxValidationCode_MVCC_READ_CONFLICT.String(): true,
+	pb.TxValidationCode_EXPIRED_CHAINCODE.String():  true,
+}
+
+type submittedApproval struct {
+	request       event.Event
+	resubmissions int
+	submittedAt   time.Time
+}
+
+// approvalTracker tracks the approvals submitted by this peer until they are
+// committed, keyed by the trace ID of their approval request, so that the
+// approvals invalidated on commit can be resubmitted.
+type approvalTracker struct {
+	mutex     sync.Mutex
+	submitted map[string]*submittedApproval
+	clock     clock.Clock
+}
+
+func newApprovalTracker(clk clock.Clock) *approvalTracker {
+	return &approvalTracker{
+		submitted: map[string]*submittedApproval{},
+		clock:     clk,
+	}
+}
+
+// track records the submission of the approval requested by request. The
+// resubmissions of a tracked approval are kept counting.
+func (t *approvalTracker) track(request event.Event) {
+	if request.TraceID == "" {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.clock.Now()
+	for traceID, approval := range t.submitted {
+		if now.Sub(approval.submittedAt) > submittedApprovalTTL {
+			delete(t.submitted, traceID)
+		}
+	}
+
+	approval, ok := t.submitted[request.TraceID]
+	if !ok {
+		approval = &submittedApproval{request: request}
+		t.submitted[request.TraceID] = approval
+	}
+	approval.submittedAt = now
+}
+
+// tracked returns whether the approval of the trace was submitted by this peer
+// and is awaiting its commit.
+func (t *approvalTracker) tracked(traceID string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	_, ok := t.submitted[traceID]
+	return ok
+}
+
+// resubmit returns the request of the approval of the trace and counts its
+// resubmission, unless the approval is not tracked or was resubmitted max
+// times already, in which case it is forgotten.
+func (t *approvalTracker) resubmit(traceID string, max int) (event.Event, int, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	approval, ok := t.submitted[traceID]
+	if !ok {
+		return event.Event{}, 0, false
+	}
+	if approval.resubmissions >= max {
+		delete(t.submitted, traceID)
+		return approval.request, approval.resubmissions, false
+	}
+
+	approval.resubmissions++
+	return approval.request, approval.resubmissions, true
+}
+
+// inFlight returns the number of approvals of the channel awaiting their
+// commit.
+func (t *approvalTracker) inFlight(channelID string) int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.clock.Now()
+	count := 0
+	for _, approval := range t.submitted {
+		if approval.request.ChannelID == channelID && now.Sub(approval.submittedAt) <= submittedApprovalTTL {
+			count++
+		}
+	}
+	return count
+}
+
+// forget stops tracking the approval of the trace.
+func (t *approvalTracker) forget(traceID string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	delete(t.submitted, traceID)
+}
+
+// resubmitInvalidatedApprovals resubmits the approvals of this peer
+// invalidated on commit by a conflict, the approval being rebuilt from fresh
+// reads. Approvals failing the endorsement policy cannot succeed when
+// resubmitted and are given up.
+func (s *BloccService) resubmitInvalidatedApprovals(events <-chan event.Event) {
+	for e := range events {
+		switch e.Type {
+		case event.ApprovalCommitted, event.RejectionCommitted:
+			s.approvals.forget(e.TraceID)
+		case event.ApprovalInvalidated:
+			s.handleInvalidatedApproval(e)
+		default:
+			continue
+		}
+		s.reportInFlight(e.ChannelID)
+	}
+}
+
+func (s *BloccService) handleInvalidatedApproval(e event.Event) {
+	if !s.approvals.tracked(e.TraceID) {
+		// not submitted by this peer
+		return
+	}
+	s.metrics.ApprovalInvalidations.With("channel", e.ChannelID, "validation_code", e.ValidationCode).Add(1)
+
+	if !resubmittableCodes[e.ValidationCode] {
+		if e.ValidationCode == pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE.String() {
+			bloccProtoLogger.Errorf("Approval of reading %s, trace %s, failed the endorsement policy, giving it up", e.SensoryTxID, e.TraceID)
+		} else {
+			bloccProtoLogger.Errorf("Approval of reading %s, trace %s, invalidated with %s, giving it up", e.SensoryTxID, e.TraceID, e.ValidationCode)
+		}
+		s.approvals.forget(e.TraceID)
+		return
+	}
+
+	request, resubmissions, ok := s.approvals.resubmit(e.TraceID, maxApprovalResubmissions)
+	if !ok {
+		bloccProtoLogger.Errorf("Approval of reading %s, trace %s, invalidated with %s after %d resubmissions, giving it up", e.SensoryTxID, e.TraceID, e.ValidationCode, resubmissions)
+		return
+	}
+
+	bloccProtoLogger.Warningf("Approval of reading %s, trace %s, invalidated with %s, resubmitting it (%d of %d)", e.SensoryTxID, e.TraceID, e.ValidationCode, resubmissions, maxApprovalResubmissions)
+	s.eventBus.Publish(request)
+}
@@ -0,0 +1,70 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleInvalidatedApproval(t *testing.T) {
+	service := newTestBSCC(&mock.PeerInfoProvider{})
+	events := service.eventBus.Subscribe("test")
+	defer service.eventBus.Unsubscribe(events)
+
+	request := event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx1", TraceID: "trace1"}
+	service.approvals.track(request)
+	invalidated := event.Event{Type: event.ApprovalInvalidated, ChannelID: "mychannel", SensoryTxID: "tx1", TraceID: "trace1", ValidationCode: "MVCC_READ_CONFLICT"}
+
+	// conflicting approvals are resubmitted up to the maximum
+	for i := 0; i < maxApprovalResubmissions; i++ {
+		service.handleInvalidatedApproval(invalidated)
+		select {
+		case e := <-events:
+			require.Equal(t, request, e)
+		case <-time.After(5 * time.Second):
+			t.Fatal("approval not resubmitted")
+		}
+		service.approvals.track(request)
+	}
+	service.handleInvalidatedApproval(invalidated)
+	require.False(t, service.approvals.tracked("trace1"))
+
+	// approvals failing the endorsement policy are given up
+	service.approvals.track(request)
+	invalidated.ValidationCode = "ENDORSEMENT_POLICY_FAILURE"
+	service.handleInvalidatedApproval(invalidated)
+	require.False(t, service.approvals.tracked("trace1"))
+
+	// approvals of other peers are ignored
+	invalidated.ValidationCode = "MVCC_READ_CONFLICT"
+	service.handleInvalidatedApproval(invalidated)
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event %v", e)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestApprovalTrackerExpiry(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	tracker := newApprovalTracker(clock)
+
+	tracker.track(event.Event{TraceID: "trace1"})
+	tracker.track(event.Event{})
+	require.True(t, tracker.tracked("trace1"))
+	require.False(t, tracker.tracked(""))
+
+	clock.Increment(submittedApprovalTTL + time.Second)
+	tracker.track(event.Event{TraceID: "trace2"})
+	require.False(t, tracker.tracked("trace1"))
+	require.True(t, tracker.tracked("trace2"))
+}
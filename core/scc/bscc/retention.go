@@ -0,0 +1,114 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// defaultArchiveLimit is the number of readings archived by a transaction
+// when no limit is given.
+const defaultArchiveLimit = 1000
+
+// ArchiveResult is the outcome of an ArchiveMetricReadings transaction.
+type ArchiveResult struct {
+	// Archived is the number of metric readings removed from the index
+	Archived int `json:"archived"`
+	// Complete is false if the limit was reached before every expired reading
+	// was archived
+	Complete bool `json:"complete"`
+}
+
+// ArchiveMetricReadings removes from the metric index the readings older than
+// the retention period of the class of their sensor, at most args[0] of them
+// or 1000 by default. The retention periods are those of the endorsing peer.
+// Archived readings stay in the blocks of the channel, from which GetReading
+// still reads them.
+func (bscc *BSCC) ArchiveMetricReadings(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	limit := defaultArchiveLimit
+	if len(args) > 0 && len(args[0]) > 0 {
+		var err error
+		limit, err = strconv.Atoi(string(args[0]))
+		if err != nil || limit <= 0 {
+			return shim.Error(fmt.Sprintf("Invalid limit %s", args[0]))
+		}
+	}
+
+	timestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+	}
+
+	result, err := archiveExpiredReadings(stub, timestamp.AsTime(), bscc.currentOptions().RetentionPeriod, limit)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to archive metric readings: %s", err))
+	}
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal archive result: %s", err))
+	}
+	return shim.Success(resultBytes)
+}
+
+// archiveExpiredReadings deletes up to limit metric readings older than the
+// retention period of their sensor at now. Readings of unregistered sensors
+// are retained as those of the hot class.
+func archiveExpiredReadings(stub shim.ChaincodeStubInterface, now time.Time, retentionPeriod func(class string) time.Duration, limit int) (*ArchiveResult, error) {
+	iter, err := stub.GetStateByPartialCompositeKey(metricReadingObjectType, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to iterate the metric index")
+	}
+	defer iter.Close()
+
+	periods := map[string]time.Duration{}
+	result := &ArchiveResult{Complete: true}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to iterate the metric index")
+		}
+		reading := &MetricReading{}
+		if err := json.Unmarshal(kv.Value, reading); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal metric reading %s", kv.Key)
+		}
+
+		period, ok := periods[reading.SensorID]
+		if !ok {
+			sensor, err := loadSensor(stub, reading.SensorID)
+			if err != nil {
+				return nil, err
+			}
+			var class string
+			if sensor != nil {
+				class = sensor.RetentionClass
+			}
+			period = retentionPeriod(class)
+			periods[reading.SensorID] = period
+		}
+		if period <= 0 || !time.Unix(reading.Timestamp, 0).Before(now.Add(-period)) {
+			continue
+		}
+
+		if result.Archived == limit {
+			result.Complete = false
+			break
+		}
+		if err := stub.DelState(kv.Key); err != nil {
+			return nil, errors.WithMessagef(err, "failed to archive %s of reading %s", reading.Metric, reading.SensoryTxID)
+		}
+		result.Archived++
+	}
+
+	return result, nil
+}
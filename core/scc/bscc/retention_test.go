@@ -0,0 +1,80 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveMetricReadings(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	bscc := newTestBSCC(&mock.PeerInfoProvider{})
+	bscc.options = config.Options{ColdRetention: 24 * time.Hour}
+
+	recent := strconv.FormatInt(time.Now().Unix(), 10)
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "hvac", MSPID: "Org1MSP", RetentionClass: config.RetentionCold}))
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "beam", MSPID: "Org1MSP"}))
+	require.NoError(t, indexSensorMetrics(stub, "reading1", "hvac", "Org1MSP", readingEnvelope(t, "Set", `{"temperature":21.5}`, "", "1628887200")))
+	require.NoError(t, indexSensorMetrics(stub, "reading2", "hvac", "Org1MSP", readingEnvelope(t, "Set", `{"temperature":22}`, "", "1628887260")))
+	require.NoError(t, indexSensorMetrics(stub, "reading3", "hvac", "Org1MSP", readingEnvelope(t, "Set", `{"temperature":23}`, "", recent)))
+	require.NoError(t, indexSensorMetrics(stub, "reading4", "beam", "Org1MSP", readingEnvelope(t, "Set", `{"temperature":0.2}`, "", "1628887200")))
+	// readings of unregistered sensors are retained as hot readings
+	require.NoError(t, indexSensorMetrics(stub, "reading5", "unknown", "Org1MSP", readingEnvelope(t, "Set", `{"temperature":1}`, "", "1628887200")))
+	stub.MockTransactionEnd("setup")
+
+	archive := func(args ...string) *ArchiveResult {
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		stub.MockTransactionStart("archive")
+		resp := bscc.ArchiveMetricReadings(stub, byteArgs)
+		stub.MockTransactionEnd("archive")
+		require.Empty(t, resp.Message)
+		result := &ArchiveResult{}
+		require.NoError(t, json.Unmarshal(resp.Payload, result))
+		return result
+	}
+	indexed := func() []string {
+		var txIDs []string
+		resp := bscc.QueryMetricReadings(stub, [][]byte{[]byte("temperature")})
+		require.Empty(t, resp.Message)
+		var readings []*MetricReading
+		require.NoError(t, json.Unmarshal(resp.Payload, &Page{Records: &readings}))
+		for _, reading := range readings {
+			txIDs = append(txIDs, reading.SensoryTxID)
+		}
+		return txIDs
+	}
+
+	require.Equal(t, &ArchiveResult{Archived: 1, Complete: false}, archive("1"))
+	require.Equal(t, []string{"reading4", "reading2", "reading3", "reading5"}, indexed())
+	require.Equal(t, &ArchiveResult{Archived: 1, Complete: true}, archive())
+	require.Equal(t, []string{"reading4", "reading3", "reading5"}, indexed())
+	require.Equal(t, &ArchiveResult{Archived: 0, Complete: true}, archive())
+
+	stub.MockTransactionStart("invalid")
+	resp := bscc.ArchiveMetricReadings(stub, [][]byte{[]byte("none")})
+	stub.MockTransactionEnd("invalid")
+	require.Equal(t, "Invalid limit none", resp.Message)
+
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	stub.MockTransactionStart("register")
+	resp = bscc.RegisterSensor(stub, [][]byte{[]byte(`{"id":"sensor1","retentionClass":"frozen"}`)})
+	stub.MockTransactionEnd("register")
+	require.Equal(t, "Unknown retention class frozen of sensor sensor1, expected hot, warm or cold", resp.Message)
+}
@@ -0,0 +1,409 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	// revalidationIdleInterval is the interval between two checks for the
+	// re-validation of the readings to be enabled.
+	revalidationIdleInterval = time.Minute
+	// revalidationMSPID keys the last readings of the sensors met during a
+	// re-validation, apart from those of the approving organizations.
+	revalidationMSPID = ""
+)
+
+// Annotation flags a committed reading that fails the validation policy in
+// force. It is advisory: the reading and its approvals stand.
+type Annotation struct {
+	SensoryTxID string          `json:"sensoryTxID"`
+	BlockNum    uint64          `json:"blockNum"`
+	SensorID    string          `json:"sensorID"`
+	Reason      RejectionReason `json:"reason"`
+	Message     string          `json:"message"`
+}
+
+// Revalidation is the re-evaluation of the recent readings of the sensors of
+// a sensor type against a new validation policy of the type.
+type Revalidation struct {
+	ChannelID  string `json:"channelID"`
+	SensorType string `json:"sensorType"`
+	// PolicyTxID is the transaction that set the validation policy
+	PolicyTxID string `json:"policyTxID"`
+	// FromBlock and ToBlock are the first and last blocks re-evaluated
+	FromBlock uint64 `json:"fromBlock"`
+	ToBlock   uint64 `json:"toBlock"`
+	// Evaluated is the number of readings re-evaluated
+	Evaluated   int          `json:"evaluated"`
+	Annotations []Annotation `json:"annotations"`
+	CompletedAt time.Time    `json:"completedAt"`
+}
+
+// revalidate re-evaluates the readings of the sensors of the sensor type of
+// policy in the last blocks of source against the validation policy in the
+// state of stub, which keeps the last readings of the sensors written.
+func revalidate(stub shim.ChaincodeStubInterface, source watermarkSource, policy *ValidationPolicy, blocks uint64) (*Revalidation, error) {
+	info, err := source.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get blockchain info")
+	}
+
+	revalidation := &Revalidation{
+		SensorType:  policy.SensorType,
+		PolicyTxID:  policy.TxID,
+		ToBlock:     info.Height - 1,
+		Annotations: []Annotation{},
+	}
+	// the genesis block holds no readings
+	revalidation.FromBlock = 1
+	if info.Height > blocks+1 {
+		revalidation.FromBlock = info.Height - blocks
+	}
+	if snapshotInfo := info.GetBootstrappingSnapshotInfo(); snapshotInfo != nil && revalidation.FromBlock <= snapshotInfo.LastBlockInSnapshot {
+		revalidation.FromBlock = snapshotInfo.LastBlockInSnapshot + 1
+	}
+
+	for blockNum := revalidation.FromBlock; blockNum <= revalidation.ToBlock; blockNum++ {
+		block, err := source.GetBlockByNumber(blockNum)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get block %d", blockNum)
+		}
+		for _, reading := range blockReadings(block) {
+			id, sensor, err := readingSensor(stub, reading.envelope)
+			if _, ok := err.(*Rejection); ok {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if sensor == nil || sensor.Type != policy.SensorType {
+				continue
+			}
+
+			revalidation.Evaluated++
+			last, err := checkSensorValidationPolicy(stub, reading.txID, revalidationMSPID, id, reading.envelope)
+			if rejection, ok := err.(*Rejection); ok {
+				revalidation.Annotations = append(revalidation.Annotations, Annotation{
+					SensoryTxID: reading.txID,
+					BlockNum:    blockNum,
+					SensorID:    id,
+					Reason:      rejection.Reason,
+					Message:     rejection.Message,
+				})
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if last != nil {
+				if err := storeLastReading(stub, revalidationMSPID, last); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return revalidation, nil
+}
+
+// revalidateReadings periodically re-evaluates the recent readings of the
+// sensor types whose validation policy was not re-evaluated yet, on every
+// channel of the peer. The options are read on every round so that reloaded
+// settings take effect.
+func (s *BloccService) revalidateReadings(stop <-chan struct{}) {
+	for {
+		interval := revalidationIdleInterval
+		if options := s.currentOptions(); options.RevalidationEnabled && options.RevalidationInterval > 0 {
+			interval = options.RevalidationInterval
+		}
+		if !s.sleep(stop, interval) {
+			return
+		}
+
+		options := s.currentOptions()
+		if !options.RevalidationEnabled {
+			continue
+		}
+		for _, channel := range s.peerInfo.GetChannelsInfo() {
+			if err := s.revalidateChannel(channel.ChannelId, options); err != nil {
+				bloccProtoLogger.Warningf("Failed to re-evaluate the readings of channel %s: %s", channel.ChannelId, err)
+			}
+		}
+	}
+}
+
+// revalidateChannel re-evaluates the recent readings of the channel against
+// the validation policies not re-evaluated yet, and records the annotations.
+func (s *BloccService) revalidateChannel(channelID string, options config.Options) error {
+	ledger := s.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return errors.Errorf("channel %s not found", channelID)
+	}
+
+	policies, err := validationPolicies(newLedgerStub(channelID, ledger))
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		path := revalidationPath(options.RevalidationDir, channelID, policy)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		} else if !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to stat %s", path)
+		}
+
+		revalidation, err := revalidate(newLedgerStub(channelID, ledger), ledger, policy, options.RevalidationBlocks)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to re-evaluate the readings of sensor type %s", policy.SensorType)
+		}
+		revalidation.ChannelID = channelID
+		revalidation.CompletedAt = s.clock.Now()
+		if err := writeRevalidation(path, revalidation); err != nil {
+			return err
+		}
+		bloccProtoLogger.Infof("Re-evaluated %d readings of sensor type %s on channel %s in blocks %d to %d against validation policy %s, %d fail it",
+			revalidation.Evaluated, policy.SensorType, channelID, revalidation.FromBlock, revalidation.ToBlock, policy.TxID, len(revalidation.Annotations))
+	}
+
+	return nil
+}
+
+// validationPolicies returns the validation policies in the state of stub.
+func validationPolicies(stub shim.ChaincodeStubInterface) ([]*ValidationPolicy, error) {
+	iterator, err := stub.GetStateByPartialCompositeKey(validationPolicyObjectType, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to query validation policies")
+	}
+	defer iterator.Close()
+
+	var policies []*ValidationPolicy
+	for iterator.HasNext() {
+		kv, err := iterator.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to query validation policies")
+		}
+		policy := &ValidationPolicy{}
+		if err := json.Unmarshal(kv.Value, policy); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal validation policy %s", kv.Key)
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// revalidationPath returns the path of the re-evaluation of the readings of
+// the channel against the validation policy.
+func revalidationPath(dir, channelID string, policy *ValidationPolicy) string {
+	return filepath.Join(dir, channelID, url.PathEscape(policy.SensorType)+"_"+policy.TxID+".json")
+}
+
+func writeRevalidation(path string, revalidation *Revalidation) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create directory of %s", path)
+	}
+
+	revalidationBytes, err := json.Marshal(revalidation)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal re-validation")
+	}
+
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, revalidationBytes, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "failed to rename %s", tmpPath)
+	}
+
+	return nil
+}
+
+// readRevalidations returns the re-evaluations of the readings of the
+// channel recorded in dir, the latest first.
+func readRevalidations(dir, channelID string) ([]*Revalidation, error) {
+	infos, err := ioutil.ReadDir(filepath.Join(dir, channelID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read re-validations of channel %s", channelID)
+	}
+
+	var revalidations []*Revalidation
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, channelID, info.Name())
+		revalidationBytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", path)
+		}
+		revalidation := &Revalidation{}
+		if err := json.Unmarshal(revalidationBytes, revalidation); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal %s", path)
+		}
+		revalidations = append(revalidations, revalidation)
+	}
+	sort.Slice(revalidations, func(i, j int) bool {
+		return revalidations[i].CompletedAt.After(revalidations[j].CompletedAt)
+	})
+
+	return revalidations, nil
+}
+
+// GetRevalidations returns the JSON encoded re-evaluations of the recent
+// readings of the channel against the validation policies set since the
+// re-validation of the readings was enabled on this peer, the latest first.
+func (bscc *BSCC) GetRevalidations(channelID string) pb.Response {
+	if channelID == "" {
+		return shim.Error("ChannelID not specified")
+	}
+	if bscc.peerInfo.GetLedger(channelID) == nil {
+		return shim.Error(fmt.Sprintf("channel %s not found", channelID))
+	}
+
+	revalidations, err := readRevalidations(bscc.currentOptions().RevalidationDir, channelID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if revalidations == nil {
+		revalidations = []*Revalidation{}
+	}
+
+	revalidationsBytes, err := json.Marshal(revalidations)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal re-validations: %s", err))
+	}
+
+	return shim.Success(revalidationsBytes)
+}
+
+// queryExecutorProvider is the subset of the ledger creating query executors.
+type queryExecutorProvider interface {
+	NewQueryExecutor() (ledger.QueryExecutor, error)
+}
+
+// ledgerStub runs the validation of the readings outside of a transaction:
+// it reads the BSCC state of a channel committed to the ledger and keeps its
+// writes in memory. A query executor holds back the commits until it is
+// done, so one is created per read. The other functions of the stub are not
+// implemented.
+type ledgerStub struct {
+	shim.ChaincodeStubInterface
+	channelID string
+	ledger    queryExecutorProvider
+	writes    map[string][]byte
+}
+
+func newLedgerStub(channelID string, ledger queryExecutorProvider) *ledgerStub {
+	return &ledgerStub{channelID: channelID, ledger: ledger, writes: map[string][]byte{}}
+}
+
+func (s *ledgerStub) GetChannelID() string {
+	return s.channelID
+}
+
+func (s *ledgerStub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	return shim.CreateCompositeKey(objectType, attributes)
+}
+
+func (s *ledgerStub) GetState(key string) ([]byte, error) {
+	if value, ok := s.writes[key]; ok {
+		return value, nil
+	}
+
+	qe, err := s.ledger.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create query executor")
+	}
+	defer qe.Done()
+	return qe.GetState("bscc", key)
+}
+
+// PutState keeps the write in memory. The range queries do not read it.
+func (s *ledgerStub) PutState(key string, value []byte) error {
+	s.writes[key] = value
+	return nil
+}
+
+func (s *ledgerStub) GetStateByPartialCompositeKey(objectType string, attributes []string) (shim.StateQueryIteratorInterface, error) {
+	startKey, err := shim.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	qe, err := s.ledger.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create query executor")
+	}
+	results, err := qe.GetStateRangeScanIterator("bscc", startKey, startKey+string(utf8.MaxRune))
+	if err != nil {
+		qe.Done()
+		return nil, err
+	}
+	return &ledgerStateIterator{results: results, done: qe.Done}, nil
+}
+
+// ledgerStateIterator iterates over the results of a range scan of the
+// ledger state, done releasing its query executor when it is closed.
+type ledgerStateIterator struct {
+	results commonledger.ResultsIterator
+	done    func()
+	next    *queryresult.KV
+	err     error
+}
+
+func (i *ledgerStateIterator) HasNext() bool {
+	if i.next != nil || i.err != nil {
+		return true
+	}
+	result, err := i.results.Next()
+	if err != nil {
+		i.err = err
+		return true
+	}
+	if result == nil {
+		return false
+	}
+	i.next = result.(*queryresult.KV)
+	return true
+}
+
+func (i *ledgerStateIterator) Next() (*queryresult.KV, error) {
+	if !i.HasNext() {
+		return nil, errors.New("no more results")
+	}
+	kv, err := i.next, i.err
+	i.next, i.err = nil, nil
+	return kv, err
+}
+
+func (i *ledgerStateIterator) Close() error {
+	i.results.Close()
+	i.done()
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevalidate(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	sensor1, sensor2 := sensorIdentity(t, "sensor1"), sensorIdentity(t, "sensor2")
+
+	type reading struct {
+		txID    string
+		creator []byte
+		args    []string
+		invalid bool
+	}
+	block := func(number uint64, readings ...reading) *cb.Block {
+		block := protoutil.NewBlock(number, nil)
+		for _, r := range readings {
+			env := sensoryTransaction("sensor_chaincode", r.args, nil).TransactionEnvelope
+			payload, err := protoutil.UnmarshalPayload(env.Payload)
+			require.NoError(t, err)
+			payload.Header = &cb.Header{
+				ChannelHeader:   protoutil.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_ENDORSER_TRANSACTION), TxId: r.txID}),
+				SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{Creator: r.creator}),
+			}
+			env.Payload = protoutil.MarshalOrPanic(payload)
+			block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(env))
+			flag := byte(pb.TxValidationCode_VALID)
+			if r.invalid {
+				flag = byte(pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+			}
+			block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = append(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER], flag)
+		}
+		return block
+	}
+	source := &fakeWatermarkSource{fakeBlockSource: fakeBlockSource{
+		protoutil.NewBlock(0, nil),
+		block(1,
+			reading{txID: "tx1", creator: sensor1, args: []string{"Set", "21.5", "0.4", "1628887200"}},
+			reading{txID: "tx2", creator: sensor2, args: []string{"Set", "40", "0.4", "1628887200"}},
+		),
+		block(2,
+			reading{txID: "tx3", creator: sensor1, args: []string{"Set", "35", "0.4", "1628887201"}},
+			reading{txID: "tx4", creator: sensor1, args: []string{"Set", "45", "0.4", "1628887201"}, invalid: true},
+		),
+		block(3, reading{txID: "tx5", creator: sensor1, args: []string{"Set", "25.5", "0.4", "1628887202"}}),
+	}}
+
+	max := 30.0
+	policy := &ValidationPolicy{
+		SensorType:      "dht22",
+		Ranges:          map[string]MetricRange{"temperature": {Max: &max}},
+		MaxRateOfChange: map[string]float64{"temperature": 1},
+		TxID:            "policyTx",
+	}
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP", Type: "dht22"}))
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor2", MSPID: "Org1MSP", Type: "bme280"}))
+	policyBytes, err := marshalState(policy)
+	require.NoError(t, err)
+	key, err := stub.CreateCompositeKey(validationPolicyObjectType, []string{"dht22"})
+	require.NoError(t, err)
+	require.NoError(t, stub.PutState(key, policyBytes))
+	stub.MockTransactionEnd("setup")
+
+	// the readings of other sensor types and invalid readings are left out,
+	// tx5 being checked against tx1, the last reading passing the policy
+	stub.MockTransactionStart("revalidation")
+	revalidation, err := revalidate(stub, source, policy, 10)
+	stub.MockTransactionEnd("revalidation")
+	require.NoError(t, err)
+	require.Equal(t, &Revalidation{
+		SensorType: "dht22",
+		PolicyTxID: "policyTx",
+		FromBlock:  1,
+		ToBlock:    3,
+		Evaluated:  3,
+		Annotations: []Annotation{
+			{SensoryTxID: "tx3", BlockNum: 2, SensorID: "sensor1", Reason: ReasonMetricOutOfRange, Message: "temperature of sensor sensor1 is 35, above the maximum of 30"},
+			{SensoryTxID: "tx5", BlockNum: 3, SensorID: "sensor1", Reason: ReasonRateOfChangeExceeded, Message: "temperature of sensor sensor1 changed by 4 in 2s since reading tx1, above the maximum of 1/s"},
+		},
+	}, revalidation)
+
+	// only the last blocks are re-evaluated
+	stub = shimtest.NewMockStub("bscc", nil)
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP", Type: "dht22"}))
+	require.NoError(t, stub.PutState(key, policyBytes))
+	stub.MockTransactionEnd("setup")
+	stub.MockTransactionStart("revalidation")
+	revalidation, err = revalidate(stub, source, policy, 1)
+	stub.MockTransactionEnd("revalidation")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), revalidation.FromBlock)
+	require.Equal(t, 1, revalidation.Evaluated)
+	require.Empty(t, revalidation.Annotations)
+}
+
+func TestRevalidations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "revalidation")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	revalidations, err := readRevalidations(dir, "mychannel")
+	require.NoError(t, err)
+	require.Empty(t, revalidations)
+
+	first := &Revalidation{ChannelID: "mychannel", SensorType: "dht22", PolicyTxID: "tx1", Annotations: []Annotation{}, CompletedAt: time.Unix(1628887200, 0).UTC()}
+	second := &Revalidation{ChannelID: "mychannel", SensorType: "air quality", PolicyTxID: "tx2", Annotations: []Annotation{}, CompletedAt: time.Unix(1628890800, 0).UTC()}
+	for _, revalidation := range []*Revalidation{first, second} {
+		path := revalidationPath(dir, "mychannel", &ValidationPolicy{SensorType: revalidation.SensorType, TxID: revalidation.PolicyTxID})
+		require.NoError(t, writeRevalidation(path, revalidation))
+	}
+	_, err = os.Stat(filepath.Join(dir, "mychannel", "air%20quality_tx2.json"))
+	require.NoError(t, err)
+
+	revalidations, err = readRevalidations(dir, "mychannel")
+	require.NoError(t, err)
+	require.Equal(t, []*Revalidation{second, first}, revalidations)
+}
@@ -0,0 +1,116 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// selectorQuery restricts the CouchDB selector to documents of the given type
+// and returns the resulting query string.
+func selectorQuery(docType string, selector []byte) (string, error) {
+	var userSelector map[string]interface{}
+	if err := json.Unmarshal(selector, &userSelector); err != nil {
+		return "", errors.Wrap(err, "selector must be a JSON object")
+	}
+
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"$and": []interface{}{
+				map[string]interface{}{"docType": docType},
+				userSelector,
+			},
+		},
+	}
+
+	queryBytes, err := json.Marshal(query)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal query")
+	}
+
+	return string(queryBytes), nil
+}
+
+// QueryApprovalsBySelector returns a page of the approval records matching
+// the CouchDB selector in args[0]. args[1] and args[2] are the optional page
+// size and bookmark, the bookmarks being offsets as those of QueryApprovals.
+// Private records of other organizations are redacted. Rich queries require
+// CouchDB as the state database.
+func (bscc *BSCC) QueryApprovalsBySelector(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	query, err := selectorQuery(approvalObjectType, args[0])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	req, err := parsePageRequest(args[1:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	callerMSPID, _ := creatorMSPID(stub)
+
+	// Initialise to an empty array
+	records := make([]*ApprovalRecord, 0)
+	bookmark, err := iterateOffsetPage(req, richQuery(stub, query), func(key string, value []byte) error {
+		record := &ApprovalRecord{}
+		if err := json.Unmarshal(value, record); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal approval record %s", key)
+		}
+		records = append(records, record.redactedFor(callerMSPID))
+		return nil
+	})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query approval records: %s", err))
+	}
+
+	jsonResponse, err := json.Marshal(&Page{Records: records, Bookmark: bookmark})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal approval records: %s", err))
+	}
+
+	return shim.Success(jsonResponse)
+}
+
+// QuerySensorsBySelector returns a page of the sensor registry entries
+// matching the CouchDB selector in args[0]. args[1] and args[2] are the
+// optional page size and bookmark.
+func (bscc *BSCC) QuerySensorsBySelector(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	query, err := selectorQuery(sensorObjectType, args[0])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	req, err := parsePageRequest(args[1:])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	// Initialise to an empty array
+	sensors := make([]*Sensor, 0)
+	bookmark, err := iterateQueryPage(stub, query, req, func(key string, value []byte) error {
+		sensor := &Sensor{}
+		if err := json.Unmarshal(value, sensor); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal sensor %s", key)
+		}
+		sensors = append(sensors, sensor)
+		return nil
+	})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to query sensors: %s", err))
+	}
+
+	jsonResponse, err := json.Marshal(&Page{Records: sensors, Bookmark: bookmark})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal sensors: %s", err))
+	}
+
+	return shim.Success(jsonResponse)
+}
@@ -0,0 +1,188 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+)
+
+// sensorStatsPendingTTL bounds how long a received reading is awaited to be
+// approved or rejected before it is no longer tracked.
+const sensorStatsPendingTTL = 24 * time.Hour
+
+// SensorStats are the approval statistics of a sensor, counted since this
+// peer started. A reading counts as approved or rejected according to the
+// first approval or rejection committed for it.
+type SensorStats struct {
+	ChannelID string `json:"channelID"`
+	SensorID  string `json:"sensorID"`
+	Received  uint64 `json:"received"`
+	Approved  uint64 `json:"approved"`
+	Rejected  uint64 `json:"rejected"`
+	// AverageApprovalLatency is the mean time, in seconds, between the
+	// receipt of a reading and the commit of its first approval or rejection
+	AverageApprovalLatency float64 `json:"averageApprovalLatency"`
+}
+
+type sensorCounters struct {
+	received     uint64
+	approved     uint64
+	rejected     uint64
+	totalLatency time.Duration
+}
+
+type pendingReading struct {
+	key        sensorKey
+	receivedAt time.Time
+}
+
+// sensorStats counts the readings received from each sensor and the
+// decisions committed for them.
+type sensorStats struct {
+	mutex    sync.Mutex
+	counters map[sensorKey]*sensorCounters
+	pending  map[string]pendingReading
+	metrics  *Metrics
+	clock    clock.Clock
+}
+
+func newSensorStats(metrics *Metrics, clk clock.Clock) *sensorStats {
+	return &sensorStats{
+		counters: map[sensorKey]*sensorCounters{},
+		pending:  map[string]pendingReading{},
+		metrics:  metrics,
+		clock:    clk,
+	}
+}
+
+func (s *sensorStats) countersOf(key sensorKey) *sensorCounters {
+	counters, ok := s.counters[key]
+	if !ok {
+		counters = &sensorCounters{}
+		s.counters[key] = counters
+	}
+	return counters
+}
+
+// received records the receipt of the reading sensoryTxID of the sensor.
+func (s *sensorStats) received(channelID, sensorID, sensoryTxID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.clock.Now()
+	for txID, reading := range s.pending {
+		if now.Sub(reading.receivedAt) > sensorStatsPendingTTL {
+			delete(s.pending, txID)
+		}
+	}
+
+	key := sensorKey{channelID: channelID, sensorID: sensorID}
+	s.countersOf(key).received++
+	s.pending[sensoryTxID] = pendingReading{key: key, receivedAt: now}
+	s.metrics.SensorReadings.With("channel", channelID, "sensor", sensorID).Add(1)
+}
+
+// decided records the first approval or rejection committed for a reading
+// received by this peer. Subsequent decisions are ignored.
+func (s *sensorStats) decided(sensoryTxID string, approved bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	reading, ok := s.pending[sensoryTxID]
+	if !ok {
+		return
+	}
+	delete(s.pending, sensoryTxID)
+
+	latency := s.clock.Now().Sub(reading.receivedAt)
+	counters := s.countersOf(reading.key)
+	counters.totalLatency += latency
+
+	labels := []string{"channel", reading.key.channelID, "sensor", reading.key.sensorID}
+	if approved {
+		counters.approved++
+		s.metrics.SensorApprovals.With(labels...).Add(1)
+	} else {
+		counters.rejected++
+		s.metrics.SensorRejections.With(labels...).Add(1)
+	}
+	s.metrics.SensorApprovalLatency.With(labels...).Observe(latency.Seconds())
+}
+
+// stats returns the statistics of the sensors of the channel, or of the
+// listed sensors only, ordered by sensor ID.
+func (s *sensorStats) stats(channelID string, sensorIDs ...string) []SensorStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	wanted := map[string]bool{}
+	for _, sensorID := range sensorIDs {
+		wanted[sensorID] = true
+	}
+
+	stats := []SensorStats{}
+	for key, counters := range s.counters {
+		if key.channelID != channelID || (len(wanted) > 0 && !wanted[key.sensorID]) {
+			continue
+		}
+
+		var averageLatency float64
+		if decided := counters.approved + counters.rejected; decided > 0 {
+			averageLatency = counters.totalLatency.Seconds() / float64(decided)
+		}
+		stats = append(stats, SensorStats{
+			ChannelID:              key.channelID,
+			SensorID:               key.sensorID,
+			Received:               counters.received,
+			Approved:               counters.approved,
+			Rejected:               counters.rejected,
+			AverageApprovalLatency: averageLatency,
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].SensorID < stats[j].SensorID })
+	return stats
+}
+
+// countDecisions records the approvals and rejections committed on the
+// channels of this peer.
+func (s *BloccService) countDecisions(events <-chan event.Event) {
+	for e := range events {
+		switch e.Type {
+		case event.ApprovalCommitted:
+			s.sensorStats.decided(e.SensoryTxID, true)
+		case event.RejectionCommitted:
+			s.sensorStats.decided(e.SensoryTxID, false)
+		}
+	}
+}
+
+// GetSensorStats returns the JSON encoded approval statistics of the sensors
+// of the channel, restricted to the sensors listed in args if any.
+func (bscc *BSCC) GetSensorStats(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	var sensorIDs []string
+	for _, arg := range args {
+		if len(arg) > 0 {
+			sensorIDs = append(sensorIDs, string(arg))
+		}
+	}
+
+	statsBytes, err := json.Marshal(bscc.sensorStats.stats(stub.GetChannelID(), sensorIDs...))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal sensor statistics: %s", err))
+	}
+
+	return shim.Success(statsBytes)
+}
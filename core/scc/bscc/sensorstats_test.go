@@ -0,0 +1,63 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSensorStats(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	stats := newSensorStats(NewMetrics(&disabled.Provider{}), clock)
+
+	stats.received("mychannel", "Org1MSP/sensor2", "tx1")
+	stats.received("mychannel", "Org1MSP/sensor1", "tx2")
+	stats.received("mychannel", "Org1MSP/sensor1", "tx3")
+	stats.received("otherchannel", "Org1MSP/sensor1", "tx4")
+
+	clock.Increment(2 * time.Second)
+	stats.decided("tx2", true)
+	clock.Increment(2 * time.Second)
+	stats.decided("tx3", false)
+	// only the first decision of a reading counts
+	stats.decided("tx3", true)
+	// readings not received by this peer are ignored
+	stats.decided("tx5", true)
+
+	require.Equal(t, []SensorStats{
+		{
+			ChannelID:              "mychannel",
+			SensorID:               "Org1MSP/sensor1",
+			Received:               2,
+			Approved:               1,
+			Rejected:               1,
+			AverageApprovalLatency: 3,
+		},
+		{
+			ChannelID: "mychannel",
+			SensorID:  "Org1MSP/sensor2",
+			Received:  1,
+		},
+	}, stats.stats("mychannel"))
+
+	require.Equal(t, []SensorStats{{
+		ChannelID: "mychannel",
+		SensorID:  "Org1MSP/sensor2",
+		Received:  1,
+	}}, stats.stats("mychannel", "Org1MSP/sensor2"))
+	require.Equal(t, []SensorStats{}, stats.stats("mychannel", "Org1MSP/sensor3"))
+
+	// readings awaiting a decision for too long are no longer tracked
+	clock.Increment(sensorStatsPendingTTL + time.Second)
+	stats.received("mychannel", "Org1MSP/sensor2", "tx6")
+	stats.decided("tx1", true)
+	require.Zero(t, stats.stats("mychannel", "Org1MSP/sensor2")[0].Approved)
+}
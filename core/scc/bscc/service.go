@@ -0,0 +1,557 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/metrics"
+	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/devorderer"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/ingestion"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/pinning"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Config holds the settings the BLOCC service approves readings with.
+type Config struct {
+	// PeerAddress is the address of this peer
+	PeerAddress string
+	// TLSCertFile is the TLS root certificate file of this peer
+	TLSCertFile string
+	// CryptoProvider signs the approvals, the crypto provider of the peer
+	// being used if unset
+	CryptoProvider bccsp.BCCSP
+	// PeerIdentity is the serialized identity this peer endorses with, used
+	// to recognize the readings it endorsed in approve-on-endorse mode
+	PeerIdentity []byte
+	// Signer signs the blocks of the development orderer in dev mode
+	Signer identity.SignerSerializer
+	// Gossip gossips the approval digests of this peer, none being gossiped
+	// if it is nil
+	Gossip ApprovalGossiper
+}
+
+// BloccService runs the BLOCC subsystems of a peer: the approval of sensory
+// readings, the height, fork and sensor silence monitors, and the delivery of
+// events to webhooks and streaming platforms. It is started by the peer
+// rather than by the initialization of BSCC, and can be stopped and started
+// again.
+type BloccService struct {
+	peerInfo       PeerInfoProvider
+	config         Config
+	options        config.Options
+	optionsLock    sync.RWMutex
+	metrics        *Metrics
+	forkStatuses   *forkStatusCache
+	forkAcks       *forkAckStore
+	sensorActivity *sensorActivity
+	drain          *approvalDrain
+	sensorStats    *sensorStats
+	// reliabilityAlerts tracks the sensors reported as unreliable
+	reliabilityAlerts *reliabilityAlerts
+	approvals         *approvalTracker
+	approverTracker   *approverTracker
+	// decisions and lostApprovals hold the approvals of this peer listed in
+	// its approval digests, and the approvals of the other peers reported
+	// as lost
+	decisions     *submittedDecisions
+	lostApprovals *lostApprovals
+	// approvalAccess tells the channels whose approvals are suspended, the
+	// approval identity having lost its write access to them
+	approvalAccess *approvalAccess
+	// watermarks caches the approval watermarks of the channels
+	watermarks *approvalWatermarks
+	// pending stores the approvals that could not be broadcast as the
+	// orderer was unavailable, nil if store-and-forward is disabled
+	pending *pendingApprovals
+	// projection holds the approval pipeline state projected into SQLite
+	projection *sqlProjection
+	// streams carries the approvals to the orderers, nil if the orderer is
+	// dialed for every approval
+	streams     *blocc.BroadcastStreams
+	streamsLock sync.RWMutex
+	// pinner verifies the TLS certificates of the orderers against their
+	// pins, counting the mismatches
+	pinner *pinning.Pinner
+	// shedder sheds the readings endorsed by the peer while it is
+	// overloaded, counting the readings shed
+	shedder  *ingestion.Shedder
+	recorder *eventRecorder
+	// eventBus carries the events of the peer between its components
+	eventBus *event.Bus
+	// clock is the source of time of the components of the service
+	clock clock.Clock
+	// devOrderer orders the approvals in dev mode, devOrdererDir holding
+	// its chains
+	devOrderer    *devorderer.Server
+	devOrdererDir string
+	// newApproval connects the approval of a reading to the peer and the
+	// orderer
+	newApproval func(input *blocc.ApproveForThisPeerInput, options config.Options, cryptoProvider bccsp.BCCSP, allowOrdererUnavailable bool) (*blocc.ApproveForThisPeer, error)
+
+	// runLock guards the start and stop of the service
+	runLock sync.Mutex
+	stop    chan struct{}
+	running sync.WaitGroup
+}
+
+// NewBloccService returns a stopped BLOCC service exchanging events on the
+// event bus of the peer.
+func NewBloccService(peerInfo PeerInfoProvider, metricsProvider metrics.Provider, eventBus *event.Bus) *BloccService {
+	return newBloccService(peerInfo, metricsProvider, eventBus, clock.NewClock())
+}
+
+func newBloccService(peerInfo PeerInfoProvider, metricsProvider metrics.Provider, eventBus *event.Bus, clk clock.Clock) *BloccService {
+	s := &BloccService{
+		peerInfo:          peerInfo,
+		metrics:           NewMetrics(metricsProvider),
+		forkStatuses:      newForkStatusCache(eventBus, clk),
+		forkAcks:          newForkAckStore(clk),
+		sensorActivity:    newSensorActivity(clk),
+		reliabilityAlerts: newReliabilityAlerts(),
+		drain:             newApprovalDrain(),
+		approvals:         newApprovalTracker(clk),
+		approverTracker:   newApproverTracker(clk),
+		decisions:         newSubmittedDecisions(clk),
+		lostApprovals:     newLostApprovals(clk),
+		approvalAccess:    newApprovalAccess(clk),
+		watermarks:        newApprovalWatermarks(),
+		projection:        newSQLProjection(),
+		eventBus:          eventBus,
+		clock:             clk,
+		newApproval:       blocc.NewApproveForThisPeer,
+	}
+	s.sensorStats = newSensorStats(s.metrics, clk)
+	s.pinner = pinning.NewPinner(s.countPinMismatch)
+	s.shedder = ingestion.NewShedder(s.countShedReading)
+	s.recorder = newEventRecorder(s.metrics, clk)
+	return s
+}
+
+// Start reads the BLOCC options, runs the preflight checks and starts the
+// subsystems, restoring the approval requests saved when the service was last
+// stopped or drained.
+func (s *BloccService) Start(cfg Config) error {
+	if cfg.PeerAddress == "" {
+		return errors.New("peer address is not set")
+	}
+	if cfg.TLSCertFile == "" {
+		return errors.New("peer TLS root certificate file is not set")
+	}
+	if cfg.CryptoProvider == nil {
+		cfg.CryptoProvider = s.peerInfo.GetCryptoProvider()
+	}
+
+	s.runLock.Lock()
+	defer s.runLock.Unlock()
+
+	if s.stop != nil {
+		return errors.New("BLOCC service is already started")
+	}
+	bloccProtoLogger.Info("Starting BLOCC service")
+
+	s.config = cfg
+	s.optionsLock.Lock()
+	s.options = config.GetOptions(viper.GetViper())
+	s.optionsLock.Unlock()
+	applySensorChaincodes(s.currentOptions())
+	applyDeadLetterStore(s.currentOptions())
+	applyMessageCatalog(s.currentOptions())
+	s.applyIngestionLimits(s.currentOptions())
+	s.pending = nil
+	if options := s.currentOptions(); options.StoreAndForwardEnabled {
+		pending, err := newPendingApprovals(options.StoreAndForwardDir, options.StoreAndForwardFsync)
+		if err != nil {
+			return errors.WithMessage(err, "failed to create the store of the pending approvals")
+		}
+		s.pending = pending
+	}
+	if err := s.startDevOrderer(); err != nil {
+		return errors.WithMessage(err, "failed to start the development orderer")
+	}
+	sensorcc.Default.SetMigrationHook(s.countMigratedReading)
+	s.drain = newApprovalDrain()
+	if options := s.currentOptions(); options.ApprovalStreamsEnabled {
+		s.streamsLock.Lock()
+		s.streams = blocc.NewBroadcastStreams(options.ApprovalStreamWindow, s.pinner)
+		s.streamsLock.Unlock()
+	}
+	s.stop = make(chan struct{})
+	stop := s.stop
+
+	// the observers of the events subscribe before the preflight checks, so
+	// that they receive the ServiceReady event
+	s.recorder.resize(s.currentOptions().RecentEventsBufferSize)
+	s.applySlowConsumerDetection(s.currentOptions())
+	go s.recorder.serve(s.subscribe("recorder", stop))
+	go s.countDecisions(s.subscribe("decision-counter", stop))
+	go s.monitorSensorReliability(s.subscribe("reliability-monitor", stop))
+	go s.resubmitInvalidatedApprovals(s.subscribe("approval-resubmitter", stop))
+	go s.trackApprovers(s.subscribe("approver-tracker", stop))
+	go s.reconcileApprovals(s.subscribe("approval-reconciler", stop))
+	go s.projectDecisions(s.subscribe("sql-projection", stop))
+	go newWebhookDispatcher(s.metrics, s.currentOptions, s.clock).serve(s.subscribe("webhook-dispatcher", stop))
+	s.startEventMirror(stop)
+	s.runPreflight(newPreflight(s))
+	s.checkStateMigrations()
+
+	// approval requests are redelivered until the approval is submitted, so
+	// that requests are not lost when the orderer is briefly unreachable
+	queues := newApprovalQueues()
+	subscription := s.eventBus.SubscribeWith(event.AtLeastOnce, s.currentOptions().ApprovalRedeliveryTimeout)
+	subscription.SetMaxPending(s.currentOptions().ApprovalMaxPending, func(e event.Event) {
+		if e.Type == event.ApprovalRequest {
+			bloccProtoLogger.Warningf("Dropping the approval request of transaction %s on channel %s: too many pending approval requests", e.SensoryTxID, e.ChannelID)
+		}
+	})
+	s.restoreApprovalQueue(subscription)
+	s.goRun(func() { s.serveApprovals(queues, subscription, stop) })
+	s.goRun(func() {
+		for {
+			select {
+			case delivery := <-subscription.Deliveries():
+				if delivery.Type != event.ApprovalRequest {
+					delivery.Ack()
+					continue
+				}
+				s.enqueue(queues, delivery)
+			case <-stop:
+				return
+			}
+		}
+	})
+
+	s.goRun(func() { s.monitorHeight(stop) })
+	s.goRun(func() { s.monitorForks(stop) })
+	s.goRun(func() { s.monitorSensorSilence(stop) })
+	s.goRun(func() { s.monitorMissingApprovals(stop) })
+	s.goRun(func() { s.monitorSoak(stop) })
+	s.goRun(func() { s.revalidateReadings(stop) })
+	s.goRun(func() { s.projectPipeline(stop) })
+	s.goRun(func() { s.registerAsApprover(stop) })
+	s.goRun(func() { s.gossipApprovalDigests(stop) })
+	if s.pending != nil {
+		s.restorePendingApprovals()
+		s.goRun(func() { s.forwardPendingApprovals(stop) })
+		if s.pending.fsync == FsyncPeriodic {
+			s.goRun(func() { s.syncPendingApprovals(stop) })
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the subsystems once the approvals being processed, if any,
+// completed.
+// The pending approval requests are saved to be restored on the next start.
+func (s *BloccService) Stop() {
+	s.runLock.Lock()
+	defer s.runLock.Unlock()
+
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	s.running.Wait()
+	s.stop = nil
+	s.streamsLock.Lock()
+	if s.streams != nil {
+		s.streams.Close()
+		s.streams = nil
+	}
+	s.streamsLock.Unlock()
+	s.stopDevOrderer()
+	sensorcc.Default.SetMigrationHook(nil)
+	bloccProtoLogger.Info("BLOCC service stopped")
+}
+
+// currentDrain returns the drain of the approvals started last.
+func (s *BloccService) currentDrain() *approvalDrain {
+	s.runLock.Lock()
+	defer s.runLock.Unlock()
+	return s.drain
+}
+
+// currentStreams returns the broadcast streams of the approvals, nil if the
+// orderer is dialed for every approval.
+func (s *BloccService) currentStreams() *blocc.BroadcastStreams {
+	s.streamsLock.RLock()
+	defer s.streamsLock.RUnlock()
+	return s.streams
+}
+
+// goRun runs f in a goroutine that Stop waits for.
+func (s *BloccService) goRun(f func()) {
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		f()
+	}()
+}
+
+// subscribe subscribes to the event bus as name until stop is closed or the
+// bus disconnects the subscriber as slow, the returned channel being closed
+// then.
+func (s *BloccService) subscribe(name string, stop <-chan struct{}) <-chan event.Event {
+	events := s.eventBus.Subscribe(name)
+	forwarded := make(chan event.Event)
+	s.goRun(func() {
+		defer close(forwarded)
+		defer s.eventBus.Unsubscribe(events)
+		for {
+			select {
+			case e, ok := <-events:
+				if !ok {
+					return
+				}
+				select {
+				case forwarded <- e:
+				case <-stop:
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	})
+	return forwarded
+}
+
+// sleep waits for d, returning false if stop is closed meanwhile.
+func (s *BloccService) sleep(stop <-chan struct{}, d time.Duration) bool {
+	timer := s.clock.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+func (s *BloccService) processEvent(event event.Event) error {
+	var err error
+	bloccProtoLogger.Info("BLOCC - Received approval event:", event)
+	if !s.currentOptions().ApprovesChannel(event.ChannelID) {
+		bloccProtoLogger.Debugf("Skipping approval on channel %s, not in the approval channels", event.ChannelID)
+		return nil
+	}
+	if reason, suspended := s.approvalAccess.suspended(event.ChannelID); suspended {
+		bloccProtoLogger.Debugf("Skipping approval of reading %s on channel %s, approvals are suspended: %s", event.SensoryTxID, event.ChannelID, reason)
+		return nil
+	}
+	autoApproval, err := s.featureEnabled(event.ChannelID, autoApprovalFlag)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to read feature flags: %s", err)
+		return err
+	}
+	if !autoApproval {
+		bloccProtoLogger.Debugf("Skipping approval on channel %s, auto-approval is disabled", event.ChannelID)
+		return nil
+	}
+	if s.currentOptions().ApproveOnEndorse && s.endorsedReading(event) {
+		bloccProtoLogger.Infof("Reading %s, trace %s, approved on endorsement, skipping approval transaction", event.SensoryTxID, event.TraceID)
+		s.publishEndorsementApproval(event)
+		return nil
+	}
+	return s.withOrderer(event.ChannelID, func(address, rootCertFilePath string, bftEndpoints []string) error {
+		err := s.approveSensoryReading(address, rootCertFilePath, bftEndpoints, event)
+		if err != nil {
+			bloccProtoLogger.Errorf("Failed to approve sensory reading: %s", err)
+		}
+		return err
+	})
+}
+
+// withOrderer calls f with the address and the file of the TLS root certs of
+// the orderer of the channel, and the endpoints of its nodes if it is a BFT
+// ordering service.
+func (s *BloccService) withOrderer(channelID string, f func(address, rootCertFilePath string, bftEndpoints []string) error) error {
+	address, rootCertFile, err := s.gatherOrdererInfo(channelID)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to gather orderer info: %s", err)
+		return err
+	}
+	bftEndpoints, bftRootCerts, err := s.gatherBFTOrdererInfo(channelID)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to gather BFT orderer info: %s", err)
+		return err
+	}
+	if len(bftEndpoints) > 0 {
+		rootCertFile = bftRootCerts
+	}
+
+	rootCertFilePath, err := s.createTempFile(rootCertFile)
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to create temp file: %s", err)
+		return err
+	}
+	defer s.removeTempFile(rootCertFilePath)
+
+	return f(address, rootCertFilePath, bftEndpoints)
+}
+
+func (s *BloccService) gatherOrdererInfo(channelID string) (address string, rootCertFile []byte, err error) {
+	if s.devOrderer != nil {
+		return s.devOrderer.Address(), s.devOrderer.RootCert(), nil
+	}
+
+	_, ordererOrg, err := s.peerInfo.GetOrdererInfo(channelID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(ordererOrg) == 0 {
+		return "", nil, bloccerrors.WithCategory(errors.New("No orderer organization found"), bloccerrors.ErrOrdererUnavailable)
+	} else {
+		for _, orderer := range ordererOrg {
+			// TODO: This is a hack, we should not assume that the orderer has only one address and one root cert.
+			// To be checked against multiple orderers.
+			return orderer.Addresses[0], orderer.RootCerts[0], nil
+		}
+	}
+
+	return "", nil, errors.New("Error occurred gathering orderer info")
+}
+
+// approvalCommitTimeout is the time the peer waits for its approval
+// transactions to be committed.
+const approvalCommitTimeout = 30 * time.Second
+
+// bftConsensusType is the consensus type of the channels ordered by a BFT
+// ordering service.
+const bftConsensusType = "BFT"
+
+// gatherBFTOrdererInfo returns the endpoints of the nodes of the BFT ordering
+// service of the channel, with the TLS root certs of all its organizations,
+// or no endpoint if the channel is not ordered by a BFT ordering service. The
+// endpoints are those configured for the channel, or else those of all the
+// orderer organizations if the consensus type of the channel is BFT.
+func (s *BloccService) gatherBFTOrdererInfo(channelID string) (endpoints []string, rootCerts []byte, err error) {
+	if s.devOrderer != nil {
+		return nil, nil, nil
+	}
+
+	endpoints = s.currentOptions().ApprovalBFTOrderers[channelID]
+	if len(endpoints) == 0 {
+		resources := s.peerInfo.GetChannelResources(channelID)
+		if resources == nil {
+			return nil, nil, nil
+		}
+		ordererConfig, ok := resources.OrdererConfig()
+		if !ok || ordererConfig.ConsensusType() != bftConsensusType {
+			return nil, nil, nil
+		}
+	}
+
+	_, ordererOrgs, err := s.peerInfo.GetOrdererInfo(channelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	orgNames := make([]string, 0, len(ordererOrgs))
+	for orgName := range ordererOrgs {
+		orgNames = append(orgNames, orgName)
+	}
+	sort.Strings(orgNames)
+
+	configured := len(endpoints) > 0
+	for _, orgName := range orgNames {
+		org := ordererOrgs[orgName]
+		if !configured {
+			endpoints = append(endpoints, org.Addresses...)
+		}
+		for _, rootCert := range org.RootCerts {
+			rootCerts = append(rootCerts, rootCert...)
+			rootCerts = append(rootCerts, '\n')
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, nil, bloccerrors.WithCategory(errors.Errorf("no BFT orderer endpoint found for channel %s", channelID), bloccerrors.ErrOrdererUnavailable)
+	}
+	return endpoints, rootCerts, nil
+}
+
+func (s *BloccService) createTempFile(rootCertFile []byte) (string, error) {
+	tempFile, err := ioutil.TempFile("", rootCertTempFilePrefix)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tempFile.Write(rootCertFile)
+	if err != nil {
+		return "", err
+	}
+
+	err = tempFile.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return tempFile.Name(), nil
+}
+
+func (s *BloccService) removeTempFile(filePath string) {
+	if err := os.Remove(filePath); err != nil {
+		bloccProtoLogger.Errorf("Failed to remove temp file: %s", err)
+	}
+}
+
+func (s *BloccService) approveSensoryReading(address, rootCertFilePath string, bftEndpoints []string, event event.Event) error {
+	var dryRun func(*cb.Envelope) error
+	if s.currentOptions().ApprovalDryRun {
+		dryRun = func(env *cb.Envelope) error { return s.dryRunApproval(event.ChannelID, env) }
+	}
+	var store func(*cb.Envelope) error
+	var forwarding func() bool
+	if s.currentOptions().StoreAndForwardEnabled {
+		store = s.storeApproval
+		forwarding = func() bool { return s.forwarding(event.ChannelID) }
+	}
+	// the approval is built from the event rather than from the flags of the
+	// command, as the workers of the channels approve concurrently
+	input := &blocc.ApproveForThisPeerInput{
+		OrdererAddress:      address,
+		BFTOrdererAddresses: bftEndpoints,
+		RootCertFilePath:    rootCertFilePath,
+		ChannelID:           event.ChannelID,
+		TxID:                event.SensoryTxID,
+		PeerAddress:         s.config.PeerAddress,
+		TLSRootCertFile:     s.config.TLSCertFile,
+		WaitForEvent:        true,
+		WaitForEventTimeout: approvalCommitTimeout,
+		TraceID:             event.TraceID,
+		BroadcastStreams:    s.currentStreams(),
+		Pinner:              s.pinner,
+	}
+	approval, err := s.newApproval(input, s.currentOptions(), s.config.CryptoProvider, store != nil)
+	if err != nil {
+		return err
+	}
+	approval.DryRun = dryRun
+	approval.Store = store
+	approval.Forwarding = forwarding
+	err = approval.Approve()
+	if err == nil {
+		s.decisions.record(event.ChannelID, event.SensoryTxID)
+	}
+
+	return err
+}
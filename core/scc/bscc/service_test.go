@@ -0,0 +1,232 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/bccsp"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/scc/bscc/mock"
+	blocc "github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestBloccServiceRestart(t *testing.T) {
+	queueFile := filepath.Join(t.TempDir(), "blocc", "approval_queue.json")
+	viper.Set("blocc.approvals.queueFile", queueFile)
+	defer viper.Reset()
+
+	service := NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus())
+	service.Stop()
+
+	require.EqualError(t, service.Start(Config{TLSCertFile: "ca.crt"}), "peer address is not set")
+	require.EqualError(t, service.Start(Config{PeerAddress: "peer0:7051"}), "peer TLS root certificate file is not set")
+
+	cfg := Config{PeerAddress: "peer0:7051", TLSCertFile: "ca.crt"}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, service.Start(cfg))
+		require.EqualError(t, service.Start(cfg), "BLOCC service is already started")
+		require.NoFileExists(t, queueFile)
+
+		stopped := make(chan struct{})
+		go func() {
+			service.Stop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatal("BLOCC service not stopped")
+		}
+
+		// the pending approval requests are saved for the next start
+		require.FileExists(t, queueFile)
+		service.Stop()
+	}
+}
+
+func TestBloccServiceSleep(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	service := newBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus(), clock)
+
+	done := make(chan bool)
+	go func() { done <- service.sleep(make(chan struct{}), time.Minute) }()
+	clock.WaitForWatcherAndIncrement(time.Minute)
+	require.True(t, <-done)
+
+	// stopping interrupts the sleep
+	stop := make(chan struct{})
+	go func() { done <- service.sleep(stop, time.Minute) }()
+	require.Eventually(t, func() bool { return clock.WatcherCount() == 1 }, time.Second, time.Millisecond)
+	close(stop)
+	require.False(t, <-done)
+}
+
+func TestBloccServiceEventBusIsolation(t *testing.T) {
+	service1 := NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus())
+	service2 := NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus())
+
+	stop := make(chan struct{})
+	defer close(stop)
+	events1, events2 := service1.subscribe("test", stop), service2.subscribe("test", stop)
+
+	service1.eventBus.Publish(event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx1"})
+	select {
+	case e := <-events1:
+		require.Equal(t, "tx1", e.SensoryTxID)
+	case <-time.After(time.Second):
+		t.Fatal("event not received")
+	}
+	select {
+	case e := <-events2:
+		t.Fatalf("event %v received by the service of another peer", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+type approvalSigner struct{}
+
+func (approvalSigner) Sign(msg []byte) ([]byte, error) { return msg, nil }
+func (approvalSigner) Serialize() ([]byte, error)      { return []byte("approver"), nil }
+
+// approvalEndorser records the reading approved by every proposal by the
+// channel of the proposal.
+type approvalEndorser struct {
+	mu       sync.Mutex
+	approved map[string][]string
+}
+
+func (e *approvalEndorser) ProcessProposal(_ context.Context, signedProposal *pb.SignedProposal, _ ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.Payload)
+	if err != nil {
+		return nil, err
+	}
+	cis, err := protoutil.UnmarshalChaincodeInvocationSpec(payload.Input)
+	if err != nil {
+		return nil, err
+	}
+	args := &lb.ApproveSensoryTxArgs{}
+	if err := proto.Unmarshal(cis.ChaincodeSpec.Input.Args[1], args); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.approved[chdr.ChannelId] = append(e.approved[chdr.ChannelId], args.TxId)
+	e.mu.Unlock()
+
+	return &pb.ProposalResponse{
+		Response:    &pb.Response{Status: int32(cb.Status_SUCCESS)},
+		Payload:     []byte("payload"),
+		Endorsement: &pb.Endorsement{Endorser: []byte("endorser")},
+	}, nil
+}
+
+// approvalBroadcaster checks that the approvals are sent to the orderer of
+// their channel.
+type approvalBroadcaster struct {
+	t         *testing.T
+	channelID string
+	address   string
+}
+
+func (b *approvalBroadcaster) Send(env *cb.Envelope) error {
+	chdr, err := protoutil.ChannelHeader(env)
+	require.NoError(b.t, err)
+	require.Equal(b.t, "orderer."+chdr.ChannelId+":7050", b.address)
+	require.Equal(b.t, b.channelID, chdr.ChannelId)
+	return nil
+}
+
+func (b *approvalBroadcaster) Close() error { return nil }
+
+func TestConcurrentApprovals(t *testing.T) {
+	service := NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus())
+	service.config = Config{PeerAddress: "peer0:7051", TLSCertFile: "ca.crt"}
+	endorser := &approvalEndorser{approved: map[string][]string{}}
+	service.newApproval = func(input *blocc.ApproveForThisPeerInput, _ config.Options, _ bccsp.BCCSP, _ bool) (*blocc.ApproveForThisPeer, error) {
+		require.Equal(t, "peer0:7051", input.PeerAddress)
+		require.Equal(t, "ca-"+input.ChannelID+".pem", input.RootCertFilePath)
+		input.WaitForEvent = false
+		return &blocc.ApproveForThisPeer{
+			Input:           input,
+			BroadcastClient: &approvalBroadcaster{t: t, channelID: input.ChannelID, address: input.OrdererAddress},
+			EndorserClients: []blocc.EndorserClient{endorser},
+			Signer:          approvalSigner{},
+		}, nil
+	}
+
+	// the workers of two channels approve at the same time
+	const approvals = 50
+	var wg sync.WaitGroup
+	for _, channelID := range []string{"channel1", "channel2"} {
+		for i := 0; i < approvals; i++ {
+			wg.Add(1)
+			go func(channelID string, i int) {
+				defer wg.Done()
+				e := event.Event{Type: event.ApprovalRequest, ChannelID: channelID, SensoryTxID: fmt.Sprintf("%s-tx%d", channelID, i)}
+				err := service.approveSensoryReading("orderer."+channelID+":7050", "ca-"+channelID+".pem", nil, e)
+				require.NoError(t, err)
+			}(channelID, i)
+		}
+	}
+	wg.Wait()
+
+	// every reading is approved once, on its own channel
+	for _, channelID := range []string{"channel1", "channel2"} {
+		require.Len(t, endorser.approved[channelID], approvals)
+		for _, txID := range endorser.approved[channelID] {
+			require.True(t, strings.HasPrefix(txID, channelID+"-"), "reading %s approved on %s", txID, channelID)
+		}
+	}
+}
+
+func TestApprovalBroadcastStreamsPerService(t *testing.T) {
+	// the approvals of each service are broadcast over its own streams
+	for i := 0; i < 2; i++ {
+		service := NewBloccService(&mock.PeerInfoProvider{}, &disabled.Provider{}, event.NewEventBus())
+		service.streams = blocc.NewBroadcastStreams(1, nil)
+		var streams *blocc.BroadcastStreams
+		service.newApproval = func(input *blocc.ApproveForThisPeerInput, _ config.Options, _ bccsp.BCCSP, _ bool) (*blocc.ApproveForThisPeer, error) {
+			streams = input.BroadcastStreams
+			return nil, errors.New("not connected")
+		}
+		e := event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx1"}
+		err := service.approveSensoryReading("orderer:7050", "ca.pem", nil, e)
+		require.EqualError(t, err, "not connected")
+		require.Same(t, service.streams, streams)
+		service.streams.Close()
+	}
+}
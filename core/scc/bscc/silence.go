@@ -0,0 +1,129 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// sensorSilenceIdleInterval is the interval between two checks for the
+// silence threshold to be enabled.
+const sensorSilenceIdleInterval = 30 * time.Second
+
+type sensorKey struct {
+	channelID string
+	sensorID  string
+}
+
+// sensorActivity tracks when each sensor last submitted a reading so that
+// sensors which stop reporting can be flagged. A silent sensor is reported
+// once, and again only after it resumed and fell silent anew.
+type sensorActivity struct {
+	mutex    sync.Mutex
+	lastSeen map[sensorKey]time.Time
+	reported map[sensorKey]bool
+	clock    clock.Clock
+}
+
+func newSensorActivity(clk clock.Clock) *sensorActivity {
+	return &sensorActivity{
+		lastSeen: map[sensorKey]time.Time{},
+		reported: map[sensorKey]bool{},
+		clock:    clk,
+	}
+}
+
+func (a *sensorActivity) observe(channelID, sensorID string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	key := sensorKey{channelID: channelID, sensorID: sensorID}
+	a.lastSeen[key] = a.clock.Now()
+	delete(a.reported, key)
+}
+
+// silent returns a SensorSilent event for every sensor that fell silent for
+// longer than threshold since the last call.
+func (a *sensorActivity) silent(threshold time.Duration) []event.Event {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	var events []event.Event
+	now := a.clock.Now()
+	for key, lastSeen := range a.lastSeen {
+		if a.reported[key] || now.Sub(lastSeen) <= threshold {
+			continue
+		}
+		a.reported[key] = true
+		events = append(events, event.Event{
+			Type:      event.SensorSilent,
+			ChannelID: key.channelID,
+			SensorID:  key.sensorID,
+			LastSeen:  lastSeen,
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].ChannelID != events[j].ChannelID {
+			return events[i].ChannelID < events[j].ChannelID
+		}
+		return events[i].SensorID < events[j].SensorID
+	})
+	return events
+}
+
+// observeSensor records the activity of the sensor that created the reading,
+// and the receipt of the reading in the sensor's statistics.
+func (s *BloccService) observeSensor(channelID, sensoryTxID string, envelope *cb.Envelope) {
+	if envelope == nil {
+		return
+	}
+
+	creator, err := protoutil.ExtractCreatorFromEnvelope(envelope)
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to extract reading creator: %s", err)
+		return
+	}
+
+	id, err := sensorID(creator)
+	if err != nil {
+		bloccProtoLogger.Warningf("Failed to identify reading sensor: %s", err)
+		return
+	}
+
+	s.sensorActivity.observe(channelID, id)
+	s.sensorStats.received(channelID, id, sensoryTxID)
+}
+
+// monitorSensorSilence periodically publishes a SensorSilent event for the
+// sensors that have not submitted readings within the configured threshold.
+// The options are read on every round so that reloaded settings take effect.
+func (s *BloccService) monitorSensorSilence(stop <-chan struct{}) {
+	for {
+		threshold := s.currentOptions().SensorSilenceThreshold
+		if threshold <= 0 {
+			if !s.sleep(stop, sensorSilenceIdleInterval) {
+				return
+			}
+			continue
+		}
+		if !s.sleep(stop, threshold/4) {
+			return
+		}
+
+		for _, e := range s.sensorActivity.silent(threshold) {
+			bloccProtoLogger.Warningf("Sensor %s on channel %s is silent since %s", e.SensorID, e.ChannelID, e.LastSeen)
+			s.eventBus.Publish(e)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSensorActivity(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Unix(1700000000, 0))
+	activity := newSensorActivity(clock)
+
+	activity.observe("mychannel", "Org1MSP/sensor1")
+	activity.observe("mychannel", "Org1MSP/sensor2")
+	require.Empty(t, activity.silent(time.Minute))
+
+	clock.Increment(30 * time.Second)
+	activity.observe("mychannel", "Org1MSP/sensor2")
+
+	clock.Increment(45 * time.Second)
+	require.Equal(t, []event.Event{{
+		Type:      event.SensorSilent,
+		ChannelID: "mychannel",
+		SensorID:  "Org1MSP/sensor1",
+		LastSeen:  time.Unix(1700000000, 0),
+	}}, activity.silent(time.Minute))
+
+	// reported once only
+	require.Empty(t, activity.silent(time.Minute))
+
+	// reported again after resuming and falling silent anew
+	activity.observe("mychannel", "Org1MSP/sensor1")
+	clock.Increment(2 * time.Minute)
+	silent := activity.silent(time.Minute)
+	require.Len(t, silent, 2)
+	require.Equal(t, "Org1MSP/sensor1", silent[0].SensorID)
+	require.Equal(t, "Org1MSP/sensor2", silent[1].SensorID)
+}
@@ -0,0 +1,35 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"strconv"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+)
+
+// applySlowConsumerDetection sets how the event bus detects and handles its
+// slow subscribers.
+func (s *BloccService) applySlowConsumerDetection(options config.Options) {
+	threshold := options.SlowConsumerThreshold
+	if threshold < 0 {
+		bloccProtoLogger.Warningf("Invalid slow consumer threshold %s, disabling the detection of slow event consumers", threshold)
+		threshold = 0
+	}
+	s.eventBus.DetectSlowConsumers(threshold, options.DisconnectSlowConsumers, s.reportSlowConsumer)
+}
+
+// reportSlowConsumer logs and counts a slow subscriber of the event bus. It
+// is called with the bus locked.
+func (s *BloccService) reportSlowConsumer(slow event.SlowConsumer) {
+	if slow.Disconnected {
+		bloccProtoLogger.Warningf("Disconnected slow event consumer %s, its buffer was full for %s", slow.Name, slow.FullFor)
+	} else {
+		bloccProtoLogger.Warningf("Event consumer %s is slow, its buffer has been full for %s", slow.Name, slow.FullFor)
+	}
+	s.metrics.SlowEventConsumers.With("subscriber", slow.Name, "disconnected", strconv.FormatBool(slow.Disconnected)).Add(1)
+}
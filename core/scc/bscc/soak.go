@@ -0,0 +1,132 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// soakIdleInterval is the interval at which the soak monitor checks
+	// whether soak mode was turned on.
+	soakIdleInterval = time.Minute
+	// soakTimeFormat formats the time of a snapshot in its file names, so
+	// that snapshots sort by name in time order.
+	soakTimeFormat = "20060102T150405Z"
+	soakFileSuffix = ".pb.gz"
+)
+
+// soakProfiles are the runtime profiles snapshotted in soak mode.
+var soakProfiles = []string{"heap", "goroutine"}
+
+// monitorSoak periodically snapshots the heap and goroutine profiles of the
+// peer while soak mode is on, to diagnose leaks of the approval pipeline
+// over long-running experiments. The options are read on every round so
+// that reloaded settings take effect.
+func (s *BloccService) monitorSoak(stop <-chan struct{}) {
+	for {
+		interval := soakIdleInterval
+		if options := s.currentOptions(); options.SoakProfilingEnabled && options.SoakProfilingInterval > 0 {
+			interval = options.SoakProfilingInterval
+		}
+		if !s.sleep(stop, interval) {
+			return
+		}
+
+		options := s.currentOptions()
+		if !options.SoakProfilingEnabled {
+			continue
+		}
+		if err := writeSoakSnapshot(options.SoakProfilingDir, options.SoakProfilingMaxSize, s.clock.Now()); err != nil {
+			bloccProtoLogger.Errorf("Failed to write soak snapshot: %s", err)
+		}
+	}
+}
+
+// writeSoakSnapshot writes the soak profiles to dir as
+// <profile>-<time>.pb.gz, to be read with go tool pprof, and prunes the
+// snapshots down to maxSize bytes.
+func writeSoakSnapshot(dir string, maxSize int64, now time.Time) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create %s", dir)
+	}
+
+	stamp := now.UTC().Format(soakTimeFormat)
+	for _, profile := range soakProfiles {
+		if err := writeProfile(filepath.Join(dir, profile+"-"+stamp+soakFileSuffix), profile); err != nil {
+			return err
+		}
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	bloccProtoLogger.Infof("Wrote soak snapshot %s to %s: %d goroutines, %d bytes of heap in use", stamp, dir, runtime.NumGoroutine(), memStats.HeapInuse)
+
+	return pruneSoakSnapshots(dir, maxSize)
+}
+
+func writeProfile(path, profile string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", path)
+	}
+	if err := pprof.Lookup(profile).WriteTo(f, 0); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write %s profile", profile)
+	}
+	return errors.Wrapf(f.Close(), "failed to close %s", path)
+}
+
+// pruneSoakSnapshots removes the oldest snapshots of dir once the snapshots
+// exceed maxSize bytes, the latest snapshot being always kept. Zero leaves
+// the snapshots unbounded.
+func pruneSoakSnapshots(dir string, maxSize int64) error {
+	if maxSize <= 0 {
+		return nil
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", dir)
+	}
+	type snapshotFile struct {
+		name  string
+		stamp string
+		size  int64
+	}
+	var files []snapshotFile
+	for _, info := range infos {
+		name := info.Name()
+		i := strings.LastIndex(name, "-")
+		if info.IsDir() || i < 0 || !strings.HasSuffix(name, soakFileSuffix) {
+			continue
+		}
+		files = append(files, snapshotFile{name: name, stamp: strings.TrimSuffix(name[i+1:], soakFileSuffix), size: info.Size()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].stamp > files[j].stamp })
+
+	var total int64
+	for _, f := range files {
+		total += f.size
+		if total <= maxSize || f.stamp == files[0].stamp {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, f.name)); err != nil {
+			return errors.Wrapf(err, "failed to remove %s", f.name)
+		}
+	}
+
+	return nil
+}
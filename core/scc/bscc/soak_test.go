@@ -0,0 +1,69 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSoakSnapshot(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "soak")
+	now := time.Date(2026, 10, 15, 9, 30, 0, 0, time.UTC)
+
+	require.NoError(t, writeSoakSnapshot(dir, 0, now))
+	require.FileExists(t, filepath.Join(dir, "heap-20261015T093000Z.pb.gz"))
+	require.FileExists(t, filepath.Join(dir, "goroutine-20261015T093000Z.pb.gz"))
+}
+
+func TestPruneSoakSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, size int) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), make([]byte, size), 0o644))
+	}
+	write("heap-20261015T090000Z.pb.gz", 40)
+	write("goroutine-20261015T090000Z.pb.gz", 10)
+	write("heap-20261015T100000Z.pb.gz", 40)
+	write("goroutine-20261015T100000Z.pb.gz", 10)
+	write("heap-20261015T110000Z.pb.gz", 40)
+	write("goroutine-20261015T110000Z.pb.gz", 10)
+	write("notes.txt", 1000)
+
+	names := func() []string {
+		infos, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		var names []string
+		for _, info := range infos {
+			names = append(names, info.Name())
+		}
+		return names
+	}
+
+	require.NoError(t, pruneSoakSnapshots(dir, 0))
+	require.Len(t, names(), 7)
+
+	// the oldest snapshots are removed first
+	require.NoError(t, pruneSoakSnapshots(dir, 100))
+	require.Equal(t, []string{
+		"goroutine-20261015T100000Z.pb.gz",
+		"goroutine-20261015T110000Z.pb.gz",
+		"heap-20261015T100000Z.pb.gz",
+		"heap-20261015T110000Z.pb.gz",
+		"notes.txt",
+	}, names())
+
+	// the latest snapshot is kept even if it exceeds the cap
+	require.NoError(t, pruneSoakSnapshots(dir, 10))
+	require.Equal(t, []string{
+		"goroutine-20261015T110000Z.pb.gz",
+		"heap-20261015T110000Z.pb.gz",
+		"notes.txt",
+	}, names())
+}
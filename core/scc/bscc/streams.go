@@ -0,0 +1,98 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/streaming"
+)
+
+// maxStreamingRetryBackoff caps the delay between two publication attempts.
+const maxStreamingRetryBackoff = time.Minute
+
+// eventMirror mirrors the events of the BLOCC event bus onto a streaming
+// platform. Failed publications are retried until they succeed, so every
+// event is delivered at least once while the peer is running; events are
+// keyed by channel so that the events of a channel stay in order.
+type eventMirror struct {
+	publisher streaming.Publisher
+	options   func() config.Options
+	clock     clock.Clock
+}
+
+func newEventMirror(publisher streaming.Publisher, options func() config.Options, clk clock.Clock) *eventMirror {
+	return &eventMirror{
+		publisher: publisher,
+		options:   options,
+		clock:     clk,
+	}
+}
+
+// serve mirrors the events received on events. Approval requests and
+// digests are internal to the peer and are not mirrored.
+func (m *eventMirror) serve(events <-chan event.Event) {
+	for e := range events {
+		if e.Type == event.ApprovalRequest || e.Type == event.ApprovalDigestReceived {
+			continue
+		}
+		m.publish(e)
+	}
+}
+
+func (m *eventMirror) publish(e event.Event) {
+	payload, err := json.Marshal(newEventPayload(e, m.clock.Now()))
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to marshal event payload: %s", err)
+		return
+	}
+
+	backoff := m.options().StreamingRetryBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	for {
+		// the topic is looked up on every attempt so that a reloaded
+		// mapping applies to pending events
+		topic := m.options().StreamTopic(e.ChannelID)
+		err := m.publisher.Publish(topic, []byte(e.ChannelID), payload)
+		if err == nil {
+			return
+		}
+
+		bloccProtoLogger.Warningf("Failed to publish %s event to %s, retrying in %s: %s", e.Type, topic, backoff, err)
+		m.clock.Sleep(backoff)
+		if backoff *= 2; backoff > maxStreamingRetryBackoff {
+			backoff = maxStreamingRetryBackoff
+		}
+	}
+}
+
+// startEventMirror mirrors the event bus onto the configured streaming
+// platform, if any, until stop is closed. The platform is selected at
+// startup only.
+func (s *BloccService) startEventMirror(stop <-chan struct{}) {
+	publisher, err := streaming.New(s.currentOptions())
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to create event publisher: %s", err)
+		return
+	}
+	if publisher == nil {
+		return
+	}
+
+	events := s.subscribe("event-mirror", stop)
+	go func() {
+		newEventMirror(publisher, s.currentOptions, s.clock).serve(events)
+		if err := publisher.Close(); err != nil {
+			bloccProtoLogger.Errorf("Failed to close event publisher: %s", err)
+		}
+	}()
+}
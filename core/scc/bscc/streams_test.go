@@ -0,0 +1,65 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePublisher struct {
+	failures int
+	topics   []string
+	keys     []string
+	payloads []string
+}
+
+func (p *fakePublisher) Publish(topic string, key, payload []byte) error {
+	p.topics = append(p.topics, topic)
+	p.keys = append(p.keys, string(key))
+	p.payloads = append(p.payloads, string(payload))
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker unavailable")
+	}
+	return nil
+}
+
+func (p *fakePublisher) Close() error {
+	return nil
+}
+
+func TestEventMirror(t *testing.T) {
+	publisher := &fakePublisher{failures: 2}
+	options := config.Options{
+		StreamingTopic:         "blocc-events",
+		StreamingChannelTopics: map[string]string{"sensorchannel": "sensor-events"},
+		StreamingRetryBackoff:  time.Second,
+	}
+
+	clock := newSleepRecorder()
+	m := newEventMirror(publisher, func() config.Options { return options }, clock)
+
+	events := make(chan event.Event, 3)
+	events <- event.Event{Type: event.ApprovalRequest, ChannelID: "sensorchannel", SensoryTxID: "tx1"}
+	events <- event.Event{Type: event.ApprovalCommitted, ChannelID: "sensorchannel", SensoryTxID: "tx1", MSPID: "Org1MSP"}
+	events <- event.Event{Type: event.HeightLag, ChannelID: "otherchannel", PeerHeight: 5, OrdererHeight: 20}
+	close(events)
+	m.serve(events)
+
+	// the first event is retried until published, the clock advancing by
+	// the backoff
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.slept())
+	require.Equal(t, []string{"sensor-events", "sensor-events", "sensor-events", "blocc-events"}, publisher.topics)
+	require.Equal(t, []string{"sensorchannel", "sensorchannel", "sensorchannel", "otherchannel"}, publisher.keys)
+	require.JSONEq(t, `{"type":"ApprovalCommitted","channelID":"sensorchannel","timestamp":1700000000,"sensoryTxID":"tx1","mspID":"Org1MSP"}`, publisher.payloads[2])
+	require.JSONEq(t, `{"type":"HeightLag","channelID":"otherchannel","timestamp":1700000003,"peerHeight":5,"ordererHeight":20}`, publisher.payloads[3])
+}
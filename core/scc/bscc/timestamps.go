@@ -0,0 +1,48 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// TimestampAttestation compares the timestamp reported by a sensor with the
+// time at which the approving peer received the reading.
+type TimestampAttestation struct {
+	// SensorTimestamp is the reading timestamp reported by the sensor, in seconds
+	SensorTimestamp int64
+	// ReceiptTimestamp is the approving peer's time of receipt, in seconds
+	ReceiptTimestamp int64
+	// ClockSkew is the receipt time minus the sensor time, in seconds
+	ClockSkew int64
+	// SkewExceeded flags readings whose clock skew exceeds the configured maximum
+	SkewExceeded bool
+}
+
+// attestTimestamp compares the timestamp of the reading in envelope with the
+// receipt time. The skew is never flagged if maxSkew is zero.
+func attestTimestamp(envelope *cb.Envelope, receiptTimestamp int64, maxSkew time.Duration) (*TimestampAttestation, error) {
+	_, sensorTimestamp, err := protoutil.ExtractMetricsReadingFromEnvelope(envelope)
+	if err != nil {
+		return nil, reject(ReasonMalformedReading, "failed to extract reading timestamp: %s", err)
+	}
+
+	skew := receiptTimestamp - sensorTimestamp
+	absSkew := time.Duration(skew) * time.Second
+	if absSkew < 0 {
+		absSkew = -absSkew
+	}
+
+	return &TimestampAttestation{
+		SensorTimestamp:  sensorTimestamp,
+		ReceiptTimestamp: receiptTimestamp,
+		ClockSkew:        skew,
+		SkewExceeded:     maxSkew > 0 && absSkew > maxSkew,
+	}, nil
+}
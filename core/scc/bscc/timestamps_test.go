@@ -0,0 +1,74 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func readingEnvelope(t *testing.T, args ...string) *cb.Envelope {
+	input := &pb.ChaincodeInput{}
+	for _, arg := range args {
+		input.Args = append(input.Args, []byte(arg))
+	}
+
+	cisBytes, err := proto.Marshal(&pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "sensor_chaincode"},
+			Input:       input,
+		},
+	})
+	require.NoError(t, err)
+
+	cppBytes, err := proto.Marshal(&pb.ChaincodeProposalPayload{Input: cisBytes})
+	require.NoError(t, err)
+
+	capBytes, err := proto.Marshal(&pb.ChaincodeActionPayload{ChaincodeProposalPayload: cppBytes})
+	require.NoError(t, err)
+
+	txBytes, err := proto.Marshal(&pb.Transaction{Actions: []*pb.TransactionAction{{Payload: capBytes}}})
+	require.NoError(t, err)
+
+	payloadBytes, err := proto.Marshal(&cb.Payload{Header: &cb.Header{}, Data: txBytes})
+	require.NoError(t, err)
+
+	return &cb.Envelope{Payload: payloadBytes}
+}
+
+func TestAttestTimestamp(t *testing.T) {
+	envelope := readingEnvelope(t, "Set", "21.5", "0.4", "1628887200")
+
+	attestation, err := attestTimestamp(envelope, 1628887230, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, &TimestampAttestation{
+		SensorTimestamp:  1628887200,
+		ReceiptTimestamp: 1628887230,
+		ClockSkew:        30,
+	}, attestation)
+
+	// sensor clock ahead of the peer
+	attestation, err = attestTimestamp(envelope, 1628887100, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(-100), attestation.ClockSkew)
+	require.True(t, attestation.SkewExceeded)
+
+	// never flagged when disabled
+	attestation, err = attestTimestamp(envelope, 1628897200, 0)
+	require.NoError(t, err)
+	require.False(t, attestation.SkewExceeded)
+}
+
+func TestAttestTimestampMalformed(t *testing.T) {
+	_, err := attestTimestamp(readingEnvelope(t, "Set", "21.5", "0.4", "yesterday"), 1628887200, time.Minute)
+	require.IsType(t, &Rejection{}, err)
+	require.Equal(t, ReasonMalformedReading, err.(*Rejection).Reason)
+}
@@ -0,0 +1,191 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// IssueSensorToken issues, or rotates, the API token of the registered sensor
+// in args[0], the token being passed in the transient field. Tokens
+// authenticate the submissions of a sensor's gateway independently of MSP
+// certificates, so that compromised gateway credentials can be rotated
+// without re-enrolling the sensor. Only the hash of the token is stored.
+func (bscc *BSCC) IssueSensorToken(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	sensor, err := ownedSensor(stub, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	transient, err := stub.GetTransient()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transient data: %s", err))
+	}
+	token := transient[tokenTransientKey]
+	if len(token) == 0 {
+		return shim.Error(fmt.Sprintf("Token of sensor %s not specified in transient field '%s'", sensor.ID, tokenTransientKey))
+	}
+
+	sensor.TokenHash = tokenHash(token)
+	if err := storeSensor(stub, sensor); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
+// RevokeSensorToken revokes the API token of the registered sensor in
+// args[0]. The submissions of the sensor are refused until a token is issued
+// again.
+func (bscc *BSCC) RevokeSensorToken(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	sensor, err := ownedSensor(stub, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	sensor.TokenHash = ""
+	if err := storeSensor(stub, sensor); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(nil)
+}
+
+// AuthenticateSensor validates the API token passed in the transient field
+// against the token issued to the sensor in args[0], so that ingestion
+// services can refuse the readings of unauthenticated gateways before
+// submitting them for endorsement. args[1], if any, is the sequence number
+// of the submitted reading, which must be greater than the last one accepted
+// through the organization of the creator so that replayed submissions are
+// refused. It becomes the last accepted
+// sequence number once the invocation is committed.
+func (bscc *BSCC) AuthenticateSensor(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 1 || len(args[0]) == 0 {
+		return shim.Error("Sensor ID not specified")
+	}
+	sensor, err := loadSensor(stub, string(args[0]))
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if sensor == nil {
+		return shim.Error(fmt.Sprintf("Sensor %s is not registered", string(args[0])))
+	}
+	if sensor.Decommission != nil {
+		return shim.Error(fmt.Sprintf("Sensor %s is decommissioned", sensor.ID))
+	}
+
+	transient, err := stub.GetTransient()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transient data: %s", err))
+	}
+	if err := sensor.verifyToken(transient[tokenTransientKey]); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	if len(args) > 1 && len(args[1]) > 0 {
+		sequence, err := strconv.ParseUint(string(args[1]), 10, 64)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("Invalid sequence number '%s'", args[1]))
+		}
+		if err := acceptSequence(stub, sensor.ID, sequence); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
+	return shim.Success(nil)
+}
+
+// verifyToken returns an error unless token is the API token issued to the
+// sensor.
+func (s *Sensor) verifyToken(token []byte) error {
+	if s.TokenHash == "" {
+		return errors.Errorf("sensor %s has no token", s.ID)
+	}
+	if len(token) == 0 {
+		return errors.Errorf("token of sensor %s not specified", s.ID)
+	}
+	if subtle.ConstantTimeCompare([]byte(tokenHash(token)), []byte(s.TokenHash)) != 1 {
+		return errors.Errorf("invalid token for sensor %s", s.ID)
+	}
+	return nil
+}
+
+func tokenHash(token []byte) string {
+	hash := sha256.Sum256(token)
+	return hex.EncodeToString(hash[:])
+}
+
+// ownedSensor returns the registry entry of the sensor in args[0], which must
+// have been registered by the creator's organization.
+func ownedSensor(stub shim.ChaincodeStubInterface, args [][]byte) (*Sensor, error) {
+	if len(args) < 1 || len(args[0]) == 0 {
+		return nil, errors.New("Sensor ID not specified")
+	}
+	sensor, err := loadSensor(stub, string(args[0]))
+	if err != nil {
+		return nil, err
+	}
+	if sensor == nil {
+		return nil, errors.Errorf("Sensor %s is not registered", string(args[0]))
+	}
+
+	mspID, err := creatorMSPID(stub)
+	if err != nil {
+		return nil, err
+	}
+	if sensor.MSPID != mspID {
+		return nil, errors.Errorf("Sensor %s is registered by %s, not %s", sensor.ID, sensor.MSPID, mspID)
+	}
+
+	return sensor, nil
+}
+
+// sensorSequenceObjectType is the composite key object type of the last
+// sequence number accepted from each sensor, keyed by sensor ID and by the
+// MSP ID of the organization which submitted it.
+const sensorSequenceObjectType = "sensorSequence"
+
+// acceptSequence records sequence as the last sequence number accepted from
+// the sensor through the organization of the creator, unless it is not
+// greater than the last accepted one.
+func acceptSequence(stub shim.ChaincodeStubInterface, sensorID string, sequence uint64) error {
+	mspID, err := creatorMSPID(stub)
+	if err != nil {
+		return err
+	}
+	key, err := stub.CreateCompositeKey(sensorSequenceObjectType, []string{sensorID, mspID})
+	if err != nil {
+		return errors.WithMessage(err, "failed to create sequence key")
+	}
+
+	lastBytes, err := stub.GetState(key)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get last sequence number of sensor %s", sensorID)
+	}
+	if lastBytes != nil {
+		last, err := strconv.ParseUint(string(lastBytes), 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse last sequence number of sensor %s", sensorID)
+		}
+		if sequence <= last {
+			return errors.Errorf("replayed sequence number %d of sensor %s, last accepted is %d", sequence, sensorID, last)
+		}
+	}
+
+	if err := stub.PutState(key, []byte(strconv.FormatUint(sequence, 10))); err != nil {
+		return errors.WithMessagef(err, "failed to store sequence number of sensor %s", sensorID)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSensorTokens(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := &BSCC{}
+
+	invoke := func(txID, token string, f func() string) string {
+		stub.MockTransactionStart(txID)
+		defer stub.MockTransactionEnd(txID)
+		stub.TransientMap = map[string][]byte{}
+		if token != "" {
+			stub.TransientMap[tokenTransientKey] = []byte(token)
+		}
+		return f()
+	}
+	issue := func(txID, id, token string) string {
+		return invoke(txID, token, func() string { return bscc.IssueSensorToken(stub, [][]byte{[]byte(id)}).Message })
+	}
+	revoke := func(txID, id string) string {
+		return invoke(txID, "", func() string { return bscc.RevokeSensorToken(stub, [][]byte{[]byte(id)}).Message })
+	}
+	authenticate := func(id, token string) string {
+		return invoke("auth", token, func() string { return bscc.AuthenticateSensor(stub, [][]byte{[]byte(id)}).Message })
+	}
+
+	require.Equal(t, "Sensor sensor1 is not registered", issue("tx0", "sensor1", "t0k3n"))
+
+	stub.MockTransactionStart("tx1")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP"}))
+	stub.MockTransactionEnd("tx1")
+
+	require.Equal(t, "sensor sensor1 has no token", authenticate("sensor1", "t0k3n"))
+	require.Equal(t, "Token of sensor sensor1 not specified in transient field 'token'", issue("tx2", "sensor1", ""))
+	require.Empty(t, issue("tx3", "sensor1", "t0k3n"))
+	require.Empty(t, authenticate("sensor1", "t0k3n"))
+	require.Equal(t, "invalid token for sensor sensor1", authenticate("sensor1", "stolen"))
+	require.Equal(t, "token of sensor sensor1 not specified", authenticate("sensor1", ""))
+
+	// rotated tokens replace the previous ones
+	require.Empty(t, issue("tx4", "sensor1", "r0tated"))
+	require.Equal(t, "invalid token for sensor sensor1", authenticate("sensor1", "t0k3n"))
+	require.Empty(t, authenticate("sensor1", "r0tated"))
+
+	// replayed sequence numbers are refused
+	sequence := func(txID, token, seq string) string {
+		return invoke(txID, token, func() string {
+			return bscc.AuthenticateSensor(stub, [][]byte{[]byte("sensor1"), []byte(seq)}).Message
+		})
+	}
+	require.Empty(t, sequence("seq1", "r0tated", "1"))
+	require.Empty(t, sequence("seq2", "r0tated", "5"))
+	require.Equal(t, "replayed sequence number 5 of sensor sensor1, last accepted is 5", sequence("seq3", "r0tated", "5"))
+	require.Equal(t, "replayed sequence number 2 of sensor sensor1, last accepted is 5", sequence("seq4", "r0tated", "2"))
+	require.Equal(t, "Invalid sequence number 'next'", sequence("seq5", "r0tated", "next"))
+	require.Equal(t, "invalid token for sensor sensor1", sequence("seq6", "stolen", "6"))
+	require.Empty(t, sequence("seq7", "r0tated", "6"))
+
+	require.Empty(t, revoke("tx5", "sensor1"))
+	require.Equal(t, "sensor sensor1 has no token", authenticate("sensor1", "r0tated"))
+
+	// tokens are managed by the organization of the sensor only
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org2MSP"})
+	require.Equal(t, "Sensor sensor1 is registered by Org1MSP, not Org2MSP", issue("tx6", "sensor1", "t0k3n"))
+	require.Equal(t, "Sensor sensor1 is registered by Org1MSP, not Org2MSP", revoke("tx7", "sensor1"))
+}
@@ -0,0 +1,196 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/transform"
+	"github.com/pkg/errors"
+)
+
+// transformationObjectType is the composite key object type of the reading
+// transformations, keyed by the sensor type and the transformation version.
+// Version zero keys the latest version.
+const transformationObjectType = "transformation"
+
+// Transformation maps the output fields of the readings of a sensor type to
+// expressions over the fields of the raw readings, see package transform. The
+// latest version is applied to the readings as they are approved, the metric
+// index recording the version each metric was computed with.
+type Transformation struct {
+	SensorType string            `json:"sensorType"`
+	Version    uint64            `json:"version"`
+	Fields     map[string]string `json:"fields"`
+	MSPID      string            `json:"mspID"`
+	Timestamp  int64             `json:"timestamp"`
+	TxID       string            `json:"txID"`
+}
+
+// SetTransformation stores the JSON encoded transformation in args[0] as the
+// next version of the transformation of its sensor type. The expressions are
+// compiled beforehand so that invalid transformations are never stored.
+func (bscc *BSCC) SetTransformation(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	transformation := &Transformation{}
+	if err := json.Unmarshal(args[0], transformation); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to unmarshal transformation: %s", err))
+	}
+	if transformation.SensorType == "" {
+		return shim.Error("Sensor type not specified")
+	}
+	if _, err := transform.NewPipeline(transformation.Fields); err != nil {
+		return shim.Error(fmt.Sprintf("Invalid transformation of sensor type %s: %s", transformation.SensorType, err))
+	}
+
+	latest, err := loadTransformation(stub, transformation.SensorType, 0)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	mspID, err := creatorMSPID(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	timestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+	}
+
+	transformation.Version = 1
+	if latest != nil {
+		transformation.Version = latest.Version + 1
+	}
+	transformation.MSPID = mspID
+	transformation.Timestamp = timestamp.GetSeconds()
+	transformation.TxID = stub.GetTxID()
+
+	for _, version := range []uint64{transformation.Version, 0} {
+		if err := storeTransformation(stub, transformation, version); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
+	return shim.Success([]byte(strconv.FormatUint(transformation.Version, 10)))
+}
+
+// GetTransformation returns the JSON encoded transformation of the sensor
+// type in args[0], at the version in args[1] if any or the latest version.
+func (bscc *BSCC) GetTransformation(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	sensorType := string(args[0])
+
+	var version uint64
+	if len(args) > 1 && len(args[1]) > 0 {
+		var err error
+		if version, err = strconv.ParseUint(string(args[1]), 10, 64); err != nil || version == 0 {
+			return shim.Error(fmt.Sprintf("Invalid transformation version '%s'", args[1]))
+		}
+	}
+
+	transformation, err := loadTransformation(stub, sensorType, version)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if transformation == nil {
+		return shim.Error(fmt.Sprintf("No transformation of sensor type %s found", sensorType))
+	}
+
+	transformationBytes, err := json.Marshal(transformation)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal transformation: %s", err))
+	}
+
+	return shim.Success(transformationBytes)
+}
+
+// transformReading applies the latest transformation of the type of the
+// sensor to the metrics of its reading, returning the output metrics and the
+// version of the transformation, or the metrics unchanged and version zero
+// if the sensor type has no transformation. Readings the transformation
+// cannot be evaluated against, e.g. as they lack one of its fields, are left
+// unchanged as well.
+func transformReading(stub shim.ChaincodeStubInterface, sensorID string, metrics map[string]float64) (map[string]float64, uint64, error) {
+	sensor, err := loadSensor(stub, sensorID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if sensor == nil || sensor.Type == "" {
+		return metrics, 0, nil
+	}
+
+	transformation, err := loadTransformation(stub, sensor.Type, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+	if transformation == nil {
+		return metrics, 0, nil
+	}
+
+	pipeline, err := transform.NewPipeline(transformation.Fields)
+	if err != nil {
+		return nil, 0, errors.WithMessagef(err, "invalid transformation %d of sensor type %s", transformation.Version, sensor.Type)
+	}
+	transformed, err := pipeline.Apply(metrics)
+	if err != nil {
+		bloccProtoLogger.Warningf("Reading of sensor %s left untransformed, transformation %d of sensor type %s failed: %s", sensorID, transformation.Version, sensor.Type, err)
+		return metrics, 0, nil
+	}
+
+	return transformed, transformation.Version, nil
+}
+
+func transformationKey(stub shim.ChaincodeStubInterface, sensorType string, version uint64) (string, error) {
+	key, err := stub.CreateCompositeKey(transformationObjectType, []string{sensorType, strconv.FormatUint(version, 10)})
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to create transformation key")
+	}
+	return key, nil
+}
+
+// loadTransformation returns the given version of the transformation of the
+// sensor type, or nil if there is none.
+func loadTransformation(stub shim.ChaincodeStubInterface, sensorType string, version uint64) (*Transformation, error) {
+	key, err := transformationKey(stub, sensorType, version)
+	if err != nil {
+		return nil, err
+	}
+
+	transformationBytes, err := stub.GetState(key)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get transformation of sensor type %s", sensorType)
+	}
+	if transformationBytes == nil {
+		return nil, nil
+	}
+
+	transformation := &Transformation{}
+	if err := json.Unmarshal(transformationBytes, transformation); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal transformation of sensor type %s", sensorType)
+	}
+
+	return transformation, nil
+}
+
+func storeTransformation(stub shim.ChaincodeStubInterface, transformation *Transformation, version uint64) error {
+	key, err := transformationKey(stub, transformation.SensorType, version)
+	if err != nil {
+		return err
+	}
+
+	transformationBytes, err := marshalState(transformation)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal transformation")
+	}
+
+	if err := stub.PutState(key, transformationBytes); err != nil {
+		return errors.WithMessagef(err, "failed to store transformation of sensor type %s", transformation.SensorType)
+	}
+
+	return nil
+}
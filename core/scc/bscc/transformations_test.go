@@ -0,0 +1,66 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformationVersions(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := &BSCC{}
+
+	set := func(txID, transformation string) (string, string) {
+		stub.MockTransactionStart(txID)
+		defer stub.MockTransactionEnd(txID)
+		resp := bscc.SetTransformation(stub, [][]byte{[]byte(transformation)})
+		return string(resp.Payload), resp.Message
+	}
+	get := func(args ...string) *Transformation {
+		var byteArgs [][]byte
+		for _, arg := range args {
+			byteArgs = append(byteArgs, []byte(arg))
+		}
+		resp := bscc.GetTransformation(stub, byteArgs)
+		require.Equal(t, int32(200), resp.Status, resp.Message)
+		transformation := &Transformation{}
+		require.NoError(t, json.Unmarshal(resp.Payload, transformation))
+		return transformation
+	}
+
+	version, _ := set("tx1", `{"sensorType":"dht22","fields":{"temperature":"temperature"}}`)
+	require.Equal(t, "1", version)
+	version, _ = set("tx2", `{"sensorType":"dht22","fields":{"temperatureF":"temperature * 9 / 5 + 32"}}`)
+	require.Equal(t, "2", version)
+
+	latest := get("dht22")
+	require.Equal(t, uint64(2), latest.Version)
+	require.Equal(t, map[string]string{"temperatureF": "temperature * 9 / 5 + 32"}, latest.Fields)
+	require.Equal(t, "Org1MSP", latest.MSPID)
+	require.Equal(t, "tx2", latest.TxID)
+
+	first := get("dht22", "1")
+	require.Equal(t, uint64(1), first.Version)
+	require.Equal(t, map[string]string{"temperature": "temperature"}, first.Fields)
+
+	_, message := set("tx3", `{"sensorType":"dht22","fields":{"t":"temperature %"}}`)
+	require.Contains(t, message, "Invalid transformation of sensor type dht22: output field t: failed to parse expression")
+	require.Equal(t, uint64(2), get("dht22").Version)
+
+	_, message = set("tx4", `{"fields":{"t":"temperature"}}`)
+	require.Equal(t, "Sensor type not specified", message)
+
+	require.Equal(t, "No transformation of sensor type sht31 found", bscc.GetTransformation(stub, [][]byte{[]byte("sht31")}).Message)
+	require.Equal(t, "No transformation of sensor type dht22 found", bscc.GetTransformation(stub, [][]byte{[]byte("dht22"), []byte("3")}).Message)
+	require.Equal(t, "Invalid transformation version '0'", bscc.GetTransformation(stub, [][]byte{[]byte("dht22"), []byte("0")}).Message)
+}
@@ -0,0 +1,40 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
+)
+
+// applySensorChaincodes sets the sensor chaincodes whose readings are
+// approved. Invalid entries are skipped, and the default sensor chaincode is
+// kept if no entry is valid.
+func applySensorChaincodes(options config.Options) {
+	var chaincodes []sensorcc.Chaincode
+	for _, entry := range options.SensorChaincodes {
+		cc, err := sensorcc.Parse(entry)
+		if err != nil {
+			bloccProtoLogger.Warningf("Skipping sensor chaincode: %s", err)
+			continue
+		}
+		chaincodes = append(chaincodes, cc)
+	}
+	if len(chaincodes) == 0 {
+		chaincodes = []sensorcc.Chaincode{{Name: sensorcc.DefaultName}}
+	}
+
+	sensorcc.Default.Set(chaincodes)
+	bloccProtoLogger.Infof("Approving the readings of sensor chaincodes %v", chaincodes)
+}
+
+// countMigratedReading is the migration hook of the sensor chaincodes,
+// counting the readings of the chaincodes being migrated from so that
+// operators can tell when an old chaincode can be retired.
+func (s *BloccService) countMigratedReading(channelID, txID string, from sensorcc.Chaincode) {
+	bloccProtoLogger.Debugf("Reading %s on channel %s is from sensor chaincode %s being migrated from", txID, channelID, from)
+	s.metrics.MigratedReadings.With("channel", channelID, "chaincode", from.String()).Add(1)
+}
@@ -0,0 +1,24 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySensorChaincodes(t *testing.T) {
+	defer sensorcc.Default.Set([]sensorcc.Chaincode{{Name: sensorcc.DefaultName}})
+
+	applySensorChaincodes(config.Options{SensorChaincodes: []string{"sensor_green", ":1.0", "sensor_chaincode:1.0"}})
+	require.Equal(t, []sensorcc.Chaincode{{Name: "sensor_green"}, {Name: "sensor_chaincode", Version: "1.0"}}, sensorcc.Default.Chaincodes())
+
+	applySensorChaincodes(config.Options{})
+	require.Equal(t, []sensorcc.Chaincode{{Name: sensorcc.DefaultName}}, sensorcc.Default.Chaincodes())
+}
@@ -0,0 +1,113 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// validateReading checks the sensory transaction against the sensor registry
+// before it is approved and returns its envelope. Readings of unregistered
+// sensors are accepted as is. A *Rejection is returned if the reading must be
+// declined.
+func (bscc *BSCC) validateReading(stub shim.ChaincodeStubInterface, sensoryTxID string) (*cb.Envelope, error) {
+	channelID := stub.GetChannelID()
+
+	ledger := bscc.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return nil, bloccerrors.WithCategory(errors.Errorf("channel %s not found", channelID), bloccerrors.ErrChannelNotFound)
+	}
+
+	processedTx, err := ledger.GetTransactionByID(sensoryTxID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get sensory transaction %s", sensoryTxID)
+	}
+	if processedTx.ValidationCode != int32(pb.TxValidationCode_VALID) {
+		return nil, reject(ReasonInvalidTransaction, "sensory transaction was invalidated with code %s",
+			pb.TxValidationCode(processedTx.ValidationCode))
+	}
+	envelope := processedTx.GetTransactionEnvelope()
+
+	_, sensor, err := readingSensor(stub, envelope)
+	if err != nil {
+		return nil, err
+	}
+	if sensor != nil && sensor.Decommission != nil {
+		if err := checkDecommission(ledger, sensor, sensoryTxID); err != nil {
+			return nil, err
+		}
+	}
+	if sensor == nil || sensor.PairedWith == "" {
+		return envelope, nil
+	}
+
+	return envelope, bscc.validateCoSignature(channelID, sensor, envelope)
+}
+
+// readingSensor returns the ID of the sensor that created the reading in
+// envelope and its registry entry, nil if the sensor is not registered.
+func readingSensor(stub shim.ChaincodeStubInterface, envelope *cb.Envelope) (string, *Sensor, error) {
+	creator, err := protoutil.ExtractCreatorFromEnvelope(envelope)
+	if err != nil {
+		return "", nil, reject(ReasonMalformedReading, "failed to extract reading creator: %s", err)
+	}
+
+	id, err := sensorID(creator)
+	if err != nil {
+		return "", nil, reject(ReasonMalformedReading, "%s", err)
+	}
+
+	sensor, err := loadSensor(stub, id)
+	if err != nil {
+		return "", nil, err
+	}
+	return id, sensor, nil
+}
+
+// validateCoSignature checks that the reading carries a valid signature of
+// the sensor paired with its creator.
+func (bscc *BSCC) validateCoSignature(channelID string, sensor *Sensor, envelope *cb.Envelope) error {
+	message, coSigner, signature, err := protoutil.ExtractCoSignatureFromEnvelope(envelope)
+	if err != nil {
+		return reject(ReasonMalformedReading, "failed to extract reading co-signature: %s", err)
+	}
+	if coSigner == nil {
+		return reject(ReasonMissingCoSignature, "reading of sensor %s is not co-signed by paired sensor %s", sensor.ID, sensor.PairedWith)
+	}
+
+	coSignerID, err := sensorID(coSigner)
+	if err != nil {
+		return reject(ReasonMalformedReading, "%s", err)
+	}
+	if coSignerID != sensor.PairedWith {
+		return reject(ReasonPolicyViolation, "reading of sensor %s is co-signed by %s instead of paired sensor %s", sensor.ID, coSignerID, sensor.PairedWith)
+	}
+
+	mspManager := bscc.peerInfo.GetMSPManager(channelID)
+	if mspManager == nil {
+		return bloccerrors.WithCategory(errors.Errorf("channel %s not found", channelID), bloccerrors.ErrChannelNotFound)
+	}
+
+	identity, err := mspManager.DeserializeIdentity(coSigner)
+	if err != nil {
+		return reject(ReasonInvalidSignature, "failed to deserialize co-signer %s: %s", coSignerID, err)
+	}
+
+	if err := identity.Validate(); err != nil {
+		return reject(ReasonInvalidSignature, "co-signer %s is not valid: %s", coSignerID, err)
+	}
+
+	if err := identity.Verify(message, signature); err != nil {
+		return reject(ReasonInvalidSignature, "invalid co-signature of sensor %s: %s", coSignerID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,335 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+const (
+	// validationPolicyObjectType is the composite key object type of the
+	// validation policies, keyed by sensor type.
+	validationPolicyObjectType = "validationPolicy"
+	// lastReadingObjectType is the composite key object type of the last
+	// reading of a sensor approved by an organization, keyed by sensor ID
+	// and MSP ID, against which the rate of change of the next reading is
+	// checked.
+	lastReadingObjectType = "lastReading"
+)
+
+// MetricRange bounds the values of a metric. A nil bound is not checked.
+type MetricRange struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// ValidationPolicy holds the validation rules of the readings of a sensor
+// type, applied on approval on top of the sensor registry checks. Rules of
+// metrics a reading does not carry are not applied.
+type ValidationPolicy struct {
+	SensorType string `json:"sensorType"`
+	// Ranges bounds the values of the metrics, by metric name
+	Ranges map[string]MetricRange `json:"ranges,omitempty"`
+	// MaxRateOfChange bounds the change per second of the metrics, by metric
+	// name, between two readings of a sensor, e.g. a temperature jumping by
+	// 30°C in one second is rejected with a maximum of 1.
+	MaxRateOfChange map[string]float64 `json:"maxRateOfChange,omitempty"`
+	// AllowedFirmwareVersions, if any, are the firmware versions the sensors
+	// may run, as attested at the time of their readings. The readings of
+	// sensors without attested firmware are rejected.
+	AllowedFirmwareVersions []string `json:"allowedFirmwareVersions,omitempty"`
+	MSPID                   string   `json:"mspID"`
+	Timestamp               int64    `json:"timestamp"`
+	TxID                    string   `json:"txID"`
+}
+
+// lastReading is the last reading of a sensor approved by an organization.
+type lastReading struct {
+	SensorID  string             `json:"sensorID"`
+	TxID      string             `json:"txID"`
+	Timestamp int64              `json:"timestamp"`
+	Metrics   map[string]float64 `json:"metrics"`
+}
+
+// SetValidationPolicy stores the JSON encoded validation policy in args[0]
+// as the policy of its sensor type.
+func (bscc *BSCC) SetValidationPolicy(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 1 {
+		return shim.Error("Validation policy not specified")
+	}
+	policy := &ValidationPolicy{}
+	if err := json.Unmarshal(args[0], policy); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to unmarshal validation policy: %s", err))
+	}
+	if policy.SensorType == "" {
+		return shim.Error("Sensor type not specified")
+	}
+	for metric, r := range policy.Ranges {
+		if metric == "" {
+			return shim.Error(fmt.Sprintf("Range without metric name in validation policy of sensor type %s", policy.SensorType))
+		}
+		if r.Min != nil && r.Max != nil && *r.Min > *r.Max {
+			return shim.Error(fmt.Sprintf("Invalid range of %s, minimum %g is above maximum %g", metric, *r.Min, *r.Max))
+		}
+	}
+	for metric, rate := range policy.MaxRateOfChange {
+		if metric == "" {
+			return shim.Error(fmt.Sprintf("Rate of change without metric name in validation policy of sensor type %s", policy.SensorType))
+		}
+		if rate <= 0 || math.IsNaN(rate) {
+			return shim.Error(fmt.Sprintf("Invalid maximum rate of change %g of %s, must be positive", rate, metric))
+		}
+	}
+	for _, version := range policy.AllowedFirmwareVersions {
+		if version == "" {
+			return shim.Error(fmt.Sprintf("Empty firmware version in validation policy of sensor type %s", policy.SensorType))
+		}
+	}
+
+	mspID, err := creatorMSPID(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	timestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transaction timestamp: %s", err))
+	}
+	policy.MSPID = mspID
+	policy.Timestamp = timestamp.GetSeconds()
+	policy.TxID = stub.GetTxID()
+
+	key, err := stub.CreateCompositeKey(validationPolicyObjectType, []string{policy.SensorType})
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to create validation policy key: %s", err))
+	}
+	policyBytes, err := marshalState(policy)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal validation policy: %s", err))
+	}
+	if err := stub.PutState(key, policyBytes); err != nil {
+		return shim.Error(fmt.Sprintf("Failed to store validation policy of sensor type %s: %s", policy.SensorType, err))
+	}
+
+	return shim.Success(nil)
+}
+
+// GetValidationPolicy returns the JSON encoded validation policy of the
+// sensor type in args[0].
+func (bscc *BSCC) GetValidationPolicy(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 1 || len(args[0]) == 0 {
+		return shim.Error("Sensor type not specified")
+	}
+	sensorType := string(args[0])
+
+	policy, err := loadValidationPolicy(stub, sensorType)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if policy == nil {
+		return shim.Error(fmt.Sprintf("No validation policy of sensor type %s found", sensorType))
+	}
+
+	policyBytes, err := json.Marshal(policy)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal validation policy: %s", err))
+	}
+
+	return shim.Success(policyBytes)
+}
+
+// loadValidationPolicy returns the validation policy of the sensor type, or
+// nil if there is none.
+func loadValidationPolicy(stub shim.ChaincodeStubInterface, sensorType string) (*ValidationPolicy, error) {
+	key, err := stub.CreateCompositeKey(validationPolicyObjectType, []string{sensorType})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create validation policy key")
+	}
+
+	policyBytes, err := stub.GetState(key)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get validation policy of sensor type %s", sensorType)
+	}
+	if policyBytes == nil {
+		return nil, nil
+	}
+
+	policy := &ValidationPolicy{}
+	if err := json.Unmarshal(policyBytes, policy); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal validation policy of sensor type %s", sensorType)
+	}
+
+	return policy, nil
+}
+
+// checkValidationPolicy rejects the reading if one of its metrics is out of
+// the range allowed by the validation policy of the sensor type, or changed
+// faster than allowed since the last reading of the sensor approved by the
+// organization mspID, or if the sensor ran a firmware not allowed by the
+// policy. The reading to record as the last one once approved is
+// returned, nil if none.
+func checkValidationPolicy(stub shim.ChaincodeStubInterface, sensoryTxID, mspID string, envelope *cb.Envelope) (*lastReading, error) {
+	creator, err := protoutil.ExtractCreatorFromEnvelope(envelope)
+	if err != nil {
+		return nil, reject(ReasonMalformedReading, "failed to extract reading creator: %s", err)
+	}
+	id, err := sensorID(creator)
+	if err != nil {
+		return nil, reject(ReasonMalformedReading, "%s", err)
+	}
+
+	return checkSensorValidationPolicy(stub, sensoryTxID, mspID, id, envelope)
+}
+
+func checkSensorValidationPolicy(stub shim.ChaincodeStubInterface, sensoryTxID, mspID, id string, envelope *cb.Envelope) (*lastReading, error) {
+	sensor, err := loadSensor(stub, id)
+	if err != nil {
+		return nil, err
+	}
+	if sensor == nil || sensor.Type == "" {
+		return nil, nil
+	}
+
+	policy, err := loadValidationPolicy(stub, sensor.Type)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return nil, nil
+	}
+
+	metrics, timestamp, err := protoutil.ExtractMetricsReadingFromEnvelope(envelope)
+	if err != nil {
+		return nil, reject(ReasonMalformedReading, "failed to extract reading: %s", err)
+	}
+	if len(policy.AllowedFirmwareVersions) > 0 {
+		if err := checkFirmware(stub, sensor.ID, timestamp, policy.AllowedFirmwareVersions); err != nil {
+			return nil, err
+		}
+	}
+
+	names := metricNames(metrics)
+	for _, metric := range names {
+		value := metrics[metric]
+		r, ok := policy.Ranges[metric]
+		if !ok {
+			continue
+		}
+		if r.Min != nil && value < *r.Min {
+			return nil, reject(ReasonMetricOutOfRange, "%s of sensor %s is %g, below the minimum of %g", metric, sensor.ID, value, *r.Min)
+		}
+		if r.Max != nil && value > *r.Max {
+			return nil, reject(ReasonMetricOutOfRange, "%s of sensor %s is %g, above the maximum of %g", metric, sensor.ID, value, *r.Max)
+		}
+	}
+
+	if len(policy.MaxRateOfChange) == 0 {
+		return nil, nil
+	}
+	current := &lastReading{
+		SensorID:  sensor.ID,
+		TxID:      sensoryTxID,
+		Timestamp: timestamp,
+		Metrics:   metrics,
+	}
+
+	previous, err := loadLastReading(stub, sensor.ID, mspID)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return current, nil
+	}
+	if timestamp < previous.Timestamp {
+		// readings approved out of order are not checked against newer ones
+		return nil, nil
+	}
+
+	elapsed := float64(timestamp - previous.Timestamp)
+	for _, metric := range names {
+		max, ok := policy.MaxRateOfChange[metric]
+		previousValue, previousOK := previous.Metrics[metric]
+		if !ok || !previousOK {
+			continue
+		}
+		change := math.Abs(metrics[metric] - previousValue)
+		if change == 0 {
+			continue
+		}
+		if elapsed == 0 || change/elapsed > max {
+			return nil, reject(ReasonRateOfChangeExceeded, "%s of sensor %s changed by %g in %gs since reading %s, above the maximum of %g/s",
+				metric, sensor.ID, change, elapsed, previous.TxID, max)
+		}
+	}
+
+	return current, nil
+}
+
+// metricNames returns the sorted names of the metrics.
+func metricNames(metrics map[string]float64) []string {
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lastReadingKey(stub shim.ChaincodeStubInterface, sensorID, mspID string) (string, error) {
+	key, err := stub.CreateCompositeKey(lastReadingObjectType, []string{sensorID, mspID})
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to create last reading key")
+	}
+	return key, nil
+}
+
+func loadLastReading(stub shim.ChaincodeStubInterface, sensorID, mspID string) (*lastReading, error) {
+	key, err := lastReadingKey(stub, sensorID, mspID)
+	if err != nil {
+		return nil, err
+	}
+
+	readingBytes, err := stub.GetState(key)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get last reading of sensor %s", sensorID)
+	}
+	if readingBytes == nil {
+		return nil, nil
+	}
+
+	reading := &lastReading{}
+	if err := json.Unmarshal(readingBytes, reading); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal last reading of sensor %s", sensorID)
+	}
+
+	return reading, nil
+}
+
+func storeLastReading(stub shim.ChaincodeStubInterface, mspID string, reading *lastReading) error {
+	key, err := lastReadingKey(stub, reading.SensorID, mspID)
+	if err != nil {
+		return err
+	}
+
+	readingBytes, err := marshalState(reading)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal last reading")
+	}
+
+	if err := stub.PutState(key, readingBytes); err != nil {
+		return errors.WithMessagef(err, "failed to store last reading of sensor %s", reading.SensorID)
+	}
+
+	return nil
+}
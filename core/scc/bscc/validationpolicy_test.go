@@ -0,0 +1,119 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationPolicy(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.Creator = protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	bscc := &BSCC{}
+
+	set := func(txID, policy string) string {
+		stub.MockTransactionStart(txID)
+		defer stub.MockTransactionEnd(txID)
+		return bscc.SetValidationPolicy(stub, [][]byte{[]byte(policy)}).Message
+	}
+
+	require.Equal(t, "Sensor type not specified", set("tx1", `{"maxRateOfChange":{"temperature":1}}`))
+	require.Equal(t, "Rate of change without metric name in validation policy of sensor type dht22", set("tx1", `{"sensorType":"dht22","maxRateOfChange":{"":1}}`))
+	require.Equal(t, "Invalid range of co2, minimum 5000 is above maximum 400", set("tx1", `{"sensorType":"dht22","ranges":{"co2":{"min":5000,"max":400}}}`))
+	require.Equal(t, "Invalid maximum rate of change -1 of temperature, must be positive", set("tx1", `{"sensorType":"dht22","maxRateOfChange":{"temperature":-1}}`))
+	require.Equal(t, "Empty firmware version in validation policy of sensor type dht22", set("tx1", `{"sensorType":"dht22","allowedFirmwareVersions":[""]}`))
+	require.Empty(t, set("tx1", `{"sensorType":"dht22","maxRateOfChange":{"temperature":0.5}}`))
+
+	resp := bscc.GetValidationPolicy(stub, [][]byte{[]byte("dht22")})
+	require.Empty(t, resp.Message)
+	policy := &ValidationPolicy{}
+	require.NoError(t, json.Unmarshal(resp.Payload, policy))
+	require.Equal(t, map[string]float64{"temperature": 0.5}, policy.MaxRateOfChange)
+	require.Equal(t, "Org1MSP", policy.MSPID)
+	require.Equal(t, "tx1", policy.TxID)
+
+	require.Equal(t, "No validation policy of sensor type bme280 found", bscc.GetValidationPolicy(stub, [][]byte{[]byte("bme280")}).Message)
+}
+
+func TestCheckValidationPolicy(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+
+	stub.MockTransactionStart("setup")
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor1", MSPID: "Org1MSP", Type: "dht22"}))
+	require.NoError(t, storeSensor(stub, &Sensor{DocType: sensorObjectType, ID: "sensor2", MSPID: "Org1MSP"}))
+	maxCO2 := 5000.0
+	policyBytes, err := marshalState(&ValidationPolicy{
+		SensorType:      "dht22",
+		Ranges:          map[string]MetricRange{"co2": {Max: &maxCO2}},
+		MaxRateOfChange: map[string]float64{"temperature": 0.5},
+	})
+	require.NoError(t, err)
+	key, err := stub.CreateCompositeKey(validationPolicyObjectType, []string{"dht22"})
+	require.NoError(t, err)
+	require.NoError(t, stub.PutState(key, policyBytes))
+	stub.MockTransactionEnd("setup")
+
+	check := func(txID, sensor string, args ...string) (*lastReading, error) {
+		stub.MockTransactionStart(txID)
+		defer stub.MockTransactionEnd(txID)
+		last, err := checkSensorValidationPolicy(stub, txID, "Org1MSP", sensor, readingEnvelope(t, args...))
+		if err == nil && last != nil {
+			require.NoError(t, storeLastReading(stub, "Org1MSP", last))
+		}
+		return last, err
+	}
+
+	// sensors without a type or policy are not checked
+	last, err := check("tx0", "sensor2", "Set", "21.5", "0.4", "1628887200")
+	require.NoError(t, err)
+	require.Nil(t, last)
+
+	// the first reading is only recorded
+	last, err = check("tx1", "sensor1", "Set", "21.5", "0.4", "1628887200")
+	require.NoError(t, err)
+	require.Equal(t, &lastReading{
+		SensorID:  "sensor1",
+		TxID:      "tx1",
+		Timestamp: 1628887200,
+		Metrics:   map[string]float64{"temperature": 21.5, "relativeHumidity": 0.4},
+	}, last)
+
+	// 5°C in 20s is within 0.5°C/s, humidity is not bounded
+	_, err = check("tx2", "sensor1", "Set", "26.5", "0.9", "1628887220")
+	require.NoError(t, err)
+
+	// 30°C in 1s is rejected
+	_, err = check("tx3", "sensor1", "Set", "56.5", "0.9", "1628887221")
+	require.IsType(t, &Rejection{}, err)
+	require.Equal(t, ReasonRateOfChangeExceeded, err.(*Rejection).Reason)
+	require.Contains(t, err.Error(), "temperature of sensor sensor1 changed by 30 in 1s since reading tx2")
+
+	// readings of other organizations are checked separately
+	stub.MockTransactionStart("tx4")
+	last, err = checkSensorValidationPolicy(stub, "tx4", "Org2MSP", "sensor1", readingEnvelope(t, "Set", "56.5", "0.9", "1628887221"))
+	stub.MockTransactionEnd("tx4")
+	require.NoError(t, err)
+	require.NotNil(t, last)
+
+	// metrics of composite readings are checked one by one
+	_, err = check("tx6", "sensor1", "Set", `{"temperature":27,"co2":415}`, "", "1628887230")
+	require.NoError(t, err)
+	_, err = check("tx7", "sensor1", "Set", `{"co2":6000}`, "", "1628887240")
+	require.IsType(t, &Rejection{}, err)
+	require.Equal(t, ReasonMetricOutOfRange, err.(*Rejection).Reason)
+	require.Contains(t, err.Error(), "co2 of sensor sensor1 is 6000, above the maximum of 5000")
+
+	// older readings are not checked against newer ones
+	last, err = check("tx5", "sensor1", "Set", "90", "0.9", "1628887100")
+	require.NoError(t, err)
+	require.Nil(t, last)
+}
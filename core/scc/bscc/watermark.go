@@ -0,0 +1,272 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sensorcc"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// ApprovalWatermark is the highest block of a channel up to which every
+// valid sensory reading is approved by at least Threshold organizations, so
+// that the readings up to it can be consumed as final.
+type ApprovalWatermark struct {
+	ChannelID string `json:"channelID"`
+	// BlockNum is the number of the watermark block. A reading of the block
+	// after it is approved by fewer than Threshold organizations.
+	BlockNum uint64 `json:"blockNum"`
+	// Height is the height of the channel on this peer
+	Height uint64 `json:"height"`
+	// Threshold is the dead-letter approval threshold of the peer
+	Threshold int `json:"threshold"`
+}
+
+// watermarkSource is the subset of the ledger needed to compute approval
+// watermarks.
+type watermarkSource interface {
+	GetBlockchainInfo() (*cb.BlockchainInfo, error)
+	GetBlockByNumber(blockNumber uint64) (*cb.Block, error)
+}
+
+type watermarkKey struct {
+	channelID string
+	threshold int
+}
+
+// approvalWatermarks caches the approval watermarks of the channels. The
+// approval records are never deleted, so a watermark only moves forward and
+// the blocks up to it need not be scanned again.
+type approvalWatermarks struct {
+	mutex sync.Mutex
+	// next is the first block not known to have all its readings approved,
+	// by channel and threshold
+	next map[watermarkKey]uint64
+}
+
+func newApprovalWatermarks() *approvalWatermarks {
+	return &approvalWatermarks{next: map[watermarkKey]uint64{}}
+}
+
+func (w *approvalWatermarks) get(key watermarkKey) uint64 {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.next[key]
+}
+
+func (w *approvalWatermarks) advance(key watermarkKey, next uint64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if next > w.next[key] {
+		w.next[key] = next
+	}
+}
+
+// GetApprovalWatermark returns the JSON encoded ApprovalWatermark of the
+// channel, the approval threshold being the dead-letter threshold of the
+// peer. The blocks before the snapshot the ledger was bootstrapped from, if
+// any, are below the watermark.
+func (bscc *BSCC) GetApprovalWatermark(stub shim.ChaincodeStubInterface, channelID string) pb.Response {
+	if channelID == "" {
+		return shim.Error("ChannelID not specified")
+	}
+
+	ledger := bscc.peerInfo.GetLedger(channelID)
+	if ledger == nil {
+		return shim.Error(fmt.Sprintf("channel %s not found", channelID))
+	}
+
+	// the approvals of the channel of the proposal are read through its
+	// simulator: the simulator holds the commit lock of the ledger, so a
+	// query executor of the same ledger would wait behind a commit itself
+	// waiting for the simulator to be done
+	approvals := func(sensoryTxID string) (int, error) {
+		return countApprovals(stub, sensoryTxID)
+	}
+	if stub.GetChannelID() != channelID {
+		approvals = ledgerApprovals(ledger)
+	}
+
+	key := watermarkKey{channelID: channelID, threshold: bscc.currentOptions().DeadLetterThreshold}
+	watermark, err := approvalWatermark(ledger, approvals, bscc.watermarks.get(key), key.threshold)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to compute the approval watermark of channel %s: %s", channelID, err))
+	}
+	bscc.watermarks.advance(key, watermark.BlockNum+1)
+	watermark.ChannelID = channelID
+
+	watermarkBytes, err := json.Marshal(watermark)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to marshal approval watermark: %s", err))
+	}
+
+	return shim.Success(watermarkBytes)
+}
+
+// countApprovals counts the approval records of the reading in the state of
+// the stub.
+func countApprovals(stub shim.ChaincodeStubInterface, sensoryTxID string) (int, error) {
+	iterator, err := stub.GetStateByPartialCompositeKey(approvalObjectType, []string{sensoryTxID})
+	if err != nil {
+		return 0, errors.WithMessagef(err, "failed to query the approvals of reading %s", sensoryTxID)
+	}
+	defer iterator.Close()
+
+	count := 0
+	for iterator.HasNext() {
+		if _, err := iterator.Next(); err != nil {
+			return 0, errors.WithMessagef(err, "failed to query the approvals of reading %s", sensoryTxID)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// ledgerApprovals counts the approval records of the readings of a channel
+// other than the one of the proposal, whose commits the simulator of the
+// proposal does not hold back. A query executor holds back the commits until
+// it is done, so one is created per reading rather than for the whole scan.
+func ledgerApprovals(peerLedger ledger.PeerLedger) func(sensoryTxID string) (int, error) {
+	return func(sensoryTxID string) (int, error) {
+		startKey, err := shim.CreateCompositeKey(approvalObjectType, []string{sensoryTxID})
+		if err != nil {
+			return 0, errors.WithMessage(err, "failed to create approval key")
+		}
+		qe, err := peerLedger.NewQueryExecutor()
+		if err != nil {
+			return 0, errors.WithMessage(err, "failed to create query executor")
+		}
+		defer qe.Done()
+		iterator, err := qe.GetStateRangeScanIterator("bscc", startKey, startKey+string(utf8.MaxRune))
+		if err != nil {
+			return 0, errors.WithMessagef(err, "failed to query the approvals of reading %s", sensoryTxID)
+		}
+		defer iterator.Close()
+
+		count := 0
+		for {
+			result, err := iterator.Next()
+			if err != nil {
+				return 0, errors.WithMessagef(err, "failed to query the approvals of reading %s", sensoryTxID)
+			}
+			if result == nil {
+				return count, nil
+			}
+			count++
+		}
+	}
+}
+
+// approvalWatermark scans the blocks of the source from block next, the
+// first block not known to have all its readings approved, up to the first
+// block holding a reading with fewer than threshold approvals, as counted by
+// approvals.
+func approvalWatermark(source watermarkSource, approvals func(sensoryTxID string) (int, error), next uint64, threshold int) (*ApprovalWatermark, error) {
+	info, err := source.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get blockchain info")
+	}
+	// the genesis block holds no readings
+	if next == 0 {
+		next = 1
+	}
+	if snapshotInfo := info.GetBootstrappingSnapshotInfo(); snapshotInfo != nil && next <= snapshotInfo.LastBlockInSnapshot {
+		next = snapshotInfo.LastBlockInSnapshot + 1
+	}
+
+	watermark := &ApprovalWatermark{Height: info.Height, Threshold: threshold}
+	for ; next < info.Height; next++ {
+		block, err := source.GetBlockByNumber(next)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get block %d", next)
+		}
+		approved, err := blockApproved(block, approvals, threshold)
+		if err != nil {
+			return nil, err
+		}
+		if !approved {
+			break
+		}
+	}
+	watermark.BlockNum = next - 1
+
+	return watermark, nil
+}
+
+// blockApproved tells whether the valid sensory readings of the block are
+// all approved by threshold organizations.
+func blockApproved(block *cb.Block, approvals func(sensoryTxID string) (int, error), threshold int) (bool, error) {
+	if threshold <= 0 {
+		return true, nil
+	}
+
+	for _, reading := range blockReadings(block) {
+		count, err := approvals(reading.txID)
+		if err != nil {
+			return false, err
+		}
+		if count < threshold {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// blockReading is a valid sensory reading committed in a block.
+type blockReading struct {
+	txID     string
+	envelope *cb.Envelope
+}
+
+// blockReadings returns the valid transactions of the block that invoke a
+// sensor chaincode.
+func blockReadings(block *cb.Block) []blockReading {
+	var flags txflags.ValidationFlags
+	if len(block.GetMetadata().GetMetadata()) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = txflags.ValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var readings []blockReading
+	for i, data := range block.GetData().GetData() {
+		if len(flags) > i && flags.Flag(i) != pb.TxValidationCode_VALID {
+			continue
+		}
+		env, err := protoutil.GetEnvelopeFromBlock(data)
+		if err != nil {
+			continue
+		}
+		chdr, err := protoutil.ChannelHeader(env)
+		if err != nil || cb.HeaderType(chdr.Type) != cb.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		cis, err := protoutil.ExtractChaincodeInvocationSpec(data)
+		if err != nil {
+			continue
+		}
+		action, err := protoutil.GetActionFromEnvelopeMsg(env)
+		if err != nil {
+			continue
+		}
+		if _, ok := sensorcc.Default.Match(cis.GetChaincodeSpec().GetChaincodeId().GetName(), action.GetChaincodeId().GetVersion()); !ok {
+			continue
+		}
+
+		readings = append(readings, blockReading{txID: chdr.TxId, envelope: env})
+	}
+
+	return readings
+}
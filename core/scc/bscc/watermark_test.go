@@ -0,0 +1,115 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWatermarkSource struct {
+	fakeBlockSource
+	snapshotInfo *cb.BootstrappingSnapshotInfo
+}
+
+func (f *fakeWatermarkSource) GetBlockchainInfo() (*cb.BlockchainInfo, error) {
+	return &cb.BlockchainInfo{Height: uint64(len(f.fakeBlockSource)), BootstrappingSnapshotInfo: f.snapshotInfo}, nil
+}
+
+// sensoryReadingBlock returns a block of the sensory readings of chaincode
+// sensor_chaincode txIDs, the invalid ones being flagged as such.
+func sensoryReadingBlock(number uint64, txIDs []string, invalid map[string]bool) *cb.Block {
+	block := protoutil.NewBlock(number, nil)
+	flags := make([]byte, len(txIDs))
+	for i, txID := range txIDs {
+		env := sensoryTransaction("sensor_chaincode", []string{"Set", "21.5", "0.4", "1628887200"}, nil).TransactionEnvelope
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			panic(err)
+		}
+		payload.Header.ChannelHeader = protoutil.MarshalOrPanic(&cb.ChannelHeader{
+			Type: int32(cb.HeaderType_ENDORSER_TRANSACTION),
+			TxId: txID,
+		})
+		env.Payload = protoutil.MarshalOrPanic(payload)
+		block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(env))
+		flags[i] = byte(pb.TxValidationCode_VALID)
+		if invalid[txID] {
+			flags[i] = byte(pb.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+		}
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+	return block
+}
+
+func TestApprovalWatermark(t *testing.T) {
+	source := &fakeWatermarkSource{fakeBlockSource: fakeBlockSource{
+		protoutil.NewBlock(0, nil),
+		sensoryReadingBlock(1, []string{"tx1", "tx2"}, nil),
+		sensoryReadingBlock(2, []string{"tx3", "tx4"}, map[string]bool{"tx4": true}),
+		sensoryReadingBlock(3, []string{"tx5"}, nil),
+		sensoryReadingBlock(4, []string{"tx6"}, nil),
+	}}
+	counts := map[string]int{"tx1": 2, "tx2": 1, "tx3": 1, "tx6": 1}
+	var counted []string
+	approvals := func(sensoryTxID string) (int, error) {
+		counted = append(counted, sensoryTxID)
+		return counts[sensoryTxID], nil
+	}
+
+	// the invalid readings are left out, tx5 holding the watermark back
+	watermark, err := approvalWatermark(source, approvals, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, &ApprovalWatermark{BlockNum: 2, Height: 5, Threshold: 1}, watermark)
+	require.Equal(t, []string{"tx1", "tx2", "tx3", "tx5"}, counted)
+
+	// the blocks up to the previous watermark are not scanned again
+	counts["tx5"] = 1
+	counted = nil
+	watermark, err = approvalWatermark(source, approvals, 3, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), watermark.BlockNum)
+	require.Equal(t, []string{"tx5", "tx6"}, counted)
+
+	watermark, err = approvalWatermark(source, approvals, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), watermark.BlockNum)
+
+	// a zero threshold puts the watermark at the tip
+	watermark, err = approvalWatermark(source, approvals, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), watermark.BlockNum)
+
+	// the blocks before the bootstrapping snapshot are below the watermark
+	source.snapshotInfo = &cb.BootstrappingSnapshotInfo{LastBlockInSnapshot: 3}
+	counts["tx6"] = 0
+	watermark, err = approvalWatermark(source, approvals, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), watermark.BlockNum)
+}
+
+func TestCountApprovals(t *testing.T) {
+	stub := shimtest.NewMockStub("bscc", nil)
+	stub.MockTransactionStart("tx")
+	for _, key := range [][]string{{"tx1", "Org1MSP"}, {"tx1", "Org2MSP"}, {"tx2", "Org1MSP"}} {
+		approvalKey, err := stub.CreateCompositeKey(approvalObjectType, key)
+		require.NoError(t, err)
+		require.NoError(t, stub.PutState(approvalKey, []byte("approved")))
+	}
+	stub.MockTransactionEnd("tx")
+
+	count, err := countApprovals(stub, "tx1")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	count, err = countApprovals(stub, "tx3")
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+}
@@ -0,0 +1,145 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	// webhookEventHeader names the type of the posted event
+	webhookEventHeader = "X-Blocc-Event"
+	// webhookSignatureHeader carries the hex encoded HMAC-SHA256 of the
+	// payload, keyed with the endpoint's secret
+	webhookSignatureHeader = "X-Blocc-Signature"
+)
+
+// webhookDispatcher posts the events of the BLOCC event bus to the webhook
+// endpoints configured on the peer, retrying failed deliveries with an
+// exponential backoff.
+type webhookDispatcher struct {
+	client  *http.Client
+	metrics *Metrics
+	options func() config.Options
+	clock   clock.Clock
+}
+
+func newWebhookDispatcher(metrics *Metrics, options func() config.Options, clk clock.Clock) *webhookDispatcher {
+	return &webhookDispatcher{
+		client:  &http.Client{},
+		metrics: metrics,
+		options: options,
+		clock:   clk,
+	}
+}
+
+// serve dispatches the events received on events. Approval requests and
+// digests are internal to the peer and are not dispatched.
+func (d *webhookDispatcher) serve(events <-chan event.Event) {
+	for e := range events {
+		if e.Type == event.ApprovalRequest || e.Type == event.ApprovalDigestReceived {
+			continue
+		}
+		d.dispatch(e)
+	}
+}
+
+func (d *webhookDispatcher) dispatch(e event.Event) {
+	options := d.options()
+	if len(options.Webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(newEventPayload(e, d.clock.Now()))
+	if err != nil {
+		bloccProtoLogger.Errorf("Failed to marshal webhook payload: %s", err)
+		return
+	}
+
+	for _, endpoint := range options.Webhooks {
+		if !endpoint.Accepts(e.Type.String()) {
+			continue
+		}
+		go d.deliver(endpoint, e.Type.String(), payload, options)
+	}
+}
+
+// deliver posts the payload to the endpoint, retrying up to the configured
+// number of times unless the endpoint refuses the payload.
+func (d *webhookDispatcher) deliver(endpoint config.WebhookEndpoint, eventType string, payload []byte, options config.Options) error {
+	backoff := options.WebhookRetryBackoff
+	var err error
+	for attempt := 0; attempt <= options.WebhookMaxRetries; attempt++ {
+		if attempt > 0 {
+			d.metrics.WebhookRetries.With("endpoint", endpoint.URL).Add(1)
+			d.clock.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var retry bool
+		retry, err = d.post(endpoint, eventType, payload, options.WebhookTimeout)
+		if err == nil {
+			d.metrics.WebhookDeliveries.With("endpoint", endpoint.URL, "status", "delivered").Add(1)
+			return nil
+		}
+		if !retry {
+			break
+		}
+	}
+
+	d.metrics.WebhookDeliveries.With("endpoint", endpoint.URL, "status", "failed").Add(1)
+	bloccProtoLogger.Warningf("Failed to deliver %s event to webhook %s: %s", eventType, endpoint.URL, err)
+	return err
+}
+
+// post sends a single delivery attempt and returns whether a failed attempt
+// may be retried.
+func (d *webhookDispatcher) post(endpoint config.WebhookEndpoint, eventType string, payload []byte, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, eventType)
+	if endpoint.Secret != "" {
+		req.Header.Set(webhookSignatureHeader, signWebhookPayload(endpoint.Secret, payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to post event")
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	// the endpoint refuses the payload itself, unless it is throttling
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, errors.Errorf("endpoint responded with status %d", resp.StatusCode)
+}
+
+// signWebhookPayload returns the hex encoded HMAC-SHA256 of the payload.
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,120 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bscc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	event "github.com/hyperledger/fabric/common/blocc-events"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookRecorder struct {
+	mutex    sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *webhookRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	body, _ := ioutil.ReadAll(req.Body)
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestWebhookDispatcher(options config.Options) (*webhookDispatcher, *sleepRecorder) {
+	clock := newSleepRecorder()
+	return newWebhookDispatcher(NewMetrics(&disabled.Provider{}), func() config.Options { return options }, clock), clock
+}
+
+func TestWebhookDeliver(t *testing.T) {
+	recorder := &webhookRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	options := config.Options{WebhookTimeout: time.Second}
+	d, _ := newTestWebhookDispatcher(options)
+	endpoint := config.WebhookEndpoint{URL: server.URL, Secret: "s3cret"}
+
+	payload, err := json.Marshal(newEventPayload(event.Event{Type: event.ForkStatusChanged, ChannelID: "mychannel"}, d.clock.Now()))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"ForkStatusChanged","channelID":"mychannel","timestamp":1700000000,"forked":false}`, string(payload))
+
+	require.NoError(t, d.deliver(endpoint, "ForkStatusChanged", payload, options))
+	require.Len(t, recorder.requests, 1)
+	require.Equal(t, payload, recorder.bodies[0])
+	require.Equal(t, "ForkStatusChanged", recorder.requests[0].Header.Get(webhookEventHeader))
+	require.Equal(t, signWebhookPayload("s3cret", payload), recorder.requests[0].Header.Get(webhookSignatureHeader))
+}
+
+func TestWebhookDeliverRetries(t *testing.T) {
+	recorder := &webhookRecorder{statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	options := config.Options{WebhookTimeout: time.Second, WebhookMaxRetries: 3, WebhookRetryBackoff: time.Second}
+	d, clock := newTestWebhookDispatcher(options)
+	endpoint := config.WebhookEndpoint{URL: server.URL}
+
+	require.NoError(t, d.deliver(endpoint, "SensorSilent", []byte(`{}`), options))
+	require.Len(t, recorder.requests, 3)
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.slept())
+	require.Empty(t, recorder.requests[0].Header.Get(webhookSignatureHeader))
+
+	// retries are exhausted
+	recorder.statuses = []int{500, 500, 500, 500}
+	err := d.deliver(endpoint, "SensorSilent", []byte(`{}`), options)
+	require.EqualError(t, err, "endpoint responded with status 500")
+	require.Len(t, recorder.requests, 7)
+
+	// refused payloads are not retried
+	recorder.statuses = []int{http.StatusBadRequest}
+	err = d.deliver(endpoint, "SensorSilent", []byte(`{}`), options)
+	require.EqualError(t, err, "endpoint responded with status 400")
+	require.Len(t, recorder.requests, 8)
+}
+
+func TestWebhookDispatchFiltersEvents(t *testing.T) {
+	recorder := &webhookRecorder{}
+	server := httptest.NewServer(recorder)
+	defer server.Close()
+
+	d, _ := newTestWebhookDispatcher(config.Options{
+		WebhookTimeout: time.Second,
+		Webhooks:       []config.WebhookEndpoint{{URL: server.URL, Events: []string{"SensorSilent"}}},
+	})
+
+	events := make(chan event.Event, 3)
+	events <- event.Event{Type: event.ApprovalRequest, ChannelID: "mychannel", SensoryTxID: "tx1"}
+	events <- event.Event{Type: event.HeightLag, ChannelID: "mychannel"}
+	events <- event.Event{Type: event.SensorSilent, ChannelID: "mychannel", SensorID: "Org1MSP/sensor1", LastSeen: time.Unix(1699999000, 0)}
+	close(events)
+	d.serve(events)
+
+	require.Eventually(t, func() bool {
+		recorder.mutex.Lock()
+		defer recorder.mutex.Unlock()
+		return len(recorder.requests) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.JSONEq(t, `{"type":"SensorSilent","channelID":"mychannel","timestamp":1700000000,"sensorID":"Org1MSP/sensor1","lastSeen":1699999000}`, string(recorder.bodies[0]))
+}
@@ -28,6 +28,8 @@ type TemperatureHumidityReading struct {
 	Temperature      float64 `json:"temperature"`
 	RelativeHumidity float64 `json:"relativeHumidity"`
 	Timestamp        int64   `json:"timestamp"`
+	// Metrics holds every metric of a composite reading
+	Metrics map[string]float64 `json:"metrics,omitempty"`
 }
 
 type OutputEntry struct {
@@ -244,24 +246,31 @@ func getApprovedTransactions(vledger ledger.PeerLedger, chaincodeName []byte) pb
 				return shim.Error(errMsg)
 			}
 
-			temperature, relativeHumidity, timestamp, err := protoutil.ExtractTemperatureHumidityReadingFromEnvelope(sensorTransaction.GetTransactionEnvelope())
+			metrics, timestamp, err := protoutil.ExtractMetricsReadingFromEnvelope(sensorTransaction.GetTransactionEnvelope())
 			if err != nil {
 				errMsg := fmt.Sprintf("BLOCC: Failed to fetch reading from approved transaction %s, error %s", approvedTxId, err)
 				qscclogger.Error(errMsg)
 				return shim.Error(errMsg)
 			}
 
-			qscclogger.Debugf("BLOCC: block %d, approvingMspId=%s, approvedTxId=%s, temperature=%f, relativeHumidity=%f, timestamp=%d",
-				blockNum, mspId, approvedTxId, temperature, relativeHumidity, timestamp)
+			qscclogger.Debugf("BLOCC: block %d, approvingMspId=%s, approvedTxId=%s, metrics=%v, timestamp=%d",
+				blockNum, mspId, approvedTxId, metrics, timestamp)
+
+			reading := TemperatureHumidityReading{
+				Temperature:      metrics["temperature"],
+				RelativeHumidity: metrics["relativeHumidity"],
+				Timestamp:        timestamp,
+			}
+			_, hasTemperature := metrics["temperature"]
+			_, hasHumidity := metrics["relativeHumidity"]
+			if len(metrics) != 2 || !hasTemperature || !hasHumidity {
+				reading.Metrics = metrics
+			}
 
 			agreements[approvedTxId] = OutputEntry{
 				TxID:            approvedTxId,
 				ApprovingMspIDs: []string{mspId},
-				Reading: TemperatureHumidityReading{
-					Temperature:      temperature,
-					RelativeHumidity: relativeHumidity,
-					Timestamp:        timestamp,
-				},
+				Reading:         reading,
 			}
 		}
 
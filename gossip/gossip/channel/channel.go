@@ -51,6 +51,9 @@ type Config struct {
 	RequestWaitTime             time.Duration
 	ResponseWaitTime            time.Duration
 	MsgExpirationTimeout        time.Duration
+	// EventBus is the BLOCC event bus the approval requests and digests are
+	// published to, none being published if it is nil
+	EventBus *event.Bus
 }
 
 // GossipChannel defines an object that deals with all channel-related messages
@@ -644,7 +647,24 @@ func (gc *gossipChannel) HandleMessage(msg protoext.ReceivedMessage) {
 		// TODO: This is a response to the approval request, it actually does not do anything useful and may be removed.
 		msg.Respond(gc.createApprovalMessageResponse(receiverIdentity))
 
-		event.GlobalEventBus.Publish(event.Event{ChannelID: gc.chainID.String(), SensoryTxID: txID})
+		traceID := event.NewTraceID()
+		gc.logger.Infof("BLOCC: Requesting approval of reading %s, trace %s", txID, traceID)
+		if bus := gc.GetConf().EventBus; bus != nil {
+			bus.Publish(event.Event{ChannelID: gc.chainID.String(), SensoryTxID: txID, TraceID: traceID})
+		}
+		return
+	}
+
+	if protoext.IsApprovalDigestMsg(m.GossipMessage) {
+		gc.logger.Debugf("BLOCC: Received approval digest from %s of %s", msg.GetConnectionInfo().ID, string(orgID))
+		if bus := gc.GetConf().EventBus; bus != nil {
+			bus.Publish(event.Event{
+				Type:      event.ApprovalDigestReceived,
+				ChannelID: gc.chainID.String(),
+				MSPID:     string(orgID),
+				Digest:    m.GetApprovalDigest().GetDigest(),
+			})
+		}
 		return
 	}
 
@@ -933,6 +953,16 @@ func (gc *gossipChannel) verifyMsg(msg protoext.ReceivedMessage) bool {
 		return true
 	}
 
+	if protoext.IsApprovalDigestMsg(m.GossipMessage) {
+		ad := m.GetApprovalDigest()
+		expectedMAC := GenerateMAC(msg.GetConnectionInfo().ID, gc.chainID)
+		if !bytes.Equal(expectedMAC, ad.Channel_MAC) {
+			gc.logger.Warning("Message contains wrong channel MAC(", ad.Channel_MAC, "), expected", expectedMAC)
+			return false
+		}
+		return true
+	}
+
 	if protoext.IsStateInfoPullRequestMsg(m.GossipMessage) {
 		sipr := m.GetStateInfoPullReq()
 		expectedMAC := GenerateMAC(msg.GetConnectionInfo().ID, gc.chainID)
@@ -132,6 +132,7 @@ func (ga *gossipAdapterImpl) GetConf() channel.Config {
 		RequestWaitTime:             ga.conf.RequestWaitTime,
 		ResponseWaitTime:            ga.conf.ResponseWaitTime,
 		MsgExpirationTimeout:        ga.conf.MsgExpirationTimeout,
+		EventBus:                    ga.conf.EventBus,
 	}
 }
 
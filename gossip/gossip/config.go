@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"time"
 
+	event "github.com/hyperledger/fabric/common/blocc-events"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
@@ -97,6 +98,9 @@ type Config struct {
 	MsgExpirationFactor int
 	// MaxConnectionAttempts is the max number of attempts to connect to a peer (wait for alive ack)
 	MaxConnectionAttempts int
+
+	// EventBus is the BLOCC event bus the approval requests and digests of the channels are published to.
+	EventBus *event.Bus
 }
 
 // GlobalConfig builds a Config from the given endpoint, certificate and bootstrap peers.
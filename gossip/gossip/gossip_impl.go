@@ -107,6 +107,33 @@ func (g *Node) OnBlockCommitted(txID string, channelID string) {
 	}
 }
 
+// GossipApprovalDigest gossips the digest of the approvals this peer submitted recently on the
+// channel, for the other peers of the channel to compare with their ledger.
+func (g *Node) GossipApprovalDigest(channelID string, digest []byte) {
+	gossipChannel := g.chanState.getGossipChannelByChainID(common.ChannelID(channelID))
+	if gossipChannel == nil {
+		g.logger.Errorf("BLOCC: Channel %s not found for gossiping approval digest", channelID)
+		return
+	}
+	if !gossipChannel.IsMemberInChan(g.selfNetworkMember()) {
+		g.logger.Errorf("BLOCC: %s does not belong to channel %s", g.selfOrg, channelID)
+		return
+	}
+
+	g.Gossip(&pg.GossipMessage{
+		Channel: common.ChannelID(channelID),
+		Nonce:   util.RandomUInt64(),
+		Tag:     pg.GossipMessage_APPROVAL,
+		Content: &pg.GossipMessage_ApprovalDigest{
+			ApprovalDigest: &pg.ApprovalDigest{
+				PkiId:       g.comm.GetPKIid(),
+				Channel_MAC: channel.GenerateMAC(g.comm.GetPKIid(), common.ChannelID(channelID)),
+				Digest:      digest,
+			},
+		},
+	})
+}
+
 // New creates a gossip instance attached to a gRPC server
 func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	mcs api.MessageCryptoService, selfIdentity api.PeerIdentityType,
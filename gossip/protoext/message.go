@@ -61,9 +61,15 @@ func IsApprovalResponseMsg(m *gossip.GossipMessage) bool {
 	return m.GetApprovalResponse() != nil
 }
 
+// IsApprovalDigestMsg returns whether this GossipMessage is a message summarizing the approvals
+// a peer submitted recently, for the other peers to detect the approvals missing from the chain.
+func IsApprovalDigestMsg(m *gossip.GossipMessage) bool {
+	return m.GetApprovalDigest() != nil
+}
+
 // IsApprovalMsg returns whether this GossipMessage is an approval message
 func IsApprovalMsg(m *gossip.GossipMessage) bool {
-	return IsApprovalRequestMsg(m) || IsApprovalResponseMsg(m)
+	return IsApprovalRequestMsg(m) || IsApprovalResponseMsg(m) || IsApprovalDigestMsg(m)
 }
 
 // GetPullMsgType returns the phase of the pull mechanism this GossipMessage belongs to
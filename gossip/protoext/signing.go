@@ -200,6 +200,8 @@ func (m *SignedGossipMessage) String() string {
 			gMsg = ApprovalMessageRequestToString(m.GetApprovalRequest())
 		} else if m.GetApprovalResponse() != nil {
 			gMsg = ApprovalMessageResponseToString(m.GetApprovalResponse())
+		} else if m.GetApprovalDigest() != nil {
+			gMsg = ApprovalDigestToString(m.GetApprovalDigest())
 		} else {
 			gMsg = m.GossipMessage.String()
 			isSimpleMsg = true
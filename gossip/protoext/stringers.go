@@ -33,6 +33,10 @@ func ApprovalMessageResponseToString(am *gossip.ApprovalMessageResponse) string
 	return fmt.Sprint("Approval Message Response For Tx:", am.ApprovalTxid, " PKI-id:", am.PkiId)
 }
 
+func ApprovalDigestToString(ad *gossip.ApprovalDigest) string {
+	return fmt.Sprint("Approval Digest of ", len(ad.Digest), " bytes PKI-id:", ad.PkiId, " channel MAC:", ad.Channel_MAC)
+}
+
 // AliveMessageToString of AliveMessage prints Alive Message, Identity and Timestamp
 func AliveMessageToString(am *gossip.AliveMessage) string {
 	if am.Membership == nil {
@@ -111,6 +111,10 @@ type gossipSvc interface {
 
 	// OnBlockCommitted Gossips block after being committed
 	OnBlockCommitted(txID string, channelID string)
+
+	// GossipApprovalDigest gossips the digest of the approvals this peer submitted recently
+	// on the channel
+	GossipApprovalDigest(channelID string, digest []byte)
 }
 
 // GossipBlockCommitter is an interface that allows the ledger to notify the gossip layer
@@ -45,6 +45,10 @@ func (g *gossipMock) OnBlockCommitted(txID string, channelID string) {
 	panic("implement me")
 }
 
+func (g *gossipMock) GossipApprovalDigest(channelID string, digest []byte) {
+	panic("implement me")
+}
+
 func (g *gossipMock) SelfChannelInfo(common.ChannelID) *protoext.SignedGossipMessage {
 	panic("implement me")
 }
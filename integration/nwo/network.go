@@ -118,6 +118,12 @@ type Peer struct {
 	DevMode      bool           `yaml:"devmode,omitempty"`
 	Organization string         `yaml:"organization,omitempty"`
 	Channels     []*PeerChannel `yaml:"channels,omitempty"`
+	// ClockSkew shifts the clock the peer timestamps its BLOCC approvals with,
+	// to emulate the NTP drift of the peer against the sensors and the other
+	// components of the network. The orderers take no part in the timestamp
+	// attestation, so skewing the peers covers the drift between peers and
+	// orderers.
+	ClockSkew time.Duration `yaml:"clockskew,omitempty"`
 }
 
 // PeerChannel names of the channel a peer should be joined to and whether or
@@ -1401,6 +1407,9 @@ func (n *Network) PeerRunner(p *Peer, env ...string) *ginkgomon.Runner {
 		"CORE_LEDGER_STATE_COUCHDBCONFIG_USERNAME=admin",
 		"CORE_LEDGER_STATE_COUCHDBCONFIG_PASSWORD=adminpw",
 	)
+	if p.ClockSkew != 0 {
+		cmd.Env = append(cmd.Env, "CORE_BLOCC_DEBUG_CLOCKOFFSET="+p.ClockSkew.String())
+	}
 	cmd.Env = append(cmd.Env, env...)
 
 	return ginkgomon.New(ginkgomon.Config{
@@ -223,6 +223,9 @@ Profiles:{{ range .Profiles }}
         Endorsement:
           Type: ImplicitMeta
           Rule: "MAJORITY Endorsement"
+        BloccApprovals:
+          Type: ImplicitMeta
+          Rule: "ANY Endorsement"
     {{- else }}
     Consortiums:{{ range $w.Consortiums }}
       {{ .Name }}:
@@ -3,6 +3,7 @@ package blocc
 import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/internal/peer/blocc/chaincode"
+	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/spf13/cobra"
 )
 
@@ -12,8 +13,33 @@ func Cmd(cryptoProvider bccsp.BCCSP) *cobra.Command {
 		Use:   "blocc",
 		Short: "Perform bscc operations",
 		Long:  "Perform bscc operations",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			common.InitCmd(cmd, args)
+			chaincode.LoadMessages()
+		},
 	}
 	bloccCmd.AddCommand(chaincode.Cmd(cryptoProvider))
+	bloccCmd.AddCommand(chaincode.BackfillCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.ReapproveCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.VerifyApprovalCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.ExportApprovalCmd())
+	bloccCmd.AddCommand(chaincode.SignApprovalCmd())
+	bloccCmd.AddCommand(chaincode.SubmitApprovalCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.DrainCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.PendingCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.ReorgCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.DrillCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.ClearForkCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.ExportBundleCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(chaincode.ExportProvenanceCmd(nil, cryptoProvider))
+
+	sensorCmd := &cobra.Command{
+		Use:   "sensor",
+		Short: "Manage the sensor registry",
+		Long:  "Manage the sensor registry",
+	}
+	sensorCmd.AddCommand(chaincode.ImportSensorsCmd(nil, cryptoProvider))
+	bloccCmd.AddCommand(sensorCmd)
 
 	return bloccCmd
 }
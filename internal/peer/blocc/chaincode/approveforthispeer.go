@@ -3,21 +3,29 @@ package chaincode
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/internal/peer/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/pinning"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+const approveForThisPeerCmdName = "approveforthispeer"
+
 type ApproveForThisPeer struct {
 	Certificate     tls.Certificate
 	Command         *cobra.Command
@@ -26,17 +34,48 @@ type ApproveForThisPeer struct {
 	EndorserClients []EndorserClient
 	Input           *ApproveForThisPeerInput
 	Signer          Signer
+	// Anonymous indicates that Signer is an idemix identity and that the
+	// endorsement must be re-signed with it before submission.
+	Anonymous bool
+	// DryRun, if set, checks the signed approval transaction before it is
+	// broadcast, the approval failing without being broadcast if it errs.
+	DryRun func(env *cb.Envelope) error
+	// Store, if set, stores the signed approval transaction to be broadcast
+	// later when the orderer is unavailable, the approval then succeeding.
+	Store func(env *cb.Envelope) error
+	// Forwarding, if set with Store, reports whether approvals stored earlier
+	// for the channel still wait to be broadcast. The approval is then
+	// stored behind them rather than broadcast, so that the approvals reach
+	// the orderer in order.
+	Forwarding func() bool
+	// ClockOffset shifts the timestamp of the approval transaction, which
+	// BSCC attests the reading timestamp against, to emulate clock drift.
+	ClockOffset time.Duration
 }
 
 type ApproveForThisPeerInput struct {
-	OrdererAddress        string
+	OrdererAddress string
+	// BFTOrdererAddresses are the nodes of the BFT ordering service to
+	// broadcast to instead of OrdererAddress, if any
+	BFTOrdererAddresses   []string
 	RootCertFilePath      string
 	ChannelID             string
 	TxID                  string
 	PeerAddress           string
+	TLSRootCertFile       string
 	ConnectionProfilePath string
 	WaitForEvent          bool
 	WaitForEventTimeout   time.Duration
+	// Metadata is attached to the approval record of this peer
+	Metadata map[string]string
+	// TraceID is the trace ID of the approval request, recorded with the
+	// approval transaction, if any
+	TraceID string
+	// BroadcastStreams, if set, carries the approval to the orderer over
+	// the long-lived broadcast streams of the peer
+	BroadcastStreams *BroadcastStreams
+	// Pinner verifies the TLS certificate of the orderer against its pins
+	Pinner *pinning.Pinner
 }
 
 func (a *ApproveForThisPeerInput) Validate() error {
@@ -58,50 +97,26 @@ func (a *ApproveForThisPeerInput) Validate() error {
 	return nil
 }
 
-func ApproveForThisPeerCmd(a *ApproveForThisPeer, cryptoProvider bccsp.BCCSP) *cobra.Command {
+// ApproveForThisPeerCmd returns the command approving a sensory reading,
+// checking the approval transaction with dryRun before it is broadcast if
+// dryRun is not nil, and storing it with store if the orderer is unavailable
+// or forwarding reports that stored approvals wait to be broadcast, and store
+// is not nil.
+func ApproveForThisPeerCmd(a *ApproveForThisPeer, cryptoProvider bccsp.BCCSP, dryRun, store func(env *cb.Envelope) error, forwarding func() bool) *cobra.Command {
 	chaincodeApproveForThisPeerCmd := &cobra.Command{
-		Use:   "approveforthispeer",
+		Use:   approveForThisPeerCmdName,
 		Short: "FOR INTERNAL USE ONLY. Approve a sensory reading for this peer",
 		Long:  "FOR INTERNAL USE ONLY. Approve a sensory reading for this peer",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if a == nil {
-				input, err := a.createInput()
+				var err error
+				a, err = newApproveForThisPeer(cmd, cryptoProvider, store != nil)
 				if err != nil {
 					return err
 				}
-
-				ccInput := &ClientConnectionsInput{
-					CommandName:           cmd.Name(),
-					EndorserRequired:      true,
-					OrdererRequired:       true,
-					OrderingEndpoint:      ordererAddress,
-					OrdererCAFile:         rootCertFilePath,
-					ChannelID:             channelID,
-					PeerAddresses:         []string{peerAddress},
-					TLSRootCertFiles:      []string{tlsRootCertFile},
-					ConnectionProfilePath: connectionProfilePath,
-					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
-				}
-
-				cc, err := NewClientConnections(ccInput, cryptoProvider)
-				if err != nil {
-					return err
-				}
-
-				endorserClients := make([]EndorserClient, len(cc.EndorserClients))
-				for i, e := range cc.EndorserClients {
-					endorserClients[i] = e
-				}
-
-				a = &ApproveForThisPeer{
-					Command:         cmd,
-					Input:           input,
-					Certificate:     cc.Certificate,
-					BroadcastClient: cc.BroadcastClient,
-					DeliverClients:  cc.DeliverClients,
-					EndorserClients: endorserClients,
-					Signer:          cc.Signer,
-				}
+				a.DryRun = dryRun
+				a.Store = store
+				a.Forwarding = forwarding
 			}
 			return a.Approve()
 		},
@@ -109,6 +124,7 @@ func ApproveForThisPeerCmd(a *ApproveForThisPeer, cryptoProvider bccsp.BCCSP) *c
 
 	flagList := []string{
 		"ordererAddress",
+		"bftOrdererAddresses",
 		"rootCertFilePath",
 		"channelID",
 		"txID",
@@ -117,12 +133,84 @@ func ApproveForThisPeerCmd(a *ApproveForThisPeer, cryptoProvider bccsp.BCCSP) *c
 		"connectionProfile",
 		"waitForEvent",
 		"waitForEventTimeout",
+		"traceID",
 	}
 	attachFlags(chaincodeApproveForThisPeerCmd, flagList)
 
 	return chaincodeApproveForThisPeerCmd
 }
 
+// newApproveForThisPeer connects to the peer and orderer given on the command
+// line and loads the approval identity from the BLOCC configuration. If
+// allowOrdererUnavailable is set, an unavailable orderer only fails the
+// broadcast of the approval.
+func newApproveForThisPeer(cmd *cobra.Command, cryptoProvider bccsp.BCCSP, allowOrdererUnavailable bool) (*ApproveForThisPeer, error) {
+	var a *ApproveForThisPeer
+	input, err := a.createInput()
+	if err != nil {
+		return nil, err
+	}
+
+	a, err = NewApproveForThisPeer(input, config.GetOptions(viper.GetViper()), cryptoProvider, allowOrdererUnavailable)
+	if err != nil {
+		return nil, err
+	}
+	a.Command = cmd
+	return a, nil
+}
+
+// NewApproveForThisPeer connects to the peer and orderer of input and loads
+// the approval identity from options. Unlike the command, it reads no flag,
+// so that the peer may approve the readings of several channels
+// concurrently. If allowOrdererUnavailable is set, an unavailable orderer
+// only fails the broadcast of the approval.
+func NewApproveForThisPeer(input *ApproveForThisPeerInput, options config.Options, cryptoProvider bccsp.BCCSP, allowOrdererUnavailable bool) (*ApproveForThisPeer, error) {
+	ccInput := &ClientConnectionsInput{
+		CommandName:           approveForThisPeerCmdName,
+		EndorserRequired:      true,
+		OrdererRequired:       true,
+		OrderingEndpoint:      input.OrdererAddress,
+		BFTOrderingEndpoints:  input.BFTOrdererAddresses,
+		OrdererCAFile:         input.RootCertFilePath,
+		ChannelID:             input.ChannelID,
+		PeerAddresses:         []string{input.PeerAddress},
+		TLSRootCertFiles:      []string{input.TLSRootCertFile},
+		ConnectionProfilePath: input.ConnectionProfilePath,
+		TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+
+		AllowOrdererUnavailable: allowOrdererUnavailable,
+		BroadcastStreams:        input.BroadcastStreams,
+		Pinner:                  input.Pinner,
+	}
+
+	cc, err := NewClientConnections(ccInput, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	endorserClients := make([]EndorserClient, len(cc.EndorserClients))
+	for i, e := range cc.EndorserClients {
+		endorserClients[i] = e
+	}
+
+	input.Metadata = options.ApprovalMetadata
+	signer, err := approvalSigner(cc.Signer, options, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApproveForThisPeer{
+		Input:           input,
+		Certificate:     cc.Certificate,
+		BroadcastClient: cc.BroadcastClient,
+		DeliverClients:  cc.DeliverClients,
+		EndorserClients: endorserClients,
+		Signer:          signer,
+		Anonymous:       options.AnonymousApprovals,
+		ClockOffset:     options.ClockOffset,
+	}, nil
+}
+
 func (a *ApproveForThisPeer) Approve() error {
 	err := a.Input.Validate()
 	if err != nil {
@@ -173,11 +261,36 @@ func (a *ApproveForThisPeer) Approve() error {
 	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
 		return errors.Errorf("proposal failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
 	}
+	warnDeprecation(proposalResponse)
+
+	if a.Anonymous {
+		// replace the peer's endorsement so that only the idemix identity
+		// is revealed by the approval transaction
+		for i, r := range responses {
+			responses[i], err = reendorse(r, a.Signer)
+			if err != nil {
+				return errors.WithMessage(err, "failed to re-endorse proposal response")
+			}
+		}
+	}
+
 	// assemble a signed transaction (it's an Envelope message)
 	env, err := protoutil.CreateSignedTx(proposal, a.Signer, responses...)
 	if err != nil {
 		return errors.WithMessage(err, "failed to create signed transaction")
 	}
+	if a.DryRun != nil {
+		if err := a.DryRun(env); err != nil {
+			return errors.WithMessagef(err, "approval transaction %s failed the dry run and was not broadcast", txIDSubmission)
+		}
+	}
+	if a.Store != nil && a.Forwarding != nil && a.Forwarding() {
+		if err := a.Store(env); err != nil {
+			return errors.WithMessage(err, "failed to store transaction")
+		}
+		logger.Infof("Stored approvals wait to be broadcast, approval transaction %s stored behind them", txIDSubmission)
+		return nil
+	}
 	var dg *chaincode.DeliverGroup
 	var ctx context.Context
 	if a.Input.WaitForEvent {
@@ -201,7 +314,15 @@ func (a *ApproveForThisPeer) Approve() error {
 	}
 
 	if err = a.BroadcastClient.Send(env); err != nil {
-		return errors.WithMessage(err, "failed to send transaction")
+		err = broadcastError(err)
+		if a.Store == nil || !errors.Is(err, bloccerrors.ErrOrdererUnavailable) {
+			return errors.WithMessage(err, "failed to send transaction")
+		}
+		if storeErr := a.Store(env); storeErr != nil {
+			return errors.WithMessagef(err, "failed to send transaction and to store it: %s", storeErr)
+		}
+		logger.Warningf("Orderer unavailable, approval transaction %s stored to be broadcast later: %s", txIDSubmission, err)
+		return nil
 	}
 
 	if dg != nil && ctx != nil {
@@ -215,15 +336,30 @@ func (a *ApproveForThisPeer) Approve() error {
 	return err
 }
 
+// broadcastError returns err, the failure to broadcast an approval, in the
+// ErrOrdererUnavailable category if the orderer could not be reached or was
+// temporarily unable to serve the broadcast.
+func broadcastError(err error) error {
+	var statusErr *common.BroadcastStatusError
+	if errors.As(err, &statusErr) && statusErr.Status != cb.Status_SERVICE_UNAVAILABLE {
+		return err
+	}
+	return bloccerrors.WithCategory(err, bloccerrors.ErrOrdererUnavailable)
+}
+
 func (a *ApproveForThisPeer) createInput() (*ApproveForThisPeerInput, error) {
 	input := &ApproveForThisPeerInput{
-		OrdererAddress:      ordererAddress,
-		RootCertFilePath:    rootCertFilePath,
-		ChannelID:           channelID,
-		TxID:                txID,
-		WaitForEvent:        waitForEvent,
-		WaitForEventTimeout: waitForEventTimeout,
-		PeerAddress:         peerAddress,
+		OrdererAddress:        ordererAddress,
+		BFTOrdererAddresses:   bftOrdererAddresses,
+		RootCertFilePath:      rootCertFilePath,
+		ChannelID:             channelID,
+		TxID:                  txID,
+		WaitForEvent:          waitForEvent,
+		WaitForEventTimeout:   waitForEventTimeout,
+		PeerAddress:           peerAddress,
+		TLSRootCertFile:       tlsRootCertFile,
+		ConnectionProfilePath: connectionProfilePath,
+		TraceID:               traceID,
 	}
 
 	return input, nil
@@ -246,6 +382,21 @@ func (a *ApproveForThisPeer) createProposal(inputTxID string) (proposal *pb.Prop
 		Args: append([][]byte{[]byte(approveFuncName)}, argsBytes),
 	}
 
+	// the metadata is passed in the transient field so that it stays out of
+	// the blocks when the approvals of the channel are private
+	var transient map[string][]byte
+	if len(a.Input.Metadata) > 0 {
+		metadataBytes, err := json.Marshal(a.Input.Metadata)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to marshal approval metadata")
+		}
+		transient = map[string][]byte{metadataTransientKey: metadataBytes}
+	}
+
+	if a.Input.TraceID != "" {
+		ccInput.Decorations = map[string][]byte{protoutil.TraceIDDecoration: []byte(a.Input.TraceID)}
+	}
+
 	cis := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
 			ChaincodeId: &pb.ChaincodeID{Name: bloccName},
@@ -264,11 +415,42 @@ func (a *ApproveForThisPeer) createProposal(inputTxID string) (proposal *pb.Prop
 		cis,
 		creatorBytes,
 		"",
-		nil,
+		apiversion.Transient(transient),
 	)
 	if err != nil {
 		return nil, "", errors.WithMessage(err, "failed to create ChaincodeInvocationSpec proposal")
 	}
+	if a.ClockOffset != 0 {
+		if err := shiftProposalTimestamp(proposal, a.ClockOffset); err != nil {
+			return nil, "", err
+		}
+	}
 
 	return proposal, txID, nil
 }
+
+// shiftProposalTimestamp shifts the timestamp of the channel header of the
+// proposal by offset.
+func shiftProposalTimestamp(proposal *pb.Proposal, offset time.Duration) error {
+	header, err := protoutil.UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal proposal header")
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal channel header")
+	}
+
+	timestamp, err := ptypes.Timestamp(chdr.Timestamp)
+	if err != nil {
+		return errors.Wrap(err, "invalid proposal timestamp")
+	}
+	chdr.Timestamp, err = ptypes.TimestampProto(timestamp.Add(offset))
+	if err != nil {
+		return errors.Wrap(err, "invalid shifted proposal timestamp")
+	}
+
+	header.ChannelHeader = protoutil.MarshalOrPanic(chdr)
+	proposal.Header = protoutil.MarshalOrPanic(header)
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeSigner struct{}
+
+func (fakeSigner) Sign(msg []byte) ([]byte, error) { return msg, nil }
+func (fakeSigner) Serialize() ([]byte, error)      { return []byte("creator"), nil }
+
+func TestApprovalClockOffset(t *testing.T) {
+	proposalTime := func(offset time.Duration) time.Time {
+		a := &ApproveForThisPeer{
+			Input:       &ApproveForThisPeerInput{ChannelID: "sensorchannel"},
+			Signer:      fakeSigner{},
+			ClockOffset: offset,
+		}
+		proposal, txID, err := a.createProposal("tx1")
+		require.NoError(t, err)
+		header, err := protoutil.UnmarshalHeader(proposal.Header)
+		require.NoError(t, err)
+		chdr, err := protoutil.UnmarshalChannelHeader(header.ChannelHeader)
+		require.NoError(t, err)
+		require.Equal(t, txID, chdr.TxId)
+		// the proposal tells the version of the BSCC API spoken by the CLI
+		payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.Payload)
+		require.NoError(t, err)
+		require.Equal(t, []byte(strconv.Itoa(apiversion.Current)), payload.TransientMap[apiversion.TransientKey])
+		timestamp, err := ptypes.Timestamp(chdr.Timestamp)
+		require.NoError(t, err)
+		return timestamp
+	}
+
+	now := time.Now()
+	require.WithinDuration(t, now, proposalTime(0), 10*time.Second)
+	require.WithinDuration(t, now.Add(-90*time.Second), proposalTime(-90*time.Second), 10*time.Second)
+	require.WithinDuration(t, now.Add(time.Hour), proposalTime(time.Hour), 10*time.Second)
+}
+
+func TestApprovalMetadataTransient(t *testing.T) {
+	a := &ApproveForThisPeer{
+		Input:  &ApproveForThisPeerInput{ChannelID: "sensorchannel", Metadata: map[string]string{"site": "a"}},
+		Signer: fakeSigner{},
+	}
+	proposal, _, err := a.createProposal("tx1")
+	require.NoError(t, err)
+	payload, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.Payload)
+	require.NoError(t, err)
+	// the metadata is kept out of the args, which land in the block
+	require.Equal(t, []byte(`{"site":"a"}`), payload.TransientMap[metadataTransientKey])
+	cis, err := protoutil.UnmarshalChaincodeInvocationSpec(payload.Input)
+	require.NoError(t, err)
+	require.Len(t, cis.ChaincodeSpec.Input.Args, 2)
+}
+
+type fakeEndorser struct{}
+
+func (fakeEndorser) ProcessProposal(context.Context, *pb.SignedProposal, ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	return &pb.ProposalResponse{
+		Response:    &pb.Response{Status: int32(cb.Status_SUCCESS)},
+		Payload:     []byte("payload"),
+		Endorsement: &pb.Endorsement{Endorser: []byte("endorser")},
+	}, nil
+}
+
+type fakeBroadcastClient struct {
+	sent []*cb.Envelope
+}
+
+func (b *fakeBroadcastClient) Send(env *cb.Envelope) error {
+	b.sent = append(b.sent, env)
+	return nil
+}
+
+func (b *fakeBroadcastClient) Close() error { return nil }
+
+func TestApprovalStoredWhileForwarding(t *testing.T) {
+	approve := func(forwarding bool) (sent, stored int) {
+		broadcastClient := &fakeBroadcastClient{}
+		a := &ApproveForThisPeer{
+			BroadcastClient: broadcastClient,
+			EndorserClients: []EndorserClient{fakeEndorser{}},
+			Input: &ApproveForThisPeerInput{
+				ChannelID:        "sensorchannel",
+				TxID:             "tx1",
+				PeerAddress:      "peer0:7051",
+				OrdererAddress:   "orderer:7050",
+				RootCertFilePath: "ca.pem",
+			},
+			Signer: fakeSigner{},
+			Store: func(*cb.Envelope) error {
+				stored++
+				return nil
+			},
+			Forwarding: func() bool { return forwarding },
+		}
+		require.NoError(t, a.Approve())
+		return len(broadcastClient.sent), stored
+	}
+
+	// approvals are broadcast unless stored approvals wait to be broadcast,
+	// in which case they are stored behind them
+	sent, stored := approve(false)
+	require.Equal(t, 1, sent)
+	require.Equal(t, 0, stored)
+	sent, stored = approve(true)
+	require.Equal(t, 0, sent)
+	require.Equal(t, 1, stored)
+}
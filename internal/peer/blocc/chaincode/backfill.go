@@ -0,0 +1,230 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/internal/pkg/txflags"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// Backfill submits this peer's approval for the historical sensory readings
+// of a channel that it has neither approved nor rejected, e.g. after its
+// organization joined a channel that already holds readings.
+type Backfill struct {
+	Command   *cobra.Command
+	Approver  *ApproveForThisPeer
+	FromBlock uint64
+}
+
+func BackfillCmd(b *Backfill, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Approve historical sensory readings for this peer",
+		Long:  "Scan the blocks of a channel from --fromBlock for sensory readings lacking this peer's approval and approve them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if b == nil {
+				approver, err := newApproveForThisPeer(cmd, cryptoProvider, false)
+				if err != nil {
+					return err
+				}
+
+				b = &Backfill{
+					Command:   cmd,
+					Approver:  approver,
+					FromBlock: fromBlock,
+				}
+			}
+			return b.Backfill()
+		},
+	}
+	flagList := []string{
+		"ordererAddress",
+		"rootCertFilePath",
+		"channelID",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+		"waitForEvent",
+		"waitForEventTimeout",
+		"fromBlock",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (b *Backfill) Backfill() error {
+	if b.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		b.Command.SilenceUsage = true
+	}
+
+	channelID := b.Approver.Input.ChannelID
+	if channelID == "" {
+		return errors.New("ChannelID not specified")
+	}
+
+	mspID, err := b.Approver.mspID()
+	if err != nil {
+		return err
+	}
+	q, err := b.Approver.querier()
+	if err != nil {
+		return err
+	}
+
+	infoBytes, err := q.query("qscc", "GetChainInfo", channelID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to get chain info")
+	}
+	info := &cb.BlockchainInfo{}
+	if err := proto.Unmarshal(infoBytes, info); err != nil {
+		return errors.Wrap(err, "failed to unmarshal chain info")
+	}
+
+	var approved int
+	for blockNum := b.FromBlock; blockNum < info.Height; blockNum++ {
+		blockBytes, err := q.query("qscc", "GetBlockByNumber", channelID, strconv.FormatUint(blockNum, 10))
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get block %d", blockNum)
+		}
+		block, err := protoutil.UnmarshalBlock(blockBytes)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to unmarshal block %d", blockNum)
+		}
+
+		for _, sensoryTxID := range sensoryReadings(block) {
+			if err := checkUndecided(q, sensoryTxID, mspID); err != nil {
+				if errors.Is(err, bloccerrors.ErrDuplicateApproval) {
+					continue
+				}
+				return err
+			}
+
+			logger.Infof("Approving reading %s of block %d", sensoryTxID, blockNum)
+			b.Approver.Input.TxID = sensoryTxID
+			if err := b.Approver.Approve(); err != nil {
+				return errors.WithMessagef(err, "failed to approve reading %s", sensoryTxID)
+			}
+			approved++
+		}
+	}
+
+	logger.Infof("Backfilled %d approvals on channel %s from block %d to %d", approved, channelID, b.FromBlock, info.Height)
+	return nil
+}
+
+// sensoryReadings returns the IDs of the valid sensory transactions in block.
+func sensoryReadings(block *cb.Block) []string {
+	var flags txflags.ValidationFlags
+	if len(block.GetMetadata().GetMetadata()) > int(cb.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		flags = txflags.ValidationFlags(block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	var txIDs []string
+	for i, envBytes := range block.GetData().GetData() {
+		if len(flags) > i && !flags.IsValid(i) {
+			continue
+		}
+
+		// config and other non-chaincode transactions fail to parse
+		cis, err := protoutil.ExtractChaincodeInvocationSpec(envBytes)
+		if err != nil || cis.GetChaincodeSpec().GetChaincodeId().GetName() != sensorChaincodeName {
+			continue
+		}
+
+		txID, err := protoutil.GetOrComputeTxIDFromEnvelope(envBytes)
+		if err != nil {
+			logger.Warningf("Failed to get ID of transaction %d in block %d: %s", i, block.Header.Number, err)
+			continue
+		}
+		txIDs = append(txIDs, txID)
+	}
+
+	return txIDs
+}
+
+// readingDecision returns the decision of the MSP on the reading, "approved"
+// or "rejected", or an empty string if the MSP has not decided yet.
+func readingDecision(q chaincodeQuerier, sensoryTxID, mspID string) (string, error) {
+	for _, d := range []struct{ fn, decision string }{
+		{"QueryApprovals", "approved"},
+		{"QueryRejections", "rejected"},
+	} {
+		recordsBytes, err := q.query(bloccName, d.fn, sensoryTxID)
+		if err != nil {
+			return "", errors.WithMessagef(err, "failed to query %s of reading %s", d.fn, sensoryTxID)
+		}
+
+		var page struct {
+			Records []struct {
+				MSPID string `json:"mspID"`
+			} `json:"records"`
+		}
+		if err := json.Unmarshal(recordsBytes, &page); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal %s result", d.fn)
+		}
+
+		for _, record := range page.Records {
+			if record.MSPID == mspID {
+				return d.decision, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// checkUndecided returns an error in the ErrDuplicateApproval category if the
+// organization mspID approved or rejected the reading already.
+func checkUndecided(q chaincodeQuerier, sensoryTxID, mspID string) error {
+	decision, err := readingDecision(q, sensoryTxID, mspID)
+	if err != nil {
+		return err
+	}
+	if decision != "" {
+		return bloccerrors.WithCategory(errors.Errorf("reading %s is already %s by %s", sensoryTxID, decision, mspID), bloccerrors.ErrDuplicateApproval)
+	}
+	return nil
+}
+
+// mspID returns the MSP ID of the approving identity.
+func (a *ApproveForThisPeer) mspID() (string, error) {
+	creator, err := a.Signer.Serialize()
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, identity); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal identity")
+	}
+
+	return identity.Mspid, nil
+}
+
+// querier returns a querier of the first peer the approver is connected to.
+func (a *ApproveForThisPeer) querier() (*peerQuerier, error) {
+	if len(a.EndorserClients) == 0 {
+		return nil, errors.New("no endorser clients")
+	}
+
+	return &peerQuerier{
+		ChannelID:      a.Input.ChannelID,
+		Signer:         a.Signer,
+		EndorserClient: a.EndorserClients[0],
+	}, nil
+}
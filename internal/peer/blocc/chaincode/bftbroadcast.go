@@ -0,0 +1,94 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"math/rand"
+	"strings"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/pinning"
+	"github.com/pkg/errors"
+)
+
+// bftQuorum returns the number of the n nodes of a BFT ordering service a
+// transaction is broadcast to, f+1 with f = (n-1)/3 faulty nodes tolerated,
+// so that at least one correct node orders it.
+func bftQuorum(n int) int {
+	return (n-1)/3 + 1
+}
+
+// bftBroadcastClient broadcasts the transactions to the nodes of a BFT
+// ordering service, in random order, until bftQuorum of them accepted each
+// transaction. The nodes are dialed for every transaction, so that a node
+// down does not fail the others.
+type bftBroadcastClient struct {
+	endpoints []string
+	dial      func(endpoint string) (common.BroadcastClient, error)
+	shuffle   func(n int, swap func(i, j int))
+}
+
+func newBFTBroadcastClient(endpoints []string, rootCertsPath string, pinner *pinning.Pinner) *bftBroadcastClient {
+	return &bftBroadcastClient{
+		endpoints: endpoints,
+		dial: func(endpoint string) (common.BroadcastClient, error) {
+			clientConfig, err := configOrdererSettings(endpoint, rootCertsPath, pinner)
+			return common.GetBroadcastClientWithParams(endpoint, clientConfig, err)
+		},
+		shuffle: rand.Shuffle,
+	}
+}
+
+// Send broadcasts the envelope to a quorum of the nodes. If no node accepted
+// it and the last one rejected it, the rejection is returned so that it is
+// not retried if it cannot succeed, see broadcastError.
+func (b *bftBroadcastClient) Send(env *cb.Envelope) error {
+	endpoints := append([]string(nil), b.endpoints...)
+	b.shuffle(len(endpoints), func(i, j int) { endpoints[i], endpoints[j] = endpoints[j], endpoints[i] })
+
+	quorum := bftQuorum(len(endpoints))
+	accepted := 0
+	var failures []string
+	var lastErr error
+	for _, endpoint := range endpoints {
+		if accepted == quorum {
+			break
+		}
+		err := b.send(endpoint, env)
+		if err != nil {
+			logger.Warningf("Failed to broadcast to orderer %s: %s", endpoint, err)
+			failures = append(failures, endpoint+": "+err.Error())
+			lastErr = err
+			continue
+		}
+		accepted++
+	}
+	if accepted == quorum {
+		return nil
+	}
+
+	var statusErr *common.BroadcastStatusError
+	if accepted == 0 && errors.As(lastErr, &statusErr) {
+		return lastErr
+	}
+	return errors.Errorf("transaction accepted by %d of the %d orderers required out of %d: %s",
+		accepted, quorum, len(endpoints), strings.Join(failures, "; "))
+}
+
+func (b *bftBroadcastClient) send(endpoint string, env *cb.Envelope) error {
+	client, err := b.dial(endpoint)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.Send(env)
+}
+
+// Close is a no-op, the nodes being dialed for every transaction.
+func (b *bftBroadcastClient) Close() error {
+	return nil
+}
@@ -0,0 +1,93 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrderer is the broadcast client of an orderer node, failing with err.
+type fakeOrderer struct {
+	err  error
+	sent *[]string
+	name string
+}
+
+func (f *fakeOrderer) Send(env *cb.Envelope) error {
+	if f.err == nil {
+		*f.sent = append(*f.sent, f.name)
+	}
+	return f.err
+}
+
+func (f *fakeOrderer) Close() error {
+	return nil
+}
+
+func newFakeBFTClient(endpoints []string, failures map[string]error) (*bftBroadcastClient, *[]string) {
+	sent := &[]string{}
+	return &bftBroadcastClient{
+		endpoints: endpoints,
+		dial: func(endpoint string) (common.BroadcastClient, error) {
+			if err, ok := failures["dial "+endpoint]; ok {
+				return nil, err
+			}
+			return &fakeOrderer{err: failures[endpoint], sent: sent, name: endpoint}, nil
+		},
+		shuffle: func(n int, swap func(i, j int)) {},
+	}, sent
+}
+
+func TestBFTQuorum(t *testing.T) {
+	for n, quorum := range map[int]int{1: 1, 3: 1, 4: 2, 6: 2, 7: 3, 10: 4} {
+		require.Equal(t, quorum, bftQuorum(n), "n=%d", n)
+	}
+}
+
+func TestBFTBroadcastClient(t *testing.T) {
+	endpoints := []string{"orderer0:7050", "orderer1:7050", "orderer2:7050", "orderer3:7050"}
+
+	// f+1 nodes accept the transaction
+	client, sent := newFakeBFTClient(endpoints, nil)
+	require.NoError(t, client.Send(&cb.Envelope{}))
+	require.Equal(t, []string{"orderer0:7050", "orderer1:7050"}, *sent)
+
+	// the nodes down or failing are skipped
+	client, sent = newFakeBFTClient(endpoints, map[string]error{
+		"dial orderer0:7050": errors.New("connection refused"),
+		"orderer1:7050":      &common.BroadcastStatusError{Status: cb.Status_SERVICE_UNAVAILABLE},
+	})
+	require.NoError(t, client.Send(&cb.Envelope{}))
+	require.Equal(t, []string{"orderer2:7050", "orderer3:7050"}, *sent)
+
+	// fewer than f+1 nodes accept the transaction
+	client, sent = newFakeBFTClient(endpoints, map[string]error{
+		"dial orderer0:7050": errors.New("connection refused"),
+		"dial orderer1:7050": errors.New("connection refused"),
+		"dial orderer2:7050": errors.New("connection refused"),
+	})
+	err := client.Send(&cb.Envelope{})
+	require.EqualError(t, err, "transaction accepted by 1 of the 2 orderers required out of 4: "+
+		"orderer0:7050: connection refused; orderer1:7050: connection refused; orderer2:7050: connection refused")
+	require.Equal(t, []string{"orderer3:7050"}, *sent)
+
+	// the rejection of the transaction by every node is returned
+	rejection := &common.BroadcastStatusError{Status: cb.Status_BAD_REQUEST, Info: "bad approval"}
+	client, _ = newFakeBFTClient(endpoints, map[string]error{
+		"orderer0:7050": rejection,
+		"orderer1:7050": rejection,
+		"orderer2:7050": rejection,
+		"orderer3:7050": rejection,
+	})
+	err = client.Send(&cb.Envelope{})
+	require.Equal(t, rejection, err)
+	require.Equal(t, rejection, broadcastError(err))
+}
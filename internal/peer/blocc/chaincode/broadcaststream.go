@@ -0,0 +1,191 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"sync"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/pinning"
+	"github.com/pkg/errors"
+)
+
+// BroadcastStreams keeps a long-lived broadcast stream per orderer endpoint,
+// over which the approvals are pipelined: an approval is sent without waiting
+// for the approvals sent before it to be acknowledged, up to window approvals
+// awaiting their acknowledgement per stream. The orderer acknowledges the
+// envelopes of a stream in the order they were sent. A stream that failed is
+// dialed again by the next approval sent to its endpoint.
+type BroadcastStreams struct {
+	window int
+	dial   func(endpoint, rootCertsPath string) (ab.AtomicBroadcast_BroadcastClient, error)
+
+	mutex   sync.Mutex
+	streams map[string]*broadcastStream
+}
+
+// NewBroadcastStreams returns the broadcast streams pipelining up to window
+// approvals each, the orderers being verified against their pins by pinner.
+func NewBroadcastStreams(window int, pinner *pinning.Pinner) *BroadcastStreams {
+	if window < 1 {
+		window = 1
+	}
+	return &BroadcastStreams{
+		window: window,
+		dial: func(endpoint, rootCertsPath string) (ab.AtomicBroadcast_BroadcastClient, error) {
+			return dialBroadcastStream(endpoint, rootCertsPath, pinner)
+		},
+		streams: map[string]*broadcastStream{},
+	}
+}
+
+func dialBroadcastStream(endpoint, rootCertsPath string, pinner *pinning.Pinner) (ab.AtomicBroadcast_BroadcastClient, error) {
+	clientConfig, err := configOrdererSettings(endpoint, rootCertsPath, pinner)
+	oc, err := common.NewOrdererClientFromEnvWithParams(endpoint, clientConfig, err)
+	if err != nil {
+		return nil, err
+	}
+	return oc.Broadcast()
+}
+
+// Client returns the broadcast client sending over the stream of the
+// endpoint, the stream being dialed with the TLS root certs of rootCertsPath
+// if it is not connected. Closing the client leaves the stream open.
+func (b *BroadcastStreams) Client(endpoint, rootCertsPath string) common.BroadcastClient {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	s, ok := b.streams[endpoint]
+	if !ok {
+		s = &broadcastStream{endpoint: endpoint, window: make(chan struct{}, b.window)}
+		b.streams[endpoint] = s
+	}
+	return &streamClient{stream: s, dial: func() (ab.AtomicBroadcast_BroadcastClient, error) {
+		return b.dial(endpoint, rootCertsPath)
+	}}
+}
+
+// Close closes the streams, failing the approvals awaiting their
+// acknowledgement.
+func (b *BroadcastStreams) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, s := range b.streams {
+		s.close()
+	}
+	b.streams = map[string]*broadcastStream{}
+}
+
+// broadcastStream is the stream of an orderer endpoint.
+type broadcastStream struct {
+	endpoint string
+	// window holds a token per approval awaiting its acknowledgement
+	window chan struct{}
+
+	// mutex guards conn and orders the sends with their acknowledgements
+	mutex sync.Mutex
+	conn  *streamConn
+}
+
+// streamConn is a connected stream, acks holding the channels the
+// acknowledgements of the envelopes sent are delivered to, in order.
+type streamConn struct {
+	client ab.AtomicBroadcast_BroadcastClient
+	acks   chan chan error
+}
+
+func (s *broadcastStream) send(env *cb.Envelope, dial func() (ab.AtomicBroadcast_BroadcastClient, error)) error {
+	s.window <- struct{}{}
+	defer func() { <-s.window }()
+
+	ack := make(chan error, 1)
+	s.mutex.Lock()
+	conn := s.conn
+	if conn == nil {
+		client, err := dial()
+		if err != nil {
+			s.mutex.Unlock()
+			return err
+		}
+		conn = &streamConn{client: client, acks: make(chan chan error, cap(s.window))}
+		s.conn = conn
+		go s.receive(conn)
+	}
+	if err := conn.client.Send(env); err != nil {
+		s.fail(conn)
+		s.mutex.Unlock()
+		return errors.WithMessagef(err, "could not send to orderer node %s", s.endpoint)
+	}
+	conn.acks <- ack
+	s.mutex.Unlock()
+
+	return <-ack
+}
+
+// receive delivers the acknowledgements of the envelopes sent over conn until
+// it fails, failing then the envelopes awaiting their acknowledgement.
+func (s *broadcastStream) receive(conn *streamConn) {
+	for {
+		msg, err := conn.client.Recv()
+		if err != nil {
+			s.mutex.Lock()
+			s.fail(conn)
+			s.mutex.Unlock()
+			err = errors.WithMessagef(err, "broadcast stream to orderer node %s failed", s.endpoint)
+			for {
+				select {
+				case ack := <-conn.acks:
+					ack <- err
+				default:
+					return
+				}
+			}
+		}
+
+		ack := <-conn.acks
+		if msg.Status != cb.Status_SUCCESS {
+			ack <- &common.BroadcastStatusError{Status: msg.Status, Info: msg.Info}
+			continue
+		}
+		ack <- nil
+	}
+}
+
+// fail closes conn so that the next envelope dials the stream again. The
+// mutex must be held.
+func (s *broadcastStream) fail(conn *streamConn) {
+	if s.conn == conn {
+		s.conn = nil
+		conn.client.CloseSend()
+	}
+}
+
+func (s *broadcastStream) close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.conn != nil {
+		s.fail(s.conn)
+	}
+}
+
+// streamClient sends over the stream of an endpoint.
+type streamClient struct {
+	stream *broadcastStream
+	dial   func() (ab.AtomicBroadcast_BroadcastClient, error)
+}
+
+func (c *streamClient) Send(env *cb.Envelope) error {
+	return c.stream.send(env, c.dial)
+}
+
+// Close leaves the stream open for the next approvals.
+func (c *streamClient) Close() error {
+	return nil
+}
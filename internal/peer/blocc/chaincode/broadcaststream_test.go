@@ -0,0 +1,149 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeBroadcastStream acknowledges the envelopes once released, with the
+// statuses of their payload, until it is broken.
+type fakeBroadcastStream struct {
+	grpc.ClientStream
+	sent    chan *cb.Envelope
+	release chan error
+}
+
+func newFakeBroadcastStream() *fakeBroadcastStream {
+	return &fakeBroadcastStream{sent: make(chan *cb.Envelope, 100), release: make(chan error, 100)}
+}
+
+func (f *fakeBroadcastStream) Send(env *cb.Envelope) error {
+	f.sent <- env
+	return nil
+}
+
+func (f *fakeBroadcastStream) Recv() (*ab.BroadcastResponse, error) {
+	if err := <-f.release; err != nil {
+		return nil, err
+	}
+	env := <-f.sent
+	if string(env.Payload) != "" {
+		return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: string(env.Payload)}, nil
+	}
+	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}, nil
+}
+
+func (f *fakeBroadcastStream) CloseSend() error {
+	return nil
+}
+
+// fakeDialer dials fake broadcast streams, endpoint down:7050 being down.
+type fakeDialer struct {
+	mutex  sync.Mutex
+	dialed []*fakeBroadcastStream
+}
+
+func (d *fakeDialer) dial(endpoint, rootCertsPath string) (ab.AtomicBroadcast_BroadcastClient, error) {
+	if endpoint == "down:7050" {
+		return nil, errors.New("connection refused")
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	stream := newFakeBroadcastStream()
+	d.dialed = append(d.dialed, stream)
+	return stream, nil
+}
+
+// stream returns the i-th stream dialed once it was sent n envelopes.
+func (d *fakeDialer) stream(t *testing.T, i, n int) *fakeBroadcastStream {
+	var stream *fakeBroadcastStream
+	require.Eventually(t, func() bool {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		if len(d.dialed) <= i {
+			return false
+		}
+		stream = d.dialed[i]
+		return len(stream.sent) == n
+	}, time.Second, time.Millisecond)
+	return stream
+}
+
+func (d *fakeDialer) count() int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return len(d.dialed)
+}
+
+func newFakeBroadcastStreams(window int) (*BroadcastStreams, *fakeDialer) {
+	dialer := &fakeDialer{}
+	streams := NewBroadcastStreams(window, nil)
+	streams.dial = dialer.dial
+	return streams, dialer
+}
+
+func TestBroadcastStreamsPipelining(t *testing.T) {
+	streams, dialer := newFakeBroadcastStreams(3)
+
+	// the approvals are sent over the same stream without waiting for the
+	// acknowledgements of the approvals sent before them
+	results := make(chan error, 3)
+	var wg sync.WaitGroup
+	for _, payload := range []string{"", "", "bad approval"} {
+		client := streams.Client("orderer:7050", "ca.pem")
+		wg.Add(1)
+		go func(payload string) {
+			defer wg.Done()
+			results <- client.Send(&cb.Envelope{Payload: []byte(payload)})
+		}(payload)
+	}
+	stream := dialer.stream(t, 0, 3)
+	for i := 0; i < 3; i++ {
+		stream.release <- nil
+	}
+	wg.Wait()
+	close(results)
+	var rejections []error
+	for err := range results {
+		if err != nil {
+			rejections = append(rejections, err)
+		}
+	}
+	require.Equal(t, []error{&common.BroadcastStatusError{Status: cb.Status_BAD_REQUEST, Info: "bad approval"}}, rejections)
+	require.Equal(t, 1, dialer.count())
+}
+
+func TestBroadcastStreamsFailure(t *testing.T) {
+	streams, dialer := newFakeBroadcastStreams(2)
+
+	err := streams.Client("down:7050", "ca.pem").Send(&cb.Envelope{})
+	require.EqualError(t, err, "connection refused")
+	require.True(t, errors.Is(broadcastError(err), bloccerrors.ErrOrdererUnavailable))
+
+	// the approvals awaiting their acknowledgement fail with the stream,
+	// which is dialed again by the next approval
+	client := streams.Client("orderer:7050", "ca.pem")
+	result := make(chan error, 1)
+	go func() { result <- client.Send(&cb.Envelope{}) }()
+	dialer.stream(t, 0, 1).release <- io.EOF
+	require.EqualError(t, <-result, "broadcast stream to orderer node orderer:7050 failed: EOF")
+
+	go func() { result <- client.Send(&cb.Envelope{}) }()
+	dialer.stream(t, 1, 1).release <- nil
+	require.NoError(t, <-result)
+}
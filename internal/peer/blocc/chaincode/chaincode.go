@@ -10,22 +10,30 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 )
 
 const (
-	bloccName        = "bscc"
-	approveFuncName  = "ApproveSensoryReading"
-	simulateFuncName = "SimulateForkAttempt"
+	bloccName           = "bscc"
+	approveFuncName     = "ApproveSensoryReading"
+	simulateFuncName    = "SimulateForkAttempt"
+	sensorChaincodeName = "sensor_chaincode"
+	// metadataTransientKey is the transient field of the approval metadata
+	metadataTransientKey = "metadata"
 )
 
 var logger = flogging.MustGetLogger("cli.blocc.chaincode")
 
 // Cmd returns the cobra command for Chaincode
 func Cmd(cryptoProvider bccsp.BCCSP) *cobra.Command {
-	chaincodeCmd.AddCommand(ApproveForThisPeerCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(ApproveForThisPeerCmd(nil, cryptoProvider, nil, nil, nil))
 	chaincodeCmd.AddCommand(SimulateForkAttemptCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(RegisterSensorCmd(nil, cryptoProvider))
+	chaincodeCmd.AddCommand(RegisterApproverCmd(nil, cryptoProvider))
 
 	logger.Debugf("bloccCmd: %v", chaincodeCmd)
 
@@ -34,6 +42,7 @@ func Cmd(cryptoProvider bccsp.BCCSP) *cobra.Command {
 
 var (
 	ordererAddress        string
+	bftOrdererAddresses   []string
 	rootCertFilePath      string
 	channelID             string
 	txID                  string
@@ -42,6 +51,23 @@ var (
 	connectionProfilePath string
 	waitForEvent          bool
 	waitForEventTimeout   time.Duration
+	fromBlock             uint64
+	drainTimeout          time.Duration
+	sensor                string
+	transient             string
+	inputFile             string
+	outputFile            string
+	mspID                 string
+	certFile              string
+	experimentFile        string
+	reportFile            string
+	traceID               string
+	sensorID              string
+	fromTime              string
+	toTime                string
+	bundleFile            string
+	sensorManifestFile    string
+	batchSize             int
 )
 
 var chaincodeCmd = &cobra.Command{
@@ -50,9 +76,20 @@ var chaincodeCmd = &cobra.Command{
 	Long:  "Perform blocc protocol related operations, not intended to be called directly by end users",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
+		LoadMessages()
 	},
 }
 
+// LoadMessages selects the language of the messages of the commands, as
+// configured for the peer, the messages being given in English if the
+// catalog of the locale cannot be loaded.
+func LoadMessages() {
+	options := config.GetOptions(viper.GetViper())
+	if err := messages.Default.Load(options.Locale, options.MessageCatalogDir); err != nil {
+		logger.Warningf("Failed to load the messages of locale %s: %s", options.Locale, err)
+	}
+}
+
 var flags *pflag.FlagSet
 
 func init() {
@@ -64,6 +101,8 @@ func ResetFlags() {
 	flags = &pflag.FlagSet{}
 
 	flags.StringVarP(&ordererAddress, "ordererAddress", "o", "", "The address of the orderer to connect to")
+	flags.StringSliceVarP(&bftOrdererAddresses, "bftOrdererAddresses", "", nil,
+		"The addresses of the nodes of the BFT ordering service to broadcast to instead of --ordererAddress, f+1 of the n nodes having to accept the transaction")
 	flags.StringVarP(&rootCertFilePath, "rootCertFilePath", "", "", "If TLS is enabled, the path to the TLS root cert file of the orderer to connect to")
 	flags.StringVarP(&channelID, "channelID", "c", "", "The channel on which this command should be executed")
 	flags.StringVarP(&txID, "txID", "t", "", "The transaction ID to approve using for this command")
@@ -76,6 +115,23 @@ func ResetFlags() {
 		"Whether to wait for the event from each peer's deliver filtered service signifying that the transaction has been committed successfully")
 	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
 		"Time to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully")
+	flags.StringVarP(&sensor, "sensor", "", "", "The JSON encoded sensor registry entry, e.g. '{\"id\":\"sensor1\",\"pairedWith\":\"sensor2\",\"location\":{\"latitude\":51.4988,\"longitude\":-0.1749,\"floor\":2}}'")
+	flags.StringVarP(&transient, "transient", "", "", "Transient map of arguments in JSON encoding, kept out of the transaction recorded in the block")
+	flags.StringVarP(&inputFile, "inputFile", "", "", "The file holding the offline approval message to process")
+	flags.StringVarP(&outputFile, "outputFile", "", "", "The file to write the resulting offline approval message to")
+	flags.StringVarP(&mspID, "mspID", "", "", "The MSP ID of the offline approving identity")
+	flags.StringVarP(&certFile, "certFile", "", "", "The PEM encoded certificate of the offline approving identity")
+	flags.DurationVar(&drainTimeout, "drainTimeout", 5*time.Minute, "Time to wait for the in-flight approval to complete and the pending approval requests to be saved")
+	flags.StringVarP(&experimentFile, "experimentFile", "", "", "The JSON file describing the reorganization experiment or fork drill to run")
+	flags.StringVarP(&reportFile, "reportFile", "", "", "The file to write the JSON report of the experiment or import to, the report of an experiment being written to stdout otherwise")
+	flags.StringVarP(&traceID, "traceID", "", "", "The trace ID of the approval request, recorded with the approval transaction")
+	flags.Uint64VarP(&fromBlock, "fromBlock", "", 0, "The number of the block from which to scan for sensory readings")
+	flags.StringVarP(&sensorID, "sensorID", "", "", "The ID of the sensor whose readings to export")
+	flags.StringVarP(&fromTime, "from", "", "", "The RFC 3339 time from which to export readings, e.g. 2024-01-01T00:00:00Z")
+	flags.StringVarP(&toTime, "to", "", "", "The RFC 3339 time until which to export readings")
+	flags.StringVarP(&bundleFile, "bundleFile", "", "", "The file to write the gzipped bundle to")
+	flags.StringVarP(&sensorManifestFile, "file", "f", "", "The YAML or CSV manifest of the sensors to import")
+	flags.IntVarP(&batchSize, "batchSize", "", 50, "The number of sensors registered by each transaction")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
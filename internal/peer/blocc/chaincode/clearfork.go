@@ -0,0 +1,106 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const clearForkFuncName = "ClearForkStatus"
+
+// ClearFork removes the fork information a peer keeps for a channel, so that
+// fork experiments can be repeated on a clean channel.
+type ClearFork struct {
+	Command   *cobra.Command
+	ChannelID string
+	Querier   chaincodeQuerier
+	Writer    io.Writer
+}
+
+func ClearForkCmd(c *ClearFork, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear-fork",
+		Short: "Clear the fork information of a channel on a peer",
+		Long:  "Remove the fork information a peer keeps for a channel, e.g. after a SimulateForkAttempt experiment, so that the channel is no longer reported as forked",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if c == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					PeerAddresses:         []string{peerAddress},
+					TLSRootCertFiles:      []string{tlsRootCertFile},
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+				if len(cc.EndorserClients) == 0 {
+					return errors.New("no endorser clients")
+				}
+
+				c = &ClearFork{
+					Command:   cmd,
+					ChannelID: channelID,
+					Querier: &peerQuerier{
+						Signer:         cc.Signer,
+						EndorserClient: cc.EndorserClients[0],
+					},
+					Writer: os.Stdout,
+				}
+			}
+			return c.ClearFork()
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (c *ClearFork) ClearFork() error {
+	if c.ChannelID == "" {
+		return errors.New("ChannelID not specified")
+	}
+
+	if c.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		c.Command.SilenceUsage = true
+	}
+
+	clearedBytes, err := c.Querier.query(bloccName, clearForkFuncName, c.ChannelID)
+	if err != nil {
+		return errors.WithMessage(err, "failed to clear fork information")
+	}
+
+	var cleared bool
+	if err := json.Unmarshal(clearedBytes, &cleared); err != nil {
+		return errors.Wrap(err, "failed to unmarshal result")
+	}
+
+	if cleared {
+		fmt.Fprintln(c.Writer, messages.Sprintf(messages.ForkCleared, c.ChannelID))
+	} else {
+		fmt.Fprintln(c.Writer, messages.Sprintf(messages.NoForkInformation, c.ChannelID))
+	}
+	return nil
+}
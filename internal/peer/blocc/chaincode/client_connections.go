@@ -1,14 +1,22 @@
 package chaincode
 
 import (
+	"context"
 	"crypto/tls"
 	"io/ioutil"
+	"net"
 	"strings"
 	"time"
 
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/pinning"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/proxy"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/sockopt"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/pkg/errors"
@@ -33,6 +41,7 @@ type ClientConnectionsInput struct {
 	EndorserRequired      bool
 	OrdererRequired       bool
 	OrderingEndpoint      string
+	BFTOrderingEndpoints  []string
 	OrdererCAFile         string
 	ChannelID             string
 	PeerAddresses         []string
@@ -40,6 +49,16 @@ type ClientConnectionsInput struct {
 	ConnectionProfilePath string
 	TargetPeer            string
 	TLSEnabled            bool
+	// AllowOrdererUnavailable defers the failure to connect to an
+	// unavailable orderer to the broadcasts, so that the transactions can
+	// be signed and stored until the orderer is available again
+	AllowOrdererUnavailable bool
+	// BroadcastStreams, if set, carries the broadcasts over the long-lived
+	// streams of the orderer endpoints
+	BroadcastStreams *BroadcastStreams
+	// Pinner verifies the TLS certificates of the orderers against their
+	// pins, reporting the mismatches if not nil
+	Pinner *pinning.Pinner
 }
 
 // NewClientConnections creates a new set of client connections based on the
@@ -68,9 +87,23 @@ func NewClientConnections(input *ClientConnectionsInput, cryptoProvider bccsp.BC
 	logger.Debugf("EndorserClients: %+v", c.EndorserClients)
 	logger.Debugf("DeliverClients: %+v", c.DeliverClients)
 
-	if input.OrdererRequired {
-		err := c.setOrdererClient(input.OrderingEndpoint, input.OrdererCAFile)
-		if err != nil {
+	if input.OrdererRequired && len(input.BFTOrderingEndpoints) > 0 {
+		logger.Debugf("Broadcasting to %d of the BFT orderers %v", bftQuorum(len(input.BFTOrderingEndpoints)), input.BFTOrderingEndpoints)
+		bftClient := newBFTBroadcastClient(input.BFTOrderingEndpoints, input.OrdererCAFile, input.Pinner)
+		if streams := input.BroadcastStreams; streams != nil {
+			bftClient.dial = func(endpoint string) (common.BroadcastClient, error) {
+				return streams.Client(endpoint, input.OrdererCAFile), nil
+			}
+		}
+		c.BroadcastClient = bftClient
+	} else if input.OrdererRequired && input.BroadcastStreams != nil && input.OrderingEndpoint != "" {
+		c.BroadcastClient = input.BroadcastStreams.Client(input.OrderingEndpoint, input.OrdererCAFile)
+	} else if input.OrdererRequired {
+		err := c.setOrdererClient(input.ChannelID, input.OrderingEndpoint, input.OrdererCAFile, input.Pinner)
+		if err != nil && input.AllowOrdererUnavailable && errors.Is(err, bloccerrors.ErrOrdererUnavailable) {
+			logger.Warningf("Orderer %s unavailable: %s", input.OrderingEndpoint, err)
+			c.BroadcastClient = &unavailableBroadcastClient{err: err}
+		} else if err != nil {
 			return nil, err
 		}
 	}
@@ -127,7 +160,7 @@ func (c *ClientConnections) validatePeerConnectionParameters(input *ClientConnec
 	}
 
 	multiplePeersAllowed := map[string]bool{
-		"approveforthispeer": false,
+		approveForThisPeerCmdName: false,
 	}
 	if !multiplePeersAllowed[input.CommandName] && len(input.PeerAddresses) > 1 {
 		return errors.Errorf("'%s' command supports one peer. %d peers provided", input.CommandName, len(input.PeerAddresses))
@@ -201,7 +234,7 @@ func (c *ClientConnections) setCertificate() error {
 	return nil
 }
 
-func (c *ClientConnections) setOrdererClient(ordererAddress string, rootCertsPath string) error {
+func (c *ClientConnections) setOrdererClient(channelID, ordererAddress, rootCertsPath string, pinner *pinning.Pinner) error {
 	if ordererAddress == "" {
 		// if we're here we didn't get an orderer endpoint from the command line
 		// so we'll attempt to get one from cscc - bless it
@@ -227,11 +260,14 @@ func (c *ClientConnections) setOrdererClient(ordererAddress string, rootCertsPat
 	}
 
 	logger.Debugf("About to get broadcast client")
-	clientConfig, err := configOrdererSettings(ordererAddress, rootCertsPath)
-	broadcastClient, err := common.GetBroadcastClientWithParams(ordererAddress, clientConfig, err)
+	clientConfig, err := configOrdererSettings(ordererAddress, rootCertsPath, pinner)
 	if err != nil {
 		return errors.WithMessage(err, "failed to retrieve broadcast client")
 	}
+	broadcastClient, err := common.GetBroadcastClientWithParams(ordererAddress, clientConfig, nil)
+	if err != nil {
+		return errors.WithMessage(bloccerrors.WithCategory(err, bloccerrors.ErrOrdererUnavailable), "failed to retrieve broadcast client")
+	}
 	logger.Debugf("Got broadcast client")
 
 	c.BroadcastClient = broadcastClient
@@ -239,7 +275,21 @@ func (c *ClientConnections) setOrdererClient(ordererAddress string, rootCertsPat
 	return nil
 }
 
-func configOrdererSettings(ordererAddress string, rootCertsPath string) (comm.ClientConfig, error) {
+// unavailableBroadcastClient fails the broadcasts with the error of the
+// connection to an unavailable orderer.
+type unavailableBroadcastClient struct {
+	err error
+}
+
+func (u *unavailableBroadcastClient) Send(env *cb.Envelope) error {
+	return u.err
+}
+
+func (u *unavailableBroadcastClient) Close() error {
+	return nil
+}
+
+func configOrdererSettings(ordererAddress string, rootCertsPath string, pinner *pinning.Pinner) (comm.ClientConfig, error) {
 	clientConfig := comm.ClientConfig{}
 	connTimeout := 3 * time.Second
 	clientConfig.DialTimeout = connTimeout
@@ -250,6 +300,7 @@ func configOrdererSettings(ordererAddress string, rootCertsPath string) (comm.Cl
 		ServerNameOverride: strings.Split(ordererAddress, ":")[0],
 	}
 
+	options := config.GetOptions(viper.GetViper())
 	if secOpts.UseTLS {
 		caPEM, res := ioutil.ReadFile(rootCertsPath)
 		if res != nil {
@@ -257,11 +308,49 @@ func configOrdererSettings(ordererAddress string, rootCertsPath string) (comm.Cl
 			return clientConfig, err
 		}
 		secOpts.ServerRootCAs = [][]byte{caPEM}
+
+		if options.ApprovalOrdererCABundle != "" {
+			bundlePEM, err := ioutil.ReadFile(options.ApprovalOrdererCABundle)
+			if err != nil {
+				return clientConfig, errors.Wrap(err, "unable to load the orderer CA bundle")
+			}
+			secOpts.ServerRootCAs = append(secOpts.ServerRootCAs, bundlePEM)
+		}
+
+		verify, err := pinner.Verifier(ordererAddress, options.ApprovalOrdererPins)
+		if err != nil {
+			return clientConfig, err
+		}
+		secOpts.VerifyCertificate = verify
 	}
 
 	clientConfig.SecOpts = secOpts
-	clientConfig.MaxRecvMsgSize = comm.DefaultMaxRecvMsgSize
-	clientConfig.MaxSendMsgSize = comm.DefaultMaxSendMsgSize
+	grpcOptions := options.ApprovalGRPC
+	clientConfig.KaOpts = comm.KeepaliveOptions{
+		ClientInterval: grpcOptions.KeepaliveInterval,
+		ClientTimeout:  grpcOptions.KeepaliveTimeout,
+	}
+	clientConfig.MaxRecvMsgSize = grpcOptions.MaxRecvMsgSize
+	clientConfig.MaxSendMsgSize = grpcOptions.MaxSendMsgSize
+
+	socketOptions := sockopt.Options{DSCP: grpcOptions.DSCP, TCPKeepAlive: grpcOptions.TCPKeepAlive}
+	netDialer, err := socketOptions.Dialer()
+	if err != nil {
+		return clientConfig, err
+	}
+	proxyConfig := proxy.Config{URL: options.ApprovalProxyURL, Endpoints: options.ApprovalProxyEndpoints}
+	proxyURL, err := proxyConfig.Resolve(ordererAddress)
+	if err != nil {
+		return clientConfig, err
+	}
+	if proxyURL != nil {
+		logger.Debugf("Dialing orderer %s through proxy %s", ordererAddress, proxyURL.Redacted())
+		clientConfig.Dialer = proxy.DialerVia(netDialer, proxyURL)
+	} else if socketOptions.IsSet() {
+		clientConfig.Dialer = func(ctx context.Context, address string) (net.Conn, error) {
+			return netDialer.DialContext(ctx, "tcp", address)
+		}
+	}
 
 	return clientConfig, nil
 }
@@ -0,0 +1,132 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const drainFuncName = "DrainApprovals"
+
+// Drain stops a peer from starting approvals ahead of maintenance and waits
+// until its in-flight approval completed and its pending approval requests
+// were saved for the next start.
+type Drain struct {
+	Command      *cobra.Command
+	Querier      *peerQuerier
+	Timeout      time.Duration
+	PollInterval time.Duration
+	Writer       io.Writer
+}
+
+// drainStatus mirrors the status returned by BSCC.
+type drainStatus struct {
+	Draining bool   `json:"draining"`
+	Drained  bool   `json:"drained"`
+	Saved    int    `json:"saved"`
+	Error    string `json:"error,omitempty"`
+}
+
+func DrainCmd(d *Drain, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Drain the approvals of a peer before maintenance",
+		Long:  "Stop a peer from starting approvals, wait for its in-flight approval to complete and for its pending approval requests to be saved, so that it can be restarted without losing approvals",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if d == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					PeerAddresses:         []string{peerAddress},
+					TLSRootCertFiles:      []string{tlsRootCertFile},
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+				if len(cc.EndorserClients) == 0 {
+					return errors.New("no endorser clients")
+				}
+
+				d = &Drain{
+					Command: cmd,
+					Querier: &peerQuerier{
+						Signer:         cc.Signer,
+						EndorserClient: cc.EndorserClients[0],
+					},
+					Timeout:      drainTimeout,
+					PollInterval: time.Second,
+					Writer:       os.Stdout,
+				}
+			}
+			return d.Drain()
+		},
+	}
+	flagList := []string{
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+		"drainTimeout",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (d *Drain) Drain() error {
+	if d.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		d.Command.SilenceUsage = true
+	}
+
+	deadline := time.Now().Add(d.Timeout)
+	for {
+		status, err := d.status()
+		if err != nil {
+			return err
+		}
+
+		if status.Drained {
+			if status.Error != "" {
+				return errors.Errorf("approvals drained but %d pending approval requests could not be saved: %s", status.Saved, status.Error)
+			}
+			fmt.Fprintln(d.Writer, messages.Sprintf(messages.ApprovalsDrained, status.Saved))
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return errors.Errorf("approvals not drained within %s", d.Timeout)
+		}
+		time.Sleep(d.PollInterval)
+	}
+}
+
+func (d *Drain) status() (*drainStatus, error) {
+	statusBytes, err := d.Querier.query(bloccName, drainFuncName)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to drain approvals")
+	}
+
+	status := &drainStatus{}
+	if err := json.Unmarshal(statusBytes, status); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal drain status")
+	}
+
+	return status, nil
+}
@@ -0,0 +1,230 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// DrillReport is the timing report of a fork drill. Times are in seconds
+// since the first fork attempt.
+type DrillReport struct {
+	ChannelID       string           `json:"channelID"`
+	DivergentBlocks int              `json:"divergentBlocks"`
+	StartedAt       time.Time        `json:"startedAt"`
+	FinishedAt      time.Time        `json:"finishedAt"`
+	Injections      []ReorgInjection `json:"injections"`
+	// RecoveryTriggeredAt is when the recovery phase started, after every
+	// observer detected the fork or the detection phase timed out
+	RecoveryTriggeredAt float64             `json:"recoveryTriggeredAt"`
+	Observers           []*DrillObservation `json:"observers"`
+	// Passed is set if every observer detected the fork and recovered from
+	// it in time
+	Passed bool `json:"passed"`
+}
+
+// DrillObservation is what an observer peer went through during the drill.
+type DrillObservation struct {
+	Address string `json:"address"`
+	// Detected is set once the observer reported the fork, DetectionLatency
+	// being the time from the first fork attempt until then
+	Detected         bool    `json:"detected"`
+	DetectionLatency float64 `json:"detectionLatency,omitempty"`
+	// TriggerError is the error clearing the fork information of the
+	// observer, if the recovery could not be triggered
+	TriggerError string `json:"triggerError,omitempty"`
+	// Recovered is set once the observer no longer reported the fork after
+	// the recovery trigger, RecoveryDuration being the time from the trigger
+	// until then
+	Recovered        bool    `json:"recovered"`
+	RecoveryDuration float64 `json:"recoveryDuration,omitempty"`
+	// BlocksRefetched is the number of blocks the observer committed between
+	// the recovery trigger and its recovery
+	BlocksRefetched uint64 `json:"blocksRefetched"`
+	PollErrors      int    `json:"pollErrors"`
+	LastError       string `json:"lastError,omitempty"`
+
+	triggerHeight uint64
+}
+
+// Drill runs a fork drill for operational readiness exercises: it injects
+// the divergent blocks of the experiment, waits until the observers detect
+// the fork, then triggers their recovery by clearing their fork information
+// and waits until they no longer report the fork. Each phase lasts at most
+// the timeout of the experiment.
+type Drill struct {
+	*Reorg
+}
+
+func DrillCmd(d *Drill, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "drill",
+		Short: "Run a fork drill",
+		Long:  "Inject a fork with the forking peers of --experimentFile, wait for the observer peers to detect it, trigger their recovery, and write a JSON timing report of the drill to --reportFile or stdout",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if d == nil {
+				r, err := newReorg(cmd, cryptoProvider)
+				if err != nil {
+					return err
+				}
+				d = &Drill{Reorg: r}
+
+				if reportFile != "" {
+					f, err := os.Create(reportFile)
+					if err != nil {
+						return errors.Wrap(err, "failed to create report file")
+					}
+					defer f.Close()
+					d.Writer = f
+				}
+			}
+			return d.Run()
+		},
+	}
+	flagList := []string{
+		"experimentFile",
+		"reportFile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+// Run runs the drill and writes its report.
+func (d *Drill) Run() error {
+	if err := d.Experiment.Validate(); err != nil {
+		return err
+	}
+	if len(d.Observers) != len(d.Experiment.Observers) {
+		return errors.New("every observer must have a querier")
+	}
+
+	if d.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		d.Command.SilenceUsage = true
+	}
+	if d.now == nil {
+		d.now = time.Now
+	}
+	if d.sleep == nil {
+		d.sleep = time.Sleep
+	}
+
+	report := &DrillReport{
+		ChannelID:       d.Experiment.ChannelID,
+		DivergentBlocks: d.Experiment.DivergentBlocks,
+	}
+	for _, peer := range d.Experiment.Observers {
+		report.Observers = append(report.Observers, &DrillObservation{Address: peer.Address})
+	}
+
+	report.StartedAt = d.now()
+	since := func() float64 { return d.now().Sub(report.StartedAt).Seconds() }
+
+	logger.Infof("Drill phase 1: injecting %d divergent blocks on channel %s", d.Experiment.DivergentBlocks, d.Experiment.ChannelID)
+	for block := 1; block <= d.Experiment.DivergentBlocks; block++ {
+		injection := ReorgInjection{Block: block, SubmittedAt: since()}
+		if err := d.Injector.SimulateForkAttempt(); err != nil {
+			logger.Warningf("Fork attempt %d failed: %s", block, err)
+			injection.Error = err.Error()
+		}
+		report.Injections = append(report.Injections, injection)
+	}
+	d.poll(report, func(i int, observation *DrillObservation) bool {
+		if !observation.Detected {
+			if forked, ok := d.forked(i, observation); ok && forked {
+				observation.Detected = true
+				observation.DetectionLatency = since()
+			}
+		}
+		return observation.Detected
+	})
+
+	logger.Infof("Drill phase 2: triggering the recovery of %d observers", len(d.Observers))
+	report.RecoveryTriggeredAt = since()
+	triggeredAt := d.now()
+	for i, observation := range report.Observers {
+		observation.triggerHeight, _ = d.observerHeight(i, observation)
+		if _, err := d.Observers[i].query(bloccName, clearForkFuncName, d.Experiment.ChannelID); err != nil {
+			observation.TriggerError = err.Error()
+		}
+	}
+	d.poll(report, func(i int, observation *DrillObservation) bool {
+		if !observation.Recovered && observation.TriggerError == "" {
+			if forked, ok := d.forked(i, observation); ok && !forked {
+				observation.Recovered = true
+				observation.RecoveryDuration = d.now().Sub(triggeredAt).Seconds()
+				if height, err := d.observerHeight(i, observation); err == nil && height > observation.triggerHeight {
+					observation.BlocksRefetched = height - observation.triggerHeight
+				}
+			}
+		}
+		return observation.Recovered || observation.TriggerError != ""
+	})
+	report.FinishedAt = d.now()
+
+	report.Passed = true
+	for _, observation := range report.Observers {
+		report.Passed = report.Passed && observation.Detected && observation.Recovered
+	}
+	logger.Infof("Drill finished after %.1fs, passed: %t", since(), report.Passed)
+
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal report")
+	}
+	_, err = d.Writer.Write(append(reportBytes, '\n'))
+	return errors.Wrap(err, "failed to write report")
+}
+
+// poll polls the observers with done every poll interval until it returns
+// true for all of them, or the timeout of the experiment elapses.
+func (d *Drill) poll(report *DrillReport, done func(i int, observation *DrillObservation) bool) {
+	deadline := d.now().Add(time.Duration(d.Experiment.Timeout))
+	for {
+		all := true
+		for i, observation := range report.Observers {
+			all = done(i, observation) && all
+		}
+		if all || !d.now().Before(deadline) {
+			return
+		}
+		d.sleep(time.Duration(d.Experiment.PollInterval))
+	}
+}
+
+// forked returns the fork status of the observer, and false if it could not
+// be read.
+func (d *Drill) forked(i int, observation *DrillObservation) (bool, bool) {
+	statusBytes, err := d.Observers[i].query(bloccName, checkForkStatusFuncName, d.Experiment.ChannelID)
+	if err != nil {
+		observation.PollErrors++
+		observation.LastError = err.Error()
+		return false, false
+	}
+	forked, err := parseForkStatus(statusBytes)
+	if err != nil {
+		observation.PollErrors++
+		observation.LastError = err.Error()
+		return false, false
+	}
+	return forked, true
+}
+
+func (d *Drill) observerHeight(i int, observation *DrillObservation) (uint64, error) {
+	height, err := chainHeight(d.Observers[i], d.Experiment.ChannelID)
+	if err != nil {
+		observation.PollErrors++
+		observation.LastError = err.Error()
+	}
+	return height, err
+}
@@ -0,0 +1,84 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDrillObserver is a fake observer whose fork information is cleared
+// unless clearErr is set.
+type fakeDrillObserver struct {
+	*fakeObserver
+	clearErr error
+}
+
+func (f *fakeDrillObserver) query(chaincodeName string, args ...string) ([]byte, error) {
+	if chaincodeName == bloccName && args[0] == clearForkFuncName {
+		if f.clearErr != nil {
+			return nil, f.clearErr
+		}
+		return []byte("true"), nil
+	}
+	return f.fakeObserver.query(chaincodeName, args...)
+}
+
+func TestDrill(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	injector := &fakeInjector{}
+	buf := &bytes.Buffer{}
+	d := &Drill{Reorg: &Reorg{
+		Experiment: &ReorgExperiment{
+			ChannelID:       "mychannel",
+			OrdererAddress:  "orderer.example.com:7050",
+			ForkingPeers:    []ReorgPeer{{Address: "peer0.org1.example.com:7051"}},
+			Observers:       []ReorgPeer{{Address: "peer0.org2.example.com:9051"}, {Address: "peer0.org3.example.com:11051"}},
+			DivergentBlocks: 3,
+			PollInterval:    jsonDuration(time.Second),
+			Timeout:         jsonDuration(5 * time.Second),
+		},
+		Injector: injector,
+		Observers: []chaincodeQuerier{
+			&fakeDrillObserver{fakeObserver: &fakeObserver{statuses: []string{"false", "true", "true", "false"}}},
+			&fakeDrillObserver{fakeObserver: &fakeObserver{statuses: []string{"true"}}, clearErr: errors.New("access denied")},
+		},
+		Writer: buf,
+		now:    func() time.Time { return now },
+		sleep:  func(d time.Duration) { now = now.Add(d) },
+	}}
+
+	require.NoError(t, d.Run())
+	require.Equal(t, 3, injector.attempts)
+
+	report := &DrillReport{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), report))
+	require.Len(t, report.Injections, 3)
+	require.Equal(t, float64(1), report.RecoveryTriggeredAt)
+	require.Equal(t, float64(2), report.FinishedAt.Sub(report.StartedAt).Seconds())
+	require.False(t, report.Passed)
+
+	require.Equal(t, &DrillObservation{
+		Address:          "peer0.org2.example.com:9051",
+		Detected:         true,
+		DetectionLatency: 1,
+		Recovered:        true,
+		RecoveryDuration: 1,
+		BlocksRefetched:  1,
+	}, report.Observers[0])
+
+	// detected immediately, but its recovery could not be triggered
+	require.Equal(t, &DrillObservation{
+		Address:      "peer0.org3.example.com:11051",
+		Detected:     true,
+		TriggerError: "access denied",
+	}, report.Observers[1])
+}
@@ -0,0 +1,349 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/bundle"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ExportBundle exports the readings of a sensor over a time range, with
+// their approvals and the proofs that both were committed as valid, as a
+// signed bundle that regulators verify offline with bloccverify.
+type ExportBundle struct {
+	Command   *cobra.Command
+	Querier   chaincodeQuerier
+	Signer    bundle.Signer
+	ChannelID string
+	SensorID  string
+	// From and To bound the creation time of the readings, a zero bound
+	// leaving the range open
+	From       time.Time
+	To         time.Time
+	FromBlock  uint64
+	BundleFile string
+	Writer     io.Writer
+	now        func() time.Time
+}
+
+func ExportBundleCmd(e *ExportBundle, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-bundle",
+		Short: "Export the readings of a sensor for regulators",
+		Long:  "Export the readings of a sensor created within --from and --to, their approvals, and the proofs that they were committed as valid as a signed archive that can be verified offline with bloccverify",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if e == nil {
+				from, err := parseBundleTime("from", fromTime)
+				if err != nil {
+					return err
+				}
+				to, err := parseBundleTime("to", toTime)
+				if err != nil {
+					return err
+				}
+
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					ChannelID:             channelID,
+					PeerAddresses:         []string{peerAddress},
+					TLSRootCertFiles:      []string{tlsRootCertFile},
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+				if len(cc.EndorserClients) == 0 {
+					return errors.New("no endorser clients")
+				}
+
+				e = &ExportBundle{
+					Command: cmd,
+					Querier: &peerQuerier{
+						ChannelID:      channelID,
+						Signer:         cc.Signer,
+						EndorserClient: cc.EndorserClients[0],
+					},
+					Signer:     cc.Signer,
+					ChannelID:  channelID,
+					SensorID:   sensorID,
+					From:       from,
+					To:         to,
+					FromBlock:  fromBlock,
+					BundleFile: bundleFile,
+					Writer:     os.Stdout,
+				}
+			}
+			return e.Export()
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"sensorID",
+		"from",
+		"to",
+		"fromBlock",
+		"bundleFile",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func parseBundleTime(flag, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid --%s time '%s', expected RFC 3339", flag, value)
+	}
+	return t, nil
+}
+
+func (e *ExportBundle) Export() error {
+	if e.ChannelID == "" {
+		return errors.New("ChannelID not specified")
+	}
+	if e.SensorID == "" {
+		return errors.New("SensorID not specified")
+	}
+	if e.BundleFile == "" {
+		return errors.New("BundleFile not specified")
+	}
+	if !e.From.IsZero() && !e.To.IsZero() && e.To.Before(e.From) {
+		return errors.New("end of the time range precedes its start")
+	}
+
+	if e.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		e.Command.SilenceUsage = true
+	}
+
+	now := time.Now
+	if e.now != nil {
+		now = e.now
+	}
+
+	tip, err := e.tip()
+	if err != nil {
+		return err
+	}
+	manifest := &bundle.Manifest{
+		ChannelID: e.ChannelID,
+		SensorID:  e.SensorID,
+		From:      e.From,
+		To:        e.To,
+		CreatedAt: now().UTC(),
+		Tip:       tip,
+	}
+	files := map[string][]byte{}
+
+	// the approvals of the readings are committed after them, so those
+	// committed up to the tip are all in the blocks read
+	var sensoryTxIDs []string
+	committed := map[string]bool{}
+	for blockNum := e.FromBlock; blockNum <= tip.Number; blockNum++ {
+		block, err := e.block(blockNum)
+		if err != nil {
+			return err
+		}
+
+		times := transactionTimes(block)
+		for txID := range times {
+			committed[txID] = true
+		}
+		for _, sensoryTxID := range sensoryReadings(block) {
+			if t := times[sensoryTxID]; (!e.From.IsZero() && t.Before(e.From)) || (!e.To.IsZero() && t.After(e.To)) {
+				continue
+			}
+			sensoryTxIDs = append(sensoryTxIDs, sensoryTxID)
+		}
+	}
+	for _, sensoryTxID := range sensoryTxIDs {
+		reading, err := e.exportReading(sensoryTxID, tip, committed, files)
+		if err != nil {
+			return err
+		}
+		if reading != nil {
+			manifest.Readings = append(manifest.Readings, reading)
+		}
+	}
+
+	f, err := os.Create(e.BundleFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create %s", e.BundleFile)
+	}
+	defer f.Close()
+	if err := bundle.Write(f, manifest, files, e.Signer); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %s", e.BundleFile)
+	}
+
+	fmt.Fprintln(e.Writer, messages.Sprintf(messages.BundleExported, len(manifest.Readings), e.SensorID, e.BundleFile))
+	fmt.Fprintln(e.Writer, messages.Sprintf(messages.BundleProofsChained, tip.Number, e.ChannelID, protoutil.BlockHeaderHash(tip)))
+	return nil
+}
+
+// tip returns the header of the last block of the channel, to which the
+// proofs of the bundle are chained.
+func (e *ExportBundle) tip() (*cb.BlockHeader, error) {
+	infoBytes, err := e.Querier.query("qscc", "GetChainInfo", e.ChannelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get chain info")
+	}
+	info := &cb.BlockchainInfo{}
+	if err := proto.Unmarshal(infoBytes, info); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chain info")
+	}
+	if info.Height == 0 {
+		return nil, errors.Errorf("channel %s has no blocks", e.ChannelID)
+	}
+
+	block, err := e.block(info.Height - 1)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header, nil
+}
+
+func (e *ExportBundle) block(blockNum uint64) (*cb.Block, error) {
+	blockBytes, err := e.Querier.query("qscc", "GetBlockByNumber", e.ChannelID, strconv.FormatUint(blockNum, 10))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get block %d", blockNum)
+	}
+	block, err := protoutil.UnmarshalBlock(blockBytes)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to unmarshal block %d", blockNum)
+	}
+	return block, nil
+}
+
+// exportReading adds the files of the reading to files if it is a reading of
+// the sensor, and returns its entry of the manifest. Approvals not committed
+// up to the tip, that is missing from committed, are left out.
+func (e *ExportBundle) exportReading(sensoryTxID string, tip *cb.BlockHeader, committed map[string]bool, files map[string][]byte) (*bundle.Reading, error) {
+	readingBytes, err := e.Querier.query(bloccName, "GetReading", e.ChannelID, sensoryTxID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get reading %s", sensoryTxID)
+	}
+	var decoded struct {
+		SensorID string `json:"sensorID"`
+	}
+	if err := json.Unmarshal(readingBytes, &decoded); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal reading %s", sensoryTxID)
+	}
+	if decoded.SensorID != e.SensorID {
+		return nil, nil
+	}
+
+	proofBytes, err := e.proof(sensoryTxID, tip)
+	if err != nil {
+		return nil, err
+	}
+	files[bundle.ReadingPath(sensoryTxID)] = readingBytes
+	files[bundle.ProofPath(sensoryTxID)] = proofBytes
+
+	pageBytes, err := e.Querier.query(bloccName, "QueryApprovals", sensoryTxID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to query approvals of reading %s", sensoryTxID)
+	}
+	var page struct {
+		Records []json.RawMessage `json:"records"`
+	}
+	if err := json.Unmarshal(pageBytes, &page); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal QueryApprovals result")
+	}
+	recordsBytes, err := json.Marshal(page.Records)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal approval records")
+	}
+	files[bundle.ApprovalsPath(sensoryTxID)] = recordsBytes
+
+	reading := &bundle.Reading{TxID: sensoryTxID, ApprovalTxIDs: []string{}}
+	for _, recordBytes := range page.Records {
+		var record struct {
+			ApprovalTxID string `json:"approvalTxID"`
+		}
+		if err := json.Unmarshal(recordBytes, &record); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal approval record")
+		}
+		if record.ApprovalTxID == "" {
+			continue
+		}
+
+		if !committed[record.ApprovalTxID] {
+			logger.Infof("Leaving out approval %s of reading %s committed after block %d", record.ApprovalTxID, sensoryTxID, tip.Number)
+			continue
+		}
+		proofBytes, err := e.proof(record.ApprovalTxID, tip)
+		if err != nil {
+			return nil, err
+		}
+		files[bundle.ProofPath(record.ApprovalTxID)] = proofBytes
+		reading.ApprovalTxIDs = append(reading.ApprovalTxIDs, record.ApprovalTxID)
+	}
+
+	return reading, nil
+}
+
+// proof returns the proof of the transaction txID chained up to the tip,
+// checked against the tip.
+func (e *ExportBundle) proof(txID string, tip *cb.BlockHeader) ([]byte, error) {
+	proofBytes, err := e.Querier.query(bloccName, "GetReadingProof", e.ChannelID, txID, strconv.FormatUint(tip.Number, 10))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get proof of transaction %s", txID)
+	}
+	proof := &protoutil.ReadingProof{}
+	if err := json.Unmarshal(proofBytes, proof); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal proof of transaction %s", txID)
+	}
+	if err := protoutil.VerifyReadingProof(proof, tip); err != nil {
+		return nil, errors.WithMessagef(err, "invalid proof of transaction %s", txID)
+	}
+
+	return proofBytes, nil
+}
+
+// transactionTimes returns the creation time of the transactions of block,
+// keyed by transaction ID.
+func transactionTimes(block *cb.Block) map[string]time.Time {
+	times := map[string]time.Time{}
+	for _, envBytes := range block.GetData().GetData() {
+		envelope, err := protoutil.UnmarshalEnvelope(envBytes)
+		if err != nil {
+			continue
+		}
+		chdr, err := protoutil.ChannelHeader(envelope)
+		if err != nil || chdr.Timestamp == nil {
+			continue
+		}
+		times[chdr.TxId] = chdr.Timestamp.AsTime()
+	}
+	return times
+}
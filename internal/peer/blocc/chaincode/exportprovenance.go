@@ -0,0 +1,125 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const getReadingProvenanceFuncName = "GetReadingProvenance"
+
+// ExportProvenance exports the chain of custody of a reading, from its
+// sensor to the block committing it, as a W3C PROV-JSON document.
+type ExportProvenance struct {
+	Command   *cobra.Command
+	Querier   chaincodeQuerier
+	ChannelID string
+	TxID      string
+	// OutputFile is the file the document is written to, the Writer if
+	// empty
+	OutputFile string
+	Writer     io.Writer
+}
+
+func ExportProvenanceCmd(e *ExportProvenance, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-provenance",
+		Short: "Export the chain of custody of a reading in W3C PROV format",
+		Long:  "Export the chain of custody of the reading --txID, from its sensor through its gateway, endorsements and approvals to the block committing it, as a W3C PROV-JSON document for provenance tooling",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if e == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					ChannelID:             channelID,
+					PeerAddresses:         []string{peerAddress},
+					TLSRootCertFiles:      []string{tlsRootCertFile},
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+				if len(cc.EndorserClients) == 0 {
+					return errors.New("no endorser clients")
+				}
+
+				e = &ExportProvenance{
+					Command: cmd,
+					Querier: &peerQuerier{
+						ChannelID:      channelID,
+						Signer:         cc.Signer,
+						EndorserClient: cc.EndorserClients[0],
+					},
+					ChannelID:  channelID,
+					TxID:       txID,
+					OutputFile: outputFile,
+					Writer:     os.Stdout,
+				}
+			}
+			return e.Export()
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"txID",
+		"outputFile",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (e *ExportProvenance) Export() error {
+	if e.ChannelID == "" {
+		return errors.New("ChannelID not specified")
+	}
+	if e.TxID == "" {
+		return errors.New("TxID not specified")
+	}
+
+	if e.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		e.Command.SilenceUsage = true
+	}
+
+	documentBytes, err := e.Querier.query(bloccName, getReadingProvenanceFuncName, e.TxID)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get provenance of reading %s", e.TxID)
+	}
+
+	var document bytes.Buffer
+	if err := json.Indent(&document, documentBytes, "", "  "); err != nil {
+		return errors.Wrap(err, "failed to decode provenance document")
+	}
+	document.WriteByte('\n')
+
+	if e.OutputFile == "" {
+		_, err := document.WriteTo(e.Writer)
+		return err
+	}
+	if err := ioutil.WriteFile(e.OutputFile, document.Bytes(), 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", e.OutputFile)
+	}
+	fmt.Fprintln(e.Writer, messages.Sprintf(messages.ProvenanceExported, e.TxID, e.OutputFile))
+	return nil
+}
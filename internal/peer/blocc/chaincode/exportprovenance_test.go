@@ -0,0 +1,58 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvenanceQuerier struct {
+	args []string
+	err  error
+}
+
+func (f *fakeProvenanceQuerier) query(chaincodeName string, args ...string) ([]byte, error) {
+	f.args = append([]string{chaincodeName}, args...)
+	return []byte(`{"prefix":{"blocc":"urn:blocc:"},"entity":{"blocc:reading/tx1":{}}}`), f.err
+}
+
+func TestExportProvenance(t *testing.T) {
+	querier := &fakeProvenanceQuerier{}
+	buf := &bytes.Buffer{}
+	e := &ExportProvenance{Querier: querier, ChannelID: "mychannel", TxID: "tx1", Writer: buf}
+
+	require.NoError(t, e.Export())
+	require.Equal(t, []string{"bscc", "GetReadingProvenance", "tx1"}, querier.args)
+	require.Equal(t, `{
+  "prefix": {
+    "blocc": "urn:blocc:"
+  },
+  "entity": {
+    "blocc:reading/tx1": {}
+  }
+}
+`, buf.String())
+
+	buf.Reset()
+	e.OutputFile = filepath.Join(t.TempDir(), "tx1.json")
+	require.NoError(t, e.Export())
+	require.Equal(t, "Exported the provenance of reading tx1 to "+e.OutputFile+"\n", buf.String())
+	documentBytes, err := ioutil.ReadFile(e.OutputFile)
+	require.NoError(t, err)
+	require.Contains(t, string(documentBytes), `"blocc:reading/tx1": {}`)
+
+	querier.err = errors.New("peer unavailable")
+	require.EqualError(t, e.Export(), "failed to get provenance of reading tx1: peer unavailable")
+
+	e.TxID = ""
+	require.EqualError(t, e.Export(), "TxID not specified")
+}
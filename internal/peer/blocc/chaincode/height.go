@@ -0,0 +1,55 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/pinning"
+	"github.com/pkg/errors"
+)
+
+// OrdererHeight returns the height of the channel as seen by the orderer at
+// ordererAddress, obtained by seeking the newest block over Deliver, the
+// orderer being verified against its pins by pinner.
+func OrdererHeight(ordererAddress, rootCertFilePath, channelID string, pinner *pinning.Pinner) (uint64, error) {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to retrieve default signer")
+	}
+
+	clientConfig, err := configOrdererSettings(ordererAddress, rootCertFilePath, pinner)
+	ordererClient, err := common.NewOrdererClientFromEnvWithParams(ordererAddress, clientConfig, err)
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to retrieve orderer client")
+	}
+
+	deliverClient, err := ordererClient.Deliver()
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to create deliver client for orderer")
+	}
+	defer deliverClient.CloseSend()
+
+	var tlsCertHash []byte
+	if len(ordererClient.Certificate().Certificate) > 0 {
+		tlsCertHash = util.ComputeSHA256(ordererClient.Certificate().Certificate[0])
+	}
+
+	dc := &common.DeliverClient{
+		Signer:      signer,
+		Service:     deliverClient,
+		ChannelID:   channelID,
+		TLSCertHash: tlsCertHash,
+		BestEffort:  true,
+	}
+
+	block, err := dc.GetNewestBlock()
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to get newest block from orderer")
+	}
+
+	return block.Header.Number + 1, nil
+}
@@ -0,0 +1,72 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+)
+
+// newIdemixSigner loads the idemix credential found in mspConfigPath and
+// returns its default signing identity.
+func newIdemixSigner(mspConfigPath, mspID string, cryptoProvider bccsp.BCCSP) (Signer, error) {
+	if mspConfigPath == "" {
+		return nil, errors.New("idemix MSP config path not specified")
+	}
+	if mspID == "" {
+		return nil, errors.New("idemix MSP ID not specified")
+	}
+
+	conf, err := msp.GetLocalMspConfigWithType(mspConfigPath, nil, mspID, msp.ProviderTypeToString(msp.IDEMIX))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to load idemix MSP config from %s", mspConfigPath)
+	}
+
+	idemixMSP, err := msp.New(&msp.IdemixNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_3}}, cryptoProvider)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create idemix MSP")
+	}
+
+	if err := idemixMSP.Setup(conf); err != nil {
+		return nil, errors.WithMessage(err, "failed to set up idemix MSP")
+	}
+
+	signer, err := idemixMSP.GetDefaultSigningIdentity()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to obtain idemix signing identity")
+	}
+
+	return signer, nil
+}
+
+// reendorse replaces the endorsement of a proposal response with one produced
+// by signer, following the same scheme as the default endorsement plugin. It
+// is used for anonymous approvals so that the peer's own identity does not
+// appear in the transaction.
+func reendorse(response *pb.ProposalResponse, signer Signer) (*pb.ProposalResponse, error) {
+	endorser, err := signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	data := make([]byte, len(response.Payload)+len(endorser))
+	copy(data, response.Payload)
+	copy(data[len(response.Payload):], endorser)
+	signature, err := signer.Sign(data)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to sign proposal response payload")
+	}
+
+	return &pb.ProposalResponse{
+		Version:     response.Version,
+		Timestamp:   response.Timestamp,
+		Response:    response.Response,
+		Payload:     response.Payload,
+		Endorsement: &pb.Endorsement{Endorser: endorser, Signature: signature},
+	}, nil
+}
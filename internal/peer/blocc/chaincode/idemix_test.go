@@ -0,0 +1,153 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	idemixbccsp "github.com/IBM/idemix/bccsp"
+	"github.com/IBM/idemix/bccsp/keystore"
+	schemes "github.com/IBM/idemix/bccsp/schemes"
+	"github.com/IBM/idemix/bccsp/schemes/dlog/crypto/translator/amcl"
+	math "github.com/IBM/mathlib"
+	"github.com/golang/protobuf/proto"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+// writeIdemixConfig issues an idemix credential of the organizational unit ou
+// and writes it to dir in the layout of idemixgen.
+func writeIdemixConfig(t *testing.T, dir, ou string) {
+	curve := math.Curves[math.FP256BN_AMCL]
+	csp, err := idemixbccsp.New(&keystore.Dummy{}, curve, &amcl.Fp256bn{C: curve}, true)
+	require.NoError(t, err)
+
+	issuerKey, err := csp.KeyGen(&schemes.IdemixIssuerKeyGenOpts{
+		Temporary:      true,
+		AttributeNames: []string{"OU", "Role", "EnrollmentID", "RevocationHandle"},
+	})
+	require.NoError(t, err)
+	issuerPublicKey, err := issuerKey.PublicKey()
+	require.NoError(t, err)
+	ipkBytes, err := issuerPublicKey.Bytes()
+	require.NoError(t, err)
+
+	revocationKey, err := csp.KeyGen(&schemes.IdemixRevocationKeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	revocationPublicKey, err := revocationKey.PublicKey()
+	require.NoError(t, err)
+	revocationPKBytes, err := revocationPublicKey.Bytes()
+	require.NoError(t, err)
+
+	userKey, err := csp.KeyGen(&schemes.IdemixUserSecretKeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	skBytes, err := userKey.Bytes()
+	require.NoError(t, err)
+
+	nonce := make([]byte, curve.FieldBytes)
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+	credRequest, err := csp.Sign(userKey, nil, &schemes.IdemixCredentialRequestSignerOpts{IssuerPK: issuerPublicKey, IssuerNonce: nonce})
+	require.NoError(t, err)
+	cred, err := csp.Sign(issuerKey, credRequest, &schemes.IdemixCredentialSignerOpts{
+		IssuerPK: issuerPublicKey,
+		Attributes: []schemes.IdemixAttribute{
+			{Type: schemes.IdemixBytesAttribute, Value: []byte(ou)},
+			{Type: schemes.IdemixIntAttribute, Value: 1},
+			{Type: schemes.IdemixBytesAttribute, Value: []byte("approver")},
+			{Type: schemes.IdemixIntAttribute, Value: 1},
+		},
+	})
+	require.NoError(t, err)
+	cri, err := csp.Sign(revocationKey, nil, &schemes.IdemixCRISignerOpts{RevocationAlgorithm: schemes.AlgNoRevocation})
+	require.NoError(t, err)
+
+	signerConfig, err := proto.Marshal(&mspproto.IdemixMSPSignerConfig{
+		Cred:                            cred,
+		Sk:                              skBytes,
+		OrganizationalUnitIdentifier:    ou,
+		Role:                            1,
+		EnrollmentId:                    "approver",
+		CredentialRevocationInformation: cri,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "msp"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "user"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "msp", "IssuerPublicKey"), ipkBytes, 0o644))
+	revocationPKPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: revocationPKBytes})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "msp", "RevocationPublicKey"), revocationPKPEM, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user", "SignerConfig"), signerConfig, 0o644))
+}
+
+func TestNewIdemixSigner(t *testing.T) {
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	dir := t.TempDir()
+	writeIdemixConfig(t, dir, "Org1MSP")
+
+	_, err = newIdemixSigner("", "IdemixOrg1MSP", cryptoProvider)
+	require.EqualError(t, err, "idemix MSP config path not specified")
+	_, err = newIdemixSigner(dir, "", cryptoProvider)
+	require.EqualError(t, err, "idemix MSP ID not specified")
+	_, err = newIdemixSigner(t.TempDir(), "IdemixOrg1MSP", cryptoProvider)
+	require.Error(t, err)
+
+	signer, err := newIdemixSigner(dir, "IdemixOrg1MSP", cryptoProvider)
+	require.NoError(t, err)
+	creator, err := signer.Serialize()
+	require.NoError(t, err)
+	id, err := protoutil.UnmarshalSerializedIdentity(creator)
+	require.NoError(t, err)
+	require.Equal(t, "IdemixOrg1MSP", id.Mspid)
+	// the credential discloses the organization it approves for
+	ou, ok := protoutil.IdemixOrganizationalUnit(id.IdBytes)
+	require.True(t, ok)
+	require.Equal(t, "Org1MSP", ou)
+}
+
+func TestReendorse(t *testing.T) {
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	dir := t.TempDir()
+	writeIdemixConfig(t, dir, "Org1MSP")
+	signer, err := newIdemixSigner(dir, "IdemixOrg1MSP", cryptoProvider)
+	require.NoError(t, err)
+
+	response := &pb.ProposalResponse{
+		Version:     1,
+		Response:    &pb.Response{Status: 200, Message: "token"},
+		Payload:     []byte("payload"),
+		Endorsement: &pb.Endorsement{Endorser: []byte("peer0"), Signature: []byte("peer0-signature")},
+	}
+	reendorsed, err := reendorse(response, signer)
+	require.NoError(t, err)
+	require.Equal(t, response.Response, reendorsed.Response)
+	require.Equal(t, response.Payload, reendorsed.Payload)
+	require.Equal(t, "peer0-signature", string(response.Endorsement.Signature))
+
+	// the endorsement is verified as the endorsements of the peers, against
+	// the payload followed by the endorser
+	conf, err := msp.GetVerifyingMspConfig(dir, "IdemixOrg1MSP", msp.ProviderTypeToString(msp.IDEMIX))
+	require.NoError(t, err)
+	idemixMSP, err := msp.New(&msp.IdemixNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_3}}, cryptoProvider)
+	require.NoError(t, err)
+	require.NoError(t, idemixMSP.Setup(conf))
+	endorser, err := idemixMSP.DeserializeIdentity(reendorsed.Endorsement.Endorser)
+	require.NoError(t, err)
+	require.NoError(t, endorser.Validate())
+	signed := append([]byte("payload"), reendorsed.Endorsement.Endorser...)
+	require.NoError(t, endorser.Verify(signed, reendorsed.Endorsement.Signature))
+	require.Error(t, endorser.Verify([]byte("other payload"), reendorsed.Endorsement.Signature))
+}
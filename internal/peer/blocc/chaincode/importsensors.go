@@ -0,0 +1,393 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const registerSensorsFuncName = "RegisterSensors"
+
+// Statuses of the rows of a sensor manifest after an import.
+const (
+	RowRegistered = "registered"
+	// RowInvalid rows failed the validation of the manifest and were not
+	// submitted
+	RowInvalid = "invalid"
+	// RowFailed rows were submitted but not registered
+	RowFailed = "failed"
+)
+
+// ManifestSensor is a row of a sensor manifest.
+type ManifestSensor struct {
+	ID             string            `json:"id" yaml:"id"`
+	Type           string            `json:"type,omitempty" yaml:"type"`
+	PairedWith     string            `json:"pairedWith,omitempty" yaml:"pairedWith"`
+	Location       *ManifestLocation `json:"location,omitempty" yaml:"location"`
+	RetentionClass string            `json:"retentionClass,omitempty" yaml:"retentionClass"`
+	// Token is the pre-shared token of the sensor, passed as transient data
+	// so that it is kept out of the block
+	Token string `json:"-" yaml:"token"`
+}
+
+// ManifestLocation is the position of a sensor of a manifest.
+type ManifestLocation struct {
+	Latitude  float64 `json:"latitude" yaml:"latitude"`
+	Longitude float64 `json:"longitude" yaml:"longitude"`
+	Floor     int     `json:"floor" yaml:"floor"`
+}
+
+// ImportRow is the outcome of the import of a row of a sensor manifest.
+type ImportRow struct {
+	// Row is the position of the sensor in the manifest, counted from 1 and
+	// leaving out the header of CSV manifests
+	Row    int    `json:"row"`
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ImportSensors registers the sensors of a YAML or CSV manifest in batches,
+// e.g. to onboard every sensor of a building at once. Rows failing validation
+// are reported rather than submitted, and a batch rejected because of one of
+// its rows is submitted again without it.
+type ImportSensors struct {
+	Command      *cobra.Command
+	Registrar    *RegisterSensor
+	ManifestFile string
+	BatchSize    int
+	// ReportFile is the file the JSON report of every row is written to, if
+	// set
+	ReportFile string
+	Writer     io.Writer
+}
+
+func ImportSensorsCmd(i *ImportSensors, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Register the sensors of a manifest",
+		Long:  "Validate the YAML or CSV manifest of sensors in --file and register them on behalf of this peer's organization in batches of --batchSize, reporting the outcome of every row",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if i == nil {
+				registrar, err := newRegisterSensor(cmd, cryptoProvider)
+				if err != nil {
+					return err
+				}
+
+				i = &ImportSensors{
+					Command:      cmd,
+					Registrar:    registrar,
+					ManifestFile: sensorManifestFile,
+					BatchSize:    batchSize,
+					ReportFile:   reportFile,
+					Writer:       os.Stdout,
+				}
+			}
+			return i.Import()
+		},
+	}
+	flagList := []string{
+		"ordererAddress",
+		"rootCertFilePath",
+		"channelID",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+		"waitForEvent",
+		"waitForEventTimeout",
+		"file",
+		"batchSize",
+		"reportFile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (i *ImportSensors) Import() error {
+	if i.ManifestFile == "" {
+		return errors.New("manifest file not specified")
+	}
+	if i.BatchSize < 1 {
+		return errors.New("batch size must be at least 1")
+	}
+
+	if i.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		i.Command.SilenceUsage = true
+	}
+
+	sensors, err := ReadSensorManifest(i.ManifestFile)
+	if err != nil {
+		return err
+	}
+	rows := ValidateSensorManifest(sensors)
+
+	var pending []int
+	for n, row := range rows {
+		if row.Status == "" {
+			pending = append(pending, n)
+		}
+	}
+	fmt.Fprintln(i.Writer, messages.Sprintf(messages.SensorsRegistering, len(pending), len(rows), len(rows)-len(pending)))
+
+	var registered int
+	for len(pending) > 0 {
+		size := i.BatchSize
+		if size > len(pending) {
+			size = len(pending)
+		}
+		batch := pending[:size]
+		pending = pending[size:]
+
+		for len(batch) > 0 {
+			failed, err := i.register(sensors, batch)
+			if err == nil {
+				for _, n := range batch {
+					rows[n].Status = RowRegistered
+				}
+				registered += len(batch)
+				break
+			}
+			if failed < 0 {
+				// the batch was not rejected because of one of its rows
+				for _, n := range batch {
+					rows[n].Status = RowFailed
+					rows[n].Error = err.Error()
+				}
+				break
+			}
+			rows[batch[failed]].Status = RowFailed
+			rows[batch[failed]].Error = err.Error()
+			batch = append(batch[:failed:failed], batch[failed+1:]...)
+		}
+		fmt.Fprintln(i.Writer, messages.Sprintf(messages.SensorsRegistered, registered, len(pending)))
+	}
+
+	if i.ReportFile != "" {
+		reportBytes, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal import report")
+		}
+		if err := ioutil.WriteFile(i.ReportFile, reportBytes, 0o644); err != nil {
+			return errors.Wrapf(err, "failed to write import report to %s", i.ReportFile)
+		}
+	}
+
+	var rejected int
+	for _, row := range rows {
+		if row.Status != RowRegistered {
+			fmt.Fprintln(i.Writer, messages.Sprintf(messages.SensorRowFailed, row.Row, row.ID, row.Status, row.Error))
+			rejected++
+		}
+	}
+	if rejected > 0 {
+		return errors.Errorf("%d of %d sensors were not registered", rejected, len(rows))
+	}
+	fmt.Fprintln(i.Writer, messages.Sprintf(messages.AllSensorsRegistered, len(rows), i.ManifestFile))
+	return nil
+}
+
+// batchRowError matches the error of a RegisterSensors transaction rejected
+// because of one of its sensors.
+var batchRowError = regexp.MustCompile(`Sensor (\d+): (.*)$`)
+
+// register submits a RegisterSensors transaction for the sensors at the given
+// positions of the manifest. If the transaction is rejected because of one of
+// the sensors, its index in batch and its error are returned, and -1 with the
+// error otherwise.
+func (i *ImportSensors) register(sensors []*ManifestSensor, batch []int) (int, error) {
+	var batchSensors []*ManifestSensor
+	transient := map[string][]byte{}
+	for _, n := range batch {
+		batchSensors = append(batchSensors, sensors[n])
+		if sensors[n].Token != "" {
+			transient["token."+sensors[n].ID] = []byte(sensors[n].Token)
+		}
+	}
+	sensorsBytes, err := json.Marshal(batchSensors)
+	if err != nil {
+		return -1, errors.Wrap(err, "failed to marshal sensors")
+	}
+
+	i.Registrar.Input.Sensor = string(sensorsBytes)
+	i.Registrar.Input.Batch = true
+	i.Registrar.Input.Transient = transient
+	err = i.Registrar.Register()
+	if err == nil {
+		return 0, nil
+	}
+
+	if match := batchRowError.FindStringSubmatch(err.Error()); match != nil {
+		if failed, convErr := strconv.Atoi(match[1]); convErr == nil && failed < len(batch) {
+			return failed, errors.New(match[2])
+		}
+	}
+	return -1, err
+}
+
+// ReadSensorManifest reads the sensors of a manifest, a CSV file if its
+// extension is .csv and a YAML file listing them under "sensors" otherwise.
+// The header of CSV manifests names the columns among id, type, pairedWith,
+// latitude, longitude, floor, retentionClass and token.
+func ReadSensorManifest(file string) ([]*ManifestSensor, error) {
+	manifestBytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read manifest %s", file)
+	}
+
+	if strings.EqualFold(filepath.Ext(file), ".csv") {
+		return parseCSVManifest(manifestBytes)
+	}
+
+	var manifest struct {
+		Sensors []*ManifestSensor `yaml:"sensors"`
+	}
+	if err := yaml.UnmarshalStrict(manifestBytes, &manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest %s", file)
+	}
+	if len(manifest.Sensors) == 0 {
+		return nil, errors.Errorf("manifest %s lists no sensors", file)
+	}
+	return manifest.Sensors, nil
+}
+
+func parseCSVManifest(manifestBytes []byte) ([]*ManifestSensor, error) {
+	records, err := csv.NewReader(strings.NewReader(string(manifestBytes))).ReadAll()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse CSV manifest")
+	}
+	if len(records) < 2 {
+		return nil, errors.New("CSV manifest lists no sensors")
+	}
+
+	columns := map[string]int{}
+	for n, name := range records[0] {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "id", "type", "pairedWith", "latitude", "longitude", "floor", "retentionClass", "token":
+			columns[name] = n
+		default:
+			return nil, errors.Errorf("unknown column %s in CSV manifest", name)
+		}
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, errors.New("CSV manifest has no id column")
+	}
+
+	var sensors []*ManifestSensor
+	for _, record := range records[1:] {
+		field := func(name string) string {
+			if n, ok := columns[name]; ok {
+				return strings.TrimSpace(record[n])
+			}
+			return ""
+		}
+
+		sensor := &ManifestSensor{
+			ID:             field("id"),
+			Type:           field("type"),
+			PairedWith:     field("pairedWith"),
+			RetentionClass: field("retentionClass"),
+			Token:          field("token"),
+		}
+		if field("latitude") != "" || field("longitude") != "" {
+			sensor.Location = &ManifestLocation{}
+			if sensor.Location.Latitude, err = strconv.ParseFloat(field("latitude"), 64); err != nil {
+				return nil, errors.Errorf("invalid latitude '%s' of sensor %s", field("latitude"), sensor.ID)
+			}
+			if sensor.Location.Longitude, err = strconv.ParseFloat(field("longitude"), 64); err != nil {
+				return nil, errors.Errorf("invalid longitude '%s' of sensor %s", field("longitude"), sensor.ID)
+			}
+			if floor := field("floor"); floor != "" {
+				if sensor.Location.Floor, err = strconv.Atoi(floor); err != nil {
+					return nil, errors.Errorf("invalid floor '%s' of sensor %s", floor, sensor.ID)
+				}
+			}
+		}
+		sensors = append(sensors, sensor)
+	}
+
+	return sensors, nil
+}
+
+// ValidateSensorManifest checks the rows of a manifest as the registry does,
+// returning the outcome of every row, whose status is empty if it is valid. A
+// sensor paired with a sensor of the manifest must be listed after it, so
+// that the paired sensor is registered first.
+func ValidateSensorManifest(sensors []*ManifestSensor) []*ImportRow {
+	rows := make([]*ImportRow, len(sensors))
+	listed := map[string]*ImportRow{}
+	for n, sensor := range sensors {
+		row := &ImportRow{Row: n + 1, ID: sensor.ID}
+		rows[n] = row
+		if err := validateManifestSensor(sensor, listed); err != nil {
+			row.Status = RowInvalid
+			row.Error = err.Error()
+		}
+		if _, ok := listed[sensor.ID]; !ok && sensor.ID != "" {
+			listed[sensor.ID] = row
+		}
+	}
+
+	// a sensor paired with a sensor listed later is reported as such rather
+	// than as paired with an unregistered sensor
+	for n, sensor := range sensors {
+		if rows[n].Status != "" || sensor.PairedWith == "" {
+			continue
+		}
+		if paired, ok := listed[sensor.PairedWith]; ok && paired.Row > rows[n].Row {
+			rows[n].Status = RowInvalid
+			rows[n].Error = fmt.Sprintf("paired sensor %s is listed after it, at row %d", sensor.PairedWith, paired.Row)
+		}
+	}
+
+	return rows
+}
+
+func validateManifestSensor(sensor *ManifestSensor, listed map[string]*ImportRow) error {
+	if sensor.ID == "" {
+		return errors.New("sensor ID not specified")
+	}
+	if row, ok := listed[sensor.ID]; ok {
+		return errors.Errorf("sensor %s is already listed at row %d", sensor.ID, row.Row)
+	}
+	if sensor.PairedWith == sensor.ID {
+		return errors.Errorf("sensor %s cannot be paired with itself", sensor.ID)
+	}
+	if paired, ok := listed[sensor.PairedWith]; ok && paired.Status != "" {
+		return errors.Errorf("paired sensor %s is invalid", sensor.PairedWith)
+	}
+	if l := sensor.Location; l != nil {
+		if l.Latitude < -90 || l.Latitude > 90 {
+			return errors.Errorf("latitude %v is out of range", l.Latitude)
+		}
+		if l.Longitude < -180 || l.Longitude > 180 {
+			return errors.Errorf("longitude %v is out of range", l.Longitude)
+		}
+	}
+	if !config.ValidRetentionClass(sensor.RetentionClass) {
+		return errors.Errorf("unknown retention class %s, expected %s, %s or %s",
+			sensor.RetentionClass, config.RetentionHot, config.RetentionWarm, config.RetentionCold)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSensorManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(file, []byte(content), 0o644))
+		return file
+	}
+
+	expected := []*ManifestSensor{
+		{ID: "hvac1", Type: "thermometer", Location: &ManifestLocation{Latitude: 51.4988, Longitude: -0.1749, Floor: 2}, RetentionClass: "cold", Token: "t0k3n"},
+		{ID: "hvac2", PairedWith: "hvac1"},
+	}
+
+	sensors, err := ReadSensorManifest(write("sensors.yaml", `
+sensors:
+  - id: hvac1
+    type: thermometer
+    location: {latitude: 51.4988, longitude: -0.1749, floor: 2}
+    retentionClass: cold
+    token: t0k3n
+  - id: hvac2
+    pairedWith: hvac1
+`))
+	require.NoError(t, err)
+	require.Equal(t, expected, sensors)
+
+	sensors, err = ReadSensorManifest(write("sensors.csv", `id,type,pairedWith,latitude,longitude,floor,retentionClass,token
+hvac1,thermometer,,51.4988,-0.1749,2,cold,t0k3n
+hvac2,,hvac1,,,,,
+`))
+	require.NoError(t, err)
+	require.Equal(t, expected, sensors)
+
+	_, err = ReadSensorManifest(write("unknown.csv", "id,colour\nhvac1,red\n"))
+	require.EqualError(t, err, "unknown column colour in CSV manifest")
+	_, err = ReadSensorManifest(write("latitude.csv", "id,latitude,longitude\nhvac1,north,0\n"))
+	require.EqualError(t, err, "invalid latitude 'north' of sensor hvac1")
+	_, err = ReadSensorManifest(write("empty.yaml", "sensors: []\n"))
+	require.Regexp(t, "manifest .*empty.yaml lists no sensors", err.Error())
+	_, err = ReadSensorManifest(write("typo.yaml", "sensors:\n  - id: hvac1\n    pairedwith: hvac2\n"))
+	require.Error(t, err)
+}
+
+func TestValidateSensorManifest(t *testing.T) {
+	rows := ValidateSensorManifest([]*ManifestSensor{
+		{ID: "s1"},
+		{},
+		{ID: "s1"},
+		{ID: "s3", PairedWith: "s3"},
+		{ID: "s4", Location: &ManifestLocation{Latitude: 91}},
+		{ID: "s5", RetentionClass: "frozen"},
+		{ID: "s6", PairedWith: "s5"},
+		{ID: "s7", PairedWith: "s8"},
+		{ID: "s8", PairedWith: "s1"},
+		{ID: "s9", PairedWith: "registered"},
+	})
+
+	require.Equal(t, []*ImportRow{
+		{Row: 1, ID: "s1"},
+		{Row: 2, Status: RowInvalid, Error: "sensor ID not specified"},
+		{Row: 3, ID: "s1", Status: RowInvalid, Error: "sensor s1 is already listed at row 1"},
+		{Row: 4, ID: "s3", Status: RowInvalid, Error: "sensor s3 cannot be paired with itself"},
+		{Row: 5, ID: "s4", Status: RowInvalid, Error: "latitude 91 is out of range"},
+		{Row: 6, ID: "s5", Status: RowInvalid, Error: "unknown retention class frozen, expected hot, warm or cold"},
+		{Row: 7, ID: "s6", Status: RowInvalid, Error: "paired sensor s5 is invalid"},
+		{Row: 8, ID: "s7", Status: RowInvalid, Error: "paired sensor s8 is listed after it, at row 9"},
+		{Row: 9, ID: "s8"},
+		{Row: 10, ID: "s9"},
+	}, rows)
+}
@@ -0,0 +1,377 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Kinds of the messages exchanged with an air-gapped signing host. An
+// approval goes through two offline signatures: one over the proposal and one
+// over the endorsed transaction.
+const (
+	offlineProposal          = "proposal"
+	offlineSignedProposal    = "signedProposal"
+	offlineTransaction       = "transaction"
+	offlineSignedTransaction = "signedTransaction"
+)
+
+// offlineMessage is the file format of the offline approval workflow.
+type offlineMessage struct {
+	Kind      string `json:"kind"`
+	ChannelID string `json:"channelID"`
+	Data      []byte `json:"data"`
+}
+
+func readOfflineMessage(path string) (*offlineMessage, error) {
+	messageBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	message := &offlineMessage{}
+	if err := json.Unmarshal(messageBytes, message); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+
+	return message, nil
+}
+
+func writeOfflineMessage(path string, message *offlineMessage) error {
+	messageBytes, err := json.MarshalIndent(message, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal message")
+	}
+
+	if err := ioutil.WriteFile(path, messageBytes, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", path)
+	}
+
+	return nil
+}
+
+// offlineSigner stands in for an identity whose signing key is held offline.
+// It serializes the identity but leaves messages unsigned.
+type offlineSigner struct {
+	creator []byte
+}
+
+func (s *offlineSigner) Sign(msg []byte) ([]byte, error) {
+	return nil, nil
+}
+
+func (s *offlineSigner) Serialize() ([]byte, error) {
+	return s.creator, nil
+}
+
+// ExportApprovalCmd writes the unsigned approval proposal of a sensory
+// transaction, created for the identity of --mspID and --certFile.
+func ExportApprovalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-approval",
+		Short: "Export an unsigned approval proposal for offline signing",
+		Long:  "Export the unsigned approval proposal of a sensory transaction, to be signed with 'peer blocc sign-approval' on the host holding the approving identity's key",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if channelID == "" || txID == "" || outputFile == "" {
+				return errors.New("channelID, txID and outputFile must be specified")
+			}
+			cmd.SilenceUsage = true
+
+			cert, err := ioutil.ReadFile(certFile)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read certificate %s", certFile)
+			}
+			creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: cert})
+			if err != nil {
+				return errors.Wrap(err, "failed to serialize identity")
+			}
+
+			a := &ApproveForThisPeer{
+				Signer: &offlineSigner{creator: creator},
+				Input: &ApproveForThisPeerInput{
+					ChannelID: channelID,
+					TxID:      txID,
+					Metadata:  config.GetOptions(viper.GetViper()).ApprovalMetadata,
+				},
+			}
+			proposal, _, err := a.createProposal(txID)
+			if err != nil {
+				return errors.WithMessage(err, "failed to create proposal")
+			}
+
+			proposalBytes, err := proto.Marshal(proposal)
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal proposal")
+			}
+
+			return writeOfflineMessage(outputFile, &offlineMessage{Kind: offlineProposal, ChannelID: channelID, Data: proposalBytes})
+		},
+	}
+	attachFlags(cmd, []string{"channelID", "txID", "mspID", "certFile", "outputFile"})
+
+	return cmd
+}
+
+// SignApprovalCmd signs an exported approval proposal or an endorsed approval
+// transaction with the local MSP identity.
+func SignApprovalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-approval",
+		Short: "Sign an approval proposal or transaction offline",
+		Long:  "Sign an approval proposal exported with 'peer blocc export-approval', or an approval transaction endorsed with 'peer blocc submit-approval', with the local MSP identity",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if inputFile == "" || outputFile == "" {
+				return errors.New("inputFile and outputFile must be specified")
+			}
+			cmd.SilenceUsage = true
+
+			message, err := readOfflineMessage(inputFile)
+			if err != nil {
+				return err
+			}
+
+			signer, err := common.GetDefaultSigner()
+			if err != nil {
+				return errors.WithMessage(err, "failed to retrieve default signer")
+			}
+
+			signed, err := signOfflineMessage(message, signer)
+			if err != nil {
+				return err
+			}
+
+			return writeOfflineMessage(outputFile, signed)
+		},
+	}
+	attachFlags(cmd, []string{"inputFile", "outputFile"})
+
+	return cmd
+}
+
+func signOfflineMessage(message *offlineMessage, signer Signer) (*offlineMessage, error) {
+	switch message.Kind {
+	case offlineProposal:
+		proposal := &pb.Proposal{}
+		if err := proto.Unmarshal(message.Data, proposal); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal proposal")
+		}
+
+		signedProposal, err := signProposal(proposal, signer)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to sign proposal")
+		}
+
+		signedProposalBytes, err := proto.Marshal(signedProposal)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal signed proposal")
+		}
+
+		return &offlineMessage{Kind: offlineSignedProposal, ChannelID: message.ChannelID, Data: signedProposalBytes}, nil
+	case offlineTransaction:
+		env := &cb.Envelope{}
+		if err := proto.Unmarshal(message.Data, env); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal transaction")
+		}
+
+		signature, err := signer.Sign(env.Payload)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to sign transaction")
+		}
+		env.Signature = signature
+
+		envBytes, err := proto.Marshal(env)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal transaction")
+		}
+
+		return &offlineMessage{Kind: offlineSignedTransaction, ChannelID: message.ChannelID, Data: envBytes}, nil
+	default:
+		return nil, errors.Errorf("cannot sign a message of kind '%s'", message.Kind)
+	}
+}
+
+// SubmitApproval endorses an offline signed approval proposal, or broadcasts
+// an offline signed approval transaction.
+type SubmitApproval struct {
+	Command         *cobra.Command
+	BroadcastClient common.BroadcastClient
+	EndorserClients []EndorserClient
+	InputFile       string
+	OutputFile      string
+}
+
+func SubmitApprovalCmd(s *SubmitApproval, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit-approval",
+		Short: "Submit an offline signed approval",
+		Long:  "Endorse an offline signed approval proposal and export the resulting transaction for offline signing, or broadcast an offline signed approval transaction",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if s == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					OrdererRequired:       true,
+					OrderingEndpoint:      ordererAddress,
+					OrdererCAFile:         rootCertFilePath,
+					ChannelID:             channelID,
+					PeerAddresses:         []string{peerAddress},
+					TLSRootCertFiles:      []string{tlsRootCertFile},
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+
+				endorserClients := make([]EndorserClient, len(cc.EndorserClients))
+				for i, e := range cc.EndorserClients {
+					endorserClients[i] = e
+				}
+
+				s = &SubmitApproval{
+					Command:         cmd,
+					BroadcastClient: cc.BroadcastClient,
+					EndorserClients: endorserClients,
+					InputFile:       inputFile,
+					OutputFile:      outputFile,
+				}
+			}
+			return s.Submit()
+		},
+	}
+	flagList := []string{
+		"ordererAddress",
+		"rootCertFilePath",
+		"channelID",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+		"inputFile",
+		"outputFile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (s *SubmitApproval) Submit() error {
+	if s.InputFile == "" {
+		return errors.New("inputFile must be specified")
+	}
+
+	if s.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		s.Command.SilenceUsage = true
+	}
+
+	message, err := readOfflineMessage(s.InputFile)
+	if err != nil {
+		return err
+	}
+
+	switch message.Kind {
+	case offlineSignedProposal:
+		return s.endorse(message)
+	case offlineSignedTransaction:
+		env := &cb.Envelope{}
+		if err := proto.Unmarshal(message.Data, env); err != nil {
+			return errors.Wrap(err, "failed to unmarshal transaction")
+		}
+		if err := s.BroadcastClient.Send(env); err != nil {
+			return errors.WithMessage(err, "failed to send transaction")
+		}
+		return nil
+	default:
+		return errors.Errorf("cannot submit a message of kind '%s'", message.Kind)
+	}
+}
+
+// endorse collects the endorsements of the signed proposal and writes the
+// unsigned transaction to the output file.
+func (s *SubmitApproval) endorse(message *offlineMessage) error {
+	if s.OutputFile == "" {
+		return errors.New("outputFile must be specified")
+	}
+
+	signedProposal := &pb.SignedProposal{}
+	if err := proto.Unmarshal(message.Data, signedProposal); err != nil {
+		return errors.Wrap(err, "failed to unmarshal signed proposal")
+	}
+
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal proposal")
+	}
+
+	var responses []*pb.ProposalResponse
+	for _, endorser := range s.EndorserClients {
+		proposalResponse, err := endorser.ProcessProposal(context.Background(), signedProposal)
+		if err != nil {
+			return errors.WithMessage(err, "failed to endorse proposal")
+		}
+		if proposalResponse.GetResponse() == nil {
+			return errors.New("received proposal response with nil response")
+		}
+		if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+			return errors.Errorf("proposal failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+		}
+		responses = append(responses, proposalResponse)
+	}
+
+	if len(responses) == 0 {
+		// this should only be empty due to a programming bug
+		return errors.New("no proposal responses received")
+	}
+	warnDeprecation(responses[0])
+
+	creator, err := proposalCreator(proposal)
+	if err != nil {
+		return err
+	}
+
+	// the transaction is signed offline in the next step
+	env, err := protoutil.CreateSignedTx(proposal, &offlineSigner{creator: creator}, responses...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create transaction")
+	}
+
+	envBytes, err := proto.Marshal(env)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal transaction")
+	}
+
+	return writeOfflineMessage(s.OutputFile, &offlineMessage{Kind: offlineTransaction, ChannelID: message.ChannelID, Data: envBytes})
+}
+
+func proposalCreator(proposal *pb.Proposal) ([]byte, error) {
+	hdr, err := protoutil.UnmarshalHeader(proposal.Header)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to unmarshal proposal header")
+	}
+
+	shdr, err := protoutil.UnmarshalSignatureHeader(hdr.SignatureHeader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to unmarshal signature header")
+	}
+
+	return shdr.Creator, nil
+}
@@ -0,0 +1,154 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/pinning"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const flushPendingFuncName = "FlushPendingApprovals"
+
+// BroadcastEnvelope broadcasts the signed transaction env to the orderer at
+// ordererAddress, or to the nodes of the BFT ordering service at bftEndpoints
+// if any, over streams if not nil, the orderers being verified against their
+// pins by pinner. The failures to reach the orderer are in the
+// ErrOrdererUnavailable category, see broadcastError.
+func BroadcastEnvelope(streams *BroadcastStreams, pinner *pinning.Pinner, ordererAddress, rootCertFilePath string, bftEndpoints []string, env *cb.Envelope) error {
+	var client common.BroadcastClient
+	if len(bftEndpoints) > 0 {
+		bftClient := newBFTBroadcastClient(bftEndpoints, rootCertFilePath, pinner)
+		if streams != nil {
+			bftClient.dial = func(endpoint string) (common.BroadcastClient, error) {
+				return streams.Client(endpoint, rootCertFilePath), nil
+			}
+		}
+		client = bftClient
+	} else if streams != nil {
+		client = streams.Client(ordererAddress, rootCertFilePath)
+	} else {
+		clientConfig, err := configOrdererSettings(ordererAddress, rootCertFilePath, pinner)
+		if err != nil {
+			return errors.WithMessage(err, "failed to retrieve broadcast client")
+		}
+		client, err = common.GetBroadcastClientWithParams(ordererAddress, clientConfig, nil)
+		if err != nil {
+			return errors.WithMessage(broadcastError(err), "failed to retrieve broadcast client")
+		}
+	}
+	defer client.Close()
+
+	return broadcastError(client.Send(env))
+}
+
+// FlushPending broadcasts the approvals a peer stored while the orderer was
+// unavailable, without waiting for its next periodic flush.
+type FlushPending struct {
+	Command *cobra.Command
+	Querier *peerQuerier
+	Writer  io.Writer
+}
+
+// pendingStatus mirrors the status returned by BSCC.
+type pendingStatus struct {
+	Flushed int    `json:"flushed"`
+	Dropped int    `json:"dropped"`
+	Pending int    `json:"pending"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PendingCmd returns the command managing the approvals a peer stored while
+// the orderer was unavailable.
+func PendingCmd(f *FlushPending, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending",
+		Short: "Manage the approvals stored while the orderer was unavailable",
+		Long:  "Manage the approvals a peer stored in store-and-forward mode while the orderer was unavailable",
+	}
+	cmd.AddCommand(flushPendingCmd(f, cryptoProvider))
+
+	return cmd
+}
+
+func flushPendingCmd(f *FlushPending, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flush",
+		Short: "Broadcast the stored approvals of a peer",
+		Long:  "Broadcast the approvals a peer stored while the orderer was unavailable, in the order they were stored, without waiting for its next periodic flush",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if f == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					PeerAddresses:         []string{peerAddress},
+					TLSRootCertFiles:      []string{tlsRootCertFile},
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+				if len(cc.EndorserClients) == 0 {
+					return errors.New("no endorser clients")
+				}
+
+				f = &FlushPending{
+					Command: cmd,
+					Querier: &peerQuerier{
+						Signer:         cc.Signer,
+						EndorserClient: cc.EndorserClients[0],
+					},
+					Writer: os.Stdout,
+				}
+			}
+			return f.Flush()
+		},
+	}
+	flagList := []string{
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (f *FlushPending) Flush() error {
+	if f.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		f.Command.SilenceUsage = true
+	}
+
+	// BSCC takes at least one argument
+	statusBytes, err := f.Querier.query(bloccName, flushPendingFuncName, "")
+	if err != nil {
+		return errors.WithMessage(err, "failed to flush pending approvals")
+	}
+	status := &pendingStatus{}
+	if err := json.Unmarshal(statusBytes, status); err != nil {
+		return errors.Wrap(err, "failed to unmarshal pending approvals status")
+	}
+
+	fmt.Fprintln(f.Writer, messages.Sprintf(messages.PendingApprovalsFlushed, status.Flushed, status.Dropped, status.Pending))
+	if status.Error != "" {
+		return errors.Errorf("%d approvals still pending: %s", status.Pending, status.Error)
+	}
+	return nil
+}
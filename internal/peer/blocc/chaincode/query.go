@@ -0,0 +1,107 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// peerQuerier evaluates chaincode functions on a peer without submitting
+// the resulting transactions.
+type peerQuerier struct {
+	ChannelID      string
+	Signer         Signer
+	EndorserClient EndorserClient
+}
+
+func (q *peerQuerier) query(chaincodeName string, args ...string) ([]byte, error) {
+	ccInput := &pb.ChaincodeInput{}
+	for _, arg := range args {
+		ccInput.Args = append(ccInput.Args, []byte(arg))
+	}
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: chaincodeName},
+			Input:       ccInput,
+		},
+	}
+
+	creator, err := q.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	var transient map[string][]byte
+	if chaincodeName == bloccName {
+		transient = apiversion.Transient(nil)
+	}
+	proposal, _, err := protoutil.CreateChaincodeProposalWithTransient(cb.HeaderType_ENDORSER_TRANSACTION, q.ChannelID, cis, creator, transient)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create proposal")
+	}
+
+	signedProposal, err := signProposal(proposal, q.Signer)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create signed proposal")
+	}
+
+	proposalResponse, err := q.EndorserClient.ProcessProposal(context.Background(), signedProposal)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to endorse proposal")
+	}
+
+	if proposalResponse.GetResponse() == nil {
+		return nil, errors.New("received proposal response with nil response")
+	}
+
+	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+		return nil, errors.Errorf("proposal failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+	if chaincodeName == bloccName {
+		warnDeprecation(proposalResponse)
+	}
+
+	return proposalResponse.Response.Payload, nil
+}
+
+// warnDeprecation logs the deprecation warning returned by BSCC in the
+// deprecation event of a successful response, if any.
+func warnDeprecation(response *pb.ProposalResponse) {
+	prp, err := protoutil.UnmarshalProposalResponsePayload(response.Payload)
+	if err != nil {
+		return
+	}
+	action, err := protoutil.UnmarshalChaincodeAction(prp.Extension)
+	if err != nil || len(action.Events) == 0 {
+		return
+	}
+	event, err := protoutil.UnmarshalChaincodeEvents(action.Events)
+	if err == nil && event.EventName == apiversion.DeprecationEvent {
+		logger.Warning(string(event.Payload))
+	}
+}
+
+// transactionByID returns the committed transaction with the given ID.
+func (q *peerQuerier) transactionByID(txID string) (*pb.ProcessedTransaction, error) {
+	txBytes, err := q.query("qscc", "GetTransactionByID", q.ChannelID, txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get transaction %s", txID)
+	}
+
+	processedTx := &pb.ProcessedTransaction{}
+	if err := proto.Unmarshal(txBytes, processedTx); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal transaction %s", txID)
+	}
+
+	return processedTx, nil
+}
@@ -0,0 +1,121 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// reapprovalMetadataKey is attached to the approval records resubmitted by
+// reapprove, holding the time of the re-approval, so that forced
+// re-approvals can be audited by filtering QueryApprovals on it.
+const reapprovalMetadataKey = "reapprovedAt"
+
+// Reapprove resubmits this peer's approval of a sensory reading whatever the
+// decision already recorded for it, e.g. to recover after the approval
+// transaction was invalidated by an MVCC conflict or an endorsement policy
+// mismatch.
+type Reapprove struct {
+	Command  *cobra.Command
+	Approver *ApproveForThisPeer
+	Writer   io.Writer
+	now      func() time.Time
+}
+
+func ReapproveCmd(r *Reapprove, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reapprove",
+		Short: "Force the re-approval of a sensory reading for this peer",
+		Long:  "Resubmit this peer's approval of the sensory reading --txID, even if an approval or rejection is already recorded for it, e.g. after the approval transaction was invalidated. The re-approval is recorded in the metadata of the approval record",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if r == nil {
+				approver, err := newApproveForThisPeer(cmd, cryptoProvider, false)
+				if err != nil {
+					return err
+				}
+
+				r = &Reapprove{
+					Command:  cmd,
+					Approver: approver,
+					Writer:   os.Stdout,
+				}
+			}
+			return r.Reapprove()
+		},
+	}
+	flagList := []string{
+		"ordererAddress",
+		"rootCertFilePath",
+		"channelID",
+		"txID",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+		"waitForEvent",
+		"waitForEventTimeout",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (r *Reapprove) Reapprove() error {
+	if err := r.Approver.Input.Validate(); err != nil {
+		return err
+	}
+
+	if r.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		r.Command.SilenceUsage = true
+	}
+
+	mspID, err := r.Approver.mspID()
+	if err != nil {
+		return err
+	}
+	q, err := r.Approver.querier()
+	if err != nil {
+		return err
+	}
+
+	input := r.Approver.Input
+	decision, err := readingDecision(q, input.TxID, mspID)
+	if err != nil {
+		return err
+	}
+	if decision == "" {
+		decision = "none"
+	}
+
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+	reapprovedAt := now().UTC().Format(time.RFC3339)
+
+	metadata := map[string]string{}
+	for k, v := range input.Metadata {
+		metadata[k] = v
+	}
+	metadata[reapprovalMetadataKey] = reapprovedAt
+	input.Metadata = metadata
+
+	logger.Warningf("Forcing re-approval of reading %s on channel %s by %s at %s, recorded decision: %s", input.TxID, input.ChannelID, mspID, reapprovedAt, decision)
+	if err := r.Approver.Approve(); err != nil {
+		return errors.WithMessagef(err, "failed to re-approve reading %s", input.TxID)
+	}
+
+	fmt.Fprintln(r.Writer, messages.Sprintf(messages.ReapprovalSubmitted, input.TxID, mspID, decision))
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRecordsQuerier returns the JSON encoded page of records of each BSCC
+// query function.
+type fakeRecordsQuerier map[string]string
+
+func (f fakeRecordsQuerier) query(chaincodeName string, args ...string) ([]byte, error) {
+	records, ok := f[args[0]]
+	if !ok {
+		return nil, errors.New("peer unavailable")
+	}
+	return []byte(records), nil
+}
+
+func TestReadingDecision(t *testing.T) {
+	q := fakeRecordsQuerier{
+		"QueryApprovals":  `{"records":[{"mspID":"Org1MSP"}]}`,
+		"QueryRejections": `{"records":[{"mspID":"Org2MSP"}]}`,
+	}
+
+	decision, err := readingDecision(q, "tx1", "Org1MSP")
+	require.NoError(t, err)
+	require.Equal(t, "approved", decision)
+
+	decision, err = readingDecision(q, "tx1", "Org2MSP")
+	require.NoError(t, err)
+	require.Equal(t, "rejected", decision)
+
+	decision, err = readingDecision(q, "tx1", "Org3MSP")
+	require.NoError(t, err)
+	require.Empty(t, decision)
+
+	delete(q, "QueryRejections")
+	_, err = readingDecision(q, "tx1", "Org3MSP")
+	require.EqualError(t, err, "failed to query QueryRejections of reading tx1: peer unavailable")
+}
+
+func TestCheckUndecided(t *testing.T) {
+	q := fakeRecordsQuerier{
+		"QueryApprovals":  `{"records":[{"mspID":"Org1MSP"}]}`,
+		"QueryRejections": `{"records":[]}`,
+	}
+
+	err := checkUndecided(q, "tx1", "Org1MSP")
+	require.EqualError(t, err, "reading tx1 is already approved by Org1MSP")
+	require.True(t, errors.Is(err, bloccerrors.ErrDuplicateApproval))
+
+	require.NoError(t, checkUndecided(q, "tx1", "Org2MSP"))
+
+	delete(q, "QueryRejections")
+	err = checkUndecided(q, "tx1", "Org2MSP")
+	require.Error(t, err)
+	require.False(t, errors.Is(err, bloccerrors.ErrDuplicateApproval))
+}
+
+func TestBroadcastError(t *testing.T) {
+	tests := []struct {
+		err         error
+		unavailable bool
+	}{
+		{errors.New("could not send to orderer node: EOF"), true},
+		{&common.BroadcastStatusError{Status: cb.Status_SERVICE_UNAVAILABLE}, true},
+		{&common.BroadcastStatusError{Status: cb.Status_BAD_REQUEST}, false},
+	}
+
+	for _, tt := range tests {
+		err := errors.WithMessage(broadcastError(tt.err), "failed to send transaction")
+		require.Equal(t, "failed to send transaction: "+tt.err.Error(), err.Error())
+		require.Equal(t, tt.unavailable, errors.Is(err, bloccerrors.ErrOrdererUnavailable), err.Error())
+	}
+}
@@ -0,0 +1,234 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/pinning"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	registerApproverFuncName = "RegisterApprover"
+	registerApproverCmdName  = "registerapprover"
+)
+
+// RegisterApprover registers this peer as an approver of a channel, declaring
+// its approval identity and capabilities.
+type RegisterApprover struct {
+	Command         *cobra.Command
+	BroadcastClient common.BroadcastClient
+	EndorserClients []EndorserClient
+	Input           *RegisterApproverInput
+	Signer          Signer
+}
+
+type RegisterApproverInput struct {
+	OrdererAddress        string
+	RootCertFilePath      string
+	ChannelID             string
+	PeerAddress           string
+	TLSRootCertFile       string
+	ConnectionProfilePath string
+	// Capabilities are the approval capabilities of this peer
+	Capabilities []string
+	// Pinner verifies the TLS certificate of the orderer against its pins
+	Pinner *pinning.Pinner
+}
+
+func (r *RegisterApproverInput) Validate() error {
+	if r.ChannelID == "" {
+		return errors.New("ChannelID not specified")
+	}
+	if r.PeerAddress == "" {
+		return errors.New("PeerAddresses not specified")
+	}
+	return nil
+}
+
+func RegisterApproverCmd(r *RegisterApprover, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   registerApproverCmdName,
+		Short: "FOR INTERNAL USE ONLY. Register this peer as an approver of a channel",
+		Long:  "FOR INTERNAL USE ONLY. Register this peer as an approver of a channel",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if r == nil {
+				input := &RegisterApproverInput{
+					OrdererAddress:        ordererAddress,
+					RootCertFilePath:      rootCertFilePath,
+					ChannelID:             channelID,
+					PeerAddress:           peerAddress,
+					TLSRootCertFile:       tlsRootCertFile,
+					ConnectionProfilePath: connectionProfilePath,
+				}
+				var err error
+				r, err = NewRegisterApprover(input, config.GetOptions(viper.GetViper()), cryptoProvider)
+				if err != nil {
+					return err
+				}
+				r.Command = cmd
+			}
+			return r.Register()
+		},
+	}
+	flagList := []string{
+		"ordererAddress",
+		"rootCertFilePath",
+		"channelID",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+// NewRegisterApprover connects to the peer and orderer of input and loads the
+// approval identity and capabilities from options. Unlike the command, it
+// reads no flag, so that the peer may register while it approves readings.
+func NewRegisterApprover(input *RegisterApproverInput, options config.Options, cryptoProvider bccsp.BCCSP) (*RegisterApprover, error) {
+	ccInput := &ClientConnectionsInput{
+		CommandName:           registerApproverCmdName,
+		EndorserRequired:      true,
+		OrdererRequired:       true,
+		OrderingEndpoint:      input.OrdererAddress,
+		OrdererCAFile:         input.RootCertFilePath,
+		ChannelID:             input.ChannelID,
+		PeerAddresses:         []string{input.PeerAddress},
+		TLSRootCertFiles:      []string{input.TLSRootCertFile},
+		ConnectionProfilePath: input.ConnectionProfilePath,
+		TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+		Pinner:                input.Pinner,
+	}
+
+	cc, err := NewClientConnections(ccInput, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	endorserClients := make([]EndorserClient, len(cc.EndorserClients))
+	for i, e := range cc.EndorserClients {
+		endorserClients[i] = e
+	}
+
+	// the registration declares the identity approvals are signed with
+	signer, err := approvalSigner(cc.Signer, options, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+	input.Capabilities = options.ApproverCapabilities()
+
+	return &RegisterApprover{
+		Input:           input,
+		BroadcastClient: cc.BroadcastClient,
+		EndorserClients: endorserClients,
+		Signer:          signer,
+	}, nil
+}
+
+func (r *RegisterApprover) Register() error {
+	err := r.Input.Validate()
+	if err != nil {
+		return err
+	}
+
+	if r.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		r.Command.SilenceUsage = true
+	}
+
+	proposal, err := r.createProposal()
+	if err != nil {
+		return errors.WithMessage(err, "failed to create proposal")
+	}
+
+	signedProposal, err := signProposal(proposal, r.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create signed proposal")
+	}
+
+	var responses []*pb.ProposalResponse
+	for _, endorser := range r.EndorserClients {
+		proposalResponse, err := endorser.ProcessProposal(context.Background(), signedProposal)
+		if err != nil {
+			return errors.WithMessage(err, "failed to endorse proposal")
+		}
+		responses = append(responses, proposalResponse)
+	}
+
+	if len(responses) == 0 {
+		// this should only be empty due to a programming bug
+		return errors.New("no proposal responses received")
+	}
+
+	proposalResponse := responses[0]
+	if proposalResponse.GetResponse() == nil {
+		return errors.New("received proposal response with nil response")
+	}
+
+	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("proposal failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+	warnDeprecation(proposalResponse)
+
+	env, err := protoutil.CreateSignedTx(proposal, r.Signer, responses...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create signed transaction")
+	}
+
+	if err = r.BroadcastClient.Send(env); err != nil {
+		return errors.WithMessage(err, "failed to send transaction")
+	}
+
+	return nil
+}
+
+func (r *RegisterApprover) createProposal() (*pb.Proposal, error) {
+	if r.Signer == nil {
+		return nil, errors.New("nil signer provided")
+	}
+
+	args := [][]byte{[]byte(registerApproverFuncName), []byte(r.Input.PeerAddress)}
+	for _, capability := range r.Input.Capabilities {
+		args = append(args, []byte(capability))
+	}
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: bloccName},
+			Input:       &pb.ChaincodeInput{Args: args},
+		},
+	}
+
+	creatorBytes, err := r.Signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	proposal, _, err := protoutil.CreateChaincodeProposalWithTxIDAndTransient(
+		cb.HeaderType_ENDORSER_TRANSACTION,
+		r.Input.ChannelID,
+		cis,
+		creatorBytes,
+		"",
+		apiversion.Transient(nil),
+	)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create ChaincodeInvocationSpec proposal")
+	}
+
+	return proposal, nil
+}
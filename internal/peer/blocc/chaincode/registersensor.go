@@ -0,0 +1,294 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/internal/peer/chaincode"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const registerSensorFuncName = "RegisterSensor"
+
+type RegisterSensor struct {
+	Certificate     tls.Certificate
+	Command         *cobra.Command
+	BroadcastClient common.BroadcastClient
+	DeliverClients  []pb.DeliverClient
+	EndorserClients []EndorserClient
+	Input           *RegisterSensorInput
+	Signer          Signer
+}
+
+type RegisterSensorInput struct {
+	ChannelID           string
+	PeerAddress         string
+	Sensor              string
+	WaitForEvent        bool
+	WaitForEventTimeout time.Duration
+	// Batch is set if Sensor is a JSON array of sensors to register at once
+	Batch bool
+	// Transient holds the sensitive parameters of the registration, e.g. the
+	// sensor's pre-shared token, which are not recorded in the block.
+	Transient map[string][]byte
+}
+
+func (r *RegisterSensorInput) Validate() error {
+	if r.ChannelID == "" {
+		return errors.New("ChannelID not specified")
+	}
+	if r.PeerAddress == "" {
+		return errors.New("PeerAddresses not specified")
+	}
+	if r.Sensor == "" {
+		return errors.New("Sensor not specified")
+	}
+	return nil
+}
+
+func RegisterSensorCmd(r *RegisterSensor, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registersensor",
+		Short: "Register a sensor on behalf of this peer's organization",
+		Long:  "Register a sensor on behalf of this peer's organization. Sensitive parameters such as the sensor's pre-shared token are passed with --transient",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if r == nil {
+				var err error
+				r, err = newRegisterSensor(cmd, cryptoProvider)
+				if err != nil {
+					return err
+				}
+			}
+			return r.Register()
+		},
+	}
+	flagList := []string{
+		"ordererAddress",
+		"rootCertFilePath",
+		"channelID",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+		"waitForEvent",
+		"waitForEventTimeout",
+		"sensor",
+		"transient",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+// newRegisterSensor connects to the peer and orderer given on the command
+// line.
+func newRegisterSensor(cmd *cobra.Command, cryptoProvider bccsp.BCCSP) (*RegisterSensor, error) {
+	var r *RegisterSensor
+	input, err := r.createInput()
+	if err != nil {
+		return nil, err
+	}
+
+	ccInput := &ClientConnectionsInput{
+		CommandName:           cmd.Name(),
+		EndorserRequired:      true,
+		OrdererRequired:       true,
+		OrderingEndpoint:      ordererAddress,
+		OrdererCAFile:         rootCertFilePath,
+		ChannelID:             channelID,
+		PeerAddresses:         []string{peerAddress},
+		TLSRootCertFiles:      []string{tlsRootCertFile},
+		ConnectionProfilePath: connectionProfilePath,
+		TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+	}
+
+	cc, err := NewClientConnections(ccInput, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	endorserClients := make([]EndorserClient, len(cc.EndorserClients))
+	for i, e := range cc.EndorserClients {
+		endorserClients[i] = e
+	}
+
+	return &RegisterSensor{
+		Command:         cmd,
+		Input:           input,
+		Certificate:     cc.Certificate,
+		BroadcastClient: cc.BroadcastClient,
+		DeliverClients:  cc.DeliverClients,
+		EndorserClients: endorserClients,
+		Signer:          cc.Signer,
+	}, nil
+}
+
+func (r *RegisterSensor) Register() error {
+	err := r.Input.Validate()
+	if err != nil {
+		return err
+	}
+
+	if r.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		r.Command.SilenceUsage = true
+	}
+
+	proposal, txIDSubmission, err := r.createProposal()
+	if err != nil {
+		return errors.WithMessage(err, "failed to create proposal")
+	}
+
+	signedProposal, err := signProposal(proposal, r.Signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create signed proposal")
+	}
+
+	var responses []*pb.ProposalResponse
+	for _, endorser := range r.EndorserClients {
+		proposalResponse, err := endorser.ProcessProposal(context.Background(), signedProposal)
+		if err != nil {
+			return errors.WithMessage(err, "failed to endorse proposal")
+		}
+		responses = append(responses, proposalResponse)
+	}
+
+	if len(responses) == 0 {
+		// this should only be empty due to a programming bug
+		return errors.New("no proposal responses received")
+	}
+
+	proposalResponse := responses[0]
+	if proposalResponse.GetResponse() == nil {
+		return errors.New("received proposal response with nil response")
+	}
+
+	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("proposal failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
+	}
+	warnDeprecation(proposalResponse)
+
+	// assemble a signed transaction (it's an Envelope message), which
+	// leaves out the transient data of the proposal
+	env, err := protoutil.CreateSignedTx(proposal, r.Signer, responses...)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create signed transaction")
+	}
+	var dg *chaincode.DeliverGroup
+	var ctx context.Context
+	if r.Input.WaitForEvent {
+		var cancelFunc context.CancelFunc
+		ctx, cancelFunc = context.WithTimeout(context.Background(), r.Input.WaitForEventTimeout)
+		defer cancelFunc()
+
+		dg = chaincode.NewDeliverGroup(
+			r.DeliverClients,
+			[]string{r.Input.PeerAddress},
+			r.Signer,
+			r.Certificate,
+			r.Input.ChannelID,
+			txIDSubmission,
+		)
+		// connect to deliver service on all peers
+		err := dg.Connect(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = r.BroadcastClient.Send(env); err != nil {
+		return errors.WithMessage(err, "failed to send transaction")
+	}
+
+	if dg != nil && ctx != nil {
+		// wait for event that contains the txID from all peers
+		err = dg.Wait(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (r *RegisterSensor) createInput() (*RegisterSensorInput, error) {
+	transientMap, err := parseTransient(transient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegisterSensorInput{
+		ChannelID:           channelID,
+		PeerAddress:         peerAddress,
+		Sensor:              sensor,
+		WaitForEvent:        waitForEvent,
+		WaitForEventTimeout: waitForEventTimeout,
+		Transient:           transientMap,
+	}, nil
+}
+
+func (r *RegisterSensor) createProposal() (proposal *pb.Proposal, txID string, err error) {
+	if r.Signer == nil {
+		return nil, "", errors.New("nil signer provided")
+	}
+
+	funcName := registerSensorFuncName
+	if r.Input.Batch {
+		funcName = registerSensorsFuncName
+	}
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: bloccName},
+			Input: &pb.ChaincodeInput{
+				Args: [][]byte{[]byte(funcName), []byte(r.Input.Sensor)},
+			},
+		},
+	}
+
+	creatorBytes, err := r.Signer.Serialize()
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "failed to serialize identity")
+	}
+
+	proposal, txID, err = protoutil.CreateChaincodeProposalWithTxIDAndTransient(
+		cb.HeaderType_ENDORSER_TRANSACTION,
+		r.Input.ChannelID,
+		cis,
+		creatorBytes,
+		"",
+		apiversion.Transient(r.Input.Transient),
+	)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "failed to create ChaincodeInvocationSpec proposal")
+	}
+
+	return proposal, txID, nil
+}
+
+// parseTransient parses the JSON object of the --transient flag, whose values
+// are base64 encoded, as for peer chaincode invoke.
+func parseTransient(transient string) (map[string][]byte, error) {
+	if transient == "" {
+		return nil, nil
+	}
+
+	var transientMap map[string][]byte
+	if err := json.Unmarshal([]byte(transient), &transientMap); err != nil {
+		return nil, errors.Wrap(err, "error parsing transient string")
+	}
+	return transientMap, nil
+}
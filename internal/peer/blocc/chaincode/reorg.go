@@ -0,0 +1,384 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const checkForkStatusFuncName = "CheckForkStatus"
+
+// parseForkStatus parses the CheckForkStatus response of a channel, versioned
+// or, from peers configured with the legacy format, the bare status.
+func parseForkStatus(statusBytes []byte) (bool, error) {
+	if forked, err := strconv.ParseBool(string(statusBytes)); err == nil {
+		return forked, nil
+	}
+	status := &struct {
+		Version int  `json:"version"`
+		Forked  bool `json:"forked"`
+	}{}
+	if err := json.Unmarshal(statusBytes, status); err != nil {
+		return false, errors.Wrap(err, "failed to parse fork status")
+	}
+	if status.Version != 1 {
+		return false, errors.Errorf("unsupported fork status version %d", status.Version)
+	}
+	return status.Forked, nil
+}
+
+// ReorgExperiment describes a reorganization experiment: a subset of peers
+// endorses DivergentBlocks fork attempts, one divergent block each, while
+// the fork status and height of the observer peers are polled to measure
+// when they detect the fork and when they recover from it.
+type ReorgExperiment struct {
+	ChannelID          string       `json:"channelID"`
+	OrdererAddress     string       `json:"ordererAddress"`
+	OrdererTLSRootCert string       `json:"ordererTLSRootCert,omitempty"`
+	ForkingPeers       []ReorgPeer  `json:"forkingPeers"`
+	Observers          []ReorgPeer  `json:"observers"`
+	DivergentBlocks    int          `json:"divergentBlocks"`
+	PollInterval       jsonDuration `json:"pollInterval"`
+	Timeout            jsonDuration `json:"timeout"`
+}
+
+// ReorgPeer is a peer taking part in a reorganization experiment.
+type ReorgPeer struct {
+	Address     string `json:"address"`
+	TLSRootCert string `json:"tlsRootCert,omitempty"`
+}
+
+// jsonDuration is a duration encoded as a string, e.g. "30s".
+type jsonDuration time.Duration
+
+func (d *jsonDuration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = jsonDuration(duration)
+	return nil
+}
+
+func (e *ReorgExperiment) Validate() error {
+	if e.ChannelID == "" {
+		return errors.New("ChannelID not specified")
+	}
+	if e.OrdererAddress == "" {
+		return errors.New("OrdererAddress not specified")
+	}
+	if len(e.ForkingPeers) == 0 {
+		return errors.New("no forking peers specified")
+	}
+	if len(e.Observers) == 0 {
+		return errors.New("no observers specified")
+	}
+	if e.DivergentBlocks < 1 {
+		return errors.New("DivergentBlocks must be at least 1")
+	}
+	if e.PollInterval <= 0 || e.Timeout <= 0 {
+		return errors.New("PollInterval and Timeout must be positive")
+	}
+	return nil
+}
+
+// ReorgReport is the machine-readable outcome of a reorganization experiment.
+// Durations are in seconds since the first fork attempt.
+type ReorgReport struct {
+	ChannelID       string              `json:"channelID"`
+	DivergentBlocks int                 `json:"divergentBlocks"`
+	StartedAt       time.Time           `json:"startedAt"`
+	FinishedAt      time.Time           `json:"finishedAt"`
+	Injections      []ReorgInjection    `json:"injections"`
+	Observers       []*ReorgObservation `json:"observers"`
+}
+
+// ReorgInjection is the outcome of a fork attempt.
+type ReorgInjection struct {
+	Block       int     `json:"block"`
+	SubmittedAt float64 `json:"submittedAt"`
+	Error       string  `json:"error,omitempty"`
+}
+
+// ReorgObservation is what an observer peer went through during the
+// experiment.
+type ReorgObservation struct {
+	Address        string  `json:"address"`
+	InitialHeight  uint64  `json:"initialHeight"`
+	FinalHeight    uint64  `json:"finalHeight"`
+	Detected       bool    `json:"detected"`
+	DetectedAfter  float64 `json:"detectedAfter,omitempty"`
+	Recovered      bool    `json:"recovered"`
+	RecoveredAfter float64 `json:"recoveredAfter,omitempty"`
+	PollErrors     int     `json:"pollErrors"`
+	LastError      string  `json:"lastError,omitempty"`
+}
+
+// chaincodeQuerier evaluates chaincode functions on a peer.
+type chaincodeQuerier interface {
+	query(chaincodeName string, args ...string) ([]byte, error)
+}
+
+// forkInjector submits a fork attempt.
+type forkInjector interface {
+	SimulateForkAttempt() error
+}
+
+// Reorg runs a reorganization experiment.
+type Reorg struct {
+	Command    *cobra.Command
+	Experiment *ReorgExperiment
+	Injector   forkInjector
+	// Observers holds the querier of every observer of the experiment
+	Observers []chaincodeQuerier
+	Writer    io.Writer
+	now       func() time.Time
+	sleep     func(time.Duration)
+}
+
+func ReorgCmd(r *Reorg, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reorg",
+		Short: "Run a chain reorganization experiment",
+		Long:  "Orchestrate divergent blocks endorsed by a subset of peers, measure when the observer peers detect the fork and recover from it, and write a JSON report of the experiment to --reportFile or stdout",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if r == nil {
+				var err error
+				if r, err = newReorg(cmd, cryptoProvider); err != nil {
+					return err
+				}
+
+				if reportFile != "" {
+					f, err := os.Create(reportFile)
+					if err != nil {
+						return errors.Wrap(err, "failed to create report file")
+					}
+					defer f.Close()
+					r.Writer = f
+				}
+			}
+			return r.Run()
+		},
+	}
+	flagList := []string{
+		"experimentFile",
+		"reportFile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func newReorg(cmd *cobra.Command, cryptoProvider bccsp.BCCSP) (*Reorg, error) {
+	experimentBytes, err := ioutil.ReadFile(experimentFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read experiment")
+	}
+	experiment := &ReorgExperiment{}
+	if err := json.Unmarshal(experimentBytes, experiment); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal experiment")
+	}
+	if err := experiment.Validate(); err != nil {
+		return nil, err
+	}
+
+	forkingPeers := &ClientConnectionsInput{
+		CommandName:      cmd.Name(),
+		EndorserRequired: true,
+		OrdererRequired:  true,
+		OrderingEndpoint: experiment.OrdererAddress,
+		OrdererCAFile:    experiment.OrdererTLSRootCert,
+		ChannelID:        experiment.ChannelID,
+		TLSEnabled:       viper.GetBool("peer.tls.enabled"),
+	}
+	for _, peer := range experiment.ForkingPeers {
+		forkingPeers.PeerAddresses = append(forkingPeers.PeerAddresses, peer.Address)
+		forkingPeers.TLSRootCertFiles = append(forkingPeers.TLSRootCertFiles, peer.TLSRootCert)
+	}
+	cc, err := NewClientConnections(forkingPeers, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+	endorserClients := make([]EndorserClient, len(cc.EndorserClients))
+	for i, e := range cc.EndorserClients {
+		endorserClients[i] = e
+	}
+
+	r := &Reorg{
+		Command:    cmd,
+		Experiment: experiment,
+		Injector: &SimulateForkAttempt{
+			Input: &SimulateForkAttemptInput{
+				OrdererAddress:   experiment.OrdererAddress,
+				RootCertFilePath: experiment.OrdererTLSRootCert,
+				ChannelID:        experiment.ChannelID,
+				PeerAddress:      experiment.ForkingPeers[0].Address,
+			},
+			Certificate:     cc.Certificate,
+			BroadcastClient: cc.BroadcastClient,
+			EndorserClients: endorserClients,
+			Signer:          cc.Signer,
+		},
+		Writer: os.Stdout,
+	}
+
+	for _, peer := range experiment.Observers {
+		cc, err := NewClientConnections(&ClientConnectionsInput{
+			CommandName:      cmd.Name(),
+			EndorserRequired: true,
+			ChannelID:        experiment.ChannelID,
+			PeerAddresses:    []string{peer.Address},
+			TLSRootCertFiles: []string{peer.TLSRootCert},
+			TLSEnabled:       viper.GetBool("peer.tls.enabled"),
+		}, cryptoProvider)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to connect to observer %s", peer.Address)
+		}
+		r.Observers = append(r.Observers, &peerQuerier{
+			ChannelID:      experiment.ChannelID,
+			Signer:         cc.Signer,
+			EndorserClient: cc.EndorserClients[0],
+		})
+	}
+
+	return r, nil
+}
+
+// Run injects the divergent blocks, observes the observers until all of them
+// detected the fork and recovered from it or the experiment timed out, and
+// writes the report.
+func (r *Reorg) Run() error {
+	if err := r.Experiment.Validate(); err != nil {
+		return err
+	}
+	if len(r.Observers) != len(r.Experiment.Observers) {
+		return errors.New("every observer must have a querier")
+	}
+
+	if r.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		r.Command.SilenceUsage = true
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+	if r.sleep == nil {
+		r.sleep = time.Sleep
+	}
+
+	report := &ReorgReport{
+		ChannelID:       r.Experiment.ChannelID,
+		DivergentBlocks: r.Experiment.DivergentBlocks,
+	}
+	for i, peer := range r.Experiment.Observers {
+		observation := &ReorgObservation{Address: peer.Address}
+		observation.InitialHeight, _ = r.height(r.Observers[i], observation)
+		report.Observers = append(report.Observers, observation)
+	}
+
+	report.StartedAt = r.now()
+	since := func() float64 { return r.now().Sub(report.StartedAt).Seconds() }
+
+	for block := 1; block <= r.Experiment.DivergentBlocks; block++ {
+		injection := ReorgInjection{Block: block, SubmittedAt: since()}
+		if err := r.Injector.SimulateForkAttempt(); err != nil {
+			logger.Warningf("Fork attempt %d failed: %s", block, err)
+			injection.Error = err.Error()
+		}
+		report.Injections = append(report.Injections, injection)
+	}
+
+	deadline := report.StartedAt.Add(time.Duration(r.Experiment.Timeout))
+	for {
+		done := true
+		for i, observation := range report.Observers {
+			if observation.Recovered {
+				continue
+			}
+			r.observe(r.Observers[i], observation, since())
+			done = done && observation.Recovered
+		}
+		if done || !r.now().Before(deadline) {
+			break
+		}
+		r.sleep(time.Duration(r.Experiment.PollInterval))
+	}
+	report.FinishedAt = r.now()
+
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal report")
+	}
+	_, err = r.Writer.Write(append(reportBytes, '\n'))
+	return errors.Wrap(err, "failed to write report")
+}
+
+// observe records the detection of the fork by the observer, and its
+// recovery once the fork is no longer reported after having been detected.
+func (r *Reorg) observe(querier chaincodeQuerier, observation *ReorgObservation, elapsed float64) {
+	if height, err := r.height(querier, observation); err == nil {
+		observation.FinalHeight = height
+	}
+
+	statusBytes, err := querier.query(bloccName, checkForkStatusFuncName, r.Experiment.ChannelID)
+	if err != nil {
+		observation.PollErrors++
+		observation.LastError = err.Error()
+		return
+	}
+	forked, err := parseForkStatus(statusBytes)
+	if err != nil {
+		observation.PollErrors++
+		observation.LastError = err.Error()
+		return
+	}
+
+	switch {
+	case forked && !observation.Detected:
+		observation.Detected = true
+		observation.DetectedAfter = elapsed
+	case !forked && observation.Detected:
+		observation.Recovered = true
+		observation.RecoveredAfter = elapsed
+	}
+}
+
+func (r *Reorg) height(querier chaincodeQuerier, observation *ReorgObservation) (uint64, error) {
+	height, err := chainHeight(querier, r.Experiment.ChannelID)
+	if err != nil {
+		observation.PollErrors++
+		observation.LastError = err.Error()
+	}
+	return height, err
+}
+
+// chainHeight returns the height of the channel on the peer.
+func chainHeight(querier chaincodeQuerier, channelID string) (uint64, error) {
+	infoBytes, err := querier.query("qscc", "GetChainInfo", channelID)
+	if err == nil {
+		info := &cb.BlockchainInfo{}
+		if err = proto.Unmarshal(infoBytes, info); err == nil {
+			return info.Height, nil
+		}
+	}
+	return 0, errors.WithMessage(err, "failed to get chain info")
+}
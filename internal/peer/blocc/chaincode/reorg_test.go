@@ -0,0 +1,143 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeInjector struct {
+	attempts int
+	err      error
+}
+
+func (f *fakeInjector) SimulateForkAttempt() error {
+	f.attempts++
+	return f.err
+}
+
+// fakeObserver reports the fork statuses in turn, the last one repeatedly.
+type fakeObserver struct {
+	statuses []string
+	height   uint64
+}
+
+func (f *fakeObserver) query(chaincodeName string, args ...string) ([]byte, error) {
+	if chaincodeName == "qscc" {
+		f.height++
+		return protoutil.MarshalOrPanic(&cb.BlockchainInfo{Height: f.height}), nil
+	}
+
+	status := f.statuses[0]
+	if len(f.statuses) > 1 {
+		f.statuses = f.statuses[1:]
+	}
+	if status == "error" {
+		return nil, errors.New("peer unavailable")
+	}
+	return []byte(status), nil
+}
+
+func TestReorg(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	injector := &fakeInjector{err: errors.New("orderer halted")}
+	buf := &bytes.Buffer{}
+	r := &Reorg{
+		Experiment: &ReorgExperiment{
+			ChannelID:       "mychannel",
+			OrdererAddress:  "orderer.example.com:7050",
+			ForkingPeers:    []ReorgPeer{{Address: "peer0.org1.example.com:7051"}},
+			Observers:       []ReorgPeer{{Address: "peer0.org2.example.com:9051"}, {Address: "peer0.org3.example.com:11051"}},
+			DivergentBlocks: 2,
+			PollInterval:    jsonDuration(time.Second),
+			Timeout:         jsonDuration(5 * time.Second),
+		},
+		Injector: injector,
+		Observers: []chaincodeQuerier{
+			&fakeObserver{statuses: []string{"false", "true", "error", "false"}},
+			&fakeObserver{statuses: []string{`{"version":1,"forked":true,"record":null}`}},
+		},
+		Writer: buf,
+		now:    func() time.Time { return now },
+		sleep:  func(d time.Duration) { now = now.Add(d) },
+	}
+
+	require.NoError(t, r.Run())
+	require.Equal(t, 2, injector.attempts)
+
+	report := &ReorgReport{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), report))
+	require.Equal(t, []ReorgInjection{
+		{Block: 1, Error: "orderer halted"},
+		{Block: 2, Error: "orderer halted"},
+	}, report.Injections)
+	require.Equal(t, float64(5), report.FinishedAt.Sub(report.StartedAt).Seconds())
+
+	require.Equal(t, &ReorgObservation{
+		Address:        "peer0.org2.example.com:9051",
+		InitialHeight:  1,
+		FinalHeight:    5,
+		Detected:       true,
+		DetectedAfter:  1,
+		Recovered:      true,
+		RecoveredAfter: 3,
+		PollErrors:     1,
+		LastError:      "peer unavailable",
+	}, report.Observers[0])
+
+	// forked until the experiment timed out
+	require.Equal(t, &ReorgObservation{
+		Address:       "peer0.org3.example.com:11051",
+		InitialHeight: 1,
+		FinalHeight:   7,
+		Detected:      true,
+	}, report.Observers[1])
+}
+
+func TestParseForkStatus(t *testing.T) {
+	forked, err := parseForkStatus([]byte(`{"version":1,"forked":true,"record":{"forked":true,"acknowledged":false}}`))
+	require.NoError(t, err)
+	require.True(t, forked)
+
+	forked, err = parseForkStatus([]byte("false"))
+	require.NoError(t, err)
+	require.False(t, forked)
+
+	_, err = parseForkStatus([]byte(`{"version":2,"forked":true}`))
+	require.EqualError(t, err, "unsupported fork status version 2")
+	_, err = parseForkStatus([]byte("maybe"))
+	require.Error(t, err)
+}
+
+func TestReorgExperimentValidate(t *testing.T) {
+	experiment := &ReorgExperiment{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"channelID": "mychannel",
+		"ordererAddress": "orderer.example.com:7050",
+		"forkingPeers": [{"address": "peer0.org1.example.com:7051"}],
+		"observers": [{"address": "peer0.org2.example.com:9051"}],
+		"divergentBlocks": 3,
+		"pollInterval": "500ms",
+		"timeout": "2m"
+	}`), experiment))
+	require.NoError(t, experiment.Validate())
+	require.Equal(t, jsonDuration(500*time.Millisecond), experiment.PollInterval)
+
+	experiment.DivergentBlocks = 0
+	require.EqualError(t, experiment.Validate(), "DivergentBlocks must be at least 1")
+	experiment.Observers = nil
+	require.EqualError(t, experiment.Validate(), "no observers specified")
+
+	require.Error(t, json.Unmarshal([]byte(`{"timeout": "2 minutes"}`), experiment))
+}
@@ -0,0 +1,128 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const signerBCCSPKey = "blocc.approvals.signer.BCCSP"
+
+// ApprovalSigner returns the identity the approvals of this peer are signed
+// with, as configured by the BLOCC options.
+func ApprovalSigner(cryptoProvider bccsp.BCCSP) (Signer, error) {
+	options := config.GetOptions(viper.GetViper())
+	if options.AnonymousApprovals || options.SignerMSPConfigPath != "" {
+		return approvalSigner(nil, options, cryptoProvider)
+	}
+
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// approvalSigner returns the anonymous or dedicated approval identity if one
+// is configured, and defaultSigner otherwise.
+func approvalSigner(defaultSigner Signer, options config.Options, cryptoProvider bccsp.BCCSP) (Signer, error) {
+	if options.AnonymousApprovals {
+		signer, err := newIdemixSigner(options.IdemixMSPConfigPath, options.IdemixMSPID, cryptoProvider)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to load anonymous approval identity")
+		}
+		return signer, nil
+	}
+	if options.SignerMSPConfigPath != "" {
+		bccspOpts, err := signerBCCSPOpts(viper.GetViper())
+		if err != nil {
+			return nil, err
+		}
+		signer, err := newApprovalSigner(options.SignerMSPConfigPath, options.SignerMSPID, bccspOpts)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to load approval identity")
+		}
+		return signer, nil
+	}
+	return defaultSigner, nil
+}
+
+// newApprovalSigner loads the dedicated approval identity found in
+// mspConfigPath, whose key is held by the crypto provider described by
+// bccspOpts, e.g. a PKCS#11 token, and returns its default signing identity.
+func newApprovalSigner(mspConfigPath, mspID string, bccspOpts *factory.FactoryOpts) (Signer, error) {
+	if mspConfigPath == "" {
+		return nil, errors.New("approval signer MSP config path not specified")
+	}
+	if mspID == "" {
+		return nil, errors.New("approval signer MSP ID not specified")
+	}
+
+	// defaults the software keystore to the keystore folder of mspConfigPath
+	conf, err := msp.GetLocalMspConfig(mspConfigPath, bccspOpts, mspID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to load approval signer MSP config from %s", mspConfigPath)
+	}
+
+	cryptoProvider, err := factory.GetBCCSPFromOpts(bccspOpts)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create approval signer crypto provider")
+	}
+
+	signerMSP, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_0}}, cryptoProvider)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create approval signer MSP")
+	}
+
+	if err := signerMSP.Setup(conf); err != nil {
+		return nil, errors.WithMessage(err, "failed to set up approval signer MSP")
+	}
+
+	signer, err := signerMSP.GetDefaultSigningIdentity()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to obtain approval signing identity")
+	}
+
+	return signer, nil
+}
+
+// signerBCCSPOpts decodes the crypto provider settings of the approval
+// identity, which follow the layout of peer.BCCSP and may be overridden
+// through CORE_BLOCC_APPROVALS_SIGNER_BCCSP_* environment variables, e.g.
+// to provide the PKCS#11 PIN.
+func signerBCCSPOpts(v *viper.Viper) (*factory.FactoryOpts, error) {
+	bccspOpts := factory.GetDefaultOpts()
+
+	subv := v.Sub(signerBCCSPKey)
+	if subv == nil {
+		return bccspOpts, nil
+	}
+	subv.SetEnvPrefix("CORE_BLOCC_APPROVALS_SIGNER_BCCSP")
+	subv.AutomaticEnv()
+	subv.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	subv.SetTypeByDefaultValue(true)
+
+	opts := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		factory.StringToKeyIds(),
+	))
+
+	if err := subv.Unmarshal(&bccspOpts, opts); err != nil {
+		return nil, errors.WithMessage(err, "could not decode approval signer BCCSP configuration")
+	}
+
+	return bccspOpts, nil
+}
@@ -0,0 +1,112 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/pkcs11"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewApprovalSignerPKCS11 signs with an approval identity whose key is
+// generated in softhsm, and is skipped if softhsm is not installed.
+func TestNewApprovalSignerPKCS11(t *testing.T) {
+	lib, pin, label := pkcs11.FindPKCS11Lib()
+	if lib == "" {
+		t.Skip("PKCS11 library not found")
+	}
+
+	bccspOpts := &factory.FactoryOpts{
+		Default: "PKCS11",
+		PKCS11: &pkcs11.PKCS11Opts{
+			Security: 256,
+			Hash:     "SHA2",
+			Library:  lib,
+			Label:    label,
+			Pin:      pin,
+		},
+	}
+	csp, err := factory.GetBCCSPFromOpts(bccspOpts)
+	require.NoError(t, err)
+
+	key, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	publicKey, err := key.PublicKey()
+	require.NoError(t, err)
+	publicKeyBytes, err := publicKey.Bytes()
+	require.NoError(t, err)
+	hsmPublicKey, err := x509.ParsePKIXPublicKey(publicKeyBytes)
+	require.NoError(t, err)
+
+	mspDir := t.TempDir()
+	writeApproverMSP(t, mspDir, hsmPublicKey)
+
+	signer, err := newApprovalSigner(mspDir, "Org1MSP", bccspOpts)
+	require.NoError(t, err)
+
+	message := []byte("approval")
+	signature, err := signer.Sign(message)
+	require.NoError(t, err)
+	require.NoError(t, signer.(msp.SigningIdentity).Verify(message, signature))
+}
+
+// writeApproverMSP writes an MSP directory holding a CA certificate and a
+// signing certificate issued by the CA for the public key held in the HSM.
+func writeApproverMSP(t *testing.T, mspDir string, publicKey interface{}) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := time.Now().Add(time.Hour)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.org1.example.com", Organization: []string{"org1.example.com"}},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	signTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "approver.org1.example.com", Organization: []string{"org1.example.com"}},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	signDER, err := x509.CreateCertificate(rand.Reader, signTemplate, caCert, publicKey, caKey)
+	require.NoError(t, err)
+
+	for dir, der := range map[string][]byte{"cacerts": caDER, "signcerts": signDER} {
+		require.NoError(t, os.MkdirAll(filepath.Join(mspDir, dir), 0o755))
+		pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		require.NoError(t, ioutil.WriteFile(filepath.Join(mspDir, dir, "cert.pem"), pemBytes, 0o644))
+	}
+}
@@ -0,0 +1,63 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewApprovalSigner(t *testing.T) {
+	signer, err := newApprovalSigner(configtest.GetDevMspDir(), "SampleOrg", factory.GetDefaultOpts())
+	require.NoError(t, err)
+
+	message := []byte("approval")
+	signature, err := signer.Sign(message)
+	require.NoError(t, err)
+	require.NoError(t, signer.(msp.SigningIdentity).Verify(message, signature))
+}
+
+func TestNewApprovalSignerErrors(t *testing.T) {
+	_, err := newApprovalSigner("", "SampleOrg", factory.GetDefaultOpts())
+	require.EqualError(t, err, "approval signer MSP config path not specified")
+
+	_, err = newApprovalSigner(configtest.GetDevMspDir(), "", factory.GetDefaultOpts())
+	require.EqualError(t, err, "approval signer MSP ID not specified")
+
+	_, err = newApprovalSigner(t.TempDir(), "SampleOrg", factory.GetDefaultOpts())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to load approval signer MSP config")
+}
+
+func TestSignerBCCSPOpts(t *testing.T) {
+	v := viper.New()
+	bccspOpts, err := signerBCCSPOpts(v)
+	require.NoError(t, err)
+	require.Equal(t, factory.GetDefaultOpts(), bccspOpts)
+
+	v.SetConfigType("yaml")
+	require.NoError(t, v.ReadConfig(bytes.NewBufferString(`
+blocc:
+  approvals:
+    signer:
+      BCCSP:
+        Default: SW
+        SW:
+          Hash: SHA3
+          Security: 384
+`)))
+	bccspOpts, err = signerBCCSPOpts(v)
+	require.NoError(t, err)
+	require.Equal(t, "SW", bccspOpts.Default)
+	require.Equal(t, "SHA3", bccspOpts.SW.Hash)
+	require.Equal(t, 384, bccspOpts.SW.Security)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/internal/peer/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/apiversion"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -167,6 +168,7 @@ func (s *SimulateForkAttempt) SimulateForkAttempt() error {
 	if proposalResponse.Response.Status != int32(cb.Status_SUCCESS) {
 		return errors.Errorf("proposal failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
 	}
+	warnDeprecation(proposalResponse)
 	// assemble a signed transaction (it's an Envelope message)
 	env, err := protoutil.CreateSignedTx(proposal, s.Signer, responses...)
 	if err != nil {
@@ -250,7 +252,7 @@ func (s *SimulateForkAttempt) createProposal() (proposal *pb.Proposal, txID stri
 		cis,
 		creatorBytes,
 		"",
-		nil,
+		apiversion.Transient(nil),
 	)
 	if err != nil {
 		return nil, "", errors.WithMessage(err, "failed to create ChaincodeInvocationSpec proposal")
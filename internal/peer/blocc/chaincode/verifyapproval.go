@@ -0,0 +1,307 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/messages"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// VerifyApproval checks an approval transaction on behalf of an auditor: the
+// signatures of its creator and endorsers against the channel's MSPs, and
+// the validity of the sensory transaction it approves.
+type VerifyApproval struct {
+	Command        *cobra.Command
+	Querier        *peerQuerier
+	CryptoProvider bccsp.BCCSP
+	TxID           string
+	Writer         io.Writer
+}
+
+// ApprovalCheck is the outcome of a single verification step, Err being nil
+// if the check passed.
+type ApprovalCheck struct {
+	Description string
+	Err         error
+}
+
+// ApprovalVerdict gathers the checks performed on an approval transaction.
+type ApprovalVerdict struct {
+	ApprovalTxID string
+	SensoryTxID  string
+	MSPID        string
+	Checks       []ApprovalCheck
+}
+
+// Valid returns whether all checks passed.
+func (v *ApprovalVerdict) Valid() bool {
+	for _, check := range v.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (v *ApprovalVerdict) check(description string, err error) bool {
+	v.Checks = append(v.Checks, ApprovalCheck{Description: description, Err: err})
+	return err == nil
+}
+
+func VerifyApprovalCmd(v *VerifyApproval, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-approval",
+		Short: "Verify an approval transaction",
+		Long:  "Verify the signatures of an approval transaction against the channel MSPs and check that the approved sensory transaction is valid",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if v == nil {
+				ccInput := &ClientConnectionsInput{
+					CommandName:           cmd.Name(),
+					EndorserRequired:      true,
+					ChannelID:             channelID,
+					PeerAddresses:         []string{peerAddress},
+					TLSRootCertFiles:      []string{tlsRootCertFile},
+					ConnectionProfilePath: connectionProfilePath,
+					TLSEnabled:            viper.GetBool("peer.tls.enabled"),
+				}
+
+				cc, err := NewClientConnections(ccInput, cryptoProvider)
+				if err != nil {
+					return err
+				}
+				if len(cc.EndorserClients) == 0 {
+					return errors.New("no endorser clients")
+				}
+
+				v = &VerifyApproval{
+					Command: cmd,
+					Querier: &peerQuerier{
+						ChannelID:      channelID,
+						Signer:         cc.Signer,
+						EndorserClient: cc.EndorserClients[0],
+					},
+					CryptoProvider: cryptoProvider,
+					TxID:           txID,
+					Writer:         os.Stdout,
+				}
+			}
+			return v.Verify()
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"txID",
+		"peerAddress",
+		"tlsRootCertFile",
+		"connectionProfile",
+	}
+	attachFlags(cmd, flagList)
+
+	return cmd
+}
+
+func (v *VerifyApproval) Verify() error {
+	if v.Querier.ChannelID == "" {
+		return errors.New("ChannelID not specified")
+	}
+	if v.TxID == "" {
+		return errors.New("TxID not specified")
+	}
+
+	if v.Command != nil {
+		// Parsing of the command line is done so silence cmd usage
+		v.Command.SilenceUsage = true
+	}
+
+	verdict, err := v.verdict()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(v.Writer, messages.Sprintf(messages.ApprovalVerified, verdict.ApprovalTxID, verdict.SensoryTxID, verdict.MSPID))
+	for _, check := range verdict.Checks {
+		if check.Err != nil {
+			fmt.Fprintln(v.Writer, messages.Sprintf(messages.CheckFailed, check.Description, check.Err))
+			continue
+		}
+		fmt.Fprintln(v.Writer, messages.Sprintf(messages.CheckPassed, check.Description))
+	}
+
+	if !verdict.Valid() {
+		fmt.Fprintln(v.Writer, messages.Sprintf(messages.VerdictInvalid))
+		return errors.Errorf("approval %s failed verification", v.TxID)
+	}
+	fmt.Fprintln(v.Writer, messages.Sprintf(messages.VerdictValid))
+
+	return nil
+}
+
+func (v *VerifyApproval) verdict() (*ApprovalVerdict, error) {
+	verdict := &ApprovalVerdict{ApprovalTxID: v.TxID}
+
+	approvalTx, err := v.Querier.transactionByID(v.TxID)
+	if err != nil {
+		return nil, err
+	}
+	verdict.check("approval transaction committed as valid", validationError(approvalTx))
+
+	envelope := approvalTx.GetTransactionEnvelope()
+	sensoryTxID, err := approvedReading(envelope)
+	if !verdict.check("approval transaction invokes "+approveFuncName, err) {
+		return verdict, nil
+	}
+	verdict.SensoryTxID = sensoryTxID
+
+	mspManager, err := v.channelMSPManager()
+	if err != nil {
+		return nil, err
+	}
+
+	verdict.MSPID, err = verifyCreator(mspManager, envelope)
+	verdict.check("creator signature verifies against the channel MSPs", err)
+	verdict.check("endorser signatures verify against the channel MSPs", verifyEndorsements(mspManager, envelope))
+
+	sensoryTx, err := v.Querier.transactionByID(sensoryTxID)
+	if !verdict.check("sensory transaction exists", err) {
+		return verdict, nil
+	}
+	verdict.check("sensory transaction committed as valid", validationError(sensoryTx))
+
+	return verdict, nil
+}
+
+func (v *VerifyApproval) channelMSPManager() (msp.MSPManager, error) {
+	configBytes, err := v.Querier.query("cscc", "GetChannelConfig", v.Querier.ChannelID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get channel config")
+	}
+
+	config := &cb.Config{}
+	if err := proto.Unmarshal(configBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal channel config")
+	}
+
+	bundle, err := channelconfig.NewBundle(v.Querier.ChannelID, config, v.CryptoProvider)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to load channel config")
+	}
+
+	return bundle.MSPManager(), nil
+}
+
+func validationError(processedTx *pb.ProcessedTransaction) error {
+	if code := pb.TxValidationCode(processedTx.ValidationCode); code != pb.TxValidationCode_VALID {
+		return errors.Errorf("validation code %s", code)
+	}
+	return nil
+}
+
+// approvedReading returns the ID of the sensory transaction approved by the
+// approval transaction envelope.
+func approvedReading(envelope *cb.Envelope) (string, error) {
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal envelope")
+	}
+
+	cis, err := protoutil.ExtractChaincodeInvocationSpec(envelopeBytes)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to extract chaincode invocation")
+	}
+
+	args := cis.GetChaincodeSpec().GetInput().GetArgs()
+	if name := cis.GetChaincodeSpec().GetChaincodeId().GetName(); name != bloccName || len(args) < 2 || string(args[0]) != approveFuncName {
+		return "", errors.Errorf("transaction invokes %s instead", name)
+	}
+
+	approveArgs := &lb.ApproveSensoryTxArgs{}
+	if err := proto.Unmarshal(args[1], approveArgs); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal approval arguments")
+	}
+
+	return approveArgs.TxId, nil
+}
+
+// verifyCreator verifies the envelope signature and returns the creator's MSP ID.
+func verifyCreator(mspManager msp.MSPManager, envelope *cb.Envelope) (string, error) {
+	creator, err := protoutil.ExtractCreatorFromEnvelope(envelope)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to extract creator")
+	}
+
+	identity, err := verifiedIdentity(mspManager, creator, envelope.Payload, envelope.Signature)
+	if err != nil {
+		return "", err
+	}
+
+	return identity.GetMSPIdentifier(), nil
+}
+
+func verifyEndorsements(mspManager msp.MSPManager, envelope *cb.Envelope) error {
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal payload")
+	}
+
+	tx, err := protoutil.UnmarshalTransaction(payload.Data)
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal transaction")
+	}
+	if len(tx.Actions) == 0 {
+		return errors.New("no transaction actions found")
+	}
+
+	ccActionPayload, _, err := protoutil.GetPayloads(tx.Actions[0])
+	if err != nil {
+		return errors.WithMessage(err, "failed to unmarshal chaincode action")
+	}
+
+	endorsements := ccActionPayload.GetAction().GetEndorsements()
+	if len(endorsements) == 0 {
+		return errors.New("no endorsements found")
+	}
+
+	prp := ccActionPayload.Action.ProposalResponsePayload
+	for _, endorsement := range endorsements {
+		message := append(append([]byte{}, prp...), endorsement.Endorser...)
+		if _, err := verifiedIdentity(mspManager, endorsement.Endorser, message, endorsement.Signature); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func verifiedIdentity(mspManager msp.MSPManager, serializedIdentity, message, signature []byte) (msp.Identity, error) {
+	identity, err := mspManager.DeserializeIdentity(serializedIdentity)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to deserialize identity")
+	}
+
+	if err := identity.Validate(); err != nil {
+		return nil, errors.WithMessagef(err, "identity of %s is not valid", identity.GetMSPIdentifier())
+	}
+
+	if err := identity.Verify(message, signature); err != nil {
+		return nil, errors.WithMessagef(err, "invalid signature of %s", identity.GetMSPIdentifier())
+	}
+
+	return identity, nil
+}
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package common
 
 import (
+	"fmt"
+
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
@@ -19,6 +21,17 @@ type BroadcastClient interface {
 	Close() error
 }
 
+// BroadcastStatusError is returned when the orderer acknowledges a broadcast
+// with a status other than SUCCESS.
+type BroadcastStatusError struct {
+	Status cb.Status
+	Info   string
+}
+
+func (e *BroadcastStatusError) Error() string {
+	return fmt.Sprintf("got unexpected status: %v -- %s", e.Status, e.Info)
+}
+
 type BroadcastGRPCClient struct {
 	Client ab.AtomicBroadcast_BroadcastClient
 }
@@ -57,7 +70,7 @@ func (s *BroadcastGRPCClient) getAck() error {
 		return err
 	}
 	if msg.Status != cb.Status_SUCCESS {
-		return errors.Errorf("got unexpected status: %v -- %s", msg.Status, msg.Info)
+		return &BroadcastStatusError{Status: msg.Status, Info: msg.Info}
 	}
 	return nil
 }
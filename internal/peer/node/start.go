@@ -27,6 +27,7 @@ import (
 	gatewayprotos "github.com/hyperledger/fabric-protos-go/gateway"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	bloccevent "github.com/hyperledger/fabric/common/blocc-events"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ccdef "github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -94,6 +95,8 @@ import (
 	"github.com/hyperledger/fabric/internal/peer/version"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/internal/pkg/gateway"
+	"github.com/hyperledger/fabric/internal/pkg/gateway/commit"
+	gatewayledger "github.com/hyperledger/fabric/internal/pkg/gateway/ledger"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protoutil"
@@ -443,6 +446,10 @@ func serve(args []string) error {
 		cb.HeaderType_CONFIG: &peer.ConfigTxProcessor{},
 	}
 
+	// the BLOCC events of the peer are exchanged on its own bus, so that
+	// peers running in the same process do not receive each other's events
+	eventBus := bloccevent.NewEventBus()
+
 	blockCommitter := gossipservice.GossipBlockCommitterImpl{
 		PolicyManagerGetterFunc: policyMgr,
 		ThisPeer:                signingIdentity.GetPublicVersion(),
@@ -461,6 +468,7 @@ func serve(args []string) error {
 			HashProvider:                    factory.GetDefault(),
 			EbMetadataProvider:              ebMetadataProvider,
 			BlockCommitter:                  &blockCommitter,
+			EventBus:                        eventBus,
 		},
 	)
 
@@ -480,6 +488,7 @@ func serve(args []string) error {
 		coreConfig.PeerAddress,
 		deliverServiceConfig,
 		privdataConfig,
+		eventBus,
 	)
 	if err != nil {
 		return errors.WithMessage(err, "failed to initialize gossip service")
@@ -724,7 +733,9 @@ func serve(args []string) error {
 		factory.GetDefault(),
 	)
 	qsccInst := scc.SelfDescribingSysCC(qscc.New(aclProvider, peerInstance))
-	bsccInst := bscc.New(peerInstance)
+	bloccService := bscc.NewBloccService(bscc.NewPeerInfoProvider(peerInstance), metricsProvider, eventBus)
+	bsccInst := bscc.New(bloccService, aclProvider)
+	opsSystem.RegisterHandler(bscc.WatchForkStatusPath, bloccService.WatchForkStatus(), coreConfig.OperationsTLSEnabled)
 
 	pb.RegisterChaincodeSupportServer(ccSrv.Server(), ccSupSrv)
 
@@ -763,12 +774,17 @@ func serve(args []string) error {
 	channelFetcher := endorserChannelAdapter{
 		peer: peerInstance,
 	}
+	// the ledgers notify their commits to a single consumer, shared by the
+	// gateway and the endorser
+	commitNotifier := commit.NewNotifier(&gatewayledger.PeerAdapter{Peer: peerInstance})
 	serverEndorser := &endorser.Endorser{
 		PrivateDataDistributor: gossipService,
 		ChannelFetcher:         channelFetcher,
 		LocalMSP:               localMSP,
 		Support:                endorserSupport,
 		Metrics:                endorser.NewMetrics(metricsProvider),
+		CommitNotifier:         commitNotifier,
+		ReadingAdmitter:        bloccService.Shedder(),
 	}
 
 	// deploy system chaincodes
@@ -779,7 +795,19 @@ func serve(args []string) error {
 		}
 		scc.DeploySysCC(cc, chaincodeSupport)
 		if cc.Name() == "bscc" {
-			bsccInst.Init(nil)
+			err := bloccService.Start(bscc.Config{
+				PeerAddress:    coreConfig.PeerAddress,
+				TLSCertFile:    coreconfig.GetPath("peer.tls.rootcert.file"),
+				CryptoProvider: factory.GetDefault(),
+				PeerIdentity:   signingIdentityBytes,
+				Signer:         signingIdentity,
+				Gossip:         gossipService,
+			})
+			if err != nil {
+				logger.Errorf("Failed to start BLOCC service: %s", err)
+			} else {
+				peerInstance.AddConfigCallbacks(bloccService.ConfigUpdate)
+			}
 		}
 	}
 
@@ -860,6 +888,7 @@ func serve(args []string) error {
 				coreConfig.LocalMSPID,
 				coreConfig.GatewayOptions,
 				builtinSCCs,
+				commitNotifier,
 			)
 			gatewayprotos.RegisterGatewayServer(peerServer.Server(), gatewayServer)
 		} else {
@@ -889,8 +918,13 @@ func serve(args []string) error {
 	}
 
 	handleSignals(addPlatformSignals(map[os.Signal]func(){
-		syscall.SIGINT:  func() { containerRouter.Shutdown(5 * time.Second); serve <- nil },
-		syscall.SIGTERM: func() { containerRouter.Shutdown(5 * time.Second); serve <- nil },
+		syscall.SIGINT:  func() { bloccService.Stop(); containerRouter.Shutdown(5 * time.Second); serve <- nil },
+		syscall.SIGTERM: func() { bloccService.Stop(); containerRouter.Shutdown(5 * time.Second); serve <- nil },
+		syscall.SIGHUP: func() {
+			if err := bloccService.ReloadConfig(); err != nil {
+				logger.Errorf("Failed to reload BLOCC configuration: %s", err)
+			}
+		},
 	}))
 
 	logger.Infof("Started peer with ID=[%s], network ID=[%s], address=[%s]", coreConfig.PeerID, coreConfig.NetworkID, coreConfig.PeerAddress)
@@ -1225,6 +1259,7 @@ func initGossipService(
 	peerAddress string,
 	deliverServiceConfig *deliverservice.DeliverServiceConfig,
 	privdataConfig *gossipprivdata.PrivdataConfig,
+	eventBus *bloccevent.Bus,
 ) (*gossipservice.GossipService, error) {
 	var certs *gossipcommon.TLSCertificates
 	if peerServer.TLSEnabled() {
@@ -1257,6 +1292,7 @@ func initGossipService(
 	if err != nil {
 		return nil, errors.Wrap(err, "failed obtaining gossip config")
 	}
+	gossipConfig.EventBus = eventBus
 
 	return gossipservice.New(
 		signer,
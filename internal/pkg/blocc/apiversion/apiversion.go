@@ -0,0 +1,85 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package apiversion negotiates the version of the BSCC API, its functions,
+// their arguments and the JSON documents they return, between BSCC and its
+// clients such as the peer blocc commands and the SDKs.
+//
+// A client tells the version it speaks in the TransientKey entry of the
+// transient map of its proposals, which is left out of the transactions.
+// BSCC rejects the versions it does not serve with an explicit error rather
+// than returning documents the client would misread, and serves the versions
+// older than Current with a deprecation warning in the DeprecationEvent
+// chaincode event of the response. The message of the response is left to
+// the function, ApproveSensoryReading returning its consistency token there.
+package apiversion
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TransientKey is the key of the transient map entry holding the
+	// version spoken by the client, as a decimal integer.
+	TransientKey = "blocc.apiVersion"
+	// DeprecationEvent is the name of the chaincode event holding the
+	// deprecation warning of the responses to deprecated versions.
+	DeprecationEvent = "blocc.apiDeprecation"
+
+	// Current is the version of the BSCC API of this release.
+	Current = 1
+	// Min is the oldest version served by BSCC.
+	Min = 1
+	// Unversioned is the version spoken by the clients telling none, which
+	// were released before the API was versioned.
+	Unversioned = 1
+)
+
+// FromTransient returns the version told in the transient map of a proposal,
+// Unversioned if it tells none.
+func FromTransient(transient map[string][]byte) (int, error) {
+	value, ok := transient[TransientKey]
+	if !ok {
+		return Unversioned, nil
+	}
+	version, err := strconv.Atoi(string(value))
+	if err != nil || version < 1 {
+		return 0, errors.Errorf("invalid BSCC API version '%s'", value)
+	}
+	return version, nil
+}
+
+// Transient returns a copy of the transient map with the Current version
+// added, to be sent with a proposal to BSCC.
+func Transient(transient map[string][]byte) map[string][]byte {
+	versioned := map[string][]byte{TransientKey: []byte(strconv.Itoa(Current))}
+	for key, value := range transient {
+		if key != TransientKey {
+			versioned[key] = value
+		}
+	}
+	return versioned
+}
+
+// Supported tells whether BSCC serves the version.
+func Supported(version int) bool {
+	return supported(version, Min, Current)
+}
+
+// Deprecated tells whether the version is served by BSCC but older than
+// Current, and due to be dropped by a later release.
+func Deprecated(version int) bool {
+	return deprecated(version, Min, Current)
+}
+
+func supported(version, min, current int) bool {
+	return version >= min && version <= current
+}
+
+func deprecated(version, min, current int) bool {
+	return supported(version, min, current) && version < current
+}
@@ -0,0 +1,51 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package apiversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromTransient(t *testing.T) {
+	version, err := FromTransient(nil)
+	require.NoError(t, err)
+	require.Equal(t, Unversioned, version)
+
+	version, err = FromTransient(Transient(map[string][]byte{"secret": []byte("s3cr3t")}))
+	require.NoError(t, err)
+	require.Equal(t, Current, version)
+
+	version, err = FromTransient(map[string][]byte{TransientKey: []byte("7")})
+	require.NoError(t, err)
+	require.Equal(t, 7, version)
+
+	_, err = FromTransient(map[string][]byte{TransientKey: []byte("v2")})
+	require.EqualError(t, err, "invalid BSCC API version 'v2'")
+	_, err = FromTransient(map[string][]byte{TransientKey: []byte("0")})
+	require.EqualError(t, err, "invalid BSCC API version '0'")
+}
+
+func TestTransient(t *testing.T) {
+	transient := map[string][]byte{"secret": []byte("s3cr3t"), TransientKey: []byte("9")}
+	require.Equal(t, map[string][]byte{"secret": []byte("s3cr3t"), TransientKey: []byte("1")}, Transient(transient))
+	// the map of the caller is left unchanged
+	require.Equal(t, []byte("9"), transient[TransientKey])
+}
+
+func TestSupported(t *testing.T) {
+	require.True(t, Supported(Current))
+	require.False(t, Supported(Current+1))
+	require.False(t, Supported(Min-1))
+	require.False(t, Deprecated(Current))
+
+	// with versions 2 to 4 served, 2 and 3 are deprecated
+	for version, expected := range map[int][2]bool{1: {false, false}, 2: {true, true}, 3: {true, true}, 4: {true, false}, 5: {false, false}} {
+		require.Equal(t, expected[0], supported(version, 2, 4), "version %d", version)
+		require.Equal(t, expected[1], deprecated(version, 2, 4), "version %d", version)
+	}
+}
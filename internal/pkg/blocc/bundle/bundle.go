@@ -0,0 +1,244 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package bundle implements the evidence bundles exported for external
+// regulators: the readings of a sensor over a time range, their approvals
+// and the proofs that both were committed as valid, packaged as a signed
+// archive that can be verified offline.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ManifestFile describes the content of the bundle
+	ManifestFile = "manifest.json"
+	// SignatureFile holds the signature of the manifest by the exporter
+	SignatureFile = "signature.json"
+
+	// maxFileSize bounds the size of the files read from a bundle
+	maxFileSize = 64 << 20
+)
+
+// Manifest describes the content of a bundle. Every proof of the bundle is
+// chained up to the Tip header, which a regulator can compare to the header
+// of the same block obtained from any peer of the channel.
+type Manifest struct {
+	ChannelID string          `json:"channelID"`
+	SensorID  string          `json:"sensorID"`
+	From      time.Time       `json:"from"`
+	To        time.Time       `json:"to"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Tip       *cb.BlockHeader `json:"tip"`
+	Readings  []*Reading      `json:"readings"`
+	// Files maps the other files of the bundle to their hex encoded SHA-256
+	// hash
+	Files map[string]string `json:"files"`
+}
+
+// Reading lists the approvals of a reading of the bundle. Approvals of
+// private approval records, whose transaction is not disclosed, are left out.
+type Reading struct {
+	TxID          string   `json:"txID"`
+	ApprovalTxIDs []string `json:"approvalTxIDs"`
+}
+
+// Signature is the signature of the manifest by the exporter.
+type Signature struct {
+	// Creator is the serialized identity of the exporter
+	Creator   []byte `json:"creator"`
+	Signature []byte `json:"signature"`
+}
+
+// Signer signs the manifest of a bundle.
+type Signer interface {
+	Sign(msg []byte) ([]byte, error)
+	Serialize() ([]byte, error)
+}
+
+// Bundle is a bundle read from its archive.
+type Bundle struct {
+	Manifest *Manifest
+	// ManifestBytes is the manifest as signed
+	ManifestBytes []byte
+	Signature     *Signature
+	Files         map[string][]byte
+}
+
+// ReadingPath is the file of the decoded reading txID.
+func ReadingPath(txID string) string {
+	return path.Join("readings", txID+".json")
+}
+
+// ApprovalsPath is the file of the approval records of the reading txID.
+func ApprovalsPath(txID string) string {
+	return path.Join("approvals", txID+".json")
+}
+
+// ProofPath is the file of the proof that the transaction txID, a reading or
+// an approval, was committed as valid.
+func ProofPath(txID string) string {
+	return path.Join("proofs", txID+".json")
+}
+
+// Write writes the bundle of the manifest and files to w as a gzipped tar
+// archive, after recording the hashes of the files in the manifest and
+// signing it.
+func Write(w io.Writer, manifest *Manifest, files map[string][]byte, signer Signer) error {
+	manifest.Files = map[string]string{}
+	for name, content := range files {
+		if name == ManifestFile || name == SignatureFile {
+			return errors.Errorf("file name %s is reserved", name)
+		}
+		manifest.Files[name] = hashHex(content)
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal manifest")
+	}
+	creator, err := signer.Serialize()
+	if err != nil {
+		return errors.WithMessage(err, "failed to serialize signer")
+	}
+	signature, err := signer.Sign(manifestBytes)
+	if err != nil {
+		return errors.WithMessage(err, "failed to sign manifest")
+	}
+	signatureBytes, err := json.MarshalIndent(&Signature{Creator: creator, Signature: signature}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal signature")
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	writeFile := func(name string, content []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(content)),
+			ModTime: manifest.CreatedAt,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return errors.Wrapf(err, "failed to write header of %s", name)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return errors.Wrapf(err, "failed to write %s", name)
+		}
+		return nil
+	}
+
+	if err := writeFile(ManifestFile, manifestBytes); err != nil {
+		return err
+	}
+	if err := writeFile(SignatureFile, signatureBytes); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := writeFile(name, files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "failed to close tar writer")
+	}
+	if err := gw.Close(); err != nil {
+		return errors.Wrap(err, "failed to close gzip writer")
+	}
+	return nil
+}
+
+// Read reads a bundle from its gzipped tar archive. The content of the
+// bundle is not verified.
+func Read(r io.Reader) (*Bundle, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open bundle")
+	}
+	defer gr.Close()
+
+	b := &Bundle{Files: map[string][]byte{}}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read bundle")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxFileSize {
+			return nil, errors.Errorf("file %s exceeds %d bytes", header.Name, maxFileSize)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", header.Name)
+		}
+
+		switch header.Name {
+		case ManifestFile:
+			b.ManifestBytes = content
+		case SignatureFile:
+			b.Signature = &Signature{}
+			if err := json.Unmarshal(content, b.Signature); err != nil {
+				return nil, errors.Wrap(err, "failed to unmarshal signature")
+			}
+		default:
+			b.Files[header.Name] = content
+		}
+	}
+
+	if b.ManifestBytes == nil {
+		return nil, errors.New("bundle has no manifest")
+	}
+	if b.Signature == nil {
+		return nil, errors.New("bundle has no signature")
+	}
+	b.Manifest = &Manifest{}
+	if err := json.Unmarshal(b.ManifestBytes, b.Manifest); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal manifest")
+	}
+
+	return b, nil
+}
+
+func hashHex(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func decodeFile(b *Bundle, name string, v interface{}) error {
+	content, ok := b.Files[name]
+	if !ok {
+		return errors.Errorf("bundle has no file %s", name)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %s", name)
+	}
+	return nil
+}
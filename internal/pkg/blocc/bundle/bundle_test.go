@@ -0,0 +1,199 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testSigner struct {
+	keyPair *tlsgen.CertKeyPair
+}
+
+func (s *testSigner) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return s.keyPair.Signer.Sign(rand.Reader, digest[:], nil)
+}
+
+func (s *testSigner) Serialize() ([]byte, error) {
+	return protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "RegulatorMSP", IdBytes: s.keyPair.Cert}), nil
+}
+
+func envelope(txID string, tx *pb.Transaction) []byte {
+	payload := &cb.Payload{
+		Header: &cb.Header{
+			ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{TxId: txID}),
+		},
+		Data: protoutil.MarshalOrPanic(tx),
+	}
+	return protoutil.MarshalOrPanic(&cb.Envelope{Payload: protoutil.MarshalOrPanic(payload)})
+}
+
+func approval(txID, sensoryTxID string) []byte {
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "bscc"},
+			Input: &pb.ChaincodeInput{Args: [][]byte{
+				[]byte("ApproveSensoryReading"),
+				protoutil.MarshalOrPanic(&lb.ApproveSensoryTxArgs{TxId: sensoryTxID}),
+			}},
+		},
+	}
+	cap := &pb.ChaincodeActionPayload{
+		ChaincodeProposalPayload: protoutil.MarshalOrPanic(&pb.ChaincodeProposalPayload{Input: protoutil.MarshalOrPanic(cis)}),
+	}
+	return envelope(txID, &pb.Transaction{Actions: []*pb.TransactionAction{{Payload: protoutil.MarshalOrPanic(cap)}}})
+}
+
+// testBundle returns the files and manifest of the bundle of reading1,
+// approved by approval1 in the next block.
+func testBundle(t *testing.T) (*Manifest, map[string][]byte) {
+	readingBlock := protoutil.NewBlock(3, []byte("previous"))
+	readingBlock.Data.Data = [][]byte{envelope("reading1", &pb.Transaction{})}
+	readingBlock.Header.DataHash = protoutil.BlockDataHash(readingBlock.Data)
+	readingBlock.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(pb.TxValidationCode_VALID)}
+
+	approvalBlock := protoutil.NewBlock(4, protoutil.BlockHeaderHash(readingBlock.Header))
+	approvalBlock.Data.Data = [][]byte{approval("approval1", "reading1")}
+	approvalBlock.Header.DataHash = protoutil.BlockDataHash(approvalBlock.Data)
+	approvalBlock.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(pb.TxValidationCode_VALID)}
+
+	proof := func(block *cb.Block, txID string, headers ...*cb.BlockHeader) []byte {
+		p, err := protoutil.NewReadingProof(block, txID, headers)
+		require.NoError(t, err)
+		proofBytes, err := json.Marshal(p)
+		require.NoError(t, err)
+		return proofBytes
+	}
+
+	manifest := &Manifest{
+		ChannelID: "mychannel",
+		SensorID:  "sensor1",
+		CreatedAt: time.Unix(1700000000, 0).UTC(),
+		Tip:       approvalBlock.Header,
+		Readings:  []*Reading{{TxID: "reading1", ApprovalTxIDs: []string{"approval1"}}},
+	}
+	files := map[string][]byte{
+		ReadingPath("reading1"):   []byte(`{"txID":"reading1","sensorID":"sensor1"}`),
+		ApprovalsPath("reading1"): []byte(`[{"sensoryTxID":"reading1","approvalTxID":"approval1","mspID":"Org1MSP"}]`),
+		ProofPath("reading1"):     proof(readingBlock, "reading1", readingBlock.Header, approvalBlock.Header),
+		ProofPath("approval1"):    proof(approvalBlock, "approval1", approvalBlock.Header),
+	}
+	return manifest, files
+}
+
+func TestBundle(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	keyPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+	signer := &testSigner{keyPair: keyPair}
+
+	manifest, files := testBundle(t)
+	buf := &bytes.Buffer{}
+	require.NoError(t, Write(buf, manifest, files, signer))
+
+	b, err := Read(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, files, b.Files)
+	require.Equal(t, "sensor1", b.Manifest.SensorID)
+
+	report := Verify(b, protoutil.BlockHeaderHash(manifest.Tip))
+	require.True(t, report.Valid(), "%v", report.Checks)
+	require.Equal(t, "RegulatorMSP", report.MSPID)
+	require.Equal(t, []string{
+		"manifest signature verifies against the certificate of the exporter",
+		"tip block 4 matches the trusted hash",
+		"files match the hashes of the manifest",
+		"reading reading1 is a reading of sensor sensor1",
+		"reading reading1 committed as valid",
+		"approval records of reading reading1 are included",
+		"approval approval1 of reading reading1 by Org1MSP committed as valid",
+	}, descriptions(report))
+
+	_, err = Read(bytes.NewReader([]byte("not a bundle")))
+	require.EqualError(t, err, "failed to open bundle: gzip: invalid header")
+}
+
+func TestVerifyTampering(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	keyPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+	signer := &testSigner{keyPair: keyPair}
+
+	read := func(manifest *Manifest, files map[string][]byte) *Bundle {
+		buf := &bytes.Buffer{}
+		require.NoError(t, Write(buf, manifest, files, signer))
+		b, err := Read(buf)
+		require.NoError(t, err)
+		return b
+	}
+
+	manifest, files := testBundle(t)
+	b := read(manifest, files)
+	b.Files[ReadingPath("reading1")] = []byte(`{"txID":"reading1","sensorID":"sensor2"}`)
+	require.Equal(t, map[string]string{
+		"files match the hashes of the manifest":          "file readings/reading1.json does not match its hash",
+		"reading reading1 is a reading of sensor sensor1": "file holds reading reading1 of sensor sensor2",
+	}, failures(Verify(b, nil)))
+
+	b = read(manifest, files)
+	b.ManifestBytes = bytes.Replace(b.ManifestBytes, []byte("sensor1"), []byte("sensor2"), 1)
+	require.Equal(t, map[string]string{
+		"manifest signature verifies against the certificate of the exporter": "signature is invalid",
+	}, failures(Verify(b, nil)))
+
+	require.Equal(t, map[string]string{
+		"tip block 4 matches the trusted hash": fmt.Sprintf("hash of block 4 is %x", protoutil.BlockHeaderHash(manifest.Tip)),
+	}, failures(Verify(read(manifest, files), []byte("other"))))
+
+	manifest, files = testBundle(t)
+	manifest.Readings[0].ApprovalTxIDs = []string{"reading1"}
+	files[ApprovalsPath("reading1")] = []byte(`[{"sensoryTxID":"reading1","approvalTxID":"reading1","mspID":"Org1MSP"}]`)
+	require.Equal(t, map[string]string{
+		"approval reading1 of reading reading1 by Org1MSP committed as valid": "failed to extract chaincode invocation: no transaction actions found",
+	}, failures(Verify(read(manifest, files), nil)))
+
+	manifest, files = testBundle(t)
+	manifest.Tip = &cb.BlockHeader{Number: 4}
+	require.Equal(t, map[string]string{
+		"reading reading1 committed as valid":                                  "block 4 does not match the trusted header of block 4",
+		"approval approval1 of reading reading1 by Org1MSP committed as valid": "block 4 does not match the trusted header of block 4",
+	}, failures(Verify(read(manifest, files), nil)))
+}
+
+func descriptions(report *Report) []string {
+	var descriptions []string
+	for _, check := range report.Checks {
+		descriptions = append(descriptions, check.Description)
+	}
+	return descriptions
+}
+
+func failures(report *Report) map[string]string {
+	failures := map[string]string{}
+	for _, check := range report.Checks {
+		if check.Err != nil {
+			failures[check.Description] = check.Err.Error()
+		}
+	}
+	return failures
+}
@@ -0,0 +1,232 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bundle
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Check is the outcome of a single verification step, Err being nil if the
+// check passed.
+type Check struct {
+	Description string
+	Err         error
+}
+
+// Report gathers the checks performed on a bundle.
+type Report struct {
+	// MSPID and Signer identify the exporter, Signer being the subject of its
+	// certificate
+	MSPID  string
+	Signer string
+	Checks []Check
+}
+
+// Valid returns whether all checks passed.
+func (r *Report) Valid() bool {
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Report) check(description string, err error) bool {
+	r.Checks = append(r.Checks, Check{Description: description, Err: err})
+	return err == nil
+}
+
+// Verify checks the bundle without contacting the network: the signature of
+// the manifest, the hashes of the files, and that every reading of the
+// sensor and its approvals were committed as valid in blocks chained up to
+// the tip of the manifest. The tip is checked against trustedTipHash, the
+// hash of the tip header obtained independently, if it is set. The
+// certificate of the exporter is not validated against the channel MSPs.
+func Verify(b *Bundle, trustedTipHash []byte) *Report {
+	report := &Report{}
+	manifest := b.Manifest
+
+	var err error
+	report.MSPID, report.Signer, err = verifySignature(b.ManifestBytes, b.Signature)
+	report.check("manifest signature verifies against the certificate of the exporter", err)
+
+	if manifest.Tip == nil {
+		report.check("manifest holds the tip of the channel", errors.New("no tip header"))
+		return report
+	}
+	if len(trustedTipHash) > 0 {
+		var err error
+		if tipHash := protoutil.BlockHeaderHash(manifest.Tip); !bytes.Equal(tipHash, trustedTipHash) {
+			err = errors.Errorf("hash of block %d is %x", manifest.Tip.Number, tipHash)
+		}
+		report.check(fmt.Sprintf("tip block %d matches the trusted hash", manifest.Tip.Number), err)
+	}
+
+	report.check("files match the hashes of the manifest", verifyFiles(b))
+
+	for _, reading := range manifest.Readings {
+		verifyReading(report, b, reading)
+	}
+
+	return report
+}
+
+// verifySignature verifies the signature of the manifest and returns the MSP
+// ID and the certificate subject of the signer.
+func verifySignature(manifestBytes []byte, signature *Signature) (string, string, error) {
+	identity := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(signature.Creator, identity); err != nil {
+		return "", "", errors.Wrap(err, "failed to unmarshal exporter identity")
+	}
+
+	block, _ := pem.Decode(identity.IdBytes)
+	if block == nil {
+		return identity.Mspid, "", errors.New("exporter identity is not a PEM encoded certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return identity.Mspid, "", errors.Wrap(err, "failed to parse exporter certificate")
+	}
+
+	publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return identity.Mspid, cert.Subject.String(), errors.Errorf("unsupported public key type %T", cert.PublicKey)
+	}
+	digest := sha256.Sum256(manifestBytes)
+	if !ecdsa.VerifyASN1(publicKey, digest[:], signature.Signature) {
+		return identity.Mspid, cert.Subject.String(), errors.New("signature is invalid")
+	}
+
+	return identity.Mspid, cert.Subject.String(), nil
+}
+
+func verifyFiles(b *Bundle) error {
+	for name, hash := range b.Manifest.Files {
+		content, ok := b.Files[name]
+		if !ok {
+			return errors.Errorf("file %s is missing", name)
+		}
+		if hashHex(content) != hash {
+			return errors.Errorf("file %s does not match its hash", name)
+		}
+	}
+
+	var unlisted []string
+	for name := range b.Files {
+		if _, ok := b.Manifest.Files[name]; !ok {
+			unlisted = append(unlisted, name)
+		}
+	}
+	if len(unlisted) > 0 {
+		sort.Strings(unlisted)
+		return errors.Errorf("files %v are not listed in the manifest", unlisted)
+	}
+
+	return nil
+}
+
+func verifyReading(report *Report, b *Bundle, reading *Reading) {
+	manifest := b.Manifest
+
+	var decoded struct {
+		TxID     string `json:"txID"`
+		SensorID string `json:"sensorID"`
+	}
+	err := decodeFile(b, ReadingPath(reading.TxID), &decoded)
+	if err == nil && (decoded.TxID != reading.TxID || decoded.SensorID != manifest.SensorID) {
+		err = errors.Errorf("file holds reading %s of sensor %s", decoded.TxID, decoded.SensorID)
+	}
+	report.check(fmt.Sprintf("reading %s is a reading of sensor %s", reading.TxID, manifest.SensorID), err)
+
+	_, err = verifyProof(b, reading.TxID)
+	report.check(fmt.Sprintf("reading %s committed as valid", reading.TxID), err)
+
+	var records []struct {
+		SensoryTxID  string `json:"sensoryTxID"`
+		ApprovalTxID string `json:"approvalTxID"`
+		MSPID        string `json:"mspID"`
+	}
+	if !report.check(fmt.Sprintf("approval records of reading %s are included", reading.TxID),
+		decodeFile(b, ApprovalsPath(reading.TxID), &records)) {
+		return
+	}
+	approvers := map[string]string{}
+	for _, record := range records {
+		if record.SensoryTxID == reading.TxID && record.ApprovalTxID != "" {
+			approvers[record.ApprovalTxID] = record.MSPID
+		}
+	}
+
+	for _, approvalTxID := range reading.ApprovalTxIDs {
+		mspID, ok := approvers[approvalTxID]
+		description := fmt.Sprintf("approval %s of reading %s by %s committed as valid", approvalTxID, reading.TxID, mspID)
+		if !ok {
+			report.check(description, errors.New("approval is not among the approval records of the reading"))
+			continue
+		}
+
+		envelopeBytes, err := verifyProof(b, approvalTxID)
+		if err == nil {
+			var approved string
+			if approved, err = approvedReading(envelopeBytes); err == nil && approved != reading.TxID {
+				err = errors.Errorf("transaction approves reading %s", approved)
+			}
+		}
+		report.check(description, err)
+	}
+}
+
+// verifyProof verifies the proof of the transaction txID against the tip of
+// the manifest, and returns the envelope of the transaction. The validation
+// code of the transaction is only attested by the signature of the exporter
+// on the bundle, the block hashes not covering it.
+func verifyProof(b *Bundle, txID string) ([]byte, error) {
+	proof := &protoutil.ReadingProof{}
+	if err := decodeFile(b, ProofPath(txID), proof); err != nil {
+		return nil, err
+	}
+	if proof.TxID != txID {
+		return nil, errors.Errorf("proof is the proof of transaction %s", proof.TxID)
+	}
+	if err := protoutil.VerifyReadingProof(proof, b.Manifest.Tip); err != nil {
+		return nil, err
+	}
+	return proof.Data.Data[proof.TxIndex], nil
+}
+
+// approvedReading returns the ID of the sensory transaction approved by the
+// approval transaction envelope.
+func approvedReading(envelopeBytes []byte) (string, error) {
+	cis, err := protoutil.ExtractChaincodeInvocationSpec(envelopeBytes)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to extract chaincode invocation")
+	}
+
+	args := cis.GetChaincodeSpec().GetInput().GetArgs()
+	if name := cis.GetChaincodeSpec().GetChaincodeId().GetName(); name != "bscc" || len(args) < 2 || string(args[0]) != "ApproveSensoryReading" {
+		return "", errors.Errorf("transaction invokes %s instead", name)
+	}
+
+	approveArgs := &lb.ApproveSensoryTxArgs{}
+	if err := proto.Unmarshal(args[1], approveArgs); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal approval arguments")
+	}
+
+	return approveArgs.TxId, nil
+}
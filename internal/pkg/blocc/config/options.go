@@ -0,0 +1,922 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Options is used to configure the BLOCC approval pipeline of a peer.
+type Options struct {
+	// AnonymousApprovals is used to sign approvals with an idemix credential
+	// instead of the peer's local MSP identity.
+	AnonymousApprovals bool
+	// IdemixMSPConfigPath is the directory holding the idemix credential used
+	// for anonymous approvals.
+	IdemixMSPConfigPath string
+	// IdemixMSPID is the MSP ID of the idemix credential used for anonymous approvals.
+	IdemixMSPID string
+	// SignerMSPConfigPath is the directory holding a dedicated approval
+	// signing identity, e.g. one whose key is held in an HSM. Approvals are
+	// signed with the peer's local MSP identity if empty.
+	SignerMSPConfigPath string
+	// SignerMSPID is the MSP ID of the dedicated approval signing identity.
+	SignerMSPID string
+	// MaxClockSkew is the deviation of a reading's timestamp from the peer's
+	// clock above which its approval is flagged. Zero disables the check.
+	MaxClockSkew time.Duration
+	// HeightMonitorEnabled is used to periodically compare the peer's channel
+	// heights with the orderer's.
+	HeightMonitorEnabled bool
+	// HeightMonitorInterval is the interval between two height comparisons.
+	HeightMonitorInterval time.Duration
+	// HeightLagThreshold is the number of blocks the peer may lag behind the
+	// orderer before a lag event is emitted.
+	HeightLagThreshold uint64
+	// ApprovalMetadata is attached to every approval submitted by this peer.
+	ApprovalMetadata map[string]string
+	// ApprovalChannels restricts approvals to the listed channels. Readings
+	// on every joined channel are approved if empty.
+	ApprovalChannels []string
+	// PrioritySeverities are the reading severities approved ahead of bulk
+	// telemetry, e.g. alarm conditions.
+	PrioritySeverities []string
+	// ApprovalRedeliveryTimeout is the time after which an approval request
+	// that was neither approved nor given up is delivered again.
+	ApprovalRedeliveryTimeout time.Duration
+	// ApprovalMaxDeliveries is the number of times an approval request is
+	// delivered before it is given up.
+	ApprovalMaxDeliveries int
+	// ApprovalMaxPending bounds the events kept in memory until the approval
+	// requests they carry are approved or given up, the oldest being dropped
+	// beyond it. Zero leaves them unbounded. It is applied when the service
+	// starts.
+	ApprovalMaxPending int
+	// ApprovalLimits bound the approval work of the channels without limits
+	// of their own.
+	ApprovalLimits ChannelLimits
+	// ApprovalChannelLimits maps channels to their own approval limits, the
+	// limits left unset being those of ApprovalLimits.
+	ApprovalChannelLimits map[string]ChannelLimits
+	// ApprovalQueueFile is the file to which the pending approval requests
+	// are saved when approvals are drained, and from which they are restored
+	// on the next start.
+	ApprovalQueueFile string
+	// StoreAndForwardEnabled is used to store the signed approval
+	// transactions that could not be broadcast as the orderer was
+	// unavailable, and to broadcast them once it is available again,
+	// instead of endorsing the approvals again.
+	StoreAndForwardEnabled bool
+	// StoreAndForwardDir is the directory the approval transactions are
+	// stored in until they are broadcast.
+	StoreAndForwardDir string
+	// StoreAndForwardFlushInterval is the interval between two attempts to
+	// broadcast the stored approval transactions.
+	StoreAndForwardFlushInterval time.Duration
+	// StoreAndForwardFsync is the policy the stored approval transactions
+	// are synced to disk with: "write" syncs every approval as it is stored,
+	// "periodic" every StoreAndForwardFsyncInterval and "none" leaves them
+	// to the operating system.
+	StoreAndForwardFsync string
+	// StoreAndForwardFsyncInterval is the interval between two syncs of the
+	// periodic fsync policy.
+	StoreAndForwardFsyncInterval time.Duration
+	// ApprovalDryRun checks every approval transaction against the
+	// configuration of its channel before it is broadcast, so that approvals
+	// the orderer or the committing peers would reject fail immediately.
+	ApprovalDryRun bool
+	// ApproveOnEndorse makes the endorsement of a reading by this peer stand
+	// for its approval, no approval transaction being submitted for the
+	// readings it endorsed.
+	ApproveOnEndorse bool
+	// ApproverRegistrationEnabled is used to register this peer as an
+	// approver of every channel it approves readings on, once per channel.
+	ApproverRegistrationEnabled bool
+	// ApproverRegistrationInterval is the interval between two checks for
+	// channels this peer is not yet registered on as an approver.
+	ApproverRegistrationInterval time.Duration
+	// ApprovalProxyURL is the SOCKS5 or HTTP CONNECT proxy through which the
+	// orderers are dialed. The standard proxy environment variables are
+	// honored if empty.
+	ApprovalProxyURL string
+	// ApprovalProxyEndpoints maps orderer endpoints to their own proxy URL,
+	// or to "direct" for the endpoints dialed without a proxy.
+	ApprovalProxyEndpoints map[string]string
+	// ApprovalGRPC tunes the gRPC connections of BLOCC to the orderers.
+	ApprovalGRPC GRPCOptions
+	// ApprovalOrdererCABundle is a PEM file of certificate authorities
+	// trusted for the TLS connections to the orderers, in addition to the
+	// root certificates of the orderer organization.
+	ApprovalOrdererCABundle string
+	// ApprovalOrdererPins are the base64 encoded SHA-256 hashes of the
+	// subject public key infos pinned for the orderers. A certificate of the
+	// chain presented by an orderer must match one of them if any is set.
+	ApprovalOrdererPins []string
+	// ApprovalBFTOrderers maps channels ordered by a BFT ordering service to
+	// the endpoints of its nodes. The approvals of these channels are
+	// broadcast to f+1 of the n nodes, so that at least one correct node
+	// orders them. The channels whose consensus type is BFT need no entry,
+	// the endpoints of all their orderer organizations being used.
+	ApprovalBFTOrderers map[string][]string
+	// ApprovalStreamsEnabled keeps a long-lived broadcast stream per orderer
+	// endpoint over which the approvals are pipelined, instead of dialing
+	// the orderer for every approval.
+	ApprovalStreamsEnabled bool
+	// ApprovalStreamWindow is the number of approvals that may await their
+	// acknowledgement on a broadcast stream.
+	ApprovalStreamWindow int
+	// Webhooks are the external endpoints to which BLOCC events are posted.
+	Webhooks []WebhookEndpoint
+	// WebhookMaxRetries is the number of times a failed delivery is retried.
+	WebhookMaxRetries int
+	// WebhookRetryBackoff is the delay before the first retry of a failed
+	// delivery, doubled on every subsequent retry.
+	WebhookRetryBackoff time.Duration
+	// WebhookTimeout bounds every delivery attempt.
+	WebhookTimeout time.Duration
+	// StreamingPublisher is the streaming platform onto which BLOCC events
+	// are mirrored, "kafka" or "nats". Events are not mirrored if empty.
+	StreamingPublisher string
+	// KafkaBrokers are the addresses of the Kafka brokers events are
+	// published to.
+	KafkaBrokers []string
+	// NATSURL is the URL of the NATS server events are published to.
+	NATSURL string
+	// StreamingTopic is the topic, or subject for NATS, of the events of
+	// channels without a topic of their own.
+	StreamingTopic string
+	// StreamingChannelTopics maps channels to the topic of their events.
+	StreamingChannelTopics map[string]string
+	// StreamingRetryBackoff is the delay before retrying a failed
+	// publication, doubled on every subsequent retry.
+	StreamingRetryBackoff time.Duration
+	// StreamingTimeout bounds every publication.
+	StreamingTimeout time.Duration
+	// SensorSilenceThreshold is the time without readings after which a
+	// sensor is reported as silent. Zero disables the check.
+	SensorSilenceThreshold time.Duration
+	// SensorRejectionRateThreshold is the rolling rejection rate of a sensor
+	// above which it is reported as unreliable. Zero disables the check.
+	SensorRejectionRateThreshold float64
+	// SensorAnomalyFrequencyThreshold is the rolling frequency of anomalous
+	// readings of a sensor above which it is reported as unreliable. Zero
+	// disables the check.
+	SensorAnomalyFrequencyThreshold float64
+	// MissingApprovalDeadline is the time after the receipt of a reading
+	// within which every registered approver of its channel is expected to
+	// approve or reject it. Zero disables the check.
+	MissingApprovalDeadline time.Duration
+	// ApprovalDigestInterval is the interval between two gossips of the
+	// digest of the approvals this peer submitted, for the other peers to
+	// detect the approvals missing from the chain. Zero disables the
+	// digests.
+	ApprovalDigestInterval time.Duration
+	// ApprovalDigestGracePeriod is the time an approval is given to be
+	// committed before it is listed in the approval digests.
+	ApprovalDigestGracePeriod time.Duration
+	// ForkStatusCacheTTL is how long the fork status of a channel is served
+	// from memory before it is checked again.
+	ForkStatusCacheTTL time.Duration
+	// ForkStatusLegacyFormat makes CheckForkStatus answer with the bare
+	// fork status of the channels instead of the versioned response, for
+	// the clients not reading the versioned response yet.
+	ForkStatusLegacyFormat bool
+	// ForkMonitorEnabled is used to periodically check the fork status of
+	// every channel this peer has joined.
+	ForkMonitorEnabled bool
+	// ForkMonitorInterval is the interval between two fork status checks.
+	ForkMonitorInterval time.Duration
+	// ForkCleanupAfter is how long a channel stays forked before the monitor
+	// removes its fork information, for networks where forks are simulated
+	// with SimulateForkAttempt. Zero disables the cleanup.
+	ForkCleanupAfter time.Duration
+	// SensorChaincodes are the chaincodes whose transactions are sensory
+	// readings, as "name" or "name:version", the first being the current
+	// one and the others being migrated from during an upgrade.
+	SensorChaincodes []string
+	// RecentEventsBufferSize is the number of the most recent events of the
+	// event bus kept in memory for inspection.
+	RecentEventsBufferSize int
+	// SlowConsumerThreshold is how long the buffer of a subscriber of the
+	// event bus stays full before the subscriber is reported slow. Zero
+	// disables the detection.
+	SlowConsumerThreshold time.Duration
+	// DisconnectSlowConsumers disconnects the slow subscribers of the event
+	// bus, which then receive no more events, instead of only reporting them.
+	DisconnectSlowConsumers bool
+	// DeadLetterDir is the directory to which the readings approved by fewer
+	// than DeadLetterThreshold organizations are exported when the
+	// ledger is snapshotted, and in which the approvals given up on are
+	// recorded.
+	DeadLetterDir string
+	// DeadLetterThreshold is the number of approvals of a reading
+	// not exported on snapshots. Zero disables the exports. It is also the
+	// threshold of the approval watermarks.
+	DeadLetterThreshold int
+	// HotRetention, WarmRetention and ColdRetention are how long the readings
+	// of the sensors of each retention class stay in the metric index before
+	// ArchiveMetricReadings archives them. Zero keeps them indexed.
+	HotRetention  time.Duration
+	WarmRetention time.Duration
+	ColdRetention time.Duration
+	// MaxInvokeArgSize is the size in bytes above which an argument of a BSCC
+	// invocation is rejected before it is decoded. Zero leaves the arguments
+	// unbounded.
+	MaxInvokeArgSize int64
+	// FunctionMaxArgSizes overrides MaxInvokeArgSize for the BSCC functions
+	// it maps to their own limit.
+	FunctionMaxArgSizes map[string]int64
+	// VerifyPayloadReferences fetches the off-chain payload referenced by a
+	// reading before approving it, and rejects the reading if the payload
+	// does not match the hash it references.
+	VerifyPayloadReferences bool
+	// PayloadFetchTimeout bounds the fetch of a referenced payload.
+	PayloadFetchTimeout time.Duration
+	// MaxPayloadSize is the size in bytes above which a referenced payload
+	// is not fetched. Zero leaves the payloads unbounded.
+	MaxPayloadSize int64
+	// RevalidationEnabled re-evaluates the recent readings of a sensor type
+	// when its validation policy changes, annotating the readings that would
+	// fail the new policy. The annotations are advisory: the readings and
+	// their approvals stand.
+	RevalidationEnabled bool
+	// RevalidationInterval is the interval between two checks for changed
+	// validation policies.
+	RevalidationInterval time.Duration
+	// RevalidationBlocks is the number of the most recent blocks of a
+	// channel whose readings are re-evaluated.
+	RevalidationBlocks uint64
+	// RevalidationDir is the directory the annotations are recorded in.
+	RevalidationDir string
+	// ProjectionEnabled projects the approvals and rejections committed on
+	// the channels of the peer, and the statistics of the sensors, into a
+	// SQLite database that external tools may query.
+	ProjectionEnabled bool
+	// ProjectionPath is the path of the SQLite database, replaced by every
+	// update of the projection.
+	ProjectionPath string
+	// ProjectionInterval is the interval between two updates of the
+	// projection.
+	ProjectionInterval time.Duration
+	// ProjectionMaxRows is the number of the most recent approvals, and of
+	// the most recent rejections, kept in the projection.
+	ProjectionMaxRows int
+	// IngestionMaxInFlight is the number of sensor chaincode proposals
+	// endorsed concurrently at which even the readings of the priority
+	// severities are shed, answered with status 429 and a retry-after delay.
+	// Zero disables the shedding.
+	IngestionMaxInFlight int
+	// IngestionBulkMaxInFlight is the number of sensor chaincode proposals
+	// endorsed concurrently at which the bulk readings are shed,
+	// IngestionMaxInFlight if zero or greater.
+	IngestionBulkMaxInFlight int
+	// IngestionRetryAfter is the delay the gateways are told to wait before
+	// they submit a shed reading again.
+	IngestionRetryAfter time.Duration
+	// Locale is the language of the status and error messages of BSCC and
+	// of the peer blocc commands, English if empty or en.
+	Locale string
+	// MessageCatalogDir is the directory holding the message catalog of
+	// every locale but English, as <locale>.json.
+	MessageCatalogDir string
+	// DevModeEnabled runs an in-process orderer to which the approvals are
+	// broadcast, so that BSCC runs without an ordering service. It is read
+	// when the service starts and is meant for development only.
+	DevModeEnabled bool
+	// DevOrdererAddress is the address the development orderer listens on.
+	DevOrdererAddress string
+	// SoakProfilingEnabled is used to periodically snapshot the heap and
+	// goroutine profiles of the peer during long-running experiments.
+	SoakProfilingEnabled bool
+	// SoakProfilingInterval is the interval between two snapshots.
+	SoakProfilingInterval time.Duration
+	// SoakProfilingDir is the directory the snapshots are written to.
+	SoakProfilingDir string
+	// SoakProfilingMaxSize is the size in bytes of the snapshots above which
+	// the oldest are removed. Zero leaves the snapshots unbounded.
+	SoakProfilingMaxSize int64
+	// ClockOffset shifts the time the peer timestamps its approvals with, so
+	// that integration tests can emulate the clock drift of the peer. It is
+	// meant for testing only.
+	ClockOffset time.Duration
+}
+
+// GRPCOptions tune the gRPC connections of BLOCC to the orderers, e.g. with
+// aggressive keepalives on unreliable networks.
+type GRPCOptions struct {
+	// KeepaliveInterval is the duration without activity on a connection
+	// after which the orderer is pinged, 0 disabling the pings
+	KeepaliveInterval time.Duration
+	// KeepaliveTimeout is the duration a ping is awaited before the
+	// connection is closed
+	KeepaliveTimeout time.Duration
+	// MaxRecvMsgSize and MaxSendMsgSize bound the size of the messages
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	// DSCP is the differentiated services code point the packets are
+	// marked with, 0 leaving them unmarked
+	DSCP int
+	// TCPKeepAlive is the interval between two TCP keepalive probes, 0 for
+	// the default of the Go runtime and negative to disable them
+	TCPKeepAlive time.Duration
+}
+
+// ChannelLimits bound the approval work of a channel, so that a busy channel
+// does not starve the others. QueueLength and Parallelism are applied when
+// the first approval request of the channel is queued: reloading the
+// configuration does not resize the queues and workers of the channels
+// already served, whose new limits apply from the next start.
+type ChannelLimits struct {
+	// QueueLength is the number of approval requests of the channel queued
+	// awaiting a worker, in each of the priority and bulk queues. Requests
+	// beyond it are redelivered later.
+	QueueLength int
+	// MaxInFlight is the number of approvals of the channel submitted and
+	// not yet committed, above which no approval is submitted. Zero leaves
+	// it unbounded.
+	MaxInFlight int
+	// Parallelism is the number of workers submitting the approvals of the
+	// channel concurrently.
+	Parallelism int
+}
+
+// WebhookEndpoint is an external URL to which BLOCC events are posted.
+type WebhookEndpoint struct {
+	URL string
+	// Secret is the key of the HMAC-SHA256 signature of the payloads
+	Secret string
+	// Events are the names of the event types posted to the endpoint, all
+	// types being posted if empty.
+	Events []string
+}
+
+// Accepts returns whether events of the named type are posted to the endpoint.
+func (e WebhookEndpoint) Accepts(eventType string) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, t := range e.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the endpoint without revealing its secret.
+func (e WebhookEndpoint) String() string {
+	return fmt.Sprintf("{%s %v}", e.URL, e.Events)
+}
+
+// StreamTopic returns the topic of the events of the channel.
+func (o Options) StreamTopic(channelID string) string {
+	if topic, ok := o.StreamingChannelTopics[channelID]; ok {
+		return topic
+	}
+	return o.StreamingTopic
+}
+
+// ApprovesChannel returns whether readings on the channel are to be approved.
+func (o Options) ApprovesChannel(channelID string) bool {
+	if len(o.ApprovalChannels) == 0 {
+		return true
+	}
+	for _, channel := range o.ApprovalChannels {
+		if channel == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// Capabilities declared by the peers registering as approvers.
+const (
+	// CapabilityApprove is declared by every approver
+	CapabilityApprove = "approve"
+	// CapabilityApproveOnEndorse is declared by the approvers whose
+	// endorsements stand for approvals
+	CapabilityApproveOnEndorse = "approveOnEndorse"
+	// CapabilityAnonymous is declared by the approvers signing approvals
+	// with an idemix credential
+	CapabilityAnonymous = "anonymous"
+)
+
+// ApproverCapabilities returns the capabilities this peer declares when
+// registering as an approver, in sorted order.
+func (o Options) ApproverCapabilities() []string {
+	capabilities := []string{CapabilityApprove}
+	if o.AnonymousApprovals {
+		capabilities = append(capabilities, CapabilityAnonymous)
+	}
+	if o.ApproveOnEndorse {
+		capabilities = append(capabilities, CapabilityApproveOnEndorse)
+	}
+	sort.Strings(capabilities)
+	return capabilities
+}
+
+// ChannelApprovalLimits returns the approval limits of the channel.
+func (o Options) ChannelApprovalLimits(channelID string) ChannelLimits {
+	limits := o.ApprovalLimits
+	overrides, ok := o.ApprovalChannelLimits[channelID]
+	if !ok {
+		return limits
+	}
+	if overrides.QueueLength > 0 {
+		limits.QueueLength = overrides.QueueLength
+	}
+	if overrides.MaxInFlight > 0 {
+		limits.MaxInFlight = overrides.MaxInFlight
+	}
+	if overrides.Parallelism > 0 {
+		limits.Parallelism = overrides.Parallelism
+	}
+	return limits
+}
+
+// Retention classes of the sensors, the readings of sensors without a class
+// being retained as those of the hot class.
+const (
+	RetentionHot  = "hot"
+	RetentionWarm = "warm"
+	RetentionCold = "cold"
+)
+
+// ValidRetentionClass returns whether class is a retention class, or empty.
+func ValidRetentionClass(class string) bool {
+	switch class {
+	case "", RetentionHot, RetentionWarm, RetentionCold:
+		return true
+	}
+	return false
+}
+
+// RetentionPeriod returns how long the readings of the sensors of the
+// retention class stay in the metric index, zero if they are never archived.
+func (o Options) RetentionPeriod(class string) time.Duration {
+	switch class {
+	case RetentionWarm:
+		return o.WarmRetention
+	case RetentionCold:
+		return o.ColdRetention
+	default:
+		return o.HotRetention
+	}
+}
+
+// MaxArgSize returns the size in bytes above which an argument of the BSCC
+// function fname is rejected, zero if its arguments are unbounded.
+func (o Options) MaxArgSize(fname string) int64 {
+	if size, ok := o.FunctionMaxArgSizes[fname]; ok {
+		return size
+	}
+	return o.MaxInvokeArgSize
+}
+
+// IsPriority returns whether readings of the given severity are approved
+// ahead of bulk telemetry. Severities are compared case-insensitively.
+func (o Options) IsPriority(severity string) bool {
+	for _, s := range o.PrioritySeverities {
+		if strings.EqualFold(s, severity) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff describes the options that differ between old and new, one entry per
+// field, for auditing configuration changes.
+func Diff(old, new Options) []string {
+	var changes []string
+	oldValue, newValue := reflect.ValueOf(old), reflect.ValueOf(new)
+	for i := 0; i < oldValue.NumField(); i++ {
+		o, n := oldValue.Field(i).Interface(), newValue.Field(i).Interface()
+		if !reflect.DeepEqual(o, n) {
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", oldValue.Type().Field(i).Name, o, n))
+		}
+	}
+	return changes
+}
+
+var defaultOptions = Options{
+	AnonymousApprovals:           false,
+	HeightMonitorEnabled:         true,
+	HeightMonitorInterval:        30 * time.Second,
+	HeightLagThreshold:           10,
+	MaxClockSkew:                 5 * time.Minute,
+	PrioritySeverities:           []string{"alarm", "critical"},
+	ApprovalRedeliveryTimeout:    5 * time.Minute,
+	ApprovalMaxDeliveries:        3,
+	ApprovalMaxPending:           100000,
+	ApprovalDryRun:               true,
+	ApprovalLimits:               ChannelLimits{QueueLength: 1024, MaxInFlight: 256, Parallelism: 1},
+	ApprovalQueueFile:            "/var/hyperledger/production/blocc/approval_queue.json",
+	StoreAndForwardEnabled:       true,
+	StoreAndForwardDir:           "/var/hyperledger/production/blocc/pending",
+	StoreAndForwardFlushInterval: 30 * time.Second,
+	StoreAndForwardFsync:         "write",
+	StoreAndForwardFsyncInterval: time.Second,
+	ApprovalStreamsEnabled:       true,
+	ApprovalStreamWindow:         64,
+	ApprovalGRPC: GRPCOptions{
+		KeepaliveInterval: time.Minute,
+		KeepaliveTimeout:  20 * time.Second,
+		MaxRecvMsgSize:    100 * 1024 * 1024,
+		MaxSendMsgSize:    100 * 1024 * 1024,
+	},
+	ApproverRegistrationEnabled:  true,
+	ApproverRegistrationInterval: time.Minute,
+	ApprovalDigestInterval:       5 * time.Minute,
+	ApprovalDigestGracePeriod:    2 * time.Minute,
+	ForkStatusCacheTTL:           5 * time.Second,
+	ForkMonitorEnabled:           true,
+	ForkMonitorInterval:          30 * time.Second,
+	WebhookMaxRetries:            3,
+	WebhookRetryBackoff:          time.Second,
+	WebhookTimeout:               5 * time.Second,
+	NATSURL:                      "nats://127.0.0.1:4222",
+	StreamingTopic:               "blocc-events",
+	StreamingRetryBackoff:        time.Second,
+	StreamingTimeout:             5 * time.Second,
+	RecentEventsBufferSize:       100,
+	SlowConsumerThreshold:        30 * time.Second,
+	SensorChaincodes:             []string{"sensor_chaincode"},
+	DeadLetterDir:                "/var/hyperledger/production/blocc/deadletter",
+	DeadLetterThreshold:          1,
+	WarmRetention:                30 * 24 * time.Hour,
+	ColdRetention:                7 * 24 * time.Hour,
+	MaxInvokeArgSize:             1 << 20,
+	PayloadFetchTimeout:          30 * time.Second,
+	MaxPayloadSize:               64 << 20,
+	RevalidationInterval:         time.Minute,
+	RevalidationBlocks:           1000,
+	RevalidationDir:              "/var/hyperledger/production/blocc/revalidation",
+	ProjectionPath:               "/var/hyperledger/production/blocc/projection.db",
+	ProjectionInterval:           10 * time.Second,
+	ProjectionMaxRows:            100000,
+	IngestionRetryAfter:          time.Second,
+	Locale:                       "en",
+	DevOrdererAddress:            "127.0.0.1:7059",
+	SoakProfilingInterval:        time.Hour,
+	SoakProfilingDir:             "/var/hyperledger/production/blocc/soak",
+	SoakProfilingMaxSize:         256 << 20,
+}
+
+// GetOptions gets the BLOCC configuration Options
+func GetOptions(v *viper.Viper) Options {
+	options := defaultOptions
+	if v.IsSet("blocc.approvals.anonymous.enabled") {
+		options.AnonymousApprovals = v.GetBool("blocc.approvals.anonymous.enabled")
+	}
+	if v.IsSet("blocc.approvals.anonymous.mspConfigPath") {
+		options.IdemixMSPConfigPath = v.GetString("blocc.approvals.anonymous.mspConfigPath")
+	}
+	if v.IsSet("blocc.approvals.anonymous.mspID") {
+		options.IdemixMSPID = v.GetString("blocc.approvals.anonymous.mspID")
+	}
+	if v.IsSet("blocc.approvals.signer.mspConfigPath") {
+		options.SignerMSPConfigPath = v.GetString("blocc.approvals.signer.mspConfigPath")
+	}
+	if v.IsSet("blocc.approvals.signer.mspID") {
+		options.SignerMSPID = v.GetString("blocc.approvals.signer.mspID")
+	}
+	if v.IsSet("blocc.approvals.maxClockSkew") {
+		options.MaxClockSkew = v.GetDuration("blocc.approvals.maxClockSkew")
+	}
+	if v.IsSet("blocc.heightMonitor.enabled") {
+		options.HeightMonitorEnabled = v.GetBool("blocc.heightMonitor.enabled")
+	}
+	if v.IsSet("blocc.heightMonitor.interval") {
+		options.HeightMonitorInterval = v.GetDuration("blocc.heightMonitor.interval")
+	}
+	if v.IsSet("blocc.heightMonitor.lagThreshold") {
+		options.HeightLagThreshold = uint64(v.GetInt64("blocc.heightMonitor.lagThreshold"))
+	}
+	if v.IsSet("blocc.approvals.metadata") {
+		options.ApprovalMetadata = parseMetadata(v.GetStringSlice("blocc.approvals.metadata"))
+	}
+	if v.IsSet("blocc.approvals.channels") {
+		options.ApprovalChannels = v.GetStringSlice("blocc.approvals.channels")
+	}
+	if v.IsSet("blocc.approvals.prioritySeverities") {
+		options.PrioritySeverities = v.GetStringSlice("blocc.approvals.prioritySeverities")
+	}
+	if v.IsSet("blocc.approvals.redeliveryTimeout") {
+		options.ApprovalRedeliveryTimeout = v.GetDuration("blocc.approvals.redeliveryTimeout")
+	}
+	if v.IsSet("blocc.approvals.maxDeliveries") {
+		options.ApprovalMaxDeliveries = v.GetInt("blocc.approvals.maxDeliveries")
+	}
+	if v.IsSet("blocc.approvals.maxPending") {
+		options.ApprovalMaxPending = v.GetInt("blocc.approvals.maxPending")
+	}
+	if v.IsSet("blocc.approvals.limits.queueLength") {
+		options.ApprovalLimits.QueueLength = v.GetInt("blocc.approvals.limits.queueLength")
+	}
+	if v.IsSet("blocc.approvals.limits.maxInFlight") {
+		options.ApprovalLimits.MaxInFlight = v.GetInt("blocc.approvals.limits.maxInFlight")
+	}
+	if v.IsSet("blocc.approvals.limits.parallelism") {
+		options.ApprovalLimits.Parallelism = v.GetInt("blocc.approvals.limits.parallelism")
+	}
+	if v.IsSet("blocc.approvals.limits.channels") {
+		var entries []struct {
+			Channel       string
+			ChannelLimits `mapstructure:",squash"`
+		}
+		if err := v.UnmarshalKey("blocc.approvals.limits.channels", &entries); err == nil {
+			options.ApprovalChannelLimits = map[string]ChannelLimits{}
+			for _, entry := range entries {
+				options.ApprovalChannelLimits[entry.Channel] = entry.ChannelLimits
+			}
+		}
+	}
+	if v.IsSet("blocc.approvals.queueFile") {
+		options.ApprovalQueueFile = v.GetString("blocc.approvals.queueFile")
+	}
+	if v.IsSet("blocc.approvals.storeAndForward.enabled") {
+		options.StoreAndForwardEnabled = v.GetBool("blocc.approvals.storeAndForward.enabled")
+	}
+	if v.IsSet("blocc.approvals.storeAndForward.dir") {
+		options.StoreAndForwardDir = v.GetString("blocc.approvals.storeAndForward.dir")
+	}
+	if v.IsSet("blocc.approvals.storeAndForward.flushInterval") {
+		options.StoreAndForwardFlushInterval = v.GetDuration("blocc.approvals.storeAndForward.flushInterval")
+	}
+	if v.IsSet("blocc.approvals.storeAndForward.fsync") {
+		options.StoreAndForwardFsync = v.GetString("blocc.approvals.storeAndForward.fsync")
+	}
+	if v.IsSet("blocc.approvals.storeAndForward.fsyncInterval") {
+		options.StoreAndForwardFsyncInterval = v.GetDuration("blocc.approvals.storeAndForward.fsyncInterval")
+	}
+	if v.IsSet("blocc.approvals.dryRun") {
+		options.ApprovalDryRun = v.GetBool("blocc.approvals.dryRun")
+	}
+	if v.IsSet("blocc.approvals.onEndorse.enabled") {
+		options.ApproveOnEndorse = v.GetBool("blocc.approvals.onEndorse.enabled")
+	}
+	if v.IsSet("blocc.approvals.registration.enabled") {
+		options.ApproverRegistrationEnabled = v.GetBool("blocc.approvals.registration.enabled")
+	}
+	if v.IsSet("blocc.approvals.registration.interval") {
+		options.ApproverRegistrationInterval = v.GetDuration("blocc.approvals.registration.interval")
+	}
+	if v.IsSet("blocc.approvals.proxy.url") {
+		options.ApprovalProxyURL = v.GetString("blocc.approvals.proxy.url")
+	}
+	if v.IsSet("blocc.approvals.proxy.endpoints") {
+		options.ApprovalProxyEndpoints = parseMetadata(v.GetStringSlice("blocc.approvals.proxy.endpoints"))
+	}
+	if v.IsSet("blocc.approvals.grpc.keepalive.interval") {
+		options.ApprovalGRPC.KeepaliveInterval = v.GetDuration("blocc.approvals.grpc.keepalive.interval")
+	}
+	if v.IsSet("blocc.approvals.grpc.keepalive.timeout") {
+		options.ApprovalGRPC.KeepaliveTimeout = v.GetDuration("blocc.approvals.grpc.keepalive.timeout")
+	}
+	if v.IsSet("blocc.approvals.grpc.maxRecvMsgSize") {
+		options.ApprovalGRPC.MaxRecvMsgSize = v.GetInt("blocc.approvals.grpc.maxRecvMsgSize")
+	}
+	if v.IsSet("blocc.approvals.grpc.maxSendMsgSize") {
+		options.ApprovalGRPC.MaxSendMsgSize = v.GetInt("blocc.approvals.grpc.maxSendMsgSize")
+	}
+	if v.IsSet("blocc.approvals.grpc.socket.dscp") {
+		options.ApprovalGRPC.DSCP = v.GetInt("blocc.approvals.grpc.socket.dscp")
+	}
+	if v.IsSet("blocc.approvals.grpc.socket.tcpKeepAlive") {
+		options.ApprovalGRPC.TCPKeepAlive = v.GetDuration("blocc.approvals.grpc.socket.tcpKeepAlive")
+	}
+	if v.IsSet("blocc.approvals.orderer.caBundle") {
+		options.ApprovalOrdererCABundle = v.GetString("blocc.approvals.orderer.caBundle")
+	}
+	if v.IsSet("blocc.approvals.orderer.pins") {
+		options.ApprovalOrdererPins = v.GetStringSlice("blocc.approvals.orderer.pins")
+	}
+	if v.IsSet("blocc.approvals.orderer.bft") {
+		var entries []struct {
+			Channel   string
+			Endpoints []string
+		}
+		if err := v.UnmarshalKey("blocc.approvals.orderer.bft", &entries); err == nil {
+			options.ApprovalBFTOrderers = map[string][]string{}
+			for _, entry := range entries {
+				options.ApprovalBFTOrderers[entry.Channel] = entry.Endpoints
+			}
+		}
+	}
+	if v.IsSet("blocc.approvals.orderer.streams.enabled") {
+		options.ApprovalStreamsEnabled = v.GetBool("blocc.approvals.orderer.streams.enabled")
+	}
+	if v.IsSet("blocc.approvals.orderer.streams.window") {
+		options.ApprovalStreamWindow = v.GetInt("blocc.approvals.orderer.streams.window")
+	}
+	if v.IsSet("blocc.sensorChaincodes") {
+		options.SensorChaincodes = v.GetStringSlice("blocc.sensorChaincodes")
+	}
+	if v.IsSet("blocc.forkStatus.cacheTTL") {
+		options.ForkStatusCacheTTL = v.GetDuration("blocc.forkStatus.cacheTTL")
+	}
+	if v.IsSet("blocc.forkStatus.legacyFormat") {
+		options.ForkStatusLegacyFormat = v.GetBool("blocc.forkStatus.legacyFormat")
+	}
+	if v.IsSet("blocc.forkStatus.monitor.enabled") {
+		options.ForkMonitorEnabled = v.GetBool("blocc.forkStatus.monitor.enabled")
+	}
+	if v.IsSet("blocc.forkStatus.monitor.interval") {
+		options.ForkMonitorInterval = v.GetDuration("blocc.forkStatus.monitor.interval")
+	}
+	if v.IsSet("blocc.forkStatus.cleanupAfter") {
+		options.ForkCleanupAfter = v.GetDuration("blocc.forkStatus.cleanupAfter")
+	}
+	if v.IsSet("blocc.webhooks.endpoints") {
+		var endpoints []WebhookEndpoint
+		if err := v.UnmarshalKey("blocc.webhooks.endpoints", &endpoints); err == nil {
+			options.Webhooks = endpoints
+		}
+	}
+	if v.IsSet("blocc.webhooks.maxRetries") {
+		options.WebhookMaxRetries = v.GetInt("blocc.webhooks.maxRetries")
+	}
+	if v.IsSet("blocc.webhooks.retryBackoff") {
+		options.WebhookRetryBackoff = v.GetDuration("blocc.webhooks.retryBackoff")
+	}
+	if v.IsSet("blocc.webhooks.timeout") {
+		options.WebhookTimeout = v.GetDuration("blocc.webhooks.timeout")
+	}
+	if v.IsSet("blocc.streaming.publisher") {
+		options.StreamingPublisher = v.GetString("blocc.streaming.publisher")
+	}
+	if v.IsSet("blocc.streaming.kafka.brokers") {
+		options.KafkaBrokers = v.GetStringSlice("blocc.streaming.kafka.brokers")
+	}
+	if v.IsSet("blocc.streaming.nats.url") {
+		options.NATSURL = v.GetString("blocc.streaming.nats.url")
+	}
+	if v.IsSet("blocc.streaming.topic") {
+		options.StreamingTopic = v.GetString("blocc.streaming.topic")
+	}
+	if v.IsSet("blocc.streaming.channelTopics") {
+		options.StreamingChannelTopics = parseMetadata(v.GetStringSlice("blocc.streaming.channelTopics"))
+	}
+	if v.IsSet("blocc.streaming.retryBackoff") {
+		options.StreamingRetryBackoff = v.GetDuration("blocc.streaming.retryBackoff")
+	}
+	if v.IsSet("blocc.streaming.timeout") {
+		options.StreamingTimeout = v.GetDuration("blocc.streaming.timeout")
+	}
+	if v.IsSet("blocc.sensorSilence.threshold") {
+		options.SensorSilenceThreshold = v.GetDuration("blocc.sensorSilence.threshold")
+	}
+	if v.IsSet("blocc.sensorReliability.rejectionRateThreshold") {
+		options.SensorRejectionRateThreshold = v.GetFloat64("blocc.sensorReliability.rejectionRateThreshold")
+	}
+	if v.IsSet("blocc.sensorReliability.anomalyFrequencyThreshold") {
+		options.SensorAnomalyFrequencyThreshold = v.GetFloat64("blocc.sensorReliability.anomalyFrequencyThreshold")
+	}
+	if v.IsSet("blocc.missingApprovals.deadline") {
+		options.MissingApprovalDeadline = v.GetDuration("blocc.missingApprovals.deadline")
+	}
+	if v.IsSet("blocc.approvalDigest.interval") {
+		options.ApprovalDigestInterval = v.GetDuration("blocc.approvalDigest.interval")
+	}
+	if v.IsSet("blocc.approvalDigest.gracePeriod") {
+		options.ApprovalDigestGracePeriod = v.GetDuration("blocc.approvalDigest.gracePeriod")
+	}
+	if v.IsSet("blocc.recentEvents.bufferSize") {
+		options.RecentEventsBufferSize = v.GetInt("blocc.recentEvents.bufferSize")
+	}
+	if v.IsSet("blocc.eventBus.slowConsumerThreshold") {
+		options.SlowConsumerThreshold = v.GetDuration("blocc.eventBus.slowConsumerThreshold")
+	}
+	if v.IsSet("blocc.eventBus.disconnectSlowConsumers") {
+		options.DisconnectSlowConsumers = v.GetBool("blocc.eventBus.disconnectSlowConsumers")
+	}
+	if v.IsSet("blocc.deadLetter.dir") {
+		options.DeadLetterDir = v.GetString("blocc.deadLetter.dir")
+	}
+	if v.IsSet("blocc.deadLetter.approvalThreshold") {
+		options.DeadLetterThreshold = v.GetInt("blocc.deadLetter.approvalThreshold")
+	}
+	if v.IsSet("blocc.retention.hot") {
+		options.HotRetention = v.GetDuration("blocc.retention.hot")
+	}
+	if v.IsSet("blocc.retention.warm") {
+		options.WarmRetention = v.GetDuration("blocc.retention.warm")
+	}
+	if v.IsSet("blocc.retention.cold") {
+		options.ColdRetention = v.GetDuration("blocc.retention.cold")
+	}
+	if v.IsSet("blocc.invoke.maxArgSize") {
+		options.MaxInvokeArgSize = int64(v.GetSizeInBytes("blocc.invoke.maxArgSize"))
+	}
+	if v.IsSet("blocc.invoke.functions") {
+		options.FunctionMaxArgSizes = parseSizes(v.GetStringSlice("blocc.invoke.functions"))
+	}
+	if v.IsSet("blocc.payloadReferences.verify") {
+		options.VerifyPayloadReferences = v.GetBool("blocc.payloadReferences.verify")
+	}
+	if v.IsSet("blocc.payloadReferences.fetchTimeout") {
+		options.PayloadFetchTimeout = v.GetDuration("blocc.payloadReferences.fetchTimeout")
+	}
+	if v.IsSet("blocc.payloadReferences.maxSize") {
+		options.MaxPayloadSize = int64(v.GetSizeInBytes("blocc.payloadReferences.maxSize"))
+	}
+	if v.IsSet("blocc.revalidation.enabled") {
+		options.RevalidationEnabled = v.GetBool("blocc.revalidation.enabled")
+	}
+	if v.IsSet("blocc.revalidation.interval") {
+		options.RevalidationInterval = v.GetDuration("blocc.revalidation.interval")
+	}
+	if v.IsSet("blocc.revalidation.blocks") {
+		options.RevalidationBlocks = v.GetUint64("blocc.revalidation.blocks")
+	}
+	if v.IsSet("blocc.revalidation.dir") {
+		options.RevalidationDir = v.GetString("blocc.revalidation.dir")
+	}
+	if v.IsSet("blocc.projection.enabled") {
+		options.ProjectionEnabled = v.GetBool("blocc.projection.enabled")
+	}
+	if v.IsSet("blocc.projection.path") {
+		options.ProjectionPath = v.GetString("blocc.projection.path")
+	}
+	if v.IsSet("blocc.projection.interval") {
+		options.ProjectionInterval = v.GetDuration("blocc.projection.interval")
+	}
+	if v.IsSet("blocc.projection.maxRows") {
+		options.ProjectionMaxRows = v.GetInt("blocc.projection.maxRows")
+	}
+	if v.IsSet("blocc.ingestion.maxInFlight") {
+		options.IngestionMaxInFlight = v.GetInt("blocc.ingestion.maxInFlight")
+	}
+	if v.IsSet("blocc.ingestion.bulkMaxInFlight") {
+		options.IngestionBulkMaxInFlight = v.GetInt("blocc.ingestion.bulkMaxInFlight")
+	}
+	if v.IsSet("blocc.ingestion.retryAfter") {
+		options.IngestionRetryAfter = v.GetDuration("blocc.ingestion.retryAfter")
+	}
+	if v.IsSet("blocc.messages.locale") {
+		options.Locale = v.GetString("blocc.messages.locale")
+	}
+	if v.IsSet("blocc.messages.catalogDir") {
+		options.MessageCatalogDir = v.GetString("blocc.messages.catalogDir")
+	}
+	if v.IsSet("blocc.devMode.enabled") {
+		options.DevModeEnabled = v.GetBool("blocc.devMode.enabled")
+	}
+	if v.IsSet("blocc.devMode.ordererAddress") {
+		options.DevOrdererAddress = v.GetString("blocc.devMode.ordererAddress")
+	}
+	if v.IsSet("blocc.debug.soak.enabled") {
+		options.SoakProfilingEnabled = v.GetBool("blocc.debug.soak.enabled")
+	}
+	if v.IsSet("blocc.debug.soak.interval") {
+		options.SoakProfilingInterval = v.GetDuration("blocc.debug.soak.interval")
+	}
+	if v.IsSet("blocc.debug.soak.dir") {
+		options.SoakProfilingDir = v.GetString("blocc.debug.soak.dir")
+	}
+	if v.IsSet("blocc.debug.soak.maxSize") {
+		options.SoakProfilingMaxSize = int64(v.GetSizeInBytes("blocc.debug.soak.maxSize"))
+	}
+	if v.IsSet("blocc.debug.clockOffset") {
+		options.ClockOffset = v.GetDuration("blocc.debug.clockOffset")
+	}
+
+	return options
+}
+
+// parseSizes converts a list of key=size entries into a map, sizes being
+// written as for viper.GetSizeInBytes, e.g. 8MB.
+func parseSizes(entries []string) map[string]int64 {
+	sizes := map[string]int64{}
+	for key, size := range parseMetadata(entries) {
+		v := viper.New()
+		v.Set("size", size)
+		sizes[key] = int64(v.GetSizeInBytes("size"))
+	}
+	return sizes
+}
+
+// parseMetadata converts a list of key=value entries into a map. Entries are
+// configured as a list rather than a map as viper lower-cases map keys.
+// It is used for every such key=value list, not only approval metadata.
+func parseMetadata(entries []string) map[string]string {
+	metadata := map[string]string{}
+	for _, entry := range entries {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) == 1 {
+			metadata[kv[0]] = ""
+			continue
+		}
+		metadata[kv[0]] = kv[1]
+	}
+	return metadata
+}
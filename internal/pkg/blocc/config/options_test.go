@@ -0,0 +1,397 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package config
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = []byte(`
+blocc:
+  approvals:
+    anonymous:
+      enabled: true
+      mspConfigPath: /etc/hyperledger/idemix
+      mspID: Org1IdemixMSP
+    signer:
+      mspConfigPath: /etc/hyperledger/approver
+      mspID: Org1MSP
+    maxClockSkew: 30s
+    metadata:
+      - experimentID=exp-42
+      - siteID=south-kensington
+    channels:
+      - sensorchannel
+    prioritySeverities:
+      - fire
+    redeliveryTimeout: 2m
+    maxDeliveries: 10
+    maxPending: 500
+    limits:
+      queueLength: 64
+      maxInFlight: 32
+      parallelism: 2
+      channels:
+        - channel: sensorchannel
+          parallelism: 8
+    queueFile: /tmp/blocc/approval_queue.json
+    storeAndForward:
+      enabled: false
+      dir: /tmp/blocc/pending
+      flushInterval: 1m
+      fsync: periodic
+      fsyncInterval: 5s
+    dryRun: false
+    onEndorse:
+      enabled: true
+    registration:
+      enabled: false
+      interval: 5m
+    proxy:
+      url: socks5://proxy.example.com:1080
+      endpoints:
+        - orderer0.example.com:7050=direct
+    grpc:
+      keepalive:
+        interval: 10s
+        timeout: 5s
+      maxRecvMsgSize: 1048576
+      maxSendMsgSize: 2097152
+      socket:
+        dscp: 46
+        tcpKeepAlive: 5s
+    orderer:
+      caBundle: /etc/blocc/orderer-cas.pem
+      pins:
+        - 47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=
+      bft:
+        - channel: bftchannel
+          endpoints:
+            - orderer0.example.com:7050
+            - orderer1.example.com:7050
+            - orderer2.example.com:7050
+            - orderer3.example.com:7050
+      streams:
+        enabled: false
+        window: 16
+  sensorChaincodes:
+    - sensor_green
+    - sensor_chaincode:1.0
+  heightMonitor:
+    enabled: false
+    interval: 1m
+    lagThreshold: 3
+  forkStatus:
+    cacheTTL: 1s
+    legacyFormat: true
+    monitor:
+      enabled: false
+      interval: 2m
+    cleanupAfter: 10m
+  webhooks:
+    endpoints:
+      - url: https://incidents.example.com/blocc
+        secret: s3cret
+        events:
+          - ForkStatusChanged
+    maxRetries: 5
+    retryBackoff: 2s
+    timeout: 10s
+  streaming:
+    publisher: kafka
+    kafka:
+      brokers:
+        - kafka0:9092
+    nats:
+      url: nats://nats0:4222
+    topic: sensors
+    channelTopics:
+      - sensorchannel=sensor-events
+    retryBackoff: 3s
+    timeout: 7s
+  sensorSilence:
+    threshold: 15m
+  sensorReliability:
+    rejectionRateThreshold: 0.2
+    anomalyFrequencyThreshold: 0.5
+  missingApprovals:
+    deadline: 10m
+  approvalDigest:
+    interval: 10m
+    gracePeriod: 1m
+  recentEvents:
+    bufferSize: 20
+  eventBus:
+    slowConsumerThreshold: 5s
+    disconnectSlowConsumers: true
+  deadLetter:
+    dir: /tmp/blocc/deadletter
+    approvalThreshold: 2
+  retention:
+    hot: 8760h
+    warm: 720h
+    cold: 24h
+  invoke:
+    maxArgSize: 512KB
+    functions:
+      - RegisterSensors=8MB
+      - GetSensor=1024
+  payloadReferences:
+    verify: true
+    fetchTimeout: 5s
+    maxSize: 8MB
+  revalidation:
+    enabled: true
+    interval: 10m
+    blocks: 500
+    dir: /tmp/revalidation
+  projection:
+    enabled: true
+    path: /tmp/blocc/projection.db
+    interval: 1m
+    maxRows: 5000
+  ingestion:
+    maxInFlight: 200
+    bulkMaxInFlight: 150
+    retryAfter: 2s
+  messages:
+    locale: fr
+    catalogDir: /etc/blocc/messages
+  devMode:
+    enabled: true
+    ordererAddress: 127.0.0.1:17050
+  debug:
+    soak:
+      enabled: true
+      interval: 10m
+      dir: /tmp/blocc/soak
+      maxSize: 16MB
+    clockOffset: -90s
+`)
+
+func TestDefaultOptions(t *testing.T) {
+	v := viper.New()
+	options := GetOptions(v)
+	require.Equal(t, defaultOptions, options)
+}
+
+func TestOverriddenOptions(t *testing.T) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.ReadConfig(bytes.NewBuffer(testConfig))
+	options := GetOptions(v)
+
+	expectedOptions := Options{
+		AnonymousApprovals:    true,
+		IdemixMSPConfigPath:   "/etc/hyperledger/idemix",
+		IdemixMSPID:           "Org1IdemixMSP",
+		SignerMSPConfigPath:   "/etc/hyperledger/approver",
+		SignerMSPID:           "Org1MSP",
+		MaxClockSkew:          30 * time.Second,
+		HeightMonitorEnabled:  false,
+		HeightMonitorInterval: time.Minute,
+		HeightLagThreshold:    3,
+		ApprovalMetadata: map[string]string{
+			"experimentID": "exp-42",
+			"siteID":       "south-kensington",
+		},
+		ApprovalChannels:             []string{"sensorchannel"},
+		PrioritySeverities:           []string{"fire"},
+		ApprovalRedeliveryTimeout:    2 * time.Minute,
+		ApprovalMaxDeliveries:        10,
+		ApprovalMaxPending:           500,
+		ApprovalLimits:               ChannelLimits{QueueLength: 64, MaxInFlight: 32, Parallelism: 2},
+		ApprovalChannelLimits:        map[string]ChannelLimits{"sensorchannel": {Parallelism: 8}},
+		ApprovalQueueFile:            "/tmp/blocc/approval_queue.json",
+		StoreAndForwardEnabled:       false,
+		StoreAndForwardDir:           "/tmp/blocc/pending",
+		StoreAndForwardFlushInterval: time.Minute,
+		StoreAndForwardFsync:         "periodic",
+		StoreAndForwardFsyncInterval: 5 * time.Second,
+		ApprovalDryRun:               false,
+		ApproveOnEndorse:             true,
+		ApproverRegistrationEnabled:  false,
+		ApproverRegistrationInterval: 5 * time.Minute,
+		ApprovalProxyURL:             "socks5://proxy.example.com:1080",
+		ApprovalProxyEndpoints:       map[string]string{"orderer0.example.com:7050": "direct"},
+		ApprovalGRPC: GRPCOptions{
+			KeepaliveInterval: 10 * time.Second,
+			KeepaliveTimeout:  5 * time.Second,
+			MaxRecvMsgSize:    1048576,
+			MaxSendMsgSize:    2097152,
+			DSCP:              46,
+			TCPKeepAlive:      5 * time.Second,
+		},
+		ApprovalOrdererCABundle: "/etc/blocc/orderer-cas.pem",
+		ApprovalOrdererPins:     []string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="},
+		ApprovalBFTOrderers: map[string][]string{
+			"bftchannel": {"orderer0.example.com:7050", "orderer1.example.com:7050", "orderer2.example.com:7050", "orderer3.example.com:7050"},
+		},
+		ApprovalStreamsEnabled: false,
+		ApprovalStreamWindow:   16,
+		ForkStatusCacheTTL:     time.Second,
+		ForkStatusLegacyFormat: true,
+		ForkMonitorEnabled:     false,
+		ForkMonitorInterval:    2 * time.Minute,
+		ForkCleanupAfter:       10 * time.Minute,
+		Webhooks: []WebhookEndpoint{{
+			URL:    "https://incidents.example.com/blocc",
+			Secret: "s3cret",
+			Events: []string{"ForkStatusChanged"},
+		}},
+		WebhookMaxRetries:               5,
+		WebhookRetryBackoff:             2 * time.Second,
+		WebhookTimeout:                  10 * time.Second,
+		StreamingPublisher:              "kafka",
+		KafkaBrokers:                    []string{"kafka0:9092"},
+		NATSURL:                         "nats://nats0:4222",
+		StreamingTopic:                  "sensors",
+		StreamingChannelTopics:          map[string]string{"sensorchannel": "sensor-events"},
+		StreamingRetryBackoff:           3 * time.Second,
+		StreamingTimeout:                7 * time.Second,
+		SensorSilenceThreshold:          15 * time.Minute,
+		SensorRejectionRateThreshold:    0.2,
+		SensorAnomalyFrequencyThreshold: 0.5,
+		MissingApprovalDeadline:         10 * time.Minute,
+		ApprovalDigestInterval:          10 * time.Minute,
+		ApprovalDigestGracePeriod:       time.Minute,
+		RecentEventsBufferSize:          20,
+		SlowConsumerThreshold:           5 * time.Second,
+		DisconnectSlowConsumers:         true,
+		SensorChaincodes:                []string{"sensor_green", "sensor_chaincode:1.0"},
+		DeadLetterDir:                   "/tmp/blocc/deadletter",
+		DeadLetterThreshold:             2,
+		HotRetention:                    365 * 24 * time.Hour,
+		WarmRetention:                   30 * 24 * time.Hour,
+		ColdRetention:                   24 * time.Hour,
+		MaxInvokeArgSize:                512 << 10,
+		FunctionMaxArgSizes:             map[string]int64{"RegisterSensors": 8 << 20, "GetSensor": 1024},
+		VerifyPayloadReferences:         true,
+		PayloadFetchTimeout:             5 * time.Second,
+		MaxPayloadSize:                  8 << 20,
+		RevalidationEnabled:             true,
+		RevalidationInterval:            10 * time.Minute,
+		RevalidationBlocks:              500,
+		RevalidationDir:                 "/tmp/revalidation",
+		ProjectionEnabled:               true,
+		ProjectionPath:                  "/tmp/blocc/projection.db",
+		ProjectionInterval:              time.Minute,
+		ProjectionMaxRows:               5000,
+		IngestionMaxInFlight:            200,
+		IngestionBulkMaxInFlight:        150,
+		IngestionRetryAfter:             2 * time.Second,
+		Locale:                          "fr",
+		MessageCatalogDir:               "/etc/blocc/messages",
+		DevModeEnabled:                  true,
+		DevOrdererAddress:               "127.0.0.1:17050",
+		SoakProfilingEnabled:            true,
+		SoakProfilingInterval:           10 * time.Minute,
+		SoakProfilingDir:                "/tmp/blocc/soak",
+		SoakProfilingMaxSize:            16 << 20,
+		ClockOffset:                     -90 * time.Second,
+	}
+	require.Equal(t, expectedOptions, options)
+}
+
+func TestApprovesChannel(t *testing.T) {
+	options := Options{}
+	require.True(t, options.ApprovesChannel("sensorchannel"))
+
+	options.ApprovalChannels = []string{"sensorchannel"}
+	require.True(t, options.ApprovesChannel("sensorchannel"))
+	require.False(t, options.ApprovesChannel("otherchannel"))
+}
+
+func TestChannelApprovalLimits(t *testing.T) {
+	options := Options{
+		ApprovalLimits:        ChannelLimits{QueueLength: 64, MaxInFlight: 32, Parallelism: 2},
+		ApprovalChannelLimits: map[string]ChannelLimits{"sensorchannel": {MaxInFlight: 128, Parallelism: 8}},
+	}
+	require.Equal(t, ChannelLimits{QueueLength: 64, MaxInFlight: 128, Parallelism: 8}, options.ChannelApprovalLimits("sensorchannel"))
+	require.Equal(t, ChannelLimits{QueueLength: 64, MaxInFlight: 32, Parallelism: 2}, options.ChannelApprovalLimits("otherchannel"))
+}
+
+func TestApproverCapabilities(t *testing.T) {
+	options := defaultOptions
+	require.Equal(t, []string{"approve"}, options.ApproverCapabilities())
+
+	options.AnonymousApprovals = true
+	options.ApproveOnEndorse = true
+	require.Equal(t, []string{"anonymous", "approve", "approveOnEndorse"}, options.ApproverCapabilities())
+}
+
+func TestMaxArgSize(t *testing.T) {
+	options := defaultOptions
+	require.Equal(t, int64(1<<20), options.MaxArgSize("RegisterSensor"))
+
+	options.FunctionMaxArgSizes = map[string]int64{"RegisterSensors": 8 << 20, "GetSensor": 0}
+	require.Equal(t, int64(8<<20), options.MaxArgSize("RegisterSensors"))
+	require.Equal(t, int64(0), options.MaxArgSize("GetSensor"))
+	require.Equal(t, int64(1<<20), options.MaxArgSize("RegisterSensor"))
+}
+
+func TestRetentionPeriod(t *testing.T) {
+	options := defaultOptions
+	require.Equal(t, time.Duration(0), options.RetentionPeriod(""))
+	require.Equal(t, time.Duration(0), options.RetentionPeriod(RetentionHot))
+	require.Equal(t, 30*24*time.Hour, options.RetentionPeriod(RetentionWarm))
+	require.Equal(t, 7*24*time.Hour, options.RetentionPeriod(RetentionCold))
+
+	require.True(t, ValidRetentionClass(""))
+	require.True(t, ValidRetentionClass(RetentionCold))
+	require.False(t, ValidRetentionClass("frozen"))
+}
+
+func TestIsPriority(t *testing.T) {
+	options := defaultOptions
+	require.True(t, options.IsPriority("alarm"))
+	require.True(t, options.IsPriority("CRITICAL"))
+	require.False(t, options.IsPriority("info"))
+	require.False(t, options.IsPriority(""))
+}
+
+func TestStreamTopic(t *testing.T) {
+	options := defaultOptions
+	require.Equal(t, "blocc-events", options.StreamTopic("sensorchannel"))
+
+	options.StreamingChannelTopics = map[string]string{"sensorchannel": "sensor-events"}
+	require.Equal(t, "sensor-events", options.StreamTopic("sensorchannel"))
+	require.Equal(t, "blocc-events", options.StreamTopic("otherchannel"))
+}
+
+func TestWebhookEndpoint(t *testing.T) {
+	endpoint := WebhookEndpoint{URL: "https://incidents.example.com/blocc", Secret: "s3cret"}
+	require.True(t, endpoint.Accepts("SensorSilent"))
+
+	endpoint.Events = []string{"ForkStatusChanged"}
+	require.True(t, endpoint.Accepts("ForkStatusChanged"))
+	require.False(t, endpoint.Accepts("SensorSilent"))
+
+	require.Equal(t, "{https://incidents.example.com/blocc [ForkStatusChanged]}", endpoint.String())
+}
+
+func TestDiff(t *testing.T) {
+	require.Empty(t, Diff(defaultOptions, defaultOptions))
+
+	updated := defaultOptions
+	updated.HeightLagThreshold = 3
+	updated.ApprovalChannels = []string{"sensorchannel"}
+	require.Equal(t, []string{
+		"HeightLagThreshold: 10 -> 3",
+		"ApprovalChannels: [] -> [sensorchannel]",
+	}, Diff(defaultOptions, updated))
+
+	withWebhook := defaultOptions
+	withWebhook.Webhooks = []WebhookEndpoint{{URL: "https://incidents.example.com/blocc", Secret: "s3cret"}}
+	require.Equal(t, []string{
+		"Webhooks: [] -> [{https://incidents.example.com/blocc []}]",
+	}, Diff(defaultOptions, withWebhook))
+}
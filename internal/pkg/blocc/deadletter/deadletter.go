@@ -0,0 +1,193 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package deadletter keeps the sensory readings whose approval is still
+// unresolved when the ledger holding them is snapshotted. A peer joining from
+// the snapshot has no blocks before it, so the readings are exported to keep
+// the evidence of their pending approval. It also keeps the approvals this
+// peer gave up on, with a snapshot of the state of the peer at the time.
+package deadletter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Reading is a sensory reading below the approval threshold.
+type Reading struct {
+	TxID     string `json:"txID"`
+	BlockNum uint64 `json:"blockNum"`
+	TxNum    uint64 `json:"txNum"`
+	// Approvals and Rejections are the MSP IDs of the organizations that
+	// approved or rejected the reading
+	Approvals  []string `json:"approvals"`
+	Rejections []string `json:"rejections,omitempty"`
+}
+
+// Export holds the unresolved readings of a channel up to the last block of
+// a snapshot.
+type Export struct {
+	ChannelID    string    `json:"channelID"`
+	LastBlockNum uint64    `json:"lastBlockNum"`
+	Threshold    int       `json:"threshold"`
+	Readings     []Reading `json:"readings"`
+}
+
+// Failure is an approval of a sensory reading this peer gave up on.
+type Failure struct {
+	ChannelID   string       `json:"channelID"`
+	SensoryTxID string       `json:"sensoryTxID"`
+	TraceID     string       `json:"traceID,omitempty"`
+	Attempts    int          `json:"attempts"`
+	Error       string       `json:"error"`
+	FailedAt    time.Time    `json:"failedAt"`
+	Diagnostics *Diagnostics `json:"diagnostics,omitempty"`
+}
+
+// Diagnostics is a snapshot of the state of the peer when an approval failed.
+type Diagnostics struct {
+	Goroutines int `json:"goroutines"`
+	// QueueDepths is the number of approval requests queued by channel
+	QueueDepths map[string]int `json:"queueDepths"`
+	// LastOrdererError is the last error with which an approval of the
+	// channel failed to be ordered
+	LastOrdererError string `json:"lastOrdererError,omitempty"`
+	// ChannelHeight is the height of the channel, zero if it is unknown
+	ChannelHeight uint64 `json:"channelHeight"`
+}
+
+// Store is a directory of exports, one file per channel and snapshot, and of
+// the approval failures of each channel.
+type Store struct {
+	mutex     sync.RWMutex
+	dir       string
+	threshold int
+}
+
+// NewStore returns a store of exports in dir, of the readings approved by
+// fewer than threshold organizations. A threshold of zero disables exports.
+func NewStore(dir string, threshold int) *Store {
+	return &Store{dir: dir, threshold: threshold}
+}
+
+// Default is the dead-letter store of this peer, disabled until the BLOCC
+// options are applied.
+var Default = NewStore("", 0)
+
+// Set replaces the directory and threshold of the store.
+func (s *Store) Set(dir string, threshold int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.dir = dir
+	s.threshold = threshold
+}
+
+// Threshold returns the number of approvals of a resolved reading, zero if
+// exports are disabled.
+func (s *Store) Threshold() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.dir == "" {
+		return 0
+	}
+	return s.threshold
+}
+
+// Path returns the path of the export of the channel up to lastBlockNum.
+func (s *Store) Path(channelID string, lastBlockNum uint64) string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return filepath.Join(s.dir, channelID, strconv.FormatUint(lastBlockNum, 10)+".json")
+}
+
+// Write stores the export, replacing any earlier export of the same channel
+// and block, and returns its path.
+func (s *Store) Write(export *Export) (string, error) {
+	path := s.Path(export.ChannelID, export.LastBlockNum)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", errors.Wrapf(err, "failed to create directory of %s", path)
+	}
+
+	exportBytes, err := json.Marshal(export)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal unresolved readings")
+	}
+
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, exportBytes, 0o644); err != nil {
+		return "", errors.Wrapf(err, "failed to write %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", errors.Wrapf(err, "failed to rename %s", tmpPath)
+	}
+
+	return path, nil
+}
+
+// FailurePath returns the path of the failure of the approval with the given
+// trace ID of the channel.
+func (s *Store) FailurePath(channelID, traceID string) string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return filepath.Join(s.dir, channelID, "failures", traceID+".json")
+}
+
+// WriteFailure stores the approval failure and returns its path, or an empty
+// path if the store has no directory. Failures without trace ID are stored
+// under the ID of their reading.
+func (s *Store) WriteFailure(failure *Failure) (string, error) {
+	s.mutex.RLock()
+	dir := s.dir
+	s.mutex.RUnlock()
+	if dir == "" {
+		return "", nil
+	}
+
+	traceID := failure.TraceID
+	if traceID == "" {
+		traceID = failure.SensoryTxID
+	}
+	path := s.FailurePath(failure.ChannelID, traceID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", errors.Wrapf(err, "failed to create directory of %s", path)
+	}
+
+	failureBytes, err := json.Marshal(failure)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal approval failure")
+	}
+	if err := ioutil.WriteFile(path, failureBytes, 0o644); err != nil {
+		return "", errors.Wrapf(err, "failed to write %s", path)
+	}
+
+	return path, nil
+}
+
+// Read returns the export of the channel up to lastBlockNum, or nil if there
+// is none.
+func (s *Store) Read(channelID string, lastBlockNum uint64) (*Export, error) {
+	path := s.Path(channelID, lastBlockNum)
+	exportBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+
+	export := &Export{}
+	if err := json.Unmarshal(exportBytes, export); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", path)
+	}
+
+	return export, nil
+}
@@ -0,0 +1,78 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deadletter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore("", 2)
+	require.Equal(t, 0, store.Threshold())
+
+	store.Set(dir, 2)
+	require.Equal(t, 2, store.Threshold())
+
+	export, err := store.Read("mychannel", 10)
+	require.NoError(t, err)
+	require.Nil(t, export)
+
+	export = &Export{
+		ChannelID:    "mychannel",
+		LastBlockNum: 10,
+		Threshold:    2,
+		Readings: []Reading{
+			{TxID: "tx1", BlockNum: 3, TxNum: 1, Approvals: []string{"Org1MSP"}},
+			{TxID: "tx2", BlockNum: 7, Rejections: []string{"Org2MSP"}},
+		},
+	}
+	path, err := store.Write(export)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "mychannel", "10.json"), path)
+
+	stored, err := store.Read("mychannel", 10)
+	require.NoError(t, err)
+	require.Equal(t, export, stored)
+}
+
+func TestWriteFailure(t *testing.T) {
+	store := NewStore("", 0)
+	failure := &Failure{
+		ChannelID:   "mychannel",
+		SensoryTxID: "tx1",
+		Attempts:    5,
+		Error:       "orderer unavailable",
+		FailedAt:    time.Unix(1700000000, 0).UTC(),
+		Diagnostics: &Diagnostics{Goroutines: 42, QueueDepths: map[string]int{"mychannel": 3}, ChannelHeight: 10},
+	}
+	path, err := store.WriteFailure(failure)
+	require.NoError(t, err)
+	require.Empty(t, path)
+
+	dir := t.TempDir()
+	store.Set(dir, 0)
+	path, err = store.WriteFailure(failure)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "mychannel", "failures", "tx1.json"), path)
+
+	failureBytes, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	stored := &Failure{}
+	require.NoError(t, json.Unmarshal(failureBytes, stored))
+	require.Equal(t, failure, stored)
+
+	failure.TraceID = "trace1"
+	path, err = store.WriteFailure(failure)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "mychannel", "failures", "trace1.json"), path)
+}
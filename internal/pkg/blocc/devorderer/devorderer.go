@@ -0,0 +1,395 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package devorderer is an in-process orderer stub for development. It
+// orders every broadcast transaction into a block of its own, signed by the
+// peer, and delivers the blocks of its chains, so that the approval loop of
+// BSCC can be exercised on a single peer without an ordering service. It
+// checks no policy and supports no configuration update: it must never be
+// used outside of development.
+package devorderer
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/ledger/blockledger/fileledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/util"
+	bloccerrors "github.com/hyperledger/fabric/internal/pkg/blocc/errors"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("blocc.devorderer")
+
+// Ledger is the ledger of a channel of the peer, which the chain of the
+// channel starts from.
+type Ledger interface {
+	GetBlockchainInfo() (*cb.BlockchainInfo, error)
+	GetBlockByNumber(blockNumber uint64) (*cb.Block, error)
+}
+
+// Orderer orders the transactions broadcast on the channels of the peer.
+type Orderer struct {
+	signer  identity.SignerSerializer
+	ledgers func(channelID string) Ledger
+	factory blockledger.Factory
+
+	mutex  sync.Mutex
+	chains map[string]*chain
+}
+
+type chain struct {
+	mutex      sync.Mutex
+	ledger     blockledger.ReadWriter
+	lastConfig uint64
+}
+
+// New returns an orderer keeping its chains in dir and signing its blocks
+// with signer. The chain of a channel starts from the blocks committed by
+// the peer, ledgers returning the ledger of the channel, or nil if the peer
+// has not joined it.
+func New(dir string, signer identity.SignerSerializer, ledgers func(channelID string) Ledger) (*Orderer, error) {
+	factory, err := fileledger.New(dir, &disabled.Provider{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create the ledger of the development orderer")
+	}
+	return &Orderer{
+		signer:  signer,
+		ledgers: ledgers,
+		factory: factory,
+		chains:  map[string]*chain{},
+	}, nil
+}
+
+// Close releases the ledger of the orderer.
+func (o *Orderer) Close() {
+	o.factory.Close()
+}
+
+// chain returns the chain of the channel, catching up with the blocks the
+// peer committed, e.g. its genesis block, the first time.
+func (o *Orderer) chain(channelID string) (*chain, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if c, ok := o.chains[channelID]; ok {
+		return c, nil
+	}
+
+	peerLedger := o.ledgers(channelID)
+	if peerLedger == nil {
+		return nil, bloccerrors.WithCategory(errors.Errorf("channel %s not found", channelID), bloccerrors.ErrChannelNotFound)
+	}
+	info, err := peerLedger.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get chain info of channel %s", channelID)
+	}
+	rw, err := o.factory.GetOrCreate(channelID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to create the chain of channel %s", channelID)
+	}
+	for number := rw.Height(); number < info.Height; number++ {
+		block, err := peerLedger.GetBlockByNumber(number)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get block %d of channel %s", number, channelID)
+		}
+		if err := rw.Append(block); err != nil {
+			return nil, errors.WithMessagef(err, "failed to append block %d of channel %s", number, channelID)
+		}
+	}
+	if rw.Height() == 0 {
+		return nil, errors.Errorf("channel %s has no genesis block", channelID)
+	}
+
+	last, err := rw.RetrieveBlockByNumber(rw.Height() - 1)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get the last block of channel %s", channelID)
+	}
+	lastConfig, err := protoutil.GetLastConfigIndexFromBlock(last)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get the last config of channel %s", channelID)
+	}
+
+	c := &chain{ledger: rw, lastConfig: lastConfig}
+	o.chains[channelID] = c
+	return c, nil
+}
+
+// order orders the transaction into a new block of the chain of its channel
+// and returns the block.
+func (o *Orderer) order(env *cb.Envelope) (*cb.Block, error) {
+	chdr, err := channelHeader(env)
+	if err != nil {
+		return nil, err
+	}
+	switch cb.HeaderType(chdr.Type) {
+	case cb.HeaderType_CONFIG, cb.HeaderType_CONFIG_UPDATE, cb.HeaderType_ORDERER_TRANSACTION:
+		return nil, errors.Errorf("%s transactions are not supported by the development orderer", cb.HeaderType(chdr.Type))
+	}
+
+	c, err := o.chain(chdr.ChannelId)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	previous, err := c.ledger.RetrieveBlockByNumber(c.ledger.Height() - 1)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get the last block of channel %s", chdr.ChannelId)
+	}
+	block := protoutil.NewBlock(previous.Header.Number+1, protoutil.BlockHeaderHash(previous.Header))
+	block.Data.Data = [][]byte{protoutil.MarshalOrPanic(env)}
+	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+	if err := o.sign(block, c.lastConfig); err != nil {
+		return nil, err
+	}
+
+	if err := c.ledger.Append(block); err != nil {
+		return nil, errors.WithMessagef(err, "failed to append block %d of channel %s", block.Header.Number, chdr.ChannelId)
+	}
+	return block, nil
+}
+
+// sign adds the last config and signature metadata to the block, as the
+// block writer of an orderer does.
+func (o *Orderer) sign(block *cb.Block, lastConfig uint64) error {
+	signatureHeader, err := protoutil.NewSignatureHeader(o.signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create the signature header of the block")
+	}
+	blockSignature := &cb.MetadataSignature{SignatureHeader: protoutil.MarshalOrPanic(signatureHeader)}
+	value := protoutil.MarshalOrPanic(&cb.OrdererBlockMetadata{LastConfig: &cb.LastConfig{Index: lastConfig}})
+	blockSignature.Signature, err = o.signer.Sign(util.ConcatenateBytes(value, blockSignature.SignatureHeader, protoutil.BlockHeaderBytes(block.Header)))
+	if err != nil {
+		return errors.WithMessage(err, "failed to sign the block")
+	}
+
+	block.Metadata.Metadata[cb.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&cb.Metadata{
+		Value:      value,
+		Signatures: []*cb.MetadataSignature{blockSignature},
+	})
+	block.Metadata.Metadata[cb.BlockMetadataIndex_LAST_CONFIG] = protoutil.MarshalOrPanic(&cb.Metadata{
+		Value: protoutil.MarshalOrPanic(&cb.LastConfig{Index: lastConfig}),
+	})
+	return nil
+}
+
+// Broadcast orders every transaction received into a block of its own.
+func (o *Orderer) Broadcast(srv ab.AtomicBroadcast_BroadcastServer) error {
+	for {
+		env, err := srv.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp := &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
+		block, err := o.order(env)
+		switch {
+		case errors.Is(err, bloccerrors.ErrChannelNotFound):
+			resp = &ab.BroadcastResponse{Status: cb.Status_NOT_FOUND, Info: err.Error()}
+		case err != nil:
+			resp = &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+		default:
+			logger.Debugf("Ordered transaction into block %d", block.Header.Number)
+		}
+		if err != nil {
+			logger.Warningf("Refusing broadcast transaction: %s", err)
+		}
+
+		if err := srv.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// Deliver delivers the blocks requested by every seek info received.
+func (o *Orderer) Deliver(srv ab.AtomicBroadcast_DeliverServer) error {
+	for {
+		env, err := srv.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		status, err := o.deliverBlocks(srv, env)
+		if err != nil {
+			return err
+		}
+		if err := srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Status{Status: status}}); err != nil {
+			return err
+		}
+	}
+}
+
+// deliverBlocks sends the blocks requested by the seek info in env and
+// returns the status ending the delivery. An error is returned if the blocks
+// could not be sent.
+func (o *Orderer) deliverBlocks(srv ab.AtomicBroadcast_DeliverServer, env *cb.Envelope) (cb.Status, error) {
+	chdr, err := channelHeader(env)
+	if err != nil {
+		logger.Warningf("Refusing delivery: %s", err)
+		return cb.Status_BAD_REQUEST, nil
+	}
+	payload, _ := protoutil.UnmarshalPayload(env.Payload)
+	seekInfo := &ab.SeekInfo{}
+	if err := proto.Unmarshal(payload.Data, seekInfo); err != nil || seekInfo.Start == nil || seekInfo.Stop == nil {
+		logger.Warningf("Refusing delivery on channel %s: malformed seek info", chdr.ChannelId)
+		return cb.Status_BAD_REQUEST, nil
+	}
+
+	c, err := o.chain(chdr.ChannelId)
+	if err != nil {
+		logger.Warningf("Refusing delivery: %s", err)
+		return cb.Status_NOT_FOUND, nil
+	}
+
+	iterator, number := c.ledger.Iterator(seekInfo.Start)
+	defer iterator.Close()
+
+	var stop uint64
+	switch position := seekInfo.Stop.Type.(type) {
+	case *ab.SeekPosition_Oldest:
+		stop = number
+	case *ab.SeekPosition_Newest:
+		stop = c.ledger.Height() - 1
+		if seekInfo.Start.GetNewest() != nil {
+			stop = number
+		}
+	case *ab.SeekPosition_Specified:
+		stop = position.Specified.Number
+	default:
+		return cb.Status_BAD_REQUEST, nil
+	}
+	if stop < number {
+		return cb.Status_BAD_REQUEST, nil
+	}
+
+	for {
+		if seekInfo.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY && number >= c.ledger.Height() {
+			return cb.Status_NOT_FOUND, nil
+		}
+		block, status := next(srv.Context(), iterator)
+		if status != cb.Status_SUCCESS {
+			return status, nil
+		}
+		if err := srv.Send(&ab.DeliverResponse{Type: &ab.DeliverResponse_Block{Block: block}}); err != nil {
+			return cb.Status_UNKNOWN, err
+		}
+		if block.Header.Number >= stop {
+			return cb.Status_SUCCESS, nil
+		}
+		number = block.Header.Number + 1
+	}
+}
+
+// next returns the next block of the iterator, unless ctx is done first.
+func next(ctx context.Context, iterator blockledger.Iterator) (*cb.Block, cb.Status) {
+	type result struct {
+		block  *cb.Block
+		status cb.Status
+	}
+	results := make(chan result, 1)
+	go func() {
+		block, status := iterator.Next()
+		results <- result{block: block, status: status}
+	}()
+
+	select {
+	case r := <-results:
+		return r.block, r.status
+	case <-ctx.Done():
+		return nil, cb.Status_SERVICE_UNAVAILABLE
+	}
+}
+
+func channelHeader(env *cb.Envelope) (*cb.ChannelHeader, error) {
+	payload, err := protoutil.UnmarshalPayload(env.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing payload header")
+	}
+	return protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+}
+
+// Server serves an orderer over TLS, with a certificate issued by a
+// certificate authority of its own.
+type Server struct {
+	*Orderer
+	server *comm.GRPCServer
+	ca     tlsgen.CA
+}
+
+// Listen starts serving the orderer on address, as host:port.
+func Listen(address string, orderer *Orderer) (*Server, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address %s", address)
+	}
+	ca, err := tlsgen.NewCA()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create the TLS CA of the development orderer")
+	}
+	keyPair, err := ca.NewServerCertKeyPair(host)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to issue the TLS certificate of the development orderer")
+	}
+
+	server, err := comm.NewGRPCServer(address, comm.ServerConfig{
+		SecOpts: comm.SecureOptions{
+			UseTLS:      true,
+			Certificate: keyPair.Cert,
+			Key:         keyPair.Key,
+		},
+	})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to listen on %s", address)
+	}
+	ab.RegisterAtomicBroadcastServer(server.Server(), orderer)
+	go func() {
+		if err := server.Start(); err != nil {
+			logger.Errorf("Development orderer stopped serving: %s", err)
+		}
+	}()
+
+	return &Server{Orderer: orderer, server: server, ca: ca}, nil
+}
+
+// Address returns the address the orderer is served on.
+func (s *Server) Address() string {
+	return s.server.Address()
+}
+
+// RootCert returns the PEM encoded certificate of the CA that issued the
+// TLS certificate of the server.
+func (s *Server) RootCert() []byte {
+	return s.ca.CertBytes()
+}
+
+// Stop stops serving the orderer and closes it.
+func (s *Server) Stop() {
+	s.server.Stop()
+	s.Orderer.Close()
+}
@@ -0,0 +1,139 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package devorderer
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSigner struct{}
+
+func (fakeSigner) Sign(message []byte) ([]byte, error) { return []byte("signature"), nil }
+
+func (fakeSigner) Serialize() ([]byte, error) { return []byte("peer0"), nil }
+
+type fakeLedger []*cb.Block
+
+func (f fakeLedger) GetBlockchainInfo() (*cb.BlockchainInfo, error) {
+	return &cb.BlockchainInfo{Height: uint64(len(f))}, nil
+}
+
+func (f fakeLedger) GetBlockByNumber(blockNumber uint64) (*cb.Block, error) {
+	if blockNumber >= uint64(len(f)) {
+		return nil, errors.Errorf("no block %d", blockNumber)
+	}
+	return f[blockNumber], nil
+}
+
+func envelope(t *testing.T, headerType cb.HeaderType, channelID string, data []byte) *cb.Envelope {
+	return &cb.Envelope{Payload: protoutil.MarshalOrPanic(&cb.Payload{
+		Header: &cb.Header{ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{Type: int32(headerType), ChannelId: channelID})},
+		Data:   data,
+	})}
+}
+
+func TestOrderer(t *testing.T) {
+	genesis := protoutil.NewBlock(0, nil)
+	genesis.Data.Data = [][]byte{[]byte("config")}
+	genesis.Header.DataHash = protoutil.BlockDataHash(genesis.Data)
+
+	orderer, err := New(t.TempDir(), fakeSigner{}, func(channelID string) Ledger {
+		if channelID != "mychannel" {
+			return nil
+		}
+		return fakeLedger{genesis}
+	})
+	require.NoError(t, err)
+	server, err := Listen("127.0.0.1:0", orderer)
+	require.NoError(t, err)
+	defer server.Stop()
+
+	conn, err := comm.ClientConfig{
+		SecOpts:     comm.SecureOptions{UseTLS: true, ServerRootCAs: [][]byte{server.RootCert()}},
+		DialTimeout: 5 * time.Second,
+	}.Dial(server.Address())
+	require.NoError(t, err)
+	defer conn.Close()
+	client := ab.NewAtomicBroadcastClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	broadcast, err := client.Broadcast(ctx)
+	require.NoError(t, err)
+	send := func(env *cb.Envelope) *ab.BroadcastResponse {
+		require.NoError(t, broadcast.Send(env))
+		resp, err := broadcast.Recv()
+		require.NoError(t, err)
+		return resp
+	}
+
+	tx := envelope(t, cb.HeaderType_ENDORSER_TRANSACTION, "mychannel", []byte("approval"))
+	require.Equal(t, cb.Status_SUCCESS, send(tx).Status)
+	require.Equal(t, cb.Status_SUCCESS, send(tx).Status)
+	require.Equal(t, &ab.BroadcastResponse{Status: cb.Status_NOT_FOUND, Info: "channel otherchannel not found"},
+		send(envelope(t, cb.HeaderType_ENDORSER_TRANSACTION, "otherchannel", nil)))
+	require.Equal(t, &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: "CONFIG_UPDATE transactions are not supported by the development orderer"},
+		send(envelope(t, cb.HeaderType_CONFIG_UPDATE, "mychannel", nil)))
+
+	deliver, err := client.Deliver(ctx)
+	require.NoError(t, err)
+	require.NoError(t, deliver.Send(envelope(t, cb.HeaderType_DELIVER_SEEK_INFO, "mychannel", protoutil.MarshalOrPanic(&ab.SeekInfo{
+		Start:    &ab.SeekPosition{Type: &ab.SeekPosition_Oldest{Oldest: &ab.SeekOldest{}}},
+		Stop:     &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: math.MaxUint64}}},
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}))))
+
+	var blocks []*cb.Block
+	for len(blocks) < 3 {
+		resp, err := deliver.Recv()
+		require.NoError(t, err)
+		require.NotNil(t, resp.GetBlock(), "unexpected status %s", resp.GetStatus())
+		blocks = append(blocks, resp.GetBlock())
+	}
+	require.True(t, proto.Equal(genesis, blocks[0]))
+	for i, block := range blocks[1:] {
+		require.Equal(t, uint64(i+1), block.Header.Number)
+		require.Equal(t, protoutil.BlockHeaderHash(blocks[i].Header), block.Header.PreviousHash)
+		require.Equal(t, protoutil.BlockDataHash(block.Data), block.Header.DataHash)
+		require.Equal(t, [][]byte{protoutil.MarshalOrPanic(tx)}, block.Data.Data)
+
+		signatures, err := protoutil.GetMetadataFromBlock(block, cb.BlockMetadataIndex_SIGNATURES)
+		require.NoError(t, err)
+		require.Len(t, signatures.Signatures, 1)
+		require.Equal(t, []byte("signature"), signatures.Signatures[0].Signature)
+		lastConfig, err := protoutil.GetLastConfigIndexFromBlock(block)
+		require.NoError(t, err)
+		require.Zero(t, lastConfig)
+	}
+
+	// blocks are delivered as they are ordered
+	require.Equal(t, cb.Status_SUCCESS, send(tx).Status)
+	resp, err := deliver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), resp.GetBlock().GetHeader().GetNumber())
+
+	deliver, err = client.Deliver(ctx)
+	require.NoError(t, err)
+	require.NoError(t, deliver.Send(envelope(t, cb.HeaderType_DELIVER_SEEK_INFO, "mychannel", protoutil.MarshalOrPanic(&ab.SeekInfo{
+		Start:    &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 10}}},
+		Stop:     &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: 10}}},
+		Behavior: ab.SeekInfo_FAIL_IF_NOT_READY,
+	}))))
+	resp, err = deliver.Recv()
+	require.NoError(t, err)
+	require.Equal(t, cb.Status_NOT_FOUND, resp.GetStatus())
+}
@@ -0,0 +1,50 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package errors defines the categories of the errors returned by the BLOCC
+// packages, so that callers can branch on the category of an error with
+// errors.Is whatever the messages wrapping it.
+package errors
+
+import "errors"
+
+var (
+	// ErrOrdererUnavailable is the category of the failures to reach an
+	// orderer, or of the broadcasts an orderer was temporarily unable to
+	// serve.
+	ErrOrdererUnavailable = errors.New("orderer unavailable")
+	// ErrPolicyViolation is the category of the failures of an identity or a
+	// transaction to satisfy a policy of a channel.
+	ErrPolicyViolation = errors.New("policy violation")
+	// ErrDuplicateApproval is the category of the approvals of readings the
+	// organization approved or rejected already.
+	ErrDuplicateApproval = errors.New("duplicate approval")
+	// ErrChannelNotFound is the category of the failures to find a channel
+	// this peer has joined.
+	ErrChannelNotFound = errors.New("channel not found")
+)
+
+// WithCategory returns err in the category, its message unchanged: errors.Is
+// matches the returned error against category, and errors.As and
+// errors.Cause still reach err.
+func WithCategory(err, category error) error {
+	if err == nil {
+		return nil
+	}
+	return &categorized{err: err, category: category}
+}
+
+type categorized struct {
+	err      error
+	category error
+}
+
+func (c *categorized) Error() string { return c.err.Error() }
+
+func (c *categorized) Unwrap() error { return c.err }
+
+func (c *categorized) Cause() error { return c.err }
+
+func (c *categorized) Is(target error) bool { return target == c.category }
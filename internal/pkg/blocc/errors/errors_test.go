@@ -0,0 +1,33 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type statusError struct{ status int }
+
+func (e *statusError) Error() string { return "bad status" }
+
+func TestWithCategory(t *testing.T) {
+	require.NoError(t, WithCategory(nil, ErrOrdererUnavailable))
+
+	cause := &statusError{status: 503}
+	err := pkgerrors.WithMessage(WithCategory(cause, ErrOrdererUnavailable), "failed to send transaction")
+	require.EqualError(t, err, "failed to send transaction: bad status")
+	require.True(t, errors.Is(err, ErrOrdererUnavailable))
+	require.False(t, errors.Is(err, ErrPolicyViolation))
+
+	var statusErr *statusError
+	require.True(t, errors.As(err, &statusErr))
+	require.Equal(t, 503, statusErr.status)
+	require.Equal(t, cause, pkgerrors.Cause(err))
+}
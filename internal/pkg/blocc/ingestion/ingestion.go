@@ -0,0 +1,144 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package ingestion sheds the proposals of the sensor chaincodes, i.e. the
+// submissions of readings, while the peer is overloaded, so that gateways
+// are told to retry later rather than timing out.
+//
+// The load is the number of sensor chaincode proposals the peer is
+// endorsing. The readings are shed deterministically by priority class: bulk
+// readings once the bulk limit is reached, and priority readings only once
+// the higher limit of the priority class is reached.
+package ingestion
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatusTooManyRequests is the status of the responses to the proposals of
+// shed readings.
+const StatusTooManyRequests = 429
+
+// Class is the priority class of a reading.
+type Class string
+
+const (
+	// Priority readings, e.g. alarms, are shed last
+	Priority Class = "priority"
+	// Bulk readings are shed first
+	Bulk Class = "bulk"
+)
+
+// Limits bound the sensor chaincode proposals endorsed concurrently.
+type Limits struct {
+	// MaxInFlight is the number of proposals in flight at which priority
+	// readings are shed. Zero disables the shedding.
+	MaxInFlight int
+	// BulkMaxInFlight is the number of proposals in flight at which bulk
+	// readings are shed, MaxInFlight if zero.
+	BulkMaxInFlight int
+	// RetryAfter is the delay the gateways are told to wait before they
+	// submit a shed reading again.
+	RetryAfter time.Duration
+	// PrioritySeverities are the severities of the priority readings,
+	// compared case-insensitively.
+	PrioritySeverities []string
+}
+
+func (l Limits) class(severity string) Class {
+	for _, s := range l.PrioritySeverities {
+		if strings.EqualFold(s, severity) {
+			return Priority
+		}
+	}
+	return Bulk
+}
+
+func (l Limits) limit(class Class) int {
+	if class == Bulk && l.BulkMaxInFlight > 0 && l.BulkMaxInFlight < l.MaxInFlight {
+		return l.BulkMaxInFlight
+	}
+	return l.MaxInFlight
+}
+
+// Shed tells why a reading was shed, as the JSON payload of the response to
+// its proposal.
+type Shed struct {
+	ChannelID string `json:"channelID"`
+	Class     Class  `json:"class"`
+	// InFlight is the number of proposals in flight, Limit the limit of
+	// the class of the reading
+	InFlight int `json:"inFlight"`
+	Limit    int `json:"limit"`
+	// RetryAfterMillis is the delay to wait before submitting the reading
+	// again, in milliseconds
+	RetryAfterMillis int64 `json:"retryAfterMillis"`
+}
+
+func (s *Shed) Error() string {
+	return fmt.Sprintf("%s reading on channel %s shed as the peer is overloaded with %d proposals in flight, retry after %s",
+		s.Class, s.ChannelID, s.InFlight, time.Duration(s.RetryAfterMillis)*time.Millisecond)
+}
+
+// ShedHook is called for every reading shed.
+type ShedHook func(channelID string, class Class)
+
+// Shedder admits the proposals of the sensor chaincodes within the limits.
+type Shedder struct {
+	mutex    sync.Mutex
+	limits   Limits
+	inFlight int
+	hook     ShedHook
+}
+
+// NewShedder returns the shedder calling hook for every reading shed, if not
+// nil. It admits all the proposals until its limits are set.
+func NewShedder(hook ShedHook) *Shedder {
+	return &Shedder{hook: hook}
+}
+
+// SetLimits replaces the limits, the proposals in flight being kept.
+func (s *Shedder) SetLimits(limits Limits) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.limits = limits
+}
+
+// Admit admits the proposal of a reading of the severity on the channel,
+// returning the function to call once it is endorsed, or the Shed if the
+// limit of its class is reached.
+func (s *Shedder) Admit(channelID, severity string) (release func(), shed *Shed) {
+	s.mutex.Lock()
+	class := s.limits.class(severity)
+	if limit := s.limits.limit(class); limit > 0 && s.inFlight >= limit {
+		shed = &Shed{
+			ChannelID:        channelID,
+			Class:            class,
+			InFlight:         s.inFlight,
+			Limit:            limit,
+			RetryAfterMillis: s.limits.RetryAfter.Milliseconds(),
+		}
+		hook := s.hook
+		s.mutex.Unlock()
+		if hook != nil {
+			hook(channelID, class)
+		}
+		return nil, shed
+	}
+	s.inFlight++
+	s.mutex.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mutex.Lock()
+			s.inFlight--
+			s.mutex.Unlock()
+		})
+	}, nil
+}
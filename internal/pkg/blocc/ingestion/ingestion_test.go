@@ -0,0 +1,66 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ingestion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShedder(t *testing.T) {
+	var shed []Class
+	s := NewShedder(func(channelID string, class Class) {
+		require.Equal(t, "sensorchannel", channelID)
+		shed = append(shed, class)
+	})
+
+	// no reading is shed without limits
+	var releases []func()
+	for i := 0; i < 5; i++ {
+		release, err := s.Admit("sensorchannel", "")
+		require.Nil(t, err)
+		releases = append(releases, release)
+	}
+	for _, release := range releases {
+		release()
+	}
+
+	s.SetLimits(Limits{MaxInFlight: 3, BulkMaxInFlight: 2, RetryAfter: 1500 * time.Millisecond, PrioritySeverities: []string{"alarm"}})
+	release1, err := s.Admit("sensorchannel", "info")
+	require.Nil(t, err)
+	_, err = s.Admit("sensorchannel", "")
+	require.Nil(t, err)
+
+	// bulk readings are shed first, priority readings at the higher limit
+	_, err = s.Admit("sensorchannel", "info")
+	require.Equal(t, &Shed{ChannelID: "sensorchannel", Class: Bulk, InFlight: 2, Limit: 2, RetryAfterMillis: 1500}, err)
+	require.EqualError(t, err, "bulk reading on channel sensorchannel shed as the peer is overloaded with 2 proposals in flight, retry after 1.5s")
+	_, err = s.Admit("sensorchannel", "ALARM")
+	require.Nil(t, err)
+	_, err = s.Admit("sensorchannel", "alarm")
+	require.Equal(t, &Shed{ChannelID: "sensorchannel", Class: Priority, InFlight: 3, Limit: 3, RetryAfterMillis: 1500}, err)
+	require.Equal(t, []Class{Bulk, Priority}, shed)
+
+	// released proposals are counted once
+	release1()
+	release1()
+	_, err = s.Admit("sensorchannel", "alarm")
+	require.Nil(t, err)
+	_, err = s.Admit("sensorchannel", "alarm")
+	require.NotNil(t, err)
+}
+
+func TestLimits(t *testing.T) {
+	limits := Limits{MaxInFlight: 10}
+	require.Equal(t, 10, limits.limit(Bulk))
+	limits.BulkMaxInFlight = 20
+	require.Equal(t, 10, limits.limit(Bulk))
+	limits.BulkMaxInFlight = 4
+	require.Equal(t, 4, limits.limit(Bulk))
+	require.Equal(t, 10, limits.limit(Priority))
+}
@@ -0,0 +1,96 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lorawan
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Measurement types
+const (
+	DigitalInput  = "digitalInput"
+	DigitalOutput = "digitalOutput"
+	AnalogInput   = "analogInput"
+	AnalogOutput  = "analogOutput"
+	Illuminance   = "illuminance"
+	Presence      = "presence"
+	Temperature   = "temperature"
+	Humidity      = "humidity"
+	Accelerometer = "accelerometer"
+	Barometer     = "barometer"
+	Gyrometer     = "gyrometer"
+	GPS           = "gps"
+)
+
+// lppType describes the encoding of a Cayenne LPP data type: a value is made
+// of len(resolutions) big endian fields of size bytes each, scaled by their
+// resolution.
+type lppType struct {
+	name        string
+	size        int
+	signed      bool
+	resolutions []float64
+}
+
+var lppTypes = map[byte]lppType{
+	0:   {name: DigitalInput, size: 1, resolutions: []float64{1}},
+	1:   {name: DigitalOutput, size: 1, resolutions: []float64{1}},
+	2:   {name: AnalogInput, size: 2, signed: true, resolutions: []float64{0.01}},
+	3:   {name: AnalogOutput, size: 2, signed: true, resolutions: []float64{0.01}},
+	101: {name: Illuminance, size: 2, resolutions: []float64{1}},
+	102: {name: Presence, size: 1, resolutions: []float64{1}},
+	103: {name: Temperature, size: 2, signed: true, resolutions: []float64{0.1}},
+	104: {name: Humidity, size: 1, resolutions: []float64{0.5}},
+	113: {name: Accelerometer, size: 2, signed: true, resolutions: []float64{0.001, 0.001, 0.001}},
+	115: {name: Barometer, size: 2, resolutions: []float64{0.1}},
+	134: {name: Gyrometer, size: 2, signed: true, resolutions: []float64{0.01, 0.01, 0.01}},
+	136: {name: GPS, size: 3, signed: true, resolutions: []float64{0.0001, 0.0001, 0.01}},
+}
+
+// CayenneLPPDecoder decodes Cayenne Low Power Payload uplinks, a sequence of
+// channel, type and value triples. Temperatures are in degrees Celsius and
+// humidities in percent.
+type CayenneLPPDecoder struct{}
+
+func (d *CayenneLPPDecoder) Decode(payload []byte) ([]Measurement, error) {
+	var measurements []Measurement
+	for offset := 0; offset < len(payload); {
+		if len(payload)-offset < 2 {
+			return nil, errors.Errorf("truncated measurement header at byte %d", offset)
+		}
+		channel, typeID := payload[offset], payload[offset+1]
+		offset += 2
+
+		t, ok := lppTypes[typeID]
+		if !ok {
+			return nil, errors.Errorf("unknown Cayenne LPP type %d on channel %d", typeID, channel)
+		}
+		if len(payload)-offset < t.size*len(t.resolutions) {
+			return nil, errors.Errorf("truncated %s value on channel %d", t.name, channel)
+		}
+
+		m := Measurement{Channel: channel, Type: t.name}
+		for _, resolution := range t.resolutions {
+			m.Values = append(m.Values, float64(decodeField(payload[offset:offset+t.size], t.signed))*resolution)
+			offset += t.size
+		}
+		measurements = append(measurements, m)
+	}
+
+	return measurements, nil
+}
+
+// decodeField decodes a big endian field, sign extending signed fields.
+func decodeField(field []byte, signed bool) int64 {
+	var value int64
+	for _, b := range field {
+		value = value<<8 | int64(b)
+	}
+	if bits := uint(8 * len(field)); signed && value&(1<<(bits-1)) != 0 {
+		value -= 1 << bits
+	}
+	return value
+}
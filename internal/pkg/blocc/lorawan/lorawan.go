@@ -0,0 +1,83 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package lorawan decodes the application payloads of LoRaWAN sensor uplinks
+// into sensory readings, so that raw uplinks can be submitted without an
+// external decoder service.
+package lorawan
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	// CayenneLPP decodes Cayenne Low Power Payload uplinks
+	CayenneLPP = "cayennelpp"
+)
+
+// Measurement is a single value of an uplink, e.g. a temperature, reported
+// on a channel of the device.
+type Measurement struct {
+	Channel uint8
+	Type    string
+	// Values holds a single value for scalar measurements, and the axes or
+	// coordinates of the measurement otherwise.
+	Values []float64
+}
+
+// Reading is a sensory reading, the relative humidity being a fraction.
+type Reading struct {
+	Temperature      float64
+	RelativeHumidity float64
+	Timestamp        int64
+}
+
+// Decoder decodes the application payload of an uplink.
+type Decoder interface {
+	Decode(payload []byte) ([]Measurement, error)
+}
+
+// New returns the decoder of the payload encoding.
+func New(encoding string) (Decoder, error) {
+	switch encoding {
+	case CayenneLPP:
+		return &CayenneLPPDecoder{}, nil
+	default:
+		return nil, errors.Errorf("unknown LoRaWAN payload encoding '%s'", encoding)
+	}
+}
+
+// DecodeReading decodes the uplink payload into a reading taken at the given
+// Unix timestamp, from the first temperature and humidity measurements of the
+// payload.
+func DecodeReading(decoder Decoder, payload []byte, timestamp int64) (*Reading, error) {
+	measurements, err := decoder.Decode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var temperature, humidity *float64
+	for i := range measurements {
+		m := &measurements[i]
+		switch {
+		case m.Type == Temperature && temperature == nil:
+			temperature = &m.Values[0]
+		case m.Type == Humidity && humidity == nil:
+			humidity = &m.Values[0]
+		}
+	}
+	if temperature == nil {
+		return nil, errors.New("uplink carries no temperature")
+	}
+	if humidity == nil {
+		return nil, errors.New("uplink carries no humidity")
+	}
+
+	return &Reading{
+		Temperature:      *temperature,
+		RelativeHumidity: *humidity / 100,
+		Timestamp:        timestamp,
+	}, nil
+}
@@ -0,0 +1,67 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lorawan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCayenneLPPDecode(t *testing.T) {
+	decoder, err := New(CayenneLPP)
+	require.NoError(t, err)
+
+	// examples of the Cayenne LPP specification
+	measurements, err := decoder.Decode([]byte{0x03, 0x67, 0x01, 0x10, 0x05, 0x67, 0x00, 0xFF})
+	require.NoError(t, err)
+	require.Len(t, measurements, 2)
+	require.Equal(t, uint8(3), measurements[0].Channel)
+	require.Equal(t, Temperature, measurements[0].Type)
+	require.InDelta(t, 27.2, measurements[0].Values[0], 1e-9)
+	require.Equal(t, uint8(5), measurements[1].Channel)
+	require.InDelta(t, 25.5, measurements[1].Values[0], 1e-9)
+
+	measurements, err = decoder.Decode([]byte{0x06, 0x71, 0x04, 0xD2, 0xFB, 0x2E, 0x00, 0x00})
+	require.NoError(t, err)
+	require.Equal(t, Accelerometer, measurements[0].Type)
+	require.InDeltaSlice(t, []float64{1.234, -1.234, 0}, measurements[0].Values, 1e-9)
+
+	measurements, err = decoder.Decode([]byte{0x01, 0x88, 0x06, 0x76, 0x5F, 0xF2, 0x96, 0x0A, 0x00, 0x03, 0xE8})
+	require.NoError(t, err)
+	require.Equal(t, GPS, measurements[0].Type)
+	require.InDeltaSlice(t, []float64{42.3519, -87.9094, 10}, measurements[0].Values, 1e-9)
+
+	// negative temperature
+	measurements, err = decoder.Decode([]byte{0x01, 0x67, 0xFF, 0xD7})
+	require.NoError(t, err)
+	require.InDelta(t, -4.1, measurements[0].Values[0], 1e-9)
+
+	_, err = decoder.Decode([]byte{0x01})
+	require.EqualError(t, err, "truncated measurement header at byte 0")
+	_, err = decoder.Decode([]byte{0x01, 0x67, 0x01})
+	require.EqualError(t, err, "truncated temperature value on channel 1")
+	_, err = decoder.Decode([]byte{0x01, 0xFF, 0x01})
+	require.EqualError(t, err, "unknown Cayenne LPP type 255 on channel 1")
+
+	_, err = New("hex")
+	require.EqualError(t, err, "unknown LoRaWAN payload encoding 'hex'")
+}
+
+func TestDecodeReading(t *testing.T) {
+	decoder := &CayenneLPPDecoder{}
+
+	reading, err := DecodeReading(decoder, []byte{0x01, 0x67, 0x00, 0xD7, 0x02, 0x68, 0x50}, 1628887200)
+	require.NoError(t, err)
+	require.InDelta(t, 21.5, reading.Temperature, 1e-9)
+	require.InDelta(t, 0.4, reading.RelativeHumidity, 1e-9)
+	require.Equal(t, int64(1628887200), reading.Timestamp)
+
+	_, err = DecodeReading(decoder, []byte{0x02, 0x68, 0x50}, 1628887200)
+	require.EqualError(t, err, "uplink carries no temperature")
+	_, err = DecodeReading(decoder, []byte{0x01, 0x67, 0x00, 0xD7}, 1628887200)
+	require.EqualError(t, err, "uplink carries no humidity")
+}
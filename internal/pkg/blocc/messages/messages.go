@@ -0,0 +1,175 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package messages is the catalog of the user-facing status and error
+// messages of BSCC and of the peer blocc commands, so that the operators of
+// a consortium can read them in their own language.
+//
+// English is built in. The messages of another locale are read from the
+// JSON file named after the locale, e.g. fr.json, in the catalog directory:
+// an object mapping the keys of the messages to their translation. The
+// translations take the arguments of the English messages, in the same
+// order unless they use explicit argument indexes such as %[2]s. The
+// messages missing from a translation are given in English.
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Key identifies a message of the catalog.
+type Key string
+
+// The keys of the messages of BSCC.
+const (
+	IncorrectArguments Key = "IncorrectArguments"
+	AccessDenied       Key = "AccessDenied"
+	FunctionNotFound   Key = "FunctionNotFound"
+	ChannelIDRequired  Key = "ChannelIDRequired"
+	// UnsupportedAPIVersion and DeprecatedAPIVersion tell the clients of
+	// BSCC that the version of the API they speak is no longer, or not yet,
+	// served, or is due to be dropped.
+	UnsupportedAPIVersion Key = "UnsupportedAPIVersion"
+	DeprecatedAPIVersion  Key = "DeprecatedAPIVersion"
+)
+
+// The keys of the messages of the peer blocc commands.
+const (
+	ForkCleared             Key = "ForkCleared"
+	NoForkInformation       Key = "NoForkInformation"
+	ApprovalsDrained        Key = "ApprovalsDrained"
+	PendingApprovalsFlushed Key = "PendingApprovalsFlushed"
+	BundleExported          Key = "BundleExported"
+	BundleProofsChained     Key = "BundleProofsChained"
+	ProvenanceExported      Key = "ProvenanceExported"
+	SensorsRegistering      Key = "SensorsRegistering"
+	SensorsRegistered       Key = "SensorsRegistered"
+	SensorRowFailed         Key = "SensorRowFailed"
+	AllSensorsRegistered    Key = "AllSensorsRegistered"
+	ReapprovalSubmitted     Key = "ReapprovalSubmitted"
+	ApprovalVerified        Key = "ApprovalVerified"
+	CheckFailed             Key = "CheckFailed"
+	CheckPassed             Key = "CheckPassed"
+	VerdictInvalid          Key = "VerdictInvalid"
+	VerdictValid            Key = "VerdictValid"
+)
+
+// DefaultLocale is the locale of the built-in messages.
+const DefaultLocale = "en"
+
+var english = map[Key]string{
+	IncorrectArguments:    "Incorrect number of arguments, %d",
+	AccessDenied:          "access denied for [%s]: %s",
+	FunctionNotFound:      "Requested function %s not found.",
+	ChannelIDRequired:     "channel ID must be provided",
+	UnsupportedAPIVersion: "BSCC API version %d is not supported by this peer, which serves versions %d to %d; upgrade the client or the peer",
+	DeprecatedAPIVersion:  "BSCC API version %d is deprecated and will be dropped by a later release of the peer, upgrade the client to version %d",
+
+	ForkCleared:             "Fork information of channel %s cleared",
+	NoForkInformation:       "Channel %s has no fork information",
+	ApprovalsDrained:        "Approvals drained, %d pending approval requests saved for the next start",
+	PendingApprovalsFlushed: "Flushed %d stored approvals, %d rejected and dropped, %d still pending",
+	BundleExported:          "Exported %d readings of sensor %s to %s",
+	BundleProofsChained:     "Proofs are chained up to block %d of channel %s with hash %x",
+	ProvenanceExported:      "Exported the provenance of reading %s to %s",
+	SensorsRegistering:      "Registering %d of %d sensors, %d invalid",
+	SensorsRegistered:       "Registered %d sensors, %d left to submit",
+	SensorRowFailed:         "Row %d, sensor %s, %s: %s",
+	AllSensorsRegistered:    "Registered all %d sensors of %s",
+	ReapprovalSubmitted:     "Re-approval of reading %s submitted by %s, recorded decision was: %s",
+	ApprovalVerified:        "Approval %s of reading %s by %s",
+	CheckFailed:             "  [FAIL] %s: %s",
+	CheckPassed:             "  [OK]   %s",
+	VerdictInvalid:          "Verdict: INVALID",
+	VerdictValid:            "Verdict: VALID",
+}
+
+// localePattern matches the locales, such as fr or pt-BR, so that a locale
+// cannot name a file outside of the catalog directory.
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}([-_][A-Za-z0-9]{2,8})*$`)
+
+// Catalog gives the messages in the selected locale.
+type Catalog struct {
+	mutex        sync.RWMutex
+	locale       string
+	translations map[Key]string
+}
+
+// Default is the catalog of the messages of this process.
+var Default = &Catalog{locale: DefaultLocale}
+
+// Load selects the locale, reading its translations from the catalog
+// directory dir. The built-in English messages are selected if locale is
+// empty or DefaultLocale. The selected locale is left unchanged on error.
+func (c *Catalog) Load(locale, dir string) error {
+	if locale == "" || locale == DefaultLocale {
+		c.set(DefaultLocale, nil)
+		return nil
+	}
+	if !localePattern.MatchString(locale) {
+		return errors.Errorf("invalid locale '%s'", locale)
+	}
+	if dir == "" {
+		return errors.Errorf("no message catalog directory set for locale %s", locale)
+	}
+
+	path := filepath.Join(dir, locale+".json")
+	catalogBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read message catalog %s", path)
+	}
+	translations := map[Key]string{}
+	if err := json.Unmarshal(catalogBytes, &translations); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal message catalog %s", path)
+	}
+	for key := range translations {
+		if _, ok := english[key]; !ok {
+			return errors.Errorf("unknown message %s in message catalog %s", key, path)
+		}
+	}
+
+	c.set(locale, translations)
+	return nil
+}
+
+func (c *Catalog) set(locale string, translations map[Key]string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.locale = locale
+	c.translations = translations
+}
+
+// Locale returns the selected locale.
+func (c *Catalog) Locale() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.locale
+}
+
+// Sprintf formats the message of the key in the selected locale.
+func (c *Catalog) Sprintf(key Key, args ...interface{}) string {
+	c.mutex.RLock()
+	format, ok := c.translations[key]
+	c.mutex.RUnlock()
+	if !ok {
+		if format, ok = english[key]; !ok {
+			format = string(key)
+		}
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Sprintf formats the message of the key in the locale selected for the
+// Default catalog.
+func Sprintf(key Key, args ...interface{}) string {
+	return Default.Sprintf(key, args...)
+}
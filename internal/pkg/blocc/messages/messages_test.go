@@ -0,0 +1,49 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package messages
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "messages")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "fr.json"), []byte(`{
+		"ForkCleared": "Informations de fork du canal %s effacées",
+		"ApprovalVerified": "Approbation %[1]s de la lecture %[2]s par %[3]s"
+	}`), 0o644)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"Unknown": "Unbekannt"}`), 0o644)
+	require.NoError(t, err)
+
+	c := &Catalog{locale: DefaultLocale}
+	require.Equal(t, "Fork information of channel mychannel cleared", c.Sprintf(ForkCleared, "mychannel"))
+
+	require.NoError(t, c.Load("fr", dir))
+	require.Equal(t, "fr", c.Locale())
+	require.Equal(t, "Informations de fork du canal mychannel effacées", c.Sprintf(ForkCleared, "mychannel"))
+	require.Equal(t, "Approbation tx2 de la lecture tx1 par Org1MSP", c.Sprintf(ApprovalVerified, "tx2", "tx1", "Org1MSP"))
+	// untranslated messages are given in English
+	require.Equal(t, "Channel mychannel has no fork information", c.Sprintf(NoForkInformation, "mychannel"))
+
+	require.EqualError(t, c.Load("de", dir), "unknown message Unknown in message catalog "+filepath.Join(dir, "de.json"))
+	require.Error(t, c.Load("es", dir))
+	require.EqualError(t, c.Load("../fr", dir), "invalid locale '../fr'")
+	require.EqualError(t, c.Load("fr", ""), "no message catalog directory set for locale fr")
+	require.Equal(t, "fr", c.Locale())
+
+	require.NoError(t, c.Load("", dir))
+	require.Equal(t, DefaultLocale, c.Locale())
+	require.Equal(t, "Fork information of channel mychannel cleared", c.Sprintf(ForkCleared, "mychannel"))
+}
@@ -0,0 +1,107 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pinning pins the TLS certificates of the orderers BLOCC connects
+// to, by the SHA-256 hash of their subject public key info (SPKI), as a
+// defense of the approval path against a compromised certificate authority.
+package pinning
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MismatchHook is called with the address of every orderer presenting a
+// certificate chain that matches none of the pins.
+type MismatchHook func(address string)
+
+// Pinner verifies the certificate chains presented by the orderers against
+// their pins. A nil Pinner verifies them without reporting the mismatches.
+type Pinner struct {
+	hook MismatchHook
+}
+
+// NewPinner returns the pinner calling hook on every pin mismatch, if not
+// nil.
+func NewPinner(hook MismatchHook) *Pinner {
+	return &Pinner{hook: hook}
+}
+
+// MismatchError is returned when an orderer presents a certificate chain
+// that matches none of the pins.
+type MismatchError struct {
+	Address string
+	// Presented are the SPKI hashes of the certificates presented
+	Presented []string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("TLS certificate of orderer %s matches none of the pinned keys, presented keys: %s", e.Address, strings.Join(e.Presented, ", "))
+}
+
+// Hash returns the pin of the certificate, the base64 encoded SHA-256 hash
+// of its subject public key info.
+func Hash(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// Verifier returns the function verifying that a certificate of the chain
+// presented by the orderer at address, or of the chains it was verified
+// against, matches one of the pins, as called once the chain is verified by
+// TLS. Nil is returned if there are no pins.
+func (p *Pinner) Verifier(address string, pins []string) (func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error, error) {
+	if len(pins) == 0 {
+		return nil, nil
+	}
+	pinned := map[string]bool{}
+	for _, pin := range pins {
+		hash, err := base64.StdEncoding.DecodeString(pin)
+		if err != nil || len(hash) != sha256.Size {
+			return nil, errors.Errorf("invalid orderer pin '%s', expected the base64 encoded SHA-256 hash of a subject public key info", pin)
+		}
+		pinned[pin] = true
+	}
+
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		var presented []string
+		seen := map[string]bool{}
+		check := func(cert *x509.Certificate) bool {
+			hash := Hash(cert)
+			if !seen[hash] {
+				seen[hash] = true
+				presented = append(presented, hash)
+			}
+			return pinned[hash]
+		}
+
+		for _, chain := range verifiedChains {
+			for _, cert := range chain {
+				if check(cert) {
+					return nil
+				}
+			}
+		}
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				continue
+			}
+			if check(cert) {
+				return nil
+			}
+		}
+
+		if p != nil && p.hook != nil {
+			p.hook(address)
+		}
+		return &MismatchError{Address: address, Presented: presented}
+	}, nil
+}
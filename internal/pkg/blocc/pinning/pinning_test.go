@@ -0,0 +1,64 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pinning
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCert(t *testing.T, pemBytes []byte) *x509.Certificate {
+	block, _ := pem.Decode(pemBytes)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestVerifier(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	pair, err := ca.NewServerCertKeyPair("orderer0.example.com")
+	require.NoError(t, err)
+	caCert, leaf := parseCert(t, ca.CertBytes()), parseCert(t, pair.Cert)
+	otherCA, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	otherCert := parseCert(t, otherCA.CertBytes())
+
+	var mismatches []string
+	pinner := NewPinner(func(address string) { mismatches = append(mismatches, address) })
+
+	verify, err := pinner.Verifier("orderer0.example.com:7050", nil)
+	require.NoError(t, err)
+	require.Nil(t, verify)
+
+	_, err = pinner.Verifier("orderer0.example.com:7050", []string{"not a pin"})
+	require.EqualError(t, err, "invalid orderer pin 'not a pin', expected the base64 encoded SHA-256 hash of a subject public key info")
+
+	// the leaf or its CA may be pinned
+	for _, pin := range []string{Hash(leaf), Hash(caCert)} {
+		verify, err = pinner.Verifier("orderer0.example.com:7050", []string{Hash(otherCert), pin})
+		require.NoError(t, err)
+		require.NoError(t, verify([][]byte{leaf.Raw}, [][]*x509.Certificate{{leaf, caCert}}))
+	}
+	require.Empty(t, mismatches)
+
+	verify, err = pinner.Verifier("orderer0.example.com:7050", []string{Hash(otherCert)})
+	require.NoError(t, err)
+	err = verify([][]byte{leaf.Raw}, [][]*x509.Certificate{{leaf, caCert}})
+	require.Equal(t, &MismatchError{Address: "orderer0.example.com:7050", Presented: []string{Hash(leaf), Hash(caCert)}}, err)
+	require.Equal(t, []string{"orderer0.example.com:7050"}, mismatches)
+
+	// a nil pinner rejects the mismatches without reporting them
+	verify, err = (*Pinner)(nil).Verifier("orderer0.example.com:7050", []string{Hash(otherCert)})
+	require.NoError(t, err)
+	require.IsType(t, &MismatchError{}, verify([][]byte{leaf.Raw}, nil))
+	require.Len(t, mismatches, 1)
+}
@@ -0,0 +1,181 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package prov builds W3C PROV documents serialized as PROV-JSON
+// (https://www.w3.org/Submission/prov-json/), for the interoperability of the
+// chain of custody of the readings with provenance tooling.
+//
+// The identifiers are qualified names whose prefixes are declared in the
+// document, and the relations are identified by blank nodes numbered in the
+// order they are added, so that a document built twice from the same records
+// is serialized identically.
+package prov
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Attributes are the attributes of a record, keyed by qualified name. The
+// values are strings, numbers, booleans or typed Values.
+type Attributes map[string]interface{}
+
+// Value is a typed literal of PROV-JSON.
+type Value struct {
+	Value string `json:"$"`
+	Type  string `json:"type"`
+}
+
+// QualifiedName returns the literal of a qualified name, e.g. the value of a
+// prov:type attribute.
+func QualifiedName(name string) Value {
+	return Value{Value: name, Type: "prov:QUALIFIED_NAME"}
+}
+
+// DateTime returns the xsd:dateTime literal of the time, in UTC.
+func DateTime(t time.Time) Value {
+	return Value{Value: Time(t), Type: "xsd:dateTime"}
+}
+
+// Time returns the time as the xsd:dateTime string, in UTC, expected for the
+// times of the activities and relations.
+func Time(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// The kinds of the records of a document, named as in PROV-JSON.
+const (
+	entity            = "entity"
+	activity          = "activity"
+	agent             = "agent"
+	wasGeneratedBy    = "wasGeneratedBy"
+	used              = "used"
+	wasInformedBy     = "wasInformedBy"
+	wasAttributedTo   = "wasAttributedTo"
+	wasAssociatedWith = "wasAssociatedWith"
+	actedOnBehalfOf   = "actedOnBehalfOf"
+	hadMember         = "hadMember"
+)
+
+// Document is a PROV document. The zero value is not usable, documents are
+// created with NewDocument.
+type Document struct {
+	prefixes map[string]string
+	records  map[string]map[string]Attributes
+	blanks   int
+}
+
+// NewDocument returns an empty document.
+func NewDocument() *Document {
+	return &Document{
+		prefixes: map[string]string{},
+		records:  map[string]map[string]Attributes{},
+	}
+}
+
+// Prefix declares the namespace of the prefix of qualified names.
+func (d *Document) Prefix(prefix, namespace string) {
+	d.prefixes[prefix] = namespace
+}
+
+// Entity adds the entity id, merging the attributes into those of an entity
+// of the same id already added.
+func (d *Document) Entity(id string, attributes Attributes) {
+	d.add(entity, id, attributes)
+}
+
+// Activity adds the activity id, merging the attributes into those of an
+// activity of the same id already added.
+func (d *Document) Activity(id string, attributes Attributes) {
+	d.add(activity, id, attributes)
+}
+
+// Agent adds the agent id, merging the attributes into those of an agent of
+// the same id already added.
+func (d *Document) Agent(id string, attributes Attributes) {
+	d.add(agent, id, attributes)
+}
+
+// WasGeneratedBy relates the entity to the activity that generated it.
+func (d *Document) WasGeneratedBy(entityID, activityID string, attributes Attributes) {
+	d.relate(wasGeneratedBy, attributes, "prov:entity", entityID, "prov:activity", activityID)
+}
+
+// Used relates the activity to the entity it used.
+func (d *Document) Used(activityID, entityID string, attributes Attributes) {
+	d.relate(used, attributes, "prov:activity", activityID, "prov:entity", entityID)
+}
+
+// WasInformedBy relates the informed activity to the informant activity
+// that generated an entity it used.
+func (d *Document) WasInformedBy(informed, informant string, attributes Attributes) {
+	d.relate(wasInformedBy, attributes, "prov:informed", informed, "prov:informant", informant)
+}
+
+// WasAttributedTo relates the entity to the agent it is ascribed to.
+func (d *Document) WasAttributedTo(entityID, agentID string, attributes Attributes) {
+	d.relate(wasAttributedTo, attributes, "prov:entity", entityID, "prov:agent", agentID)
+}
+
+// WasAssociatedWith relates the activity to an agent responsible for it, in
+// the role if not empty.
+func (d *Document) WasAssociatedWith(activityID, agentID, role string, attributes Attributes) {
+	if role != "" {
+		attributes = merge(attributes, Attributes{"prov:role": QualifiedName(role)})
+	}
+	d.relate(wasAssociatedWith, attributes, "prov:activity", activityID, "prov:agent", agentID)
+}
+
+// ActedOnBehalfOf relates the delegate agent to the responsible agent.
+func (d *Document) ActedOnBehalfOf(delegate, responsible string, attributes Attributes) {
+	d.relate(actedOnBehalfOf, attributes, "prov:delegate", delegate, "prov:responsible", responsible)
+}
+
+// HadMember relates the collection entity to one of its member entities.
+func (d *Document) HadMember(collection, entityID string) {
+	d.relate(hadMember, nil, "prov:collection", collection, "prov:entity", entityID)
+}
+
+func (d *Document) add(kind, id string, attributes Attributes) {
+	records := d.records[kind]
+	if records == nil {
+		records = map[string]Attributes{}
+		d.records[kind] = records
+	}
+	records[id] = merge(records[id], attributes)
+}
+
+func (d *Document) relate(kind string, attributes Attributes, keysAndIDs ...string) {
+	relation := merge(nil, attributes)
+	for i := 0; i < len(keysAndIDs); i += 2 {
+		relation[keysAndIDs[i]] = keysAndIDs[i+1]
+	}
+	d.blanks++
+	d.add(kind, fmt.Sprintf("_:%s%d", kind, d.blanks), relation)
+}
+
+func merge(attributes, more Attributes) Attributes {
+	merged := Attributes{}
+	for k, v := range attributes {
+		merged[k] = v
+	}
+	for k, v := range more {
+		merged[k] = v
+	}
+	return merged
+}
+
+// MarshalJSON serializes the document as PROV-JSON.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	document := map[string]interface{}{}
+	if len(d.prefixes) > 0 {
+		document["prefix"] = d.prefixes
+	}
+	for kind, records := range d.records {
+		document[kind] = records
+	}
+	return json.Marshal(document)
+}
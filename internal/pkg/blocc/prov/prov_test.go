@@ -0,0 +1,56 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package prov
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	d := NewDocument()
+	d.Prefix("ex", "urn:example:")
+	d.Entity("ex:reading", Attributes{"prov:type": QualifiedName("ex:Reading")})
+	d.Entity("ex:reading", Attributes{"ex:value": 21.5})
+	d.Agent("ex:sensor", nil)
+	d.Agent("ex:org", nil)
+	d.Activity("ex:sensing", Attributes{"prov:endTime": Time(time.Unix(1628887200, 0))})
+	d.Entity("ex:block", Attributes{"prov:type": QualifiedName("prov:Collection")})
+	d.WasGeneratedBy("ex:reading", "ex:sensing", nil)
+	d.WasAssociatedWith("ex:sensing", "ex:sensor", "ex:sensor", nil)
+	d.WasAttributedTo("ex:reading", "ex:sensor", Attributes{"ex:at": DateTime(time.Unix(0, 0))})
+	d.ActedOnBehalfOf("ex:sensor", "ex:org", nil)
+	d.Used("ex:sensing", "ex:reading", nil)
+	d.WasInformedBy("ex:sensing", "ex:sensing", nil)
+	d.HadMember("ex:block", "ex:reading")
+
+	documentBytes, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"prefix": {"ex": "urn:example:"},
+		"entity": {
+			"ex:reading": {"prov:type": {"$": "ex:Reading", "type": "prov:QUALIFIED_NAME"}, "ex:value": 21.5},
+			"ex:block": {"prov:type": {"$": "prov:Collection", "type": "prov:QUALIFIED_NAME"}}
+		},
+		"agent": {"ex:sensor": {}, "ex:org": {}},
+		"activity": {"ex:sensing": {"prov:endTime": "2021-08-13T20:40:00Z"}},
+		"wasGeneratedBy": {"_:wasGeneratedBy1": {"prov:entity": "ex:reading", "prov:activity": "ex:sensing"}},
+		"wasAssociatedWith": {"_:wasAssociatedWith2": {"prov:activity": "ex:sensing", "prov:agent": "ex:sensor", "prov:role": {"$": "ex:sensor", "type": "prov:QUALIFIED_NAME"}}},
+		"wasAttributedTo": {"_:wasAttributedTo3": {"prov:entity": "ex:reading", "prov:agent": "ex:sensor", "ex:at": {"$": "1970-01-01T00:00:00Z", "type": "xsd:dateTime"}}},
+		"actedOnBehalfOf": {"_:actedOnBehalfOf4": {"prov:delegate": "ex:sensor", "prov:responsible": "ex:org"}},
+		"used": {"_:used5": {"prov:activity": "ex:sensing", "prov:entity": "ex:reading"}},
+		"wasInformedBy": {"_:wasInformedBy6": {"prov:informed": "ex:sensing", "prov:informant": "ex:sensing"}},
+		"hadMember": {"_:hadMember7": {"prov:collection": "ex:block", "prov:entity": "ex:reading"}}
+	}`, string(documentBytes))
+
+	// the serialization is deterministic
+	again, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, documentBytes, again)
+}
@@ -0,0 +1,296 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package proxy dials the orderer connections of BLOCC through a SOCKS5 or
+// HTTP CONNECT proxy, for deployments whose egress must go through one.
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Direct is the proxy of the endpoints dialed without a proxy.
+const Direct = "direct"
+
+// Config selects the proxy of every orderer endpoint.
+type Config struct {
+	// URL is the proxy of the endpoints without a proxy of their own, e.g.
+	// socks5://proxy:1080 or http://proxy:3128. The standard proxy
+	// environment variables are honored if empty.
+	URL string
+	// Endpoints maps endpoints, as host:port, to their proxy URL or Direct.
+	Endpoints map[string]string
+}
+
+// Resolve returns the URL of the proxy of the endpoint address, or nil if it
+// is dialed directly. ALL_PROXY, then HTTPS_PROXY, is the proxy of endpoints
+// not excluded by NO_PROXY if neither the endpoint nor the configuration has
+// a proxy, the orderer connections being TLS connections.
+func (c Config) Resolve(address string) (*url.URL, error) {
+	rawURL, ok := c.Endpoints[address]
+	if !ok {
+		rawURL = c.URL
+	}
+	if rawURL == "" {
+		if noProxy(address, getenv("NO_PROXY", "no_proxy")) {
+			return nil, nil
+		}
+		rawURL = getenv("ALL_PROXY", "all_proxy")
+		if rawURL == "" {
+			rawURL = getenv("HTTPS_PROXY", "https_proxy")
+		}
+	}
+	if rawURL == "" || rawURL == Direct {
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid proxy URL of %s", address)
+	}
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h", "http":
+	default:
+		return nil, errors.Errorf("unsupported scheme '%s' of proxy %s, expected socks5 or http", proxyURL.Scheme, proxyURL.Redacted())
+	}
+	if proxyURL.Host == "" {
+		return nil, errors.Errorf("proxy URL of %s has no host", address)
+	}
+
+	return proxyURL, nil
+}
+
+func getenv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// noProxy returns whether the address is excluded from proxying by the
+// comma-separated NO_PROXY entries, which are "*", hosts, host:port pairs or
+// domain suffixes.
+func noProxy(address, entries string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	for _, entry := range strings.Split(entries, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case entry == "*":
+			return true
+		case entry == strings.ToLower(address) || entry == strings.ToLower(host):
+			return true
+		case strings.HasSuffix(strings.ToLower(host), "."+strings.TrimPrefix(entry, ".")):
+			return true
+		}
+	}
+	return false
+}
+
+// Dialer returns a dialer connecting to addresses through the proxy at
+// proxyURL, authenticating with the user info of the URL if any.
+func Dialer(proxyURL *url.URL) func(ctx context.Context, address string) (net.Conn, error) {
+	return DialerVia(&net.Dialer{}, proxyURL)
+}
+
+// DialerVia returns a dialer connecting to addresses through the proxy at
+// proxyURL, the connection to the proxy being dialed with d.
+func DialerVia(d *net.Dialer, proxyURL *url.URL) func(ctx context.Context, address string) (net.Conn, error) {
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		conn, err := d.DialContext(ctx, "tcp", proxyURL.Host)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to proxy %s", proxyURL.Redacted())
+		}
+
+		// the handshake is bounded by the dial deadline
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		if proxyURL.Scheme == "http" {
+			err = connectHTTP(conn, proxyURL, address)
+		} else {
+			err = connectSOCKS5(conn, proxyURL, address)
+		}
+		if err != nil {
+			conn.Close()
+			return nil, errors.WithMessagef(err, "failed to connect to %s through proxy %s", address, proxyURL.Redacted())
+		}
+		conn.SetDeadline(time.Time{})
+
+		return conn, nil
+	}
+}
+
+// connectHTTP opens a tunnel to address with an HTTP CONNECT request.
+func connectHTTP(conn net.Conn, proxyURL *url.URL, address string) error {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		return errors.Wrap(err, "failed to send CONNECT request")
+	}
+
+	// the server speaks first once the tunnel is open, so that nothing but
+	// the response is buffered
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return errors.Wrap(err, "failed to read CONNECT response")
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("proxy refused CONNECT: %s", resp.Status)
+	}
+
+	return nil
+}
+
+const (
+	socks5Version      = 5
+	socks5NoAuth       = 0
+	socks5PasswordAuth = 2
+	socks5NoAcceptable = 0xff
+	socks5Connect      = 1
+	socks5IPv4         = 1
+	socks5Domain       = 3
+	socks5IPv6         = 4
+)
+
+// connectSOCKS5 opens a tunnel to address with a SOCKS5 CONNECT request. The
+// host name is resolved by the proxy.
+func connectSOCKS5(conn net.Conn, proxyURL *url.URL, address string) error {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrapf(err, "invalid address %s", address)
+	}
+	port, err := strconv.ParseUint(portString, 10, 16)
+	if err != nil {
+		return errors.Errorf("invalid port of address %s", address)
+	}
+
+	methods := []byte{socks5NoAuth}
+	if proxyURL.User != nil {
+		methods = append(methods, socks5PasswordAuth)
+	}
+	if _, err := conn.Write(append([]byte{socks5Version, byte(len(methods))}, methods...)); err != nil {
+		return errors.Wrap(err, "failed to send SOCKS5 greeting")
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return errors.Wrap(err, "failed to read SOCKS5 greeting")
+	}
+	if reply[0] != socks5Version {
+		return errors.Errorf("unexpected SOCKS version %d", reply[0])
+	}
+	switch reply[1] {
+	case socks5NoAuth:
+	case socks5PasswordAuth:
+		if err := authenticateSOCKS5(conn, proxyURL.User); err != nil {
+			return err
+		}
+	case socks5NoAcceptable:
+		return errors.New("no acceptable SOCKS5 authentication method")
+	default:
+		return errors.Errorf("unexpected SOCKS5 authentication method %d", reply[1])
+	}
+
+	req := []byte{socks5Version, socks5Connect, 0}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return errors.Errorf("host name of address %s is too long", address)
+		}
+		req = append(req, socks5Domain, byte(len(host)))
+		req = append(req, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socks5IPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socks5IPv6)
+		req = append(req, ip...)
+	}
+	req = binary.BigEndian.AppendUint16(req, uint16(port))
+	if _, err := conn.Write(req); err != nil {
+		return errors.Wrap(err, "failed to send SOCKS5 request")
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return errors.Wrap(err, "failed to read SOCKS5 reply")
+	}
+	if header[1] != 0 {
+		return errors.Errorf("proxy refused SOCKS5 request with code %d", header[1])
+	}
+	// the bound address is of no use
+	var boundLength int
+	switch header[3] {
+	case socks5IPv4:
+		boundLength = net.IPv4len
+	case socks5IPv6:
+		boundLength = net.IPv6len
+	case socks5Domain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return errors.Wrap(err, "failed to read SOCKS5 reply")
+		}
+		boundLength = int(length[0])
+	default:
+		return errors.Errorf("unexpected SOCKS5 address type %d", header[3])
+	}
+	if _, err := io.ReadFull(conn, make([]byte, boundLength+2)); err != nil {
+		return errors.Wrap(err, "failed to read SOCKS5 reply")
+	}
+
+	return nil
+}
+
+// authenticateSOCKS5 performs the SOCKS5 username/password authentication.
+func authenticateSOCKS5(conn net.Conn, user *url.Userinfo) error {
+	password, _ := user.Password()
+	if len(user.Username()) > 255 || len(password) > 255 {
+		return errors.New("SOCKS5 credentials are too long")
+	}
+	req := []byte{1, byte(len(user.Username()))}
+	req = append(req, user.Username()...)
+	req = append(req, byte(len(password)))
+	req = append(req, password...)
+	if _, err := conn.Write(req); err != nil {
+		return errors.Wrap(err, "failed to send SOCKS5 credentials")
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return errors.Wrap(err, "failed to read SOCKS5 authentication reply")
+	}
+	if reply[1] != 0 {
+		return errors.New("SOCKS5 authentication failed")
+	}
+
+	return nil
+}
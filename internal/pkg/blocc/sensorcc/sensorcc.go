@@ -0,0 +1,148 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sensorcc identifies the chaincodes whose transactions are sensory
+// readings. Several chaincodes may be matched at once, so that readings keep
+// being handled during a blue/green upgrade of the sensor chaincode, while
+// the old and new chaincodes run side by side.
+package sensorcc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultName is the name of the sensor chaincode of a BLOCC network.
+const DefaultName = "sensor_chaincode"
+
+// Chaincode identifies a sensor chaincode by name and, optionally, version.
+// An empty version matches every version of the chaincode.
+type Chaincode struct {
+	Name    string
+	Version string
+}
+
+// Parse parses a chaincode given as "name" or "name:version".
+func Parse(s string) (Chaincode, error) {
+	parts := strings.SplitN(s, ":", 2)
+	cc := Chaincode{Name: strings.TrimSpace(parts[0])}
+	if len(parts) == 2 {
+		cc.Version = strings.TrimSpace(parts[1])
+		if cc.Version == "" {
+			return Chaincode{}, errors.Errorf("empty version in sensor chaincode '%s'", s)
+		}
+	}
+	if cc.Name == "" {
+		return Chaincode{}, errors.Errorf("empty name in sensor chaincode '%s'", s)
+	}
+	return cc, nil
+}
+
+func (c Chaincode) String() string {
+	if c.Version == "" {
+		return c.Name
+	}
+	return fmt.Sprintf("%s:%s", c.Name, c.Version)
+}
+
+func (c Chaincode) matches(name, version string) bool {
+	return c.Name == name && (c.Version == "" || c.Version == version)
+}
+
+// MigrationHook is called for every reading of a chaincode being migrated
+// from, i.e. matched by another chaincode than the current one.
+type MigrationHook func(channelID, txID string, from Chaincode)
+
+// Matcher matches the transactions of the sensor chaincodes. The first
+// chaincode is the current one, the others being migrated from.
+type Matcher struct {
+	mutex      sync.RWMutex
+	chaincodes []Chaincode
+	hook       MigrationHook
+}
+
+// NewMatcher returns a matcher of the given chaincodes, the first being the
+// current one.
+func NewMatcher(chaincodes ...Chaincode) *Matcher {
+	return &Matcher{chaincodes: chaincodes}
+}
+
+// Default is the matcher of the sensor chaincodes of this peer.
+var Default = NewMatcher(Chaincode{Name: DefaultName})
+
+// Set replaces the matched chaincodes, the first being the current one.
+func (m *Matcher) Set(chaincodes []Chaincode) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.chaincodes = append([]Chaincode{}, chaincodes...)
+}
+
+// Chaincodes returns the matched chaincodes, the current one first.
+func (m *Matcher) Chaincodes() []Chaincode {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]Chaincode{}, m.chaincodes...)
+}
+
+// SetMigrationHook sets the hook called for the readings of the chaincodes
+// being migrated from, nil removing it.
+func (m *Matcher) SetMigrationHook(hook MigrationHook) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.hook = hook
+}
+
+// Match returns the chaincode matching the chaincode name and version, if any.
+func (m *Matcher) Match(name, version string) (Chaincode, bool) {
+	cc, _, ok := m.match(name, version)
+	return cc, ok
+}
+
+// MatchName returns whether a chaincode of the name is matched, whatever its
+// version, e.g. for proposals whose chaincode version is not yet known.
+func (m *Matcher) MatchName(name string) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, cc := range m.chaincodes {
+		if cc.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle returns whether the transaction of the chaincode name and version
+// is a sensory reading, calling the migration hook if it is a reading of a
+// chaincode being migrated from.
+func (m *Matcher) Handle(channelID, txID, name, version string) bool {
+	cc, current, ok := m.match(name, version)
+	if !ok {
+		return false
+	}
+
+	m.mutex.RLock()
+	hook := m.hook
+	m.mutex.RUnlock()
+	if !current && hook != nil {
+		hook(channelID, txID, cc)
+	}
+	return true
+}
+
+func (m *Matcher) match(name, version string) (cc Chaincode, current bool, ok bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for i, cc := range m.chaincodes {
+		if cc.matches(name, version) {
+			return cc, i == 0, true
+		}
+	}
+	return Chaincode{}, false, false
+}
@@ -0,0 +1,63 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sensorcc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cc, err := Parse("sensor_chaincode")
+	require.NoError(t, err)
+	require.Equal(t, Chaincode{Name: "sensor_chaincode"}, cc)
+	require.Equal(t, "sensor_chaincode", cc.String())
+
+	cc, err = Parse("sensor_chaincode:2.0")
+	require.NoError(t, err)
+	require.Equal(t, Chaincode{Name: "sensor_chaincode", Version: "2.0"}, cc)
+	require.Equal(t, "sensor_chaincode:2.0", cc.String())
+
+	_, err = Parse(":2.0")
+	require.EqualError(t, err, "empty name in sensor chaincode ':2.0'")
+	_, err = Parse("sensor_chaincode:")
+	require.EqualError(t, err, "empty version in sensor chaincode 'sensor_chaincode:'")
+}
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher(Chaincode{Name: "sensor_green"}, Chaincode{Name: "sensor_chaincode", Version: "1.0"})
+
+	type migration struct {
+		channelID, txID string
+		from            Chaincode
+	}
+	var migrations []migration
+	m.SetMigrationHook(func(channelID, txID string, from Chaincode) {
+		migrations = append(migrations, migration{channelID, txID, from})
+	})
+
+	// every version of the current chaincode is matched
+	require.True(t, m.Handle("mychannel", "tx1", "sensor_green", "3.1"))
+	require.True(t, m.Handle("mychannel", "tx2", "sensor_chaincode", "1.0"))
+	require.False(t, m.Handle("mychannel", "tx3", "sensor_chaincode", "0.9"))
+	require.False(t, m.Handle("mychannel", "tx4", "mycc", "1.0"))
+
+	// the readings of the chaincode migrated from go through the hook
+	require.Equal(t, []migration{{"mychannel", "tx2", Chaincode{Name: "sensor_chaincode", Version: "1.0"}}}, migrations)
+
+	cc, ok := m.Match("sensor_chaincode", "1.0")
+	require.True(t, ok)
+	require.Equal(t, Chaincode{Name: "sensor_chaincode", Version: "1.0"}, cc)
+	require.True(t, m.MatchName("sensor_chaincode"))
+	require.False(t, m.MatchName("mycc"))
+
+	m.Set([]Chaincode{{Name: "sensor_chaincode"}})
+	require.Equal(t, []Chaincode{{Name: "sensor_chaincode"}}, m.Chaincodes())
+	require.True(t, m.Handle("mychannel", "tx5", "sensor_chaincode", "0.9"))
+	require.False(t, m.Handle("mychannel", "tx6", "sensor_green", "3.1"))
+	require.Len(t, migrations, 1)
+}
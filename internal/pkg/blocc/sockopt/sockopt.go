@@ -0,0 +1,65 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sockopt sets the socket options of the BLOCC connections to the
+// orderers, such as the DSCP marking of their packets, so that the networks
+// carrying the approvals can prioritize them and detect dead connections
+// quickly.
+package sockopt
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Options are the socket options of a connection.
+type Options struct {
+	// DSCP is the differentiated services code point the packets are marked
+	// with, 0 leaving them unmarked
+	DSCP int
+	// TCPKeepAlive is the interval between two TCP keepalive probes, 0 for
+	// the default of the Go runtime and negative to disable them
+	TCPKeepAlive time.Duration
+}
+
+// IsSet returns whether any option differs from the defaults.
+func (o Options) IsSet() bool {
+	return o.DSCP != 0 || o.TCPKeepAlive != 0
+}
+
+// Dialer returns the dialer of the connections with the options.
+func (o Options) Dialer() (*net.Dialer, error) {
+	if o.DSCP < 0 || o.DSCP > 63 {
+		return nil, errors.Errorf("invalid DSCP %d, must be between 0 and 63", o.DSCP)
+	}
+
+	d := &net.Dialer{KeepAlive: o.TCPKeepAlive}
+	if o.DSCP != 0 {
+		dscp := o.DSCP
+		d.Control = func(network, address string, c syscall.RawConn) error {
+			var err error
+			if controlErr := c.Control(func(fd uintptr) { err = setDSCP(fd, network, dscp) }); controlErr != nil {
+				return controlErr
+			}
+			return errors.Wrapf(err, "failed to set DSCP %d on the connection to %s", dscp, address)
+		}
+	}
+	return d, nil
+}
+
+// DialContext returns the function dialing TCP connections with the options.
+func (o Options) DialContext() (func(ctx context.Context, address string) (net.Conn, error), error) {
+	d, err := o.Dialer()
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, address string) (net.Conn, error) {
+		return d.DialContext(ctx, "tcp", address)
+	}, nil
+}
@@ -0,0 +1,49 @@
+//go:build !windows
+// +build !windows
+
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sockopt
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialer(t *testing.T) {
+	_, err := Options{DSCP: 64}.Dialer()
+	require.EqualError(t, err, "invalid DSCP 64, must be between 0 and 63")
+	require.False(t, Options{}.IsSet())
+	require.True(t, Options{DSCP: 46}.IsSet())
+
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Close()
+		}
+	}()
+
+	dial, err := Options{DSCP: 46}.DialContext()
+	require.NoError(t, err)
+	conn, err := dial(context.Background(), listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	rawConn, err := conn.(*net.TCPConn).SyscallConn()
+	require.NoError(t, err)
+	var tos int
+	require.NoError(t, rawConn.Control(func(fd uintptr) {
+		tos, err = syscall.GetsockoptInt(int(fd), syscall.IPPROTO_IP, syscall.IP_TOS)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 46<<2, tos)
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+// +build !windows
+
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sockopt
+
+import "syscall"
+
+// setDSCP marks the packets of the socket fd with the DSCP, in the upper six
+// bits of the IPv4 type of service or of the IPv6 traffic class.
+func setDSCP(fd uintptr, network string, dscp int) error {
+	if network == "tcp6" || network == "udp6" {
+		return syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IPV6, syscall.IPV6_TCLASS, dscp<<2)
+	}
+	return syscall.SetsockoptInt(int(fd), syscall.IPPROTO_IP, syscall.IP_TOS, dscp<<2)
+}
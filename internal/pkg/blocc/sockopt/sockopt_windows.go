@@ -0,0 +1,16 @@
+//go:build windows
+// +build windows
+
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sockopt
+
+import "github.com/pkg/errors"
+
+// setDSCP fails, Windows marking packets through its QoS policies instead.
+func setDSCP(fd uintptr, network string, dscp int) error {
+	return errors.New("DSCP marking is not supported on Windows, use a QoS policy instead")
+}
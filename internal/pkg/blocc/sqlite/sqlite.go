@@ -0,0 +1,415 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package sqlite writes snapshots of tables as SQLite database files, so
+// that external tools get SQL access to BLOCC data without a database
+// server. It implements the subset of the SQLite file format needed to write
+// a database in one go: rowid tables without indexes, in UTF-8, with a
+// rollback journal. The databases are never modified in place: every
+// snapshot replaces the previous file atomically, so that readers always
+// see a consistent database.
+package sqlite
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	pageSize = 4096
+	// usableSize is the size of the pages less the bytes reserved at their
+	// end, none
+	usableSize = pageSize
+	// headerSize is the size of the database header at the start of page 1
+	headerSize = 100
+	// sqliteVersion is the SQLite version the database files claim to have
+	// been written by
+	sqliteVersion = 3031001
+
+	tableInteriorPage = 0x05
+	tableLeafPage     = 0x0d
+)
+
+// Table is a rowid table of a database. The rows are stored in order, with
+// rowids from 1.
+type Table struct {
+	Name string
+	// Columns are the column definitions of the table, e.g. "name TEXT"
+	Columns []string
+	// Rows hold nil, int64, float64, string or []byte values, one per column
+	Rows [][]interface{}
+}
+
+// WriteFile writes the tables to the database file at path, replacing the
+// previous database atomically. The file is made read-only, as it is only
+// meant to be read by other processes.
+func WriteFile(path string, tables []Table) error {
+	db, err := Encode(tables)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return errors.Wrapf(err, "failed to create directory of %s", path)
+	}
+	tmpPath := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	os.Remove(tmpPath)
+	if err := ioutil.WriteFile(tmpPath, db, 0o444); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "failed to rename %s", tmpPath)
+	}
+	return nil
+}
+
+// Encode returns the SQLite database file holding the tables.
+func Encode(tables []Table) ([]byte, error) {
+	w := &writer{pages: [][]byte{make([]byte, pageSize)}}
+
+	schema := make([][]interface{}, 0, len(tables))
+	for _, table := range tables {
+		if !validName(table.Name) {
+			return nil, errors.Errorf("invalid table name '%s'", table.Name)
+		}
+		for _, row := range table.Rows {
+			if len(row) != len(table.Columns) {
+				return nil, errors.Errorf("row of table %s has %d values instead of %d", table.Name, len(row), len(table.Columns))
+			}
+		}
+		root, err := w.writeTree(table.Rows, 0, 0)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to write table %s", table.Name)
+		}
+		sql := fmt.Sprintf("CREATE TABLE %s(%s)", table.Name, strings.Join(table.Columns, ", "))
+		schema = append(schema, []interface{}{"table", table.Name, table.Name, int64(root), sql})
+	}
+	// the schema table is rooted in page 1, after the database header
+	if _, err := w.writeTree(schema, 1, headerSize); err != nil {
+		return nil, errors.WithMessage(err, "failed to write schema")
+	}
+
+	db := make([]byte, 0, len(w.pages)*pageSize)
+	for _, page := range w.pages {
+		db = append(db, page...)
+	}
+	writeHeader(db, uint32(len(w.pages)))
+	return db, nil
+}
+
+func validName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "sqlite_") {
+		return false
+	}
+	for i, r := range name {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func writeHeader(db []byte, pageCount uint32) {
+	copy(db, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(db[16:], pageSize)
+	// file format write and read versions of the rollback journal
+	db[18], db[19] = 1, 1
+	// reserved bytes and payload fractions, fixed by the format
+	db[20], db[21], db[22], db[23] = 0, 64, 32, 32
+	// file change counter
+	binary.BigEndian.PutUint32(db[24:], 1)
+	binary.BigEndian.PutUint32(db[28:], pageCount)
+	// schema cookie and schema format
+	binary.BigEndian.PutUint32(db[40:], 1)
+	binary.BigEndian.PutUint32(db[44:], 4)
+	// text encoding, UTF-8
+	binary.BigEndian.PutUint32(db[56:], 1)
+	// version-valid-for and version of the writer
+	binary.BigEndian.PutUint32(db[92:], 1)
+	binary.BigEndian.PutUint32(db[96:], sqliteVersion)
+}
+
+// writer lays out the pages of a database, pages[i] being page i+1.
+type writer struct {
+	pages [][]byte
+}
+
+func (w *writer) allocate() uint32 {
+	w.pages = append(w.pages, make([]byte, pageSize))
+	return uint32(len(w.pages))
+}
+
+// node is a b-tree page being laid out, with the largest rowid it holds.
+type node struct {
+	cells  [][]byte
+	size   int
+	maxKey int64
+	right  uint32
+}
+
+// maxInteriorCellSize is the size of an interior cell with the largest
+// rowid: a page number and a 9-byte varint.
+const maxInteriorCellSize = 4 + 9
+
+// writeTree writes the rows as a table b-tree and returns its root page. The
+// root is written to page root if it is not zero, which must have been
+// reserved. The pages are filled as if offset bytes at their start were
+// used, as on page 1.
+func (w *writer) writeTree(rows [][]interface{}, root uint32, offset int) (uint32, error) {
+	var leaves []*node
+	current := &node{}
+	for i, row := range rows {
+		rowid := int64(i + 1)
+		cell, err := w.leafCell(rowid, row)
+		if err != nil {
+			return 0, err
+		}
+		if !fits(&node{}, cell, offset, 8) {
+			return 0, errors.Errorf("row %d does not fit in a page", rowid)
+		}
+		if len(current.cells) > 0 && !fits(current, cell, offset, 8) {
+			leaves = append(leaves, current)
+			current = &node{}
+		}
+		current.cells = append(current.cells, cell)
+		current.size += len(cell)
+		current.maxKey = rowid
+	}
+	leaves = append(leaves, current)
+
+	// the children are spread evenly over their parents, so that every
+	// interior page has at least one cell besides its right child
+	perPage := (usableSize - offset - 12) / (maxInteriorCellSize + 2)
+	level, pageType := leaves, byte(tableLeafPage)
+	for len(level) > 1 {
+		parentCount := (len(level) + perPage - 1) / perPage
+		parents := make([]*node, parentCount)
+		for i, n := range level {
+			number := w.allocate()
+			w.writePage(number, pageType, n)
+			parent := parents[i*parentCount/len(level)]
+			if parent == nil {
+				parent = &node{}
+				parents[i*parentCount/len(level)] = parent
+			}
+			cell := append(make([]byte, 4, maxInteriorCellSize), putVarint(nil, uint64(n.maxKey))...)
+			binary.BigEndian.PutUint32(cell, number)
+			parent.cells = append(parent.cells, cell)
+			parent.size += len(cell)
+			parent.maxKey = n.maxKey
+		}
+		// the last child of every parent is its right child
+		for _, parent := range parents {
+			last := parent.cells[len(parent.cells)-1]
+			parent.cells = parent.cells[:len(parent.cells)-1]
+			parent.size -= len(last)
+			parent.right = binary.BigEndian.Uint32(last)
+		}
+		level, pageType = parents, tableInteriorPage
+	}
+
+	if root == 0 {
+		root = w.allocate()
+	}
+	w.writePage(root, pageType, level[0])
+	return root, nil
+}
+
+// fits tells whether the cell fits in the page of the node, whose b-tree
+// page header is headerLen bytes long.
+func fits(n *node, cell []byte, offset, headerLen int) bool {
+	return offset+headerLen+2*(len(n.cells)+1)+n.size+len(cell) <= usableSize
+}
+
+func (w *writer) writePage(number uint32, pageType byte, n *node) {
+	page := w.pages[number-1]
+	// the b-tree page header follows the database header on page 1
+	offset := 0
+	if number == 1 {
+		offset = headerSize
+	}
+	page[offset] = pageType
+	headerLen := 8
+	if pageType == tableInteriorPage {
+		headerLen = 12
+		binary.BigEndian.PutUint32(page[offset+8:], n.right)
+	}
+	binary.BigEndian.PutUint16(page[offset+3:], uint16(len(n.cells)))
+
+	content := usableSize
+	for i, cell := range n.cells {
+		content -= len(cell)
+		copy(page[content:], cell)
+		binary.BigEndian.PutUint16(page[offset+headerLen+2*i:], uint16(content))
+	}
+	// a content area starting at 65536 is written as 0
+	binary.BigEndian.PutUint16(page[offset+5:], uint16(content))
+}
+
+// leafCell returns the table leaf cell of the row, the part of its record
+// that does not fit in the page being written to overflow pages.
+func (w *writer) leafCell(rowid int64, row []interface{}) ([]byte, error) {
+	payload, err := record(row)
+	if err != nil {
+		return nil, err
+	}
+
+	cell := putVarint(nil, uint64(len(payload)))
+	cell = putVarint(cell, uint64(rowid))
+	local := localSize(len(payload))
+	cell = append(cell, payload[:local]...)
+	if local == len(payload) {
+		return cell, nil
+	}
+
+	cell = append(cell, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(cell[len(cell)-4:], w.writeOverflow(payload[local:]))
+	return cell, nil
+}
+
+// writeOverflow writes the overflowing payload to a chain of overflow pages
+// and returns the first page.
+func (w *writer) writeOverflow(payload []byte) uint32 {
+	var first, previous uint32
+	for len(payload) > 0 {
+		number := w.allocate()
+		if previous == 0 {
+			first = number
+		} else {
+			binary.BigEndian.PutUint32(w.pages[previous-1], number)
+		}
+		n := copy(w.pages[number-1][4:], payload)
+		payload = payload[n:]
+		previous = number
+	}
+	return first
+}
+
+// localSize returns the size of the part of a payload of size bytes stored
+// in a table leaf cell, as defined by the file format.
+func localSize(size int) int {
+	maxLocal := usableSize - 35
+	if size <= maxLocal {
+		return size
+	}
+	minLocal := (usableSize-12)*32/255 - 23
+	local := minLocal + (size-minLocal)%(usableSize-4)
+	if local > maxLocal {
+		local = minLocal
+	}
+	return local
+}
+
+// record returns the values in the record format of SQLite.
+func record(values []interface{}) ([]byte, error) {
+	var header, body []byte
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			header = putVarint(header, 0)
+		case int64:
+			serialType, encoded := integer(v)
+			header = putVarint(header, serialType)
+			body = append(body, encoded...)
+		case int:
+			serialType, encoded := integer(int64(v))
+			header = putVarint(header, serialType)
+			body = append(body, encoded...)
+		case uint64:
+			if v > math.MaxInt64 {
+				return nil, errors.Errorf("integer %d out of range", v)
+			}
+			serialType, encoded := integer(int64(v))
+			header = putVarint(header, serialType)
+			body = append(body, encoded...)
+		case float64:
+			header = putVarint(header, 7)
+			body = append(body, make([]byte, 8)...)
+			binary.BigEndian.PutUint64(body[len(body)-8:], math.Float64bits(v))
+		case string:
+			header = putVarint(header, uint64(len(v))*2+13)
+			body = append(body, v...)
+		case []byte:
+			header = putVarint(header, uint64(len(v))*2+12)
+			body = append(body, v...)
+		default:
+			return nil, errors.Errorf("unsupported value type %T", value)
+		}
+	}
+
+	// the header size includes its own varint
+	headerSize := len(header) + 1
+	for len(header)+len(putVarint(nil, uint64(headerSize))) != headerSize {
+		headerSize = len(header) + len(putVarint(nil, uint64(headerSize)))
+	}
+	var buf bytes.Buffer
+	buf.Write(putVarint(nil, uint64(headerSize)))
+	buf.Write(header)
+	buf.Write(body)
+	return buf.Bytes(), nil
+}
+
+// integer returns the serial type of v and its big-endian encoding in the
+// smallest size.
+func integer(v int64) (uint64, []byte) {
+	switch {
+	case v == 0:
+		return 8, nil
+	case v == 1:
+		return 9, nil
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return 1, []byte{byte(v)}
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return 2, bigEndian(v, 2)
+	case v >= -1<<23 && v < 1<<23:
+		return 3, bigEndian(v, 3)
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return 4, bigEndian(v, 4)
+	case v >= -1<<47 && v < 1<<47:
+		return 5, bigEndian(v, 6)
+	default:
+		return 6, bigEndian(v, 8)
+	}
+}
+
+func bigEndian(v int64, size int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+	return b[8-size:]
+}
+
+// putVarint appends the SQLite varint encoding of v to b: big-endian groups
+// of 7 bits, the ninth byte holding 8 bits.
+func putVarint(b []byte, v uint64) []byte {
+	if v > 1<<56-1 {
+		var buf [9]byte
+		buf[8] = byte(v)
+		v >>= 8
+		for i := 7; i >= 0; i-- {
+			buf[i] = byte(v&0x7f) | 0x80
+			v >>= 7
+		}
+		return append(b, buf[:]...)
+	}
+
+	var buf [8]byte
+	i := len(buf) - 1
+	buf[i] = byte(v & 0x7f)
+	v >>= 7
+	for v > 0 {
+		i--
+		buf[i] = byte(v&0x7f) | 0x80
+		v >>= 7
+	}
+	return append(b, buf[i:]...)
+}
@@ -0,0 +1,210 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sqlite
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readVarint decodes the SQLite varint at the start of b.
+func readVarint(b []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < 8; i++ {
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return v<<8 | uint64(b[8]), 9
+}
+
+// readTable returns the records of the table b-tree rooted at page root,
+// by rowid.
+func readTable(t *testing.T, db []byte, root uint32) map[int64][]byte {
+	records := map[int64][]byte{}
+	var walk func(number uint32)
+	walk = func(number uint32) {
+		page := db[(number-1)*pageSize : number*pageSize]
+		offset := 0
+		if number == 1 {
+			offset = headerSize
+		}
+		headerLen := 8
+		if page[offset] == tableInteriorPage {
+			headerLen = 12
+		}
+		cells := int(binary.BigEndian.Uint16(page[offset+3:]))
+		for i := 0; i < cells; i++ {
+			cell := page[binary.BigEndian.Uint16(page[offset+headerLen+2*i:]):]
+			if page[offset] == tableInteriorPage {
+				walk(binary.BigEndian.Uint32(cell))
+				continue
+			}
+			size, n := readVarint(cell)
+			rowid, m := readVarint(cell[n:])
+			cell = cell[n+m:]
+			local := localSize(int(size))
+			payload := append([]byte(nil), cell[:local]...)
+			if local < int(size) {
+				for next := binary.BigEndian.Uint32(cell[local:]); next != 0; {
+					overflow := db[(next-1)*pageSize : next*pageSize]
+					payload = append(payload, overflow[4:]...)
+					next = binary.BigEndian.Uint32(overflow)
+				}
+				payload = payload[:size]
+			}
+			_, ok := records[int64(rowid)]
+			require.False(t, ok, "duplicate rowid %d", rowid)
+			records[int64(rowid)] = payload
+		}
+		if page[offset] == tableInteriorPage {
+			walk(binary.BigEndian.Uint32(page[offset+8:]))
+		}
+	}
+	walk(root)
+	return records
+}
+
+// decodeRecord returns the values of a record, the integers as int64.
+func decodeRecord(record []byte) []interface{} {
+	headerSize, n := readVarint(record)
+	header, body := record[n:headerSize], record[headerSize:]
+	var values []interface{}
+	for len(header) > 0 {
+		serialType, n := readVarint(header)
+		header = header[n:]
+		switch {
+		case serialType == 0:
+			values = append(values, nil)
+		case serialType == 8 || serialType == 9:
+			values = append(values, int64(serialType-8))
+		case serialType <= 6:
+			size := []int{0, 1, 2, 3, 4, 6, 8}[serialType]
+			v := int64(int8(body[0]))
+			for _, b := range body[1:size] {
+				v = v<<8 | int64(b)
+			}
+			values = append(values, v)
+			body = body[size:]
+		case serialType == 7:
+			values = append(values, math.Float64frombits(binary.BigEndian.Uint64(body)))
+			body = body[8:]
+		case serialType%2 == 0:
+			size := (serialType - 12) / 2
+			values = append(values, append([]byte(nil), body[:size]...))
+			body = body[size:]
+		default:
+			size := (serialType - 13) / 2
+			values = append(values, string(body[:size]))
+			body = body[size:]
+		}
+	}
+	return values
+}
+
+func TestPutVarint(t *testing.T) {
+	for _, tc := range []struct {
+		value   uint64
+		encoded []byte
+	}{
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x81, 0x00}},
+		{16383, []byte{0xff, 0x7f}},
+		{1<<56 - 1, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}},
+		{math.MaxUint64, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+	} {
+		require.Equal(t, tc.encoded, putVarint(nil, tc.value), "%d", tc.value)
+		value, n := readVarint(tc.encoded)
+		require.Equal(t, tc.value, value)
+		require.Equal(t, len(tc.encoded), n)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	var readings [][]interface{}
+	for i := 0; i < 20000; i++ {
+		readings = append(readings, []interface{}{"sensorchannel", fmt.Sprintf("tx%d", i), int64(i) * 1000003, float64(i) / 3, nil})
+	}
+	large := [][]interface{}{
+		{"text", strings.Repeat("a", 10000)},
+		{"blob", []byte(strings.Repeat("b", pageSize-34))},
+		{"local", strings.Repeat("c", pageSize-45)},
+		{"integers", int64(math.MinInt64)},
+		{"one", int64(1)},
+	}
+
+	db, err := Encode([]Table{
+		{Name: "readings", Columns: []string{"channel_id TEXT", "tx_id TEXT", "value INTEGER", "ratio REAL", "note TEXT"}, Rows: readings},
+		{Name: "empty", Columns: []string{"a"}},
+		{Name: "large", Columns: []string{"k TEXT", "v"}, Rows: large},
+	})
+	require.NoError(t, err)
+	require.Zero(t, len(db)%pageSize)
+	require.Equal(t, "SQLite format 3\x00", string(db[:16]))
+	require.Equal(t, uint32(len(db)/pageSize), binary.BigEndian.Uint32(db[28:]))
+
+	schema := readTable(t, db, 1)
+	require.Len(t, schema, 3)
+	roots := map[string]uint32{}
+	for _, record := range schema {
+		values := decodeRecord(record)
+		roots[values[1].(string)] = uint32(values[3].(int64))
+	}
+	require.Equal(t, []interface{}{"table", "readings", "readings", int64(roots["readings"]), "CREATE TABLE readings(channel_id TEXT, tx_id TEXT, value INTEGER, ratio REAL, note TEXT)"}, decodeRecord(schema[1]))
+
+	records := readTable(t, db, roots["readings"])
+	require.Len(t, records, len(readings))
+	for i, row := range readings {
+		require.Equal(t, row, decodeRecord(records[int64(i+1)]))
+	}
+	require.Empty(t, readTable(t, db, roots["empty"]))
+	records = readTable(t, db, roots["large"])
+	for i, row := range large {
+		require.Equal(t, row, decodeRecord(records[int64(i+1)]))
+	}
+
+	_, err = Encode([]Table{{Name: "sqlite_master"}})
+	require.EqualError(t, err, "invalid table name 'sqlite_master'")
+	_, err = Encode([]Table{{Name: "t", Columns: []string{"a"}, Rows: [][]interface{}{{"x", "y"}}}})
+	require.EqualError(t, err, "row of table t has 2 values instead of 1")
+	_, err = Encode([]Table{{Name: "t", Columns: []string{"a"}, Rows: [][]interface{}{{true}}}})
+	require.EqualError(t, err, "failed to write table t: unsupported value type bool")
+}
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlite")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "projection", "blocc.db")
+	tables := []Table{{Name: "t", Columns: []string{"a"}, Rows: [][]interface{}{{int64(42)}}}}
+	require.NoError(t, WriteFile(path, tables))
+	// the read-only database is replaced by the next snapshot
+	tables[0].Rows = append(tables[0].Rows, []interface{}{int64(43)})
+	require.NoError(t, WriteFile(path, tables))
+
+	db, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	expected, err := Encode(tables)
+	require.NoError(t, err)
+	require.Equal(t, expected, db)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o444), info.Mode().Perm())
+	files, err := ioutil.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
@@ -0,0 +1,51 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+)
+
+// KafkaPublisher publishes messages to Kafka, waiting for all in-sync
+// replicas to acknowledge every message.
+type KafkaPublisher struct {
+	producer sarama.SyncProducer
+}
+
+func NewKafkaPublisher(brokers []string) (*KafkaPublisher, error) {
+	if len(brokers) == 0 {
+		return nil, errors.New("no Kafka brokers specified")
+	}
+
+	config := sarama.NewConfig()
+	config.ClientID = "blocc-peer"
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+
+	producer, err := sarama.NewSyncProducer(brokers, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create Kafka producer")
+	}
+
+	return &KafkaPublisher{producer: producer}, nil
+}
+
+func (p *KafkaPublisher) Publish(topic string, key, payload []byte) error {
+	_, _, err := p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.ByteEncoder(key),
+		Value: sarama.ByteEncoder(payload),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to publish to Kafka topic %s", topic)
+	}
+	return nil
+}
+
+func (p *KafkaPublisher) Close() error {
+	return p.producer.Close()
+}
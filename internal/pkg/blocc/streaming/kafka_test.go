@@ -0,0 +1,36 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaPublisher(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	publisher := &KafkaPublisher{producer: producer}
+
+	producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+		require.Equal(t, `{"type":"SensorSilent"}`, string(value))
+		return nil
+	})
+	require.NoError(t, publisher.Publish("blocc-events", []byte("mychannel"), []byte(`{"type":"SensorSilent"}`)))
+
+	producer.ExpectSendMessageAndFail(sarama.ErrNotEnoughReplicas)
+	err := publisher.Publish("blocc-events", []byte("mychannel"), []byte(`{}`))
+	require.EqualError(t, err, "failed to publish to Kafka topic blocc-events: kafka server: Messages are rejected since there are fewer in-sync replicas than required.")
+
+	require.NoError(t, publisher.Close())
+}
+
+func TestNewKafkaPublisherNoBrokers(t *testing.T) {
+	_, err := NewKafkaPublisher(nil)
+	require.EqualError(t, err, "no Kafka brokers specified")
+}
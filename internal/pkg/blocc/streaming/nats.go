@@ -0,0 +1,208 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NATSPublisher publishes messages to NATS JetStream subjects over the NATS
+// client protocol. Every message is acknowledged by the stream capturing its
+// subject, so subjects must be bound to a JetStream stream. The connection is
+// established lazily and re-established after a failed publication.
+type NATSPublisher struct {
+	url     string
+	timeout time.Duration
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	inbox  string
+}
+
+func NewNATSPublisher(url string, timeout time.Duration) *NATSPublisher {
+	return &NATSPublisher{url: url, timeout: timeout}
+}
+
+// jetStreamAck is the acknowledgement of a message by a JetStream stream.
+type jetStreamAck struct {
+	Stream   string `json:"stream"`
+	Sequence uint64 `json:"seq"`
+	Error    *struct {
+		Code        int    `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+// Publish publishes the payload to the subject. The key is not used, as the
+// messages of a subject are delivered in order.
+func (p *NATSPublisher) Publish(subject string, key, payload []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.publish(subject, payload); err != nil {
+		p.disconnect()
+		return errors.WithMessagef(err, "failed to publish to NATS subject %s", subject)
+	}
+	return nil
+}
+
+func (p *NATSPublisher) publish(subject string, payload []byte) error {
+	if p.conn == nil {
+		if err := p.connect(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.conn.SetDeadline(time.Now().Add(p.timeout)); err != nil {
+		return errors.Wrap(err, "failed to set deadline")
+	}
+
+	if _, err := fmt.Fprintf(p.conn, "PUB %s %s %d\r\n%s\r\n", subject, p.inbox, len(payload), payload); err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	ackBytes, err := p.awaitMessage()
+	if err != nil {
+		return err
+	}
+
+	ack := &jetStreamAck{}
+	if err := json.Unmarshal(ackBytes, ack); err != nil {
+		return errors.Wrap(err, "failed to parse acknowledgement")
+	}
+	if ack.Error != nil {
+		return errors.Errorf("message refused with code %d: %s", ack.Error.Code, ack.Error.Description)
+	}
+
+	return nil
+}
+
+// awaitMessage returns the payload of the next message delivered to the
+// inbox, answering the server's pings in the meantime.
+func (p *NATSPublisher) awaitMessage() ([]byte, error) {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case line == "PING":
+			if _, err := io.WriteString(p.conn, "PONG\r\n"); err != nil {
+				return nil, errors.Wrap(err, "failed to answer ping")
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return nil, errors.Errorf("server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(line)
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return nil, errors.Errorf("malformed message header '%s'", line)
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(p.reader, payload); err != nil {
+				return nil, errors.Wrap(err, "failed to read message")
+			}
+			return payload[:size], nil
+		}
+	}
+}
+
+func (p *NATSPublisher) connect() error {
+	u, err := url.Parse(p.url)
+	if err != nil {
+		return errors.Wrapf(err, "invalid NATS URL %s", p.url)
+	}
+
+	conn, err := net.DialTimeout("tcp", u.Host, p.timeout)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to %s", u.Host)
+	}
+	p.conn, p.reader = conn, bufio.NewReader(conn)
+
+	if err := p.conn.SetDeadline(time.Now().Add(p.timeout)); err != nil {
+		return errors.Wrap(err, "failed to set deadline")
+	}
+
+	info, err := p.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(info, "INFO") {
+		return errors.Errorf("unexpected server greeting '%s'", info)
+	}
+
+	inboxID := make([]byte, 8)
+	if _, err := rand.Read(inboxID); err != nil {
+		return errors.Wrap(err, "failed to create inbox")
+	}
+	p.inbox = "_INBOX.blocc." + hex.EncodeToString(inboxID)
+
+	connect := map[string]interface{}{"verbose": false, "pedantic": false, "name": "blocc-peer", "lang": "go"}
+	if u.User != nil {
+		connect["user"] = u.User.Username()
+		connect["pass"], _ = u.User.Password()
+	}
+	connectBytes, err := json.Marshal(connect)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal connect options")
+	}
+
+	if _, err := fmt.Fprintf(p.conn, "CONNECT %s\r\nSUB %s 1\r\nPING\r\n", connectBytes, p.inbox); err != nil {
+		return errors.Wrap(err, "failed to send connect")
+	}
+
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.Errorf("connection refused: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (p *NATSPublisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read from server")
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *NATSPublisher) disconnect() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn, p.reader = nil, nil
+	}
+}
+
+func (p *NATSPublisher) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.disconnect()
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package streaming
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNATSServer accepts a single connection and acknowledges published
+// messages with the given JetStream acknowledgements, in order.
+type fakeNATSServer struct {
+	listener  net.Listener
+	acks      []string
+	published chan string
+}
+
+func newFakeNATSServer(t *testing.T, acks ...string) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeNATSServer{listener: listener, acks: acks, published: make(chan string, len(acks))}
+	go s.serve()
+	return s
+}
+
+func (s *fakeNATSServer) url() string {
+	return "nats://" + s.listener.Addr().String()
+}
+
+func (s *fakeNATSServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\"}\r\n")
+
+	var inbox string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "SUB":
+			inbox = fields[1]
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "PUB":
+			size, _ := strconv.Atoi(fields[3])
+			payload := make([]byte, size+2)
+			io.ReadFull(reader, payload)
+			s.published <- fields[1] + " " + string(payload[:size])
+
+			ack := s.acks[0]
+			s.acks = s.acks[1:]
+			fmt.Fprintf(conn, "PING\r\nMSG %s 1 %d\r\n%s\r\n", inbox, len(ack), ack)
+		}
+	}
+}
+
+func TestNATSPublisher(t *testing.T) {
+	server := newFakeNATSServer(t,
+		`{"stream":"BLOCC","seq":1}`,
+		`{"error":{"code":503,"description":"stream unavailable"}}`,
+	)
+	defer server.listener.Close()
+
+	publisher := NewNATSPublisher(server.url(), time.Second)
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish("blocc.events", []byte("mychannel"), []byte(`{"type":"SensorSilent"}`)))
+	require.Equal(t, `blocc.events {"type":"SensorSilent"}`, <-server.published)
+
+	err := publisher.Publish("blocc.events", []byte("mychannel"), []byte(`{}`))
+	require.EqualError(t, err, "failed to publish to NATS subject blocc.events: message refused with code 503: stream unavailable")
+	require.Nil(t, publisher.conn)
+}
+
+func TestNATSPublisherUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	publisher := NewNATSPublisher("nats://"+address, time.Second)
+	err = publisher.Publish("blocc.events", nil, []byte(`{}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to connect to "+address)
+}
@@ -0,0 +1,45 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package streaming publishes BLOCC events onto the streaming platforms of
+// sites with existing streaming infrastructure.
+package streaming
+
+import (
+	"github.com/hyperledger/fabric/internal/pkg/blocc/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Kafka publishes events to Kafka topics
+	Kafka = "kafka"
+	// NATS publishes events to NATS JetStream subjects
+	NATS = "nats"
+)
+
+// Publisher publishes messages onto a streaming platform. Publish returns
+// once the platform acknowledged the message, so that callers retrying
+// failed publications achieve at-least-once delivery.
+type Publisher interface {
+	// Publish publishes the payload to the topic. Messages sharing a key
+	// are delivered in order.
+	Publish(topic string, key, payload []byte) error
+	Close() error
+}
+
+// New returns the publisher configured in options, or nil if streaming is
+// disabled.
+func New(options config.Options) (Publisher, error) {
+	switch options.StreamingPublisher {
+	case "":
+		return nil, nil
+	case Kafka:
+		return NewKafkaPublisher(options.KafkaBrokers)
+	case NATS:
+		return NewNATSPublisher(options.NATSURL, options.StreamingTimeout), nil
+	default:
+		return nil, errors.Errorf("unknown streaming publisher '%s'", options.StreamingPublisher)
+	}
+}
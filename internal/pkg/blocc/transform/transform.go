@@ -0,0 +1,218 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package transform evaluates the reading transformations operators define
+// per sensor type, e.g. unit conversions, field renamings and derived
+// metrics. A transformation maps every output field of a reading to an
+// arithmetic expression over the fields of the raw reading, written in the
+// Go expression syntax, e.g. "temperature * 9 / 5 + 32".
+//
+// Expressions are made of numeric literals, field names, parentheses, the
+// unary and binary + - * / operators and the functions abs, min, max, pow,
+// round and sqrt.
+package transform
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+type function struct {
+	arity int
+	call  func(args []float64) float64
+}
+
+var functions = map[string]function{
+	"abs":   {arity: 1, call: func(args []float64) float64 { return math.Abs(args[0]) }},
+	"min":   {arity: 2, call: func(args []float64) float64 { return math.Min(args[0], args[1]) }},
+	"max":   {arity: 2, call: func(args []float64) float64 { return math.Max(args[0], args[1]) }},
+	"pow":   {arity: 2, call: func(args []float64) float64 { return math.Pow(args[0], args[1]) }},
+	"round": {arity: 1, call: func(args []float64) float64 { return math.Round(args[0]) }},
+	"sqrt":  {arity: 1, call: func(args []float64) float64 { return math.Sqrt(args[0]) }},
+}
+
+// Expression is a compiled transformation expression.
+type Expression struct {
+	source string
+	expr   ast.Expr
+}
+
+// Compile parses the expression and checks that it only uses the supported
+// constructs.
+func Compile(source string) (*Expression, error) {
+	expr, err := parser.ParseExpr(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse expression '%s'", source)
+	}
+	if err := check(expr); err != nil {
+		return nil, errors.WithMessagef(err, "invalid expression '%s'", source)
+	}
+	return &Expression{source: source, expr: expr}, nil
+}
+
+func check(expr ast.Expr) error {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT && e.Kind != token.FLOAT {
+			return errors.Errorf("unsupported literal %s", e.Value)
+		}
+	case *ast.Ident:
+	case *ast.ParenExpr:
+		return check(e.X)
+	case *ast.UnaryExpr:
+		if e.Op != token.ADD && e.Op != token.SUB {
+			return errors.Errorf("unsupported operator %s", e.Op)
+		}
+		return check(e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.ADD, token.SUB, token.MUL, token.QUO:
+		default:
+			return errors.Errorf("unsupported operator %s", e.Op)
+		}
+		if err := check(e.X); err != nil {
+			return err
+		}
+		return check(e.Y)
+	case *ast.CallExpr:
+		name, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			return errors.New("unsupported function call")
+		}
+		f, ok := functions[name.Name]
+		if !ok {
+			return errors.Errorf("unknown function %s", name.Name)
+		}
+		if len(e.Args) != f.arity || e.Ellipsis.IsValid() {
+			return errors.Errorf("function %s takes %d arguments", name.Name, f.arity)
+		}
+		for _, arg := range e.Args {
+			if err := check(arg); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("unsupported construct at offset %d", expr.Pos()-1)
+	}
+	return nil
+}
+
+// Eval evaluates the expression against the fields of a reading.
+func (e *Expression) Eval(fields map[string]float64) (float64, error) {
+	value, err := eval(e.expr, fields)
+	if err != nil {
+		return 0, errors.WithMessagef(err, "failed to evaluate '%s'", e.source)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, errors.Errorf("'%s' evaluates to %v", e.source, value)
+	}
+	return value, nil
+}
+
+func eval(expr ast.Expr, fields map[string]float64) (float64, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return strconv.ParseFloat(e.Value, 64)
+	case *ast.Ident:
+		value, ok := fields[e.Name]
+		if !ok {
+			return 0, errors.Errorf("reading has no field %s", e.Name)
+		}
+		return value, nil
+	case *ast.ParenExpr:
+		return eval(e.X, fields)
+	case *ast.UnaryExpr:
+		x, err := eval(e.X, fields)
+		if e.Op == token.SUB {
+			x = -x
+		}
+		return x, err
+	case *ast.BinaryExpr:
+		x, err := eval(e.X, fields)
+		if err != nil {
+			return 0, err
+		}
+		y, err := eval(e.Y, fields)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		default:
+			if y == 0 {
+				return 0, errors.New("division by zero")
+			}
+			return x / y, nil
+		}
+	case *ast.CallExpr:
+		args := make([]float64, len(e.Args))
+		for i, arg := range e.Args {
+			value, err := eval(arg, fields)
+			if err != nil {
+				return 0, err
+			}
+			args[i] = value
+		}
+		return functions[e.Fun.(*ast.Ident).Name].call(args), nil
+	}
+	// unreachable for checked expressions
+	return 0, errors.Errorf("unsupported construct at offset %d", expr.Pos()-1)
+}
+
+// Pipeline transforms readings into the output fields of a transformation.
+type Pipeline struct {
+	fields      []string
+	expressions map[string]*Expression
+}
+
+// NewPipeline compiles the expressions of the output fields. A field of the
+// raw reading is only kept if it is an output field, e.g. "humidity":
+// "humidity", so that fields are renamed by mapping them to a new name.
+func NewPipeline(fields map[string]string) (*Pipeline, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("no output fields specified")
+	}
+
+	p := &Pipeline{expressions: map[string]*Expression{}}
+	for field, source := range fields {
+		if !token.IsIdentifier(field) {
+			return nil, errors.Errorf("invalid output field name '%s'", field)
+		}
+		expression, err := Compile(source)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "output field %s", field)
+		}
+		p.fields = append(p.fields, field)
+		p.expressions[field] = expression
+	}
+	sort.Strings(p.fields)
+
+	return p, nil
+}
+
+// Apply returns the output fields of the reading. Every expression is
+// evaluated against the raw reading, independently of the other outputs.
+func (p *Pipeline) Apply(reading map[string]float64) (map[string]float64, error) {
+	output := make(map[string]float64, len(p.fields))
+	for _, field := range p.fields {
+		value, err := p.expressions[field].Eval(reading)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "output field %s", field)
+		}
+		output[field] = value
+	}
+	return output, nil
+}
@@ -0,0 +1,94 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	for _, source := range []string{
+		"temperature",
+		"-temperature * 9 / 5 + 32",
+		"round(max(0, min(humidity, 1)) * 100)",
+		"sqrt(pow(x, 2) + pow(y, 2))",
+		"abs(1.5e1 - (2 + temperature))",
+	} {
+		_, err := Compile(source)
+		require.NoError(t, err, source)
+	}
+
+	for source, expectedErr := range map[string]string{
+		"temperature +":       "failed to parse expression 'temperature +': 1:14: expected operand, found 'EOF'",
+		`"hot"`:               `invalid expression '"hot"': unsupported literal "hot"`,
+		"temperature % 2":     "invalid expression 'temperature % 2': unsupported operator %",
+		"!alarm":              "invalid expression '!alarm': unsupported operator !",
+		"exp(temperature)":    "invalid expression 'exp(temperature)': unknown function exp",
+		"min(temperature)":    "invalid expression 'min(temperature)': function min takes 2 arguments",
+		"math.Abs(x)":         "invalid expression 'math.Abs(x)': unsupported function call",
+		"fields[temperature]": "invalid expression 'fields[temperature]': unsupported construct at offset 0",
+	} {
+		_, err := Compile(source)
+		require.EqualError(t, err, expectedErr)
+	}
+}
+
+func TestEval(t *testing.T) {
+	reading := map[string]float64{"temperature": 21.5, "humidity": 0.4, "zero": 0}
+
+	for source, expected := range map[string]float64{
+		"temperature * 9 / 5 + 32":      70.7,
+		"-temperature":                  -21.5,
+		"round(humidity * 100)":         40,
+		"max(temperature, 30) - 1":      29,
+		"(temperature + 0.5) / 2":       11,
+		"sqrt(pow(3, 2) + pow(4, 2))":   5,
+		"abs(humidity - temperature)":   21.1,
+		"min(humidity, 1) * 100 + zero": 40,
+	} {
+		expression, err := Compile(source)
+		require.NoError(t, err)
+		value, err := expression.Eval(reading)
+		require.NoError(t, err, source)
+		require.InDelta(t, expected, value, 1e-9, source)
+	}
+
+	for source, expectedErr := range map[string]string{
+		"pressure * 2":       "failed to evaluate 'pressure * 2': reading has no field pressure",
+		"temperature/zero":   "failed to evaluate 'temperature/zero': division by zero",
+		"sqrt(-temperature)": "'sqrt(-temperature)' evaluates to NaN",
+	} {
+		expression, err := Compile(source)
+		require.NoError(t, err)
+		_, err = expression.Eval(reading)
+		require.EqualError(t, err, expectedErr)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	pipeline, err := NewPipeline(map[string]string{
+		"temperatureF": "temperature * 9 / 5 + 32",
+		"rh":           "humidity * 100",
+		"temperature":  "temperature",
+	})
+	require.NoError(t, err)
+
+	output, err := pipeline.Apply(map[string]float64{"temperature": 20, "humidity": 0.4})
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"temperatureF": 68, "rh": 40, "temperature": 20}, output)
+
+	_, err = pipeline.Apply(map[string]float64{"temperature": 20})
+	require.EqualError(t, err, "output field rh: failed to evaluate 'humidity * 100': reading has no field humidity")
+
+	_, err = NewPipeline(nil)
+	require.EqualError(t, err, "no output fields specified")
+	_, err = NewPipeline(map[string]string{"temperature F": "temperature"})
+	require.EqualError(t, err, "invalid output field name 'temperature F'")
+	_, err = NewPipeline(map[string]string{"t": "temperature +"})
+	require.EqualError(t, err, "output field t: failed to parse expression 'temperature +': 1:14: expected operand, found 'EOF'")
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"net"
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -91,6 +92,9 @@ type ClientConfig struct {
 	MaxRecvMsgSize int
 	// Maximum message size the client can send
 	MaxSendMsgSize int
+	// Dialer, if not nil, establishes the connections in place of the
+	// default dialer, e.g. through a proxy
+	Dialer func(ctx context.Context, address string) (net.Conn, error)
 }
 
 // Convert the ClientConfig to the approriate set of grpc.DialOptions.
@@ -123,6 +127,10 @@ func (cc ClientConfig) DialOptions() ([]grpc.DialOption, error) {
 		grpc.MaxCallSendMsgSize(maxSendMsgSize),
 	))
 
+	if cc.Dialer != nil {
+		dialOpts = append(dialOpts, grpc.WithContextDialer(cc.Dialer))
+	}
+
 	tlsConfig, err := cc.SecOpts.TLSConfig()
 	if err != nil {
 		return nil, err
@@ -7,14 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package comm
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"io/ioutil"
+	"net"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/keepalive"
@@ -257,6 +260,24 @@ func TestClientConfigDialOptions_GoodConfig(t *testing.T) {
 	require.NotEmpty(t, opts)
 }
 
+func TestClientConfigDialer(t *testing.T) {
+	dialed := make(chan string, 1)
+	config := ClientConfig{
+		DialTimeout: time.Second,
+		Dialer: func(ctx context.Context, address string) (net.Conn, error) {
+			select {
+			case dialed <- address:
+			default:
+			}
+			return nil, errors.New("proxy unreachable")
+		},
+	}
+
+	_, err := config.Dial("orderer.example.com:7050")
+	require.Error(t, err)
+	require.Equal(t, "orderer.example.com:7050", <-dialed)
+}
+
 func TestClientConfigDialOptions_BadConfig(t *testing.T) {
 	testCerts := LoadTestCerts(t)
 
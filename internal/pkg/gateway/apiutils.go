@@ -14,6 +14,7 @@ import (
 	gp "github.com/hyperledger/fabric-protos-go/gateway"
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/chaincode"
+	"github.com/hyperledger/fabric/internal/pkg/blocc/ingestion"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -46,6 +47,9 @@ func responseStatus(response *peer.ProposalResponse, err error) (statusCode code
 			}
 			// some other error - retry on another peer
 			return codes.Aborted, response.Response.Message, true, false
+		} else if response.Payload == nil && response.Response.Status == ingestion.StatusTooManyRequests {
+			// BLOCC: the peer shed the reading as it is overloaded - retry on another peer
+			return codes.ResourceExhausted, response.Response.Message, true, false
 		} else {
 			// otherwise it must be an error response generated by the chaincode
 			return codes.Unknown, fmt.Sprintf("chaincode response %d, %s", response.Response.Status, response.Response.Message), false, false
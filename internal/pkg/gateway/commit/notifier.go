@@ -46,6 +46,13 @@ func (n *Notifier) notifyStatus(done <-chan struct{}, channelName string, transa
 	return notifyChannel, nil
 }
 
+// NotifyStatus notifies the caller when the named transaction commits on the named channel, once the state updates of
+// its block are committed. The caller is only notified of commits occurring after registering for notifications, and
+// should close done when no longer interested.
+func (n *Notifier) NotifyStatus(done <-chan struct{}, channelName string, transactionID string) (<-chan *Status, error) {
+	return n.notifyStatus(done, channelName, transactionID)
+}
+
 // close the notifier. This closes all notification channels obtained from this notifier. Behavior is undefined after
 // closing and the notifier should not be used.
 func (n *Notifier) close() {
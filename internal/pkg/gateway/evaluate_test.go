@@ -195,6 +195,27 @@ func TestEvaluate(t *testing.T) {
 			},
 			expectedEndorsers: []string{"peer4:11051"},
 		},
+		{
+			name: "reading shed by overloaded peers - retry on next peer",
+			members: []networkMember{
+				{"id1", "localhost:7051", "msp1", 4},
+				{"id2", "peer1:8051", "msp1", 4},
+				{"id3", "peer2:9051", "msp2", 3},
+				{"id4", "peer3:10051", "msp2", 4},
+				{"id5", "peer4:11051", "msp3", 5},
+			},
+			localLedgerHeight: 4,
+			plan: endorsementPlan{
+				"g1": {{endorser: localhostMock, height: 4}, {endorser: peer1Mock, height: 4}}, // msp1
+				"g2": {{endorser: peer2Mock, height: 3}, {endorser: peer3Mock, height: 4}},     // msp2
+				"g3": {{endorser: peer4Mock, height: 5}},                                       // msp3
+			},
+			postSetup: func(t *testing.T, def *preparedTest) {
+				def.localEndorser.ProcessProposalReturns(createErrorResponse(t, 429, "bulk reading shed", []byte(`{"retryAfterMillis":1000}`)), nil)
+				peer1Mock.client.(*mocks.EndorserClient).ProcessProposalReturns(createErrorResponse(t, 429, "bulk reading shed", []byte(`{"retryAfterMillis":1000}`)), nil)
+			},
+			expectedEndorsers: []string{"peer4:11051"},
+		},
 		{
 			name: "restrict to local org peers - which all fail",
 			members: []networkMember{
@@ -49,7 +49,8 @@ type ACLChecker interface {
 	CheckACL(policyName string, channelName string, data interface{}) error
 }
 
-// CreateServer creates an embedded instance of the Gateway.
+// CreateServer creates an embedded instance of the Gateway, notified of the
+// commits by notifier.
 func CreateServer(
 	localEndorser peerproto.EndorserServer,
 	discovery Discovery,
@@ -59,11 +60,11 @@ func CreateServer(
 	localMSPID string,
 	options config.Options,
 	systemChaincodes scc.BuiltinSCCs,
+	notifier *commit.Notifier,
 ) *Server {
 	adapter := &ledger.PeerAdapter{
 		Peer: peerInstance,
 	}
-	notifier := commit.NewNotifier(adapter)
 
 	server := newServer(
 		&EndorserServerAdapter{
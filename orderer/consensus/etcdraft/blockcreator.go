@@ -47,6 +47,9 @@ func (bc *blockCreator) createNextBlock(envs []*cb.Envelope) *cb.Block {
 			if string(funcName) == "SimulateForkAttempt" {
 				shouldFork = true
 			}
+			if traceID := specs.ChaincodeSpec.GetInput().GetDecorations()[protoutil.TraceIDDecoration]; len(traceID) > 0 {
+				bc.logger.Infof("BLOCC: Ordering transaction %d of block %d, trace %s", i, bc.number+1, traceID)
+			}
 		}
 	}
 
@@ -0,0 +1,193 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// BlockMetadataIndexApprovalAnchor is the index of the block metadata entry
+// holding the approval anchor, following the entries defined by Fabric. The
+// anchor is tagged with approvalAnchorTag so that an entry that a later
+// version of Fabric defined at that index is never read as an anchor, nor
+// overwritten by one.
+const BlockMetadataIndexApprovalAnchor = common.BlockMetadataIndex_COMMIT_HASH + 1
+
+// approvalAnchorTag prefixes the approval anchor in the block metadata.
+var approvalAnchorTag = []byte("blocc.approvalAnchor.v1:")
+
+// ApprovalAnchor commits to the BSCC approval records of a channel. Root is
+// the Merkle root of the approval records written by the block, and Anchor
+// chains Root to the anchor of the previous block.
+//
+// Like the commit hash, the anchor is computed by every peer at commit and
+// is neither covered by the block hash nor signed: it is attested only by
+// the peer serving the block. Comparing the anchors of the peers of several
+// organizations detects a peer diverging from the others.
+type ApprovalAnchor struct {
+	Root   []byte
+	Anchor []byte
+}
+
+// ChainApprovalAnchor returns the anchor of a block given the anchor of the
+// previous block and the approval root of the block.
+func ChainApprovalAnchor(previous, root []byte) []byte {
+	h := sha256.New()
+	h.Write(previous)
+	h.Write(root)
+	return h.Sum(nil)
+}
+
+// SetApprovalAnchor stores the approval anchor in the block metadata. The
+// tag, the root and the anchor are stored back to back in the metadata value.
+// An error is returned if the metadata entry is already taken by another
+// entry than an approval anchor.
+func SetApprovalAnchor(block *common.Block, anchor *ApprovalAnchor) error {
+	for len(block.Metadata.Metadata) <= int(BlockMetadataIndexApprovalAnchor) {
+		block.Metadata.Metadata = append(block.Metadata.Metadata, nil)
+	}
+	if existing := block.Metadata.Metadata[BlockMetadataIndexApprovalAnchor]; len(existing) > 0 {
+		md := &common.Metadata{}
+		if err := proto.Unmarshal(existing, md); err != nil || (len(md.Value) > 0 && !bytes.HasPrefix(md.Value, approvalAnchorTag)) {
+			return errors.Errorf("block metadata entry %d is taken by another entry than an approval anchor", BlockMetadataIndexApprovalAnchor)
+		}
+	}
+
+	value := append(append(append([]byte{}, approvalAnchorTag...), anchor.Root...), anchor.Anchor...)
+	block.Metadata.Metadata[BlockMetadataIndexApprovalAnchor] = MarshalOrPanic(&common.Metadata{Value: value})
+	return nil
+}
+
+// GetApprovalAnchor retrieves the approval anchor from the block metadata,
+// or nil if the block carries none. Anchors written before they were tagged
+// are read as well.
+func GetApprovalAnchor(block *common.Block) (*ApprovalAnchor, error) {
+	if len(block.GetMetadata().GetMetadata()) <= int(BlockMetadataIndexApprovalAnchor) {
+		return nil, nil
+	}
+
+	md := &common.Metadata{}
+	if err := proto.Unmarshal(block.Metadata.Metadata[BlockMetadataIndexApprovalAnchor], md); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling approval anchor")
+	}
+	value := md.Value
+	switch {
+	case len(value) == 0:
+		return nil, nil
+	case bytes.HasPrefix(value, approvalAnchorTag):
+		value = value[len(approvalAnchorTag):]
+	case len(value) != 2*sha256.Size:
+		// another entry than an approval anchor
+		return nil, nil
+	}
+	if len(value) != 2*sha256.Size {
+		return nil, errors.Errorf("invalid approval anchor length %d", len(value))
+	}
+
+	return &ApprovalAnchor{Root: value[:sha256.Size], Anchor: value[sha256.Size:]}, nil
+}
+
+// ReadingProof proves that a reading transaction is committed to a verifier
+// trusting the header of a later block. Headers chain the block of
+// the reading up to that block, and Data, whose hash is the data hash of the
+// first header, holds the transaction at TxIndex. The validation flags are
+// not covered by the block hash: they are only attested by the endorsement
+// signature of the peer serving the proof.
+type ReadingProof struct {
+	TxID               string                `json:"txID"`
+	TxIndex            int                   `json:"txIndex"`
+	Headers            []*common.BlockHeader `json:"headers"`
+	Data               *common.BlockData     `json:"data"`
+	TransactionsFilter []byte                `json:"transactionsFilter"`
+}
+
+// NewReadingProof returns the proof of the transaction txID of block, where
+// headers chain block up to a later block.
+func NewReadingProof(block *common.Block, txID string, headers []*common.BlockHeader) (*ReadingProof, error) {
+	if len(headers) == 0 || headers[0].Number != block.Header.Number {
+		return nil, errors.Errorf("header chain must start at block %d", block.Header.Number)
+	}
+
+	for i, envBytes := range block.GetData().GetData() {
+		id, err := GetOrComputeTxIDFromEnvelope(envBytes)
+		if err != nil || id != txID {
+			continue
+		}
+
+		var filter []byte
+		if len(block.GetMetadata().GetMetadata()) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+			filter = block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+		}
+
+		return &ReadingProof{
+			TxID:               txID,
+			TxIndex:            i,
+			Headers:            headers,
+			Data:               block.Data,
+			TransactionsFilter: filter,
+		}, nil
+	}
+
+	return nil, errors.Errorf("transaction %s not found in block %d", txID, block.Header.Number)
+}
+
+// VerifyReadingProof checks that the proof chains the reading transaction up
+// to the trusted header, and that the validation code of the transaction in
+// TransactionsFilter is VALID. TransactionsFilter is not covered by the block
+// hash: the validity of the transaction is only attested by the endorsement
+// signature of the peer on the response carrying the proof, which the
+// verifier must check and trust.
+func VerifyReadingProof(proof *ReadingProof, trusted *common.BlockHeader) error {
+	if len(proof.Headers) == 0 {
+		return errors.New("proof holds no block headers")
+	}
+	if trusted == nil {
+		return errors.New("trusted header not specified")
+	}
+
+	for i := 1; i < len(proof.Headers); i++ {
+		previous, header := proof.Headers[i-1], proof.Headers[i]
+		if header.Number != previous.Number+1 || !bytes.Equal(header.PreviousHash, BlockHeaderHash(previous)) {
+			return errors.Errorf("block %d does not chain to block %d", header.Number, previous.Number)
+		}
+	}
+
+	last := proof.Headers[len(proof.Headers)-1]
+	if !bytes.Equal(BlockHeaderHash(last), BlockHeaderHash(trusted)) {
+		return errors.Errorf("block %d does not match the trusted header of block %d", last.Number, trusted.Number)
+	}
+
+	first := proof.Headers[0]
+	if !bytes.Equal(first.DataHash, BlockDataHash(proof.Data)) {
+		return errors.Errorf("transactions do not match the data hash of block %d", first.Number)
+	}
+
+	if proof.TxIndex < 0 || proof.TxIndex >= len(proof.Data.GetData()) {
+		return errors.Errorf("transaction index %d out of range", proof.TxIndex)
+	}
+	txID, err := GetOrComputeTxIDFromEnvelope(proof.Data.Data[proof.TxIndex])
+	if err != nil {
+		return errors.WithMessage(err, "failed to get transaction ID")
+	}
+	if txID != proof.TxID {
+		return errors.Errorf("transaction %d of block %d is %s instead of %s", proof.TxIndex, first.Number, txID, proof.TxID)
+	}
+
+	if proof.TxIndex >= len(proof.TransactionsFilter) {
+		return errors.Errorf("no validation code for transaction %s", proof.TxID)
+	}
+	if code := peer.TxValidationCode(proof.TransactionsFilter[proof.TxIndex]); code != peer.TxValidationCode_VALID {
+		return errors.Errorf("transaction %s was invalidated with code %s", proof.TxID, code)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoutil_test
+
+import (
+	"bytes"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalAnchor(t *testing.T) {
+	block := protoutil.NewBlock(1, nil)
+	anchor, err := protoutil.GetApprovalAnchor(block)
+	require.NoError(t, err)
+	require.Nil(t, anchor)
+
+	root := bytes.Repeat([]byte{1}, 32)
+	expected := &protoutil.ApprovalAnchor{Root: root, Anchor: protoutil.ChainApprovalAnchor(nil, root)}
+	require.NoError(t, protoutil.SetApprovalAnchor(block, expected))
+	require.Len(t, block.Metadata.Metadata, int(protoutil.BlockMetadataIndexApprovalAnchor)+1)
+
+	anchor, err = protoutil.GetApprovalAnchor(block)
+	require.NoError(t, err)
+	require.Equal(t, expected, anchor)
+
+	// an anchor replaces an anchor
+	require.NoError(t, protoutil.SetApprovalAnchor(block, expected))
+
+	// untagged anchors are still read
+	legacy := append(append([]byte{}, root...), expected.Anchor...)
+	block.Metadata.Metadata[protoutil.BlockMetadataIndexApprovalAnchor] = protoutil.MarshalOrPanic(&cb.Metadata{Value: legacy})
+	anchor, err = protoutil.GetApprovalAnchor(block)
+	require.NoError(t, err)
+	require.Equal(t, expected, anchor)
+
+	block.Metadata.Metadata[protoutil.BlockMetadataIndexApprovalAnchor] = protoutil.MarshalOrPanic(&cb.Metadata{Value: append([]byte("blocc.approvalAnchor.v1:"), root...)})
+	_, err = protoutil.GetApprovalAnchor(block)
+	require.EqualError(t, err, "invalid approval anchor length 32")
+
+	// other entries at the index of the anchor are neither read as anchors
+	// nor overwritten
+	other := protoutil.MarshalOrPanic(&cb.Metadata{Value: root})
+	block.Metadata.Metadata[protoutil.BlockMetadataIndexApprovalAnchor] = other
+	anchor, err = protoutil.GetApprovalAnchor(block)
+	require.NoError(t, err)
+	require.Nil(t, anchor)
+	require.EqualError(t, protoutil.SetApprovalAnchor(block, expected), "block metadata entry 5 is taken by another entry than an approval anchor")
+	require.Equal(t, other, block.Metadata.Metadata[protoutil.BlockMetadataIndexApprovalAnchor])
+}
+
+func TestChainApprovalAnchor(t *testing.T) {
+	root := bytes.Repeat([]byte{1}, 32)
+	first := protoutil.ChainApprovalAnchor(nil, root)
+	require.Len(t, first, 32)
+	require.NotEqual(t, first, protoutil.ChainApprovalAnchor(first, root))
+}
+
+func readingProofChain(txIDs ...string) (*cb.Block, []*cb.BlockHeader) {
+	block := protoutil.NewBlock(3, []byte("previous"))
+	for _, txID := range txIDs {
+		payload := &cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{TxId: txID}),
+			},
+		}
+		block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(&cb.Envelope{Payload: protoutil.MarshalOrPanic(payload)}))
+	}
+	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+	block.Metadata.Metadata[cb.BlockMetadataIndex_TRANSACTIONS_FILTER] = []byte{byte(pb.TxValidationCode_VALID), byte(pb.TxValidationCode_MVCC_READ_CONFLICT)}
+
+	headers := []*cb.BlockHeader{block.Header}
+	for i := 0; i < 2; i++ {
+		next := protoutil.NewBlock(headers[i].Number+1, protoutil.BlockHeaderHash(headers[i]))
+		headers = append(headers, next.Header)
+	}
+
+	return block, headers
+}
+
+func TestReadingProof(t *testing.T) {
+	block, headers := readingProofChain("tx1", "tx2")
+	trusted := headers[len(headers)-1]
+
+	proof, err := protoutil.NewReadingProof(block, "tx1", headers)
+	require.NoError(t, err)
+	require.Equal(t, 0, proof.TxIndex)
+	require.NoError(t, protoutil.VerifyReadingProof(proof, trusted))
+
+	_, err = protoutil.NewReadingProof(block, "tx3", headers)
+	require.EqualError(t, err, "transaction tx3 not found in block 3")
+
+	_, err = protoutil.NewReadingProof(block, "tx1", headers[1:])
+	require.EqualError(t, err, "header chain must start at block 3")
+
+	proof, err = protoutil.NewReadingProof(block, "tx2", headers)
+	require.NoError(t, err)
+	require.EqualError(t, protoutil.VerifyReadingProof(proof, trusted), "transaction tx2 was invalidated with code MVCC_READ_CONFLICT")
+}
+
+func TestVerifyReadingProofTampering(t *testing.T) {
+	block, headers := readingProofChain("tx1", "tx2")
+	trusted := headers[len(headers)-1]
+
+	newProof := func() *protoutil.ReadingProof {
+		proof, err := protoutil.NewReadingProof(block, "tx1", headers)
+		require.NoError(t, err)
+		proof.Headers = append([]*cb.BlockHeader{}, proof.Headers...)
+		return proof
+	}
+
+	proof := newProof()
+	require.EqualError(t, protoutil.VerifyReadingProof(proof, nil), "trusted header not specified")
+	require.EqualError(t, protoutil.VerifyReadingProof(proof, block.Header), "block 5 does not match the trusted header of block 3")
+
+	proof = newProof()
+	proof.Headers = []*cb.BlockHeader{proof.Headers[0], proof.Headers[2]}
+	require.EqualError(t, protoutil.VerifyReadingProof(proof, trusted), "block 5 does not chain to block 3")
+
+	proof = newProof()
+	proof.Data = &cb.BlockData{Data: [][]byte{proof.Data.Data[0]}}
+	require.EqualError(t, protoutil.VerifyReadingProof(proof, trusted), "transactions do not match the data hash of block 3")
+
+	proof = newProof()
+	proof.TxID = "tx2"
+	require.EqualError(t, protoutil.VerifyReadingProof(proof, trusted), "transaction 0 of block 3 is tx1 instead of tx2")
+
+	proof = newProof()
+	proof.TxIndex = 2
+	require.EqualError(t, protoutil.VerifyReadingProof(proof, trusted), "transaction index 2 out of range")
+
+	proof = newProof()
+	proof.TransactionsFilter = nil
+	require.EqualError(t, protoutil.VerifyReadingProof(proof, trusted), "no validation code for transaction tx1")
+}
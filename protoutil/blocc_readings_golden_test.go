@@ -0,0 +1,107 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoutil_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+// updateGolden rewrites the golden files of the reading samples with the
+// current output, e.g. go test ./protoutil -run TestReadingGoldenFiles -update
+var updateGolden = flag.Bool("update", false, "update the golden files of the reading samples")
+
+// readingSample is a sample sensory transaction payload, read from a
+// testdata/readings/*.json file.
+type readingSample struct {
+	Description string   `json:"description"`
+	Args        []string `json:"args"`
+}
+
+// normalizedReading is the outcome of parsing a sample reading, compared
+// against the sample's .golden file.
+type normalizedReading struct {
+	Temperature      float64 `json:"temperature"`
+	RelativeHumidity float64 `json:"relativeHumidity"`
+	Timestamp        int64   `json:"timestamp"`
+	// CoSignedMessage is hex encoded, its fields being length-prefixed
+	CoSignedMessage string `json:"coSignedMessage"`
+	CoSigner        string `json:"coSigner,omitempty"`
+	CoSignature     string `json:"coSignature,omitempty"`
+	Severity        string `json:"severity,omitempty"`
+}
+
+type readingOutcome struct {
+	Reading *normalizedReading `json:"reading,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+func normalizeReading(envelope *cb.Envelope) *readingOutcome {
+	temperature, humidity, timestamp, err := protoutil.ExtractTemperatureHumidityReadingFromEnvelope(envelope)
+	if err != nil {
+		return &readingOutcome{Error: err.Error()}
+	}
+	message, coSigner, signature, err := protoutil.ExtractCoSignatureFromEnvelope(envelope)
+	if err != nil {
+		return &readingOutcome{Error: err.Error()}
+	}
+	severity, err := protoutil.ExtractSeverityFromEnvelope(envelope)
+	if err != nil {
+		return &readingOutcome{Error: err.Error()}
+	}
+
+	return &readingOutcome{Reading: &normalizedReading{
+		Temperature:      temperature,
+		RelativeHumidity: humidity,
+		Timestamp:        timestamp,
+		CoSignedMessage:  hex.EncodeToString(message),
+		CoSigner:         string(coSigner),
+		CoSignature:      string(signature),
+		Severity:         severity,
+	}}
+}
+
+// TestReadingGoldenFiles parses every sample payload of testdata/readings
+// and compares the outcome with the sample's golden file. A new sensor format
+// is covered by adding a sample and generating its golden file with -update.
+func TestReadingGoldenFiles(t *testing.T) {
+	samples, err := filepath.Glob(filepath.Join("testdata", "readings", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, samples)
+
+	for _, samplePath := range samples {
+		samplePath := samplePath
+		name := strings.TrimSuffix(filepath.Base(samplePath), ".json")
+		t.Run(name, func(t *testing.T) {
+			sampleBytes, err := ioutil.ReadFile(samplePath)
+			require.NoError(t, err)
+			sample := &readingSample{}
+			require.NoError(t, json.Unmarshal(sampleBytes, sample), "malformed sample %s", samplePath)
+
+			outcomeBytes, err := json.MarshalIndent(normalizeReading(readingEnvelope(t, nil, sample.Args...)), "", "  ")
+			require.NoError(t, err)
+			outcomeBytes = append(outcomeBytes, '\n')
+
+			goldenPath := strings.TrimSuffix(samplePath, ".json") + ".golden"
+			if *updateGolden {
+				require.NoError(t, ioutil.WriteFile(goldenPath, outcomeBytes, 0o644))
+			}
+
+			goldenBytes, err := ioutil.ReadFile(goldenPath)
+			require.NoError(t, err, "missing golden file, run the test with -update to generate it")
+			require.Equal(t, string(goldenBytes), string(outcomeBytes), sample.Description)
+		})
+	}
+}
@@ -6,6 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 package protoutil
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
 	"strconv"
 
 	"github.com/golang/protobuf/proto"
@@ -60,6 +67,61 @@ func ExtractChaincodeInvocationSpec(envelopeBytes []byte) (*peer.ChaincodeInvoca
 	return cis, nil
 }
 
+// TraceIDDecoration is the chaincode input decoration carrying the trace ID of
+// the approval request that led to a BSCC transaction. It is set by the
+// client and recorded with the transaction, as endorsers do not pass client
+// decorations on to chaincodes.
+const TraceIDDecoration = "blocc.traceID"
+
+// ExtractTraceID returns the trace ID decorating the chaincode invocation of
+// the given transaction, empty if it has none.
+func ExtractTraceID(envelopeBytes []byte) string {
+	cis, err := ExtractChaincodeInvocationSpec(envelopeBytes)
+	if err != nil {
+		return ""
+	}
+	return string(cis.GetChaincodeSpec().GetInput().GetDecorations()[TraceIDDecoration])
+}
+
+// ConsistencyTokenDecoration is the chaincode input decoration carrying the
+// consistency token of an approval transaction. Endorsers wait for the
+// approval to be committed before simulating a proposal decorated with it,
+// so that queries read the writes of the approval.
+const ConsistencyTokenDecoration = "blocc.consistencyToken"
+
+// ConsistencyToken identifies the approval or rejection record written by a
+// BSCC approval transaction. It is returned by ApproveSensoryReading in the
+// message of the response, encoded with Encode.
+type ConsistencyToken struct {
+	TxID        string `json:"txID"`
+	SensoryTxID string `json:"sensoryTxID"`
+	MSPID       string `json:"mspID"`
+	// Rejection is set if the transaction writes a rejection record
+	Rejection bool `json:"rejection,omitempty"`
+}
+
+// Encode returns the token as an opaque URL-safe string.
+func (t *ConsistencyToken) Encode() string {
+	tokenBytes, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(tokenBytes)
+}
+
+// DecodeConsistencyToken decodes a token encoded with Encode.
+func DecodeConsistencyToken(s string) (*ConsistencyToken, error) {
+	tokenBytes, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid consistency token")
+	}
+	token := &ConsistencyToken{}
+	if err := json.Unmarshal(tokenBytes, token); err != nil {
+		return nil, errors.Wrap(err, "invalid consistency token")
+	}
+	if token.TxID == "" || token.SensoryTxID == "" || token.MSPID == "" {
+		return nil, errors.New("incomplete consistency token")
+	}
+	return token, nil
+}
+
 // ExtractApprovalInfo returns the MSP ID of the peer and the TxID of
 // a sensor reading transaction that it approves with BSCC transaction
 func ExtractApprovalInfo(envelopeBytes []byte) (string, string, error) {
@@ -97,7 +159,7 @@ func IsBscc(envelopeBytes []byte) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	chaincodeName := cis.ChaincodeSpec.ChaincodeId.Name
+	chaincodeName := cis.GetChaincodeSpec().GetChaincodeId().GetName()
 
 	return chaincodeName == "bscc", nil
 }
@@ -131,6 +193,25 @@ func ExtractMspIdFromEnvelope(envelopeBytes []byte) (string, error) {
 	return serializedIdentity.Mspid, nil
 }
 
+// IdemixOrganizationalUnit returns the organizational unit disclosed by the
+// idemix identity idBytes, the IdBytes of a SerializedIdentity. It returns
+// false if idBytes is not an idemix identity disclosing its organizational
+// unit, such as an X.509 certificate.
+func IdemixOrganizationalUnit(idBytes []byte) (string, bool) {
+	if bytes.HasPrefix(bytes.TrimSpace(idBytes), []byte("-----BEGIN")) {
+		return "", false
+	}
+	idemixIdentity := &msp.SerializedIdemixIdentity{}
+	if err := proto.Unmarshal(idBytes, idemixIdentity); err != nil || len(idemixIdentity.Ou) == 0 || len(idemixIdentity.Proof) == 0 {
+		return "", false
+	}
+	ou := &msp.OrganizationUnit{}
+	if err := proto.Unmarshal(idemixIdentity.Ou, ou); err != nil || ou.OrganizationalUnitIdentifier == "" {
+		return "", false
+	}
+	return ou.OrganizationalUnitIdentifier, true
+}
+
 // ExtractTemperatureHumidityReadingFromEnvelope retrieve the temperature, relative humidity, timestamp
 // from a TemperatureHumidityReadingContract transaction
 func ExtractTemperatureHumidityReadingFromEnvelope(envelope *common.Envelope) (float64, float64, int64, error) {
@@ -173,6 +254,10 @@ func ExtractTemperatureHumidityReadingFromEnvelope(envelope *common.Envelope) (f
 		return 0, 0, 0, err
 	}
 
+	if len(ccInvocationSpec.GetChaincodeSpec().GetInput().GetArgs()) < 4 {
+		return 0, 0, 0, errors.New("expected at least 4 reading args")
+	}
+
 	temperatureBytes := ccInvocationSpec.ChaincodeSpec.Input.Args[1]
 	relativeHumidityBytes := ccInvocationSpec.ChaincodeSpec.Input.Args[2]
 	timestampBytes := ccInvocationSpec.ChaincodeSpec.Input.Args[3]
@@ -192,3 +277,281 @@ func ExtractTemperatureHumidityReadingFromEnvelope(envelope *common.Envelope) (f
 
 	return temperature, relativeHumidity, timestamp, nil
 }
+
+// PayloadReferenceField is the member of the JSON object of a composite
+// reading holding the reference to its off-chain payload.
+const PayloadReferenceField = "payloadRef"
+
+// PayloadReference references the off-chain payload of a reading, e.g. a
+// vibration waveform kept in object storage, by its location and hash.
+type PayloadReference struct {
+	URI string `json:"uri"`
+	// SHA256 is the hex encoded SHA-256 hash of the payload
+	SHA256 string `json:"sha256"`
+	// Size is the size of the payload in bytes, zero if not given
+	Size      int64  `json:"size,omitempty"`
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+// ExtractMetricsReadingFromEnvelope retrieves the metrics, by name, and the
+// timestamp of a TemperatureHumidityReadingContract transaction. A composite
+// reading carries a JSON object of its metrics in place of the temperature
+// arg and leaves the relative humidity arg empty, so that the co-signature
+// and severity args keep their positions. The metrics of a temperature and
+// humidity reading are temperature and relativeHumidity. A composite reading
+// referencing an off-chain payload may have no metrics.
+func ExtractMetricsReadingFromEnvelope(envelope *common.Envelope) (map[string]float64, int64, error) {
+	cis, err := extractChaincodeInvocationSpecFromEnvelope(envelope)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	args := cis.GetChaincodeSpec().GetInput().GetArgs()
+	if len(args) < 4 {
+		return nil, 0, errors.New("expected at least 4 reading args")
+	}
+
+	timestamp, err := strconv.ParseInt(string(args[3]), 10, 64)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(args[1]), []byte("{")) {
+		temperature, err := strconv.ParseFloat(string(args[1]), 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		relativeHumidity, err := strconv.ParseFloat(string(args[2]), 64)
+		if err != nil {
+			return nil, 0, err
+		}
+		return map[string]float64{"temperature": temperature, "relativeHumidity": relativeHumidity}, timestamp, nil
+	}
+
+	fields, err := compositeFields(args)
+	if err != nil {
+		return nil, 0, err
+	}
+	_, referenced := fields[PayloadReferenceField]
+	delete(fields, PayloadReferenceField)
+	if len(fields) == 0 && !referenced {
+		return nil, 0, errors.New("composite reading has no metrics")
+	}
+	metrics := map[string]float64{}
+	for name, raw := range fields {
+		if name == "" {
+			return nil, 0, errors.New("composite reading has a metric without name")
+		}
+		var value float64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, 0, errors.Wrap(err, "failed to unmarshal composite reading metrics")
+		}
+		if math.IsInf(value, 0) || math.IsNaN(value) {
+			return nil, 0, errors.Errorf("invalid value of metric %s", name)
+		}
+		metrics[name] = value
+	}
+
+	return metrics, timestamp, nil
+}
+
+// ExtractPayloadReferenceFromEnvelope retrieves the reference to the
+// off-chain payload of a TemperatureHumidityReadingContract transaction,
+// carried in the PayloadReferenceField member of a composite reading. A nil
+// reference is returned if the reading has none.
+func ExtractPayloadReferenceFromEnvelope(envelope *common.Envelope) (*PayloadReference, error) {
+	cis, err := extractChaincodeInvocationSpecFromEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	args := cis.GetChaincodeSpec().GetInput().GetArgs()
+	if len(args) < 4 {
+		return nil, errors.New("expected at least 4 reading args")
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(args[1]), []byte("{")) {
+		return nil, nil
+	}
+
+	fields, err := compositeFields(args)
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := fields[PayloadReferenceField]
+	if !ok {
+		return nil, nil
+	}
+
+	ref := &PayloadReference{}
+	if err := json.Unmarshal(raw, ref); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal payload reference")
+	}
+	if ref.URI == "" {
+		return nil, errors.New("payload reference has no URI")
+	}
+	if hash, err := hex.DecodeString(ref.SHA256); err != nil || len(hash) != sha256.Size {
+		return nil, errors.Errorf("invalid SHA-256 hash '%s' of payload %s", ref.SHA256, ref.URI)
+	}
+	if ref.Size < 0 {
+		return nil, errors.Errorf("invalid size %d of payload %s", ref.Size, ref.URI)
+	}
+	return ref, nil
+}
+
+// compositeFields returns the members of the JSON object of a composite
+// reading.
+func compositeFields(args [][]byte) (map[string]json.RawMessage, error) {
+	if len(args[2]) > 0 {
+		return nil, errors.New("unexpected relative humidity arg in composite reading")
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(args[1], &fields); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal composite reading metrics")
+	}
+	return fields, nil
+}
+
+// ExtractCreatorFromEnvelope returns the serialized identity of the creator
+// of the given transaction envelope
+func ExtractCreatorFromEnvelope(envelope *common.Envelope) ([]byte, error) {
+	if envelope == nil {
+		return nil, errors.New("envelope should not be nil")
+	}
+
+	payload, err := UnmarshalPayload(envelope.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Header == nil {
+		return nil, errors.New("payload header should not be nil")
+	}
+
+	sigHeader, err := UnmarshalSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return sigHeader.Creator, nil
+}
+
+// ExtractEndorsersFromEnvelope retrieves the serialized identities of the
+// peers that endorsed the transaction.
+func ExtractEndorsersFromEnvelope(envelope *common.Envelope) ([][]byte, error) {
+	if envelope == nil {
+		return nil, errors.New("envelope should not be nil")
+	}
+
+	payload, err := UnmarshalPayload(envelope.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := UnmarshalTransaction(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.Actions) == 0 {
+		return nil, errors.New("at least one TransactionAction required")
+	}
+
+	actionPayload, err := UnmarshalChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var endorsers [][]byte
+	for _, endorsement := range actionPayload.GetAction().GetEndorsements() {
+		endorsers = append(endorsers, endorsement.Endorser)
+	}
+	return endorsers, nil
+}
+
+// ExtractCoSignatureFromEnvelope retrieves the co-signature of a paired sensor
+// from a TemperatureHumidityReadingContract transaction. The co-signer's
+// serialized identity and signature are carried in the invocation args that
+// follow the reading. The co-signed message is CoSignedMessage of the channel
+// and the ID of the transaction, and of the reading args, so that a
+// co-signature can neither be replayed in another transaction nor match other
+// reading args. A nil co-signer is returned if the reading is not co-signed,
+// i.e. if the co-signer arg is absent or empty.
+func ExtractCoSignatureFromEnvelope(envelope *common.Envelope) (message, coSigner, signature []byte, err error) {
+	cis, err := extractChaincodeInvocationSpecFromEnvelope(envelope)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	args := cis.ChaincodeSpec.Input.Args
+	if len(args) < 4 {
+		return nil, nil, nil, errors.Errorf("expected at least 4 reading args, got %d", len(args))
+	}
+
+	payload, err := UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	chdr, err := UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	message = CoSignedMessage(chdr.ChannelId, chdr.TxId, args[1:4]...)
+
+	if len(args) < 6 || len(args[4]) == 0 {
+		return message, nil, nil, nil
+	}
+
+	return message, args[4], args[5], nil
+}
+
+// CoSignedMessage returns the message co-signed by the paired sensor of a
+// TemperatureHumidityReadingContract transaction of the channel channelID
+// with the ID txID and the reading args: each of channelID, txID and args
+// prefixed with its length as a 4 byte big-endian integer.
+func CoSignedMessage(channelID, txID string, args ...[]byte) []byte {
+	fields := append([][]byte{[]byte(channelID), []byte(txID)}, args...)
+	var message []byte
+	for _, field := range fields {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(field)))
+		message = append(message, length[:]...)
+		message = append(message, field...)
+	}
+	return message
+}
+
+// ExtractSeverityFromEnvelope retrieves the severity of a
+// TemperatureHumidityReadingContract transaction, carried in the invocation
+// arg that follows the co-signature args, which are left empty if the reading
+// is not co-signed. An empty severity is returned if the reading has none.
+func ExtractSeverityFromEnvelope(envelope *common.Envelope) (string, error) {
+	cis, err := extractChaincodeInvocationSpecFromEnvelope(envelope)
+	if err != nil {
+		return "", err
+	}
+
+	return ExtractSeverityFromInput(cis.ChaincodeSpec.Input), nil
+}
+
+// ExtractSeverityFromInput retrieves the severity of the input of a
+// TemperatureHumidityReadingContract invocation, empty if it has none.
+func ExtractSeverityFromInput(input *peer.ChaincodeInput) string {
+	args := input.GetArgs()
+	if len(args) < 7 {
+		return ""
+	}
+
+	return string(args[6])
+}
+
+func extractChaincodeInvocationSpecFromEnvelope(envelope *common.Envelope) (*peer.ChaincodeInvocationSpec, error) {
+	if envelope == nil {
+		return nil, errors.New("envelope should not be nil")
+	}
+
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExtractChaincodeInvocationSpec(envelopeBytes)
+}
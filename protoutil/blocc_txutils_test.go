@@ -0,0 +1,229 @@
+/*
+BLOCC Project
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoutil_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func readingEnvelope(t *testing.T, creator []byte, args ...string) *cb.Envelope {
+	input := &pb.ChaincodeInput{}
+	for _, arg := range args {
+		input.Args = append(input.Args, []byte(arg))
+	}
+	return invocationEnvelope(t, creator, input)
+}
+
+func invocationEnvelope(t *testing.T, creator []byte, input *pb.ChaincodeInput) *cb.Envelope {
+	cisBytes, err := proto.Marshal(&pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "sensor_chaincode"},
+			Input:       input,
+		},
+	})
+	require.NoError(t, err)
+
+	cppBytes, err := proto.Marshal(&pb.ChaincodeProposalPayload{Input: cisBytes})
+	require.NoError(t, err)
+
+	capBytes, err := proto.Marshal(&pb.ChaincodeActionPayload{ChaincodeProposalPayload: cppBytes})
+	require.NoError(t, err)
+
+	txBytes, err := proto.Marshal(&pb.Transaction{Actions: []*pb.TransactionAction{{Payload: capBytes}}})
+	require.NoError(t, err)
+
+	shdrBytes, err := proto.Marshal(&cb.SignatureHeader{Creator: creator})
+	require.NoError(t, err)
+
+	payloadBytes, err := proto.Marshal(&cb.Payload{
+		Header: &cb.Header{SignatureHeader: shdrBytes},
+		Data:   txBytes,
+	})
+	require.NoError(t, err)
+
+	return &cb.Envelope{Payload: payloadBytes}
+}
+
+func TestExtractCreatorFromEnvelope(t *testing.T) {
+	creator, err := protoutil.ExtractCreatorFromEnvelope(readingEnvelope(t, []byte("creator"), "Set", "21.5", "0.4", "1628887200"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("creator"), creator)
+
+	_, err = protoutil.ExtractCreatorFromEnvelope(nil)
+	require.EqualError(t, err, "envelope should not be nil")
+
+	_, err = protoutil.ExtractCreatorFromEnvelope(&cb.Envelope{Payload: []byte("garbage")})
+	require.Error(t, err)
+}
+
+func TestExtractEndorsersFromEnvelope(t *testing.T) {
+	capBytes, err := proto.Marshal(&pb.ChaincodeActionPayload{
+		Action: &pb.ChaincodeEndorsedAction{
+			Endorsements: []*pb.Endorsement{
+				{Endorser: []byte("peer0.org1"), Signature: []byte("sig1")},
+				{Endorser: []byte("peer0.org2"), Signature: []byte("sig2")},
+			},
+		},
+	})
+	require.NoError(t, err)
+	txBytes, err := proto.Marshal(&pb.Transaction{Actions: []*pb.TransactionAction{{Payload: capBytes}}})
+	require.NoError(t, err)
+	payloadBytes, err := proto.Marshal(&cb.Payload{Header: &cb.Header{}, Data: txBytes})
+	require.NoError(t, err)
+
+	endorsers, err := protoutil.ExtractEndorsersFromEnvelope(&cb.Envelope{Payload: payloadBytes})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("peer0.org1"), []byte("peer0.org2")}, endorsers)
+
+	// a reading without endorsements has no endorsers
+	endorsers, err = protoutil.ExtractEndorsersFromEnvelope(readingEnvelope(t, []byte("creator"), "Set", "21.5", "0.4", "1628887200"))
+	require.NoError(t, err)
+	require.Empty(t, endorsers)
+
+	_, err = protoutil.ExtractEndorsersFromEnvelope(nil)
+	require.EqualError(t, err, "envelope should not be nil")
+
+	payloadBytes, err = proto.Marshal(&cb.Payload{Header: &cb.Header{}, Data: protoutil.MarshalOrPanic(&pb.Transaction{})})
+	require.NoError(t, err)
+	_, err = protoutil.ExtractEndorsersFromEnvelope(&cb.Envelope{Payload: payloadBytes})
+	require.EqualError(t, err, "at least one TransactionAction required")
+}
+
+func TestExtractCoSignatureFromEnvelope(t *testing.T) {
+	message, coSigner, signature, err := protoutil.ExtractCoSignatureFromEnvelope(readingEnvelope(t, nil, "Set", "21.5", "0.4", "1628887200"))
+	require.NoError(t, err)
+	require.Equal(t, protoutil.CoSignedMessage("", "", []byte("21.5"), []byte("0.4"), []byte("1628887200")), message)
+	require.Nil(t, coSigner)
+	require.Nil(t, signature)
+
+	// the message binds the reading args to the channel and the transaction
+	envelope := readingEnvelope(t, nil, "Set", "21.5", "0.4", "1628887200", "cosigner", "signature")
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	require.NoError(t, err)
+	payload.Header.ChannelHeader = protoutil.MarshalOrPanic(&cb.ChannelHeader{ChannelId: "ch", TxId: "tx1"})
+	envelope.Payload = protoutil.MarshalOrPanic(payload)
+	message, coSigner, signature, err = protoutil.ExtractCoSignatureFromEnvelope(envelope)
+	require.NoError(t, err)
+	require.Equal(t, []byte("\x00\x00\x00\x02ch\x00\x00\x00\x03tx1\x00\x00\x00\x0421.5\x00\x00\x00\x030.4\x00\x00\x00\x0a1628887200"), message)
+	require.Equal(t, []byte("cosigner"), coSigner)
+	require.Equal(t, []byte("signature"), signature)
+
+	// the args can not be shifted into one another
+	require.NotEqual(t, protoutil.CoSignedMessage("ch", "tx1", []byte("21.5"), []byte("0.4"), []byte("1")), protoutil.CoSignedMessage("ch", "tx1", []byte("21.50"), []byte(".4"), []byte("1")))
+
+	_, coSigner, _, err = protoutil.ExtractCoSignatureFromEnvelope(readingEnvelope(t, nil, "Set", "21.5", "0.4", "1628887200", "", "", "alarm"))
+	require.NoError(t, err)
+	require.Nil(t, coSigner)
+
+	_, _, _, err = protoutil.ExtractCoSignatureFromEnvelope(readingEnvelope(t, nil, "Set", "21.5"))
+	require.EqualError(t, err, "expected at least 4 reading args, got 2")
+}
+
+func TestExtractTraceID(t *testing.T) {
+	env := invocationEnvelope(t, nil, &pb.ChaincodeInput{
+		Args:        [][]byte{[]byte("ApproveSensoryReading")},
+		Decorations: map[string][]byte{protoutil.TraceIDDecoration: []byte("trace1")},
+	})
+	require.Equal(t, "trace1", protoutil.ExtractTraceID(protoutil.MarshalOrPanic(env)))
+
+	env = readingEnvelope(t, nil, "ApproveSensoryReading")
+	require.Empty(t, protoutil.ExtractTraceID(protoutil.MarshalOrPanic(env)))
+	require.Empty(t, protoutil.ExtractTraceID([]byte("garbage")))
+}
+
+func TestConsistencyToken(t *testing.T) {
+	token := &protoutil.ConsistencyToken{TxID: "approval1", SensoryTxID: "reading1", MSPID: "Org1MSP", Rejection: true}
+	decoded, err := protoutil.DecodeConsistencyToken(token.Encode())
+	require.NoError(t, err)
+	require.Equal(t, token, decoded)
+
+	_, err = protoutil.DecodeConsistencyToken("not base64!")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid consistency token")
+	_, err = protoutil.DecodeConsistencyToken((&protoutil.ConsistencyToken{TxID: "approval1"}).Encode())
+	require.EqualError(t, err, "incomplete consistency token")
+}
+
+func TestExtractSeverityFromEnvelope(t *testing.T) {
+	severity, err := protoutil.ExtractSeverityFromEnvelope(readingEnvelope(t, nil, "Set", "21.5", "0.4", "1628887200"))
+	require.NoError(t, err)
+	require.Empty(t, severity)
+
+	severity, err = protoutil.ExtractSeverityFromEnvelope(readingEnvelope(t, nil, "Set", "21.5", "0.4", "1628887200", "", "", "alarm"))
+	require.NoError(t, err)
+	require.Equal(t, "alarm", severity)
+
+	_, err = protoutil.ExtractSeverityFromEnvelope(nil)
+	require.EqualError(t, err, "envelope should not be nil")
+
+	require.Empty(t, protoutil.ExtractSeverityFromInput(nil))
+}
+
+func TestExtractTemperatureHumidityReadingFromEnvelope(t *testing.T) {
+	temperature, humidity, timestamp, err := protoutil.ExtractTemperatureHumidityReadingFromEnvelope(readingEnvelope(t, nil, "Set", "21.5", "0.4", "1628887200"))
+	require.NoError(t, err)
+	require.Equal(t, 21.5, temperature)
+	require.Equal(t, 0.4, humidity)
+	require.Equal(t, int64(1628887200), timestamp)
+
+	_, _, _, err = protoutil.ExtractTemperatureHumidityReadingFromEnvelope(readingEnvelope(t, nil, "Set", "21.5"))
+	require.EqualError(t, err, "expected at least 4 reading args")
+}
+
+func TestExtractMetricsReadingFromEnvelope(t *testing.T) {
+	metrics, timestamp, err := protoutil.ExtractMetricsReadingFromEnvelope(readingEnvelope(t, nil, "Set", "21.5", "0.4", "1628887200"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"temperature": 21.5, "relativeHumidity": 0.4}, metrics)
+	require.Equal(t, int64(1628887200), timestamp)
+
+	metrics, timestamp, err = protoutil.ExtractMetricsReadingFromEnvelope(readingEnvelope(t, nil, "Set", `{"temperature":21.5,"relativeHumidity":0.4,"co2":415}`, "", "1628887200", "", "", "alarm"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"temperature": 21.5, "relativeHumidity": 0.4, "co2": 415}, metrics)
+	require.Equal(t, int64(1628887200), timestamp)
+
+	_, _, err = protoutil.ExtractMetricsReadingFromEnvelope(readingEnvelope(t, nil, "Set", `{"co2":415}`, "0.4", "1628887200"))
+	require.EqualError(t, err, "unexpected relative humidity arg in composite reading")
+	_, _, err = protoutil.ExtractMetricsReadingFromEnvelope(readingEnvelope(t, nil, "Set", `{}`, "", "1628887200"))
+	require.EqualError(t, err, "composite reading has no metrics")
+	_, _, err = protoutil.ExtractMetricsReadingFromEnvelope(readingEnvelope(t, nil, "Set", `{"":415}`, "", "1628887200"))
+	require.EqualError(t, err, "composite reading has a metric without name")
+	_, _, err = protoutil.ExtractMetricsReadingFromEnvelope(readingEnvelope(t, nil, "Set", "21.5"))
+	require.EqualError(t, err, "expected at least 4 reading args")
+
+	// a reading referencing an off-chain payload may carry no metrics
+	metrics, _, err = protoutil.ExtractMetricsReadingFromEnvelope(readingEnvelope(t, nil, "Set", `{"payloadRef":{"uri":"https://store/w1"}}`, "", "1628887200"))
+	require.NoError(t, err)
+	require.Empty(t, metrics)
+}
+
+func TestExtractPayloadReferenceFromEnvelope(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	ref, err := protoutil.ExtractPayloadReferenceFromEnvelope(readingEnvelope(t, nil, "Set",
+		`{"peakVelocity":2.5,"payloadRef":{"uri":"https://store/w1","sha256":"`+hash+`","size":4096,"mediaType":"application/octet-stream"}}`, "", "1628887200"))
+	require.NoError(t, err)
+	require.Equal(t, &protoutil.PayloadReference{URI: "https://store/w1", SHA256: hash, Size: 4096, MediaType: "application/octet-stream"}, ref)
+
+	ref, err = protoutil.ExtractPayloadReferenceFromEnvelope(readingEnvelope(t, nil, "Set", "21.5", "0.4", "1628887200"))
+	require.NoError(t, err)
+	require.Nil(t, ref)
+	ref, err = protoutil.ExtractPayloadReferenceFromEnvelope(readingEnvelope(t, nil, "Set", `{"co2":415}`, "", "1628887200"))
+	require.NoError(t, err)
+	require.Nil(t, ref)
+
+	_, err = protoutil.ExtractPayloadReferenceFromEnvelope(readingEnvelope(t, nil, "Set", `{"payloadRef":{"sha256":"`+hash+`"}}`, "", "1628887200"))
+	require.EqualError(t, err, "payload reference has no URI")
+	_, err = protoutil.ExtractPayloadReferenceFromEnvelope(readingEnvelope(t, nil, "Set", `{"payloadRef":{"uri":"https://store/w1","sha256":"abc"}}`, "", "1628887200"))
+	require.EqualError(t, err, "invalid SHA-256 hash 'abc' of payload https://store/w1")
+	_, err = protoutil.ExtractPayloadReferenceFromEnvelope(readingEnvelope(t, nil, "Set", `{"payloadRef":"https://store/w1"}`, "", "1628887200"))
+	require.Error(t, err)
+}
@@ -7,10 +7,13 @@
 
 set -eux -o pipefail
 
-# Find all proto dirs to be processed
+# Find all proto dirs to be processed. The BLOCC protos are bound to Go in
+# the fabric-protos-go fork rather than here.
 PROTO_DIRS="$(find "$(pwd)" \
     -path "$(pwd)/vendor" -prune -o \
     -path "$(pwd)/build" -prune -o \
+    -path "$(pwd)/protos/blocc" -prune -o \
+    -path "$(pwd)/third_party" -prune -o \
     -name '*.proto' -print0 | \
     xargs -0 -n 1 dirname | \
     sort -u | grep -v testdata)"
@@ -18,3 +21,10 @@ PROTO_DIRS="$(find "$(pwd)" \
 for dir in ${PROTO_DIRS}; do
     protoc --proto_path="$dir" --go_out=plugins=grpc,paths=source_relative:"$dir" "$dir"/*.proto
 done
+
+# The gossip package of the fabric-protos-go fork adds the BLOCC approval
+# messages to the content of the GossipMessage. Run go mod vendor afterwards.
+FORK_DIR="$(pwd)/third_party/fabric-protos-go"
+protoc --proto_path="$FORK_DIR/protos" --proto_path="$(pwd)/protos/blocc" \
+    --go_out=plugins=grpc,paths=source_relative:"$FORK_DIR" \
+    gossip/message.proto gossip/blocc.proto
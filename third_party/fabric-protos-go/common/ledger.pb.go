@@ -0,0 +1,155 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: common/ledger.proto
+
+package common
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// Contains information about the blockchain ledger such as height, current
+// block hash, and previous block hash.
+type BlockchainInfo struct {
+	Height            uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	CurrentBlockHash  []byte `protobuf:"bytes,2,opt,name=currentBlockHash,proto3" json:"currentBlockHash,omitempty"`
+	PreviousBlockHash []byte `protobuf:"bytes,3,opt,name=previousBlockHash,proto3" json:"previousBlockHash,omitempty"`
+	// Specifies bootstrapping snapshot info if the channel is bootstrapped from a snapshot.
+	// It is nil if the channel is not bootstrapped from a snapshot.
+	BootstrappingSnapshotInfo *BootstrappingSnapshotInfo `protobuf:"bytes,4,opt,name=bootstrappingSnapshotInfo,proto3" json:"bootstrappingSnapshotInfo,omitempty"`
+	XXX_NoUnkeyedLiteral      struct{}                   `json:"-"`
+	XXX_unrecognized          []byte                     `json:"-"`
+	XXX_sizecache             int32                      `json:"-"`
+}
+
+func (m *BlockchainInfo) Reset()         { *m = BlockchainInfo{} }
+func (m *BlockchainInfo) String() string { return proto.CompactTextString(m) }
+func (*BlockchainInfo) ProtoMessage()    {}
+func (*BlockchainInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da3410306adbea27, []int{0}
+}
+
+func (m *BlockchainInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockchainInfo.Unmarshal(m, b)
+}
+func (m *BlockchainInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockchainInfo.Marshal(b, m, deterministic)
+}
+func (m *BlockchainInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockchainInfo.Merge(m, src)
+}
+func (m *BlockchainInfo) XXX_Size() int {
+	return xxx_messageInfo_BlockchainInfo.Size(m)
+}
+func (m *BlockchainInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockchainInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockchainInfo proto.InternalMessageInfo
+
+func (m *BlockchainInfo) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockchainInfo) GetCurrentBlockHash() []byte {
+	if m != nil {
+		return m.CurrentBlockHash
+	}
+	return nil
+}
+
+func (m *BlockchainInfo) GetPreviousBlockHash() []byte {
+	if m != nil {
+		return m.PreviousBlockHash
+	}
+	return nil
+}
+
+func (m *BlockchainInfo) GetBootstrappingSnapshotInfo() *BootstrappingSnapshotInfo {
+	if m != nil {
+		return m.BootstrappingSnapshotInfo
+	}
+	return nil
+}
+
+// Contains information for the bootstrapping snapshot.
+type BootstrappingSnapshotInfo struct {
+	LastBlockInSnapshot  uint64   `protobuf:"varint,1,opt,name=lastBlockInSnapshot,proto3" json:"lastBlockInSnapshot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BootstrappingSnapshotInfo) Reset()         { *m = BootstrappingSnapshotInfo{} }
+func (m *BootstrappingSnapshotInfo) String() string { return proto.CompactTextString(m) }
+func (*BootstrappingSnapshotInfo) ProtoMessage()    {}
+func (*BootstrappingSnapshotInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_da3410306adbea27, []int{1}
+}
+
+func (m *BootstrappingSnapshotInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BootstrappingSnapshotInfo.Unmarshal(m, b)
+}
+func (m *BootstrappingSnapshotInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BootstrappingSnapshotInfo.Marshal(b, m, deterministic)
+}
+func (m *BootstrappingSnapshotInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BootstrappingSnapshotInfo.Merge(m, src)
+}
+func (m *BootstrappingSnapshotInfo) XXX_Size() int {
+	return xxx_messageInfo_BootstrappingSnapshotInfo.Size(m)
+}
+func (m *BootstrappingSnapshotInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_BootstrappingSnapshotInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BootstrappingSnapshotInfo proto.InternalMessageInfo
+
+func (m *BootstrappingSnapshotInfo) GetLastBlockInSnapshot() uint64 {
+	if m != nil {
+		return m.LastBlockInSnapshot
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*BlockchainInfo)(nil), "common.BlockchainInfo")
+	proto.RegisterType((*BootstrappingSnapshotInfo)(nil), "common.BootstrappingSnapshotInfo")
+}
+
+func init() { proto.RegisterFile("common/ledger.proto", fileDescriptor_da3410306adbea27) }
+
+var fileDescriptor_da3410306adbea27 = []byte{
+	// 252 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x90, 0xc1, 0x4a, 0xc4, 0x30,
+	0x10, 0x86, 0x89, 0x2e, 0x3d, 0x44, 0x11, 0xcd, 0x82, 0x74, 0x6f, 0x75, 0xf1, 0x50, 0xc4, 0x4d,
+	0x45, 0xdf, 0xa0, 0x27, 0xf7, 0xe0, 0xa5, 0x82, 0x07, 0x2f, 0x92, 0xc6, 0x6c, 0x12, 0xec, 0x66,
+	0xc2, 0x24, 0x15, 0x7c, 0x5e, 0x5f, 0x44, 0xb6, 0xa9, 0x28, 0xec, 0xf6, 0x38, 0xff, 0xff, 0x4d,
+	0xf8, 0x32, 0x74, 0x2e, 0x61, 0xbb, 0x05, 0x57, 0x75, 0xea, 0x5d, 0x2b, 0xe4, 0x1e, 0x21, 0x02,
+	0xcb, 0x52, 0xb8, 0xfc, 0x26, 0xf4, 0xac, 0xee, 0x40, 0x7e, 0x48, 0x23, 0xac, 0x5b, 0xbb, 0x0d,
+	0xb0, 0x4b, 0x9a, 0x19, 0x65, 0xb5, 0x89, 0x39, 0x29, 0x48, 0x39, 0x6b, 0xc6, 0x89, 0xdd, 0xd0,
+	0x73, 0xd9, 0x23, 0x2a, 0x17, 0x87, 0x85, 0x47, 0x11, 0x4c, 0x7e, 0x54, 0x90, 0xf2, 0xb4, 0xd9,
+	0xcb, 0xd9, 0x2d, 0xbd, 0xf0, 0xa8, 0x3e, 0x2d, 0xf4, 0xe1, 0x0f, 0x3e, 0x1e, 0xe0, 0xfd, 0x82,
+	0xbd, 0xd1, 0x45, 0x0b, 0x10, 0x43, 0x44, 0xe1, 0xbd, 0x75, 0xfa, 0xd9, 0x09, 0x1f, 0x0c, 0xc4,
+	0x9d, 0x4e, 0x3e, 0x2b, 0x48, 0x79, 0x72, 0x7f, 0xc5, 0x93, 0x30, 0xaf, 0xa7, 0xc0, 0x66, 0xfa,
+	0x8d, 0xe5, 0x13, 0x5d, 0x4c, 0xee, 0xb1, 0x3b, 0x3a, 0xef, 0x44, 0x48, 0xf2, 0x6b, 0xf7, 0x5b,
+	0x8d, 0x9f, 0x3f, 0x54, 0xd5, 0x2f, 0xf4, 0x1a, 0x50, 0x73, 0xf3, 0xe5, 0x15, 0x8e, 0x57, 0xdd,
+	0x88, 0x16, 0xad, 0x4c, 0xc7, 0x0d, 0xa3, 0xeb, 0x2b, 0xd7, 0x36, 0x9a, 0xbe, 0xdd, 0x8d, 0xd5,
+	0x3f, 0xb8, 0x4a, 0xf0, 0x2a, 0xc1, 0x2b, 0x0d, 0x55, 0xe2, 0xdb, 0x6c, 0x48, 0x1e, 0x7e, 0x02,
+	0x00, 0x00, 0xff, 0xff, 0x34, 0xc4, 0xfd, 0x0b, 0xb2, 0x01, 0x00, 0x00,
+}
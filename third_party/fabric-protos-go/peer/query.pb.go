@@ -0,0 +1,333 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/query.proto
+
+package peer
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+// ChaincodeQueryResponse returns information about each chaincode that pertains
+// to a query in lscc.go, such as GetChaincodes (returns all chaincodes
+// instantiated on a channel), and GetInstalledChaincodes (returns all chaincodes
+// installed on a peer)
+type ChaincodeQueryResponse struct {
+	Chaincodes           []*ChaincodeInfo `protobuf:"bytes,1,rep,name=chaincodes,proto3" json:"chaincodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ChaincodeQueryResponse) Reset()         { *m = ChaincodeQueryResponse{} }
+func (m *ChaincodeQueryResponse) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeQueryResponse) ProtoMessage()    {}
+func (*ChaincodeQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d45bcf7fe2423301, []int{0}
+}
+
+func (m *ChaincodeQueryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeQueryResponse.Unmarshal(m, b)
+}
+func (m *ChaincodeQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeQueryResponse.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeQueryResponse.Merge(m, src)
+}
+func (m *ChaincodeQueryResponse) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeQueryResponse.Size(m)
+}
+func (m *ChaincodeQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeQueryResponse proto.InternalMessageInfo
+
+func (m *ChaincodeQueryResponse) GetChaincodes() []*ChaincodeInfo {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+// ChaincodeInfo contains general information about an installed/instantiated
+// chaincode
+type ChaincodeInfo struct {
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// the path as specified by the install/instantiate transaction
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// the chaincode function upon instantiation and its arguments. This will be
+	// blank if the query is returning information about installed chaincodes.
+	Input string `protobuf:"bytes,4,opt,name=input,proto3" json:"input,omitempty"`
+	// the name of the ESCC for this chaincode. This will be
+	// blank if the query is returning information about installed chaincodes.
+	Escc string `protobuf:"bytes,5,opt,name=escc,proto3" json:"escc,omitempty"`
+	// the name of the VSCC for this chaincode. This will be
+	// blank if the query is returning information about installed chaincodes.
+	Vscc string `protobuf:"bytes,6,opt,name=vscc,proto3" json:"vscc,omitempty"`
+	// the chaincode unique id.
+	// computed as: H(
+	//                H(name || version) ||
+	//                H(CodePackage)
+	//              )
+	Id                   []byte   `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeInfo) Reset()         { *m = ChaincodeInfo{} }
+func (m *ChaincodeInfo) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeInfo) ProtoMessage()    {}
+func (*ChaincodeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d45bcf7fe2423301, []int{1}
+}
+
+func (m *ChaincodeInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeInfo.Unmarshal(m, b)
+}
+func (m *ChaincodeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeInfo.Marshal(b, m, deterministic)
+}
+func (m *ChaincodeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeInfo.Merge(m, src)
+}
+func (m *ChaincodeInfo) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeInfo.Size(m)
+}
+func (m *ChaincodeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeInfo proto.InternalMessageInfo
+
+func (m *ChaincodeInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ChaincodeInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ChaincodeInfo) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ChaincodeInfo) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+func (m *ChaincodeInfo) GetEscc() string {
+	if m != nil {
+		return m.Escc
+	}
+	return ""
+}
+
+func (m *ChaincodeInfo) GetVscc() string {
+	if m != nil {
+		return m.Vscc
+	}
+	return ""
+}
+
+func (m *ChaincodeInfo) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+// ChannelQueryResponse returns information about each channel that pertains
+// to a query in lscc.go, such as GetChannels (returns all channels for a
+// given peer)
+type ChannelQueryResponse struct {
+	Channels             []*ChannelInfo `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *ChannelQueryResponse) Reset()         { *m = ChannelQueryResponse{} }
+func (m *ChannelQueryResponse) String() string { return proto.CompactTextString(m) }
+func (*ChannelQueryResponse) ProtoMessage()    {}
+func (*ChannelQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d45bcf7fe2423301, []int{2}
+}
+
+func (m *ChannelQueryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelQueryResponse.Unmarshal(m, b)
+}
+func (m *ChannelQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelQueryResponse.Marshal(b, m, deterministic)
+}
+func (m *ChannelQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelQueryResponse.Merge(m, src)
+}
+func (m *ChannelQueryResponse) XXX_Size() int {
+	return xxx_messageInfo_ChannelQueryResponse.Size(m)
+}
+func (m *ChannelQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelQueryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelQueryResponse proto.InternalMessageInfo
+
+func (m *ChannelQueryResponse) GetChannels() []*ChannelInfo {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+// ChannelInfo contains general information about channels
+type ChannelInfo struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelInfo) Reset()         { *m = ChannelInfo{} }
+func (m *ChannelInfo) String() string { return proto.CompactTextString(m) }
+func (*ChannelInfo) ProtoMessage()    {}
+func (*ChannelInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d45bcf7fe2423301, []int{3}
+}
+
+func (m *ChannelInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelInfo.Unmarshal(m, b)
+}
+func (m *ChannelInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelInfo.Marshal(b, m, deterministic)
+}
+func (m *ChannelInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelInfo.Merge(m, src)
+}
+func (m *ChannelInfo) XXX_Size() int {
+	return xxx_messageInfo_ChannelInfo.Size(m)
+}
+func (m *ChannelInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelInfo proto.InternalMessageInfo
+
+func (m *ChannelInfo) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// JoinBySnapshotStatus contains information about whether or a JoinBySnapshot operation
+// is in progress and the related bootstrap dir if it is running.
+type JoinBySnapshotStatus struct {
+	InProgress               bool     `protobuf:"varint,1,opt,name=in_progress,json=inProgress,proto3" json:"in_progress,omitempty"`
+	BootstrappingSnapshotDir string   `protobuf:"bytes,2,opt,name=bootstrapping_snapshot_dir,json=bootstrappingSnapshotDir,proto3" json:"bootstrapping_snapshot_dir,omitempty"`
+	XXX_NoUnkeyedLiteral     struct{} `json:"-"`
+	XXX_unrecognized         []byte   `json:"-"`
+	XXX_sizecache            int32    `json:"-"`
+}
+
+func (m *JoinBySnapshotStatus) Reset()         { *m = JoinBySnapshotStatus{} }
+func (m *JoinBySnapshotStatus) String() string { return proto.CompactTextString(m) }
+func (*JoinBySnapshotStatus) ProtoMessage()    {}
+func (*JoinBySnapshotStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d45bcf7fe2423301, []int{4}
+}
+
+func (m *JoinBySnapshotStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinBySnapshotStatus.Unmarshal(m, b)
+}
+func (m *JoinBySnapshotStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinBySnapshotStatus.Marshal(b, m, deterministic)
+}
+func (m *JoinBySnapshotStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinBySnapshotStatus.Merge(m, src)
+}
+func (m *JoinBySnapshotStatus) XXX_Size() int {
+	return xxx_messageInfo_JoinBySnapshotStatus.Size(m)
+}
+func (m *JoinBySnapshotStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinBySnapshotStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinBySnapshotStatus proto.InternalMessageInfo
+
+func (m *JoinBySnapshotStatus) GetInProgress() bool {
+	if m != nil {
+		return m.InProgress
+	}
+	return false
+}
+
+func (m *JoinBySnapshotStatus) GetBootstrappingSnapshotDir() string {
+	if m != nil {
+		return m.BootstrappingSnapshotDir
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ChaincodeQueryResponse)(nil), "protos.ChaincodeQueryResponse")
+	proto.RegisterType((*ChaincodeInfo)(nil), "protos.ChaincodeInfo")
+	proto.RegisterType((*ChannelQueryResponse)(nil), "protos.ChannelQueryResponse")
+	proto.RegisterType((*ChannelInfo)(nil), "protos.ChannelInfo")
+	proto.RegisterType((*JoinBySnapshotStatus)(nil), "protos.JoinBySnapshotStatus")
+}
+
+func init() { proto.RegisterFile("peer/query.proto", fileDescriptor_d45bcf7fe2423301) }
+
+var fileDescriptor_d45bcf7fe2423301 = []byte{
+	// 366 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x92, 0xcf, 0x6a, 0xe3, 0x30,
+	0x10, 0x87, 0x71, 0xfe, 0x67, 0xb2, 0xbb, 0x2c, 0x6a, 0x5a, 0x44, 0xa1, 0x34, 0xf8, 0x94, 0x43,
+	0x62, 0x43, 0x4b, 0x6f, 0x3d, 0x25, 0x85, 0x92, 0x5e, 0xda, 0x3a, 0xb7, 0x5e, 0x8c, 0x6c, 0x2b,
+	0xb6, 0x20, 0x91, 0x54, 0x49, 0x0e, 0xe4, 0x69, 0xfa, 0xaa, 0x45, 0x96, 0x1d, 0x9c, 0x93, 0x67,
+	0xbe, 0xf9, 0xc6, 0xe2, 0x27, 0x04, 0xff, 0x25, 0xa5, 0x2a, 0xfc, 0x2e, 0xa9, 0x3a, 0x05, 0x52,
+	0x09, 0x23, 0xd0, 0xa0, 0xfa, 0x68, 0xff, 0x1d, 0x6e, 0xd6, 0x05, 0x61, 0x3c, 0x15, 0x19, 0xfd,
+	0xb4, 0xf3, 0x88, 0x6a, 0x29, 0xb8, 0xa6, 0xe8, 0x09, 0x20, 0x6d, 0x26, 0x1a, 0x7b, 0xb3, 0xee,
+	0x7c, 0xf2, 0x70, 0xed, 0xb6, 0x75, 0x70, 0xde, 0xd9, 0xf0, 0x9d, 0x88, 0x5a, 0xa2, 0xff, 0xe3,
+	0xc1, 0xdf, 0x8b, 0x29, 0x42, 0xd0, 0xe3, 0xe4, 0x40, 0xb1, 0x37, 0xf3, 0xe6, 0xe3, 0xa8, 0xaa,
+	0x11, 0x86, 0xe1, 0x91, 0x2a, 0xcd, 0x04, 0xc7, 0x9d, 0x0a, 0x37, 0xad, 0xb5, 0x25, 0x31, 0x05,
+	0xee, 0x3a, 0xdb, 0xd6, 0x68, 0x0a, 0x7d, 0xc6, 0x65, 0x69, 0x70, 0xaf, 0x82, 0xae, 0xb1, 0x26,
+	0xd5, 0x69, 0x8a, 0xfb, 0xce, 0xb4, 0xb5, 0x65, 0x47, 0xcb, 0x06, 0x8e, 0xd9, 0x1a, 0xfd, 0x83,
+	0x0e, 0xcb, 0xf0, 0x70, 0xe6, 0xcd, 0xff, 0x44, 0x1d, 0x96, 0xf9, 0xaf, 0x30, 0x5d, 0x17, 0x84,
+	0x73, 0xba, 0xbf, 0x0c, 0x1c, 0xc2, 0x28, 0x75, 0xbc, 0x89, 0x7b, 0xd5, 0x8a, 0x6b, 0x79, 0x15,
+	0xf6, 0x2c, 0xf9, 0x0b, 0x98, 0xb4, 0x06, 0xe8, 0xae, 0xba, 0x30, 0xdb, 0xc6, 0x2c, 0xab, 0xd3,
+	0x8e, 0x6b, 0xb2, 0xc9, 0xfc, 0x12, 0xa6, 0x6f, 0x82, 0xf1, 0xd5, 0x69, 0xcb, 0x89, 0xd4, 0x85,
+	0x30, 0x5b, 0x43, 0x4c, 0xa9, 0xd1, 0x3d, 0x4c, 0x18, 0x8f, 0xa5, 0x12, 0xb9, 0xa2, 0x5a, 0x57,
+	0x7b, 0xa3, 0x08, 0x18, 0xff, 0xa8, 0x09, 0x7a, 0x86, 0xdb, 0x44, 0x08, 0xa3, 0x8d, 0x22, 0x52,
+	0x32, 0x9e, 0xc7, 0xba, 0xfe, 0x41, 0x9c, 0x31, 0x55, 0x5f, 0x1f, 0xbe, 0x30, 0x9a, 0x13, 0x5e,
+	0x98, 0x5a, 0x45, 0xe0, 0x0b, 0x95, 0x07, 0xc5, 0x49, 0x52, 0xb5, 0xa7, 0x59, 0x4e, 0x55, 0xb0,
+	0x23, 0x89, 0x62, 0x69, 0x93, 0xcd, 0x3e, 0x8d, 0xaf, 0x45, 0xce, 0x4c, 0x51, 0x26, 0x41, 0x2a,
+	0x0e, 0x61, 0x4b, 0x0d, 0x9d, 0xba, 0x74, 0xea, 0x32, 0x17, 0xa1, 0xb5, 0x13, 0xf7, 0x78, 0x1e,
+	0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x2c, 0x43, 0x94, 0x8d, 0x57, 0x02, 0x00, 0x00,
+}